@@ -1,11 +1,17 @@
 package main
 
 import (
+	"context"
 	"log"
+	"os"
+	"time"
 
 	"ratemysoft-backend/internal/auth"
 	"ratemysoft-backend/internal/platform/config"
 	"ratemysoft-backend/internal/platform/db"
+	"ratemysoft-backend/internal/platform/mail"
+	"ratemysoft-backend/internal/repository/postgres"
+	"ratemysoft-backend/internal/services"
 	"ratemysoft-backend/internal/transport/http"
 	"ratemysoft-backend/internal/transport/http/handlers"
 	"ratemysoft-backend/internal/transport/http/middleware"
@@ -23,12 +29,58 @@ func main() {
 	}
 	defer pool.Close()
 
-	// Initialize JWT service
-	jwtService := auth.NewJWTService(cfg.JWTSecret, cfg.JWTExpiryHours)
+	// `ratemysoft migrate up|down|status` manages the schema and exits
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := runMigrate(pool, os.Args[2:]); err != nil {
+			pool.Close()
+			log.Fatalf("Migration failed: %v", err)
+		}
+		return
+	}
+
+	if cfg.MigrateOnStart {
+		if err := runMigrate(pool, []string{"up"}); err != nil {
+			pool.Close()
+			log.Fatalf("Failed to apply migrations: %v", err)
+		}
+	}
+
+	store := postgres.NewStore(pool, queries)
+
+	// Initialize JWT service with server-side revocation backed by the sessions table
+	jwtService := auth.NewJWTService(cfg.JWTSecret, time.Duration(cfg.JWTAccessExpiryMinutes)*time.Minute)
+	sessionService := services.NewSessionService(store, time.Duration(cfg.RefreshTokenExpiryHours)*time.Hour)
+	jwtService.SetRevocationChecker(sessionService)
+
+	// Periodically drop expired refresh tokens, deny-list entries, idle sessions and old failed sign-ins
+	go pruneSessions(sessionService, time.Hour)
+
+	// Keep the precomputed leaderboard scores fresh
+	leaderboardService := services.NewLeaderboardService(store, float64(cfg.LeaderboardPriorWeight))
+	go refreshLeaderboard(leaderboardService, time.Duration(cfg.LeaderboardRefreshMinutes)*time.Minute)
+
+	// Reviewer reputation and badges are precomputed the same way
+	reputationService := services.NewReputationService(store)
+	go refreshReputation(reputationService, time.Duration(cfg.ReputationRefreshMinutes)*time.Minute)
+
+	// Verification and password reset links go out through the configured mail driver
+	mailer, err := mail.New(cfg)
+	if err != nil {
+		pool.Close()
+		log.Fatalf("Failed to set up mail: %v", err)
+	}
 
 	// Setup Echo server
 	e := echo.New()
 	e.Validator = utils.NewValidator()
+	e.HTTPErrorHandler = http.HTTPErrorHandler
+
+	// Client addresses come from X-Forwarded-For only behind the configured proxies
+	e.IPExtractor, err = middleware.ClientIP(cfg.TrustedProxies)
+	if err != nil {
+		pool.Close()
+		log.Fatalf("Failed to set up client addresses: %v", err)
+	}
 
 	// TODO: Set environment from config when you add environment configuration
 	productionOrigins := []string{
@@ -38,14 +90,72 @@ func main() {
 	}
 	e.Use(middleware.CORSWithEnvironment("development", productionOrigins))
 
-	// Initialize handlers with dependencies
-	handler := handlers.NewHandler(queries, jwtService)
+	// Tag every request with an X-Request-ID (kept if the client sent one) for the audit log
+	e.Use(middleware.RequestID())
+
+	// Initialize handlers with dependencies; services reach the tables through the repository store
+	handler := handlers.NewHandler(store, jwtService, sessionService, leaderboardService, reputationService, mailer, cfg)
 
 	// Setup routes
 	http.SetupRoutes(e, handler, jwtService)
 
 	// Start server
 	log.Printf("Server starting on port %s", cfg.ServerPort)
-	log.Printf("JWT expiry: %d hours", cfg.JWTExpiryHours)
+	log.Printf("Access token expiry: %d minutes, refresh token expiry: %d hours", cfg.JWTAccessExpiryMinutes, cfg.RefreshTokenExpiryHours)
+	log.Printf("Review approval required: %t", cfg.RequireReviewApproval)
+	log.Printf("Mail driver: %s", cfg.MailDriver)
 	e.Logger.Fatal(e.Start(":" + cfg.ServerPort))
 }
+
+func pruneSessions(sessionService *services.SessionService, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		if err := sessionService.PruneExpired(ctx); err != nil {
+			log.Printf("Failed to prune expired sessions: %v", err)
+		}
+		cancel()
+	}
+}
+
+// refreshLeaderboard rebuilds the leaderboard once at startup and then on every tick
+func refreshLeaderboard(leaderboardService *services.LeaderboardService, interval time.Duration) {
+	if interval <= 0 {
+		interval = 15 * time.Minute
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+		if err := leaderboardService.Refresh(ctx); err != nil {
+			log.Printf("Failed to refresh leaderboard: %v", err)
+		}
+		cancel()
+
+		<-ticker.C
+	}
+}
+
+// refreshReputation recomputes reviewer reputation once at startup and then on every tick
+func refreshReputation(reputationService *services.ReputationService, interval time.Duration) {
+	if interval <= 0 {
+		interval = time.Hour
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+		if err := reputationService.Refresh(ctx); err != nil {
+			log.Printf("Failed to refresh reputation: %v", err)
+		}
+		cancel()
+
+		<-ticker.C
+	}
+}
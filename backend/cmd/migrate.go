@@ -0,0 +1,118 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"ratemysoft-backend/internal/platform/migrate"
+	"ratemysoft-backend/migrations"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const migrateUsage = `usage: ratemysoft migrate <command>
+
+commands:
+  up                  apply all pending migrations
+  down [N]            roll back the last N applied migrations (default 1)
+  status              list migrations and whether they are applied
+  baseline <VERSION>  mark migrations up to VERSION as applied without running them,
+                      for a database whose schema already exists`
+
+// runMigrate implements the `ratemysoft migrate up|down|status|baseline` subcommand
+func runMigrate(pool *pgxpool.Pool, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("missing migrate command\n%s", migrateUsage)
+	}
+
+	migrator, err := migrate.New(pool, migrations.FS)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+
+	switch args[0] {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		for _, m := range applied {
+			log.Printf("Applied migration %04d_%s", m.Version, m.Name)
+		}
+		if err != nil {
+			return err
+		}
+		if len(applied) == 0 {
+			log.Println("No pending migrations")
+		}
+		return nil
+
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			steps, err = strconv.Atoi(args[1])
+			if err != nil || steps < 1 {
+				return fmt.Errorf("invalid step count %q\n%s", args[1], migrateUsage)
+			}
+		}
+		rolledBack, err := migrator.Down(ctx, steps)
+		for _, m := range rolledBack {
+			log.Printf("Rolled back migration %04d_%s", m.Version, m.Name)
+		}
+		if err != nil {
+			return err
+		}
+		if len(rolledBack) == 0 {
+			log.Println("No applied migrations to roll back")
+		}
+		return nil
+
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSION\tNAME\tSTATUS\tAPPLIED AT")
+		for _, s := range statuses {
+			status, appliedAt := "pending", "-"
+			if s.AppliedAt != nil {
+				status = "applied"
+				appliedAt = s.AppliedAt.Format(time.RFC3339)
+			}
+			if s.Modified {
+				status = "modified"
+			}
+			fmt.Fprintf(w, "%04d\t%s\t%s\t%s\n", s.Version, s.Name, status, appliedAt)
+		}
+		return w.Flush()
+
+	case "baseline":
+		if len(args) < 2 {
+			return fmt.Errorf("missing baseline version\n%s", migrateUsage)
+		}
+		version, err := strconv.ParseInt(args[1], 10, 64)
+		if err != nil || version < 1 {
+			return fmt.Errorf("invalid baseline version %q\n%s", args[1], migrateUsage)
+		}
+		recorded, err := migrator.Baseline(ctx, version)
+		if err != nil {
+			return err
+		}
+		for _, m := range recorded {
+			log.Printf("Marked migration %04d_%s as applied", m.Version, m.Name)
+		}
+		if len(recorded) == 0 {
+			log.Printf("Migrations up to %04d are already applied", version)
+		}
+		return nil
+
+	default:
+		return fmt.Errorf("unknown migrate command %q\n%s", args[0], migrateUsage)
+	}
+}
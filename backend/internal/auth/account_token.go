@@ -0,0 +1,13 @@
+package auth
+
+// GenerateAccountToken creates the token of an emailed link (email verification, password
+// reset) and returns it with the hash to persist. These are as random as refresh tokens, so
+// they share the generator and a fast hash that allows lookup.
+func GenerateAccountToken() (token string, hash string, err error) {
+	return GenerateRefreshToken()
+}
+
+// HashAccountToken returns the hash an account token is stored under
+func HashAccountToken(token string) string {
+	return HashRefreshToken(token)
+}
@@ -1,7 +1,7 @@
 package auth
 
 import (
-	"fmt"
+	"ratemysoft-backend/internal/domain"
 
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
@@ -14,6 +14,8 @@ type JWTClaims struct {
 	Email  string `json:"email"`
 	Handle string `json:"handle"`
 	Role   string `json:"role"`
+	// SessionID ties the access token to the login session (and refresh token chain) that issued it
+	SessionID string `json:"sid,omitempty"`
 	jwt.RegisteredClaims
 }
 
@@ -23,26 +25,40 @@ const (
 	ContextKeyUserEmail  = "user_email"
 	ContextKeyUserRole   = "user_role"
 	ContextKeyUserHandle = "user_handle"
+	ContextKeyClaims     = "jwt_claims"
 )
 
+// ErrNotAuthenticated is returned by the context helpers when no valid user was attached by the auth middleware
+var ErrNotAuthenticated = domain.Unauthorized("unauthenticated", "user not authenticated")
+
 // SetUserInContext stores user information from claims into Echo context
 func SetUserInContext(c echo.Context, claims *JWTClaims) {
 	c.Set(ContextKeyUserID, claims.UserID)
 	c.Set(ContextKeyUserEmail, claims.Email)
 	c.Set(ContextKeyUserRole, claims.Role)
 	c.Set(ContextKeyUserHandle, claims.Handle)
+	c.Set(ContextKeyClaims, claims)
+}
+
+// GetClaimsFromContext retrieves the full access token claims from the Echo context
+func GetClaimsFromContext(c echo.Context) (*JWTClaims, error) {
+	claims, ok := c.Get(ContextKeyClaims).(*JWTClaims)
+	if !ok {
+		return nil, ErrNotAuthenticated
+	}
+	return claims, nil
 }
 
 // GetUserIDFromContext retrieves the user ID from the Echo context
 func GetUserIDFromContext(c echo.Context) (uuid.UUID, error) {
 	userIDStr, ok := c.Get(ContextKeyUserID).(string)
 	if !ok {
-		return uuid.Nil, fmt.Errorf("user ID not found in context")
+		return uuid.Nil, ErrNotAuthenticated
 	}
 
 	userID, err := uuid.Parse(userIDStr)
 	if err != nil {
-		return uuid.Nil, fmt.Errorf("invalid user ID format: %w", err)
+		return uuid.Nil, ErrNotAuthenticated.Wrap(err)
 	}
 
 	return userID, nil
@@ -52,7 +68,7 @@ func GetUserIDFromContext(c echo.Context) (uuid.UUID, error) {
 func GetUserEmailFromContext(c echo.Context) (string, error) {
 	email, ok := c.Get(ContextKeyUserEmail).(string)
 	if !ok {
-		return "", fmt.Errorf("user email not found in context")
+		return "", ErrNotAuthenticated
 	}
 	return email, nil
 }
@@ -61,7 +77,7 @@ func GetUserEmailFromContext(c echo.Context) (string, error) {
 func GetUserRoleFromContext(c echo.Context) (string, error) {
 	role, ok := c.Get(ContextKeyUserRole).(string)
 	if !ok {
-		return "", fmt.Errorf("user role not found in context")
+		return "", ErrNotAuthenticated
 	}
 	return role, nil
 }
@@ -70,11 +86,26 @@ func GetUserRoleFromContext(c echo.Context) (string, error) {
 func GetUserHandleFromContext(c echo.Context) (string, error) {
 	handle, ok := c.Get(ContextKeyUserHandle).(string)
 	if !ok {
-		return "", fmt.Errorf("user handle not found in context")
+		return "", ErrNotAuthenticated
 	}
 	return handle, nil
 }
 
+// GetActorFromContext returns the authenticated user as a domain.Actor for service calls
+func GetActorFromContext(c echo.Context) (domain.Actor, error) {
+	userID, err := GetUserIDFromContext(c)
+	if err != nil {
+		return domain.Actor{}, err
+	}
+
+	role, err := GetUserRoleFromContext(c)
+	if err != nil {
+		return domain.Actor{}, err
+	}
+
+	return domain.Actor{UserID: userID, Role: domain.UserRole(role)}, nil
+}
+
 // IsAdmin checks if the user in the context has admin role
 func IsAdmin(c echo.Context) bool {
 	role, err := GetUserRoleFromContext(c)
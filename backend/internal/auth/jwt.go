@@ -1,41 +1,62 @@
 package auth
 
 import (
+	"context"
 	"fmt"
 	"time"
 
 	"ratemysoft-backend/internal/domain"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 )
 
+// RevocationChecker reports whether an access token has been revoked server-side,
+// either individually (by jti) or because its session was ended
+type RevocationChecker interface {
+	IsAccessTokenRevoked(ctx context.Context, tokenID, sessionID string) (bool, error)
+}
+
 // JWTService handles JWT token generation and validation
 type JWTService struct {
-	secretKey []byte
-	issuer    string
-	expiry    time.Duration
+	secretKey   []byte
+	issuer      string
+	expiry      time.Duration
+	revocations RevocationChecker // optional; nil disables revocation checks
 }
 
-// NewJWTService creates a new JWT service
-func NewJWTService(secretKey string, expiryHours int) *JWTService {
+// NewJWTService creates a new JWT service issuing access tokens valid for expiry
+func NewJWTService(secretKey string, expiry time.Duration) *JWTService {
 	return &JWTService{
 		secretKey: []byte(secretKey),
 		issuer:    "ratemysoft",
-		expiry:    time.Duration(expiryHours) * time.Hour,
+		expiry:    expiry,
 	}
 }
 
-// GenerateToken creates a new JWT token for the given user
-func (s *JWTService) GenerateToken(user *domain.User) (string, error) {
+// SetRevocationChecker enables server-side revocation checks in ValidateAccessToken
+func (s *JWTService) SetRevocationChecker(checker RevocationChecker) {
+	s.revocations = checker
+}
+
+// AccessTokenExpiry returns how long newly issued access tokens are valid
+func (s *JWTService) AccessTokenExpiry() time.Duration {
+	return s.expiry
+}
+
+// GenerateToken creates a new short-lived access token for the given user and session
+func (s *JWTService) GenerateToken(user *domain.User, sessionID domain.ID) (string, error) {
 	now := time.Now()
 	expiresAt := now.Add(s.expiry)
 
 	claims := &JWTClaims{
-		UserID: user.ID.String(),
-		Email:  string(user.Email),
-		Handle: user.Handle,
-		Role:   string(user.Role),
+		UserID:    user.ID.String(),
+		Email:     string(user.Email),
+		Handle:    user.Handle,
+		Role:      string(user.Role),
+		SessionID: sessionID.String(),
 		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.NewString(),
 			ExpiresAt: jwt.NewNumericDate(expiresAt),
 			IssuedAt:  jwt.NewNumericDate(now),
 			NotBefore: jwt.NewNumericDate(now),
@@ -80,6 +101,29 @@ func (s *JWTService) ValidateToken(tokenString string) (*JWTClaims, error) {
 	return claims, nil
 }
 
+// ValidateAccessToken validates a token like ValidateToken and additionally rejects
+// tokens that were revoked by logout
+func (s *JWTService) ValidateAccessToken(ctx context.Context, tokenString string) (*JWTClaims, error) {
+	claims, err := s.ValidateToken(tokenString)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.revocations == nil {
+		return claims, nil
+	}
+
+	revoked, err := s.revocations.IsAccessTokenRevoked(ctx, claims.ID, claims.SessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check token revocation: %w", err)
+	}
+	if revoked {
+		return nil, fmt.Errorf("token has been revoked")
+	}
+
+	return claims, nil
+}
+
 // ParseClaims parses a token without validating (useful for debugging)
 func (s *JWTService) ParseClaims(tokenString string) (*JWTClaims, error) {
 	token, _, err := jwt.NewParser().ParseUnverified(tokenString, &JWTClaims{})
@@ -0,0 +1,73 @@
+package oauth
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCodeChallengeRFC7636(t *testing.T) {
+	// Appendix B of RFC 7636
+	got := CodeChallenge("dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk")
+	if want := "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM"; got != want {
+		t.Errorf("challenge = %q, want %q", got, want)
+	}
+}
+
+func TestFlowCodecRoundTrip(t *testing.T) {
+	flow, err := NewFlow("github", 10*time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if flow.State == flow.CodeVerifier || flow.State == flow.Nonce || len(flow.CodeVerifier) < 43 {
+		t.Fatalf("flow secrets are not independent or too short: %+v", flow)
+	}
+
+	codec := NewFlowCodec("secret")
+	got, err := codec.Decode(codec.Encode(flow), time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Provider != "github" || got.State != flow.State || got.CodeVerifier != flow.CodeVerifier || got.Nonce != flow.Nonce {
+		t.Errorf("decoded = %+v, want %+v", got, flow)
+	}
+}
+
+func TestFlowCodecRejects(t *testing.T) {
+	flow, err := NewFlow("github", time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	codec := NewFlowCodec("secret")
+	token := codec.Encode(flow)
+	payload, _, _ := strings.Cut(token, ".")
+
+	cases := map[string]struct {
+		token string
+		now   time.Time
+	}{
+		"expired":      {token, flow.ExpiresAt},
+		"other secret": {NewFlowCodec("other").Encode(flow), time.Now()},
+		"no signature": {payload, time.Now()},
+		"tampered":     {"x" + token, time.Now()},
+		"empty":        {"", time.Now()},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if _, err := codec.Decode(tc.token, tc.now); !errors.Is(err, ErrInvalidFlow) {
+				t.Errorf("err = %v, want ErrInvalidFlow", err)
+			}
+		})
+	}
+}
+
+func TestRegistryNames(t *testing.T) {
+	r := NewRegistry(NewGitHub(GitHubConfig{}), NewGoogle("id", "secret"), NewOIDC(OIDCConfig{Name: "corp"}))
+	if got := strings.Join(r.Names(), ","); got != "corp,github,google" {
+		t.Errorf("names = %s", got)
+	}
+	if _, ok := r.Get("gitlab"); ok {
+		t.Error("unexpected gitlab provider")
+	}
+}
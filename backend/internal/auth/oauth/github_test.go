@@ -0,0 +1,87 @@
+package oauth_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"ratemysoft-backend/internal/auth/oauth"
+)
+
+// githubStub serves the token and REST endpoints GitHub login uses
+func githubStub(t *testing.T, emails []map[string]any) *oauth.GitHub {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /login/oauth/access_token", func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		if r.Header.Get("Accept") != "application/json" {
+			http.Error(w, "form-encoded response", http.StatusNotAcceptable)
+			return
+		}
+		if r.PostForm.Get("code") != "good" || r.PostForm.Get("code_verifier") == "" {
+			_ = json.NewEncoder(w).Encode(map[string]string{"error": "bad_verification_code", "error_description": "The code passed is incorrect or expired."})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]string{"access_token": "gho_test", "token_type": "bearer"})
+	})
+	mux.HandleFunc("GET /user", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer gho_test" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"id": 583231, "login": "octocat", "name": "The Octocat"})
+	})
+	mux.HandleFunc("GET /user/emails", func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(emails)
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return oauth.NewGitHub(oauth.GitHubConfig{
+		ClientID:     "id",
+		ClientSecret: "secret",
+		AuthURL:      server.URL + "/login/oauth/authorize",
+		TokenURL:     server.URL + "/login/oauth/access_token",
+		APIURL:       server.URL,
+		Client:       server.Client(),
+	})
+}
+
+func TestGitHubExchange(t *testing.T) {
+	github := githubStub(t, []map[string]any{
+		{"email": "octo@users.noreply.github.com", "primary": false, "verified": true},
+		{"email": "octocat@github.com", "primary": true, "verified": true},
+	})
+
+	identity, err := github.Exchange(context.Background(), oauth.ExchangeRequest{Code: "good", CodeVerifier: "verifier"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := oauth.Identity{Subject: "583231", Email: "octocat@github.com", EmailVerified: true, Username: "octocat", Name: "The Octocat"}
+	if *identity != want {
+		t.Errorf("identity = %+v, want %+v", *identity, want)
+	}
+}
+
+func TestGitHubUnverifiedPrimaryEmail(t *testing.T) {
+	github := githubStub(t, []map[string]any{{"email": "octocat@github.com", "primary": true, "verified": false}})
+
+	identity, err := github.Exchange(context.Background(), oauth.ExchangeRequest{Code: "good", CodeVerifier: "verifier"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if identity.EmailVerified {
+		t.Error("unverified primary email reported as verified")
+	}
+}
+
+func TestGitHubTokenError(t *testing.T) {
+	github := githubStub(t, nil)
+
+	if _, err := github.Exchange(context.Background(), oauth.ExchangeRequest{Code: "bad", CodeVerifier: "verifier"}); err == nil {
+		t.Error("Exchange succeeded with a rejected code")
+	}
+}
@@ -0,0 +1,244 @@
+// Package oauthtest runs a stub OpenID Connect provider for tests. It approves every
+// authorization request for a configurable user, and enforces the parts of the protocol the
+// client is responsible for: client credentials, redirect URI and the PKCE code verifier.
+package oauthtest
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"ratemysoft-backend/internal/auth/oauth"
+)
+
+const (
+	// ClientID and ClientSecret are the credentials the stub accepts
+	ClientID     = "test-client"
+	ClientSecret = "test-secret"
+
+	keyID = "test-key"
+)
+
+// User is who the stub signs in
+type User struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Username      string
+}
+
+// IdP is a running stub provider
+type IdP struct {
+	URL string // issuer URL
+
+	server *httptest.Server
+	key    *rsa.PrivateKey
+
+	mu     sync.Mutex
+	user   User
+	grants map[string]grant
+}
+
+// grant is an issued authorization code and what it was issued for
+type grant struct {
+	user          User
+	redirectURI   string
+	codeChallenge string
+	nonce         string
+}
+
+// NewIdP starts a stub provider that signs in user; it is shut down when the test ends
+func NewIdP(t *testing.T, user User) *IdP {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generate signing key: %v", err)
+	}
+	idp := &IdP{key: key, user: user, grants: map[string]grant{}}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /.well-known/openid-configuration", idp.discovery)
+	mux.HandleFunc("GET /authorize", idp.authorize)
+	mux.HandleFunc("POST /token", idp.token)
+	mux.HandleFunc("GET /jwks", idp.jwks)
+	mux.HandleFunc("GET /userinfo", idp.userinfo)
+
+	idp.server = httptest.NewServer(mux)
+	idp.URL = idp.server.URL
+	t.Cleanup(idp.server.Close)
+	return idp
+}
+
+// Provider returns an OIDC provider named name pointed at the stub
+func (idp *IdP) Provider(name string) *oauth.OIDC {
+	return oauth.NewOIDC(oauth.OIDCConfig{
+		Name:         name,
+		IssuerURL:    idp.URL,
+		ClientID:     ClientID,
+		ClientSecret: ClientSecret,
+		Client:       idp.server.Client(),
+	})
+}
+
+// SetUser changes who the next authorization request signs in
+func (idp *IdP) SetUser(user User) {
+	idp.mu.Lock()
+	defer idp.mu.Unlock()
+	idp.user = user
+}
+
+// Authorize follows an authorization URL the way a browser would after the user consents, and
+// returns the callback URL the stub redirects to
+func (idp *IdP) Authorize(t *testing.T, authURL string) *url.URL {
+	t.Helper()
+
+	client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}}
+	resp, err := client.Get(authURL)
+	if err != nil {
+		t.Fatalf("authorize: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusFound {
+		t.Fatalf("authorize: status %d, want 302", resp.StatusCode)
+	}
+	callback, err := url.Parse(resp.Header.Get("Location"))
+	if err != nil {
+		t.Fatalf("authorize: bad redirect: %v", err)
+	}
+	return callback
+}
+
+func (idp *IdP) discovery(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{
+		"issuer":                 idp.URL,
+		"authorization_endpoint": idp.URL + "/authorize",
+		"token_endpoint":         idp.URL + "/token",
+		"userinfo_endpoint":      idp.URL + "/userinfo",
+		"jwks_uri":               idp.URL + "/jwks",
+	})
+}
+
+func (idp *IdP) authorize(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	redirectURI := q.Get("redirect_uri")
+	if q.Get("client_id") != ClientID || redirectURI == "" {
+		http.Error(w, "unknown client", http.StatusBadRequest)
+		return
+	}
+	if q.Get("code_challenge_method") != "S256" || q.Get("code_challenge") == "" {
+		http.Error(w, "PKCE required", http.StatusBadRequest)
+		return
+	}
+
+	code := rand.Text()
+	idp.mu.Lock()
+	idp.grants[code] = grant{
+		user:          idp.user,
+		redirectURI:   redirectURI,
+		codeChallenge: q.Get("code_challenge"),
+		nonce:         q.Get("nonce"),
+	}
+	idp.mu.Unlock()
+
+	callback, _ := url.Parse(redirectURI)
+	values := callback.Query()
+	values.Set("code", code)
+	values.Set("state", q.Get("state"))
+	callback.RawQuery = values.Encode()
+	http.Redirect(w, r, callback.String(), http.StatusFound)
+}
+
+func (idp *IdP) token(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_request"})
+		return
+	}
+	if r.PostForm.Get("client_id") != ClientID || r.PostForm.Get("client_secret") != ClientSecret {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "invalid_client"})
+		return
+	}
+
+	// Codes are single use, whether or not the exchange succeeds
+	code := r.PostForm.Get("code")
+	idp.mu.Lock()
+	g, ok := idp.grants[code]
+	delete(idp.grants, code)
+	idp.mu.Unlock()
+
+	if !ok || g.redirectURI != r.PostForm.Get("redirect_uri") ||
+		oauth.CodeChallenge(r.PostForm.Get("code_verifier")) != g.codeChallenge {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant"})
+		return
+	}
+
+	now := time.Now()
+	idToken := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"iss":                idp.URL,
+		"sub":                g.user.Subject,
+		"aud":                ClientID,
+		"iat":                now.Unix(),
+		"exp":                now.Add(5 * time.Minute).Unix(),
+		"nonce":              g.nonce,
+		"email":              g.user.Email,
+		"email_verified":     g.user.EmailVerified,
+		"preferred_username": g.user.Username,
+	})
+	idToken.Header["kid"] = keyID
+	signed, err := idToken.SignedString(idp.key)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "server_error"})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"access_token": "access-" + g.user.Subject,
+		"token_type":   "Bearer",
+		"expires_in":   300,
+		"id_token":     signed,
+	})
+}
+
+func (idp *IdP) jwks(w http.ResponseWriter, _ *http.Request) {
+	pub := idp.key.PublicKey
+	writeJSON(w, http.StatusOK, map[string]any{
+		"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": keyID,
+			"use": "sig",
+			"alg": "RS256",
+			"n":   base64.RawURLEncoding.EncodeToString(pub.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes()),
+		}},
+	})
+}
+
+func (idp *IdP) userinfo(w http.ResponseWriter, _ *http.Request) {
+	idp.mu.Lock()
+	user := idp.user
+	idp.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"sub":            user.Subject,
+		"email":          user.Email,
+		"email_verified": user.EmailVerified,
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
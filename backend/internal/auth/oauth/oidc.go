@@ -0,0 +1,283 @@
+package oauth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// idTokenLeeway absorbs clock skew between us and the issuer when checking ID token times
+const idTokenLeeway = time.Minute
+
+// OIDCConfig configures a generic OpenID Connect provider
+type OIDCConfig struct {
+	Name         string
+	IssuerURL    string // discovery document is read from <IssuerURL>/.well-known/openid-configuration
+	ClientID     string
+	ClientSecret string
+	Scopes       []string     // defaults to openid, email and profile
+	Client       *http.Client // defaults to a client with a short timeout
+}
+
+// OIDC is a provider speaking OpenID Connect, configured through discovery
+type OIDC struct {
+	cfg    OIDCConfig
+	client *http.Client
+
+	mu        sync.Mutex
+	discovery *discovery
+	keys      *keySet
+}
+
+// discovery holds the parts of the provider metadata document we use
+type discovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	UserinfoEndpoint      string `json:"userinfo_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// NewOIDC creates a provider for the issuer in cfg. Discovery happens on first use, so a
+// provider that is down at startup does not keep the server from starting.
+func NewOIDC(cfg OIDCConfig) *OIDC {
+	if len(cfg.Scopes) == 0 {
+		cfg.Scopes = []string{"openid", "email", "profile"}
+	}
+	cfg.IssuerURL = strings.TrimSuffix(cfg.IssuerURL, "/")
+	return &OIDC{cfg: cfg, client: httpClient(cfg.Client)}
+}
+
+// NewGoogle creates a provider for Google accounts
+func NewGoogle(clientID, clientSecret string) *OIDC {
+	return NewOIDC(OIDCConfig{
+		Name:         "google",
+		IssuerURL:    "https://accounts.google.com",
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+	})
+}
+
+// Name implements Provider
+func (p *OIDC) Name() string {
+	return p.cfg.Name
+}
+
+// AuthURL implements Provider
+func (p *OIDC) AuthURL(ctx context.Context, req AuthRequest) (string, error) {
+	meta, err := p.metadata(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	query := url.Values{
+		"response_type":         {"code"},
+		"client_id":             {p.cfg.ClientID},
+		"redirect_uri":          {req.RedirectURL},
+		"scope":                 {strings.Join(p.cfg.Scopes, " ")},
+		"state":                 {req.State},
+		"nonce":                 {req.Nonce},
+		"code_challenge":        {req.CodeChallenge},
+		"code_challenge_method": {"S256"},
+	}
+	return withQuery(meta.AuthorizationEndpoint, query)
+}
+
+// Exchange implements Provider. The identity comes from the ID token; the userinfo endpoint is
+// only consulted when the token carries no email.
+func (p *OIDC) Exchange(ctx context.Context, req ExchangeRequest) (*Identity, error) {
+	meta, err := p.metadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var token struct {
+		AccessToken string `json:"access_token"`
+		IDToken     string `json:"id_token"`
+	}
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {req.Code},
+		"redirect_uri":  {req.RedirectURL},
+		"client_id":     {p.cfg.ClientID},
+		"client_secret": {p.cfg.ClientSecret},
+		"code_verifier": {req.CodeVerifier},
+	}
+	if err := postForm(ctx, p.client, meta.TokenEndpoint, form, &token); err != nil {
+		return nil, err
+	}
+	if token.IDToken == "" {
+		return nil, errors.New("token response has no id_token")
+	}
+
+	claims, err := p.verifyIDToken(ctx, meta, token.IDToken, req.Nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	if claims.Email == "" && meta.UserinfoEndpoint != "" && token.AccessToken != "" {
+		var info idClaims
+		if err := getJSON(ctx, p.client, meta.UserinfoEndpoint, token.AccessToken, &info); err != nil {
+			return nil, err
+		}
+		// The userinfo response must describe the same user as the ID token
+		if info.Subject == claims.Subject {
+			claims.Email, claims.EmailVerified = info.Email, info.EmailVerified
+		}
+	}
+
+	return &Identity{
+		Subject:       claims.Subject,
+		Email:         claims.Email,
+		EmailVerified: bool(claims.EmailVerified),
+		Username:      claims.PreferredUsername,
+		Name:          claims.FullName,
+	}, nil
+}
+
+// idClaims are the ID token (and userinfo) claims we read
+type idClaims struct {
+	jwt.RegisteredClaims
+	Nonce             string   `json:"nonce"`
+	Email             string   `json:"email"`
+	EmailVerified     flexBool `json:"email_verified"`
+	PreferredUsername string   `json:"preferred_username"`
+	FullName          string   `json:"name"`
+}
+
+func (p *OIDC) verifyIDToken(ctx context.Context, meta *discovery, raw, nonce string) (*idClaims, error) {
+	keys := p.keySet(meta.JWKSURI)
+
+	var claims idClaims
+	_, err := jwt.ParseWithClaims(raw, &claims, func(token *jwt.Token) (any, error) {
+		kid, _ := token.Header["kid"].(string)
+		return keys.key(ctx, kid)
+	},
+		jwt.WithValidMethods([]string{"RS256"}),
+		jwt.WithIssuer(meta.Issuer),
+		jwt.WithAudience(p.cfg.ClientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(idTokenLeeway),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("invalid id_token: %w", err)
+	}
+	if claims.Nonce != nonce {
+		return nil, errors.New("invalid id_token: nonce mismatch")
+	}
+	if claims.Subject == "" {
+		return nil, errors.New("invalid id_token: missing subject")
+	}
+	return &claims, nil
+}
+
+// metadata fetches the discovery document once and caches it; failures are retried next time
+func (p *OIDC) metadata(ctx context.Context) (*discovery, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.discovery != nil {
+		return p.discovery, nil
+	}
+
+	var meta discovery
+	if err := getJSON(ctx, p.client, p.cfg.IssuerURL+"/.well-known/openid-configuration", "", &meta); err != nil {
+		return nil, fmt.Errorf("oidc discovery: %w", err)
+	}
+	if strings.TrimSuffix(meta.Issuer, "/") != p.cfg.IssuerURL {
+		return nil, fmt.Errorf("oidc discovery: issuer %q does not match %q", meta.Issuer, p.cfg.IssuerURL)
+	}
+	if meta.AuthorizationEndpoint == "" || meta.TokenEndpoint == "" || meta.JWKSURI == "" {
+		return nil, errors.New("oidc discovery: document is missing endpoints")
+	}
+	p.discovery = &meta
+	return p.discovery, nil
+}
+
+func (p *OIDC) keySet(uri string) *keySet {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.keys == nil {
+		p.keys = newKeySet(p.client, uri)
+	}
+	return p.keys
+}
+
+// flexBool decodes booleans some providers send as the strings "true" and "false"
+type flexBool bool
+
+func (b *flexBool) UnmarshalJSON(data []byte) error {
+	switch strings.Trim(string(data), `"`) {
+	case "true":
+		*b = true
+	case "false", "null":
+		*b = false
+	default:
+		return fmt.Errorf("invalid boolean %s", data)
+	}
+	return nil
+}
+
+func withQuery(endpoint string, query url.Values) (string, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", fmt.Errorf("invalid endpoint %q: %w", endpoint, err)
+	}
+	existing := u.Query()
+	for key, values := range query {
+		existing[key] = values
+	}
+	u.RawQuery = existing.Encode()
+	return u.String(), nil
+}
+
+// postForm posts a form and decodes the JSON response into out
+func postForm(ctx context.Context, client *http.Client, endpoint string, form url.Values, out any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return doJSON(client, req, out)
+}
+
+// getJSON fetches endpoint, authenticating with token when given, and decodes into out
+func getJSON(ctx context.Context, client *http.Client, endpoint, token string, out any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return err
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	return doJSON(client, req, out)
+}
+
+func doJSON(client *http.Client, req *http.Request, out any) error {
+	req.Header.Set("Accept", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s %s: status %d: %s", req.Method, req.URL.Redacted(), resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("%s %s: %w", req.Method, req.URL.Redacted(), err)
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+package oauth_test
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	"ratemysoft-backend/internal/auth/oauth"
+	"ratemysoft-backend/internal/auth/oauth/oauthtest"
+)
+
+const redirectURL = "http://app.test/auth/oauth/oidc/callback"
+
+// login runs one flow against the stub and returns the exchange result
+func login(t *testing.T, idp *oauthtest.IdP, provider oauth.Provider, tamper func(*oauth.ExchangeRequest)) (*oauth.Identity, error) {
+	t.Helper()
+	ctx := context.Background()
+
+	flow, err := oauth.NewFlow(provider.Name(), time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	authURL, err := provider.AuthURL(ctx, oauth.AuthRequest{
+		RedirectURL:   redirectURL,
+		State:         flow.State,
+		CodeChallenge: flow.CodeChallenge(),
+		Nonce:         flow.Nonce,
+	})
+	if err != nil {
+		t.Fatalf("AuthURL: %v", err)
+	}
+
+	callback := idp.Authorize(t, authURL)
+	if callback.Query().Get("state") != flow.State {
+		t.Fatalf("state = %q, want %q", callback.Query().Get("state"), flow.State)
+	}
+
+	req := oauth.ExchangeRequest{
+		RedirectURL:  redirectURL,
+		Code:         callback.Query().Get("code"),
+		CodeVerifier: flow.CodeVerifier,
+		Nonce:        flow.Nonce,
+	}
+	if tamper != nil {
+		tamper(&req)
+	}
+	return provider.Exchange(ctx, req)
+}
+
+func TestOIDCLogin(t *testing.T) {
+	idp := oauthtest.NewIdP(t, oauthtest.User{Subject: "sub-1", Email: "alice@example.com", EmailVerified: true, Username: "alice"})
+	provider := idp.Provider("oidc")
+
+	identity, err := login(t, idp, provider, nil)
+	if err != nil {
+		t.Fatalf("Exchange: %v", err)
+	}
+	want := oauth.Identity{Subject: "sub-1", Email: "alice@example.com", EmailVerified: true, Username: "alice"}
+	if *identity != want {
+		t.Errorf("identity = %+v, want %+v", *identity, want)
+	}
+}
+
+func TestOIDCAuthURL(t *testing.T) {
+	idp := oauthtest.NewIdP(t, oauthtest.User{Subject: "sub-1"})
+
+	raw, err := idp.Provider("oidc").AuthURL(context.Background(), oauth.AuthRequest{RedirectURL: redirectURL, State: "s", CodeChallenge: "c", Nonce: "n"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	u, _ := url.Parse(raw)
+	q := u.Query()
+	if u.Path != "/authorize" || q.Get("code_challenge_method") != "S256" || q.Get("scope") != "openid email profile" || q.Get("client_id") != oauthtest.ClientID {
+		t.Errorf("auth URL = %s", raw)
+	}
+}
+
+func TestOIDCExchangeRejects(t *testing.T) {
+	idp := oauthtest.NewIdP(t, oauthtest.User{Subject: "sub-1", Email: "alice@example.com", EmailVerified: true})
+	provider := idp.Provider("oidc")
+
+	cases := map[string]func(*oauth.ExchangeRequest){
+		"wrong verifier": func(r *oauth.ExchangeRequest) { r.CodeVerifier = "not-the-verifier-not-the-verifier-not-the-ver" },
+		"wrong nonce":    func(r *oauth.ExchangeRequest) { r.Nonce = "replayed" },
+		"wrong redirect": func(r *oauth.ExchangeRequest) { r.RedirectURL = "http://evil.test/callback" },
+		"unknown code":   func(r *oauth.ExchangeRequest) { r.Code = "forged" },
+	}
+	for name, tamper := range cases {
+		t.Run(name, func(t *testing.T) {
+			if identity, err := login(t, idp, provider, tamper); err == nil {
+				t.Errorf("Exchange succeeded with %+v", identity)
+			}
+		})
+	}
+}
+
+func TestOIDCIssuerMismatch(t *testing.T) {
+	idp := oauthtest.NewIdP(t, oauthtest.User{Subject: "sub-1"})
+	provider := oauth.NewOIDC(oauth.OIDCConfig{Name: "oidc", IssuerURL: idp.URL + "/other", ClientID: oauthtest.ClientID})
+
+	if _, err := provider.AuthURL(context.Background(), oauth.AuthRequest{}); err == nil {
+		t.Error("AuthURL succeeded against a provider with another issuer")
+	}
+}
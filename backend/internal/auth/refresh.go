@@ -0,0 +1,30 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+)
+
+// refreshTokenBytes is the amount of randomness in a refresh token (256 bits)
+const refreshTokenBytes = 32
+
+// GenerateRefreshToken creates a new opaque refresh token and returns it with the hash to persist
+func GenerateRefreshToken() (token string, hash string, err error) {
+	buf := make([]byte, refreshTokenBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", fmt.Errorf("failed to generate refresh token: %w", err)
+	}
+
+	token = base64.RawURLEncoding.EncodeToString(buf)
+	return token, HashRefreshToken(token), nil
+}
+
+// HashRefreshToken returns the hex-encoded SHA-256 of a refresh token.
+// Refresh tokens are high-entropy, so a fast hash is sufficient and allows lookup by hash.
+func HashRefreshToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
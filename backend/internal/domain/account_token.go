@@ -0,0 +1,27 @@
+package domain
+
+import "time"
+
+// TokenPurpose is what an account token emailed to a user lets them do
+type TokenPurpose string
+
+const (
+	TokenEmailVerification TokenPurpose = "email_verification"
+	TokenPasswordReset     TokenPurpose = "password_reset"
+)
+
+// AccountToken is a single-use link sent by email. Only a hash of the token is stored.
+type AccountToken struct {
+	ID        ID
+	UserID    ID
+	Purpose   TokenPurpose
+	Email     string // the address the link was sent to
+	ExpiresAt time.Time
+	CreatedAt time.Time
+	UsedAt    *time.Time
+}
+
+// Usable reports whether the token can still be redeemed at now
+func (t *AccountToken) Usable(now time.Time) bool {
+	return t.UsedAt == nil && now.Before(t.ExpiresAt)
+}
@@ -0,0 +1,75 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// AuditAction is the kind of mutation an audit event records.
+type AuditAction string
+
+const (
+	AuditCreate  AuditAction = "create"
+	AuditUpdate  AuditAction = "update"
+	AuditDelete  AuditAction = "delete"
+	AuditApprove AuditAction = "approve"
+	AuditReject  AuditAction = "reject"
+)
+
+var ErrInvalidAuditAction = InvalidField("invalid_audit_action", "action", "must be one of: create update delete approve reject")
+
+func NewAuditAction(v string) (AuditAction, error) {
+	switch a := AuditAction(v); a {
+	case AuditCreate, AuditUpdate, AuditDelete, AuditApprove, AuditReject:
+		return a, nil
+	default:
+		return "", ErrInvalidAuditAction
+	}
+}
+
+// AuditEntity is the type of record an audit event concerns.
+type AuditEntity string
+
+const (
+	AuditEntityCompany AuditEntity = "company"
+	AuditEntityProduct AuditEntity = "product"
+	AuditEntityReview  AuditEntity = "review"
+	AuditEntityUser    AuditEntity = "user"
+)
+
+var ErrInvalidAuditEntity = InvalidField("invalid_entity_type", "entity_type", "must be one of: company product review user")
+
+func NewAuditEntity(v string) (AuditEntity, error) {
+	switch e := AuditEntity(v); e {
+	case AuditEntityCompany, AuditEntityProduct, AuditEntityReview, AuditEntityUser:
+		return e, nil
+	default:
+		return "", ErrInvalidAuditEntity
+	}
+}
+
+// AuditEvent records who changed which record, and how. Before and After are JSON objects
+// holding only the fields that changed; Before is nil on create and After on delete.
+type AuditEvent struct {
+	ID          ID
+	ActorID     *ID // nil for changes made by the system
+	ActorHandle string
+	Action      AuditAction
+	EntityType  AuditEntity
+	EntityID    ID
+	Before      json.RawMessage
+	After       json.RawMessage
+	RequestID   string
+	IPAddress   string
+	CreatedAt   time.Time
+}
+
+// AuditFilter narrows an audit log query; zero fields match everything.
+type AuditFilter struct {
+	ActorID    *ID
+	Action     AuditAction
+	EntityType AuditEntity
+	EntityID   *ID
+	From       *time.Time // inclusive
+	To         *time.Time // exclusive
+}
@@ -0,0 +1,22 @@
+package domain
+
+import "time"
+
+// Category groups products. Categories form a tree: a product listed under a
+// subcategory also shows up when browsing any of its ancestors.
+type Category struct {
+	ID          ID
+	Slug        ProductCategory
+	Name        string
+	Description string
+	Icon        string // icon name understood by the frontend
+	ParentID    *ID    // nil for top-level categories
+	SortOrder   int
+
+	// Live products filed directly under the category, and under it or any descendant
+	ProductCount      int
+	TotalProductCount int
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
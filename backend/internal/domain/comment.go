@@ -0,0 +1,27 @@
+package domain
+
+import "time"
+
+// ReviewComment is a reply in the discussion under a review. Top-level comments have no
+// parent; official responses are top-level comments written on behalf of the product's company.
+// Comments share the review moderation statuses.
+type ReviewComment struct {
+	ID          ID
+	ReviewID    ID
+	ParentID    *ID
+	UserID      ID
+	UserHandle  string
+	Body        string
+	Status      ReviewStatus
+	Official    bool
+	CompanyID   *ID    // set on official responses
+	CompanyName string // set on official responses
+	FlagCount   int
+	Edited      bool
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt *time.Time // deleted comments stay in a thread as placeholders while they have replies
+
+	Replies []*ReviewComment
+}
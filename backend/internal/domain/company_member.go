@@ -0,0 +1,76 @@
+package domain
+
+import "time"
+
+// CompanyRole is a user's role within a company. Owners can do everything editors can,
+// plus delete the company and manage its members.
+type CompanyRole string
+
+const (
+	CompanyOwner  CompanyRole = "owner"
+	CompanyEditor CompanyRole = "editor"
+)
+
+var ErrInvalidCompanyRole = Invalid("invalid_company_role", "invalid company role")
+
+func NewCompanyRole(v string) (CompanyRole, error) {
+	switch r := CompanyRole(v); r {
+	case CompanyOwner, CompanyEditor:
+		return r, nil
+	default:
+		return "", ErrInvalidCompanyRole
+	}
+}
+
+// Satisfies reports whether a member with role r may act where required is needed.
+func (r CompanyRole) Satisfies(required CompanyRole) bool {
+	if r == CompanyOwner {
+		return true
+	}
+	return r == required
+}
+
+type CompanyMember struct {
+	CompanyID  ID
+	UserID     ID
+	UserHandle string
+	Role       CompanyRole
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+type ClaimStatus string
+
+const (
+	ClaimPending  ClaimStatus = "pending"
+	ClaimApproved ClaimStatus = "approved"
+	ClaimRejected ClaimStatus = "rejected"
+)
+
+// ClaimVerification is how a company claim was (or will be) verified.
+type ClaimVerification string
+
+const (
+	// VerifyEmailDomain: the claimant's verified email domain matches the company website; approved automatically
+	VerifyEmailDomain ClaimVerification = "email_domain"
+	// VerifyManual: an admin reviews the claimant's evidence
+	VerifyManual ClaimVerification = "manual"
+)
+
+// CompanyClaim is a user's request to become owner of an existing company.
+type CompanyClaim struct {
+	ID                 ID
+	CompanyID          ID
+	CompanyName        string
+	UserID             ID
+	UserHandle         string
+	UserEmail          string
+	Status             ClaimStatus
+	VerificationMethod ClaimVerification
+	Evidence           string // optional
+	ReviewedBy         *ID
+	ReviewNote         string // optional
+	ReviewedAt         *time.Time
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+}
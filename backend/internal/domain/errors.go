@@ -1,10 +1,110 @@
 package domain
 
-import "errors"
+import (
+	"errors"
+	"time"
+)
+
+// Error kinds. Every *Error unwraps to exactly one of these, so callers can
+// classify with errors.Is(err, domain.ErrNotFound) and the HTTP layer can pick a status.
+var (
+	ErrNotFound     = errors.New("not found")
+	ErrConflict     = errors.New("conflict")
+	ErrForbidden    = errors.New("forbidden")
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrValidation   = errors.New("validation failed")
+	ErrRateLimited  = errors.New("too many requests")
+)
+
+// Validation errors returned by the value object constructors
+var (
+	ErrInvalidEmail    = Invalid("invalid_email", "invalid email")
+	ErrInvalidSlug     = Invalid("invalid_slug", "invalid slug")
+	ErrInvalidRating   = Invalid("invalid_rating", "invalid rating")
+	ErrInvalidCategory = Invalid("invalid_category", "invalid category")
+	ErrInvalidTag      = Invalid("invalid_tag", "invalid tag")
+	ErrEmptyHandle     = Invalid("handle_required", "handle required")
+)
 
+// Lookups that found nothing
 var (
-	ErrInvalidEmail  = errors.New("invalid email")
-	ErrInvalidSlug   = errors.New("invalid slug")
-	ErrInvalidRating = errors.New("invalid rating")
-	ErrEmptyHandle   = errors.New("handle required")
+	ErrUserNotFound        = NotFound("user_not_found", "user not found")
+	ErrCompanyNotFound     = NotFound("company_not_found", "company not found")
+	ErrProductNotFound     = NotFound("product_not_found", "product not found")
+	ErrReviewNotFound      = NotFound("review_not_found", "review not found")
+	ErrCommentNotFound     = NotFound("comment_not_found", "comment not found")
+	ErrPricingPlanNotFound = NotFound("pricing_plan_not_found", "pricing plan not found")
+	ErrCategoryNotFound    = NotFound("category_not_found", "category not found")
+	ErrTagNotFound         = NotFound("tag_not_found", "tag not found")
 )
+
+// FieldError describes why a single input field was rejected.
+type FieldError struct {
+	Field   string
+	Message string
+}
+
+// Error is a classified domain error with a stable, machine-readable code.
+// Message is safe to show to API clients; Cause is for logs only.
+type Error struct {
+	Kind    error
+	Code    string
+	Message string
+	Fields  []FieldError
+	Cause   error
+
+	// RetryAfter tells rate limited clients how long to wait before trying again
+	RetryAfter time.Duration
+}
+
+func (e *Error) Error() string {
+	if e.Cause != nil {
+		return e.Message + ": " + e.Cause.Error()
+	}
+	return e.Message
+}
+
+// Unwrap exposes both the kind and the cause to errors.Is / errors.As.
+func (e *Error) Unwrap() []error {
+	if e.Cause != nil {
+		return []error{e.Kind, e.Cause}
+	}
+	return []error{e.Kind}
+}
+
+// Wrap returns a copy of e that records cause, leaving shared sentinels untouched.
+func (e *Error) Wrap(cause error) *Error {
+	wrapped := *e
+	wrapped.Cause = cause
+	return &wrapped
+}
+
+func NotFound(code, message string) *Error {
+	return &Error{Kind: ErrNotFound, Code: code, Message: message}
+}
+
+func Conflict(code, message string) *Error {
+	return &Error{Kind: ErrConflict, Code: code, Message: message}
+}
+
+func Forbidden(code, message string) *Error {
+	return &Error{Kind: ErrForbidden, Code: code, Message: message}
+}
+
+func Unauthorized(code, message string) *Error {
+	return &Error{Kind: ErrUnauthorized, Code: code, Message: message}
+}
+
+// RateLimited is an error for a client that has to wait retryAfter before trying again.
+func RateLimited(code, message string, retryAfter time.Duration) *Error {
+	return &Error{Kind: ErrRateLimited, Code: code, Message: message, RetryAfter: retryAfter}
+}
+
+func Invalid(code, message string, fields ...FieldError) *Error {
+	return &Error{Kind: ErrValidation, Code: code, Message: message, Fields: fields}
+}
+
+// InvalidField is a validation error about one named input field.
+func InvalidField(code, field, message string) *Error {
+	return Invalid(code, "invalid "+field+": "+message, FieldError{Field: field, Message: message})
+}
@@ -1,16 +1,12 @@
 package domain
 
-import (
-	"errors"
-
-	"github.com/google/uuid"
-)
+import "github.com/google/uuid"
 
 type ID = uuid.UUID
 
 func NewID() ID { return uuid.New() }
 
-var ErrInvalidID = errors.New("invalid id")
+var ErrInvalidID = Invalid("invalid_id", "invalid id")
 
 func ParseID(s string) (ID, error) {
 	id, err := uuid.Parse(s)
@@ -0,0 +1,75 @@
+package domain
+
+import "time"
+
+// LeaderboardWindow limits a leaderboard to reviews written within a recent period.
+type LeaderboardWindow string
+
+const (
+	Window30Days  LeaderboardWindow = "30d"
+	Window90Days  LeaderboardWindow = "90d"
+	WindowAllTime LeaderboardWindow = "all"
+)
+
+// LeaderboardWindows lists every window the leaderboard job maintains.
+var LeaderboardWindows = []LeaderboardWindow{Window30Days, Window90Days, WindowAllTime}
+
+var ErrInvalidLeaderboardWindow = Invalid("invalid_window", "window must be one of 30d, 90d, all")
+
+func NewLeaderboardWindow(v string) (LeaderboardWindow, error) {
+	switch w := LeaderboardWindow(v); w {
+	case Window30Days, Window90Days, WindowAllTime:
+		return w, nil
+	default:
+		return "", ErrInvalidLeaderboardWindow
+	}
+}
+
+// Since returns the earliest review time counted in the window, or nil for all time.
+func (w LeaderboardWindow) Since(now time.Time) *time.Time {
+	var since time.Time
+	switch w {
+	case Window30Days:
+		since = now.UTC().AddDate(0, 0, -30)
+	case Window90Days:
+		since = now.UTC().AddDate(0, 0, -90)
+	default:
+		return nil
+	}
+	return &since
+}
+
+// LeaderboardScore selects the confidence-adjusted score products are ranked by.
+type LeaderboardScore string
+
+const (
+	ScoreBayesian LeaderboardScore = "bayesian" // average shrunk towards the global mean
+	ScoreWilson   LeaderboardScore = "wilson"   // lower bound on the share of positive (4-5 star) reviews
+)
+
+var ErrInvalidLeaderboardScore = Invalid("invalid_score", "score must be one of bayesian, wilson")
+
+func NewLeaderboardScore(v string) (LeaderboardScore, error) {
+	switch s := LeaderboardScore(v); s {
+	case ScoreBayesian, ScoreWilson:
+		return s, nil
+	default:
+		return "", ErrInvalidLeaderboardScore
+	}
+}
+
+// LeaderboardEntry is a ranked product on a leaderboard.
+type LeaderboardEntry struct {
+	Rank          int
+	ProductID     ID
+	ProductName   string
+	ProductSlug   Slug
+	Category      ProductCategory
+	CompanyName   string
+	CompanySlug   Slug
+	ReviewCount   int
+	PositiveCount int
+	AvgRating     float64
+	BayesianScore float64
+	WilsonScore   float64
+}
@@ -0,0 +1,13 @@
+package domain
+
+import "time"
+
+// LockedAccount is an account that refuses sign-ins until LockedUntil after too many failed attempts.
+type LockedAccount struct {
+	UserID         ID
+	Handle         string
+	Email          Email
+	FailedAttempts int
+	LastFailureAt  time.Time
+	LockedUntil    time.Time
+}
@@ -0,0 +1,23 @@
+package domain
+
+// PageRequest selects one page of a list: by offset, or by keyset when Cursor is set
+type PageRequest struct {
+	Limit  int32
+	Offset int32 // ignored when Cursor is set
+	Cursor *Cursor
+}
+
+// Cursor marks the boundary row of a keyset page by its sort key values and ID.
+// A forward cursor continues after the row, a backward one stops before it.
+type Cursor struct {
+	Sort     string   // sort order the cursor was issued for
+	Keys     []string // text form of the row's sort key values
+	ID       ID
+	Backward bool
+}
+
+// PageCursors points at the pages around a page; either is nil at that end of the list
+type PageCursors struct {
+	Next *Cursor
+	Prev *Cursor
+}
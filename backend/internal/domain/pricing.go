@@ -0,0 +1,97 @@
+package domain
+
+import (
+	"regexp"
+	"strings"
+	"time"
+)
+
+// BillingPeriod is how often a pricing plan's price is charged.
+type BillingPeriod string
+
+const (
+	BillingMonthly BillingPeriod = "monthly"
+	BillingYearly  BillingPeriod = "yearly"
+	BillingOneTime BillingPeriod = "one_time"
+	BillingUsage   BillingPeriod = "usage" // pay as you go, no fixed period
+)
+
+var ErrInvalidBillingPeriod = Invalid("invalid_billing_period", "invalid billing period")
+
+func NewBillingPeriod(v string) (BillingPeriod, error) {
+	switch p := BillingPeriod(v); p {
+	case BillingMonthly, BillingYearly, BillingOneTime, BillingUsage:
+		return p, nil
+	default:
+		return "", ErrInvalidBillingPeriod
+	}
+}
+
+// PricingUnit is what a plan's price is charged for.
+type PricingUnit string
+
+const (
+	UnitFlat     PricingUnit = "flat"
+	UnitPerSeat  PricingUnit = "per_seat"
+	UnitPerUsage PricingUnit = "per_usage"
+)
+
+var ErrInvalidPricingUnit = Invalid("invalid_pricing_unit", "invalid pricing unit")
+
+func NewPricingUnit(v string) (PricingUnit, error) {
+	switch u := PricingUnit(v); u {
+	case UnitFlat, UnitPerSeat, UnitPerUsage:
+		return u, nil
+	default:
+		return "", ErrInvalidPricingUnit
+	}
+}
+
+// Currency is an upper-case ISO 4217 code such as USD.
+type Currency string
+
+var currencyRe = regexp.MustCompile(`^[A-Z]{3}$`)
+
+var ErrInvalidCurrency = Invalid("invalid_currency", "invalid currency")
+
+func NewCurrency(v string) (Currency, error) {
+	v = strings.ToUpper(strings.TrimSpace(v))
+	if !currencyRe.MatchString(v) {
+		return "", ErrInvalidCurrency
+	}
+	return Currency(v), nil
+}
+
+// PricingPlan is one of the plans a product is sold under.
+type PricingPlan struct {
+	ID            ID
+	ProductID     ID
+	Name          string
+	BillingPeriod BillingPeriod
+	Currency      Currency
+	PriceCents    int64 // per billing period and per unit
+	IsFree        bool
+	PricingUnit   PricingUnit
+	UsageUnit     string // e.g. "1k requests"; per_usage plans only
+	Features      []string
+	SortOrder     int
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// MonthlyPriceCents normalizes the price to a monthly amount so plans can be compared.
+// ok is false for one-time and usage-based plans, which have no monthly equivalent.
+func (p *PricingPlan) MonthlyPriceCents() (cents int64, ok bool) {
+	if p.IsFree {
+		return 0, true
+	}
+	switch p.BillingPeriod {
+	case BillingMonthly:
+		return p.PriceCents, true
+	case BillingYearly:
+		return (p.PriceCents + 6) / 12, true
+	default:
+		return 0, false
+	}
+}
@@ -18,6 +18,9 @@ type Product struct {
 	AvgRating    *float64
 	TotalReviews int
 
+	// Tags, loaded only where responses include them
+	Tags []Tag
+
 	CreatedAt time.Time
 	UpdatedAt time.Time
 	DeletedAt *time.Time
@@ -0,0 +1,54 @@
+package domain
+
+import "time"
+
+// RatingDimension is an aspect of a product a reviewer can score separately from the overall rating.
+type RatingDimension string
+
+const (
+	DimensionEaseOfUse   RatingDimension = "ease_of_use"
+	DimensionDocs        RatingDimension = "docs"
+	DimensionSupport     RatingDimension = "support"
+	DimensionValue       RatingDimension = "value"
+	DimensionReliability RatingDimension = "reliability"
+)
+
+// RatingDimensions lists every dimension in display order.
+var RatingDimensions = []RatingDimension{
+	DimensionEaseOfUse,
+	DimensionDocs,
+	DimensionSupport,
+	DimensionValue,
+	DimensionReliability,
+}
+
+var ErrInvalidRatingDimension = Invalid("invalid_rating_dimension", "invalid rating dimension")
+
+func NewRatingDimension(v string) (RatingDimension, error) {
+	switch d := RatingDimension(v); d {
+	case DimensionEaseOfUse, DimensionDocs, DimensionSupport, DimensionValue, DimensionReliability:
+		return d, nil
+	default:
+		return "", ErrInvalidRatingDimension
+	}
+}
+
+// SubRatings are a review's optional per-dimension scores.
+type SubRatings map[RatingDimension]Rating
+
+// DimensionStat is the average score of one dimension across a product's published reviews.
+type DimensionStat struct {
+	Dimension RatingDimension
+	AvgRating float64
+	Count     int
+}
+
+// ProductStats is the rating breakdown of a product's published reviews.
+type ProductStats struct {
+	ProductID    ID
+	AvgRating    *float64
+	TotalReviews int
+	Histogram    [5]int // Histogram[i] counts reviews with i+1 stars
+	Dimensions   []DimensionStat
+	UpdatedAt    *time.Time
+}
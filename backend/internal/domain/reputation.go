@@ -0,0 +1,31 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// PublicProfile is what anyone can see about a reviewer.
+type PublicProfile struct {
+	UserID       ID
+	Handle       string
+	JoinedAt     time.Time
+	ReviewCount  int // published reviews
+	HelpfulVotes int // upvotes received on published reviews
+	Reputation   int
+	Badges       []ReviewerBadge
+	RefreshedAt  *time.Time // when Reputation was computed; nil until the user's reviews were first counted
+}
+
+// ReviewerBadge marks one of the top reviewers of a category by helpful votes received.
+type ReviewerBadge struct {
+	Category     Slug
+	CategoryName string
+	Rank         int
+	HelpfulVotes int
+}
+
+// Label is the badge's display text, e.g. "Top reviewer in CI/CD".
+func (b ReviewerBadge) Label() string {
+	return fmt.Sprintf("Top reviewer in %s", b.CategoryName)
+}
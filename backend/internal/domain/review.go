@@ -17,9 +17,11 @@ type Review struct {
 	Title        string
 	Body         string
 	Rating       Rating
+	SubRatings   SubRatings // optional, keyed by dimension
 	Status       ReviewStatus
 	HelpfulCount int
 	FlagCount    int
+	CommentCount int // published comments, including replies
 	Edited       bool
 
 	CreatedAt time.Time
@@ -43,3 +45,62 @@ func NewReview(productID, userID ID, rating Rating, body string, now time.Time)
 }
 
 func (r *Review) Touch(now time.Time) { r.UpdatedAt = now.UTC() }
+
+// ReviewModeration records a moderator's decision to move a review, or one of its comments, between statuses.
+type ReviewModeration struct {
+	ID              ID
+	ReviewID        ID
+	CommentID       *ID // set when the decision concerns a comment
+	ModeratorID     ID
+	ModeratorHandle string
+	FromStatus      ReviewStatus
+	ToStatus        ReviewStatus
+	Reason          string // optional for approvals, required for rejections
+	CreatedAt       time.Time
+}
+
+// ReviewRevision is a superseded version of a review, numbered from 1 (the original).
+// Changes summarizes how the version after it differs; Title and Body are only filled in
+// for viewers allowed to read the full history.
+type ReviewRevision struct {
+	ReviewID   ID
+	Revision   int
+	Title      string
+	Body       string
+	Rating     Rating
+	SubRatings SubRatings
+	Changes    ReviewChanges
+	CreatedAt  time.Time // when an edit replaced this version
+}
+
+// ReviewChanges is the public summary of one edit.
+type ReviewChanges struct {
+	TitleChanged      bool
+	BodyChanged       bool
+	SubRatingsChanged bool
+	RatingFrom        Rating
+	RatingTo          Rating
+}
+
+// RatingEventKind is why a review's rating started, stopped or changed counting towards product stats.
+type RatingEventKind string
+
+const (
+	RatingEventCreated     RatingEventKind = "created"
+	RatingEventEdited      RatingEventKind = "edited"
+	RatingEventDeleted     RatingEventKind = "deleted"
+	RatingEventPublished   RatingEventKind = "published"
+	RatingEventUnpublished RatingEventKind = "unpublished"
+)
+
+// RatingEvent records a change to the ratings counted in a product's stats.
+// FromRating is nil when the review starts counting, ToRating when it stops.
+type RatingEvent struct {
+	ID         ID
+	ReviewID   ID
+	ProductID  ID
+	Kind       RatingEventKind
+	FromRating *Rating
+	ToRating   *Rating
+	CreatedAt  time.Time
+}
@@ -0,0 +1,25 @@
+package domain
+
+// ProductSearchHit is a product matched by full-text search with its relevance and highlights.
+// Highlights are HTML-escaped with matched terms wrapped in <mark> tags.
+type ProductSearchHit struct {
+	Product       *Product
+	CompanyName   string
+	CompanySlug   string
+	Rank          float64
+	NameHighlight string
+	Snippet       string
+}
+
+// FacetCount is the number of search matches sharing one facet value.
+type FacetCount struct {
+	Value string
+	Count int64
+}
+
+// SearchFacets breaks down all matches of a query, ignoring the category and rating filters.
+// Rating buckets are the floor of the average rating ("0" also covers unrated products).
+type SearchFacets struct {
+	Categories    []FacetCount
+	RatingBuckets []FacetCount
+}
@@ -0,0 +1,16 @@
+package domain
+
+import "time"
+
+// Session is a single login; access tokens carry its ID and refresh tokens rotate within it.
+type Session struct {
+	ID         ID
+	UserID     ID
+	UserAgent  string // optional
+	IPAddress  string // optional
+	CreatedAt  time.Time
+	LastUsedAt time.Time
+	RevokedAt  *time.Time
+}
+
+func (s *Session) IsRevoked() bool { return s.RevokedAt != nil }
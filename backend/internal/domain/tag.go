@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Tag is a free-form label such as "open-source", "self-hosted" or "soc2".
+// Unlike categories a product can carry many tags.
+type Tag struct {
+	ID           ID
+	Slug         Slug   // normalized form used in filters
+	Name         string // display form, as first entered
+	ProductCount int    // live products carrying the tag; only set when listing tags
+	CreatedAt    time.Time
+}
+
+const maxTagLength = 50
+
+var tagSeparatorRe = regexp.MustCompile(`[\s_]+`)
+
+// NewTagSlug normalizes a tag as typed by a user ("Self Hosted") into its slug ("self-hosted")
+func NewTagSlug(v string) (Slug, error) {
+	v = strings.ToLower(strings.TrimSpace(v))
+	v = tagSeparatorRe.ReplaceAllString(v, "-")
+	if len(v) > maxTagLength || !slugRe.MatchString(v) {
+		return "", ErrInvalidTag
+	}
+	return Slug(v), nil
+}
+
+type TagSuggestionStatus string
+
+const (
+	TagSuggestionPending  TagSuggestionStatus = "pending"
+	TagSuggestionApproved TagSuggestionStatus = "approved"
+	TagSuggestionRejected TagSuggestionStatus = "rejected"
+)
+
+// TagSuggestion is a tag proposed for a product by any user. The product's
+// company editors approve (which attaches the tag) or reject it.
+type TagSuggestion struct {
+	ID         ID
+	ProductID  ID
+	UserID     ID
+	UserHandle string
+	TagSlug    Slug
+	TagName    string
+	Status     TagSuggestionStatus
+	ReviewedBy *ID
+	ReviewNote string // optional
+	ReviewedAt *time.Time
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
@@ -13,14 +13,15 @@ const (
 )
 
 type User struct {
-	ID        ID
-	Email     Email
-	Handle    string // public username
-	Role      UserRole
-	TenantID  *ID // keep optional multi-tenant path
-	CreatedAt time.Time
-	UpdatedAt time.Time
-	DeletedAt *time.Time
+	ID              ID
+	Email           Email
+	Handle          string // public username
+	Role            UserRole
+	EmailVerifiedAt *time.Time // nil until the user proves they own Email
+	TenantID        *ID        // keep optional multi-tenant path
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+	DeletedAt       *time.Time
 }
 
 func NewUser(email Email, handle string, now time.Time) (*User, error) {
@@ -38,3 +39,13 @@ func NewUser(email Email, handle string, now time.Time) (*User, error) {
 }
 
 func (u *User) Touch(now time.Time) { u.UpdatedAt = now.UTC() }
+
+func (u *User) IsEmailVerified() bool { return u.EmailVerifiedAt != nil }
+
+// Actor is the authenticated user on whose behalf a service call is made.
+type Actor struct {
+	UserID ID
+	Role   UserRole
+}
+
+func (a Actor) IsAdmin() bool { return a.Role == RoleAdmin }
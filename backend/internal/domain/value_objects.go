@@ -40,13 +40,16 @@ func NewRating(n int) (Rating, error) {
 	return Rating(n), nil
 }
 
-// ProductCategory (extend freely)
+// ProductCategory is the slug of a category; categories live in the database
+// (see Category) so new ones can be added without a deploy.
 type ProductCategory string
 
-const (
-	CategoryHosting        ProductCategory = "hosting"
-	CategoryFeatureToggles ProductCategory = "feature_toggles"
-	CategoryCI             ProductCategory = "ci_cd"
-	CategoryObservability  ProductCategory = "observability"
-	CategoryOther          ProductCategory = "other"
-)
+var categorySlugRe = regexp.MustCompile(`^[a-z0-9]+(?:[_-][a-z0-9]+)*$`)
+
+func NewProductCategory(v string) (ProductCategory, error) {
+	v = strings.TrimSpace(strings.ToLower(v))
+	if !categorySlugRe.MatchString(v) {
+		return "", ErrInvalidCategory
+	}
+	return ProductCategory(v), nil
+}
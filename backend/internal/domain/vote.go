@@ -0,0 +1,46 @@
+package domain
+
+import "time"
+
+// VoteDirection is a user's vote on a review; VoteNone means no vote.
+type VoteDirection string
+
+const (
+	VoteUp   VoteDirection = "up"
+	VoteDown VoteDirection = "down"
+	VoteNone VoteDirection = ""
+)
+
+// FlagReason is the reason code a user gives when flagging a review.
+type FlagReason string
+
+const (
+	FlagSpam               FlagReason = "spam"
+	FlagOffensive          FlagReason = "offensive"
+	FlagOffTopic           FlagReason = "off_topic"
+	FlagConflictOfInterest FlagReason = "conflict_of_interest"
+	FlagFake               FlagReason = "fake"
+	FlagOther              FlagReason = "other"
+)
+
+var ErrInvalidFlagReason = Invalid("invalid_flag_reason", "invalid flag reason")
+
+func NewFlagReason(v string) (FlagReason, error) {
+	switch r := FlagReason(v); r {
+	case FlagSpam, FlagOffensive, FlagOffTopic, FlagConflictOfInterest, FlagFake, FlagOther:
+		return r, nil
+	default:
+		return "", ErrInvalidFlagReason
+	}
+}
+
+// ReviewFlag is a single user's report against a review.
+type ReviewFlag struct {
+	ReviewID   ID
+	UserID     ID
+	UserHandle string
+	Reason     FlagReason
+	Note       string // optional
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
@@ -108,6 +108,29 @@ func (q *Queries) GetCompanyBySlug(ctx context.Context, slug string) (Company, e
 	return i, err
 }
 
+const getCompanyForUpdate = `-- name: GetCompanyForUpdate :one
+SELECT id, name, website, slug, logo_url, created_at, updated_at, deleted_at FROM companies
+WHERE id = $1 AND deleted_at IS NULL
+FOR UPDATE
+`
+
+// Serializes membership changes of one company
+func (q *Queries) GetCompanyForUpdate(ctx context.Context, id uuid.UUID) (Company, error) {
+	row := q.db.QueryRow(ctx, getCompanyForUpdate, id)
+	var i Company
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Website,
+		&i.Slug,
+		&i.LogoUrl,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
 const hardDeleteCompany = `-- name: HardDeleteCompany :exec
 DELETE FROM companies
 WHERE id = $1
@@ -118,47 +141,6 @@ func (q *Queries) HardDeleteCompany(ctx context.Context, id uuid.UUID) error {
 	return err
 }
 
-const listCompanies = `-- name: ListCompanies :many
-SELECT id, name, website, slug, logo_url, created_at, updated_at, deleted_at FROM companies
-WHERE deleted_at IS NULL
-ORDER BY created_at DESC
-LIMIT $1 OFFSET $2
-`
-
-type ListCompaniesParams struct {
-	Limit  int32 `json:"limit"`
-	Offset int32 `json:"offset"`
-}
-
-func (q *Queries) ListCompanies(ctx context.Context, arg ListCompaniesParams) ([]Company, error) {
-	rows, err := q.db.Query(ctx, listCompanies, arg.Limit, arg.Offset)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-	var items []Company
-	for rows.Next() {
-		var i Company
-		if err := rows.Scan(
-			&i.ID,
-			&i.Name,
-			&i.Website,
-			&i.Slug,
-			&i.LogoUrl,
-			&i.CreatedAt,
-			&i.UpdatedAt,
-			&i.DeletedAt,
-		); err != nil {
-			return nil, err
-		}
-		items = append(items, i)
-	}
-	if err := rows.Err(); err != nil {
-		return nil, err
-	}
-	return items, nil
-}
-
 const searchCompanies = `-- name: SearchCompanies :many
 SELECT id, name, website, slug, logo_url, created_at, updated_at, deleted_at FROM companies
 WHERE deleted_at IS NULL
@@ -51,6 +51,19 @@ func (q *Queries) CreateCredential(ctx context.Context, arg CreateCredentialPara
 	return i, err
 }
 
+const deleteUserCredentials = `-- name: DeleteUserCredentials :execrows
+DELETE FROM credentials
+WHERE user_id = $1
+`
+
+func (q *Queries) DeleteUserCredentials(ctx context.Context, userID uuid.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteUserCredentials, userID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const getCredential = `-- name: GetCredential :one
 SELECT user_id, provider, identifier, secret_hash, created_at, updated_at, deleted_at FROM credentials
 WHERE user_id = $1 AND provider = $2 AND deleted_at IS NULL
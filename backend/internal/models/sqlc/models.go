@@ -9,6 +9,42 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+type AccountToken struct {
+	ID        uuid.UUID          `json:"id"`
+	UserID    uuid.UUID          `json:"user_id"`
+	Purpose   string             `json:"purpose"`
+	TokenHash string             `json:"token_hash"`
+	Email     string             `json:"email"`
+	ExpiresAt pgtype.Timestamptz `json:"expires_at"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	UsedAt    pgtype.Timestamptz `json:"used_at"`
+}
+
+type AuditEvent struct {
+	ID         uuid.UUID          `json:"id"`
+	ActorID    *uuid.UUID         `json:"actor_id"`
+	Action     string             `json:"action"`
+	EntityType string             `json:"entity_type"`
+	EntityID   uuid.UUID          `json:"entity_id"`
+	Before     []byte             `json:"before"`
+	After      []byte             `json:"after"`
+	RequestID  *string            `json:"request_id"`
+	IpAddress  *string            `json:"ip_address"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+}
+
+type Category struct {
+	ID          uuid.UUID          `json:"id"`
+	Slug        string             `json:"slug"`
+	Name        string             `json:"name"`
+	Description *string            `json:"description"`
+	Icon        *string            `json:"icon"`
+	ParentID    *uuid.UUID         `json:"parent_id"`
+	SortOrder   int32              `json:"sort_order"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
+}
+
 type Company struct {
 	ID        uuid.UUID          `json:"id"`
 	Name      string             `json:"name"`
@@ -20,6 +56,28 @@ type Company struct {
 	DeletedAt pgtype.Timestamptz `json:"deleted_at"`
 }
 
+type CompanyClaim struct {
+	ID                 uuid.UUID          `json:"id"`
+	CompanyID          uuid.UUID          `json:"company_id"`
+	UserID             uuid.UUID          `json:"user_id"`
+	Status             string             `json:"status"`
+	VerificationMethod string             `json:"verification_method"`
+	Evidence           *string            `json:"evidence"`
+	ReviewedBy         *uuid.UUID         `json:"reviewed_by"`
+	ReviewNote         *string            `json:"review_note"`
+	ReviewedAt         pgtype.Timestamptz `json:"reviewed_at"`
+	CreatedAt          pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt          pgtype.Timestamptz `json:"updated_at"`
+}
+
+type CompanyMember struct {
+	CompanyID uuid.UUID          `json:"company_id"`
+	UserID    uuid.UUID          `json:"user_id"`
+	Role      string             `json:"role"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+}
+
 type Credential struct {
 	UserID     uuid.UUID          `json:"user_id"`
 	Provider   string             `json:"provider"`
@@ -30,6 +88,30 @@ type Credential struct {
 	DeletedAt  pgtype.Timestamptz `json:"deleted_at"`
 }
 
+type LoginThrottle struct {
+	Scope         string             `json:"scope"`
+	Key           string             `json:"key"`
+	Failures      int32              `json:"failures"`
+	LastFailureAt pgtype.Timestamptz `json:"last_failure_at"`
+	LockedUntil   pgtype.Timestamptz `json:"locked_until"`
+}
+
+type PricingPlan struct {
+	ID            uuid.UUID          `json:"id"`
+	ProductID     uuid.UUID          `json:"product_id"`
+	Name          string             `json:"name"`
+	BillingPeriod string             `json:"billing_period"`
+	Currency      string             `json:"currency"`
+	PriceCents    int64              `json:"price_cents"`
+	IsFree        bool               `json:"is_free"`
+	PricingUnit   string             `json:"pricing_unit"`
+	UsageUnit     *string            `json:"usage_unit"`
+	Features      []string           `json:"features"`
+	SortOrder     int32              `json:"sort_order"`
+	CreatedAt     pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt     pgtype.Timestamptz `json:"updated_at"`
+}
+
 type Product struct {
 	ID           uuid.UUID          `json:"id"`
 	CompanyID    uuid.UUID          `json:"company_id"`
@@ -47,6 +129,57 @@ type Product struct {
 	DeletedAt    pgtype.Timestamptz `json:"deleted_at"`
 }
 
+type ProductDimensionStat struct {
+	ProductID   uuid.UUID          `json:"product_id"`
+	Dimension   string             `json:"dimension"`
+	AvgRating   float64            `json:"avg_rating"`
+	RatingCount int32              `json:"rating_count"`
+	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
+}
+
+type ProductLeaderboard struct {
+	TimeWindow    string             `json:"time_window"`
+	ProductID     uuid.UUID          `json:"product_id"`
+	ReviewCount   int32              `json:"review_count"`
+	PositiveCount int32              `json:"positive_count"`
+	AvgRating     float64            `json:"avg_rating"`
+	BayesianScore float64            `json:"bayesian_score"`
+	WilsonScore   float64            `json:"wilson_score"`
+	RefreshedAt   pgtype.Timestamptz `json:"refreshed_at"`
+}
+
+type ProductRatingStat struct {
+	ProductID uuid.UUID          `json:"product_id"`
+	Star1     int32              `json:"star_1"`
+	Star2     int32              `json:"star_2"`
+	Star3     int32              `json:"star_3"`
+	Star4     int32              `json:"star_4"`
+	Star5     int32              `json:"star_5"`
+	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+}
+
+type ProductSearchDocument struct {
+	ProductID uuid.UUID          `json:"product_id"`
+	Document  interface{}        `json:"document"`
+	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+}
+
+type ProductTag struct {
+	ProductID uuid.UUID          `json:"product_id"`
+	TagID     uuid.UUID          `json:"tag_id"`
+	AddedBy   *uuid.UUID         `json:"added_by"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+type RefreshToken struct {
+	ID        uuid.UUID          `json:"id"`
+	SessionID uuid.UUID          `json:"session_id"`
+	TokenHash string             `json:"token_hash"`
+	ExpiresAt pgtype.Timestamptz `json:"expires_at"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	UsedAt    pgtype.Timestamptz `json:"used_at"`
+}
+
 type Review struct {
 	ID            uuid.UUID          `json:"id"`
 	ProductID     uuid.UUID          `json:"product_id"`
@@ -64,12 +197,149 @@ type Review struct {
 	DeletedAt     pgtype.Timestamptz `json:"deleted_at"`
 }
 
-type User struct {
+type ReviewComment struct {
 	ID        uuid.UUID          `json:"id"`
-	Email     string             `json:"email"`
-	Handle    string             `json:"handle"`
-	Role      string             `json:"role"`
+	ReviewID  uuid.UUID          `json:"review_id"`
+	ParentID  *uuid.UUID         `json:"parent_id"`
+	UserID    uuid.UUID          `json:"user_id"`
+	Body      string             `json:"body"`
+	Status    string             `json:"status"`
+	Official  bool               `json:"official"`
+	CompanyID *uuid.UUID         `json:"company_id"`
+	FlagCount int32              `json:"flag_count"`
+	Edited    bool               `json:"edited"`
 	CreatedAt pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
 	DeletedAt pgtype.Timestamptz `json:"deleted_at"`
 }
+
+type ReviewCommentFlag struct {
+	CommentID uuid.UUID          `json:"comment_id"`
+	UserID    uuid.UUID          `json:"user_id"`
+	Reason    string             `json:"reason"`
+	Note      *string            `json:"note"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+}
+
+type ReviewFlag struct {
+	ReviewID  uuid.UUID          `json:"review_id"`
+	UserID    uuid.UUID          `json:"user_id"`
+	Reason    string             `json:"reason"`
+	Note      *string            `json:"note"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+}
+
+type ReviewModeration struct {
+	ID          uuid.UUID          `json:"id"`
+	ReviewID    uuid.UUID          `json:"review_id"`
+	ModeratorID uuid.UUID          `json:"moderator_id"`
+	FromStatus  string             `json:"from_status"`
+	ToStatus    string             `json:"to_status"`
+	Reason      *string            `json:"reason"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+	CommentID   *uuid.UUID         `json:"comment_id"`
+}
+
+type ReviewRatingEvent struct {
+	ID         uuid.UUID          `json:"id"`
+	ReviewID   uuid.UUID          `json:"review_id"`
+	ProductID  uuid.UUID          `json:"product_id"`
+	Kind       string             `json:"kind"`
+	FromRating *int32             `json:"from_rating"`
+	ToRating   *int32             `json:"to_rating"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+}
+
+type ReviewRevision struct {
+	ID         uuid.UUID          `json:"id"`
+	ReviewID   uuid.UUID          `json:"review_id"`
+	Revision   int32              `json:"revision"`
+	Title      *string            `json:"title"`
+	Body       string             `json:"body"`
+	Rating     int32              `json:"rating"`
+	SubRatings []byte             `json:"sub_ratings"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+}
+
+type ReviewSubRating struct {
+	ReviewID  uuid.UUID          `json:"review_id"`
+	Dimension string             `json:"dimension"`
+	Rating    int32              `json:"rating"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+type ReviewVote struct {
+	ReviewID  uuid.UUID          `json:"review_id"`
+	UserID    uuid.UUID          `json:"user_id"`
+	Vote      string             `json:"vote"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+}
+
+type ReviewerBadge struct {
+	UserID       uuid.UUID          `json:"user_id"`
+	Category     string             `json:"category"`
+	Rank         int32              `json:"rank"`
+	HelpfulVotes int32              `json:"helpful_votes"`
+	RefreshedAt  pgtype.Timestamptz `json:"refreshed_at"`
+}
+
+type RevokedAccessToken struct {
+	Jti       uuid.UUID          `json:"jti"`
+	UserID    uuid.UUID          `json:"user_id"`
+	ExpiresAt pgtype.Timestamptz `json:"expires_at"`
+	RevokedAt pgtype.Timestamptz `json:"revoked_at"`
+}
+
+type Session struct {
+	ID         uuid.UUID          `json:"id"`
+	UserID     uuid.UUID          `json:"user_id"`
+	UserAgent  *string            `json:"user_agent"`
+	IpAddress  *string            `json:"ip_address"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+	LastUsedAt pgtype.Timestamptz `json:"last_used_at"`
+	RevokedAt  pgtype.Timestamptz `json:"revoked_at"`
+}
+
+type Tag struct {
+	ID        uuid.UUID          `json:"id"`
+	Slug      string             `json:"slug"`
+	Name      string             `json:"name"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+type TagSuggestion struct {
+	ID         uuid.UUID          `json:"id"`
+	ProductID  uuid.UUID          `json:"product_id"`
+	UserID     uuid.UUID          `json:"user_id"`
+	TagSlug    string             `json:"tag_slug"`
+	TagName    string             `json:"tag_name"`
+	Status     string             `json:"status"`
+	ReviewedBy *uuid.UUID         `json:"reviewed_by"`
+	ReviewNote *string            `json:"review_note"`
+	ReviewedAt pgtype.Timestamptz `json:"reviewed_at"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt  pgtype.Timestamptz `json:"updated_at"`
+}
+
+type User struct {
+	ID              uuid.UUID          `json:"id"`
+	Email           string             `json:"email"`
+	Handle          string             `json:"handle"`
+	Role            string             `json:"role"`
+	CreatedAt       pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt       pgtype.Timestamptz `json:"updated_at"`
+	DeletedAt       pgtype.Timestamptz `json:"deleted_at"`
+	EmailVerifiedAt pgtype.Timestamptz `json:"email_verified_at"`
+}
+
+type UserReputation struct {
+	UserID         uuid.UUID          `json:"user_id"`
+	PublishedCount int32              `json:"published_count"`
+	RejectedCount  int32              `json:"rejected_count"`
+	HelpfulVotes   int32              `json:"helpful_votes"`
+	Reputation     int32              `json:"reputation"`
+	RefreshedAt    pgtype.Timestamptz `json:"refreshed_at"`
+}
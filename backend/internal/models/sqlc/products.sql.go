@@ -12,18 +12,6 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
-const countProducts = `-- name: CountProducts :one
-SELECT COUNT(*) FROM products
-WHERE deleted_at IS NULL
-`
-
-func (q *Queries) CountProducts(ctx context.Context) (int64, error) {
-	row := q.db.QueryRow(ctx, countProducts)
-	var count int64
-	err := row.Scan(&count)
-	return count, err
-}
-
 const countProductsByCompany = `-- name: CountProductsByCompany :one
 SELECT COUNT(*) FROM products
 WHERE company_id = $1 AND deleted_at IS NULL
@@ -232,92 +220,20 @@ func (q *Queries) HardDeleteProduct(ctx context.Context, id uuid.UUID) error {
 	return err
 }
 
-const listProducts = `-- name: ListProducts :many
-SELECT p.id, p.company_id, p.name, p.slug, p.category, p.short_tagline, p.description, p.homepage_url, p.docs_url, p.avg_rating, p.total_reviews, p.created_at, p.updated_at, p.deleted_at, c.name as company_name, c.slug as company_slug
-FROM products p
-JOIN companies c ON p.company_id = c.id
-WHERE p.deleted_at IS NULL AND c.deleted_at IS NULL
-ORDER BY p.created_at DESC
-LIMIT $1 OFFSET $2
-`
-
-type ListProductsParams struct {
-	Limit  int32 `json:"limit"`
-	Offset int32 `json:"offset"`
-}
-
-type ListProductsRow struct {
-	ID           uuid.UUID          `json:"id"`
-	CompanyID    uuid.UUID          `json:"company_id"`
-	Name         string             `json:"name"`
-	Slug         string             `json:"slug"`
-	Category     string             `json:"category"`
-	ShortTagline *string            `json:"short_tagline"`
-	Description  *string            `json:"description"`
-	HomepageUrl  *string            `json:"homepage_url"`
-	DocsUrl      *string            `json:"docs_url"`
-	AvgRating    *float64           `json:"avg_rating"`
-	TotalReviews int32              `json:"total_reviews"`
-	CreatedAt    pgtype.Timestamptz `json:"created_at"`
-	UpdatedAt    pgtype.Timestamptz `json:"updated_at"`
-	DeletedAt    pgtype.Timestamptz `json:"deleted_at"`
-	CompanyName  string             `json:"company_name"`
-	CompanySlug  string             `json:"company_slug"`
-}
-
-func (q *Queries) ListProducts(ctx context.Context, arg ListProductsParams) ([]ListProductsRow, error) {
-	rows, err := q.db.Query(ctx, listProducts, arg.Limit, arg.Offset)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-	var items []ListProductsRow
-	for rows.Next() {
-		var i ListProductsRow
-		if err := rows.Scan(
-			&i.ID,
-			&i.CompanyID,
-			&i.Name,
-			&i.Slug,
-			&i.Category,
-			&i.ShortTagline,
-			&i.Description,
-			&i.HomepageUrl,
-			&i.DocsUrl,
-			&i.AvgRating,
-			&i.TotalReviews,
-			&i.CreatedAt,
-			&i.UpdatedAt,
-			&i.DeletedAt,
-			&i.CompanyName,
-			&i.CompanySlug,
-		); err != nil {
-			return nil, err
-		}
-		items = append(items, i)
-	}
-	if err := rows.Err(); err != nil {
-		return nil, err
-	}
-	return items, nil
-}
-
-const listProductsByCategory = `-- name: ListProductsByCategory :many
+const listProductsForComparison = `-- name: ListProductsForComparison :many
 SELECT p.id, p.company_id, p.name, p.slug, p.category, p.short_tagline, p.description, p.homepage_url, p.docs_url, p.avg_rating, p.total_reviews, p.created_at, p.updated_at, p.deleted_at, c.name as company_name, c.slug as company_slug
 FROM products p
 JOIN companies c ON p.company_id = c.id
-WHERE p.category = $1 AND p.deleted_at IS NULL AND c.deleted_at IS NULL
-ORDER BY p.created_at DESC
-LIMIT $2 OFFSET $3
+WHERE (p.id = ANY($1::uuid[]) OR p.slug = ANY($2::text[]))
+AND p.deleted_at IS NULL AND c.deleted_at IS NULL
 `
 
-type ListProductsByCategoryParams struct {
-	Category string `json:"category"`
-	Limit    int32  `json:"limit"`
-	Offset   int32  `json:"offset"`
+type ListProductsForComparisonParams struct {
+	Ids   []uuid.UUID `json:"ids"`
+	Slugs []string    `json:"slugs"`
 }
 
-type ListProductsByCategoryRow struct {
+type ListProductsForComparisonRow struct {
 	ID           uuid.UUID          `json:"id"`
 	CompanyID    uuid.UUID          `json:"company_id"`
 	Name         string             `json:"name"`
@@ -336,15 +252,15 @@ type ListProductsByCategoryRow struct {
 	CompanySlug  string             `json:"company_slug"`
 }
 
-func (q *Queries) ListProductsByCategory(ctx context.Context, arg ListProductsByCategoryParams) ([]ListProductsByCategoryRow, error) {
-	rows, err := q.db.Query(ctx, listProductsByCategory, arg.Category, arg.Limit, arg.Offset)
+func (q *Queries) ListProductsForComparison(ctx context.Context, arg ListProductsForComparisonParams) ([]ListProductsForComparisonRow, error) {
+	rows, err := q.db.Query(ctx, listProductsForComparison, arg.Ids, arg.Slugs)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []ListProductsByCategoryRow
+	var items []ListProductsForComparisonRow
 	for rows.Next() {
-		var i ListProductsByCategoryRow
+		var i ListProductsForComparisonRow
 		if err := rows.Scan(
 			&i.ID,
 			&i.CompanyID,
@@ -373,76 +289,15 @@ func (q *Queries) ListProductsByCategory(ctx context.Context, arg ListProductsBy
 	return items, nil
 }
 
-const searchProducts = `-- name: SearchProducts :many
-SELECT p.id, p.company_id, p.name, p.slug, p.category, p.short_tagline, p.description, p.homepage_url, p.docs_url, p.avg_rating, p.total_reviews, p.created_at, p.updated_at, p.deleted_at, c.name as company_name, c.slug as company_slug
-FROM products p
-JOIN companies c ON p.company_id = c.id
-WHERE p.deleted_at IS NULL AND c.deleted_at IS NULL
-AND (p.name ILIKE $1 OR p.short_tagline ILIKE $1 OR c.name ILIKE $1)
-ORDER BY p.name ASC
-LIMIT $2 OFFSET $3
+const lockProduct = `-- name: LockProduct :exec
+SELECT id FROM products
+WHERE id = $1
+FOR UPDATE
 `
 
-type SearchProductsParams struct {
-	Name   string `json:"name"`
-	Limit  int32  `json:"limit"`
-	Offset int32  `json:"offset"`
-}
-
-type SearchProductsRow struct {
-	ID           uuid.UUID          `json:"id"`
-	CompanyID    uuid.UUID          `json:"company_id"`
-	Name         string             `json:"name"`
-	Slug         string             `json:"slug"`
-	Category     string             `json:"category"`
-	ShortTagline *string            `json:"short_tagline"`
-	Description  *string            `json:"description"`
-	HomepageUrl  *string            `json:"homepage_url"`
-	DocsUrl      *string            `json:"docs_url"`
-	AvgRating    *float64           `json:"avg_rating"`
-	TotalReviews int32              `json:"total_reviews"`
-	CreatedAt    pgtype.Timestamptz `json:"created_at"`
-	UpdatedAt    pgtype.Timestamptz `json:"updated_at"`
-	DeletedAt    pgtype.Timestamptz `json:"deleted_at"`
-	CompanyName  string             `json:"company_name"`
-	CompanySlug  string             `json:"company_slug"`
-}
-
-func (q *Queries) SearchProducts(ctx context.Context, arg SearchProductsParams) ([]SearchProductsRow, error) {
-	rows, err := q.db.Query(ctx, searchProducts, arg.Name, arg.Limit, arg.Offset)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-	var items []SearchProductsRow
-	for rows.Next() {
-		var i SearchProductsRow
-		if err := rows.Scan(
-			&i.ID,
-			&i.CompanyID,
-			&i.Name,
-			&i.Slug,
-			&i.Category,
-			&i.ShortTagline,
-			&i.Description,
-			&i.HomepageUrl,
-			&i.DocsUrl,
-			&i.AvgRating,
-			&i.TotalReviews,
-			&i.CreatedAt,
-			&i.UpdatedAt,
-			&i.DeletedAt,
-			&i.CompanyName,
-			&i.CompanySlug,
-		); err != nil {
-			return nil, err
-		}
-		items = append(items, i)
-	}
-	if err := rows.Err(); err != nil {
-		return nil, err
-	}
-	return items, nil
+func (q *Queries) LockProduct(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.Exec(ctx, lockProduct, id)
+	return err
 }
 
 const softDeleteProduct = `-- name: SoftDeleteProduct :exec
@@ -0,0 +1,24 @@
+-- name: CreateAccountToken :one
+INSERT INTO account_tokens (
+    id, user_id, purpose, token_hash, email, expires_at, created_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+) RETURNING *;
+
+-- name: GetAccountTokenByHash :one
+SELECT * FROM account_tokens
+WHERE token_hash = $1;
+
+-- name: UseAccountToken :execrows
+UPDATE account_tokens
+SET used_at = $2
+WHERE id = $1 AND used_at IS NULL;
+
+-- name: UseUserAccountTokens :execrows
+UPDATE account_tokens
+SET used_at = $3
+WHERE user_id = $1 AND purpose = $2 AND used_at IS NULL;
+
+-- name: DeleteExpiredAccountTokens :execrows
+DELETE FROM account_tokens
+WHERE expires_at < $1;
@@ -0,0 +1,21 @@
+-- name: CreateAuditEvent :exec
+INSERT INTO audit_events (
+    id, actor_id, action, entity_type, entity_id, before, after, request_id, ip_address, created_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10
+);
+
+-- name: RedactUserAuditEvents :execrows
+-- Overwrites the email and handle recorded in a user's audit events, e.g. with the values
+-- of their deleted-account tombstone; fields an event did not record are left out
+UPDATE audit_events
+SET
+    before = before || jsonb_strip_nulls(jsonb_build_object(
+        'email', CASE WHEN before ? 'email' THEN to_jsonb(sqlc.arg(email)::text) END,
+        'handle', CASE WHEN before ? 'handle' THEN to_jsonb(sqlc.arg(handle)::text) END
+    )),
+    after = after || jsonb_strip_nulls(jsonb_build_object(
+        'email', CASE WHEN after ? 'email' THEN to_jsonb(sqlc.arg(email)::text) END,
+        'handle', CASE WHEN after ? 'handle' THEN to_jsonb(sqlc.arg(handle)::text) END
+    ))
+WHERE entity_type = 'user' AND entity_id = sqlc.arg(user_id)::uuid;
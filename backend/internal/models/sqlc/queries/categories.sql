@@ -0,0 +1,47 @@
+-- name: CreateCategory :one
+INSERT INTO categories (
+    id, slug, name, description, icon, parent_id, sort_order, created_at, updated_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9
+)
+RETURNING *;
+
+-- name: GetCategory :one
+SELECT * FROM categories
+WHERE id = $1;
+
+-- name: GetCategoryBySlug :one
+SELECT * FROM categories
+WHERE slug = $1;
+
+-- name: ListCategories :many
+SELECT c.*,
+  (SELECT COUNT(*) FROM products p WHERE p.category = c.slug AND p.deleted_at IS NULL) AS product_count
+FROM categories c
+ORDER BY c.sort_order ASC, c.name ASC;
+
+-- name: UpdateCategory :one
+UPDATE categories
+SET 
+    slug = $2,
+    name = $3,
+    description = $4,
+    icon = $5,
+    parent_id = $6,
+    sort_order = $7,
+    updated_at = $8
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteCategory :exec
+DELETE FROM categories
+WHERE id = $1;
+
+-- name: CountCategoryChildren :one
+SELECT COUNT(*) FROM categories
+WHERE parent_id = $1;
+
+-- name: CountProductsInCategory :one
+-- Soft-deleted products still reference their category, so they count too
+SELECT COUNT(*) FROM products
+WHERE category = $1;
@@ -9,15 +9,15 @@ INSERT INTO companies (
 SELECT * FROM companies
 WHERE id = $1 AND deleted_at IS NULL;
 
--- name: GetCompanyBySlug :one
+-- name: GetCompanyForUpdate :one
+-- Serializes membership changes of one company
 SELECT * FROM companies
-WHERE slug = $1 AND deleted_at IS NULL;
+WHERE id = $1 AND deleted_at IS NULL
+FOR UPDATE;
 
--- name: ListCompanies :many
+-- name: GetCompanyBySlug :one
 SELECT * FROM companies
-WHERE deleted_at IS NULL
-ORDER BY created_at DESC
-LIMIT $1 OFFSET $2;
+WHERE slug = $1 AND deleted_at IS NULL;
 
 -- name: SearchCompanies :many
 SELECT * FROM companies
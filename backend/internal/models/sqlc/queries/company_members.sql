@@ -0,0 +1,72 @@
+-- name: UpsertCompanyMember :one
+INSERT INTO company_members (
+  company_id, user_id, role, created_at, updated_at
+) VALUES (
+  $1, $2, $3, $4, $5
+)
+ON CONFLICT (company_id, user_id) DO UPDATE
+SET role = EXCLUDED.role,
+    updated_at = EXCLUDED.updated_at
+RETURNING *;
+
+-- name: GetCompanyMember :one
+SELECT * FROM company_members
+WHERE company_id = $1 AND user_id = $2;
+
+-- name: ListCompanyMembers :many
+SELECT cm.*, u.handle AS user_handle
+FROM company_members cm
+JOIN users u ON cm.user_id = u.id
+WHERE cm.company_id = $1
+ORDER BY cm.role DESC, cm.created_at ASC;
+
+-- name: ListUserCompanyMemberships :many
+SELECT * FROM company_members
+WHERE user_id = $1
+ORDER BY company_id;
+
+-- name: DeleteCompanyMember :execrows
+DELETE FROM company_members
+WHERE company_id = $1 AND user_id = $2;
+
+-- name: CountCompanyOwners :one
+SELECT COUNT(*) FROM company_members
+WHERE company_id = $1 AND role = 'owner';
+
+-- name: CreateCompanyClaim :one
+INSERT INTO company_claims (
+  id, company_id, user_id, status, verification_method, evidence, created_at, updated_at
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $8
+)
+RETURNING *;
+
+-- name: GetCompanyClaim :one
+SELECT * FROM company_claims
+WHERE id = $1;
+
+-- name: GetPendingCompanyClaim :one
+SELECT * FROM company_claims
+WHERE company_id = $1 AND user_id = $2 AND status = 'pending';
+
+-- name: ListCompanyClaimsByStatus :many
+SELECT cc.*, c.name AS company_name, u.handle AS user_handle, u.email AS user_email
+FROM company_claims cc
+JOIN companies c ON cc.company_id = c.id
+JOIN users u ON cc.user_id = u.id
+WHERE cc.status = $1
+ORDER BY cc.created_at ASC
+LIMIT $2 OFFSET $3;
+
+-- name: CountCompanyClaimsByStatus :one
+SELECT COUNT(*) FROM company_claims
+WHERE status = $1;
+
+-- name: ResolveCompanyClaim :one
+UPDATE company_claims
+SET status = $2,
+    reviewed_by = $3,
+    review_note = $4,
+    reviewed_at = $5
+WHERE id = $1 AND status = 'pending'
+RETURNING *;
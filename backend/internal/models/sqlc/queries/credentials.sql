@@ -30,3 +30,7 @@ WHERE user_id = $1 AND provider = $2 AND deleted_at IS NULL;
 -- name: HardDeleteCredential :exec
 DELETE FROM credentials
 WHERE user_id = $1 AND provider = $2;
+
+-- name: DeleteUserCredentials :execrows
+DELETE FROM credentials
+WHERE user_id = $1;
@@ -0,0 +1,90 @@
+-- Leaderboard scores are precomputed per time window by RefreshLeaderboardWindow.
+-- bayesian_score shrinks each product's average towards the window-wide mean, weighted by prior_weight reviews.
+-- wilson_score is the lower bound of the 95% Wilson interval on the share of 4-5 star reviews.
+-- A category filter matches the category and all of its descendants, like ListProducts; the
+-- recursive CTE uses UNION so a cycle in the hierarchy cannot make it loop.
+
+-- name: DeleteLeaderboardWindow :exec
+DELETE FROM product_leaderboard
+WHERE time_window = $1;
+
+-- name: LockLeaderboardRefresh :exec
+SELECT pg_advisory_xact_lock(hashtext('product_leaderboard'));
+
+-- name: RefreshLeaderboardWindow :exec
+WITH windowed AS (
+  SELECT r.product_id, r.rating
+  FROM reviews r
+  JOIN products p ON r.product_id = p.id
+  WHERE r.deleted_at IS NULL AND r.status = 'published' AND p.deleted_at IS NULL
+  AND (sqlc.narg(since)::timestamptz IS NULL OR r.created_at >= sqlc.narg(since)::timestamptz)
+), prior AS (
+  SELECT COALESCE(AVG(rating), 0)::float8 AS mean
+  FROM windowed
+), totals AS (
+  SELECT product_id,
+    COUNT(*)::int AS review_count,
+    (COUNT(*) FILTER (WHERE rating >= 4))::int AS positive_count,
+    AVG(rating)::float8 AS avg_rating
+  FROM windowed
+  GROUP BY product_id
+)
+INSERT INTO product_leaderboard (
+    time_window, product_id, review_count, positive_count, avg_rating, bayesian_score, wilson_score, refreshed_at
+)
+SELECT
+  sqlc.arg(time_window)::text,
+  t.product_id,
+  t.review_count,
+  t.positive_count,
+  t.avg_rating,
+  (sqlc.arg(prior_weight)::float8 * prior.mean + t.review_count * t.avg_rating) / (sqlc.arg(prior_weight)::float8 + t.review_count),
+  (t.positive_count::float8 / t.review_count + 1.9208 / t.review_count
+    - 1.96 * sqrt((t.positive_count::float8 / t.review_count) * (1 - t.positive_count::float8 / t.review_count) / t.review_count + 0.9604 / (t.review_count::float8 * t.review_count)))
+    / (1 + 3.8416 / t.review_count),
+  NOW()
+FROM totals t
+CROSS JOIN prior;
+
+-- name: ListLeaderboard :many
+SELECT l.product_id, l.review_count, l.positive_count, l.avg_rating, l.bayesian_score, l.wilson_score, l.refreshed_at,
+  p.name AS product_name, p.slug AS product_slug, p.category, c.name AS company_name, c.slug AS company_slug
+FROM product_leaderboard l
+JOIN products p ON l.product_id = p.id
+JOIN companies c ON p.company_id = c.id
+WHERE l.time_window = sqlc.arg(time_window)::text
+AND p.deleted_at IS NULL AND c.deleted_at IS NULL
+AND (sqlc.narg(category)::text IS NULL OR p.category IN (
+  WITH RECURSIVE subtree AS (
+    SELECT id, slug FROM categories WHERE categories.slug = sqlc.narg(category)::text
+    UNION
+    SELECT ch.id, ch.slug FROM categories ch JOIN subtree st ON ch.parent_id = st.id
+  )
+  SELECT slug FROM subtree
+))
+ORDER BY
+  CASE WHEN sqlc.arg(score)::text = 'wilson' THEN l.wilson_score ELSE l.bayesian_score END DESC,
+  l.review_count DESC,
+  p.name ASC
+LIMIT sqlc.arg('limit')::int OFFSET sqlc.arg('offset')::int;
+
+-- name: CountLeaderboard :one
+SELECT COUNT(*)
+FROM product_leaderboard l
+JOIN products p ON l.product_id = p.id
+JOIN companies c ON p.company_id = c.id
+WHERE l.time_window = sqlc.arg(time_window)::text
+AND p.deleted_at IS NULL AND c.deleted_at IS NULL
+AND (sqlc.narg(category)::text IS NULL OR p.category IN (
+  WITH RECURSIVE subtree AS (
+    SELECT id, slug FROM categories WHERE categories.slug = sqlc.narg(category)::text
+    UNION
+    SELECT ch.id, ch.slug FROM categories ch JOIN subtree st ON ch.parent_id = st.id
+  )
+  SELECT slug FROM subtree
+));
+
+-- name: GetLeaderboardRefreshedAt :one
+SELECT MAX(refreshed_at)::timestamptz AS refreshed_at
+FROM product_leaderboard
+WHERE time_window = $1;
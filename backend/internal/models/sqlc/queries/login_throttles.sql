@@ -0,0 +1,51 @@
+-- name: GetLoginThrottle :one
+SELECT * FROM login_throttles
+WHERE scope = $1 AND key = $2;
+
+-- name: ReserveLoginAttempt :one
+-- Counts a sign-in attempt as failed before its password is checked, holding the row until the
+-- transaction ends. Attempts on a locked key are not counted; earlier failures before
+-- forget_before no longer count.
+INSERT INTO login_throttles (scope, key, failures, last_failure_at)
+VALUES (sqlc.arg(scope), sqlc.arg(key), 1, sqlc.arg(attempted_at))
+ON CONFLICT (scope, key) DO UPDATE
+SET
+    failures = CASE
+        WHEN login_throttles.locked_until > EXCLUDED.last_failure_at THEN login_throttles.failures
+        WHEN login_throttles.last_failure_at < sqlc.arg(forget_before) THEN 1
+        ELSE login_throttles.failures + 1
+    END,
+    last_failure_at = CASE
+        WHEN login_throttles.locked_until > EXCLUDED.last_failure_at THEN login_throttles.last_failure_at
+        ELSE EXCLUDED.last_failure_at
+    END
+RETURNING *;
+
+-- name: ReleaseLoginAttempt :exec
+-- Takes back a reserved attempt whose password was right, and the lock it set if any
+UPDATE login_throttles
+SET
+    failures = GREATEST(failures - 1, 0),
+    locked_until = CASE WHEN locked_until = sqlc.narg(reserved_lock) THEN NULL ELSE locked_until END
+WHERE scope = sqlc.arg(scope) AND key = sqlc.arg(key);
+
+-- name: LockLoginThrottle :exec
+UPDATE login_throttles
+SET locked_until = $3
+WHERE scope = $1 AND key = $2;
+
+-- name: DeleteLoginThrottle :execrows
+DELETE FROM login_throttles
+WHERE scope = $1 AND key = $2;
+
+-- name: ListLockedAccounts :many
+SELECT u.id AS user_id, u.handle, t.key AS email, t.failures, t.last_failure_at, t.locked_until
+FROM login_throttles t
+JOIN users u ON u.email = t.key AND u.deleted_at IS NULL
+WHERE t.scope = 'account' AND t.locked_until > $1
+ORDER BY t.locked_until DESC, t.key;
+
+-- name: DeleteStaleLoginThrottles :execrows
+-- Drops keys whose failures are forgotten and whose lockout is over
+DELETE FROM login_throttles
+WHERE last_failure_at < sqlc.arg(stale_before) AND (locked_until IS NULL OR locked_until < sqlc.arg(stale_before));
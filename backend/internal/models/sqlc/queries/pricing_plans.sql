@@ -0,0 +1,42 @@
+-- name: CreatePricingPlan :one
+INSERT INTO pricing_plans (
+    id, product_id, name, billing_period, currency, price_cents, is_free,
+    pricing_unit, usage_unit, features, sort_order, created_at, updated_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13
+)
+RETURNING *;
+
+-- name: GetPricingPlan :one
+SELECT * FROM pricing_plans
+WHERE id = $1 AND product_id = $2;
+
+-- name: ListPricingPlansByProduct :many
+SELECT * FROM pricing_plans
+WHERE product_id = $1
+ORDER BY sort_order ASC, price_cents ASC, name ASC;
+
+-- name: ListPricingPlansByProducts :many
+SELECT * FROM pricing_plans
+WHERE product_id = ANY(sqlc.arg(product_ids)::uuid[])
+ORDER BY product_id, sort_order ASC, price_cents ASC, name ASC;
+
+-- name: UpdatePricingPlan :one
+UPDATE pricing_plans
+SET 
+    name = $3,
+    billing_period = $4,
+    currency = $5,
+    price_cents = $6,
+    is_free = $7,
+    pricing_unit = $8,
+    usage_unit = $9,
+    features = $10,
+    sort_order = $11,
+    updated_at = $12
+WHERE id = $1 AND product_id = $2
+RETURNING *;
+
+-- name: DeletePricingPlan :execrows
+DELETE FROM pricing_plans
+WHERE id = $1 AND product_id = $2;
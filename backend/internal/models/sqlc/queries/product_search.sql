@@ -0,0 +1,92 @@
+-- Full-text product search. Matches on the weighted search document, with a trigram
+-- fallback on product and company names so small typos still find results.
+-- Highlights are delimited with U+E000/U+E001 so the service can escape text before adding markup.
+-- A category filter matches the category and all of its descendants, like ListProducts; the
+-- recursive CTE uses UNION so a cycle in the hierarchy cannot make it loop.
+
+-- name: SearchProducts :many
+WITH search AS (
+  SELECT websearch_to_tsquery('english', sqlc.arg(query)::text) AS tsq,
+         sqlc.arg(query)::text AS raw
+)
+SELECT p.*, c.name AS company_name, c.slug AS company_slug,
+  (ts_rank_cd(d.document, s.tsq) + GREATEST(word_similarity(s.raw, p.name), word_similarity(s.raw, c.name) * 0.5))::float8 AS rank,
+  ts_headline('english', p.name, s.tsq, 'HighlightAll=true, StartSel=' || chr(57344) || ', StopSel=' || chr(57345))::text AS name_highlight,
+  ts_headline('english', coalesce(p.description, p.short_tagline, ''), s.tsq, 'MaxFragments=2, MaxWords=30, MinWords=10, StartSel=' || chr(57344) || ', StopSel=' || chr(57345))::text AS snippet
+FROM products p
+JOIN companies c ON p.company_id = c.id
+JOIN product_search_documents d ON d.product_id = p.id
+CROSS JOIN search s
+WHERE p.deleted_at IS NULL AND c.deleted_at IS NULL
+AND (d.document @@ s.tsq OR s.raw <% p.name OR s.raw <% c.name)
+AND (sqlc.narg(category)::text IS NULL OR p.category IN (
+  WITH RECURSIVE subtree AS (
+    SELECT id, slug FROM categories WHERE categories.slug = sqlc.narg(category)::text
+    UNION
+    SELECT ch.id, ch.slug FROM categories ch JOIN subtree st ON ch.parent_id = st.id
+  )
+  SELECT slug FROM subtree
+))
+AND (sqlc.narg(min_rating)::float8 IS NULL OR p.avg_rating >= sqlc.narg(min_rating)::float8)
+AND (cardinality(sqlc.arg(tags)::text[]) = 0 OR (
+  SELECT COUNT(*) FROM product_tags pt JOIN tags t ON pt.tag_id = t.id
+  WHERE pt.product_id = p.id AND t.slug = ANY(sqlc.arg(tags)::text[])
+) >= CASE WHEN sqlc.arg(match_all)::bool THEN cardinality(sqlc.arg(tags)::text[]) ELSE 1 END)
+ORDER BY rank DESC, p.name ASC
+LIMIT sqlc.arg('limit')::int OFFSET sqlc.arg('offset')::int;
+
+-- name: CountSearchProducts :one
+WITH search AS (
+  SELECT websearch_to_tsquery('english', sqlc.arg(query)::text) AS tsq,
+         sqlc.arg(query)::text AS raw
+)
+SELECT COUNT(*)
+FROM products p
+JOIN companies c ON p.company_id = c.id
+JOIN product_search_documents d ON d.product_id = p.id
+CROSS JOIN search s
+WHERE p.deleted_at IS NULL AND c.deleted_at IS NULL
+AND (d.document @@ s.tsq OR s.raw <% p.name OR s.raw <% c.name)
+AND (sqlc.narg(category)::text IS NULL OR p.category IN (
+  WITH RECURSIVE subtree AS (
+    SELECT id, slug FROM categories WHERE categories.slug = sqlc.narg(category)::text
+    UNION
+    SELECT ch.id, ch.slug FROM categories ch JOIN subtree st ON ch.parent_id = st.id
+  )
+  SELECT slug FROM subtree
+))
+AND (sqlc.narg(min_rating)::float8 IS NULL OR p.avg_rating >= sqlc.narg(min_rating)::float8)
+AND (cardinality(sqlc.arg(tags)::text[]) = 0 OR (
+  SELECT COUNT(*) FROM product_tags pt JOIN tags t ON pt.tag_id = t.id
+  WHERE pt.product_id = p.id AND t.slug = ANY(sqlc.arg(tags)::text[])
+) >= CASE WHEN sqlc.arg(match_all)::bool THEN cardinality(sqlc.arg(tags)::text[]) ELSE 1 END);
+
+-- name: SearchProductCategoryFacets :many
+WITH search AS (
+  SELECT websearch_to_tsquery('english', sqlc.arg(query)::text) AS tsq,
+         sqlc.arg(query)::text AS raw
+)
+SELECT p.category, COUNT(*) AS count
+FROM products p
+JOIN companies c ON p.company_id = c.id
+JOIN product_search_documents d ON d.product_id = p.id
+CROSS JOIN search s
+WHERE p.deleted_at IS NULL AND c.deleted_at IS NULL
+AND (d.document @@ s.tsq OR s.raw <% p.name OR s.raw <% c.name)
+GROUP BY p.category
+ORDER BY count DESC, p.category ASC;
+
+-- name: SearchProductRatingFacets :many
+WITH search AS (
+  SELECT websearch_to_tsquery('english', sqlc.arg(query)::text) AS tsq,
+         sqlc.arg(query)::text AS raw
+)
+SELECT COALESCE(FLOOR(p.avg_rating), 0)::int AS rating_bucket, COUNT(*) AS count
+FROM products p
+JOIN companies c ON p.company_id = c.id
+JOIN product_search_documents d ON d.product_id = p.id
+CROSS JOIN search s
+WHERE p.deleted_at IS NULL AND c.deleted_at IS NULL
+AND (d.document @@ s.tsq OR s.raw <% p.name OR s.raw <% c.name)
+GROUP BY rating_bucket
+ORDER BY rating_bucket DESC;
@@ -0,0 +1,45 @@
+-- name: RefreshProductRatingStats :exec
+INSERT INTO product_rating_stats (
+    product_id, star_1, star_2, star_3, star_4, star_5, updated_at
+)
+SELECT
+    sqlc.arg(product_id)::uuid,
+    COUNT(*) FILTER (WHERE rating = 1),
+    COUNT(*) FILTER (WHERE rating = 2),
+    COUNT(*) FILTER (WHERE rating = 3),
+    COUNT(*) FILTER (WHERE rating = 4),
+    COUNT(*) FILTER (WHERE rating = 5),
+    NOW()
+FROM reviews
+WHERE product_id = sqlc.arg(product_id) AND deleted_at IS NULL AND status = 'published'
+ON CONFLICT (product_id) DO UPDATE
+SET
+    star_1 = EXCLUDED.star_1,
+    star_2 = EXCLUDED.star_2,
+    star_3 = EXCLUDED.star_3,
+    star_4 = EXCLUDED.star_4,
+    star_5 = EXCLUDED.star_5,
+    updated_at = EXCLUDED.updated_at;
+
+-- name: DeleteProductDimensionStats :exec
+DELETE FROM product_dimension_stats
+WHERE product_id = $1;
+
+-- name: RefreshProductDimensionStats :exec
+INSERT INTO product_dimension_stats (
+    product_id, dimension, avg_rating, rating_count, updated_at
+)
+SELECT r.product_id, s.dimension, AVG(s.rating)::float8, COUNT(*)::int, NOW()
+FROM review_sub_ratings s
+JOIN reviews r ON s.review_id = r.id
+WHERE r.product_id = $1 AND r.deleted_at IS NULL AND r.status = 'published'
+GROUP BY r.product_id, s.dimension;
+
+-- name: GetProductRatingStats :one
+SELECT * FROM product_rating_stats
+WHERE product_id = $1;
+
+-- name: ListProductDimensionStats :many
+SELECT * FROM product_dimension_stats
+WHERE product_id = $1
+ORDER BY dimension;
@@ -16,35 +16,17 @@ FROM products p
 JOIN companies c ON p.company_id = c.id
 WHERE p.slug = $1 AND p.deleted_at IS NULL AND c.deleted_at IS NULL;
 
--- name: GetProductsByCompany :many
-SELECT * FROM products
-WHERE company_id = $1 AND deleted_at IS NULL
-ORDER BY created_at DESC
-LIMIT $2 OFFSET $3;
-
--- name: ListProducts :many
+-- name: ListProductsForComparison :many
 SELECT p.*, c.name as company_name, c.slug as company_slug
 FROM products p
 JOIN companies c ON p.company_id = c.id
-WHERE p.deleted_at IS NULL AND c.deleted_at IS NULL
-ORDER BY p.created_at DESC
-LIMIT $1 OFFSET $2;
-
--- name: ListProductsByCategory :many
-SELECT p.*, c.name as company_name, c.slug as company_slug
-FROM products p
-JOIN companies c ON p.company_id = c.id
-WHERE p.category = $1 AND p.deleted_at IS NULL AND c.deleted_at IS NULL
-ORDER BY p.created_at DESC
-LIMIT $2 OFFSET $3;
+WHERE (p.id = ANY(sqlc.arg(ids)::uuid[]) OR p.slug = ANY(sqlc.arg(slugs)::text[]))
+AND p.deleted_at IS NULL AND c.deleted_at IS NULL;
 
--- name: SearchProducts :many
-SELECT p.*, c.name as company_name, c.slug as company_slug
-FROM products p
-JOIN companies c ON p.company_id = c.id
-WHERE p.deleted_at IS NULL AND c.deleted_at IS NULL
-AND (p.name ILIKE $1 OR p.short_tagline ILIKE $1 OR c.name ILIKE $1)
-ORDER BY p.name ASC
+-- name: GetProductsByCompany :many
+SELECT * FROM products
+WHERE company_id = $1 AND deleted_at IS NULL
+ORDER BY created_at DESC
 LIMIT $2 OFFSET $3;
 
 -- name: UpdateProduct :one
@@ -63,6 +45,11 @@ SET
 WHERE id = $1 AND deleted_at IS NULL
 RETURNING *;
 
+-- name: LockProduct :exec
+SELECT id FROM products
+WHERE id = $1
+FOR UPDATE;
+
 -- name: UpdateProductStats :exec
 UPDATE products
 SET 
@@ -80,10 +67,6 @@ WHERE id = $1 AND deleted_at IS NULL;
 DELETE FROM products
 WHERE id = $1;
 
--- name: CountProducts :one
-SELECT COUNT(*) FROM products
-WHERE deleted_at IS NULL;
-
 -- name: CountProductsByCompany :one
 SELECT COUNT(*) FROM products
 WHERE company_id = $1 AND deleted_at IS NULL;
\ No newline at end of file
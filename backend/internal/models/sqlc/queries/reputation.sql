@@ -0,0 +1,76 @@
+-- Reviewer reputation and badges are precomputed by RefreshUserReputation and RefreshReviewerBadges.
+-- reputation = helpful votes received + 5 per published review - 20 per rejected review
+--   + 1 per 30 days since sign-up (at most 24, and only once the user has published a review),
+--   never below 0.
+-- The top_n reviewers of each category by helpful votes received get a badge; ties go to the older account.
+
+-- name: LockReputationRefresh :exec
+SELECT pg_advisory_xact_lock(hashtext('user_reputation'));
+
+-- name: DeleteUserReputation :exec
+DELETE FROM user_reputation;
+
+-- name: RefreshUserReputation :exec
+WITH authored AS (
+  SELECT r.user_id,
+    (COUNT(*) FILTER (WHERE r.status = 'published' AND r.deleted_at IS NULL AND p.deleted_at IS NULL))::int AS published_count,
+    (COUNT(*) FILTER (WHERE r.status = 'rejected'))::int AS rejected_count,
+    (COALESCE(SUM(r.upvote_count) FILTER (WHERE r.status = 'published' AND r.deleted_at IS NULL AND p.deleted_at IS NULL), 0))::int AS helpful_votes
+  FROM reviews r
+  JOIN products p ON r.product_id = p.id
+  GROUP BY r.user_id
+)
+INSERT INTO user_reputation (user_id, published_count, rejected_count, helpful_votes, reputation, refreshed_at)
+SELECT a.user_id, a.published_count, a.rejected_count, a.helpful_votes,
+  GREATEST(0, a.helpful_votes + 5 * a.published_count - 20 * a.rejected_count
+    + CASE WHEN a.published_count > 0
+        THEN LEAST(24, FLOOR(EXTRACT(EPOCH FROM NOW() - u.created_at) / 2592000)::int)
+        ELSE 0 END),
+  NOW()
+FROM authored a
+JOIN users u ON a.user_id = u.id;
+
+-- name: DeleteReviewerBadges :exec
+DELETE FROM reviewer_badges;
+
+-- name: RefreshReviewerBadges :exec
+WITH category_votes AS (
+  SELECT r.user_id, p.category, u.created_at AS joined_at,
+    SUM(r.upvote_count)::int AS helpful_votes,
+    COUNT(*) AS review_count
+  FROM reviews r
+  JOIN products p ON r.product_id = p.id
+  JOIN users u ON r.user_id = u.id
+  WHERE r.deleted_at IS NULL AND r.status = 'published' AND p.deleted_at IS NULL AND u.deleted_at IS NULL
+  GROUP BY r.user_id, p.category, u.created_at
+  HAVING SUM(r.upvote_count) > 0
+), ranked AS (
+  SELECT user_id, category, helpful_votes,
+    ROW_NUMBER() OVER (PARTITION BY category ORDER BY helpful_votes DESC, review_count DESC, joined_at, user_id)::int AS rank
+  FROM category_votes
+)
+INSERT INTO reviewer_badges (user_id, category, rank, helpful_votes, refreshed_at)
+SELECT user_id, category, rank, helpful_votes, NOW()
+FROM ranked
+WHERE rank <= sqlc.arg(top_n)::int;
+
+-- name: GetPublicProfile :one
+-- Review count and helpful votes are live; reputation is as of the last refresh.
+SELECT u.id, u.handle, u.created_at, stats.review_count, stats.helpful_votes,
+  COALESCE(ur.reputation, 0)::int AS reputation, ur.refreshed_at
+FROM users u
+LEFT JOIN user_reputation ur ON ur.user_id = u.id
+CROSS JOIN LATERAL (
+  SELECT COUNT(*) AS review_count, COALESCE(SUM(r.upvote_count), 0)::bigint AS helpful_votes
+  FROM reviews r
+  JOIN products p ON r.product_id = p.id
+  WHERE r.user_id = u.id AND r.status = 'published' AND r.deleted_at IS NULL AND p.deleted_at IS NULL
+) stats
+WHERE u.handle = $1 AND u.deleted_at IS NULL;
+
+-- name: ListReviewerBadges :many
+SELECT b.category, c.name AS category_name, b.rank, b.helpful_votes
+FROM reviewer_badges b
+JOIN categories c ON b.category = c.slug
+WHERE b.user_id = $1
+ORDER BY b.rank, c.sort_order, c.name;
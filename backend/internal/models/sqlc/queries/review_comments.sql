@@ -0,0 +1,119 @@
+-- name: CreateReviewComment :one
+INSERT INTO review_comments (
+    id, review_id, parent_id, user_id, body, status, official, company_id, flag_count, edited, created_at, updated_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12
+) RETURNING *;
+
+-- name: GetReviewComment :one
+SELECT * FROM review_comments
+WHERE id = $1 AND deleted_at IS NULL;
+
+-- name: GetReviewCommentForUpdate :one
+SELECT * FROM review_comments
+WHERE id = $1 AND deleted_at IS NULL
+FOR UPDATE;
+
+-- name: GetOfficialReviewComment :one
+SELECT * FROM review_comments
+WHERE review_id = $1 AND official AND deleted_at IS NULL;
+
+-- name: ListReviewComments :many
+-- Includes deleted comments so their replies can still be threaded beneath them
+SELECT sqlc.embed(rc), u.handle as user_handle, co.name as company_name
+FROM review_comments rc
+JOIN users u ON rc.user_id = u.id
+LEFT JOIN companies co ON rc.company_id = co.id
+WHERE rc.review_id = $1
+ORDER BY rc.created_at ASC, rc.id ASC;
+
+-- name: CountReviewCommentsByReviews :many
+SELECT review_id, COUNT(*) as comment_count
+FROM review_comments
+WHERE review_id = ANY(sqlc.arg(review_ids)::uuid[]) AND status = 'published' AND deleted_at IS NULL
+GROUP BY review_id;
+
+-- name: ListReviewCommentsByStatus :many
+SELECT sqlc.embed(rc), u.handle as user_handle
+FROM review_comments rc
+JOIN users u ON rc.user_id = u.id
+WHERE rc.status = $1 AND rc.deleted_at IS NULL
+ORDER BY rc.created_at ASC
+LIMIT $2 OFFSET $3;
+
+-- name: CountReviewCommentsByStatus :one
+SELECT COUNT(*) FROM review_comments
+WHERE status = $1 AND deleted_at IS NULL;
+
+-- name: ListFlaggedReviewComments :many
+SELECT sqlc.embed(rc), u.handle as user_handle
+FROM review_comments rc
+JOIN users u ON rc.user_id = u.id
+WHERE rc.flag_count >= $1 AND rc.status <> 'rejected'
+AND rc.deleted_at IS NULL
+ORDER BY rc.flag_count DESC, rc.created_at ASC
+LIMIT $2 OFFSET $3;
+
+-- name: CountFlaggedReviewComments :one
+SELECT COUNT(*) FROM review_comments
+WHERE flag_count >= $1 AND status <> 'rejected' AND deleted_at IS NULL;
+
+-- name: UpdateReviewCommentBody :one
+UPDATE review_comments
+SET 
+    body = $2,
+    edited = true,
+    updated_at = $3
+WHERE id = $1 AND deleted_at IS NULL
+RETURNING *;
+
+-- name: UpdateReviewCommentStatus :exec
+UPDATE review_comments
+SET 
+    status = $2,
+    updated_at = NOW()
+WHERE id = $1 AND deleted_at IS NULL;
+
+-- name: AdjustReviewCommentFlagCount :exec
+UPDATE review_comments
+SET 
+    flag_count = GREATEST(flag_count + sqlc.arg(flag_delta)::int, 0),
+    updated_at = NOW()
+WHERE id = sqlc.arg(id) AND deleted_at IS NULL;
+
+-- name: ClearReviewCommentFlags :exec
+UPDATE review_comments
+SET 
+    flag_count = 0,
+    updated_at = NOW()
+WHERE id = $1 AND deleted_at IS NULL;
+
+-- name: SoftDeleteReviewComment :exec
+UPDATE review_comments
+SET deleted_at = NOW()
+WHERE id = $1 AND deleted_at IS NULL;
+
+-- name: GetReviewCommentFlag :one
+SELECT * FROM review_comment_flags
+WHERE comment_id = $1 AND user_id = $2;
+
+-- name: UpsertReviewCommentFlag :one
+INSERT INTO review_comment_flags (
+    comment_id, user_id, reason, note, created_at, updated_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+)
+ON CONFLICT (comment_id, user_id) DO UPDATE
+SET 
+    reason = EXCLUDED.reason,
+    note = EXCLUDED.note,
+    updated_at = EXCLUDED.updated_at
+RETURNING *;
+
+-- name: DeleteReviewCommentFlag :execrows
+DELETE FROM review_comment_flags
+WHERE comment_id = $1 AND user_id = $2;
+
+-- name: DeleteReviewCommentFlags :exec
+DELETE FROM review_comment_flags
+WHERE comment_id = $1;
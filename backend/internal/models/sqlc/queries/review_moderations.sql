@@ -0,0 +1,13 @@
+-- name: CreateReviewModeration :one
+INSERT INTO review_moderations (
+    id, review_id, moderator_id, from_status, to_status, reason, created_at, comment_id
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8
+) RETURNING *;
+
+-- name: ListReviewModerations :many
+SELECT m.*, u.handle as moderator_handle
+FROM review_moderations m
+JOIN users u ON m.moderator_id = u.id
+WHERE m.review_id = $1
+ORDER BY m.created_at DESC;
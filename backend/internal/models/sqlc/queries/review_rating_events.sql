@@ -0,0 +1,10 @@
+-- name: CreateReviewRatingEvent :exec
+INSERT INTO review_rating_events (
+    id, review_id, product_id, kind, from_rating, to_rating, created_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+);
+
+-- name: CountProductRatingEvents :one
+SELECT COUNT(*) FROM review_rating_events
+WHERE product_id = $1;
@@ -0,0 +1,16 @@
+-- name: CreateReviewRevision :exec
+INSERT INTO review_revisions (
+    id, review_id, revision, title, body, rating, sub_ratings, created_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8
+);
+
+-- name: GetLatestReviewRevision :one
+-- Returns 0 for a review that has never been edited
+SELECT COALESCE(MAX(revision), 0)::int FROM review_revisions
+WHERE review_id = $1;
+
+-- name: ListReviewRevisions :many
+SELECT * FROM review_revisions
+WHERE review_id = $1
+ORDER BY revision ASC;
@@ -0,0 +1,15 @@
+-- name: CreateReviewSubRating :exec
+INSERT INTO review_sub_ratings (
+    review_id, dimension, rating, created_at
+) VALUES (
+    $1, $2, $3, $4
+);
+
+-- name: DeleteReviewSubRatings :exec
+DELETE FROM review_sub_ratings
+WHERE review_id = $1;
+
+-- name: ListReviewSubRatings :many
+SELECT * FROM review_sub_ratings
+WHERE review_id = ANY(sqlc.arg(review_ids)::uuid[])
+ORDER BY review_id, dimension;
@@ -0,0 +1,55 @@
+-- name: GetReviewVote :one
+SELECT * FROM review_votes
+WHERE review_id = $1 AND user_id = $2;
+
+-- name: GetUserVotesForReviews :many
+SELECT * FROM review_votes
+WHERE user_id = sqlc.arg(user_id) AND review_id = ANY(sqlc.arg(review_ids)::uuid[]);
+
+-- name: UpsertReviewVote :one
+INSERT INTO review_votes (
+    review_id, user_id, vote, created_at, updated_at
+) VALUES (
+    $1, $2, $3, $4, $5
+)
+ON CONFLICT (review_id, user_id) DO UPDATE
+SET 
+    vote = EXCLUDED.vote,
+    updated_at = EXCLUDED.updated_at
+RETURNING *;
+
+-- name: DeleteReviewVote :execrows
+DELETE FROM review_votes
+WHERE review_id = $1 AND user_id = $2;
+
+-- name: GetReviewFlag :one
+SELECT * FROM review_flags
+WHERE review_id = $1 AND user_id = $2;
+
+-- name: ListReviewFlags :many
+SELECT f.*, u.handle as user_handle
+FROM review_flags f
+JOIN users u ON f.user_id = u.id
+WHERE f.review_id = $1
+ORDER BY f.created_at ASC;
+
+-- name: UpsertReviewFlag :one
+INSERT INTO review_flags (
+    review_id, user_id, reason, note, created_at, updated_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+)
+ON CONFLICT (review_id, user_id) DO UPDATE
+SET 
+    reason = EXCLUDED.reason,
+    note = EXCLUDED.note,
+    updated_at = EXCLUDED.updated_at
+RETURNING *;
+
+-- name: DeleteReviewFlag :execrows
+DELETE FROM review_flags
+WHERE review_id = $1 AND user_id = $2;
+
+-- name: DeleteReviewFlags :exec
+DELETE FROM review_flags
+WHERE review_id = $1;
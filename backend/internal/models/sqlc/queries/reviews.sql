@@ -11,38 +11,35 @@ SELECT r.*, u.handle as user_handle, p.name as product_name
 FROM reviews r
 JOIN users u ON r.user_id = u.id
 JOIN products p ON r.product_id = p.id
-WHERE r.id = $1 AND r.deleted_at IS NULL AND u.deleted_at IS NULL AND p.deleted_at IS NULL;
+WHERE r.id = $1 AND r.deleted_at IS NULL AND p.deleted_at IS NULL;
 
--- name: GetReviewsByProduct :many
-SELECT r.*, u.handle as user_handle
+-- name: GetReviewsByStatus :many
+SELECT r.*, u.handle as user_handle, p.name as product_name
 FROM reviews r
 JOIN users u ON r.user_id = u.id
-WHERE r.product_id = $1 AND r.deleted_at IS NULL AND u.deleted_at IS NULL
-ORDER BY 
-    CASE WHEN $2 = 'upvotes' THEN r.upvote_count END DESC,
-    CASE WHEN $2 = 'rating_desc' THEN r.rating END DESC,
-    CASE WHEN $2 = 'rating_asc' THEN r.rating END ASC,
-    r.created_at DESC
-LIMIT $3 OFFSET $4;
-
--- name: GetReviewsByUser :many
-SELECT r.*, p.name as product_name, p.slug as product_slug, c.name as company_name
-FROM reviews r
 JOIN products p ON r.product_id = p.id
-JOIN companies c ON p.company_id = c.id
-WHERE r.user_id = $1 AND r.deleted_at IS NULL AND p.deleted_at IS NULL AND c.deleted_at IS NULL
-ORDER BY r.created_at DESC
+WHERE r.status = $1 AND r.deleted_at IS NULL AND p.deleted_at IS NULL
+ORDER BY r.created_at ASC
 LIMIT $2 OFFSET $3;
 
--- name: GetReviewsByStatus :many
+-- name: ListFlaggedReviews :many
 SELECT r.*, u.handle as user_handle, p.name as product_name
 FROM reviews r
 JOIN users u ON r.user_id = u.id
 JOIN products p ON r.product_id = p.id
-WHERE r.status = $1 AND r.deleted_at IS NULL AND u.deleted_at IS NULL AND p.deleted_at IS NULL
-ORDER BY r.created_at ASC
+WHERE r.flag_count >= $1 AND r.status <> 'rejected'
+AND r.deleted_at IS NULL AND p.deleted_at IS NULL
+ORDER BY r.flag_count DESC, r.created_at ASC
 LIMIT $2 OFFSET $3;
 
+-- name: CountReviewsByStatus :one
+SELECT COUNT(*) FROM reviews
+WHERE status = $1 AND deleted_at IS NULL;
+
+-- name: CountFlaggedReviews :one
+SELECT COUNT(*) FROM reviews
+WHERE flag_count >= $1 AND status <> 'rejected' AND deleted_at IS NULL;
+
 -- name: GetUserReviewForProduct :one
 SELECT * FROM reviews
 WHERE product_id = $1 AND user_id = $2 AND deleted_at IS NULL;
@@ -66,24 +63,30 @@ SET
     updated_at = NOW()
 WHERE id = $1 AND deleted_at IS NULL;
 
--- name: IncrementUpvoteCount :exec
+-- name: GetReviewForUpdate :one
+SELECT * FROM reviews
+WHERE id = $1 AND deleted_at IS NULL
+FOR UPDATE;
+
+-- name: AdjustReviewVoteCounts :exec
 UPDATE reviews
 SET 
-    upvote_count = upvote_count + 1,
+    upvote_count = GREATEST(upvote_count + sqlc.arg(upvote_delta)::int, 0),
+    downvote_count = GREATEST(downvote_count + sqlc.arg(downvote_delta)::int, 0),
     updated_at = NOW()
-WHERE id = $1 AND deleted_at IS NULL;
+WHERE id = sqlc.arg(id) AND deleted_at IS NULL;
 
--- name: IncrementDownvoteCount :exec
+-- name: AdjustReviewFlagCount :exec
 UPDATE reviews
 SET 
-    downvote_count = downvote_count + 1,
+    flag_count = GREATEST(flag_count + sqlc.arg(flag_delta)::int, 0),
     updated_at = NOW()
-WHERE id = $1 AND deleted_at IS NULL;
+WHERE id = sqlc.arg(id) AND deleted_at IS NULL;
 
--- name: IncrementFlagCount :exec
+-- name: ClearReviewFlags :exec
 UPDATE reviews
 SET 
-    flag_count = flag_count + 1,
+    flag_count = 0,
     updated_at = NOW()
 WHERE id = $1 AND deleted_at IS NULL;
 
@@ -102,7 +105,8 @@ WHERE product_id = $1 AND deleted_at IS NULL AND status = 'published';
 
 -- name: CountReviewsByUser :one
 SELECT COUNT(*) FROM reviews
-WHERE user_id = $1 AND deleted_at IS NULL;
+WHERE user_id = sqlc.arg(user_id) AND deleted_at IS NULL
+AND (status = 'published' OR NOT sqlc.arg(published_only)::bool);
 
 -- name: GetAverageRatingByProduct :one
 SELECT AVG(rating)::DECIMAL(3,2) as avg_rating
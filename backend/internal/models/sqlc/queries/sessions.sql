@@ -0,0 +1,75 @@
+-- name: CreateSession :one
+INSERT INTO sessions (
+  id, user_id, user_agent, ip_address, created_at, last_used_at
+) VALUES (
+  $1, $2, $3, $4, $5, $6
+)
+RETURNING *;
+
+-- name: GetSession :one
+SELECT * FROM sessions
+WHERE id = $1;
+
+-- name: TouchSession :exec
+UPDATE sessions
+SET last_used_at = $2
+WHERE id = $1;
+
+-- name: RevokeSession :execrows
+UPDATE sessions
+SET revoked_at = $3
+WHERE id = $1 AND user_id = $2 AND revoked_at IS NULL;
+
+-- name: RevokeUserSessions :execrows
+UPDATE sessions
+SET revoked_at = $2
+WHERE user_id = $1 AND revoked_at IS NULL;
+
+-- name: RevokeOtherUserSessions :execrows
+UPDATE sessions
+SET revoked_at = $3
+WHERE user_id = $1 AND id <> $2 AND revoked_at IS NULL;
+
+-- name: DeleteStaleSessions :execrows
+DELETE FROM sessions
+WHERE last_used_at < $1;
+
+-- name: CreateRefreshToken :one
+INSERT INTO refresh_tokens (
+  id, session_id, token_hash, expires_at, created_at
+) VALUES (
+  $1, $2, $3, $4, $5
+)
+RETURNING *;
+
+-- name: GetRefreshTokenByHashForUpdate :one
+SELECT * FROM refresh_tokens
+WHERE token_hash = $1
+FOR UPDATE;
+
+-- name: MarkRefreshTokenUsed :exec
+UPDATE refresh_tokens
+SET used_at = $2
+WHERE id = $1;
+
+-- name: DeleteExpiredRefreshTokens :execrows
+DELETE FROM refresh_tokens
+WHERE expires_at < $1;
+
+-- name: RevokeAccessToken :exec
+INSERT INTO revoked_access_tokens (
+  jti, user_id, expires_at, revoked_at
+) VALUES (
+  $1, $2, $3, $4
+)
+ON CONFLICT (jti) DO NOTHING;
+
+-- name: IsAccessTokenRevoked :one
+SELECT (
+  EXISTS (SELECT 1 FROM revoked_access_tokens rat WHERE rat.jti = sqlc.arg(jti)::uuid)
+  OR EXISTS (SELECT 1 FROM sessions s WHERE s.id = sqlc.arg(session_id)::uuid AND s.revoked_at IS NOT NULL)
+)::boolean AS revoked;
+
+-- name: DeleteExpiredRevokedAccessTokens :execrows
+DELETE FROM revoked_access_tokens
+WHERE expires_at < $1;
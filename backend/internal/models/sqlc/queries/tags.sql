@@ -0,0 +1,85 @@
+-- name: UpsertTag :one
+-- An existing tag keeps its display name; the no-op update makes RETURNING yield the row either way
+INSERT INTO tags (
+  id, slug, name, created_at
+) VALUES (
+  $1, $2, $3, $4
+)
+ON CONFLICT (slug) DO UPDATE
+SET slug = EXCLUDED.slug
+RETURNING *;
+
+-- name: GetTagBySlug :one
+SELECT * FROM tags
+WHERE slug = $1;
+
+-- name: ListTags :many
+SELECT t.*, COUNT(p.id) AS product_count
+FROM tags t
+LEFT JOIN product_tags pt ON pt.tag_id = t.id
+LEFT JOIN products p ON p.id = pt.product_id AND p.deleted_at IS NULL
+WHERE (sqlc.narg(prefix)::text IS NULL OR t.slug LIKE sqlc.narg(prefix)::text || '%')
+GROUP BY t.id
+ORDER BY product_count DESC, t.slug ASC
+LIMIT sqlc.arg('limit')::int OFFSET sqlc.arg('offset')::int;
+
+-- name: AddProductTag :execrows
+INSERT INTO product_tags (
+  product_id, tag_id, added_by, created_at
+) VALUES (
+  $1, $2, $3, $4
+)
+ON CONFLICT (product_id, tag_id) DO NOTHING;
+
+-- name: RemoveProductTag :execrows
+DELETE FROM product_tags pt
+USING tags t
+WHERE pt.tag_id = t.id AND pt.product_id = $1 AND t.slug = $2;
+
+-- name: CountProductTags :one
+SELECT COUNT(*) FROM product_tags
+WHERE product_id = $1;
+
+-- name: ListProductTags :many
+SELECT pt.product_id, t.id, t.slug, t.name
+FROM product_tags pt
+JOIN tags t ON pt.tag_id = t.id
+WHERE pt.product_id = ANY(sqlc.arg(product_ids)::uuid[])
+ORDER BY pt.product_id, t.slug;
+
+-- name: CreateTagSuggestion :one
+INSERT INTO tag_suggestions (
+  id, product_id, user_id, tag_slug, tag_name, status, created_at, updated_at
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $8
+)
+RETURNING *;
+
+-- name: GetTagSuggestion :one
+SELECT * FROM tag_suggestions
+WHERE id = $1;
+
+-- name: GetPendingTagSuggestion :one
+SELECT * FROM tag_suggestions
+WHERE product_id = $1 AND tag_slug = $2 AND status = 'pending';
+
+-- name: ListTagSuggestionsByProduct :many
+SELECT ts.*, u.handle AS user_handle
+FROM tag_suggestions ts
+JOIN users u ON ts.user_id = u.id
+WHERE ts.product_id = $1 AND ts.status = $2
+ORDER BY ts.created_at ASC
+LIMIT $3 OFFSET $4;
+
+-- name: CountTagSuggestionsByProduct :one
+SELECT COUNT(*) FROM tag_suggestions
+WHERE product_id = $1 AND status = $2;
+
+-- name: ResolveTagSuggestion :one
+UPDATE tag_suggestions
+SET status = $2,
+    reviewed_by = $3,
+    review_note = $4,
+    reviewed_at = $5
+WHERE id = $1 AND status = 'pending'
+RETURNING *;
@@ -24,15 +24,37 @@ ORDER BY created_at DESC
 LIMIT $1 OFFSET $2;
 
 -- name: UpdateUser :one
+-- A new email address starts out unverified
 UPDATE users
 SET 
     email = $2,
     handle = $3,
     role = $4,
+    email_verified_at = CASE WHEN email = $2 THEN email_verified_at END,
     updated_at = $5
 WHERE id = $1 AND deleted_at IS NULL
 RETURNING *;
 
+-- name: SetUserEmailVerified :one
+UPDATE users
+SET
+    email_verified_at = $2,
+    updated_at = $2
+WHERE id = $1 AND deleted_at IS NULL
+RETURNING *;
+
+-- name: AnonymizeUser :one
+-- Soft-deletes a user, replacing the email and handle so they can be taken again
+UPDATE users
+SET
+    email = $2,
+    handle = $3,
+    email_verified_at = NULL,
+    deleted_at = $4,
+    updated_at = $4
+WHERE id = $1 AND deleted_at IS NULL
+RETURNING *;
+
 -- name: SoftDeleteUser :exec
 UPDATE users
 SET deleted_at = NOW()
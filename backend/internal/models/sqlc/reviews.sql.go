@@ -12,6 +12,69 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const adjustReviewFlagCount = `-- name: AdjustReviewFlagCount :exec
+UPDATE reviews
+SET 
+    flag_count = GREATEST(flag_count + $1::int, 0),
+    updated_at = NOW()
+WHERE id = $2 AND deleted_at IS NULL
+`
+
+type AdjustReviewFlagCountParams struct {
+	FlagDelta int32     `json:"flag_delta"`
+	ID        uuid.UUID `json:"id"`
+}
+
+func (q *Queries) AdjustReviewFlagCount(ctx context.Context, arg AdjustReviewFlagCountParams) error {
+	_, err := q.db.Exec(ctx, adjustReviewFlagCount, arg.FlagDelta, arg.ID)
+	return err
+}
+
+const adjustReviewVoteCounts = `-- name: AdjustReviewVoteCounts :exec
+UPDATE reviews
+SET 
+    upvote_count = GREATEST(upvote_count + $1::int, 0),
+    downvote_count = GREATEST(downvote_count + $2::int, 0),
+    updated_at = NOW()
+WHERE id = $3 AND deleted_at IS NULL
+`
+
+type AdjustReviewVoteCountsParams struct {
+	UpvoteDelta   int32     `json:"upvote_delta"`
+	DownvoteDelta int32     `json:"downvote_delta"`
+	ID            uuid.UUID `json:"id"`
+}
+
+func (q *Queries) AdjustReviewVoteCounts(ctx context.Context, arg AdjustReviewVoteCountsParams) error {
+	_, err := q.db.Exec(ctx, adjustReviewVoteCounts, arg.UpvoteDelta, arg.DownvoteDelta, arg.ID)
+	return err
+}
+
+const clearReviewFlags = `-- name: ClearReviewFlags :exec
+UPDATE reviews
+SET 
+    flag_count = 0,
+    updated_at = NOW()
+WHERE id = $1 AND deleted_at IS NULL
+`
+
+func (q *Queries) ClearReviewFlags(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.Exec(ctx, clearReviewFlags, id)
+	return err
+}
+
+const countFlaggedReviews = `-- name: CountFlaggedReviews :one
+SELECT COUNT(*) FROM reviews
+WHERE flag_count >= $1 AND status <> 'rejected' AND deleted_at IS NULL
+`
+
+func (q *Queries) CountFlaggedReviews(ctx context.Context, flagCount int32) (int64, error) {
+	row := q.db.QueryRow(ctx, countFlaggedReviews, flagCount)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const countReviewsByProduct = `-- name: CountReviewsByProduct :one
 SELECT COUNT(*) FROM reviews
 WHERE product_id = $1 AND deleted_at IS NULL AND status = 'published'
@@ -24,13 +87,31 @@ func (q *Queries) CountReviewsByProduct(ctx context.Context, productID uuid.UUID
 	return count, err
 }
 
+const countReviewsByStatus = `-- name: CountReviewsByStatus :one
+SELECT COUNT(*) FROM reviews
+WHERE status = $1 AND deleted_at IS NULL
+`
+
+func (q *Queries) CountReviewsByStatus(ctx context.Context, status string) (int64, error) {
+	row := q.db.QueryRow(ctx, countReviewsByStatus, status)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const countReviewsByUser = `-- name: CountReviewsByUser :one
 SELECT COUNT(*) FROM reviews
 WHERE user_id = $1 AND deleted_at IS NULL
+AND (status = 'published' OR NOT $2::bool)
 `
 
-func (q *Queries) CountReviewsByUser(ctx context.Context, userID uuid.UUID) (int64, error) {
-	row := q.db.QueryRow(ctx, countReviewsByUser, userID)
+type CountReviewsByUserParams struct {
+	UserID        uuid.UUID `json:"user_id"`
+	PublishedOnly bool      `json:"published_only"`
+}
+
+func (q *Queries) CountReviewsByUser(ctx context.Context, arg CountReviewsByUserParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countReviewsByUser, arg.UserID, arg.PublishedOnly)
 	var count int64
 	err := row.Scan(&count)
 	return count, err
@@ -115,7 +196,7 @@ SELECT r.id, r.product_id, r.user_id, r.title, r.body, r.rating, r.status, r.upv
 FROM reviews r
 JOIN users u ON r.user_id = u.id
 JOIN products p ON r.product_id = p.id
-WHERE r.id = $1 AND r.deleted_at IS NULL AND u.deleted_at IS NULL AND p.deleted_at IS NULL
+WHERE r.id = $1 AND r.deleted_at IS NULL AND p.deleted_at IS NULL
 `
 
 type GetReviewRow struct {
@@ -161,83 +242,32 @@ func (q *Queries) GetReview(ctx context.Context, id uuid.UUID) (GetReviewRow, er
 	return i, err
 }
 
-const getReviewsByProduct = `-- name: GetReviewsByProduct :many
-SELECT r.id, r.product_id, r.user_id, r.title, r.body, r.rating, r.status, r.upvote_count, r.downvote_count, r.flag_count, r.edited, r.created_at, r.updated_at, r.deleted_at, u.handle as user_handle
-FROM reviews r
-JOIN users u ON r.user_id = u.id
-WHERE r.product_id = $1 AND r.deleted_at IS NULL AND u.deleted_at IS NULL
-ORDER BY 
-    CASE WHEN $2 = 'upvotes' THEN r.upvote_count END DESC,
-    CASE WHEN $2 = 'rating_desc' THEN r.rating END DESC,
-    CASE WHEN $2 = 'rating_asc' THEN r.rating END ASC,
-    r.created_at DESC
-LIMIT $3 OFFSET $4
+const getReviewForUpdate = `-- name: GetReviewForUpdate :one
+SELECT id, product_id, user_id, title, body, rating, status, upvote_count, downvote_count, flag_count, edited, created_at, updated_at, deleted_at FROM reviews
+WHERE id = $1 AND deleted_at IS NULL
+FOR UPDATE
 `
 
-type GetReviewsByProductParams struct {
-	ProductID uuid.UUID   `json:"product_id"`
-	Column2   interface{} `json:"column_2"`
-	Limit     int32       `json:"limit"`
-	Offset    int32       `json:"offset"`
-}
-
-type GetReviewsByProductRow struct {
-	ID            uuid.UUID          `json:"id"`
-	ProductID     uuid.UUID          `json:"product_id"`
-	UserID        uuid.UUID          `json:"user_id"`
-	Title         *string            `json:"title"`
-	Body          string             `json:"body"`
-	Rating        int32              `json:"rating"`
-	Status        string             `json:"status"`
-	UpvoteCount   int32              `json:"upvote_count"`
-	DownvoteCount int32              `json:"downvote_count"`
-	FlagCount     int32              `json:"flag_count"`
-	Edited        bool               `json:"edited"`
-	CreatedAt     pgtype.Timestamptz `json:"created_at"`
-	UpdatedAt     pgtype.Timestamptz `json:"updated_at"`
-	DeletedAt     pgtype.Timestamptz `json:"deleted_at"`
-	UserHandle    string             `json:"user_handle"`
-}
-
-func (q *Queries) GetReviewsByProduct(ctx context.Context, arg GetReviewsByProductParams) ([]GetReviewsByProductRow, error) {
-	rows, err := q.db.Query(ctx, getReviewsByProduct,
-		arg.ProductID,
-		arg.Column2,
-		arg.Limit,
-		arg.Offset,
+func (q *Queries) GetReviewForUpdate(ctx context.Context, id uuid.UUID) (Review, error) {
+	row := q.db.QueryRow(ctx, getReviewForUpdate, id)
+	var i Review
+	err := row.Scan(
+		&i.ID,
+		&i.ProductID,
+		&i.UserID,
+		&i.Title,
+		&i.Body,
+		&i.Rating,
+		&i.Status,
+		&i.UpvoteCount,
+		&i.DownvoteCount,
+		&i.FlagCount,
+		&i.Edited,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
 	)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-	var items []GetReviewsByProductRow
-	for rows.Next() {
-		var i GetReviewsByProductRow
-		if err := rows.Scan(
-			&i.ID,
-			&i.ProductID,
-			&i.UserID,
-			&i.Title,
-			&i.Body,
-			&i.Rating,
-			&i.Status,
-			&i.UpvoteCount,
-			&i.DownvoteCount,
-			&i.FlagCount,
-			&i.Edited,
-			&i.CreatedAt,
-			&i.UpdatedAt,
-			&i.DeletedAt,
-			&i.UserHandle,
-		); err != nil {
-			return nil, err
-		}
-		items = append(items, i)
-	}
-	if err := rows.Err(); err != nil {
-		return nil, err
-	}
-	return items, nil
+	return i, err
 }
 
 const getReviewsByStatus = `-- name: GetReviewsByStatus :many
@@ -245,7 +275,7 @@ SELECT r.id, r.product_id, r.user_id, r.title, r.body, r.rating, r.status, r.upv
 FROM reviews r
 JOIN users u ON r.user_id = u.id
 JOIN products p ON r.product_id = p.id
-WHERE r.status = $1 AND r.deleted_at IS NULL AND u.deleted_at IS NULL AND p.deleted_at IS NULL
+WHERE r.status = $1 AND r.deleted_at IS NULL AND p.deleted_at IS NULL
 ORDER BY r.created_at ASC
 LIMIT $2 OFFSET $3
 `
@@ -312,23 +342,66 @@ func (q *Queries) GetReviewsByStatus(ctx context.Context, arg GetReviewsByStatus
 	return items, nil
 }
 
-const getReviewsByUser = `-- name: GetReviewsByUser :many
-SELECT r.id, r.product_id, r.user_id, r.title, r.body, r.rating, r.status, r.upvote_count, r.downvote_count, r.flag_count, r.edited, r.created_at, r.updated_at, r.deleted_at, p.name as product_name, p.slug as product_slug, c.name as company_name
+const getUserReviewForProduct = `-- name: GetUserReviewForProduct :one
+SELECT id, product_id, user_id, title, body, rating, status, upvote_count, downvote_count, flag_count, edited, created_at, updated_at, deleted_at FROM reviews
+WHERE product_id = $1 AND user_id = $2 AND deleted_at IS NULL
+`
+
+type GetUserReviewForProductParams struct {
+	ProductID uuid.UUID `json:"product_id"`
+	UserID    uuid.UUID `json:"user_id"`
+}
+
+func (q *Queries) GetUserReviewForProduct(ctx context.Context, arg GetUserReviewForProductParams) (Review, error) {
+	row := q.db.QueryRow(ctx, getUserReviewForProduct, arg.ProductID, arg.UserID)
+	var i Review
+	err := row.Scan(
+		&i.ID,
+		&i.ProductID,
+		&i.UserID,
+		&i.Title,
+		&i.Body,
+		&i.Rating,
+		&i.Status,
+		&i.UpvoteCount,
+		&i.DownvoteCount,
+		&i.FlagCount,
+		&i.Edited,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
+const hardDeleteReview = `-- name: HardDeleteReview :exec
+DELETE FROM reviews
+WHERE id = $1
+`
+
+func (q *Queries) HardDeleteReview(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.Exec(ctx, hardDeleteReview, id)
+	return err
+}
+
+const listFlaggedReviews = `-- name: ListFlaggedReviews :many
+SELECT r.id, r.product_id, r.user_id, r.title, r.body, r.rating, r.status, r.upvote_count, r.downvote_count, r.flag_count, r.edited, r.created_at, r.updated_at, r.deleted_at, u.handle as user_handle, p.name as product_name
 FROM reviews r
+JOIN users u ON r.user_id = u.id
 JOIN products p ON r.product_id = p.id
-JOIN companies c ON p.company_id = c.id
-WHERE r.user_id = $1 AND r.deleted_at IS NULL AND p.deleted_at IS NULL AND c.deleted_at IS NULL
-ORDER BY r.created_at DESC
+WHERE r.flag_count >= $1 AND r.status <> 'rejected'
+AND r.deleted_at IS NULL AND p.deleted_at IS NULL
+ORDER BY r.flag_count DESC, r.created_at ASC
 LIMIT $2 OFFSET $3
 `
 
-type GetReviewsByUserParams struct {
-	UserID uuid.UUID `json:"user_id"`
-	Limit  int32     `json:"limit"`
-	Offset int32     `json:"offset"`
+type ListFlaggedReviewsParams struct {
+	FlagCount int32 `json:"flag_count"`
+	Limit     int32 `json:"limit"`
+	Offset    int32 `json:"offset"`
 }
 
-type GetReviewsByUserRow struct {
+type ListFlaggedReviewsRow struct {
 	ID            uuid.UUID          `json:"id"`
 	ProductID     uuid.UUID          `json:"product_id"`
 	UserID        uuid.UUID          `json:"user_id"`
@@ -343,20 +416,19 @@ type GetReviewsByUserRow struct {
 	CreatedAt     pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt     pgtype.Timestamptz `json:"updated_at"`
 	DeletedAt     pgtype.Timestamptz `json:"deleted_at"`
+	UserHandle    string             `json:"user_handle"`
 	ProductName   string             `json:"product_name"`
-	ProductSlug   string             `json:"product_slug"`
-	CompanyName   string             `json:"company_name"`
 }
 
-func (q *Queries) GetReviewsByUser(ctx context.Context, arg GetReviewsByUserParams) ([]GetReviewsByUserRow, error) {
-	rows, err := q.db.Query(ctx, getReviewsByUser, arg.UserID, arg.Limit, arg.Offset)
+func (q *Queries) ListFlaggedReviews(ctx context.Context, arg ListFlaggedReviewsParams) ([]ListFlaggedReviewsRow, error) {
+	rows, err := q.db.Query(ctx, listFlaggedReviews, arg.FlagCount, arg.Limit, arg.Offset)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []GetReviewsByUserRow
+	var items []ListFlaggedReviewsRow
 	for rows.Next() {
-		var i GetReviewsByUserRow
+		var i ListFlaggedReviewsRow
 		if err := rows.Scan(
 			&i.ID,
 			&i.ProductID,
@@ -372,9 +444,8 @@ func (q *Queries) GetReviewsByUser(ctx context.Context, arg GetReviewsByUserPara
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.DeletedAt,
+			&i.UserHandle,
 			&i.ProductName,
-			&i.ProductSlug,
-			&i.CompanyName,
 		); err != nil {
 			return nil, err
 		}
@@ -386,87 +457,6 @@ func (q *Queries) GetReviewsByUser(ctx context.Context, arg GetReviewsByUserPara
 	return items, nil
 }
 
-const getUserReviewForProduct = `-- name: GetUserReviewForProduct :one
-SELECT id, product_id, user_id, title, body, rating, status, upvote_count, downvote_count, flag_count, edited, created_at, updated_at, deleted_at FROM reviews
-WHERE product_id = $1 AND user_id = $2 AND deleted_at IS NULL
-`
-
-type GetUserReviewForProductParams struct {
-	ProductID uuid.UUID `json:"product_id"`
-	UserID    uuid.UUID `json:"user_id"`
-}
-
-func (q *Queries) GetUserReviewForProduct(ctx context.Context, arg GetUserReviewForProductParams) (Review, error) {
-	row := q.db.QueryRow(ctx, getUserReviewForProduct, arg.ProductID, arg.UserID)
-	var i Review
-	err := row.Scan(
-		&i.ID,
-		&i.ProductID,
-		&i.UserID,
-		&i.Title,
-		&i.Body,
-		&i.Rating,
-		&i.Status,
-		&i.UpvoteCount,
-		&i.DownvoteCount,
-		&i.FlagCount,
-		&i.Edited,
-		&i.CreatedAt,
-		&i.UpdatedAt,
-		&i.DeletedAt,
-	)
-	return i, err
-}
-
-const hardDeleteReview = `-- name: HardDeleteReview :exec
-DELETE FROM reviews
-WHERE id = $1
-`
-
-func (q *Queries) HardDeleteReview(ctx context.Context, id uuid.UUID) error {
-	_, err := q.db.Exec(ctx, hardDeleteReview, id)
-	return err
-}
-
-const incrementDownvoteCount = `-- name: IncrementDownvoteCount :exec
-UPDATE reviews
-SET 
-    downvote_count = downvote_count + 1,
-    updated_at = NOW()
-WHERE id = $1 AND deleted_at IS NULL
-`
-
-func (q *Queries) IncrementDownvoteCount(ctx context.Context, id uuid.UUID) error {
-	_, err := q.db.Exec(ctx, incrementDownvoteCount, id)
-	return err
-}
-
-const incrementFlagCount = `-- name: IncrementFlagCount :exec
-UPDATE reviews
-SET 
-    flag_count = flag_count + 1,
-    updated_at = NOW()
-WHERE id = $1 AND deleted_at IS NULL
-`
-
-func (q *Queries) IncrementFlagCount(ctx context.Context, id uuid.UUID) error {
-	_, err := q.db.Exec(ctx, incrementFlagCount, id)
-	return err
-}
-
-const incrementUpvoteCount = `-- name: IncrementUpvoteCount :exec
-UPDATE reviews
-SET 
-    upvote_count = upvote_count + 1,
-    updated_at = NOW()
-WHERE id = $1 AND deleted_at IS NULL
-`
-
-func (q *Queries) IncrementUpvoteCount(ctx context.Context, id uuid.UUID) error {
-	_, err := q.db.Exec(ctx, incrementUpvoteCount, id)
-	return err
-}
-
 const softDeleteReview = `-- name: SoftDeleteReview :exec
 UPDATE reviews
 SET deleted_at = NOW()
@@ -12,6 +12,47 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const anonymizeUser = `-- name: AnonymizeUser :one
+UPDATE users
+SET
+    email = $2,
+    handle = $3,
+    email_verified_at = NULL,
+    deleted_at = $4,
+    updated_at = $4
+WHERE id = $1 AND deleted_at IS NULL
+RETURNING id, email, handle, role, created_at, updated_at, deleted_at, email_verified_at
+`
+
+type AnonymizeUserParams struct {
+	ID        uuid.UUID          `json:"id"`
+	Email     string             `json:"email"`
+	Handle    string             `json:"handle"`
+	DeletedAt pgtype.Timestamptz `json:"deleted_at"`
+}
+
+// Soft-deletes a user, replacing the email and handle so they can be taken again
+func (q *Queries) AnonymizeUser(ctx context.Context, arg AnonymizeUserParams) (User, error) {
+	row := q.db.QueryRow(ctx, anonymizeUser,
+		arg.ID,
+		arg.Email,
+		arg.Handle,
+		arg.DeletedAt,
+	)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Email,
+		&i.Handle,
+		&i.Role,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.EmailVerifiedAt,
+	)
+	return i, err
+}
+
 const countUsers = `-- name: CountUsers :one
 SELECT COUNT(*) FROM users
 WHERE deleted_at IS NULL
@@ -29,7 +70,7 @@ INSERT INTO users (
     id, email, handle, role, created_at, updated_at
 ) VALUES (
     $1, $2, $3, $4, $5, $6
-) RETURNING id, email, handle, role, created_at, updated_at, deleted_at
+) RETURNING id, email, handle, role, created_at, updated_at, deleted_at, email_verified_at
 `
 
 type CreateUserParams struct {
@@ -59,12 +100,13 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, e
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.EmailVerifiedAt,
 	)
 	return i, err
 }
 
 const getUser = `-- name: GetUser :one
-SELECT id, email, handle, role, created_at, updated_at, deleted_at FROM users
+SELECT id, email, handle, role, created_at, updated_at, deleted_at, email_verified_at FROM users
 WHERE id = $1 AND deleted_at IS NULL
 `
 
@@ -79,12 +121,13 @@ func (q *Queries) GetUser(ctx context.Context, id uuid.UUID) (User, error) {
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.EmailVerifiedAt,
 	)
 	return i, err
 }
 
 const getUserByEmail = `-- name: GetUserByEmail :one
-SELECT id, email, handle, role, created_at, updated_at, deleted_at FROM users
+SELECT id, email, handle, role, created_at, updated_at, deleted_at, email_verified_at FROM users
 WHERE email = $1 AND deleted_at IS NULL
 `
 
@@ -99,12 +142,13 @@ func (q *Queries) GetUserByEmail(ctx context.Context, email string) (User, error
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.EmailVerifiedAt,
 	)
 	return i, err
 }
 
 const getUserByHandle = `-- name: GetUserByHandle :one
-SELECT id, email, handle, role, created_at, updated_at, deleted_at FROM users
+SELECT id, email, handle, role, created_at, updated_at, deleted_at, email_verified_at FROM users
 WHERE handle = $1 AND deleted_at IS NULL
 `
 
@@ -119,6 +163,7 @@ func (q *Queries) GetUserByHandle(ctx context.Context, handle string) (User, err
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.EmailVerifiedAt,
 	)
 	return i, err
 }
@@ -134,7 +179,7 @@ func (q *Queries) HardDeleteUser(ctx context.Context, id uuid.UUID) error {
 }
 
 const listUsers = `-- name: ListUsers :many
-SELECT id, email, handle, role, created_at, updated_at, deleted_at FROM users
+SELECT id, email, handle, role, created_at, updated_at, deleted_at, email_verified_at FROM users
 WHERE deleted_at IS NULL
 ORDER BY created_at DESC
 LIMIT $1 OFFSET $2
@@ -162,6 +207,7 @@ func (q *Queries) ListUsers(ctx context.Context, arg ListUsersParams) ([]User, e
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.DeletedAt,
+			&i.EmailVerifiedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -173,6 +219,36 @@ func (q *Queries) ListUsers(ctx context.Context, arg ListUsersParams) ([]User, e
 	return items, nil
 }
 
+const setUserEmailVerified = `-- name: SetUserEmailVerified :one
+UPDATE users
+SET
+    email_verified_at = $2,
+    updated_at = $2
+WHERE id = $1 AND deleted_at IS NULL
+RETURNING id, email, handle, role, created_at, updated_at, deleted_at, email_verified_at
+`
+
+type SetUserEmailVerifiedParams struct {
+	ID              uuid.UUID          `json:"id"`
+	EmailVerifiedAt pgtype.Timestamptz `json:"email_verified_at"`
+}
+
+func (q *Queries) SetUserEmailVerified(ctx context.Context, arg SetUserEmailVerifiedParams) (User, error) {
+	row := q.db.QueryRow(ctx, setUserEmailVerified, arg.ID, arg.EmailVerifiedAt)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Email,
+		&i.Handle,
+		&i.Role,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.EmailVerifiedAt,
+	)
+	return i, err
+}
+
 const softDeleteUser = `-- name: SoftDeleteUser :exec
 UPDATE users
 SET deleted_at = NOW()
@@ -190,9 +266,10 @@ SET
     email = $2,
     handle = $3,
     role = $4,
+    email_verified_at = CASE WHEN email = $2 THEN email_verified_at END,
     updated_at = $5
 WHERE id = $1 AND deleted_at IS NULL
-RETURNING id, email, handle, role, created_at, updated_at, deleted_at
+RETURNING id, email, handle, role, created_at, updated_at, deleted_at, email_verified_at
 `
 
 type UpdateUserParams struct {
@@ -203,6 +280,7 @@ type UpdateUserParams struct {
 	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
 }
 
+// A new email address starts out unverified
 func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (User, error) {
 	row := q.db.QueryRow(ctx, updateUser,
 		arg.ID,
@@ -220,6 +298,7 @@ func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (User, e
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.EmailVerifiedAt,
 	)
 	return i, err
 }
@@ -0,0 +1,99 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"time"
+
+	"ratemysoft-backend/internal/models/sqlc"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// DefaultTxAttempts is how many times a unit of work runs before a retryable failure is returned
+const DefaultTxAttempts = 3
+
+// txRetryBackoff is the base delay before retrying; it doubles on every attempt
+const txRetryBackoff = 20 * time.Millisecond
+
+// TxManager runs units of work inside database transactions. A unit of work that fails
+// with a serialization failure or deadlock is rolled back and run again from the start,
+// so it must only touch the database through the queries it is given and must not keep
+// state from a previous attempt.
+type TxManager struct {
+	pool        *pgxpool.Pool
+	queries     *sqlc.Queries
+	maxAttempts int
+}
+
+func NewTxManager(pool *pgxpool.Pool, queries *sqlc.Queries) *TxManager {
+	return &TxManager{
+		pool:        pool,
+		queries:     queries,
+		maxAttempts: DefaultTxAttempts,
+	}
+}
+
+// InTx executes fn against transaction-scoped queries at the default isolation level,
+// committing only if fn succeeds
+func (m *TxManager) InTx(ctx context.Context, fn func(q *sqlc.Queries) error) error {
+	return m.InTxWithOptions(ctx, pgx.TxOptions{}, fn)
+}
+
+// InTxWithOptions is InTx with explicit transaction options such as a stricter isolation level
+func (m *TxManager) InTxWithOptions(ctx context.Context, opts pgx.TxOptions, fn func(q *sqlc.Queries) error) error {
+	var err error
+	for attempt := 1; ; attempt++ {
+		err = m.runOnce(ctx, opts, fn)
+		if err == nil || !isRetryableTxError(err) || attempt >= m.maxAttempts {
+			return err
+		}
+
+		// Back off with jitter so the transactions that collided don't collide again
+		delay := txRetryBackoff << (attempt - 1)
+		delay += rand.N(delay)
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(delay):
+		}
+	}
+}
+
+func (m *TxManager) runOnce(ctx context.Context, opts pgx.TxOptions, fn func(q *sqlc.Queries) error) error {
+	tx, err := m.pool.BeginTx(ctx, opts)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	// Rollback is a no-op once the transaction has been committed
+	defer tx.Rollback(ctx)
+
+	if err := fn(m.queries.WithTx(tx)); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// isRetryableTxError reports whether err aborted the transaction because it conflicted with
+// a concurrent one, in which case running it again can succeed
+func isRetryableTxError(err error) bool {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return false
+	}
+	switch pgErr.Code {
+	case "40001", // serialization_failure
+		"40P01": // deadlock_detected
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,70 @@
+package mail
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRender(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	data, err := render("RateMySoft <no-reply@example.com>", Message{
+		To:      "alice@example.com",
+		Subject: "Réinitialiser",
+		Body:    "line one\nline two\n",
+	}, now)
+	if err != nil {
+		t.Fatalf("render: %v", err)
+	}
+
+	want := "From: RateMySoft <no-reply@example.com>\r\n" +
+		"To: alice@example.com\r\n" +
+		"Subject: =?utf-8?q?R=C3=A9initialiser?=\r\n" +
+		"Date: Fri, 01 Mar 2024 12:00:00 +0000\r\n" +
+		"MIME-Version: 1.0\r\n" +
+		"Content-Type: text/plain; charset=utf-8\r\n" +
+		"Content-Transfer-Encoding: 8bit\r\n" +
+		"\r\n" +
+		"line one\r\nline two\r\n"
+	if string(data) != want {
+		t.Errorf("render =\n%q\nwant\n%q", data, want)
+	}
+}
+
+func TestRenderRejectsHeaderInjection(t *testing.T) {
+	for _, msg := range []Message{
+		{To: "not an address", Subject: "hi"},
+		{To: "alice@example.com\r\nBcc: eve@example.com", Subject: "hi"},
+		{To: "alice@example.com", Subject: "hi\r\nBcc: eve@example.com"},
+	} {
+		if _, err := render("no-reply@example.com", msg, time.Now()); err == nil {
+			t.Errorf("render(%+v) succeeded, want an error", msg)
+		}
+	}
+}
+
+func TestFileSink(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "mail")
+	sink, err := NewFileSink(dir, "no-reply@example.com")
+	if err != nil {
+		t.Fatalf("NewFileSink: %v", err)
+	}
+	if err := sink.Send(context.Background(), Message{To: "alice@example.com", Subject: "hi", Body: "hello"}); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.eml"))
+	if err != nil || len(files) != 1 {
+		t.Fatalf("files = %v, %v; want one .eml", files, err)
+	}
+	data, err := os.ReadFile(files[0])
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	if !strings.Contains(string(data), "To: alice@example.com\r\n") || !strings.HasSuffix(string(data), "\r\nhello\r\n") {
+		t.Errorf("file =\n%s", data)
+	}
+}
@@ -0,0 +1,59 @@
+// Package mailtest records outgoing mail so tests can follow the links in it.
+package mailtest
+
+import (
+	"context"
+	"net/url"
+	"regexp"
+	"sync"
+	"testing"
+
+	"ratemysoft-backend/internal/platform/mail"
+)
+
+var linkPattern = regexp.MustCompile(`https?://\S+`)
+
+// Recorder is a mail.Mailer that keeps every message instead of sending it
+type Recorder struct {
+	mu       sync.Mutex
+	messages []mail.Message
+}
+
+// Send implements mail.Mailer
+func (r *Recorder) Send(_ context.Context, msg mail.Message) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.messages = append(r.messages, msg)
+	return nil
+}
+
+// Messages returns the messages sent so far, oldest first
+func (r *Recorder) Messages() []mail.Message {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]mail.Message(nil), r.messages...)
+}
+
+// Last returns the latest message sent to addr, failing the test if there is none
+func (r *Recorder) Last(t testing.TB, addr string) mail.Message {
+	t.Helper()
+	messages := r.Messages()
+	for i := len(messages) - 1; i >= 0; i-- {
+		if messages[i].To == addr {
+			return messages[i]
+		}
+	}
+	t.Fatalf("no mail sent to %s", addr)
+	return mail.Message{}
+}
+
+// Token returns the token query parameter of the link in the latest message sent to addr
+func (r *Recorder) Token(t testing.TB, addr string) string {
+	t.Helper()
+	msg := r.Last(t, addr)
+	link, err := url.Parse(linkPattern.FindString(msg.Body))
+	if err != nil || link.Query().Get("token") == "" {
+		t.Fatalf("no token link in mail to %s:\n%s", addr, msg.Body)
+	}
+	return link.Query().Get("token")
+}
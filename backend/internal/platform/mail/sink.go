@@ -0,0 +1,61 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// LogSink prints every message instead of sending it, for local development
+type LogSink struct {
+	logger *log.Logger
+}
+
+// NewLogSink logs to logger, or to the standard logger when nil
+func NewLogSink(logger *log.Logger) *LogSink {
+	if logger == nil {
+		logger = log.Default()
+	}
+	return &LogSink{logger: logger}
+}
+
+// Send implements Mailer
+func (s *LogSink) Send(_ context.Context, msg Message) error {
+	s.logger.Printf("mail to %s: %s\n%s", msg.To, msg.Subject, msg.Body)
+	return nil
+}
+
+// FileSink writes every message to its own .eml file in a directory, for local development
+type FileSink struct {
+	dir  string
+	from string
+}
+
+// NewFileSink creates dir if needed and writes messages into it
+func NewFileSink(dir, from string) (*FileSink, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("mail: create %s: %w", dir, err)
+	}
+	return &FileSink{dir: dir, from: from}, nil
+}
+
+// Send implements Mailer
+func (s *FileSink) Send(_ context.Context, msg Message) error {
+	now := time.Now()
+	data, err := render(s.from, msg, now)
+	if err != nil {
+		return err
+	}
+
+	// Timestamped names sort in sending order
+	name := fmt.Sprintf("%s-%s.eml", now.UTC().Format("20060102T150405.000000000Z"), uuid.NewString()[:8])
+	if err := os.WriteFile(filepath.Join(s.dir, name), data, 0o600); err != nil {
+		return fmt.Errorf("mail: write %s: %w", name, err)
+	}
+	return nil
+}
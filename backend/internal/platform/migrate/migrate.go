@@ -0,0 +1,357 @@
+package migrate
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// lockKey is the pg_advisory_lock key held while migrations run, so concurrent
+// deploys or server starts apply each migration exactly once
+const lockKey int64 = 7263547789312
+
+var fileNamePattern = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.(up|down)\.sql$`)
+
+// Migration is one versioned schema change read from a pair of up/down files
+type Migration struct {
+	Version  int64
+	Name     string
+	Up       string
+	Down     string
+	Checksum string // SHA-256 of the up file, recorded when applied
+}
+
+// MigrationStatus reports whether a migration has been applied and whether its file changed since
+type MigrationStatus struct {
+	Migration
+	AppliedAt *time.Time
+	Modified  bool
+}
+
+// Migrator applies embedded SQL migrations and records them in schema_migrations
+type Migrator struct {
+	pool       *pgxpool.Pool
+	migrations []Migration
+}
+
+type appliedMigration struct {
+	version   int64
+	checksum  string
+	appliedAt time.Time
+}
+
+// New loads the migration files at the root of fsys; every version needs both an up and a down file
+func New(pool *pgxpool.Pool, fsys fs.FS) (*Migrator, error) {
+	migrations, err := load(fsys)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Migrator{
+		pool:       pool,
+		migrations: migrations,
+	}, nil
+}
+
+// Up applies every pending migration in version order and returns the ones it applied
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	var applied []Migration
+	err := m.withLock(ctx, func(conn *pgxpool.Conn) error {
+		done, err := m.verify(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range m.migrations {
+			if _, ok := done[migration.Version]; ok {
+				continue
+			}
+			if err := apply(ctx, conn, migration); err != nil {
+				return err
+			}
+			applied = append(applied, migration)
+		}
+		return nil
+	})
+	return applied, err
+}
+
+// Down rolls back the most recently applied migrations, newest first, and returns the ones it rolled back
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	if steps < 1 {
+		return nil, fmt.Errorf("invalid step count: %d", steps)
+	}
+
+	var rolledBack []Migration
+	err := m.withLock(ctx, func(conn *pgxpool.Conn) error {
+		done, err := m.verify(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for i := len(m.migrations) - 1; i >= 0 && len(rolledBack) < steps; i-- {
+			migration := m.migrations[i]
+			if _, ok := done[migration.Version]; !ok {
+				continue
+			}
+			if err := revert(ctx, conn, migration); err != nil {
+				return err
+			}
+			rolledBack = append(rolledBack, migration)
+		}
+		return nil
+	})
+	return rolledBack, err
+}
+
+// Baseline records every migration up to and including version as applied without running it,
+// and returns the ones it recorded. It adopts a database whose schema was created some other way,
+// such as by the docker-entrypoint-initdb.d scripts that predate the runner, so that Up only
+// applies the migrations after it.
+func (m *Migrator) Baseline(ctx context.Context, version int64) ([]Migration, error) {
+	known := false
+	for _, migration := range m.migrations {
+		known = known || migration.Version == version
+	}
+	if !known {
+		return nil, fmt.Errorf("unknown migration version %d", version)
+	}
+
+	var recorded []Migration
+	err := m.withLock(ctx, func(conn *pgxpool.Conn) error {
+		done, err := m.verify(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		return pgx.BeginFunc(ctx, conn, func(tx pgx.Tx) error {
+			for _, migration := range m.migrations {
+				if migration.Version > version {
+					break
+				}
+				if _, ok := done[migration.Version]; ok {
+					continue
+				}
+				if _, err := tx.Exec(ctx,
+					"INSERT INTO schema_migrations (version, name, checksum) VALUES ($1, $2, $3)",
+					migration.Version, migration.Name, migration.Checksum,
+				); err != nil {
+					return fmt.Errorf("failed to record migration %d: %w", migration.Version, err)
+				}
+				recorded = append(recorded, migration)
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return recorded, nil
+}
+
+// Status lists every known migration with when it was applied; it does not fail on checksum mismatches
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	var statuses []MigrationStatus
+	err := m.withLock(ctx, func(conn *pgxpool.Conn) error {
+		done, err := appliedMigrations(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		statuses = make([]MigrationStatus, 0, len(m.migrations))
+		for _, migration := range m.migrations {
+			status := MigrationStatus{Migration: migration}
+			if a, ok := done[migration.Version]; ok {
+				appliedAt := a.appliedAt
+				status.AppliedAt = &appliedAt
+				status.Modified = a.checksum != migration.Checksum
+			}
+			statuses = append(statuses, status)
+		}
+		return nil
+	})
+	return statuses, err
+}
+
+// withLock runs fn on a dedicated connection holding the migration advisory lock.
+// Advisory locks are per session, so every statement must go through that one connection.
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *pgxpool.Conn) error) error {
+	conn, err := m.pool.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to acquire connection: %w", err)
+	}
+	defer conn.Release()
+
+	if _, err := conn.Exec(ctx, "SELECT pg_advisory_lock($1)", lockKey); err != nil {
+		return fmt.Errorf("failed to acquire migration lock: %w", err)
+	}
+	defer func() {
+		// Use a fresh context so the lock is released even if ctx was cancelled
+		unlockCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_, _ = conn.Exec(unlockCtx, "SELECT pg_advisory_unlock($1)", lockKey)
+	}()
+
+	if _, err := conn.Exec(ctx, `
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+		  version bigint PRIMARY KEY,
+		  name text NOT NULL,
+		  checksum text NOT NULL,
+		  applied_at timestamptz NOT NULL DEFAULT NOW()
+		)`); err != nil {
+		return fmt.Errorf("failed to create schema_migrations table: %w", err)
+	}
+
+	return fn(conn)
+}
+
+// verify refuses to continue if an applied migration was edited or deleted after it ran
+func (m *Migrator) verify(ctx context.Context, conn *pgxpool.Conn) (map[int64]appliedMigration, error) {
+	done, err := appliedMigrations(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+
+	known := make(map[int64]Migration, len(m.migrations))
+	for _, migration := range m.migrations {
+		known[migration.Version] = migration
+	}
+
+	for version, a := range done {
+		migration, ok := known[version]
+		if !ok {
+			return nil, fmt.Errorf("applied migration %d has no migration file", version)
+		}
+		if a.checksum != migration.Checksum {
+			return nil, fmt.Errorf("checksum mismatch for migration %d (%s): file was modified after it was applied", version, migration.Name)
+		}
+	}
+
+	return done, nil
+}
+
+func appliedMigrations(ctx context.Context, conn *pgxpool.Conn) (map[int64]appliedMigration, error) {
+	rows, err := conn.Query(ctx, "SELECT version, checksum, applied_at FROM schema_migrations")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read schema_migrations: %w", err)
+	}
+	defer rows.Close()
+
+	done := make(map[int64]appliedMigration)
+	for rows.Next() {
+		var a appliedMigration
+		if err := rows.Scan(&a.version, &a.checksum, &a.appliedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan schema_migrations: %w", err)
+		}
+		done[a.version] = a
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read schema_migrations: %w", err)
+	}
+
+	return done, nil
+}
+
+// apply runs an up migration and records it in the same transaction
+func apply(ctx context.Context, conn *pgxpool.Conn, migration Migration) error {
+	return pgx.BeginFunc(ctx, conn, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, migration.Up); err != nil {
+			return fmt.Errorf("failed to apply migration %d (%s): %w", migration.Version, migration.Name, err)
+		}
+		if _, err := tx.Exec(ctx,
+			"INSERT INTO schema_migrations (version, name, checksum) VALUES ($1, $2, $3)",
+			migration.Version, migration.Name, migration.Checksum,
+		); err != nil {
+			return fmt.Errorf("failed to record migration %d: %w", migration.Version, err)
+		}
+		return nil
+	})
+}
+
+// revert runs a down migration and removes its record in the same transaction
+func revert(ctx context.Context, conn *pgxpool.Conn, migration Migration) error {
+	return pgx.BeginFunc(ctx, conn, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, migration.Down); err != nil {
+			return fmt.Errorf("failed to roll back migration %d (%s): %w", migration.Version, migration.Name, err)
+		}
+		if _, err := tx.Exec(ctx, "DELETE FROM schema_migrations WHERE version = $1", migration.Version); err != nil {
+			return fmt.Errorf("failed to unrecord migration %d: %w", migration.Version, err)
+		}
+		return nil
+	})
+}
+
+// load reads NNNN_name.up.sql / NNNN_name.down.sql pairs and sorts them by version
+func load(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations: %w", err)
+	}
+
+	byVersion := make(map[int64]*Migration)
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		match := fileNamePattern.FindStringSubmatch(entry.Name())
+		if match == nil {
+			continue
+		}
+
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid migration version in %s: %w", entry.Name(), err)
+		}
+
+		contents, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", entry.Name(), err)
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = migration
+		} else if migration.Name != match[2] {
+			return nil, fmt.Errorf("migration %d has conflicting names %q and %q", version, migration.Name, match[2])
+		}
+
+		if match[3] == "up" {
+			sum := sha256.Sum256(contents)
+			migration.Up = string(contents)
+			migration.Checksum = hex.EncodeToString(sum[:])
+		} else {
+			migration.Down = string(contents)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Up == "" {
+			return nil, fmt.Errorf("migration %d (%s) is missing its up file", migration.Version, migration.Name)
+		}
+		if migration.Down == "" {
+			return nil, fmt.Errorf("migration %d (%s) is missing its down file", migration.Version, migration.Name)
+		}
+		migrations = append(migrations, *migration)
+	}
+	if len(migrations) == 0 {
+		return nil, errors.New("no migrations found")
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
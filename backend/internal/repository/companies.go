@@ -0,0 +1,34 @@
+package repository
+
+import (
+	"context"
+
+	"ratemysoft-backend/internal/models/sqlc"
+
+	"github.com/google/uuid"
+)
+
+// CompanyRepository stores companies along with their members and ownership claims
+type CompanyRepository interface {
+	CreateCompany(ctx context.Context, arg sqlc.CreateCompanyParams) (sqlc.Company, error)
+	GetCompany(ctx context.Context, id uuid.UUID) (sqlc.Company, error)
+	// GetCompanyForUpdate locks the company until the transaction ends
+	GetCompanyForUpdate(ctx context.Context, id uuid.UUID) (sqlc.Company, error)
+	GetCompanyBySlug(ctx context.Context, slug string) (sqlc.Company, error)
+	UpdateCompany(ctx context.Context, arg sqlc.UpdateCompanyParams) (sqlc.Company, error)
+	SoftDeleteCompany(ctx context.Context, id uuid.UUID) error
+	CountCompanies(ctx context.Context) (int64, error)
+
+	GetCompanyMember(ctx context.Context, arg sqlc.GetCompanyMemberParams) (sqlc.CompanyMember, error)
+	UpsertCompanyMember(ctx context.Context, arg sqlc.UpsertCompanyMemberParams) (sqlc.CompanyMember, error)
+	DeleteCompanyMember(ctx context.Context, arg sqlc.DeleteCompanyMemberParams) (int64, error)
+	ListCompanyMembers(ctx context.Context, companyID uuid.UUID) ([]sqlc.ListCompanyMembersRow, error)
+	ListUserCompanyMemberships(ctx context.Context, userID uuid.UUID) ([]sqlc.CompanyMember, error)
+	CountCompanyOwners(ctx context.Context, companyID uuid.UUID) (int64, error)
+
+	CreateCompanyClaim(ctx context.Context, arg sqlc.CreateCompanyClaimParams) (sqlc.CompanyClaim, error)
+	GetCompanyClaim(ctx context.Context, id uuid.UUID) (sqlc.CompanyClaim, error)
+	GetPendingCompanyClaim(ctx context.Context, arg sqlc.GetPendingCompanyClaimParams) (sqlc.CompanyClaim, error)
+	ResolveCompanyClaim(ctx context.Context, arg sqlc.ResolveCompanyClaimParams) (sqlc.CompanyClaim, error)
+	CountCompanyClaimsByStatus(ctx context.Context, status string) (int64, error)
+}
@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"slices"
+
+	"ratemysoft-backend/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+// KeyedRow is a row of a listing page with its ID and its sort keys as text, the form in
+// which cursors carry them
+type KeyedRow[T any] struct {
+	Row  T
+	ID   uuid.UUID
+	Keys []string
+}
+
+// CheckCursor rejects cursors issued for an order other than the named one with this many keys
+func CheckCursor(order string, keys int, cursor *domain.Cursor) error {
+	if cursor == nil {
+		return nil
+	}
+	if cursor.Sort != order || len(cursor.Keys) != keys {
+		return ErrCursorMismatch
+	}
+	return nil
+}
+
+// FinishPage turns the rows fetched for a page, in scan direction and with one look-ahead row
+// past page.Limit when another page follows, into the page in list order along with the
+// cursors of the neighbouring pages. A backward cursor scans in reverse list order.
+func FinishPage[T any](order string, rows []KeyedRow[T], page domain.PageRequest) ([]T, domain.PageCursors) {
+	more := len(rows) > int(page.Limit)
+	if more {
+		rows = rows[:page.Limit]
+	}
+
+	backward := page.Cursor != nil && page.Cursor.Backward
+	if backward {
+		rows = slices.Clone(rows)
+		slices.Reverse(rows)
+	}
+
+	items := make([]T, 0, len(rows))
+	for _, row := range rows {
+		items = append(items, row.Row)
+	}
+	if len(rows) == 0 {
+		return items, domain.PageCursors{}
+	}
+
+	cursorAt := func(row KeyedRow[T], backward bool) *domain.Cursor {
+		return &domain.Cursor{Sort: order, Keys: row.Keys, ID: row.ID, Backward: backward}
+	}
+	first, last := rows[0], rows[len(rows)-1]
+
+	// Paging backward, the look-ahead row tells whether earlier rows exist and the
+	// page we came from always follows; paging forward it is the other way round
+	var cursors domain.PageCursors
+	if backward {
+		cursors.Next = cursorAt(last, false)
+		if more {
+			cursors.Prev = cursorAt(first, true)
+		}
+	} else {
+		if more {
+			cursors.Next = cursorAt(last, false)
+		}
+		if page.Cursor != nil || page.Offset > 0 {
+			cursors.Prev = cursorAt(first, true)
+		}
+	}
+	return items, cursors
+}
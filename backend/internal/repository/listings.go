@@ -0,0 +1,137 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"ratemysoft-backend/internal/domain"
+	"ratemysoft-backend/internal/models/sqlc"
+
+	"github.com/google/uuid"
+)
+
+// ErrCursorMismatch is returned by a listing given a cursor issued for a different order
+var ErrCursorMismatch = errors.New("cursor does not match the listing order")
+
+// ProductOrder is an ordering of the product listing; ties fall back to the product ID
+type ProductOrder string
+
+const (
+	ProductsByNewest      ProductOrder = "newest"       // creation time, newest first
+	ProductsByRating      ProductOrder = "rating"       // average rating, highest first; unrated products count as 0
+	ProductsByReviewCount ProductOrder = "review_count" // review count, most first
+	ProductsByName        ProductOrder = "name"         // name, A to Z
+	ProductsByTrending    ProductOrder = "trending"     // published reviews of the last 30 days, most first
+)
+
+// ReviewOrder is an ordering of a product's reviews; ties fall back to the newest review
+type ReviewOrder string
+
+const (
+	ReviewsByRecent     ReviewOrder = "recent"
+	ReviewsByUpvotes    ReviewOrder = "upvotes"
+	ReviewsByRatingDesc ReviewOrder = "rating_desc"
+	ReviewsByRatingAsc  ReviewOrder = "rating_asc"
+	// ReviewsByHelpful weights upvotes by the author's reputation, so trusted reviewers
+	// surface first even before their review collects votes:
+	// (upvotes + 1) * (1 + ln(1 + reputation)), where authors without a reputation count as 0
+	ReviewsByHelpful ReviewOrder = "helpful"
+)
+
+// ProductFilter narrows the product listing; zero fields do not filter
+type ProductFilter struct {
+	Category     string // slug; matches the category and all of its descendants
+	CompanyID    *uuid.UUID
+	CompanySlug  string
+	MinRating    *float64
+	MinReviews   *int32
+	Tags         []string // tag slugs
+	MatchAllTags bool     // require every tag instead of at least one
+	CreatedAfter *time.Time
+}
+
+// ProductSearch is a full-text product search with its optional filters
+type ProductSearch struct {
+	Query        string
+	Category     string // slug; matches the category and all of its descendants
+	MinRating    *float64
+	Tags         []string // tag slugs
+	MatchAllTags bool     // require every tag instead of at least one
+}
+
+// LeaderboardFilter selects the scores of one leaderboard window
+type LeaderboardFilter struct {
+	Window   domain.LeaderboardWindow
+	Category string // optional slug; matches the category and all of its descendants
+}
+
+// LeaderboardRow is a leaderboard score with its 1-based rank across the whole listing
+type LeaderboardRow struct {
+	sqlc.ListLeaderboardRow
+	Rank int64
+}
+
+// AuditEventRow is an audit event with its actor's handle, which is empty when the actor is unknown
+type AuditEventRow struct {
+	sqlc.AuditEvent
+	ActorHandle string
+}
+
+// ListingRepository pages through the listings whose filters and order are chosen per request.
+// A page request carries an offset for the first pages or a cursor taken from a neighbouring
+// page; each page comes back in list order with the cursors of its own neighbours. Listings
+// read outside of units of work, so they belong to the Store rather than to Queries.
+type ListingRepository interface {
+	// ListProductsPage lists the products of live companies
+	ListProductsPage(ctx context.Context, filter ProductFilter, order ProductOrder, page domain.PageRequest) ([]sqlc.Product, domain.PageCursors, error)
+	CountProductsMatching(ctx context.Context, filter ProductFilter) (int64, error)
+	// SearchProductsPage lists the live products matching a full-text search, most relevant
+	// first, then by name
+	SearchProductsPage(ctx context.Context, search ProductSearch, page domain.PageRequest) ([]sqlc.SearchProductsRow, domain.PageCursors, error)
+	CountSearchMatches(ctx context.Context, search ProductSearch) (int64, error)
+
+	// ListLeaderboardPage lists the live products of a leaderboard window by score, highest
+	// first, then by review count and name
+	ListLeaderboardPage(ctx context.Context, filter LeaderboardFilter, score domain.LeaderboardScore, page domain.PageRequest) ([]LeaderboardRow, domain.PageCursors, error)
+	CountLeaderboardEntries(ctx context.Context, filter LeaderboardFilter) (int64, error)
+
+	// ListCompaniesPage lists companies, newest first
+	ListCompaniesPage(ctx context.Context, page domain.PageRequest) ([]sqlc.Company, domain.PageCursors, error)
+	// SearchCompaniesPage lists the companies whose name or slug contains query, ignoring case, by name
+	SearchCompaniesPage(ctx context.Context, query string, page domain.PageRequest) ([]sqlc.Company, domain.PageCursors, error)
+	CountCompaniesMatching(ctx context.Context, query string) (int64, error)
+	// ListCompanyClaimsPage lists the claims with a status, oldest first
+	ListCompanyClaimsPage(ctx context.Context, status string, page domain.PageRequest) ([]sqlc.ListCompanyClaimsByStatusRow, domain.PageCursors, error)
+
+	// ListProductReviewsPage lists the published reviews of a product
+	ListProductReviewsPage(ctx context.Context, productID uuid.UUID, order ReviewOrder, page domain.PageRequest) ([]sqlc.Review, domain.PageCursors, error)
+	// ListUserReviewsPage lists the reviews of a live user on live products, newest first;
+	// pending and rejected reviews only with includeUnpublished
+	ListUserReviewsPage(ctx context.Context, userID uuid.UUID, includeUnpublished bool, page domain.PageRequest) ([]sqlc.Review, domain.PageCursors, error)
+
+	// ListReviewsByStatusPage lists the reviews with a status on live products, oldest first
+	ListReviewsByStatusPage(ctx context.Context, status string, page domain.PageRequest) ([]sqlc.GetReviewsByStatusRow, domain.PageCursors, error)
+	// ListFlaggedReviewsPage lists the unrejected reviews with at least minFlags flags on live
+	// products, most flagged first, then oldest first
+	ListFlaggedReviewsPage(ctx context.Context, minFlags int32, page domain.PageRequest) ([]sqlc.ListFlaggedReviewsRow, domain.PageCursors, error)
+
+	// ListCommentsByStatusPage lists the comments with a status, oldest first
+	ListCommentsByStatusPage(ctx context.Context, status string, page domain.PageRequest) ([]sqlc.ListReviewCommentsByStatusRow, domain.PageCursors, error)
+	// ListFlaggedCommentsPage lists the unrejected comments with at least minFlags flags,
+	// most flagged first, then oldest first
+	ListFlaggedCommentsPage(ctx context.Context, minFlags int32, page domain.PageRequest) ([]sqlc.ListFlaggedReviewCommentsRow, domain.PageCursors, error)
+
+	// ListTagsPage lists the tags whose slug starts with prefix, most used on live products first
+	ListTagsPage(ctx context.Context, prefix string, page domain.PageRequest) ([]sqlc.ListTagsRow, domain.PageCursors, error)
+	CountTagsMatching(ctx context.Context, prefix string) (int64, error)
+	// ListTagSuggestionsPage lists a product's tag suggestions with a status, oldest first
+	ListTagSuggestionsPage(ctx context.Context, productID uuid.UUID, status string, page domain.PageRequest) ([]sqlc.ListTagSuggestionsByProductRow, domain.PageCursors, error)
+
+	// ListProductRatingEventsPage lists the rating changes of a product, newest first
+	ListProductRatingEventsPage(ctx context.Context, productID uuid.UUID, page domain.PageRequest) ([]sqlc.ReviewRatingEvent, domain.PageCursors, error)
+
+	// ListAuditEventsPage lists the audit events matching the filter, newest first
+	ListAuditEventsPage(ctx context.Context, filter domain.AuditFilter, page domain.PageRequest) ([]AuditEventRow, domain.PageCursors, error)
+	CountAuditEvents(ctx context.Context, filter domain.AuditFilter) (int64, error)
+}
@@ -0,0 +1,93 @@
+package memory
+
+import (
+	"context"
+
+	"ratemysoft-backend/internal/models/sqlc"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func (q *queries) CreateAccountToken(ctx context.Context, arg sqlc.CreateAccountTokenParams) (sqlc.AccountToken, error) {
+	st, done := q.begin()
+	defer done()
+
+	if _, ok := st.tokens[arg.ID]; ok {
+		return sqlc.AccountToken{}, uniqueViolation("account_tokens_pkey")
+	}
+	for _, t := range st.tokens {
+		if t.TokenHash == arg.TokenHash {
+			return sqlc.AccountToken{}, uniqueViolation("account_tokens_token_hash_key")
+		}
+	}
+	if _, ok := st.users[arg.UserID]; !ok {
+		return sqlc.AccountToken{}, foreignKeyViolation("account_tokens_user_id_fkey")
+	}
+
+	t := sqlc.AccountToken{
+		ID:        arg.ID,
+		UserID:    arg.UserID,
+		Purpose:   arg.Purpose,
+		TokenHash: arg.TokenHash,
+		Email:     arg.Email,
+		ExpiresAt: arg.ExpiresAt,
+		CreatedAt: arg.CreatedAt,
+	}
+	st.tokens[t.ID] = t
+	return t, nil
+}
+
+func (q *queries) GetAccountTokenByHash(ctx context.Context, tokenHash string) (sqlc.AccountToken, error) {
+	st, done := q.begin()
+	defer done()
+
+	for _, t := range st.tokens {
+		if t.TokenHash == tokenHash {
+			return t, nil
+		}
+	}
+	return sqlc.AccountToken{}, pgx.ErrNoRows
+}
+
+func (q *queries) UseAccountToken(ctx context.Context, arg sqlc.UseAccountTokenParams) (int64, error) {
+	st, done := q.begin()
+	defer done()
+
+	t, ok := st.tokens[arg.ID]
+	if !ok || t.UsedAt.Valid {
+		return 0, nil
+	}
+	t.UsedAt = arg.UsedAt
+	st.tokens[t.ID] = t
+	return 1, nil
+}
+
+func (q *queries) UseUserAccountTokens(ctx context.Context, arg sqlc.UseUserAccountTokensParams) (int64, error) {
+	st, done := q.begin()
+	defer done()
+
+	var n int64
+	for id, t := range st.tokens {
+		if t.UserID == arg.UserID && t.Purpose == arg.Purpose && !t.UsedAt.Valid {
+			t.UsedAt = arg.UsedAt
+			st.tokens[id] = t
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (q *queries) DeleteExpiredAccountTokens(ctx context.Context, expiresAt pgtype.Timestamptz) (int64, error) {
+	st, done := q.begin()
+	defer done()
+
+	var n int64
+	for id, t := range st.tokens {
+		if compareTime(t.ExpiresAt, expiresAt) < 0 {
+			delete(st.tokens, id)
+			n++
+		}
+	}
+	return n, nil
+}
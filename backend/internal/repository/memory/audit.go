@@ -0,0 +1,57 @@
+package memory
+
+import (
+	"context"
+	"encoding/json"
+
+	"ratemysoft-backend/internal/models/sqlc"
+)
+
+func (q *queries) CreateAuditEvent(ctx context.Context, arg sqlc.CreateAuditEventParams) error {
+	st, done := q.begin()
+	defer done()
+
+	st.auditEvents = append(st.auditEvents, sqlc.AuditEvent(arg))
+	return nil
+}
+
+func (q *queries) RedactUserAuditEvents(ctx context.Context, arg sqlc.RedactUserAuditEventsParams) (int64, error) {
+	st, done := q.begin()
+	defer done()
+
+	redact := func(data []byte) ([]byte, error) {
+		if data == nil {
+			return nil, nil
+		}
+		var fields map[string]any
+		if err := json.Unmarshal(data, &fields); err != nil {
+			return nil, err
+		}
+		if _, ok := fields["email"]; ok {
+			fields["email"] = arg.Email
+		}
+		if _, ok := fields["handle"]; ok {
+			fields["handle"] = arg.Handle
+		}
+		return json.Marshal(fields)
+	}
+
+	var n int64
+	for i, e := range st.auditEvents {
+		if e.EntityType != "user" || e.EntityID != arg.UserID {
+			continue
+		}
+		before, err := redact(e.Before)
+		if err != nil {
+			return 0, err
+		}
+		after, err := redact(e.After)
+		if err != nil {
+			return 0, err
+		}
+		st.auditEvents[i].Before = before
+		st.auditEvents[i].After = after
+		n++
+	}
+	return n, nil
+}
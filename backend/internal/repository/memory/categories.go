@@ -0,0 +1,248 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"strings"
+
+	"ratemysoft-backend/internal/models/sqlc"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// seedCategories inserts the categories created by the categories migration
+func (s *Store) seedCategories() {
+	seeds := []struct {
+		slug, name, description, icon string
+		sortOrder                     int32
+	}{
+		{"hosting", "Web Hosting", "Hosting and deployment platforms", "server", 10},
+		{"feature_toggles", "Feature Management", "Feature flags and experimentation", "toggle-right", 20},
+		{"ci_cd", "CI/CD & DevOps", "Continuous integration and delivery", "git-branch", 30},
+		{"observability", "Monitoring & Analytics", "Logging, metrics, tracing and alerting", "activity", 40},
+		{"other", "Other Tools", "Everything else", "box", 1000},
+	}
+
+	ts := now()
+	for _, seed := range seeds {
+		c := sqlc.Category{
+			ID:          uuid.New(),
+			Slug:        seed.slug,
+			Name:        seed.name,
+			Description: &seed.description,
+			Icon:        &seed.icon,
+			SortOrder:   seed.sortOrder,
+			CreatedAt:   ts,
+			UpdatedAt:   ts,
+		}
+		s.state.categories[c.ID] = c
+	}
+}
+
+func (q *queries) CreateCategory(ctx context.Context, arg sqlc.CreateCategoryParams) (sqlc.Category, error) {
+	st, done := q.begin()
+	defer done()
+
+	for _, c := range st.categories {
+		switch {
+		case c.ID == arg.ID:
+			return sqlc.Category{}, uniqueViolation("categories_pkey")
+		case c.Slug == arg.Slug:
+			return sqlc.Category{}, uniqueViolation("categories_slug_key")
+		}
+	}
+	if arg.ParentID != nil {
+		if _, ok := st.categories[*arg.ParentID]; !ok {
+			return sqlc.Category{}, foreignKeyViolation("categories_parent_id_fkey")
+		}
+	}
+
+	c := sqlc.Category{
+		ID:          arg.ID,
+		Slug:        arg.Slug,
+		Name:        arg.Name,
+		Description: arg.Description,
+		Icon:        arg.Icon,
+		ParentID:    arg.ParentID,
+		SortOrder:   arg.SortOrder,
+		CreatedAt:   arg.CreatedAt,
+		UpdatedAt:   arg.UpdatedAt,
+	}
+	st.categories[c.ID] = c
+	return c, nil
+}
+
+func (q *queries) GetCategory(ctx context.Context, id uuid.UUID) (sqlc.Category, error) {
+	st, done := q.begin()
+	defer done()
+
+	c, ok := st.categories[id]
+	if !ok {
+		return sqlc.Category{}, pgx.ErrNoRows
+	}
+	return c, nil
+}
+
+func (q *queries) GetCategoryBySlug(ctx context.Context, slug string) (sqlc.Category, error) {
+	st, done := q.begin()
+	defer done()
+
+	for _, c := range st.categories {
+		if c.Slug == slug {
+			return c, nil
+		}
+	}
+	return sqlc.Category{}, pgx.ErrNoRows
+}
+
+func (q *queries) ListCategories(ctx context.Context) ([]sqlc.ListCategoriesRow, error) {
+	st, done := q.begin()
+	defer done()
+
+	counts := map[string]int64{}
+	for _, p := range st.products {
+		if !deleted(p.DeletedAt) {
+			counts[p.Category]++
+		}
+	}
+
+	rows := make([]sqlc.ListCategoriesRow, 0, len(st.categories))
+	for _, c := range st.categories {
+		rows = append(rows, sqlc.ListCategoriesRow{
+			ID:           c.ID,
+			Slug:         c.Slug,
+			Name:         c.Name,
+			Description:  c.Description,
+			Icon:         c.Icon,
+			ParentID:     c.ParentID,
+			SortOrder:    c.SortOrder,
+			CreatedAt:    c.CreatedAt,
+			UpdatedAt:    c.UpdatedAt,
+			ProductCount: counts[c.Slug],
+		})
+	}
+	slices.SortFunc(rows, func(a, b sqlc.ListCategoriesRow) int {
+		return cmp.Or(cmp.Compare(a.SortOrder, b.SortOrder), strings.Compare(a.Name, b.Name))
+	})
+	return rows, nil
+}
+
+// UpdateCategory carries a renamed slug over to the products filed under it, like the
+// ON UPDATE CASCADE on products.category
+func (q *queries) UpdateCategory(ctx context.Context, arg sqlc.UpdateCategoryParams) (sqlc.Category, error) {
+	st, done := q.begin()
+	defer done()
+
+	c, ok := st.categories[arg.ID]
+	if !ok {
+		return sqlc.Category{}, pgx.ErrNoRows
+	}
+	for _, other := range st.categories {
+		if other.ID != arg.ID && other.Slug == arg.Slug {
+			return sqlc.Category{}, uniqueViolation("categories_slug_key")
+		}
+	}
+	if arg.ParentID != nil {
+		if _, ok := st.categories[*arg.ParentID]; !ok {
+			return sqlc.Category{}, foreignKeyViolation("categories_parent_id_fkey")
+		}
+	}
+
+	if c.Slug != arg.Slug {
+		for id, p := range st.products {
+			if p.Category == c.Slug {
+				p.Category = arg.Slug
+				st.products[id] = p
+			}
+		}
+	}
+
+	c.Slug = arg.Slug
+	c.Name = arg.Name
+	c.Description = arg.Description
+	c.Icon = arg.Icon
+	c.ParentID = arg.ParentID
+	c.SortOrder = arg.SortOrder
+	c.UpdatedAt = arg.UpdatedAt
+	st.categories[c.ID] = c
+	return c, nil
+}
+
+func (q *queries) DeleteCategory(ctx context.Context, id uuid.UUID) error {
+	st, done := q.begin()
+	defer done()
+
+	c, ok := st.categories[id]
+	if !ok {
+		return nil
+	}
+	for _, child := range st.categories {
+		if child.ParentID != nil && *child.ParentID == id {
+			return foreignKeyViolation("categories_parent_id_fkey")
+		}
+	}
+	for _, p := range st.products {
+		if p.Category == c.Slug {
+			return foreignKeyViolation("products_category_fkey")
+		}
+	}
+	delete(st.categories, id)
+	return nil
+}
+
+func (q *queries) CountCategoryChildren(ctx context.Context, parentID *uuid.UUID) (int64, error) {
+	st, done := q.begin()
+	defer done()
+
+	var n int64
+	if parentID == nil {
+		return n, nil
+	}
+	for _, c := range st.categories {
+		if c.ParentID != nil && *c.ParentID == *parentID {
+			n++
+		}
+	}
+	return n, nil
+}
+
+// CountProductsInCategory counts soft-deleted products too, since they still reference
+// their category
+func (q *queries) CountProductsInCategory(ctx context.Context, category string) (int64, error) {
+	st, done := q.begin()
+	defer done()
+
+	var n int64
+	for _, p := range st.products {
+		if p.Category == category {
+			n++
+		}
+	}
+	return n, nil
+}
+
+// categorySubtree returns the slugs of a category and all of its descendants; visited
+// categories are skipped so a cycle in the hierarchy cannot make it loop
+func (st *state) categorySubtree(slug string) map[string]bool {
+	subtree := map[string]bool{}
+	var ids []uuid.UUID
+	for _, c := range st.categories {
+		if c.Slug == slug {
+			subtree[c.Slug] = true
+			ids = append(ids, c.ID)
+		}
+	}
+	for len(ids) > 0 {
+		parent := ids[0]
+		ids = ids[1:]
+		for _, c := range st.categories {
+			if c.ParentID != nil && *c.ParentID == parent && !subtree[c.Slug] {
+				subtree[c.Slug] = true
+				ids = append(ids, c.ID)
+			}
+		}
+	}
+	return subtree
+}
@@ -0,0 +1,265 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"maps"
+	"slices"
+	"strings"
+
+	"ratemysoft-backend/internal/models/sqlc"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+func (q *queries) CreateReviewComment(ctx context.Context, arg sqlc.CreateReviewCommentParams) (sqlc.ReviewComment, error) {
+	st, done := q.begin()
+	defer done()
+
+	for _, c := range st.comments {
+		switch {
+		case c.ID == arg.ID:
+			return sqlc.ReviewComment{}, uniqueViolation("review_comments_pkey")
+		case arg.Official && c.Official && c.ReviewID == arg.ReviewID && !deleted(c.DeletedAt):
+			return sqlc.ReviewComment{}, uniqueViolation("idx_review_comments_official")
+		}
+	}
+	if _, ok := st.reviews[arg.ReviewID]; !ok {
+		return sqlc.ReviewComment{}, foreignKeyViolation("review_comments_review_id_fkey")
+	}
+	if _, ok := st.users[arg.UserID]; !ok {
+		return sqlc.ReviewComment{}, foreignKeyViolation("review_comments_user_id_fkey")
+	}
+	if arg.ParentID != nil {
+		if _, ok := st.comments[*arg.ParentID]; !ok {
+			return sqlc.ReviewComment{}, foreignKeyViolation("review_comments_parent_id_fkey")
+		}
+	}
+	if arg.CompanyID != nil {
+		if _, ok := st.companies[*arg.CompanyID]; !ok {
+			return sqlc.ReviewComment{}, foreignKeyViolation("review_comments_company_id_fkey")
+		}
+	}
+
+	c := sqlc.ReviewComment{
+		ID:        arg.ID,
+		ReviewID:  arg.ReviewID,
+		ParentID:  arg.ParentID,
+		UserID:    arg.UserID,
+		Body:      arg.Body,
+		Status:    arg.Status,
+		Official:  arg.Official,
+		CompanyID: arg.CompanyID,
+		FlagCount: arg.FlagCount,
+		Edited:    arg.Edited,
+		CreatedAt: arg.CreatedAt,
+		UpdatedAt: arg.UpdatedAt,
+	}
+	st.comments[c.ID] = c
+	return c, nil
+}
+
+func (q *queries) GetReviewComment(ctx context.Context, id uuid.UUID) (sqlc.ReviewComment, error) {
+	st, done := q.begin()
+	defer done()
+
+	c, ok := st.comments[id]
+	if !ok || deleted(c.DeletedAt) {
+		return sqlc.ReviewComment{}, pgx.ErrNoRows
+	}
+	return c, nil
+}
+
+func (q *queries) GetReviewCommentForUpdate(ctx context.Context, id uuid.UUID) (sqlc.ReviewComment, error) {
+	return q.GetReviewComment(ctx, id)
+}
+
+func (q *queries) GetOfficialReviewComment(ctx context.Context, reviewID uuid.UUID) (sqlc.ReviewComment, error) {
+	st, done := q.begin()
+	defer done()
+
+	for _, c := range st.comments {
+		if c.ReviewID == reviewID && c.Official && !deleted(c.DeletedAt) {
+			return c, nil
+		}
+	}
+	return sqlc.ReviewComment{}, pgx.ErrNoRows
+}
+
+func (q *queries) ListReviewComments(ctx context.Context, reviewID uuid.UUID) ([]sqlc.ListReviewCommentsRow, error) {
+	st, done := q.begin()
+	defer done()
+
+	var rows []sqlc.ListReviewCommentsRow
+	for _, c := range st.comments {
+		u, ok := st.users[c.UserID]
+		if c.ReviewID != reviewID || !ok {
+			continue
+		}
+		row := sqlc.ListReviewCommentsRow{ReviewComment: c, UserHandle: u.Handle}
+		if c.CompanyID != nil {
+			if co, ok := st.companies[*c.CompanyID]; ok {
+				row.CompanyName = &co.Name
+			}
+		}
+		rows = append(rows, row)
+	}
+	slices.SortFunc(rows, func(a, b sqlc.ListReviewCommentsRow) int {
+		return compareComments(a.ReviewComment, b.ReviewComment)
+	})
+	return rows, nil
+}
+
+func (q *queries) UpdateReviewCommentBody(ctx context.Context, arg sqlc.UpdateReviewCommentBodyParams) (sqlc.ReviewComment, error) {
+	st, done := q.begin()
+	defer done()
+
+	c, ok := st.comments[arg.ID]
+	if !ok || deleted(c.DeletedAt) {
+		return sqlc.ReviewComment{}, pgx.ErrNoRows
+	}
+	c.Body = arg.Body
+	c.Edited = true
+	c.UpdatedAt = arg.UpdatedAt
+	st.comments[c.ID] = c
+	return c, nil
+}
+
+func (q *queries) UpdateReviewCommentStatus(ctx context.Context, arg sqlc.UpdateReviewCommentStatusParams) error {
+	st, done := q.begin()
+	defer done()
+
+	st.updateComment(arg.ID, func(c *sqlc.ReviewComment) {
+		c.Status = arg.Status
+	})
+	return nil
+}
+
+func (q *queries) SoftDeleteReviewComment(ctx context.Context, id uuid.UUID) error {
+	st, done := q.begin()
+	defer done()
+
+	if c, ok := st.comments[id]; ok && !deleted(c.DeletedAt) {
+		c.DeletedAt = now()
+		st.comments[id] = c
+	}
+	return nil
+}
+
+func (q *queries) CountReviewCommentsByStatus(ctx context.Context, status string) (int64, error) {
+	st, done := q.begin()
+	defer done()
+
+	var n int64
+	for _, c := range st.comments {
+		if c.Status == status && !deleted(c.DeletedAt) {
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (q *queries) GetReviewCommentFlag(ctx context.Context, arg sqlc.GetReviewCommentFlagParams) (sqlc.ReviewCommentFlag, error) {
+	st, done := q.begin()
+	defer done()
+
+	f, ok := st.commentFlags[pairKey{arg.CommentID, arg.UserID}]
+	if !ok {
+		return sqlc.ReviewCommentFlag{}, pgx.ErrNoRows
+	}
+	return f, nil
+}
+
+func (q *queries) UpsertReviewCommentFlag(ctx context.Context, arg sqlc.UpsertReviewCommentFlagParams) (sqlc.ReviewCommentFlag, error) {
+	st, done := q.begin()
+	defer done()
+
+	key := pairKey{arg.CommentID, arg.UserID}
+	f, ok := st.commentFlags[key]
+	if !ok {
+		if _, ok := st.comments[arg.CommentID]; !ok {
+			return sqlc.ReviewCommentFlag{}, foreignKeyViolation("review_comment_flags_comment_id_fkey")
+		}
+		if _, ok := st.users[arg.UserID]; !ok {
+			return sqlc.ReviewCommentFlag{}, foreignKeyViolation("review_comment_flags_user_id_fkey")
+		}
+		f = sqlc.ReviewCommentFlag{CommentID: arg.CommentID, UserID: arg.UserID, CreatedAt: arg.CreatedAt}
+	}
+	f.Reason = arg.Reason
+	f.Note = arg.Note
+	f.UpdatedAt = arg.UpdatedAt
+	st.commentFlags[key] = f
+	return f, nil
+}
+
+func (q *queries) DeleteReviewCommentFlag(ctx context.Context, arg sqlc.DeleteReviewCommentFlagParams) (int64, error) {
+	st, done := q.begin()
+	defer done()
+
+	key := pairKey{arg.CommentID, arg.UserID}
+	if _, ok := st.commentFlags[key]; !ok {
+		return 0, nil
+	}
+	delete(st.commentFlags, key)
+	return 1, nil
+}
+
+func (q *queries) DeleteReviewCommentFlags(ctx context.Context, commentID uuid.UUID) error {
+	st, done := q.begin()
+	defer done()
+
+	maps.DeleteFunc(st.commentFlags, func(key pairKey, _ sqlc.ReviewCommentFlag) bool {
+		return key.a == commentID
+	})
+	return nil
+}
+
+func (q *queries) AdjustReviewCommentFlagCount(ctx context.Context, arg sqlc.AdjustReviewCommentFlagCountParams) error {
+	st, done := q.begin()
+	defer done()
+
+	st.updateComment(arg.ID, func(c *sqlc.ReviewComment) {
+		c.FlagCount = max(c.FlagCount+arg.FlagDelta, 0)
+	})
+	return nil
+}
+
+func (q *queries) ClearReviewCommentFlags(ctx context.Context, id uuid.UUID) error {
+	st, done := q.begin()
+	defer done()
+
+	st.updateComment(id, func(c *sqlc.ReviewComment) {
+		c.FlagCount = 0
+	})
+	return nil
+}
+
+func (q *queries) CountFlaggedReviewComments(ctx context.Context, flagCount int32) (int64, error) {
+	st, done := q.begin()
+	defer done()
+
+	var n int64
+	for _, c := range st.comments {
+		if c.FlagCount >= flagCount && c.Status != "rejected" && !deleted(c.DeletedAt) {
+			n++
+		}
+	}
+	return n, nil
+}
+
+// updateComment applies fn to a live comment and bumps its updated_at, like the UPDATE queries do
+func (st *state) updateComment(id uuid.UUID, fn func(c *sqlc.ReviewComment)) {
+	c, ok := st.comments[id]
+	if !ok || deleted(c.DeletedAt) {
+		return
+	}
+	fn(&c)
+	c.UpdatedAt = now()
+	st.comments[id] = c
+}
+
+// compareComments orders comments oldest first
+func compareComments(a, b sqlc.ReviewComment) int {
+	return cmp.Or(compareTime(a.CreatedAt, b.CreatedAt), strings.Compare(a.ID.String(), b.ID.String()))
+}
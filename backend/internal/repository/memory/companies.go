@@ -0,0 +1,323 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"regexp"
+	"slices"
+	"strings"
+
+	"ratemysoft-backend/internal/models/sqlc"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+func (q *queries) CreateCompany(ctx context.Context, arg sqlc.CreateCompanyParams) (sqlc.Company, error) {
+	st, done := q.begin()
+	defer done()
+
+	for _, c := range st.companies {
+		switch {
+		case c.ID == arg.ID:
+			return sqlc.Company{}, uniqueViolation("companies_pkey")
+		case c.Slug == arg.Slug:
+			return sqlc.Company{}, uniqueViolation("companies_slug_key")
+		}
+	}
+
+	c := sqlc.Company{
+		ID:        arg.ID,
+		Name:      arg.Name,
+		Website:   arg.Website,
+		Slug:      arg.Slug,
+		LogoUrl:   arg.LogoUrl,
+		CreatedAt: arg.CreatedAt,
+		UpdatedAt: arg.UpdatedAt,
+	}
+	st.companies[c.ID] = c
+	return c, nil
+}
+
+func (q *queries) GetCompany(ctx context.Context, id uuid.UUID) (sqlc.Company, error) {
+	st, done := q.begin()
+	defer done()
+
+	c, ok := st.companies[id]
+	if !ok || deleted(c.DeletedAt) {
+		return sqlc.Company{}, pgx.ErrNoRows
+	}
+	return c, nil
+}
+
+func (q *queries) GetCompanyForUpdate(ctx context.Context, id uuid.UUID) (sqlc.Company, error) {
+	return q.GetCompany(ctx, id)
+}
+
+func (q *queries) GetCompanyBySlug(ctx context.Context, slug string) (sqlc.Company, error) {
+	st, done := q.begin()
+	defer done()
+
+	for _, c := range st.companies {
+		if c.Slug == slug && !deleted(c.DeletedAt) {
+			return c, nil
+		}
+	}
+	return sqlc.Company{}, pgx.ErrNoRows
+}
+
+func (q *queries) UpdateCompany(ctx context.Context, arg sqlc.UpdateCompanyParams) (sqlc.Company, error) {
+	st, done := q.begin()
+	defer done()
+
+	c, ok := st.companies[arg.ID]
+	if !ok || deleted(c.DeletedAt) {
+		return sqlc.Company{}, pgx.ErrNoRows
+	}
+	for _, other := range st.companies {
+		if other.ID != arg.ID && other.Slug == arg.Slug {
+			return sqlc.Company{}, uniqueViolation("companies_slug_key")
+		}
+	}
+
+	c.Name = arg.Name
+	c.Website = arg.Website
+	c.Slug = arg.Slug
+	c.LogoUrl = arg.LogoUrl
+	c.UpdatedAt = arg.UpdatedAt
+	st.companies[c.ID] = c
+	return c, nil
+}
+
+func (q *queries) SoftDeleteCompany(ctx context.Context, id uuid.UUID) error {
+	st, done := q.begin()
+	defer done()
+
+	if c, ok := st.companies[id]; ok && !deleted(c.DeletedAt) {
+		c.DeletedAt = now()
+		st.companies[id] = c
+	}
+	return nil
+}
+
+func (q *queries) CountCompanies(ctx context.Context) (int64, error) {
+	st, done := q.begin()
+	defer done()
+
+	var n int64
+	for _, c := range st.companies {
+		if !deleted(c.DeletedAt) {
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (q *queries) GetCompanyMember(ctx context.Context, arg sqlc.GetCompanyMemberParams) (sqlc.CompanyMember, error) {
+	st, done := q.begin()
+	defer done()
+
+	m, ok := st.members[pairKey{arg.CompanyID, arg.UserID}]
+	if !ok {
+		return sqlc.CompanyMember{}, pgx.ErrNoRows
+	}
+	return m, nil
+}
+
+func (q *queries) UpsertCompanyMember(ctx context.Context, arg sqlc.UpsertCompanyMemberParams) (sqlc.CompanyMember, error) {
+	st, done := q.begin()
+	defer done()
+
+	if _, ok := st.companies[arg.CompanyID]; !ok {
+		return sqlc.CompanyMember{}, foreignKeyViolation("company_members_company_id_fkey")
+	}
+	if _, ok := st.users[arg.UserID]; !ok {
+		return sqlc.CompanyMember{}, foreignKeyViolation("company_members_user_id_fkey")
+	}
+
+	key := pairKey{arg.CompanyID, arg.UserID}
+	m, ok := st.members[key]
+	if !ok {
+		m = sqlc.CompanyMember{CompanyID: arg.CompanyID, UserID: arg.UserID, CreatedAt: arg.CreatedAt}
+	}
+	m.Role = arg.Role
+	m.UpdatedAt = arg.UpdatedAt
+	st.members[key] = m
+	return m, nil
+}
+
+func (q *queries) DeleteCompanyMember(ctx context.Context, arg sqlc.DeleteCompanyMemberParams) (int64, error) {
+	st, done := q.begin()
+	defer done()
+
+	key := pairKey{arg.CompanyID, arg.UserID}
+	if _, ok := st.members[key]; !ok {
+		return 0, nil
+	}
+	delete(st.members, key)
+	return 1, nil
+}
+
+func (q *queries) ListCompanyMembers(ctx context.Context, companyID uuid.UUID) ([]sqlc.ListCompanyMembersRow, error) {
+	st, done := q.begin()
+	defer done()
+
+	var rows []sqlc.ListCompanyMembersRow
+	for _, m := range st.members {
+		u, ok := st.users[m.UserID]
+		if m.CompanyID != companyID || !ok {
+			continue
+		}
+		rows = append(rows, sqlc.ListCompanyMembersRow{
+			CompanyID:  m.CompanyID,
+			UserID:     m.UserID,
+			Role:       m.Role,
+			CreatedAt:  m.CreatedAt,
+			UpdatedAt:  m.UpdatedAt,
+			UserHandle: u.Handle,
+		})
+	}
+	slices.SortFunc(rows, func(a, b sqlc.ListCompanyMembersRow) int {
+		return cmp.Or(
+			strings.Compare(b.Role, a.Role),
+			compareTime(a.CreatedAt, b.CreatedAt),
+			strings.Compare(a.UserID.String(), b.UserID.String()),
+		)
+	})
+	return rows, nil
+}
+
+func (q *queries) ListUserCompanyMemberships(ctx context.Context, userID uuid.UUID) ([]sqlc.CompanyMember, error) {
+	st, done := q.begin()
+	defer done()
+
+	var rows []sqlc.CompanyMember
+	for _, m := range st.members {
+		if m.UserID == userID {
+			rows = append(rows, m)
+		}
+	}
+	slices.SortFunc(rows, func(a, b sqlc.CompanyMember) int {
+		return strings.Compare(a.CompanyID.String(), b.CompanyID.String())
+	})
+	return rows, nil
+}
+
+func (q *queries) CountCompanyOwners(ctx context.Context, companyID uuid.UUID) (int64, error) {
+	st, done := q.begin()
+	defer done()
+
+	var n int64
+	for _, m := range st.members {
+		if m.CompanyID == companyID && m.Role == "owner" {
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (q *queries) CreateCompanyClaim(ctx context.Context, arg sqlc.CreateCompanyClaimParams) (sqlc.CompanyClaim, error) {
+	st, done := q.begin()
+	defer done()
+
+	if _, ok := st.claims[arg.ID]; ok {
+		return sqlc.CompanyClaim{}, uniqueViolation("company_claims_pkey")
+	}
+	if arg.Status == "pending" {
+		for _, c := range st.claims {
+			if c.CompanyID == arg.CompanyID && c.UserID == arg.UserID && c.Status == "pending" {
+				return sqlc.CompanyClaim{}, uniqueViolation("idx_company_claims_pending")
+			}
+		}
+	}
+
+	c := sqlc.CompanyClaim{
+		ID:                 arg.ID,
+		CompanyID:          arg.CompanyID,
+		UserID:             arg.UserID,
+		Status:             arg.Status,
+		VerificationMethod: arg.VerificationMethod,
+		Evidence:           arg.Evidence,
+		CreatedAt:          arg.CreatedAt,
+		UpdatedAt:          arg.UpdatedAt,
+	}
+	st.claims[c.ID] = c
+	return c, nil
+}
+
+func (q *queries) GetCompanyClaim(ctx context.Context, id uuid.UUID) (sqlc.CompanyClaim, error) {
+	st, done := q.begin()
+	defer done()
+
+	c, ok := st.claims[id]
+	if !ok {
+		return sqlc.CompanyClaim{}, pgx.ErrNoRows
+	}
+	return c, nil
+}
+
+func (q *queries) GetPendingCompanyClaim(ctx context.Context, arg sqlc.GetPendingCompanyClaimParams) (sqlc.CompanyClaim, error) {
+	st, done := q.begin()
+	defer done()
+
+	for _, c := range st.claims {
+		if c.CompanyID == arg.CompanyID && c.UserID == arg.UserID && c.Status == "pending" {
+			return c, nil
+		}
+	}
+	return sqlc.CompanyClaim{}, pgx.ErrNoRows
+}
+
+func (q *queries) ResolveCompanyClaim(ctx context.Context, arg sqlc.ResolveCompanyClaimParams) (sqlc.CompanyClaim, error) {
+	st, done := q.begin()
+	defer done()
+
+	c, ok := st.claims[arg.ID]
+	if !ok || c.Status != "pending" {
+		return sqlc.CompanyClaim{}, pgx.ErrNoRows
+	}
+	c.Status = arg.Status
+	c.ReviewedBy = arg.ReviewedBy
+	c.ReviewNote = arg.ReviewNote
+	c.ReviewedAt = arg.ReviewedAt
+	st.claims[c.ID] = c
+	return c, nil
+}
+
+func (q *queries) CountCompanyClaimsByStatus(ctx context.Context, status string) (int64, error) {
+	st, done := q.begin()
+	defer done()
+
+	var n int64
+	for _, c := range st.claims {
+		if c.Status == status {
+			n++
+		}
+	}
+	return n, nil
+}
+
+// likePattern compiles an ILIKE pattern, where % matches any run of characters and _
+// matches exactly one, unless escaped with a backslash
+func likePattern(pattern string) *regexp.Regexp {
+	var b strings.Builder
+	b.WriteString(`(?is)^`)
+	escaped := false
+	for _, r := range pattern {
+		switch {
+		case escaped:
+			b.WriteString(regexp.QuoteMeta(string(r)))
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == '%':
+			b.WriteString(`.*`)
+		case r == '_':
+			b.WriteString(`.`)
+		default:
+			b.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	b.WriteString(`$`)
+	return regexp.MustCompile(b.String())
+}
@@ -0,0 +1,139 @@
+package memory
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"ratemysoft-backend/internal/domain"
+	"ratemysoft-backend/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+// keysetOrder is a total order over a listing: the sort keys of a row, then its ID as
+// tie-breaker in the direction of the last key. Keys are int64, float64, string or time.Time.
+type keysetOrder[T any] struct {
+	name string
+	desc []bool // per key
+	keys func(T) []any
+	id   func(T) uuid.UUID
+}
+
+// keyedRow is a row with the values it sorts by
+type keyedRow[T any] struct {
+	row  T
+	id   uuid.UUID
+	keys []any
+}
+
+// compare orders two rows in list order
+func (o keysetOrder[T]) compare(a, b keyedRow[T]) int {
+	for i, desc := range o.desc {
+		if c := compareKey(a.keys[i], b.keys[i]); c != 0 {
+			if desc {
+				return -c
+			}
+			return c
+		}
+	}
+	c := strings.Compare(a.id.String(), b.id.String())
+	if o.desc[len(o.desc)-1] {
+		return -c
+	}
+	return c
+}
+
+// listPage pages through rows like the keyset listings of the Postgres store: rows past the
+// cursor in scan direction, or at the offset without one, plus a look-ahead row
+func listPage[T any](order keysetOrder[T], rows []T, request domain.PageRequest) ([]T, domain.PageCursors, error) {
+	if err := repository.CheckCursor(order.name, len(order.desc), request.Cursor); err != nil {
+		return nil, domain.PageCursors{}, err
+	}
+
+	keyed := make([]keyedRow[T], 0, len(rows))
+	for _, row := range rows {
+		keyed = append(keyed, keyedRow[T]{row: row, id: order.id(row), keys: order.keys(row)})
+	}
+	slices.SortFunc(keyed, order.compare)
+
+	if cursor := request.Cursor; cursor != nil && len(keyed) > 0 {
+		// Cursor keys are parsed as the type the listing sorts by
+		at := keyedRow[T]{id: cursor.ID, keys: make([]any, len(cursor.Keys))}
+		for i, text := range cursor.Keys {
+			value, err := parseKey(keyed[0].keys[i], text)
+			if err != nil {
+				return nil, domain.PageCursors{}, repository.ErrCursorMismatch
+			}
+			at.keys[i] = value
+		}
+
+		if cursor.Backward {
+			slices.Reverse(keyed)
+		}
+		keyed = slices.DeleteFunc(keyed, func(k keyedRow[T]) bool {
+			c := order.compare(k, at)
+			return c == 0 || (c < 0) != cursor.Backward
+		})
+		keyed = page(keyed, request.Limit+1, 0)
+	} else {
+		keyed = page(keyed, request.Limit+1, request.Offset)
+	}
+
+	scanned := make([]repository.KeyedRow[T], 0, len(keyed))
+	for _, k := range keyed {
+		texts := make([]string, 0, len(k.keys))
+		for _, value := range k.keys {
+			texts = append(texts, formatKey(value))
+		}
+		scanned = append(scanned, repository.KeyedRow[T]{Row: k.row, ID: k.id, Keys: texts})
+	}
+	items, cursors := repository.FinishPage(order.name, scanned, request)
+	return items, cursors, nil
+}
+
+func compareKey(a, b any) int {
+	switch a := a.(type) {
+	case int64:
+		return cmp.Compare(a, b.(int64))
+	case float64:
+		return cmp.Compare(a, b.(float64))
+	case string:
+		return strings.Compare(a, b.(string))
+	case time.Time:
+		return a.Compare(b.(time.Time))
+	}
+	panic(fmt.Sprintf("memory: unsupported sort key %T", a))
+}
+
+func formatKey(value any) string {
+	switch value := value.(type) {
+	case int64:
+		return strconv.FormatInt(value, 10)
+	case float64:
+		return strconv.FormatFloat(value, 'g', -1, 64)
+	case string:
+		return value
+	case time.Time:
+		return value.Format(time.RFC3339Nano)
+	}
+	panic(fmt.Sprintf("memory: unsupported sort key %T", value))
+}
+
+// parseKey reads cursor text as a key of the same type as like
+func parseKey(like any, text string) (any, error) {
+	switch like.(type) {
+	case int64:
+		return strconv.ParseInt(text, 10, 64)
+	case float64:
+		return strconv.ParseFloat(text, 64)
+	case string:
+		return text, nil
+	case time.Time:
+		return time.Parse(time.RFC3339Nano, text)
+	}
+	return nil, fmt.Errorf("unsupported sort key %T", like)
+}
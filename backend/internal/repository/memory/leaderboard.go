@@ -0,0 +1,190 @@
+package memory
+
+import (
+	"context"
+	"math"
+	"slices"
+
+	"ratemysoft-backend/internal/domain"
+	"ratemysoft-backend/internal/models/sqlc"
+	"ratemysoft-backend/internal/repository"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// LockLeaderboardRefresh is a no-op: units of work already hold the store's lock
+func (q *queries) LockLeaderboardRefresh(ctx context.Context) error {
+	return nil
+}
+
+func (q *queries) DeleteLeaderboardWindow(ctx context.Context, timeWindow string) error {
+	st, done := q.begin()
+	defer done()
+
+	st.leaderboard = slices.DeleteFunc(st.leaderboard, func(l sqlc.ProductLeaderboard) bool {
+		return l.TimeWindow == timeWindow
+	})
+	return nil
+}
+
+// RefreshLeaderboardWindow scores the products with published reviews in the window: the
+// Bayesian average shrinks towards the window-wide mean by PriorWeight reviews, and the Wilson
+// score is the lower bound of the 95% interval on the share of 4-5 star reviews
+func (q *queries) RefreshLeaderboardWindow(ctx context.Context, arg sqlc.RefreshLeaderboardWindowParams) error {
+	st, done := q.begin()
+	defer done()
+
+	type total struct {
+		reviews, positive, sum int64
+	}
+	totals := map[uuid.UUID]*total{}
+	var sum, count int64
+	for _, r := range st.reviews {
+		p, ok := st.products[r.ProductID]
+		if deleted(r.DeletedAt) || r.Status != "published" || !ok || deleted(p.DeletedAt) {
+			continue
+		}
+		if arg.Since.Valid && r.CreatedAt.Time.Before(arg.Since.Time) {
+			continue
+		}
+		t, ok := totals[r.ProductID]
+		if !ok {
+			t = &total{}
+			totals[r.ProductID] = t
+		}
+		t.reviews++
+		t.sum += int64(r.Rating)
+		if r.Rating >= 4 {
+			t.positive++
+		}
+		sum += int64(r.Rating)
+		count++
+	}
+
+	var mean float64
+	if count > 0 {
+		mean = float64(sum) / float64(count)
+	}
+
+	for _, l := range st.leaderboard {
+		if _, ok := totals[l.ProductID]; ok && l.TimeWindow == arg.TimeWindow {
+			return uniqueViolation("product_leaderboard_pkey")
+		}
+	}
+
+	ts := now()
+	for productID, t := range totals {
+		n := float64(t.reviews)
+		avg := float64(t.sum) / n
+		share := float64(t.positive) / n
+		st.leaderboard = append(st.leaderboard, sqlc.ProductLeaderboard{
+			TimeWindow:    arg.TimeWindow,
+			ProductID:     productID,
+			ReviewCount:   int32(t.reviews),
+			PositiveCount: int32(t.positive),
+			AvgRating:     avg,
+			BayesianScore: (arg.PriorWeight*mean + n*avg) / (arg.PriorWeight + n),
+			WilsonScore:   (share + 1.9208/n - 1.96*math.Sqrt(share*(1-share)/n+0.9604/(n*n))) / (1 + 3.8416/n),
+			RefreshedAt:   ts,
+		})
+	}
+	return nil
+}
+
+// ListLeaderboardPage ranks the whole window before paging, so that pages reached by cursor
+// still know their ranks
+func (s *Store) ListLeaderboardPage(ctx context.Context, filter repository.LeaderboardFilter, score domain.LeaderboardScore, page domain.PageRequest) ([]repository.LeaderboardRow, domain.PageCursors, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if score != domain.ScoreWilson {
+		score = domain.ScoreBayesian
+	}
+	scoreOf := func(r repository.LeaderboardRow) float64 {
+		if score == domain.ScoreWilson {
+			return r.WilsonScore
+		}
+		return r.BayesianScore
+	}
+	order := keysetOrder[repository.LeaderboardRow]{name: string(score), desc: []bool{true, true, false},
+		keys: func(r repository.LeaderboardRow) []any { return []any{scoreOf(r), int64(r.ReviewCount), r.ProductName} },
+		id:   func(r repository.LeaderboardRow) uuid.UUID { return r.ProductID },
+	}
+
+	entries := s.state.leaderboardWindow(string(filter.Window), filter.Category)
+	rows := make([]repository.LeaderboardRow, 0, len(entries))
+	for _, entry := range entries {
+		rows = append(rows, repository.LeaderboardRow{ListLeaderboardRow: entry})
+	}
+	slices.SortFunc(rows, func(a, b repository.LeaderboardRow) int {
+		return order.compare(
+			keyedRow[repository.LeaderboardRow]{id: a.ProductID, keys: order.keys(a)},
+			keyedRow[repository.LeaderboardRow]{id: b.ProductID, keys: order.keys(b)},
+		)
+	})
+	for i := range rows {
+		rows[i].Rank = int64(i + 1)
+	}
+	return listPage(order, rows, page)
+}
+
+func (s *Store) CountLeaderboardEntries(ctx context.Context, filter repository.LeaderboardFilter) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return int64(len(s.state.leaderboardWindow(string(filter.Window), filter.Category))), nil
+}
+
+// GetLeaderboardRefreshedAt returns an invalid timestamp when the window was never refreshed
+func (q *queries) GetLeaderboardRefreshedAt(ctx context.Context, timeWindow string) (pgtype.Timestamptz, error) {
+	st, done := q.begin()
+	defer done()
+
+	var latest pgtype.Timestamptz
+	for _, l := range st.leaderboard {
+		if l.TimeWindow == timeWindow && (!latest.Valid || compareTime(l.RefreshedAt, latest) > 0) {
+			latest = l.RefreshedAt
+		}
+	}
+	return latest, nil
+}
+
+// leaderboardWindow returns the window's scores of live products, optionally limited to
+// a category and its descendants
+func (st *state) leaderboardWindow(timeWindow string, category string) []sqlc.ListLeaderboardRow {
+	var categories map[string]bool
+	if category != "" {
+		categories = st.categorySubtree(category)
+	}
+
+	var rows []sqlc.ListLeaderboardRow
+	for _, l := range st.leaderboard {
+		if l.TimeWindow != timeWindow {
+			continue
+		}
+		p, ok := st.products[l.ProductID]
+		if !ok || deleted(p.DeletedAt) || categories != nil && !categories[p.Category] {
+			continue
+		}
+		c, ok := st.companies[p.CompanyID]
+		if !ok || deleted(c.DeletedAt) {
+			continue
+		}
+		rows = append(rows, sqlc.ListLeaderboardRow{
+			ProductID:     l.ProductID,
+			ReviewCount:   l.ReviewCount,
+			PositiveCount: l.PositiveCount,
+			AvgRating:     l.AvgRating,
+			BayesianScore: l.BayesianScore,
+			WilsonScore:   l.WilsonScore,
+			RefreshedAt:   l.RefreshedAt,
+			ProductName:   p.Name,
+			ProductSlug:   p.Slug,
+			Category:      p.Category,
+			CompanyName:   c.Name,
+			CompanySlug:   c.Slug,
+		})
+	}
+	return rows
+}
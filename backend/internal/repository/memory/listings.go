@@ -0,0 +1,488 @@
+package memory
+
+import (
+	"context"
+	"math"
+	"strings"
+	"time"
+
+	"ratemysoft-backend/internal/domain"
+	"ratemysoft-backend/internal/models/sqlc"
+	"ratemysoft-backend/internal/repository"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func productRowID(p sqlc.Product) uuid.UUID           { return p.ID }
+func companyRowID(c sqlc.Company) uuid.UUID           { return c.ID }
+func reviewRowID(r sqlc.Review) uuid.UUID             { return r.ID }
+func auditRowID(e repository.AuditEventRow) uuid.UUID { return e.ID }
+
+// oldestFirst orders a moderation queue by creation time, like the Postgres store
+func oldestFirst[T any](createdAt func(T) pgtype.Timestamptz, id func(T) uuid.UUID) keysetOrder[T] {
+	return keysetOrder[T]{name: "oldest", desc: []bool{false}, id: id,
+		keys: func(row T) []any { return []any{createdAt(row).Time} }}
+}
+
+// mostFlaggedFirst orders a flagged queue by flag count, then oldest first
+func mostFlaggedFirst[T any](flags func(T) int32, createdAt func(T) pgtype.Timestamptz, id func(T) uuid.UUID) keysetOrder[T] {
+	return keysetOrder[T]{name: "flagged", desc: []bool{true, false}, id: id,
+		keys: func(row T) []any { return []any{int64(flags(row)), createdAt(row).Time} }}
+}
+
+// productOrder and reviewOrder mirror the orderings of the Postgres store
+func (st *state) productOrder(order repository.ProductOrder) keysetOrder[sqlc.Product] {
+	switch order {
+	case repository.ProductsByRating:
+		return keysetOrder[sqlc.Product]{name: string(order), desc: []bool{true}, id: productRowID,
+			keys: func(p sqlc.Product) []any {
+				var rating float64
+				if p.AvgRating != nil {
+					rating = *p.AvgRating
+				}
+				return []any{rating}
+			}}
+	case repository.ProductsByReviewCount:
+		return keysetOrder[sqlc.Product]{name: string(order), desc: []bool{true}, id: productRowID,
+			keys: func(p sqlc.Product) []any { return []any{int64(p.TotalReviews)} }}
+	case repository.ProductsByName:
+		return keysetOrder[sqlc.Product]{name: string(order), desc: []bool{false}, id: productRowID,
+			keys: func(p sqlc.Product) []any { return []any{p.Name} }}
+	case repository.ProductsByTrending:
+		// Published reviews of the last 30 days
+		since := time.Now().Add(-30 * 24 * time.Hour)
+		recent := map[uuid.UUID]int64{}
+		for _, r := range st.reviews {
+			if r.Status == "published" && !deleted(r.DeletedAt) && !r.CreatedAt.Time.Before(since) {
+				recent[r.ProductID]++
+			}
+		}
+		return keysetOrder[sqlc.Product]{name: string(order), desc: []bool{true}, id: productRowID,
+			keys: func(p sqlc.Product) []any { return []any{recent[p.ID]} }}
+	default:
+		return keysetOrder[sqlc.Product]{name: string(repository.ProductsByNewest), desc: []bool{true}, id: productRowID,
+			keys: func(p sqlc.Product) []any { return []any{p.CreatedAt.Time} }}
+	}
+}
+
+func (st *state) reviewOrder(order repository.ReviewOrder) keysetOrder[sqlc.Review] {
+	byRecent := func(first func(r sqlc.Review) any, desc bool) keysetOrder[sqlc.Review] {
+		return keysetOrder[sqlc.Review]{name: string(order), desc: []bool{desc, true}, id: reviewRowID,
+			keys: func(r sqlc.Review) []any { return []any{first(r), r.CreatedAt.Time} }}
+	}
+	switch order {
+	case repository.ReviewsByUpvotes:
+		return byRecent(func(r sqlc.Review) any { return int64(r.UpvoteCount) }, true)
+	case repository.ReviewsByRatingDesc:
+		return byRecent(func(r sqlc.Review) any { return int64(r.Rating) }, true)
+	case repository.ReviewsByRatingAsc:
+		return byRecent(func(r sqlc.Review) any { return int64(r.Rating) }, false)
+	case repository.ReviewsByHelpful:
+		return byRecent(func(r sqlc.Review) any {
+			reputation := float64(st.reputation[r.UserID].Reputation)
+			return float64(r.UpvoteCount+1) * (1 + math.Log(1+reputation))
+		}, true)
+	default:
+		return keysetOrder[sqlc.Review]{name: string(repository.ReviewsByRecent), desc: []bool{true}, id: reviewRowID,
+			keys: func(r sqlc.Review) []any { return []any{r.CreatedAt.Time} }}
+	}
+}
+
+// ListProductsPage lists the products of live companies matching every filter
+func (s *Store) ListProductsPage(ctx context.Context, filter repository.ProductFilter, order repository.ProductOrder, page domain.PageRequest) ([]sqlc.Product, domain.PageCursors, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return listPage(s.state.productOrder(order), s.state.filterProducts(filter), page)
+}
+
+func (s *Store) CountProductsMatching(ctx context.Context, filter repository.ProductFilter) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return int64(len(s.state.filterProducts(filter))), nil
+}
+
+func (s *Store) ListCompaniesPage(ctx context.Context, page domain.PageRequest) ([]sqlc.Company, domain.PageCursors, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var rows []sqlc.Company
+	for _, c := range s.state.companies {
+		if !deleted(c.DeletedAt) {
+			rows = append(rows, c)
+		}
+	}
+	order := keysetOrder[sqlc.Company]{name: "newest", desc: []bool{true}, id: companyRowID,
+		keys: func(c sqlc.Company) []any { return []any{c.CreatedAt.Time} },
+	}
+	return listPage(order, rows, page)
+}
+
+// SearchCompaniesPage orders names by bytes, where Postgres would use the collation
+func (s *Store) SearchCompaniesPage(ctx context.Context, query string, page domain.PageRequest) ([]sqlc.Company, domain.PageCursors, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	order := keysetOrder[sqlc.Company]{name: "name", desc: []bool{false}, id: companyRowID,
+		keys: func(c sqlc.Company) []any { return []any{c.Name} },
+	}
+	return listPage(order, s.state.searchCompanies(query), page)
+}
+
+func (s *Store) CountCompaniesMatching(ctx context.Context, query string) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return int64(len(s.state.searchCompanies(query))), nil
+}
+
+func (s *Store) ListCompanyClaimsPage(ctx context.Context, status string, page domain.PageRequest) ([]sqlc.ListCompanyClaimsByStatusRow, domain.PageCursors, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	st := s.state
+	var rows []sqlc.ListCompanyClaimsByStatusRow
+	for _, c := range st.claims {
+		company, okCompany := st.companies[c.CompanyID]
+		u, okUser := st.users[c.UserID]
+		if c.Status != status || !okCompany || !okUser {
+			continue
+		}
+		rows = append(rows, sqlc.ListCompanyClaimsByStatusRow{
+			ID:                 c.ID,
+			CompanyID:          c.CompanyID,
+			UserID:             c.UserID,
+			Status:             c.Status,
+			VerificationMethod: c.VerificationMethod,
+			Evidence:           c.Evidence,
+			ReviewedBy:         c.ReviewedBy,
+			ReviewNote:         c.ReviewNote,
+			ReviewedAt:         c.ReviewedAt,
+			CreatedAt:          c.CreatedAt,
+			UpdatedAt:          c.UpdatedAt,
+			CompanyName:        company.Name,
+			UserHandle:         u.Handle,
+			UserEmail:          u.Email,
+		})
+	}
+	order := oldestFirst(
+		func(c sqlc.ListCompanyClaimsByStatusRow) pgtype.Timestamptz { return c.CreatedAt },
+		func(c sqlc.ListCompanyClaimsByStatusRow) uuid.UUID { return c.ID },
+	)
+	return listPage(order, rows, page)
+}
+
+func (s *Store) ListProductReviewsPage(ctx context.Context, productID uuid.UUID, order repository.ReviewOrder, page domain.PageRequest) ([]sqlc.Review, domain.PageCursors, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return listPage(s.state.reviewOrder(order), s.state.publishedReviews(productID), page)
+}
+
+// ListUserReviewsPage leaves out the reviews of deleted accounts, which stay up anonymously
+func (s *Store) ListUserReviewsPage(ctx context.Context, userID uuid.UUID, includeUnpublished bool, page domain.PageRequest) ([]sqlc.Review, domain.PageCursors, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	st := s.state
+	var rows []sqlc.Review
+	if u, ok := st.users[userID]; ok && !deleted(u.DeletedAt) {
+		for _, r := range st.reviews {
+			if r.UserID != userID || deleted(r.DeletedAt) || !includeUnpublished && r.Status != "published" {
+				continue
+			}
+			if p, ok := st.products[r.ProductID]; !ok || deleted(p.DeletedAt) || !st.liveCompany(p.CompanyID) {
+				continue
+			}
+			rows = append(rows, r)
+		}
+	}
+	return listPage(st.reviewOrder(repository.ReviewsByRecent), rows, page)
+}
+
+func (s *Store) ListReviewsByStatusPage(ctx context.Context, status string, page domain.PageRequest) ([]sqlc.GetReviewsByStatusRow, domain.PageCursors, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	st := s.state
+	var rows []sqlc.GetReviewsByStatusRow
+	for _, r := range st.reviews {
+		if r.Status != status || deleted(r.DeletedAt) {
+			continue
+		}
+		if row, ok := st.joinReview(r); ok {
+			rows = append(rows, sqlc.GetReviewsByStatusRow(row))
+		}
+	}
+	order := oldestFirst(
+		func(r sqlc.GetReviewsByStatusRow) pgtype.Timestamptz { return r.CreatedAt },
+		func(r sqlc.GetReviewsByStatusRow) uuid.UUID { return r.ID },
+	)
+	return listPage(order, rows, page)
+}
+
+func (s *Store) ListFlaggedReviewsPage(ctx context.Context, minFlags int32, page domain.PageRequest) ([]sqlc.ListFlaggedReviewsRow, domain.PageCursors, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	st := s.state
+	var rows []sqlc.ListFlaggedReviewsRow
+	for _, r := range st.reviews {
+		if r.FlagCount < minFlags || r.Status == "rejected" || deleted(r.DeletedAt) {
+			continue
+		}
+		if row, ok := st.joinReview(r); ok {
+			rows = append(rows, sqlc.ListFlaggedReviewsRow(row))
+		}
+	}
+	order := mostFlaggedFirst(
+		func(r sqlc.ListFlaggedReviewsRow) int32 { return r.FlagCount },
+		func(r sqlc.ListFlaggedReviewsRow) pgtype.Timestamptz { return r.CreatedAt },
+		func(r sqlc.ListFlaggedReviewsRow) uuid.UUID { return r.ID },
+	)
+	return listPage(order, rows, page)
+}
+
+func (s *Store) ListCommentsByStatusPage(ctx context.Context, status string, page domain.PageRequest) ([]sqlc.ListReviewCommentsByStatusRow, domain.PageCursors, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	st := s.state
+	var rows []sqlc.ListReviewCommentsByStatusRow
+	for _, c := range st.comments {
+		u, ok := st.users[c.UserID]
+		if c.Status != status || deleted(c.DeletedAt) || !ok {
+			continue
+		}
+		rows = append(rows, sqlc.ListReviewCommentsByStatusRow{ReviewComment: c, UserHandle: u.Handle})
+	}
+	order := oldestFirst(
+		func(c sqlc.ListReviewCommentsByStatusRow) pgtype.Timestamptz { return c.ReviewComment.CreatedAt },
+		func(c sqlc.ListReviewCommentsByStatusRow) uuid.UUID { return c.ReviewComment.ID },
+	)
+	return listPage(order, rows, page)
+}
+
+func (s *Store) ListFlaggedCommentsPage(ctx context.Context, minFlags int32, page domain.PageRequest) ([]sqlc.ListFlaggedReviewCommentsRow, domain.PageCursors, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	st := s.state
+	var rows []sqlc.ListFlaggedReviewCommentsRow
+	for _, c := range st.comments {
+		u, ok := st.users[c.UserID]
+		if c.FlagCount < minFlags || c.Status == "rejected" || deleted(c.DeletedAt) || !ok {
+			continue
+		}
+		rows = append(rows, sqlc.ListFlaggedReviewCommentsRow{ReviewComment: c, UserHandle: u.Handle})
+	}
+	order := mostFlaggedFirst(
+		func(c sqlc.ListFlaggedReviewCommentsRow) int32 { return c.ReviewComment.FlagCount },
+		func(c sqlc.ListFlaggedReviewCommentsRow) pgtype.Timestamptz { return c.ReviewComment.CreatedAt },
+		func(c sqlc.ListFlaggedReviewCommentsRow) uuid.UUID { return c.ReviewComment.ID },
+	)
+	return listPage(order, rows, page)
+}
+
+func (s *Store) ListTagsPage(ctx context.Context, prefix string, page domain.PageRequest) ([]sqlc.ListTagsRow, domain.PageCursors, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	order := keysetOrder[sqlc.ListTagsRow]{name: "popular", desc: []bool{true, false},
+		keys: func(t sqlc.ListTagsRow) []any { return []any{t.ProductCount, t.Slug} },
+		id:   func(t sqlc.ListTagsRow) uuid.UUID { return t.ID },
+	}
+	return listPage(order, s.state.filterTags(prefix), page)
+}
+
+func (s *Store) CountTagsMatching(ctx context.Context, prefix string) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return int64(len(s.state.filterTags(prefix))), nil
+}
+
+func (s *Store) ListTagSuggestionsPage(ctx context.Context, productID uuid.UUID, status string, page domain.PageRequest) ([]sqlc.ListTagSuggestionsByProductRow, domain.PageCursors, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	st := s.state
+	var rows []sqlc.ListTagSuggestionsByProductRow
+	for _, ts := range st.tagSuggestions {
+		u, ok := st.users[ts.UserID]
+		if ts.ProductID != productID || ts.Status != status || !ok {
+			continue
+		}
+		rows = append(rows, sqlc.ListTagSuggestionsByProductRow{
+			ID:         ts.ID,
+			ProductID:  ts.ProductID,
+			UserID:     ts.UserID,
+			TagSlug:    ts.TagSlug,
+			TagName:    ts.TagName,
+			Status:     ts.Status,
+			ReviewedBy: ts.ReviewedBy,
+			ReviewNote: ts.ReviewNote,
+			ReviewedAt: ts.ReviewedAt,
+			CreatedAt:  ts.CreatedAt,
+			UpdatedAt:  ts.UpdatedAt,
+			UserHandle: u.Handle,
+		})
+	}
+	order := oldestFirst(
+		func(ts sqlc.ListTagSuggestionsByProductRow) pgtype.Timestamptz { return ts.CreatedAt },
+		func(ts sqlc.ListTagSuggestionsByProductRow) uuid.UUID { return ts.ID },
+	)
+	return listPage(order, rows, page)
+}
+
+func (s *Store) ListProductRatingEventsPage(ctx context.Context, productID uuid.UUID, page domain.PageRequest) ([]sqlc.ReviewRatingEvent, domain.PageCursors, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var rows []sqlc.ReviewRatingEvent
+	for _, e := range s.state.ratingEvents {
+		if e.ProductID == productID {
+			rows = append(rows, e)
+		}
+	}
+	order := keysetOrder[sqlc.ReviewRatingEvent]{name: "recent", desc: []bool{true},
+		keys: func(e sqlc.ReviewRatingEvent) []any { return []any{e.CreatedAt.Time} },
+		id:   func(e sqlc.ReviewRatingEvent) uuid.UUID { return e.ID },
+	}
+	return listPage(order, rows, page)
+}
+
+func (s *Store) ListAuditEventsPage(ctx context.Context, filter domain.AuditFilter, page domain.PageRequest) ([]repository.AuditEventRow, domain.PageCursors, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	order := keysetOrder[repository.AuditEventRow]{name: "recent", desc: []bool{true}, id: auditRowID,
+		keys: func(e repository.AuditEventRow) []any { return []any{e.CreatedAt.Time} },
+	}
+	return listPage(order, s.state.filterAuditEvents(filter), page)
+}
+
+func (s *Store) CountAuditEvents(ctx context.Context, filter domain.AuditFilter) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return int64(len(s.state.filterAuditEvents(filter))), nil
+}
+
+// filterProducts returns the live products of live companies matching every filter
+func (st *state) filterProducts(filter repository.ProductFilter) []sqlc.Product {
+	var categories map[string]bool
+	if filter.Category != "" {
+		categories = st.categorySubtree(filter.Category)
+	}
+
+	var rows []sqlc.Product
+	for _, p := range st.products {
+		c, ok := st.companies[p.CompanyID]
+		switch {
+		case deleted(p.DeletedAt) || !ok || deleted(c.DeletedAt):
+			continue
+		case categories != nil && !categories[p.Category]:
+			continue
+		case filter.CompanyID != nil && p.CompanyID != *filter.CompanyID:
+			continue
+		case filter.CompanySlug != "" && c.Slug != filter.CompanySlug:
+			continue
+		case filter.MinRating != nil && (p.AvgRating == nil || *p.AvgRating < *filter.MinRating):
+			continue
+		case filter.MinReviews != nil && p.TotalReviews < *filter.MinReviews:
+			continue
+		case filter.CreatedAfter != nil && !p.CreatedAt.Time.After(*filter.CreatedAfter):
+			continue
+		case !st.hasTags(p.ID, filter.Tags, filter.MatchAllTags):
+			continue
+		}
+		rows = append(rows, p)
+	}
+	return rows
+}
+
+// hasTags reports whether a product carries all of the tag slugs, or at least one of them;
+// any product matches an empty list
+func (st *state) hasTags(productID uuid.UUID, slugs []string, matchAll bool) bool {
+	if len(slugs) == 0 {
+		return true
+	}
+	var n int
+	for _, slug := range slugs {
+		if t, ok := st.tagBySlug(slug); ok {
+			if _, ok := st.productTags[pairKey{productID, t.ID}]; ok {
+				n++
+			}
+		}
+	}
+	if matchAll {
+		return n >= len(slugs)
+	}
+	return n >= 1
+}
+
+// searchCompanies returns the live companies whose name or slug contains query, ignoring case
+func (st *state) searchCompanies(query string) []sqlc.Company {
+	pattern := likePattern("%" + query + "%")
+	var rows []sqlc.Company
+	for _, c := range st.companies {
+		if !deleted(c.DeletedAt) && (pattern.MatchString(c.Name) || pattern.MatchString(c.Slug)) {
+			rows = append(rows, c)
+		}
+	}
+	return rows
+}
+
+// filterTags returns the tags whose slug starts with prefix, with the number of live products
+// carrying each
+func (st *state) filterTags(prefix string) []sqlc.ListTagsRow {
+	var rows []sqlc.ListTagsRow
+	for _, t := range st.tags {
+		if !strings.HasPrefix(t.Slug, prefix) {
+			continue
+		}
+		row := sqlc.ListTagsRow{ID: t.ID, Slug: t.Slug, Name: t.Name, CreatedAt: t.CreatedAt}
+		for key := range st.productTags {
+			if p, ok := st.products[key.a]; ok && key.b == t.ID && !deleted(p.DeletedAt) {
+				row.ProductCount++
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+func (st *state) liveCompany(id uuid.UUID) bool {
+	c, ok := st.companies[id]
+	return ok && !deleted(c.DeletedAt)
+}
+
+// filterAuditEvents returns the audit events matching the filter with their actors' handles
+func (st *state) filterAuditEvents(filter domain.AuditFilter) []repository.AuditEventRow {
+	var rows []repository.AuditEventRow
+	for _, e := range st.auditEvents {
+		switch {
+		case filter.ActorID != nil && (e.ActorID == nil || *e.ActorID != *filter.ActorID):
+			continue
+		case filter.Action != "" && e.Action != string(filter.Action):
+			continue
+		case filter.EntityType != "" && e.EntityType != string(filter.EntityType):
+			continue
+		case filter.EntityID != nil && e.EntityID != *filter.EntityID:
+			continue
+		case filter.From != nil && e.CreatedAt.Time.Before(*filter.From):
+			continue
+		case filter.To != nil && !e.CreatedAt.Time.Before(*filter.To):
+			continue
+		}
+		row := repository.AuditEventRow{AuditEvent: e}
+		if e.ActorID != nil {
+			row.ActorHandle = st.users[*e.ActorID].Handle
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
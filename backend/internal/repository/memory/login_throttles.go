@@ -0,0 +1,132 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"ratemysoft-backend/internal/models/sqlc"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func (q *queries) GetLoginThrottle(ctx context.Context, arg sqlc.GetLoginThrottleParams) (sqlc.LoginThrottle, error) {
+	st, done := q.begin()
+	defer done()
+
+	t, ok := st.throttles[throttleKey{arg.Scope, arg.Key}]
+	if !ok {
+		return sqlc.LoginThrottle{}, pgx.ErrNoRows
+	}
+	return t, nil
+}
+
+func (q *queries) ReserveLoginAttempt(ctx context.Context, arg sqlc.ReserveLoginAttemptParams) (sqlc.LoginThrottle, error) {
+	st, done := q.begin()
+	defer done()
+
+	key := throttleKey{arg.Scope, arg.Key}
+	t, ok := st.throttles[key]
+	switch {
+	case !ok:
+		t = sqlc.LoginThrottle{Scope: arg.Scope, Key: arg.Key, Failures: 1}
+	case t.LockedUntil.Valid && compareTime(t.LockedUntil, arg.AttemptedAt) > 0:
+		return t, nil
+	case compareTime(t.LastFailureAt, arg.ForgetBefore) < 0:
+		t.Failures = 1
+	default:
+		t.Failures++
+	}
+	t.LastFailureAt = arg.AttemptedAt
+	st.throttles[key] = t
+	return t, nil
+}
+
+func (q *queries) ReleaseLoginAttempt(ctx context.Context, arg sqlc.ReleaseLoginAttemptParams) error {
+	st, done := q.begin()
+	defer done()
+
+	key := throttleKey{arg.Scope, arg.Key}
+	t, ok := st.throttles[key]
+	if !ok {
+		return nil
+	}
+	t.Failures = max(t.Failures-1, 0)
+	if arg.ReservedLock.Valid && t.LockedUntil.Valid && compareTime(t.LockedUntil, arg.ReservedLock) == 0 {
+		t.LockedUntil = pgtype.Timestamptz{}
+	}
+	st.throttles[key] = t
+	return nil
+}
+
+func (q *queries) LockLoginThrottle(ctx context.Context, arg sqlc.LockLoginThrottleParams) error {
+	st, done := q.begin()
+	defer done()
+
+	key := throttleKey{arg.Scope, arg.Key}
+	if t, ok := st.throttles[key]; ok {
+		t.LockedUntil = arg.LockedUntil
+		st.throttles[key] = t
+	}
+	return nil
+}
+
+func (q *queries) DeleteLoginThrottle(ctx context.Context, arg sqlc.DeleteLoginThrottleParams) (int64, error) {
+	st, done := q.begin()
+	defer done()
+
+	key := throttleKey{arg.Scope, arg.Key}
+	if _, ok := st.throttles[key]; !ok {
+		return 0, nil
+	}
+	delete(st.throttles, key)
+	return 1, nil
+}
+
+func (q *queries) ListLockedAccounts(ctx context.Context, lockedUntil pgtype.Timestamptz) ([]sqlc.ListLockedAccountsRow, error) {
+	st, done := q.begin()
+	defer done()
+
+	var rows []sqlc.ListLockedAccountsRow
+	for _, t := range st.throttles {
+		if t.Scope != "account" || !t.LockedUntil.Valid || compareTime(t.LockedUntil, lockedUntil) <= 0 {
+			continue
+		}
+		for _, u := range st.users {
+			if u.Email != t.Key || deleted(u.DeletedAt) {
+				continue
+			}
+			rows = append(rows, sqlc.ListLockedAccountsRow{
+				UserID:        u.ID,
+				Handle:        u.Handle,
+				Email:         t.Key,
+				Failures:      t.Failures,
+				LastFailureAt: t.LastFailureAt,
+				LockedUntil:   t.LockedUntil,
+			})
+		}
+	}
+
+	slices.SortFunc(rows, func(a, b sqlc.ListLockedAccountsRow) int {
+		if c := compareTime(b.LockedUntil, a.LockedUntil); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Email, b.Email)
+	})
+	return rows, nil
+}
+
+func (q *queries) DeleteStaleLoginThrottles(ctx context.Context, staleBefore pgtype.Timestamptz) (int64, error) {
+	st, done := q.begin()
+	defer done()
+
+	var n int64
+	for key, t := range st.throttles {
+		if compareTime(t.LastFailureAt, staleBefore) < 0 && (!t.LockedUntil.Valid || compareTime(t.LockedUntil, staleBefore) < 0) {
+			delete(st.throttles, key)
+			n++
+		}
+	}
+	return n, nil
+}
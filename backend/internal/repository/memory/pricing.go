@@ -0,0 +1,110 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"strings"
+
+	"ratemysoft-backend/internal/models/sqlc"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+func (q *queries) CreatePricingPlan(ctx context.Context, arg sqlc.CreatePricingPlanParams) (sqlc.PricingPlan, error) {
+	st, done := q.begin()
+	defer done()
+
+	for _, p := range st.pricingPlans {
+		switch {
+		case p.ID == arg.ID:
+			return sqlc.PricingPlan{}, uniqueViolation("pricing_plans_pkey")
+		case p.ProductID == arg.ProductID && p.Name == arg.Name:
+			return sqlc.PricingPlan{}, uniqueViolation("pricing_plans_product_id_name_key")
+		}
+	}
+	if _, ok := st.products[arg.ProductID]; !ok {
+		return sqlc.PricingPlan{}, foreignKeyViolation("pricing_plans_product_id_fkey")
+	}
+
+	p := sqlc.PricingPlan(arg)
+	st.pricingPlans[p.ID] = p
+	return p, nil
+}
+
+func (q *queries) GetPricingPlan(ctx context.Context, arg sqlc.GetPricingPlanParams) (sqlc.PricingPlan, error) {
+	st, done := q.begin()
+	defer done()
+
+	p, ok := st.pricingPlans[arg.ID]
+	if !ok || p.ProductID != arg.ProductID {
+		return sqlc.PricingPlan{}, pgx.ErrNoRows
+	}
+	return p, nil
+}
+
+func (q *queries) ListPricingPlansByProduct(ctx context.Context, productID uuid.UUID) ([]sqlc.PricingPlan, error) {
+	return q.ListPricingPlansByProducts(ctx, []uuid.UUID{productID})
+}
+
+func (q *queries) ListPricingPlansByProducts(ctx context.Context, productIds []uuid.UUID) ([]sqlc.PricingPlan, error) {
+	st, done := q.begin()
+	defer done()
+
+	var rows []sqlc.PricingPlan
+	for _, p := range st.pricingPlans {
+		if containsID(productIds, p.ProductID) {
+			rows = append(rows, p)
+		}
+	}
+	slices.SortFunc(rows, func(a, b sqlc.PricingPlan) int {
+		return cmp.Or(
+			strings.Compare(a.ProductID.String(), b.ProductID.String()),
+			cmp.Compare(a.SortOrder, b.SortOrder),
+			cmp.Compare(a.PriceCents, b.PriceCents),
+			strings.Compare(a.Name, b.Name),
+		)
+	})
+	return rows, nil
+}
+
+func (q *queries) UpdatePricingPlan(ctx context.Context, arg sqlc.UpdatePricingPlanParams) (sqlc.PricingPlan, error) {
+	st, done := q.begin()
+	defer done()
+
+	p, ok := st.pricingPlans[arg.ID]
+	if !ok || p.ProductID != arg.ProductID {
+		return sqlc.PricingPlan{}, pgx.ErrNoRows
+	}
+	for _, other := range st.pricingPlans {
+		if other.ID != p.ID && other.ProductID == p.ProductID && other.Name == arg.Name {
+			return sqlc.PricingPlan{}, uniqueViolation("pricing_plans_product_id_name_key")
+		}
+	}
+
+	p.Name = arg.Name
+	p.BillingPeriod = arg.BillingPeriod
+	p.Currency = arg.Currency
+	p.PriceCents = arg.PriceCents
+	p.IsFree = arg.IsFree
+	p.PricingUnit = arg.PricingUnit
+	p.UsageUnit = arg.UsageUnit
+	p.Features = arg.Features
+	p.SortOrder = arg.SortOrder
+	p.UpdatedAt = arg.UpdatedAt
+	st.pricingPlans[p.ID] = p
+	return p, nil
+}
+
+func (q *queries) DeletePricingPlan(ctx context.Context, arg sqlc.DeletePricingPlanParams) (int64, error) {
+	st, done := q.begin()
+	defer done()
+
+	p, ok := st.pricingPlans[arg.ID]
+	if !ok || p.ProductID != arg.ProductID {
+		return 0, nil
+	}
+	delete(st.pricingPlans, p.ID)
+	return 1, nil
+}
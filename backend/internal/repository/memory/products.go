@@ -0,0 +1,319 @@
+package memory
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"strings"
+
+	"ratemysoft-backend/internal/models/sqlc"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+func (q *queries) CreateProduct(ctx context.Context, arg sqlc.CreateProductParams) (sqlc.Product, error) {
+	st, done := q.begin()
+	defer done()
+
+	for _, p := range st.products {
+		switch {
+		case p.ID == arg.ID:
+			return sqlc.Product{}, uniqueViolation("products_pkey")
+		case p.CompanyID == arg.CompanyID && p.Slug == arg.Slug:
+			return sqlc.Product{}, uniqueViolation("products_company_id_slug_key")
+		}
+	}
+	if _, ok := st.companies[arg.CompanyID]; !ok {
+		return sqlc.Product{}, foreignKeyViolation("products_company_id_fkey")
+	}
+	if !st.hasCategory(arg.Category) {
+		return sqlc.Product{}, foreignKeyViolation("products_category_fkey")
+	}
+
+	p := sqlc.Product{
+		ID:           arg.ID,
+		CompanyID:    arg.CompanyID,
+		Name:         arg.Name,
+		Slug:         arg.Slug,
+		Category:     arg.Category,
+		ShortTagline: arg.ShortTagline,
+		Description:  arg.Description,
+		HomepageUrl:  arg.HomepageUrl,
+		DocsUrl:      arg.DocsUrl,
+		AvgRating:    arg.AvgRating,
+		TotalReviews: arg.TotalReviews,
+		CreatedAt:    arg.CreatedAt,
+		UpdatedAt:    arg.UpdatedAt,
+	}
+	st.products[p.ID] = p
+	return p, nil
+}
+
+func (q *queries) GetProduct(ctx context.Context, id uuid.UUID) (sqlc.Product, error) {
+	st, done := q.begin()
+	defer done()
+
+	p, ok := st.products[id]
+	if !ok || deleted(p.DeletedAt) {
+		return sqlc.Product{}, pgx.ErrNoRows
+	}
+	return p, nil
+}
+
+func (q *queries) GetProductBySlug(ctx context.Context, slug string) (sqlc.GetProductBySlugRow, error) {
+	st, done := q.begin()
+	defer done()
+
+	for _, p := range st.products {
+		c, ok := st.companies[p.CompanyID]
+		if p.Slug != slug || deleted(p.DeletedAt) || !ok || deleted(c.DeletedAt) {
+			continue
+		}
+		return sqlc.GetProductBySlugRow{
+			ID:           p.ID,
+			CompanyID:    p.CompanyID,
+			Name:         p.Name,
+			Slug:         p.Slug,
+			Category:     p.Category,
+			ShortTagline: p.ShortTagline,
+			Description:  p.Description,
+			HomepageUrl:  p.HomepageUrl,
+			DocsUrl:      p.DocsUrl,
+			AvgRating:    p.AvgRating,
+			TotalReviews: p.TotalReviews,
+			CreatedAt:    p.CreatedAt,
+			UpdatedAt:    p.UpdatedAt,
+			DeletedAt:    p.DeletedAt,
+			CompanyName:  c.Name,
+			CompanySlug:  c.Slug,
+		}, nil
+	}
+	return sqlc.GetProductBySlugRow{}, pgx.ErrNoRows
+}
+
+func (q *queries) ListProductsForComparison(ctx context.Context, arg sqlc.ListProductsForComparisonParams) ([]sqlc.ListProductsForComparisonRow, error) {
+	st, done := q.begin()
+	defer done()
+
+	var rows []sqlc.ListProductsForComparisonRow
+	for _, p := range st.products {
+		c, ok := st.companies[p.CompanyID]
+		if !containsID(arg.Ids, p.ID) && !slices.Contains(arg.Slugs, p.Slug) || deleted(p.DeletedAt) || !ok || deleted(c.DeletedAt) {
+			continue
+		}
+		rows = append(rows, sqlc.ListProductsForComparisonRow{
+			ID:           p.ID,
+			CompanyID:    p.CompanyID,
+			Name:         p.Name,
+			Slug:         p.Slug,
+			Category:     p.Category,
+			ShortTagline: p.ShortTagline,
+			Description:  p.Description,
+			HomepageUrl:  p.HomepageUrl,
+			DocsUrl:      p.DocsUrl,
+			AvgRating:    p.AvgRating,
+			TotalReviews: p.TotalReviews,
+			CreatedAt:    p.CreatedAt,
+			UpdatedAt:    p.UpdatedAt,
+			DeletedAt:    p.DeletedAt,
+			CompanyName:  c.Name,
+			CompanySlug:  c.Slug,
+		})
+	}
+	return rows, nil
+}
+
+func (q *queries) CountProductsByCompany(ctx context.Context, companyID uuid.UUID) (int64, error) {
+	st, done := q.begin()
+	defer done()
+
+	var n int64
+	for _, p := range st.products {
+		if p.CompanyID == companyID && !deleted(p.DeletedAt) {
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (q *queries) UpdateProduct(ctx context.Context, arg sqlc.UpdateProductParams) (sqlc.Product, error) {
+	st, done := q.begin()
+	defer done()
+
+	p, ok := st.products[arg.ID]
+	if !ok || deleted(p.DeletedAt) {
+		return sqlc.Product{}, pgx.ErrNoRows
+	}
+	for _, other := range st.products {
+		if other.ID != p.ID && other.CompanyID == p.CompanyID && other.Slug == arg.Slug {
+			return sqlc.Product{}, uniqueViolation("products_company_id_slug_key")
+		}
+	}
+	if !st.hasCategory(arg.Category) {
+		return sqlc.Product{}, foreignKeyViolation("products_category_fkey")
+	}
+
+	p.Name = arg.Name
+	p.Slug = arg.Slug
+	p.Category = arg.Category
+	p.ShortTagline = arg.ShortTagline
+	p.Description = arg.Description
+	p.HomepageUrl = arg.HomepageUrl
+	p.DocsUrl = arg.DocsUrl
+	p.AvgRating = arg.AvgRating
+	p.TotalReviews = arg.TotalReviews
+	p.UpdatedAt = arg.UpdatedAt
+	st.products[p.ID] = p
+	return p, nil
+}
+
+func (q *queries) SoftDeleteProduct(ctx context.Context, id uuid.UUID) error {
+	st, done := q.begin()
+	defer done()
+
+	if p, ok := st.products[id]; ok && !deleted(p.DeletedAt) {
+		p.DeletedAt = now()
+		st.products[id] = p
+	}
+	return nil
+}
+
+// LockProduct is a no-op: units of work already hold the store's lock
+func (q *queries) LockProduct(ctx context.Context, id uuid.UUID) error {
+	return nil
+}
+
+func (q *queries) UpdateProductStats(ctx context.Context, arg sqlc.UpdateProductStatsParams) error {
+	st, done := q.begin()
+	defer done()
+
+	if p, ok := st.products[arg.ID]; ok && !deleted(p.DeletedAt) {
+		p.AvgRating = arg.AvgRating
+		p.TotalReviews = arg.TotalReviews
+		p.UpdatedAt = now()
+		st.products[p.ID] = p
+	}
+	return nil
+}
+
+func (q *queries) GetProductRatingStats(ctx context.Context, productID uuid.UUID) (sqlc.ProductRatingStat, error) {
+	st, done := q.begin()
+	defer done()
+
+	stats, ok := st.ratingStats[productID]
+	if !ok {
+		return sqlc.ProductRatingStat{}, pgx.ErrNoRows
+	}
+	return stats, nil
+}
+
+func (q *queries) RefreshProductRatingStats(ctx context.Context, productID uuid.UUID) error {
+	st, done := q.begin()
+	defer done()
+
+	var stars [5]int32
+	for _, r := range st.publishedReviews(productID) {
+		if r.Rating >= 1 && r.Rating <= 5 {
+			stars[r.Rating-1]++
+		}
+	}
+	st.ratingStats[productID] = sqlc.ProductRatingStat{
+		ProductID: productID,
+		Star1:     stars[0],
+		Star2:     stars[1],
+		Star3:     stars[2],
+		Star4:     stars[3],
+		Star5:     stars[4],
+		UpdatedAt: now(),
+	}
+	return nil
+}
+
+func (q *queries) ListProductDimensionStats(ctx context.Context, productID uuid.UUID) ([]sqlc.ProductDimensionStat, error) {
+	st, done := q.begin()
+	defer done()
+
+	var rows []sqlc.ProductDimensionStat
+	for key, stat := range st.dimensionStats {
+		if key.id == productID {
+			rows = append(rows, stat)
+		}
+	}
+	slices.SortFunc(rows, func(a, b sqlc.ProductDimensionStat) int {
+		return strings.Compare(a.Dimension, b.Dimension)
+	})
+	return rows, nil
+}
+
+func (q *queries) DeleteProductDimensionStats(ctx context.Context, productID uuid.UUID) error {
+	st, done := q.begin()
+	defer done()
+
+	maps.DeleteFunc(st.dimensionStats, func(key dimensionKey, _ sqlc.ProductDimensionStat) bool {
+		return key.id == productID
+	})
+	return nil
+}
+
+func (q *queries) RefreshProductDimensionStats(ctx context.Context, productID uuid.UUID) error {
+	st, done := q.begin()
+	defer done()
+
+	type total struct {
+		sum, count int64
+	}
+	totals := map[string]*total{}
+	for _, r := range st.publishedReviews(productID) {
+		for key, s := range st.subRatings {
+			if key.id != r.ID {
+				continue
+			}
+			t, ok := totals[s.Dimension]
+			if !ok {
+				t = &total{}
+				totals[s.Dimension] = t
+			}
+			t.sum += int64(s.Rating)
+			t.count++
+		}
+	}
+
+	for dimension := range totals {
+		if _, ok := st.dimensionStats[dimensionKey{id: productID, dimension: dimension}]; ok {
+			return uniqueViolation("product_dimension_stats_pkey")
+		}
+	}
+
+	ts := now()
+	for dimension, t := range totals {
+		st.dimensionStats[dimensionKey{id: productID, dimension: dimension}] = sqlc.ProductDimensionStat{
+			ProductID:   productID,
+			Dimension:   dimension,
+			AvgRating:   float64(t.sum) / float64(t.count),
+			RatingCount: int32(t.count),
+			UpdatedAt:   ts,
+		}
+	}
+	return nil
+}
+
+func (st *state) hasCategory(slug string) bool {
+	for _, c := range st.categories {
+		if c.Slug == slug {
+			return true
+		}
+	}
+	return false
+}
+
+// publishedReviews returns the live, published reviews of a product
+func (st *state) publishedReviews(productID uuid.UUID) []sqlc.Review {
+	var rows []sqlc.Review
+	for _, r := range st.reviews {
+		if r.ProductID == productID && r.Status == "published" && !deleted(r.DeletedAt) {
+			rows = append(rows, r)
+		}
+	}
+	return rows
+}
@@ -0,0 +1,223 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"ratemysoft-backend/internal/models/sqlc"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// LockReputationRefresh is a no-op: units of work already hold the store's lock
+func (q *queries) LockReputationRefresh(ctx context.Context) error {
+	return nil
+}
+
+func (q *queries) DeleteUserReputation(ctx context.Context) error {
+	st, done := q.begin()
+	defer done()
+
+	clear(st.reputation)
+	return nil
+}
+
+// RefreshUserReputation scores every author: helpful votes received + 5 per published review
+// - 20 per rejected review + 1 per 30 days since sign-up (at most 24, and only once the user
+// has published a review), never below 0
+func (q *queries) RefreshUserReputation(ctx context.Context) error {
+	st, done := q.begin()
+	defer done()
+
+	authored := map[uuid.UUID]*sqlc.UserReputation{}
+	for _, r := range st.reviews {
+		p, ok := st.products[r.ProductID]
+		if !ok {
+			continue
+		}
+		a, ok := authored[r.UserID]
+		if !ok {
+			a = &sqlc.UserReputation{UserID: r.UserID}
+			authored[r.UserID] = a
+		}
+		switch {
+		case r.Status == "published" && !deleted(r.DeletedAt) && !deleted(p.DeletedAt):
+			a.PublishedCount++
+			a.HelpfulVotes += r.UpvoteCount
+		case r.Status == "rejected":
+			a.RejectedCount++
+		}
+	}
+
+	for userID := range authored {
+		if _, ok := st.reputation[userID]; ok {
+			return uniqueViolation("user_reputation_pkey")
+		}
+	}
+
+	ts := now()
+	for userID, a := range authored {
+		u, ok := st.users[userID]
+		if !ok {
+			continue
+		}
+		reputation := a.HelpfulVotes + 5*a.PublishedCount - 20*a.RejectedCount
+		if a.PublishedCount > 0 {
+			reputation += min(24, int32(ts.Time.Sub(u.CreatedAt.Time)/(30*24*time.Hour)))
+		}
+		a.Reputation = max(0, reputation)
+		a.RefreshedAt = ts
+		st.reputation[userID] = *a
+	}
+	return nil
+}
+
+func (q *queries) DeleteReviewerBadges(ctx context.Context) error {
+	st, done := q.begin()
+	defer done()
+
+	st.badges = nil
+	return nil
+}
+
+// RefreshReviewerBadges gives the topN reviewers of each category by helpful votes received
+// a badge; ties go to the reviewer with more reviews there, then to the older account
+func (q *queries) RefreshReviewerBadges(ctx context.Context, topN int32) error {
+	st, done := q.begin()
+	defer done()
+
+	type categoryVotes struct {
+		userID   uuid.UUID
+		category string
+		joinedAt time.Time
+		helpful  int32
+		reviews  int64
+	}
+	totals := map[string]map[uuid.UUID]*categoryVotes{}
+	for _, r := range st.reviews {
+		p, pok := st.products[r.ProductID]
+		u, uok := st.users[r.UserID]
+		if deleted(r.DeletedAt) || r.Status != "published" || !pok || deleted(p.DeletedAt) || !uok || deleted(u.DeletedAt) {
+			continue
+		}
+		byUser, ok := totals[p.Category]
+		if !ok {
+			byUser = map[uuid.UUID]*categoryVotes{}
+			totals[p.Category] = byUser
+		}
+		v, ok := byUser[r.UserID]
+		if !ok {
+			v = &categoryVotes{userID: r.UserID, category: p.Category, joinedAt: u.CreatedAt.Time}
+			byUser[r.UserID] = v
+		}
+		v.helpful += r.UpvoteCount
+		v.reviews++
+	}
+
+	if len(st.badges) > 0 && len(totals) > 0 {
+		return uniqueViolation("reviewer_badges_pkey")
+	}
+
+	ts := now()
+	for _, byUser := range totals {
+		var ranked []*categoryVotes
+		for _, v := range byUser {
+			if v.helpful > 0 {
+				ranked = append(ranked, v)
+			}
+		}
+		slices.SortFunc(ranked, func(a, b *categoryVotes) int {
+			return cmp.Or(
+				cmp.Compare(b.helpful, a.helpful),
+				cmp.Compare(b.reviews, a.reviews),
+				a.joinedAt.Compare(b.joinedAt),
+				strings.Compare(a.userID.String(), b.userID.String()),
+			)
+		})
+		for i, v := range ranked {
+			if int32(i) >= topN {
+				break
+			}
+			st.badges = append(st.badges, sqlc.ReviewerBadge{
+				UserID:       v.userID,
+				Category:     v.category,
+				Rank:         int32(i + 1),
+				HelpfulVotes: v.helpful,
+				RefreshedAt:  ts,
+			})
+		}
+	}
+	return nil
+}
+
+// GetPublicProfile counts reviews and helpful votes live; reputation is as of the last refresh
+func (q *queries) GetPublicProfile(ctx context.Context, handle string) (sqlc.GetPublicProfileRow, error) {
+	st, done := q.begin()
+	defer done()
+
+	for _, u := range st.users {
+		if u.Handle != handle || deleted(u.DeletedAt) {
+			continue
+		}
+		row := sqlc.GetPublicProfileRow{
+			ID:        u.ID,
+			Handle:    u.Handle,
+			CreatedAt: u.CreatedAt,
+		}
+		for _, r := range st.reviews {
+			p, ok := st.products[r.ProductID]
+			if r.UserID == u.ID && r.Status == "published" && !deleted(r.DeletedAt) && ok && !deleted(p.DeletedAt) {
+				row.ReviewCount++
+				row.HelpfulVotes += int64(r.UpvoteCount)
+			}
+		}
+		if rep, ok := st.reputation[u.ID]; ok {
+			row.Reputation = rep.Reputation
+			row.RefreshedAt = rep.RefreshedAt
+		}
+		return row, nil
+	}
+	return sqlc.GetPublicProfileRow{}, pgx.ErrNoRows
+}
+
+func (q *queries) ListReviewerBadges(ctx context.Context, userID uuid.UUID) ([]sqlc.ListReviewerBadgesRow, error) {
+	st, done := q.begin()
+	defer done()
+
+	type badgeRow struct {
+		sqlc.ListReviewerBadgesRow
+		sortOrder int32
+	}
+	var rows []badgeRow
+	for _, b := range st.badges {
+		if b.UserID != userID {
+			continue
+		}
+		for _, c := range st.categories {
+			if c.Slug == b.Category {
+				rows = append(rows, badgeRow{
+					ListReviewerBadgesRow: sqlc.ListReviewerBadgesRow{
+						Category:     b.Category,
+						CategoryName: c.Name,
+						Rank:         b.Rank,
+						HelpfulVotes: b.HelpfulVotes,
+					},
+					sortOrder: c.SortOrder,
+				})
+			}
+		}
+	}
+	slices.SortFunc(rows, func(a, b badgeRow) int {
+		return cmp.Or(cmp.Compare(a.Rank, b.Rank), cmp.Compare(a.sortOrder, b.sortOrder), strings.Compare(a.CategoryName, b.CategoryName))
+	})
+
+	badges := make([]sqlc.ListReviewerBadgesRow, 0, len(rows))
+	for _, row := range rows {
+		badges = append(badges, row.ListReviewerBadgesRow)
+	}
+	return badges, nil
+}
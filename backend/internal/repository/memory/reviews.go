@@ -0,0 +1,566 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"maps"
+	"math/big"
+	"slices"
+	"strings"
+
+	"ratemysoft-backend/internal/models/sqlc"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func (q *queries) CreateReview(ctx context.Context, arg sqlc.CreateReviewParams) (sqlc.Review, error) {
+	st, done := q.begin()
+	defer done()
+
+	for _, r := range st.reviews {
+		switch {
+		case r.ID == arg.ID:
+			return sqlc.Review{}, uniqueViolation("reviews_pkey")
+		case r.ProductID == arg.ProductID && r.UserID == arg.UserID:
+			return sqlc.Review{}, uniqueViolation("reviews_product_id_user_id_key")
+		}
+	}
+	if _, ok := st.products[arg.ProductID]; !ok {
+		return sqlc.Review{}, foreignKeyViolation("reviews_product_id_fkey")
+	}
+	if _, ok := st.users[arg.UserID]; !ok {
+		return sqlc.Review{}, foreignKeyViolation("reviews_user_id_fkey")
+	}
+
+	r := sqlc.Review{
+		ID:            arg.ID,
+		ProductID:     arg.ProductID,
+		UserID:        arg.UserID,
+		Title:         arg.Title,
+		Body:          arg.Body,
+		Rating:        arg.Rating,
+		Status:        arg.Status,
+		UpvoteCount:   arg.UpvoteCount,
+		DownvoteCount: arg.DownvoteCount,
+		FlagCount:     arg.FlagCount,
+		Edited:        arg.Edited,
+		CreatedAt:     arg.CreatedAt,
+		UpdatedAt:     arg.UpdatedAt,
+	}
+	st.reviews[r.ID] = r
+	return r, nil
+}
+
+func (q *queries) GetReview(ctx context.Context, id uuid.UUID) (sqlc.GetReviewRow, error) {
+	st, done := q.begin()
+	defer done()
+
+	r, ok := st.reviews[id]
+	if !ok || deleted(r.DeletedAt) {
+		return sqlc.GetReviewRow{}, pgx.ErrNoRows
+	}
+	row, ok := st.joinReview(r)
+	if !ok {
+		return sqlc.GetReviewRow{}, pgx.ErrNoRows
+	}
+	return row, nil
+}
+
+func (q *queries) GetReviewForUpdate(ctx context.Context, id uuid.UUID) (sqlc.Review, error) {
+	st, done := q.begin()
+	defer done()
+
+	r, ok := st.reviews[id]
+	if !ok || deleted(r.DeletedAt) {
+		return sqlc.Review{}, pgx.ErrNoRows
+	}
+	return r, nil
+}
+
+func (q *queries) GetUserReviewForProduct(ctx context.Context, arg sqlc.GetUserReviewForProductParams) (sqlc.Review, error) {
+	st, done := q.begin()
+	defer done()
+
+	for _, r := range st.reviews {
+		if r.ProductID == arg.ProductID && r.UserID == arg.UserID && !deleted(r.DeletedAt) {
+			return r, nil
+		}
+	}
+	return sqlc.Review{}, pgx.ErrNoRows
+}
+
+func (q *queries) UpdateReview(ctx context.Context, arg sqlc.UpdateReviewParams) (sqlc.Review, error) {
+	st, done := q.begin()
+	defer done()
+
+	r, ok := st.reviews[arg.ID]
+	if !ok || deleted(r.DeletedAt) {
+		return sqlc.Review{}, pgx.ErrNoRows
+	}
+	r.Title = arg.Title
+	r.Body = arg.Body
+	r.Rating = arg.Rating
+	r.Status = arg.Status
+	r.Edited = arg.Edited
+	r.UpdatedAt = arg.UpdatedAt
+	st.reviews[r.ID] = r
+	return r, nil
+}
+
+func (q *queries) UpdateReviewStatus(ctx context.Context, arg sqlc.UpdateReviewStatusParams) error {
+	st, done := q.begin()
+	defer done()
+
+	st.updateReview(arg.ID, func(r *sqlc.Review) {
+		r.Status = arg.Status
+	})
+	return nil
+}
+
+func (q *queries) SoftDeleteReview(ctx context.Context, id uuid.UUID) error {
+	st, done := q.begin()
+	defer done()
+
+	if r, ok := st.reviews[id]; ok && !deleted(r.DeletedAt) {
+		r.DeletedAt = now()
+		st.reviews[id] = r
+	}
+	return nil
+}
+
+func (q *queries) CountReviewsByProduct(ctx context.Context, productID uuid.UUID) (int64, error) {
+	st, done := q.begin()
+	defer done()
+
+	return int64(len(st.publishedReviews(productID))), nil
+}
+
+func (q *queries) CountReviewsByUser(ctx context.Context, arg sqlc.CountReviewsByUserParams) (int64, error) {
+	st, done := q.begin()
+	defer done()
+
+	var n int64
+	for _, r := range st.reviews {
+		if r.UserID == arg.UserID && !deleted(r.DeletedAt) && (r.Status == "published" || !arg.PublishedOnly) {
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (q *queries) CountReviewsByStatus(ctx context.Context, status string) (int64, error) {
+	st, done := q.begin()
+	defer done()
+
+	var n int64
+	for _, r := range st.reviews {
+		if r.Status == status && !deleted(r.DeletedAt) {
+			n++
+		}
+	}
+	return n, nil
+}
+
+// GetAverageRatingByProduct rounds to two decimal places like AVG(rating)::DECIMAL(3,2),
+// and is NULL when the product has no published reviews
+func (q *queries) GetAverageRatingByProduct(ctx context.Context, productID uuid.UUID) (pgtype.Numeric, error) {
+	st, done := q.begin()
+	defer done()
+
+	reviews := st.publishedReviews(productID)
+	if len(reviews) == 0 {
+		return pgtype.Numeric{}, nil
+	}
+	var sum int64
+	for _, r := range reviews {
+		sum += int64(r.Rating)
+	}
+	count := int64(len(reviews))
+	hundredths := (sum*200 + count) / (2 * count)
+	return pgtype.Numeric{Int: big.NewInt(hundredths), Exp: -2, Valid: true}, nil
+}
+
+func (q *queries) CountReviewCommentsByReviews(ctx context.Context, reviewIds []uuid.UUID) ([]sqlc.CountReviewCommentsByReviewsRow, error) {
+	st, done := q.begin()
+	defer done()
+
+	counts := map[uuid.UUID]int64{}
+	for _, c := range st.comments {
+		if containsID(reviewIds, c.ReviewID) && c.Status == "published" && !deleted(c.DeletedAt) {
+			counts[c.ReviewID]++
+		}
+	}
+
+	rows := make([]sqlc.CountReviewCommentsByReviewsRow, 0, len(counts))
+	for reviewID, n := range counts {
+		rows = append(rows, sqlc.CountReviewCommentsByReviewsRow{ReviewID: reviewID, CommentCount: n})
+	}
+	return rows, nil
+}
+
+func (q *queries) CreateReviewSubRating(ctx context.Context, arg sqlc.CreateReviewSubRatingParams) error {
+	st, done := q.begin()
+	defer done()
+
+	key := dimensionKey{id: arg.ReviewID, dimension: arg.Dimension}
+	if _, ok := st.subRatings[key]; ok {
+		return uniqueViolation("review_sub_ratings_pkey")
+	}
+	if _, ok := st.reviews[arg.ReviewID]; !ok {
+		return foreignKeyViolation("review_sub_ratings_review_id_fkey")
+	}
+	st.subRatings[key] = sqlc.ReviewSubRating{
+		ReviewID:  arg.ReviewID,
+		Dimension: arg.Dimension,
+		Rating:    arg.Rating,
+		CreatedAt: arg.CreatedAt,
+	}
+	return nil
+}
+
+func (q *queries) ListReviewSubRatings(ctx context.Context, reviewIds []uuid.UUID) ([]sqlc.ReviewSubRating, error) {
+	st, done := q.begin()
+	defer done()
+
+	var rows []sqlc.ReviewSubRating
+	for key, s := range st.subRatings {
+		if containsID(reviewIds, key.id) {
+			rows = append(rows, s)
+		}
+	}
+	slices.SortFunc(rows, func(a, b sqlc.ReviewSubRating) int {
+		return cmp.Or(strings.Compare(a.ReviewID.String(), b.ReviewID.String()), strings.Compare(a.Dimension, b.Dimension))
+	})
+	return rows, nil
+}
+
+func (q *queries) DeleteReviewSubRatings(ctx context.Context, reviewID uuid.UUID) error {
+	st, done := q.begin()
+	defer done()
+
+	maps.DeleteFunc(st.subRatings, func(key dimensionKey, _ sqlc.ReviewSubRating) bool {
+		return key.id == reviewID
+	})
+	return nil
+}
+
+func (q *queries) GetReviewVote(ctx context.Context, arg sqlc.GetReviewVoteParams) (sqlc.ReviewVote, error) {
+	st, done := q.begin()
+	defer done()
+
+	v, ok := st.votes[pairKey{arg.ReviewID, arg.UserID}]
+	if !ok {
+		return sqlc.ReviewVote{}, pgx.ErrNoRows
+	}
+	return v, nil
+}
+
+func (q *queries) UpsertReviewVote(ctx context.Context, arg sqlc.UpsertReviewVoteParams) (sqlc.ReviewVote, error) {
+	st, done := q.begin()
+	defer done()
+
+	key := pairKey{arg.ReviewID, arg.UserID}
+	v, ok := st.votes[key]
+	if !ok {
+		v = sqlc.ReviewVote{ReviewID: arg.ReviewID, UserID: arg.UserID, CreatedAt: arg.CreatedAt}
+	}
+	v.Vote = arg.Vote
+	v.UpdatedAt = arg.UpdatedAt
+	st.votes[key] = v
+	return v, nil
+}
+
+func (q *queries) DeleteReviewVote(ctx context.Context, arg sqlc.DeleteReviewVoteParams) (int64, error) {
+	st, done := q.begin()
+	defer done()
+
+	key := pairKey{arg.ReviewID, arg.UserID}
+	if _, ok := st.votes[key]; !ok {
+		return 0, nil
+	}
+	delete(st.votes, key)
+	return 1, nil
+}
+
+func (q *queries) AdjustReviewVoteCounts(ctx context.Context, arg sqlc.AdjustReviewVoteCountsParams) error {
+	st, done := q.begin()
+	defer done()
+
+	st.updateReview(arg.ID, func(r *sqlc.Review) {
+		r.UpvoteCount = max(r.UpvoteCount+arg.UpvoteDelta, 0)
+		r.DownvoteCount = max(r.DownvoteCount+arg.DownvoteDelta, 0)
+	})
+	return nil
+}
+
+func (q *queries) GetUserVotesForReviews(ctx context.Context, arg sqlc.GetUserVotesForReviewsParams) ([]sqlc.ReviewVote, error) {
+	st, done := q.begin()
+	defer done()
+
+	var rows []sqlc.ReviewVote
+	for _, v := range st.votes {
+		if v.UserID == arg.UserID && containsID(arg.ReviewIds, v.ReviewID) {
+			rows = append(rows, v)
+		}
+	}
+	return rows, nil
+}
+
+func (q *queries) GetReviewFlag(ctx context.Context, arg sqlc.GetReviewFlagParams) (sqlc.ReviewFlag, error) {
+	st, done := q.begin()
+	defer done()
+
+	f, ok := st.flags[pairKey{arg.ReviewID, arg.UserID}]
+	if !ok {
+		return sqlc.ReviewFlag{}, pgx.ErrNoRows
+	}
+	return f, nil
+}
+
+func (q *queries) UpsertReviewFlag(ctx context.Context, arg sqlc.UpsertReviewFlagParams) (sqlc.ReviewFlag, error) {
+	st, done := q.begin()
+	defer done()
+
+	key := pairKey{arg.ReviewID, arg.UserID}
+	f, ok := st.flags[key]
+	if !ok {
+		f = sqlc.ReviewFlag{ReviewID: arg.ReviewID, UserID: arg.UserID, CreatedAt: arg.CreatedAt}
+	}
+	f.Reason = arg.Reason
+	f.Note = arg.Note
+	f.UpdatedAt = arg.UpdatedAt
+	st.flags[key] = f
+	return f, nil
+}
+
+func (q *queries) DeleteReviewFlag(ctx context.Context, arg sqlc.DeleteReviewFlagParams) (int64, error) {
+	st, done := q.begin()
+	defer done()
+
+	key := pairKey{arg.ReviewID, arg.UserID}
+	if _, ok := st.flags[key]; !ok {
+		return 0, nil
+	}
+	delete(st.flags, key)
+	return 1, nil
+}
+
+func (q *queries) DeleteReviewFlags(ctx context.Context, reviewID uuid.UUID) error {
+	st, done := q.begin()
+	defer done()
+
+	maps.DeleteFunc(st.flags, func(key pairKey, _ sqlc.ReviewFlag) bool {
+		return key.a == reviewID
+	})
+	return nil
+}
+
+func (q *queries) ListReviewFlags(ctx context.Context, reviewID uuid.UUID) ([]sqlc.ListReviewFlagsRow, error) {
+	st, done := q.begin()
+	defer done()
+
+	var rows []sqlc.ListReviewFlagsRow
+	for _, f := range st.flags {
+		u, ok := st.users[f.UserID]
+		if f.ReviewID != reviewID || !ok {
+			continue
+		}
+		rows = append(rows, sqlc.ListReviewFlagsRow{
+			ReviewID:   f.ReviewID,
+			UserID:     f.UserID,
+			Reason:     f.Reason,
+			Note:       f.Note,
+			CreatedAt:  f.CreatedAt,
+			UpdatedAt:  f.UpdatedAt,
+			UserHandle: u.Handle,
+		})
+	}
+	slices.SortFunc(rows, func(a, b sqlc.ListReviewFlagsRow) int {
+		return cmp.Or(compareTime(a.CreatedAt, b.CreatedAt), strings.Compare(a.UserID.String(), b.UserID.String()))
+	})
+	return rows, nil
+}
+
+func (q *queries) AdjustReviewFlagCount(ctx context.Context, arg sqlc.AdjustReviewFlagCountParams) error {
+	st, done := q.begin()
+	defer done()
+
+	st.updateReview(arg.ID, func(r *sqlc.Review) {
+		r.FlagCount = max(r.FlagCount+arg.FlagDelta, 0)
+	})
+	return nil
+}
+
+func (q *queries) ClearReviewFlags(ctx context.Context, id uuid.UUID) error {
+	st, done := q.begin()
+	defer done()
+
+	st.updateReview(id, func(r *sqlc.Review) {
+		r.FlagCount = 0
+	})
+	return nil
+}
+
+func (q *queries) CountFlaggedReviews(ctx context.Context, flagCount int32) (int64, error) {
+	st, done := q.begin()
+	defer done()
+
+	var n int64
+	for _, r := range st.reviews {
+		if r.FlagCount >= flagCount && r.Status != "rejected" && !deleted(r.DeletedAt) {
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (q *queries) CreateReviewRevision(ctx context.Context, arg sqlc.CreateReviewRevisionParams) error {
+	st, done := q.begin()
+	defer done()
+
+	for _, rev := range st.revisions {
+		if rev.ReviewID == arg.ReviewID && rev.Revision == arg.Revision {
+			return uniqueViolation("review_revisions_review_id_revision_key")
+		}
+	}
+	st.revisions = append(st.revisions, sqlc.ReviewRevision{
+		ID:         arg.ID,
+		ReviewID:   arg.ReviewID,
+		Revision:   arg.Revision,
+		Title:      arg.Title,
+		Body:       arg.Body,
+		Rating:     arg.Rating,
+		SubRatings: arg.SubRatings,
+		CreatedAt:  arg.CreatedAt,
+	})
+	return nil
+}
+
+func (q *queries) GetLatestReviewRevision(ctx context.Context, reviewID uuid.UUID) (int32, error) {
+	st, done := q.begin()
+	defer done()
+
+	var latest int32
+	for _, rev := range st.revisions {
+		if rev.ReviewID == reviewID {
+			latest = max(latest, rev.Revision)
+		}
+	}
+	return latest, nil
+}
+
+func (q *queries) ListReviewRevisions(ctx context.Context, reviewID uuid.UUID) ([]sqlc.ReviewRevision, error) {
+	st, done := q.begin()
+	defer done()
+
+	var rows []sqlc.ReviewRevision
+	for _, rev := range st.revisions {
+		if rev.ReviewID == reviewID {
+			rows = append(rows, rev)
+		}
+	}
+	slices.SortFunc(rows, func(a, b sqlc.ReviewRevision) int {
+		return cmp.Compare(a.Revision, b.Revision)
+	})
+	return rows, nil
+}
+
+func (q *queries) CreateReviewRatingEvent(ctx context.Context, arg sqlc.CreateReviewRatingEventParams) error {
+	st, done := q.begin()
+	defer done()
+
+	st.ratingEvents = append(st.ratingEvents, sqlc.ReviewRatingEvent(arg))
+	return nil
+}
+
+func (q *queries) CountProductRatingEvents(ctx context.Context, productID uuid.UUID) (int64, error) {
+	st, done := q.begin()
+	defer done()
+
+	var n int64
+	for _, e := range st.ratingEvents {
+		if e.ProductID == productID {
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (q *queries) CreateReviewModeration(ctx context.Context, arg sqlc.CreateReviewModerationParams) (sqlc.ReviewModeration, error) {
+	st, done := q.begin()
+	defer done()
+
+	m := sqlc.ReviewModeration(arg)
+	st.moderations = append(st.moderations, m)
+	return m, nil
+}
+
+func (q *queries) ListReviewModerations(ctx context.Context, reviewID uuid.UUID) ([]sqlc.ListReviewModerationsRow, error) {
+	st, done := q.begin()
+	defer done()
+
+	var rows []sqlc.ListReviewModerationsRow
+	for _, m := range st.moderations {
+		u, ok := st.users[m.ModeratorID]
+		if m.ReviewID != reviewID || !ok {
+			continue
+		}
+		rows = append(rows, sqlc.ListReviewModerationsRow{
+			ID:              m.ID,
+			ReviewID:        m.ReviewID,
+			ModeratorID:     m.ModeratorID,
+			FromStatus:      m.FromStatus,
+			ToStatus:        m.ToStatus,
+			Reason:          m.Reason,
+			CreatedAt:       m.CreatedAt,
+			CommentID:       m.CommentID,
+			ModeratorHandle: u.Handle,
+		})
+	}
+	slices.SortStableFunc(rows, func(a, b sqlc.ListReviewModerationsRow) int {
+		return compareTime(b.CreatedAt, a.CreatedAt)
+	})
+	return rows, nil
+}
+
+// joinReview attaches the author's handle and the product name, dropping reviews whose
+// author or product has been deleted
+func (st *state) joinReview(r sqlc.Review) (sqlc.GetReviewRow, bool) {
+	u, okUser := st.users[r.UserID]
+	p, okProduct := st.products[r.ProductID]
+	if !okUser || !okProduct || deleted(p.DeletedAt) {
+		return sqlc.GetReviewRow{}, false
+	}
+	return sqlc.GetReviewRow{
+		ID:            r.ID,
+		ProductID:     r.ProductID,
+		UserID:        r.UserID,
+		Title:         r.Title,
+		Body:          r.Body,
+		Rating:        r.Rating,
+		Status:        r.Status,
+		UpvoteCount:   r.UpvoteCount,
+		DownvoteCount: r.DownvoteCount,
+		FlagCount:     r.FlagCount,
+		Edited:        r.Edited,
+		CreatedAt:     r.CreatedAt,
+		UpdatedAt:     r.UpdatedAt,
+		DeletedAt:     r.DeletedAt,
+		UserHandle:    u.Handle,
+		ProductName:   p.Name,
+	}, true
+}
+
+// updateReview applies fn to a live review and bumps its updated_at, like the
+// single-column UPDATE statements on reviews
+func (st *state) updateReview(id uuid.UUID, fn func(r *sqlc.Review)) {
+	r, ok := st.reviews[id]
+	if !ok || deleted(r.DeletedAt) {
+		return
+	}
+	fn(&r)
+	r.UpdatedAt = now()
+	st.reviews[id] = r
+}
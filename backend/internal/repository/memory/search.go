@@ -0,0 +1,220 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"math"
+	"regexp"
+	"slices"
+	"strings"
+
+	"ratemysoft-backend/internal/domain"
+	"ratemysoft-backend/internal/models/sqlc"
+	"ratemysoft-backend/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+// Markers the search highlights matched terms with, like the Postgres store's ts_headline
+const (
+	highlightStart = "\ue000"
+	highlightStop  = "\ue001"
+)
+
+// searchHit is a product matched by a search query with its rank
+type searchHit struct {
+	product sqlc.Product
+	company sqlc.Company
+	rank    float64
+}
+
+// search approximates the full-text search of the Postgres store: every word of the query
+// must occur in the product's name, tagline, description or company name, weighted in that
+// order, unless the whole query occurs in the product or company name. There is no stemming
+// and no typo tolerance.
+func (st *state) search(query string) []searchHit {
+	words := strings.Fields(strings.ToLower(query))
+	raw := strings.ToLower(strings.TrimSpace(query))
+	if len(words) == 0 {
+		return nil
+	}
+
+	var hits []searchHit
+	for _, p := range st.products {
+		c, ok := st.companies[p.CompanyID]
+		if deleted(p.DeletedAt) || !ok || deleted(c.DeletedAt) {
+			continue
+		}
+
+		// Field weights match the search document's A to D
+		fields := []struct {
+			text   string
+			weight float64
+		}{
+			{p.Name, 1.0},
+			{deref(p.ShortTagline), 0.4},
+			{deref(p.Description), 0.2},
+			{c.Name, 0.1},
+		}
+		var rank float64
+		matched := true
+		for _, word := range words {
+			found := false
+			for _, field := range fields {
+				if strings.Contains(strings.ToLower(field.text), word) {
+					rank += field.weight
+					found = true
+				}
+			}
+			matched = matched && found
+		}
+
+		switch {
+		case strings.Contains(strings.ToLower(p.Name), raw):
+			rank++
+		case strings.Contains(strings.ToLower(c.Name), raw):
+			rank += 0.5
+		case !matched:
+			continue
+		}
+		hits = append(hits, searchHit{product: p, company: c, rank: rank})
+	}
+	return hits
+}
+
+// SearchProductsPage orders names by bytes, where Postgres would use the collation
+func (s *Store) SearchProductsPage(ctx context.Context, search repository.ProductSearch, page domain.PageRequest) ([]sqlc.SearchProductsRow, domain.PageCursors, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	highlight := highlighter(search.Query)
+	var rows []sqlc.SearchProductsRow
+	for _, hit := range s.state.filterSearch(search) {
+		p := hit.product
+		snippet := deref(p.Description)
+		if p.Description == nil {
+			snippet = deref(p.ShortTagline)
+		}
+		rows = append(rows, sqlc.SearchProductsRow{
+			ID:            p.ID,
+			CompanyID:     p.CompanyID,
+			Name:          p.Name,
+			Slug:          p.Slug,
+			Category:      p.Category,
+			ShortTagline:  p.ShortTagline,
+			Description:   p.Description,
+			HomepageUrl:   p.HomepageUrl,
+			DocsUrl:       p.DocsUrl,
+			AvgRating:     p.AvgRating,
+			TotalReviews:  p.TotalReviews,
+			CreatedAt:     p.CreatedAt,
+			UpdatedAt:     p.UpdatedAt,
+			DeletedAt:     p.DeletedAt,
+			CompanyName:   hit.company.Name,
+			CompanySlug:   hit.company.Slug,
+			Rank:          hit.rank,
+			NameHighlight: highlight(p.Name),
+			Snippet:       highlight(snippet),
+		})
+	}
+
+	order := keysetOrder[sqlc.SearchProductsRow]{name: "relevance", desc: []bool{true, false},
+		keys: func(r sqlc.SearchProductsRow) []any { return []any{r.Rank, r.Name} },
+		id:   func(r sqlc.SearchProductsRow) uuid.UUID { return r.ID },
+	}
+	return listPage(order, rows, page)
+}
+
+func (s *Store) CountSearchMatches(ctx context.Context, search repository.ProductSearch) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return int64(len(s.state.filterSearch(search))), nil
+}
+
+func (q *queries) SearchProductCategoryFacets(ctx context.Context, query string) ([]sqlc.SearchProductCategoryFacetsRow, error) {
+	st, done := q.begin()
+	defer done()
+
+	counts := map[string]int64{}
+	for _, hit := range st.search(query) {
+		counts[hit.product.Category]++
+	}
+	var rows []sqlc.SearchProductCategoryFacetsRow
+	for category, count := range counts {
+		rows = append(rows, sqlc.SearchProductCategoryFacetsRow{Category: category, Count: count})
+	}
+	slices.SortFunc(rows, func(a, b sqlc.SearchProductCategoryFacetsRow) int {
+		return cmp.Or(cmp.Compare(b.Count, a.Count), strings.Compare(a.Category, b.Category))
+	})
+	return rows, nil
+}
+
+// SearchProductRatingFacets buckets the matches by whole stars; unrated products fall in bucket 0
+func (q *queries) SearchProductRatingFacets(ctx context.Context, query string) ([]sqlc.SearchProductRatingFacetsRow, error) {
+	st, done := q.begin()
+	defer done()
+
+	counts := map[int32]int64{}
+	for _, hit := range st.search(query) {
+		var bucket int32
+		if hit.product.AvgRating != nil {
+			bucket = int32(math.Floor(*hit.product.AvgRating))
+		}
+		counts[bucket]++
+	}
+	var rows []sqlc.SearchProductRatingFacetsRow
+	for bucket, count := range counts {
+		rows = append(rows, sqlc.SearchProductRatingFacetsRow{RatingBucket: bucket, Count: count})
+	}
+	slices.SortFunc(rows, func(a, b sqlc.SearchProductRatingFacetsRow) int {
+		return cmp.Compare(b.RatingBucket, a.RatingBucket)
+	})
+	return rows, nil
+}
+
+// filterSearch returns the search matches passing the optional filters
+func (st *state) filterSearch(search repository.ProductSearch) []searchHit {
+	var categories map[string]bool
+	if search.Category != "" {
+		categories = st.categorySubtree(search.Category)
+	}
+
+	var hits []searchHit
+	for _, hit := range st.search(search.Query) {
+		p := hit.product
+		switch {
+		case categories != nil && !categories[p.Category]:
+			continue
+		case search.MinRating != nil && (p.AvgRating == nil || *p.AvgRating < *search.MinRating):
+			continue
+		case !st.hasTags(p.ID, search.Tags, search.MatchAllTags):
+			continue
+		}
+		hits = append(hits, hit)
+	}
+	return hits
+}
+
+// highlighter wraps every occurrence of a query word in the highlight markers
+func highlighter(query string) func(text string) string {
+	words := strings.Fields(query)
+	if len(words) == 0 {
+		return func(text string) string { return text }
+	}
+	quoted := make([]string, 0, len(words))
+	for _, word := range words {
+		quoted = append(quoted, regexp.QuoteMeta(word))
+	}
+	pattern := regexp.MustCompile("(?i)(" + strings.Join(quoted, "|") + ")")
+	return func(text string) string {
+		return pattern.ReplaceAllString(text, highlightStart+"${1}"+highlightStop)
+	}
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,220 @@
+package memory
+
+import (
+	"context"
+	"maps"
+
+	"ratemysoft-backend/internal/models/sqlc"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func (q *queries) CreateSession(ctx context.Context, arg sqlc.CreateSessionParams) (sqlc.Session, error) {
+	st, done := q.begin()
+	defer done()
+
+	if _, ok := st.sessions[arg.ID]; ok {
+		return sqlc.Session{}, uniqueViolation("sessions_pkey")
+	}
+	if _, ok := st.users[arg.UserID]; !ok {
+		return sqlc.Session{}, foreignKeyViolation("sessions_user_id_fkey")
+	}
+
+	s := sqlc.Session{
+		ID:         arg.ID,
+		UserID:     arg.UserID,
+		UserAgent:  arg.UserAgent,
+		IpAddress:  arg.IpAddress,
+		CreatedAt:  arg.CreatedAt,
+		LastUsedAt: arg.LastUsedAt,
+	}
+	st.sessions[s.ID] = s
+	return s, nil
+}
+
+func (q *queries) GetSession(ctx context.Context, id uuid.UUID) (sqlc.Session, error) {
+	st, done := q.begin()
+	defer done()
+
+	s, ok := st.sessions[id]
+	if !ok {
+		return sqlc.Session{}, pgx.ErrNoRows
+	}
+	return s, nil
+}
+
+func (q *queries) TouchSession(ctx context.Context, arg sqlc.TouchSessionParams) error {
+	st, done := q.begin()
+	defer done()
+
+	if s, ok := st.sessions[arg.ID]; ok {
+		s.LastUsedAt = arg.LastUsedAt
+		st.sessions[s.ID] = s
+	}
+	return nil
+}
+
+func (q *queries) RevokeSession(ctx context.Context, arg sqlc.RevokeSessionParams) (int64, error) {
+	st, done := q.begin()
+	defer done()
+
+	return st.revokeSessions(arg.RevokedAt, func(s sqlc.Session) bool {
+		return s.ID == arg.ID && s.UserID == arg.UserID
+	}), nil
+}
+
+func (q *queries) RevokeUserSessions(ctx context.Context, arg sqlc.RevokeUserSessionsParams) (int64, error) {
+	st, done := q.begin()
+	defer done()
+
+	return st.revokeSessions(arg.RevokedAt, func(s sqlc.Session) bool {
+		return s.UserID == arg.UserID
+	}), nil
+}
+
+func (q *queries) RevokeOtherUserSessions(ctx context.Context, arg sqlc.RevokeOtherUserSessionsParams) (int64, error) {
+	st, done := q.begin()
+	defer done()
+
+	return st.revokeSessions(arg.RevokedAt, func(s sqlc.Session) bool {
+		return s.UserID == arg.UserID && s.ID != arg.ID
+	}), nil
+}
+
+func (q *queries) DeleteStaleSessions(ctx context.Context, lastUsedAt pgtype.Timestamptz) (int64, error) {
+	st, done := q.begin()
+	defer done()
+
+	var n int64
+	for id, s := range st.sessions {
+		if compareTime(s.LastUsedAt, lastUsedAt) < 0 {
+			delete(st.sessions, id)
+			n++
+		}
+	}
+	// refresh_tokens.session_id is ON DELETE CASCADE
+	maps.DeleteFunc(st.refreshTokens, func(_ uuid.UUID, t sqlc.RefreshToken) bool {
+		_, ok := st.sessions[t.SessionID]
+		return !ok
+	})
+	return n, nil
+}
+
+func (q *queries) CreateRefreshToken(ctx context.Context, arg sqlc.CreateRefreshTokenParams) (sqlc.RefreshToken, error) {
+	st, done := q.begin()
+	defer done()
+
+	if _, ok := st.refreshTokens[arg.ID]; ok {
+		return sqlc.RefreshToken{}, uniqueViolation("refresh_tokens_pkey")
+	}
+	for _, t := range st.refreshTokens {
+		if t.TokenHash == arg.TokenHash {
+			return sqlc.RefreshToken{}, uniqueViolation("refresh_tokens_token_hash_key")
+		}
+	}
+	if _, ok := st.sessions[arg.SessionID]; !ok {
+		return sqlc.RefreshToken{}, foreignKeyViolation("refresh_tokens_session_id_fkey")
+	}
+
+	t := sqlc.RefreshToken{
+		ID:        arg.ID,
+		SessionID: arg.SessionID,
+		TokenHash: arg.TokenHash,
+		ExpiresAt: arg.ExpiresAt,
+		CreatedAt: arg.CreatedAt,
+	}
+	st.refreshTokens[t.ID] = t
+	return t, nil
+}
+
+func (q *queries) GetRefreshTokenByHashForUpdate(ctx context.Context, tokenHash string) (sqlc.RefreshToken, error) {
+	st, done := q.begin()
+	defer done()
+
+	for _, t := range st.refreshTokens {
+		if t.TokenHash == tokenHash {
+			return t, nil
+		}
+	}
+	return sqlc.RefreshToken{}, pgx.ErrNoRows
+}
+
+func (q *queries) MarkRefreshTokenUsed(ctx context.Context, arg sqlc.MarkRefreshTokenUsedParams) error {
+	st, done := q.begin()
+	defer done()
+
+	if t, ok := st.refreshTokens[arg.ID]; ok {
+		t.UsedAt = arg.UsedAt
+		st.refreshTokens[t.ID] = t
+	}
+	return nil
+}
+
+func (q *queries) DeleteExpiredRefreshTokens(ctx context.Context, expiresAt pgtype.Timestamptz) (int64, error) {
+	st, done := q.begin()
+	defer done()
+
+	var n int64
+	for id, t := range st.refreshTokens {
+		if compareTime(t.ExpiresAt, expiresAt) < 0 {
+			delete(st.refreshTokens, id)
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (q *queries) RevokeAccessToken(ctx context.Context, arg sqlc.RevokeAccessTokenParams) error {
+	st, done := q.begin()
+	defer done()
+
+	if _, ok := st.revokedTokens[arg.Jti]; ok {
+		return nil
+	}
+	if _, ok := st.users[arg.UserID]; !ok {
+		return foreignKeyViolation("revoked_access_tokens_user_id_fkey")
+	}
+	st.revokedTokens[arg.Jti] = sqlc.RevokedAccessToken(arg)
+	return nil
+}
+
+func (q *queries) IsAccessTokenRevoked(ctx context.Context, arg sqlc.IsAccessTokenRevokedParams) (bool, error) {
+	st, done := q.begin()
+	defer done()
+
+	if _, ok := st.revokedTokens[arg.Jti]; ok {
+		return true, nil
+	}
+	s, ok := st.sessions[arg.SessionID]
+	return ok && s.RevokedAt.Valid, nil
+}
+
+func (q *queries) DeleteExpiredRevokedAccessTokens(ctx context.Context, expiresAt pgtype.Timestamptz) (int64, error) {
+	st, done := q.begin()
+	defer done()
+
+	var n int64
+	for jti, t := range st.revokedTokens {
+		if compareTime(t.ExpiresAt, expiresAt) < 0 {
+			delete(st.revokedTokens, jti)
+			n++
+		}
+	}
+	return n, nil
+}
+
+// revokeSessions stamps every live session matching fn as revoked and returns how many it changed
+func (st *state) revokeSessions(revokedAt pgtype.Timestamptz, fn func(sqlc.Session) bool) int64 {
+	var n int64
+	for id, s := range st.sessions {
+		if s.RevokedAt.Valid || !fn(s) {
+			continue
+		}
+		s.RevokedAt = revokedAt
+		st.sessions[id] = s
+		n++
+	}
+	return n
+}
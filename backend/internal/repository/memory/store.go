@@ -0,0 +1,252 @@
+// Package memory implements the repository interfaces over in-process maps. It mirrors
+// the filters, ordering and unique constraints of the sqlc queries closely enough for
+// tests and local runs without Postgres.
+package memory
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"ratemysoft-backend/internal/models/sqlc"
+	"ratemysoft-backend/internal/repository"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var _ repository.Store = (*Store)(nil)
+
+// Store keeps every table in memory behind a single lock. A unit of work holds the lock
+// for its whole duration and runs against a copy of the tables that replaces the
+// originals only when it succeeds, so transactions are serializable and never retried.
+type Store struct {
+	*queries
+
+	mu    sync.Mutex
+	state *state
+}
+
+// NewStore returns an empty store seeded with the default categories, like a freshly
+// migrated database
+func NewStore() *Store {
+	s := &Store{state: newState()}
+	s.queries = &queries{store: s}
+	s.seedCategories()
+	return s
+}
+
+// InTx executes fn against a private copy of the tables, committing it only if fn succeeds
+func (s *Store) InTx(ctx context.Context, fn func(q repository.Queries) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tx := s.state.clone()
+	if err := fn(&queries{store: s, tx: tx}); err != nil {
+		return err
+	}
+	s.state = tx
+	return nil
+}
+
+// AuditEvents returns a copy of the audit log in insertion order
+func (s *Store) AuditEvents() []sqlc.AuditEvent {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.state.auditEvents)
+}
+
+// queries implements repository.Queries either directly against the store, taking the
+// lock per call, or against the copy owned by a running unit of work
+type queries struct {
+	store *Store
+	tx    *state
+}
+
+func (q *queries) begin() (*state, func()) {
+	if q.tx != nil {
+		return q.tx, func() {}
+	}
+	q.store.mu.Lock()
+	return q.store.state, q.store.mu.Unlock
+}
+
+type pairKey struct {
+	a, b uuid.UUID
+}
+
+type dimensionKey struct {
+	id        uuid.UUID
+	dimension string
+}
+
+type credentialKey struct {
+	userID   uuid.UUID
+	provider string
+}
+
+type throttleKey struct {
+	scope, key string
+}
+
+type state struct {
+	users       map[uuid.UUID]sqlc.User
+	credentials map[credentialKey]sqlc.Credential
+	tokens      map[uuid.UUID]sqlc.AccountToken
+	throttles   map[throttleKey]sqlc.LoginThrottle
+
+	sessions      map[uuid.UUID]sqlc.Session
+	refreshTokens map[uuid.UUID]sqlc.RefreshToken
+	revokedTokens map[uuid.UUID]sqlc.RevokedAccessToken
+
+	companies map[uuid.UUID]sqlc.Company
+	members   map[pairKey]sqlc.CompanyMember
+	claims    map[uuid.UUID]sqlc.CompanyClaim
+
+	categories     map[uuid.UUID]sqlc.Category
+	products       map[uuid.UUID]sqlc.Product
+	ratingStats    map[uuid.UUID]sqlc.ProductRatingStat
+	dimensionStats map[dimensionKey]sqlc.ProductDimensionStat
+	pricingPlans   map[uuid.UUID]sqlc.PricingPlan
+	tags           map[uuid.UUID]sqlc.Tag
+	productTags    map[pairKey]sqlc.ProductTag
+	tagSuggestions map[uuid.UUID]sqlc.TagSuggestion
+
+	reviews      map[uuid.UUID]sqlc.Review
+	subRatings   map[dimensionKey]sqlc.ReviewSubRating
+	votes        map[pairKey]sqlc.ReviewVote
+	flags        map[pairKey]sqlc.ReviewFlag
+	revisions    []sqlc.ReviewRevision
+	ratingEvents []sqlc.ReviewRatingEvent
+	moderations  []sqlc.ReviewModeration
+	comments     map[uuid.UUID]sqlc.ReviewComment
+	commentFlags map[pairKey]sqlc.ReviewCommentFlag
+
+	leaderboard []sqlc.ProductLeaderboard
+	reputation  map[uuid.UUID]sqlc.UserReputation
+	badges      []sqlc.ReviewerBadge
+
+	auditEvents []sqlc.AuditEvent
+}
+
+func newState() *state {
+	return &state{
+		users:          map[uuid.UUID]sqlc.User{},
+		credentials:    map[credentialKey]sqlc.Credential{},
+		tokens:         map[uuid.UUID]sqlc.AccountToken{},
+		throttles:      map[throttleKey]sqlc.LoginThrottle{},
+		sessions:       map[uuid.UUID]sqlc.Session{},
+		refreshTokens:  map[uuid.UUID]sqlc.RefreshToken{},
+		revokedTokens:  map[uuid.UUID]sqlc.RevokedAccessToken{},
+		companies:      map[uuid.UUID]sqlc.Company{},
+		members:        map[pairKey]sqlc.CompanyMember{},
+		claims:         map[uuid.UUID]sqlc.CompanyClaim{},
+		categories:     map[uuid.UUID]sqlc.Category{},
+		products:       map[uuid.UUID]sqlc.Product{},
+		ratingStats:    map[uuid.UUID]sqlc.ProductRatingStat{},
+		dimensionStats: map[dimensionKey]sqlc.ProductDimensionStat{},
+		pricingPlans:   map[uuid.UUID]sqlc.PricingPlan{},
+		tags:           map[uuid.UUID]sqlc.Tag{},
+		productTags:    map[pairKey]sqlc.ProductTag{},
+		tagSuggestions: map[uuid.UUID]sqlc.TagSuggestion{},
+		reviews:        map[uuid.UUID]sqlc.Review{},
+		subRatings:     map[dimensionKey]sqlc.ReviewSubRating{},
+		votes:          map[pairKey]sqlc.ReviewVote{},
+		flags:          map[pairKey]sqlc.ReviewFlag{},
+		comments:       map[uuid.UUID]sqlc.ReviewComment{},
+		commentFlags:   map[pairKey]sqlc.ReviewCommentFlag{},
+		reputation:     map[uuid.UUID]sqlc.UserReputation{},
+	}
+}
+
+// clone copies every table; rows are stored by value and replaced rather than mutated,
+// so a shallow copy is enough to isolate a unit of work
+func (st *state) clone() *state {
+	return &state{
+		users:          maps.Clone(st.users),
+		credentials:    maps.Clone(st.credentials),
+		tokens:         maps.Clone(st.tokens),
+		throttles:      maps.Clone(st.throttles),
+		sessions:       maps.Clone(st.sessions),
+		refreshTokens:  maps.Clone(st.refreshTokens),
+		revokedTokens:  maps.Clone(st.revokedTokens),
+		companies:      maps.Clone(st.companies),
+		members:        maps.Clone(st.members),
+		claims:         maps.Clone(st.claims),
+		categories:     maps.Clone(st.categories),
+		products:       maps.Clone(st.products),
+		ratingStats:    maps.Clone(st.ratingStats),
+		dimensionStats: maps.Clone(st.dimensionStats),
+		pricingPlans:   maps.Clone(st.pricingPlans),
+		tags:           maps.Clone(st.tags),
+		productTags:    maps.Clone(st.productTags),
+		tagSuggestions: maps.Clone(st.tagSuggestions),
+		reviews:        maps.Clone(st.reviews),
+		subRatings:     maps.Clone(st.subRatings),
+		votes:          maps.Clone(st.votes),
+		flags:          maps.Clone(st.flags),
+		revisions:      slices.Clone(st.revisions),
+		ratingEvents:   slices.Clone(st.ratingEvents),
+		moderations:    slices.Clone(st.moderations),
+		comments:       maps.Clone(st.comments),
+		commentFlags:   maps.Clone(st.commentFlags),
+		leaderboard:    slices.Clone(st.leaderboard),
+		reputation:     maps.Clone(st.reputation),
+		badges:         slices.Clone(st.badges),
+		auditEvents:    slices.Clone(st.auditEvents),
+	}
+}
+
+func now() pgtype.Timestamptz {
+	return pgtype.Timestamptz{Time: time.Now().UTC(), Valid: true}
+}
+
+func uniqueViolation(constraint string) error {
+	return &pgconn.PgError{
+		Severity:       "ERROR",
+		Code:           "23505",
+		Message:        fmt.Sprintf("duplicate key value violates unique constraint %q", constraint),
+		ConstraintName: constraint,
+	}
+}
+
+func foreignKeyViolation(constraint string) error {
+	return &pgconn.PgError{
+		Severity:       "ERROR",
+		Code:           "23503",
+		Message:        fmt.Sprintf("insert or update violates foreign key constraint %q", constraint),
+		ConstraintName: constraint,
+	}
+}
+
+// page applies LIMIT and OFFSET to rows that are already filtered and sorted
+func page[T any](rows []T, limit, offset int32) []T {
+	if offset < 0 || int(offset) >= len(rows) {
+		return nil
+	}
+	rows = rows[offset:]
+	if limit >= 0 && int(limit) < len(rows) {
+		rows = rows[:limit]
+	}
+	return rows
+}
+
+func deleted(ts pgtype.Timestamptz) bool {
+	return ts.Valid
+}
+
+func containsID(ids []uuid.UUID, id uuid.UUID) bool {
+	return slices.Contains(ids, id)
+}
+
+func compareTime(a, b pgtype.Timestamptz) int {
+	return a.Time.Compare(b.Time)
+}
@@ -0,0 +1,465 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"ratemysoft-backend/internal/domain"
+	"ratemysoft-backend/internal/models/sqlc"
+	"ratemysoft-backend/internal/repository"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+func createUser(t *testing.T, q repository.Queries, handle string) sqlc.User {
+	t.Helper()
+	ts := now()
+	u, err := q.CreateUser(context.Background(), sqlc.CreateUserParams{
+		ID:        uuid.New(),
+		Email:     handle + "@example.com",
+		Handle:    handle,
+		Role:      "user",
+		CreatedAt: ts,
+		UpdatedAt: ts,
+	})
+	if err != nil {
+		t.Fatalf("CreateUser(%q): %v", handle, err)
+	}
+	return u
+}
+
+func createProduct(t *testing.T, q repository.Queries, slug string) sqlc.Product {
+	t.Helper()
+	ctx := context.Background()
+	ts := now()
+	company, err := q.CreateCompany(ctx, sqlc.CreateCompanyParams{
+		ID:        uuid.New(),
+		Name:      "Acme " + slug,
+		Slug:      "acme-" + slug,
+		CreatedAt: ts,
+		UpdatedAt: ts,
+	})
+	if err != nil {
+		t.Fatalf("CreateCompany: %v", err)
+	}
+	p, err := q.CreateProduct(ctx, sqlc.CreateProductParams{
+		ID:        uuid.New(),
+		CompanyID: company.ID,
+		Name:      slug,
+		Slug:      slug,
+		Category:  "hosting",
+		CreatedAt: ts,
+		UpdatedAt: ts,
+	})
+	if err != nil {
+		t.Fatalf("CreateProduct: %v", err)
+	}
+	return p
+}
+
+func createReview(t *testing.T, q repository.Queries, productID, userID uuid.UUID, rating int32, status string) sqlc.Review {
+	t.Helper()
+	ts := now()
+	r, err := q.CreateReview(context.Background(), sqlc.CreateReviewParams{
+		ID:        uuid.New(),
+		ProductID: productID,
+		UserID:    userID,
+		Body:      "works as advertised",
+		Rating:    rating,
+		Status:    status,
+		CreatedAt: ts,
+		UpdatedAt: ts,
+	})
+	if err != nil {
+		t.Fatalf("CreateReview: %v", err)
+	}
+	return r
+}
+
+func pgCode(err error) string {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return pgErr.Code
+	}
+	return ""
+}
+
+func TestInTxRollsBackOnError(t *testing.T) {
+	s := NewStore()
+	ctx := context.Background()
+	boom := errors.New("boom")
+
+	var userID uuid.UUID
+	err := s.InTx(ctx, func(q repository.Queries) error {
+		userID = createUser(t, q, "alice").ID
+		return boom
+	})
+	if !errors.Is(err, boom) {
+		t.Fatalf("InTx error = %v, want %v", err, boom)
+	}
+	if _, err := s.GetUser(ctx, userID); !errors.Is(err, pgx.ErrNoRows) {
+		t.Fatalf("GetUser after rollback error = %v, want pgx.ErrNoRows", err)
+	}
+
+	err = s.InTx(ctx, func(q repository.Queries) error {
+		userID = createUser(t, q, "alice").ID
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("InTx: %v", err)
+	}
+	if _, err := s.GetUser(ctx, userID); err != nil {
+		t.Fatalf("GetUser after commit: %v", err)
+	}
+}
+
+func TestUniqueConstraints(t *testing.T) {
+	s := NewStore()
+	ctx := context.Background()
+	alice := createUser(t, s, "alice")
+	ts := now()
+
+	_, err := s.CreateUser(ctx, sqlc.CreateUserParams{ID: uuid.New(), Email: alice.Email, Handle: "other", CreatedAt: ts, UpdatedAt: ts})
+	if pgCode(err) != "23505" {
+		t.Errorf("duplicate email error = %v, want unique violation", err)
+	}
+	_, err = s.CreateUser(ctx, sqlc.CreateUserParams{ID: uuid.New(), Email: "other@example.com", Handle: alice.Handle, CreatedAt: ts, UpdatedAt: ts})
+	if pgCode(err) != "23505" {
+		t.Errorf("duplicate handle error = %v, want unique violation", err)
+	}
+
+	product := createProduct(t, s, "widget")
+	createReview(t, s, product.ID, alice.ID, 4, "published")
+	_, err = s.CreateReview(ctx, sqlc.CreateReviewParams{ID: uuid.New(), ProductID: product.ID, UserID: alice.ID, Rating: 5, CreatedAt: ts, UpdatedAt: ts})
+	if pgCode(err) != "23505" {
+		t.Errorf("second review error = %v, want unique violation", err)
+	}
+
+	_, err = s.CreateProduct(ctx, sqlc.CreateProductParams{ID: uuid.New(), CompanyID: product.CompanyID, Name: "x", Slug: "x", Category: "no-such-category", CreatedAt: ts, UpdatedAt: ts})
+	if pgCode(err) != "23503" {
+		t.Errorf("unknown category error = %v, want foreign key violation", err)
+	}
+}
+
+func TestSoftDeletedRowsAreHidden(t *testing.T) {
+	s := NewStore()
+	ctx := context.Background()
+	product := createProduct(t, s, "widget")
+
+	if err := s.SoftDeleteProduct(ctx, product.ID); err != nil {
+		t.Fatalf("SoftDeleteProduct: %v", err)
+	}
+	if _, err := s.GetProduct(ctx, product.ID); !errors.Is(err, pgx.ErrNoRows) {
+		t.Errorf("GetProduct error = %v, want pgx.ErrNoRows", err)
+	}
+	if _, err := s.GetProductBySlug(ctx, product.Slug); !errors.Is(err, pgx.ErrNoRows) {
+		t.Errorf("GetProductBySlug error = %v, want pgx.ErrNoRows", err)
+	}
+	if n, _ := s.CountProductsByCompany(ctx, product.CompanyID); n != 0 {
+		t.Errorf("CountProductsByCompany = %d, want 0", n)
+	}
+	// Soft-deleted products still hold on to their category
+	if n, _ := s.CountProductsInCategory(ctx, "hosting"); n != 1 {
+		t.Errorf("CountProductsInCategory = %d, want 1", n)
+	}
+}
+
+func TestRatingAggregates(t *testing.T) {
+	s := NewStore()
+	ctx := context.Background()
+	product := createProduct(t, s, "widget")
+
+	avg, err := s.GetAverageRatingByProduct(ctx, product.ID)
+	if err != nil || avg.Valid {
+		t.Fatalf("GetAverageRatingByProduct without reviews = %+v, %v; want NULL", avg, err)
+	}
+
+	for i, rating := range []int32{5, 4, 4} {
+		u := createUser(t, s, string(rune('a'+i))+"-reviewer")
+		r := createReview(t, s, product.ID, u.ID, rating, "published")
+		err := s.CreateReviewSubRating(ctx, sqlc.CreateReviewSubRatingParams{ReviewID: r.ID, Dimension: "docs", Rating: rating, CreatedAt: now()})
+		if err != nil {
+			t.Fatalf("CreateReviewSubRating: %v", err)
+		}
+	}
+	hidden := createUser(t, s, "pending-reviewer")
+	createReview(t, s, product.ID, hidden.ID, 1, "pending")
+
+	avg, err = s.GetAverageRatingByProduct(ctx, product.ID)
+	if err != nil {
+		t.Fatalf("GetAverageRatingByProduct: %v", err)
+	}
+	f, _ := avg.Float64Value()
+	if f.Float64 != 4.33 {
+		t.Errorf("average = %v, want 4.33", f.Float64)
+	}
+	if n, _ := s.CountReviewsByProduct(ctx, product.ID); n != 3 {
+		t.Errorf("CountReviewsByProduct = %d, want 3", n)
+	}
+
+	if err := s.RefreshProductRatingStats(ctx, product.ID); err != nil {
+		t.Fatalf("RefreshProductRatingStats: %v", err)
+	}
+	stats, err := s.GetProductRatingStats(ctx, product.ID)
+	if err != nil {
+		t.Fatalf("GetProductRatingStats: %v", err)
+	}
+	if stats.Star1 != 0 || stats.Star4 != 2 || stats.Star5 != 1 {
+		t.Errorf("histogram = %+v, want two 4-star and one 5-star", stats)
+	}
+
+	if err := s.RefreshProductDimensionStats(ctx, product.ID); err != nil {
+		t.Fatalf("RefreshProductDimensionStats: %v", err)
+	}
+	dims, _ := s.ListProductDimensionStats(ctx, product.ID)
+	if len(dims) != 1 || dims[0].RatingCount != 3 {
+		t.Fatalf("dimension stats = %+v, want one dimension rated 3 times", dims)
+	}
+}
+
+func TestListOrderingAndPaging(t *testing.T) {
+	s := NewStore()
+	ctx := context.Background()
+	ts := now()
+	for _, name := range []string{"Charlie", "alpha", "Bravo"} {
+		_, err := s.CreateCompany(ctx, sqlc.CreateCompanyParams{ID: uuid.New(), Name: name, Slug: "co-" + name, CreatedAt: ts, UpdatedAt: ts})
+		if err != nil {
+			t.Fatalf("CreateCompany: %v", err)
+		}
+	}
+
+	// ILIKE matches case-insensitively; ORDER BY name compares bytes
+	rows, cursors, err := s.SearchCompaniesPage(ctx, "A", domain.PageRequest{Limit: 2})
+	if err != nil {
+		t.Fatalf("SearchCompaniesPage: %v", err)
+	}
+	if len(rows) != 2 || rows[0].Name != "Bravo" || rows[1].Name != "Charlie" {
+		t.Errorf("first page = %v, want Bravo, Charlie", companyNames(rows))
+	}
+	rows, _, _ = s.SearchCompaniesPage(ctx, "A", domain.PageRequest{Limit: 2, Offset: 2})
+	if len(rows) != 1 || rows[0].Name != "alpha" {
+		t.Errorf("second page = %v, want alpha", companyNames(rows))
+	}
+	rows, _, _ = s.SearchCompaniesPage(ctx, "A", domain.PageRequest{Limit: 2, Cursor: cursors.Next})
+	if len(rows) != 1 || rows[0].Name != "alpha" {
+		t.Errorf("second page by cursor = %v, want alpha", companyNames(rows))
+	}
+	if total, _ := s.CountCompaniesMatching(ctx, "A"); total != 3 {
+		t.Errorf("total = %d, want 3", total)
+	}
+}
+
+func TestKeysetPaging(t *testing.T) {
+	s := NewStore()
+	ctx := context.Background()
+	for _, slug := range []string{"echo", "bravo", "delta", "alpha", "charlie"} {
+		createProduct(t, s, slug)
+	}
+	list := func(page domain.PageRequest) ([]string, domain.PageCursors) {
+		t.Helper()
+		rows, cursors, err := s.ListProductsPage(ctx, repository.ProductFilter{}, repository.ProductsByName, page)
+		if err != nil {
+			t.Fatalf("ListProductsPage: %v", err)
+		}
+		names := make([]string, len(rows))
+		for i, p := range rows {
+			names[i] = p.Name
+		}
+		return names, cursors
+	}
+
+	names, cursors := list(domain.PageRequest{Limit: 2})
+	if !slices.Equal(names, []string{"alpha", "bravo"}) || cursors.Next == nil || cursors.Prev != nil {
+		t.Fatalf("first page = %v, %+v; want alpha, bravo with only a next cursor", names, cursors)
+	}
+	names, cursors = list(domain.PageRequest{Limit: 2, Cursor: cursors.Next})
+	if !slices.Equal(names, []string{"charlie", "delta"}) || cursors.Next == nil || cursors.Prev == nil {
+		t.Fatalf("second page = %v, %+v; want charlie, delta with both cursors", names, cursors)
+	}
+	next := cursors.Next
+	names, cursors = list(domain.PageRequest{Limit: 2, Cursor: cursors.Prev})
+	if !slices.Equal(names, []string{"alpha", "bravo"}) || cursors.Prev != nil {
+		t.Fatalf("page before the second = %v, %+v; want alpha, bravo without a previous cursor", names, cursors)
+	}
+	names, cursors = list(domain.PageRequest{Limit: 2, Cursor: next})
+	if !slices.Equal(names, []string{"echo"}) || cursors.Next != nil {
+		t.Fatalf("last page = %v, %+v; want echo without a next cursor", names, cursors)
+	}
+	names, _ = list(domain.PageRequest{Limit: 2, Offset: 3})
+	if !slices.Equal(names, []string{"delta", "echo"}) {
+		t.Errorf("page at offset 3 = %v, want delta, echo", names)
+	}
+
+	_, _, err := s.ListProductsPage(ctx, repository.ProductFilter{}, repository.ProductsByNewest, domain.PageRequest{Limit: 2, Cursor: next})
+	if !errors.Is(err, repository.ErrCursorMismatch) {
+		t.Errorf("cursor of another order error = %v, want ErrCursorMismatch", err)
+	}
+}
+
+func TestListProductsPageFilters(t *testing.T) {
+	s := NewStore()
+	ctx := context.Background()
+	hosting, _ := s.GetCategoryBySlug(ctx, "hosting")
+	ts := now()
+	_, err := s.CreateCategory(ctx, sqlc.CreateCategoryParams{ID: uuid.New(), Slug: "paas", Name: "PaaS", ParentID: &hosting.ID, CreatedAt: ts, UpdatedAt: ts})
+	if err != nil {
+		t.Fatalf("CreateCategory: %v", err)
+	}
+
+	parent := createProduct(t, s, "vps")
+	child := createProduct(t, s, "heroku")
+	child.Category = "paas"
+	rating := 4.5
+	child.AvgRating = &rating
+	other := createProduct(t, s, "other")
+	other.Category = "other"
+	for _, p := range []sqlc.Product{child, other} {
+		if _, err := s.UpdateProduct(ctx, productUpdate(p)); err != nil {
+			t.Fatalf("UpdateProduct: %v", err)
+		}
+	}
+
+	minRating := 4.0
+	for _, tc := range []struct {
+		name   string
+		filter repository.ProductFilter
+		want   int64
+	}{
+		{"category with subcategories", repository.ProductFilter{Category: "hosting"}, 2},
+		{"subcategory", repository.ProductFilter{Category: "paas"}, 1},
+		{"minimum rating", repository.ProductFilter{MinRating: &minRating}, 1},
+		{"company", repository.ProductFilter{CompanyID: &parent.CompanyID}, 1},
+	} {
+		if n, _ := s.CountProductsMatching(ctx, tc.filter); n != tc.want {
+			t.Errorf("%s: CountProductsMatching = %d, want %d", tc.name, n, tc.want)
+		}
+	}
+}
+
+func TestSearchProducts(t *testing.T) {
+	s := NewStore()
+	ctx := context.Background()
+	createProduct(t, s, "fastdeploy")
+	createProduct(t, s, "slowbuild")
+
+	rows, _, err := s.SearchProductsPage(ctx, repository.ProductSearch{Query: "Deploy"}, domain.PageRequest{Limit: 10})
+	if err != nil {
+		t.Fatalf("SearchProductsPage: %v", err)
+	}
+	if len(rows) != 1 || rows[0].Name != "fastdeploy" {
+		t.Fatalf("hits = %+v, want fastdeploy", rows)
+	}
+	if want := "fast" + highlightStart + "deploy" + highlightStop; rows[0].NameHighlight != want {
+		t.Errorf("name highlight = %q, want %q", rows[0].NameHighlight, want)
+	}
+
+	facets, _ := s.SearchProductCategoryFacets(ctx, "deploy")
+	if len(facets) != 1 || facets[0].Category != "hosting" || facets[0].Count != 1 {
+		t.Errorf("category facets = %+v, want hosting: 1", facets)
+	}
+	if n, _ := s.CountSearchMatches(ctx, repository.ProductSearch{Query: "nothing matches"}); n != 0 {
+		t.Errorf("CountSearchMatches = %d, want 0", n)
+	}
+}
+
+func TestLeaderboardAndReputationRefresh(t *testing.T) {
+	s := NewStore()
+	ctx := context.Background()
+	product := createProduct(t, s, "widget")
+	alice := createUser(t, s, "alice")
+	bob := createUser(t, s, "bob")
+	createReview(t, s, product.ID, alice.ID, 5, "published")
+	r := createReview(t, s, product.ID, bob.ID, 3, "published")
+	if err := s.AdjustReviewVoteCounts(ctx, sqlc.AdjustReviewVoteCountsParams{ID: r.ID, UpvoteDelta: 2}); err != nil {
+		t.Fatalf("AdjustReviewVoteCounts: %v", err)
+	}
+
+	err := s.RefreshLeaderboardWindow(ctx, sqlc.RefreshLeaderboardWindowParams{TimeWindow: "all", PriorWeight: 2})
+	if err != nil {
+		t.Fatalf("RefreshLeaderboardWindow: %v", err)
+	}
+	board, _, _ := s.ListLeaderboardPage(ctx, repository.LeaderboardFilter{Window: domain.WindowAllTime}, domain.ScoreBayesian, domain.PageRequest{Limit: 10})
+	if len(board) != 1 || board[0].ReviewCount != 2 || board[0].PositiveCount != 1 || board[0].BayesianScore != 4 || board[0].Rank != 1 {
+		t.Fatalf("leaderboard = %+v, want one product ranked 1 with 2 reviews scoring 4", board)
+	}
+	if err := s.RefreshLeaderboardWindow(ctx, sqlc.RefreshLeaderboardWindowParams{TimeWindow: "all"}); pgCode(err) != "23505" {
+		t.Errorf("refresh without clearing the window error = %v, want unique violation", err)
+	}
+
+	if err := s.RefreshUserReputation(ctx); err != nil {
+		t.Fatalf("RefreshUserReputation: %v", err)
+	}
+	if err := s.RefreshReviewerBadges(ctx, 3); err != nil {
+		t.Fatalf("RefreshReviewerBadges: %v", err)
+	}
+	profile, err := s.GetPublicProfile(ctx, "bob")
+	if err != nil {
+		t.Fatalf("GetPublicProfile: %v", err)
+	}
+	// Two helpful votes and one published review on an account younger than 30 days
+	if profile.Reputation != 7 || profile.HelpfulVotes != 2 || profile.ReviewCount != 1 {
+		t.Errorf("profile = %+v, want reputation 7 from 2 helpful votes and 1 review", profile)
+	}
+	badges, _ := s.ListReviewerBadges(ctx, bob.ID)
+	if len(badges) != 1 || badges[0].Category != "hosting" || badges[0].Rank != 1 {
+		t.Errorf("badges = %+v, want rank 1 in hosting", badges)
+	}
+	if badges, _ := s.ListReviewerBadges(ctx, alice.ID); len(badges) != 0 {
+		t.Errorf("badges without helpful votes = %+v, want none", badges)
+	}
+}
+
+func TestUpdateCategoryCascadesSlug(t *testing.T) {
+	s := NewStore()
+	ctx := context.Background()
+	product := createProduct(t, s, "widget")
+
+	category, err := s.GetCategoryBySlug(ctx, "hosting")
+	if err != nil {
+		t.Fatalf("GetCategoryBySlug: %v", err)
+	}
+	_, err = s.UpdateCategory(ctx, sqlc.UpdateCategoryParams{
+		ID:        category.ID,
+		Slug:      "web-hosting",
+		Name:      category.Name,
+		SortOrder: category.SortOrder,
+		UpdatedAt: now(),
+	})
+	if err != nil {
+		t.Fatalf("UpdateCategory: %v", err)
+	}
+
+	got, _ := s.GetProduct(ctx, product.ID)
+	if got.Category != "web-hosting" {
+		t.Errorf("product category = %q, want web-hosting", got.Category)
+	}
+}
+
+// productUpdate carries a product's fields into the parameters of UpdateProduct
+func productUpdate(p sqlc.Product) sqlc.UpdateProductParams {
+	return sqlc.UpdateProductParams{
+		ID:           p.ID,
+		Name:         p.Name,
+		Slug:         p.Slug,
+		Category:     p.Category,
+		ShortTagline: p.ShortTagline,
+		Description:  p.Description,
+		HomepageUrl:  p.HomepageUrl,
+		DocsUrl:      p.DocsUrl,
+		AvgRating:    p.AvgRating,
+		TotalReviews: p.TotalReviews,
+		UpdatedAt:    now(),
+	}
+}
+
+func companyNames(rows []sqlc.Company) []string {
+	names := make([]string, len(rows))
+	for i, c := range rows {
+		names[i] = c.Name
+	}
+	return names
+}
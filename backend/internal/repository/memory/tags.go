@@ -0,0 +1,189 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"strings"
+
+	"ratemysoft-backend/internal/models/sqlc"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+func (q *queries) UpsertTag(ctx context.Context, arg sqlc.UpsertTagParams) (sqlc.Tag, error) {
+	st, done := q.begin()
+	defer done()
+
+	if t, ok := st.tagBySlug(arg.Slug); ok {
+		return t, nil
+	}
+	if _, ok := st.tags[arg.ID]; ok {
+		return sqlc.Tag{}, uniqueViolation("tags_pkey")
+	}
+
+	t := sqlc.Tag(arg)
+	st.tags[t.ID] = t
+	return t, nil
+}
+
+func (q *queries) AddProductTag(ctx context.Context, arg sqlc.AddProductTagParams) (int64, error) {
+	st, done := q.begin()
+	defer done()
+
+	key := pairKey{arg.ProductID, arg.TagID}
+	if _, ok := st.productTags[key]; ok {
+		return 0, nil
+	}
+	if _, ok := st.products[arg.ProductID]; !ok {
+		return 0, foreignKeyViolation("product_tags_product_id_fkey")
+	}
+	if _, ok := st.tags[arg.TagID]; !ok {
+		return 0, foreignKeyViolation("product_tags_tag_id_fkey")
+	}
+
+	st.productTags[key] = sqlc.ProductTag(arg)
+	return 1, nil
+}
+
+func (q *queries) RemoveProductTag(ctx context.Context, arg sqlc.RemoveProductTagParams) (int64, error) {
+	st, done := q.begin()
+	defer done()
+
+	t, ok := st.tagBySlug(arg.Slug)
+	if !ok {
+		return 0, nil
+	}
+	key := pairKey{arg.ProductID, t.ID}
+	if _, ok := st.productTags[key]; !ok {
+		return 0, nil
+	}
+	delete(st.productTags, key)
+	return 1, nil
+}
+
+func (q *queries) CountProductTags(ctx context.Context, productID uuid.UUID) (int64, error) {
+	st, done := q.begin()
+	defer done()
+
+	var n int64
+	for key := range st.productTags {
+		if key.a == productID {
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (q *queries) ListProductTags(ctx context.Context, productIds []uuid.UUID) ([]sqlc.ListProductTagsRow, error) {
+	st, done := q.begin()
+	defer done()
+
+	var rows []sqlc.ListProductTagsRow
+	for key := range st.productTags {
+		t, ok := st.tags[key.b]
+		if !ok || !containsID(productIds, key.a) {
+			continue
+		}
+		rows = append(rows, sqlc.ListProductTagsRow{ProductID: key.a, ID: t.ID, Slug: t.Slug, Name: t.Name})
+	}
+	slices.SortFunc(rows, func(a, b sqlc.ListProductTagsRow) int {
+		return cmp.Or(strings.Compare(a.ProductID.String(), b.ProductID.String()), strings.Compare(a.Slug, b.Slug))
+	})
+	return rows, nil
+}
+
+func (q *queries) CreateTagSuggestion(ctx context.Context, arg sqlc.CreateTagSuggestionParams) (sqlc.TagSuggestion, error) {
+	st, done := q.begin()
+	defer done()
+
+	for _, s := range st.tagSuggestions {
+		switch {
+		case s.ID == arg.ID:
+			return sqlc.TagSuggestion{}, uniqueViolation("tag_suggestions_pkey")
+		case s.ProductID == arg.ProductID && s.TagSlug == arg.TagSlug && s.Status == "pending" && arg.Status == "pending":
+			return sqlc.TagSuggestion{}, uniqueViolation("idx_tag_suggestions_pending")
+		}
+	}
+	if _, ok := st.products[arg.ProductID]; !ok {
+		return sqlc.TagSuggestion{}, foreignKeyViolation("tag_suggestions_product_id_fkey")
+	}
+	if _, ok := st.users[arg.UserID]; !ok {
+		return sqlc.TagSuggestion{}, foreignKeyViolation("tag_suggestions_user_id_fkey")
+	}
+
+	s := sqlc.TagSuggestion{
+		ID:        arg.ID,
+		ProductID: arg.ProductID,
+		UserID:    arg.UserID,
+		TagSlug:   arg.TagSlug,
+		TagName:   arg.TagName,
+		Status:    arg.Status,
+		CreatedAt: arg.CreatedAt,
+		UpdatedAt: arg.UpdatedAt,
+	}
+	st.tagSuggestions[s.ID] = s
+	return s, nil
+}
+
+func (q *queries) GetTagSuggestion(ctx context.Context, id uuid.UUID) (sqlc.TagSuggestion, error) {
+	st, done := q.begin()
+	defer done()
+
+	s, ok := st.tagSuggestions[id]
+	if !ok {
+		return sqlc.TagSuggestion{}, pgx.ErrNoRows
+	}
+	return s, nil
+}
+
+func (q *queries) GetPendingTagSuggestion(ctx context.Context, arg sqlc.GetPendingTagSuggestionParams) (sqlc.TagSuggestion, error) {
+	st, done := q.begin()
+	defer done()
+
+	for _, s := range st.tagSuggestions {
+		if s.ProductID == arg.ProductID && s.TagSlug == arg.TagSlug && s.Status == "pending" {
+			return s, nil
+		}
+	}
+	return sqlc.TagSuggestion{}, pgx.ErrNoRows
+}
+
+func (q *queries) CountTagSuggestionsByProduct(ctx context.Context, arg sqlc.CountTagSuggestionsByProductParams) (int64, error) {
+	st, done := q.begin()
+	defer done()
+
+	var n int64
+	for _, s := range st.tagSuggestions {
+		if s.ProductID == arg.ProductID && s.Status == arg.Status {
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (q *queries) ResolveTagSuggestion(ctx context.Context, arg sqlc.ResolveTagSuggestionParams) (sqlc.TagSuggestion, error) {
+	st, done := q.begin()
+	defer done()
+
+	s, ok := st.tagSuggestions[arg.ID]
+	if !ok || s.Status != "pending" {
+		return sqlc.TagSuggestion{}, pgx.ErrNoRows
+	}
+	s.Status = arg.Status
+	s.ReviewedBy = arg.ReviewedBy
+	s.ReviewNote = arg.ReviewNote
+	s.ReviewedAt = arg.ReviewedAt
+	st.tagSuggestions[s.ID] = s
+	return s, nil
+}
+
+func (st *state) tagBySlug(slug string) (sqlc.Tag, bool) {
+	for _, t := range st.tags {
+		if t.Slug == slug {
+			return t, true
+		}
+	}
+	return sqlc.Tag{}, false
+}
@@ -0,0 +1,255 @@
+package memory
+
+import (
+	"context"
+
+	"ratemysoft-backend/internal/models/sqlc"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func (q *queries) CreateUser(ctx context.Context, arg sqlc.CreateUserParams) (sqlc.User, error) {
+	st, done := q.begin()
+	defer done()
+
+	for _, u := range st.users {
+		switch {
+		case u.ID == arg.ID:
+			return sqlc.User{}, uniqueViolation("users_pkey")
+		case u.Email == arg.Email:
+			return sqlc.User{}, uniqueViolation("users_email_key")
+		case u.Handle == arg.Handle:
+			return sqlc.User{}, uniqueViolation("users_handle_key")
+		}
+	}
+
+	u := sqlc.User{
+		ID:        arg.ID,
+		Email:     arg.Email,
+		Handle:    arg.Handle,
+		Role:      arg.Role,
+		CreatedAt: arg.CreatedAt,
+		UpdatedAt: arg.UpdatedAt,
+	}
+	st.users[u.ID] = u
+	return u, nil
+}
+
+func (q *queries) GetUser(ctx context.Context, id uuid.UUID) (sqlc.User, error) {
+	st, done := q.begin()
+	defer done()
+
+	u, ok := st.users[id]
+	if !ok || deleted(u.DeletedAt) {
+		return sqlc.User{}, pgx.ErrNoRows
+	}
+	return u, nil
+}
+
+func (q *queries) GetUserByEmail(ctx context.Context, email string) (sqlc.User, error) {
+	st, done := q.begin()
+	defer done()
+
+	for _, u := range st.users {
+		if u.Email == email && !deleted(u.DeletedAt) {
+			return u, nil
+		}
+	}
+	return sqlc.User{}, pgx.ErrNoRows
+}
+
+func (q *queries) GetUserByHandle(ctx context.Context, handle string) (sqlc.User, error) {
+	st, done := q.begin()
+	defer done()
+
+	for _, u := range st.users {
+		if u.Handle == handle && !deleted(u.DeletedAt) {
+			return u, nil
+		}
+	}
+	return sqlc.User{}, pgx.ErrNoRows
+}
+
+func (q *queries) CountUsers(ctx context.Context) (int64, error) {
+	st, done := q.begin()
+	defer done()
+
+	var n int64
+	for _, u := range st.users {
+		if !deleted(u.DeletedAt) {
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (q *queries) UpdateUser(ctx context.Context, arg sqlc.UpdateUserParams) (sqlc.User, error) {
+	st, done := q.begin()
+	defer done()
+
+	u, ok := st.users[arg.ID]
+	if !ok || deleted(u.DeletedAt) {
+		return sqlc.User{}, pgx.ErrNoRows
+	}
+	if err := checkUserUnique(st, arg.ID, arg.Email, arg.Handle); err != nil {
+		return sqlc.User{}, err
+	}
+
+	if u.Email != arg.Email {
+		u.EmailVerifiedAt = pgtype.Timestamptz{}
+	}
+	u.Email = arg.Email
+	u.Handle = arg.Handle
+	u.Role = arg.Role
+	u.UpdatedAt = arg.UpdatedAt
+	st.users[u.ID] = u
+	return u, nil
+}
+
+func (q *queries) SetUserEmailVerified(ctx context.Context, arg sqlc.SetUserEmailVerifiedParams) (sqlc.User, error) {
+	st, done := q.begin()
+	defer done()
+
+	u, ok := st.users[arg.ID]
+	if !ok || deleted(u.DeletedAt) {
+		return sqlc.User{}, pgx.ErrNoRows
+	}
+	u.EmailVerifiedAt = arg.EmailVerifiedAt
+	u.UpdatedAt = arg.EmailVerifiedAt
+	st.users[u.ID] = u
+	return u, nil
+}
+
+func (q *queries) AnonymizeUser(ctx context.Context, arg sqlc.AnonymizeUserParams) (sqlc.User, error) {
+	st, done := q.begin()
+	defer done()
+
+	u, ok := st.users[arg.ID]
+	if !ok || deleted(u.DeletedAt) {
+		return sqlc.User{}, pgx.ErrNoRows
+	}
+	if err := checkUserUnique(st, arg.ID, arg.Email, arg.Handle); err != nil {
+		return sqlc.User{}, err
+	}
+
+	u.Email = arg.Email
+	u.Handle = arg.Handle
+	u.EmailVerifiedAt = pgtype.Timestamptz{}
+	u.DeletedAt = arg.DeletedAt
+	u.UpdatedAt = arg.DeletedAt
+	st.users[u.ID] = u
+	return u, nil
+}
+
+// checkUserUnique enforces the unique email and handle of users other than id; like the
+// database constraints, it counts soft-deleted users too
+func checkUserUnique(st *state, id uuid.UUID, email, handle string) error {
+	for _, other := range st.users {
+		switch {
+		case other.ID == id:
+		case other.Email == email:
+			return uniqueViolation("users_email_key")
+		case other.Handle == handle:
+			return uniqueViolation("users_handle_key")
+		}
+	}
+	return nil
+}
+
+func (q *queries) CreateCredential(ctx context.Context, arg sqlc.CreateCredentialParams) (sqlc.Credential, error) {
+	st, done := q.begin()
+	defer done()
+
+	key := credentialKey{userID: arg.UserID, provider: arg.Provider}
+	if _, ok := st.credentials[key]; ok {
+		return sqlc.Credential{}, uniqueViolation("credentials_pkey")
+	}
+	for _, c := range st.credentials {
+		if c.Provider == arg.Provider && c.Identifier == arg.Identifier {
+			return sqlc.Credential{}, uniqueViolation("credentials_provider_identifier_key")
+		}
+	}
+	if _, ok := st.users[arg.UserID]; !ok {
+		return sqlc.Credential{}, foreignKeyViolation("credentials_user_id_fkey")
+	}
+
+	c := sqlc.Credential{
+		UserID:     arg.UserID,
+		Provider:   arg.Provider,
+		Identifier: arg.Identifier,
+		SecretHash: arg.SecretHash,
+		CreatedAt:  arg.CreatedAt,
+		UpdatedAt:  arg.UpdatedAt,
+	}
+	st.credentials[key] = c
+	return c, nil
+}
+
+func (q *queries) GetCredential(ctx context.Context, arg sqlc.GetCredentialParams) (sqlc.Credential, error) {
+	st, done := q.begin()
+	defer done()
+
+	c, ok := st.credentials[credentialKey{userID: arg.UserID, provider: arg.Provider}]
+	if !ok || deleted(c.DeletedAt) {
+		return sqlc.Credential{}, pgx.ErrNoRows
+	}
+	return c, nil
+}
+
+func (q *queries) GetCredentialByIdentifier(ctx context.Context, arg sqlc.GetCredentialByIdentifierParams) (sqlc.Credential, error) {
+	st, done := q.begin()
+	defer done()
+
+	for _, c := range st.credentials {
+		if c.Provider == arg.Provider && c.Identifier == arg.Identifier && !deleted(c.DeletedAt) {
+			return c, nil
+		}
+	}
+	return sqlc.Credential{}, pgx.ErrNoRows
+}
+
+func (q *queries) UpdateCredential(ctx context.Context, arg sqlc.UpdateCredentialParams) (sqlc.Credential, error) {
+	st, done := q.begin()
+	defer done()
+
+	key := credentialKey{userID: arg.UserID, provider: arg.Provider}
+	c, ok := st.credentials[key]
+	if !ok || deleted(c.DeletedAt) {
+		return sqlc.Credential{}, pgx.ErrNoRows
+	}
+	for k, other := range st.credentials {
+		if k != key && other.Provider == arg.Provider && other.Identifier == arg.Identifier {
+			return sqlc.Credential{}, uniqueViolation("credentials_provider_identifier_key")
+		}
+	}
+
+	c.Identifier = arg.Identifier
+	c.SecretHash = arg.SecretHash
+	c.UpdatedAt = arg.UpdatedAt
+	st.credentials[key] = c
+	return c, nil
+}
+
+func (q *queries) HardDeleteCredential(ctx context.Context, arg sqlc.HardDeleteCredentialParams) error {
+	st, done := q.begin()
+	defer done()
+
+	delete(st.credentials, credentialKey{userID: arg.UserID, provider: arg.Provider})
+	return nil
+}
+
+func (q *queries) DeleteUserCredentials(ctx context.Context, userID uuid.UUID) (int64, error) {
+	st, done := q.begin()
+	defer done()
+
+	var n int64
+	for key := range st.credentials {
+		if key.userID == userID {
+			delete(st.credentials, key)
+			n++
+		}
+	}
+	return n, nil
+}
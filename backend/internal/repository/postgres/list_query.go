@@ -0,0 +1,213 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"ratemysoft-backend/internal/domain"
+	"ratemysoft-backend/internal/models/sqlc"
+	"ratemysoft-backend/internal/repository"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// keysetKey is one ORDER BY expression. Cursors carry its value as text;
+// cast turns that text back into the expression's SQL type.
+type keysetKey struct {
+	expr string
+	cast string
+	desc bool
+}
+
+// keysetOrder is a total order over a listing: the sort keys, then the row ID as
+// tie-breaker in the direction of the last key. Keys must never be NULL.
+type keysetOrder struct {
+	name string
+	keys []keysetKey
+	id   string
+}
+
+func (o keysetOrder) idDesc() bool {
+	return o.keys[len(o.keys)-1].desc
+}
+
+// uniform reports whether every key and the ID sort in the same direction,
+// which allows a single row comparison instead of the expanded form
+func (o keysetOrder) uniform() bool {
+	for _, key := range o.keys {
+		if key.desc != o.idDesc() {
+			return false
+		}
+	}
+	return true
+}
+
+// listQuery assembles a listing query. Only constant SQL fragments are
+// concatenated; every caller-supplied value is bound as a positional argument.
+type listQuery struct {
+	columns    string // select list, in the field order the scan function expects
+	from       string // FROM and JOIN clauses
+	conditions []string
+	args       []any
+}
+
+// bind appends a value to the arguments and returns its placeholder
+func (q *listQuery) bind(value any) string {
+	q.args = append(q.args, value)
+	return fmt.Sprintf("$%d", len(q.args))
+}
+
+// where adds a condition; each %s verb in cond becomes the placeholder of the matching value
+func (q *listQuery) where(cond string, values ...any) {
+	placeholders := make([]any, 0, len(values))
+	for _, value := range values {
+		placeholders = append(placeholders, q.bind(value))
+	}
+	q.conditions = append(q.conditions, fmt.Sprintf(cond, placeholders...))
+}
+
+// whereCategory limits products p to a category and all of its descendants. UNION stops
+// the recursion should the hierarchy ever contain a cycle.
+func (q *listQuery) whereCategory(slug string) {
+	q.where(`p.category IN (
+  WITH RECURSIVE subtree AS (
+    SELECT id, slug FROM categories WHERE categories.slug = %s
+    UNION
+    SELECT ch.id, ch.slug FROM categories ch JOIN subtree st ON ch.parent_id = st.id
+  )
+  SELECT slug FROM subtree
+)`, slug)
+}
+
+func (q *listQuery) body() string {
+	if len(q.conditions) == 0 {
+		return "FROM " + q.from
+	}
+	return "FROM " + q.from + "\nWHERE " + strings.Join(q.conditions, "\nAND ")
+}
+
+func (q *listQuery) countSQL() (string, []any) {
+	return "SELECT COUNT(*)\n" + q.body(), q.args
+}
+
+// pageSQL selects one row more than the limit, so the caller can tell whether another
+// page follows, plus the row's sort keys as text. A backward cursor reverses the order.
+func (q *listQuery) pageSQL(order keysetOrder, page domain.PageRequest) (string, []any) {
+	// Work on a copy so the count query's conditions and arguments stay untouched
+	pq := &listQuery{
+		columns:    q.columns,
+		from:       q.from,
+		conditions: append([]string(nil), q.conditions...),
+		args:       append([]any(nil), q.args...),
+	}
+
+	backward := page.Cursor != nil && page.Cursor.Backward
+	if page.Cursor != nil {
+		pq.conditions = append(pq.conditions, pq.keysetCondition(order, page.Cursor))
+	}
+
+	sortKeys := make([]string, 0, len(order.keys))
+	orderBy := make([]string, 0, len(order.keys)+1)
+	for _, key := range order.keys {
+		sortKeys = append(sortKeys, "("+key.expr+")::text")
+		orderBy = append(orderBy, key.expr+" "+sqlDirection(key.desc != backward))
+	}
+	orderBy = append(orderBy, order.id+" "+sqlDirection(order.idDesc() != backward))
+
+	sql := "SELECT " + pq.columns + ", ARRAY[" + strings.Join(sortKeys, ", ") + "] AS sort_keys\n" +
+		pq.body() +
+		"\nORDER BY " + strings.Join(orderBy, ", ") +
+		"\nLIMIT " + pq.bind(page.Limit+1)
+	if page.Cursor == nil {
+		sql += " OFFSET " + pq.bind(page.Offset)
+	}
+	return sql, pq.args
+}
+
+// keysetCondition matches the rows past the cursor in scan direction. Uniform orders
+// use a row comparison; mixed directions expand to
+// k1 > v1 OR (k1 = v1 AND (k2 < v2 OR (k2 = v2 AND id < v3))).
+func (q *listQuery) keysetCondition(order keysetOrder, cursor *domain.Cursor) string {
+	after := func(desc bool) string {
+		if desc != cursor.Backward {
+			return "<"
+		}
+		return ">"
+	}
+
+	values := make([]string, 0, len(order.keys))
+	for i, key := range order.keys {
+		values = append(values, q.bind(cursor.Keys[i])+"::"+key.cast)
+	}
+	id := q.bind(cursor.ID)
+
+	if order.uniform() {
+		exprs := make([]string, 0, len(order.keys)+1)
+		for _, key := range order.keys {
+			exprs = append(exprs, key.expr)
+		}
+		exprs = append(exprs, order.id)
+		return fmt.Sprintf("(%s) %s (%s, %s)",
+			strings.Join(exprs, ", "), after(order.idDesc()), strings.Join(values, ", "), id)
+	}
+
+	cond := fmt.Sprintf("%s %s %s", order.id, after(order.idDesc()), id)
+	for i := len(order.keys) - 1; i >= 0; i-- {
+		key := order.keys[i]
+		cond = fmt.Sprintf("(%s %s %s OR (%s = %s AND %s))",
+			key.expr, after(key.desc), values[i], key.expr, values[i], cond)
+	}
+	return cond
+}
+
+func sqlDirection(desc bool) string {
+	if desc {
+		return "DESC"
+	}
+	return "ASC"
+}
+
+// pageRowScanner scans the listing columns of the current row, followed by sortKeys,
+// and returns the row with its ID
+type pageRowScanner[T any] func(rows pgx.Rows, sortKeys *[]string) (T, uuid.UUID, error)
+
+// queryPage runs one page of a listing and returns its rows in list order
+// along with the cursors of the neighbouring pages
+func queryPage[T any](ctx context.Context, db sqlc.DBTX, q *listQuery, order keysetOrder, page domain.PageRequest, scan pageRowScanner[T]) ([]T, domain.PageCursors, error) {
+	if err := repository.CheckCursor(order.name, len(order.keys), page.Cursor); err != nil {
+		return nil, domain.PageCursors{}, err
+	}
+
+	sql, args := q.pageSQL(order, page)
+	rows, err := db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, domain.PageCursors{}, err
+	}
+	defer rows.Close()
+
+	var keyed []repository.KeyedRow[T]
+	for rows.Next() {
+		var keys []string
+		row, id, err := scan(rows, &keys)
+		if err != nil {
+			return nil, domain.PageCursors{}, err
+		}
+		keyed = append(keyed, repository.KeyedRow[T]{Row: row, ID: id, Keys: keys})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, domain.PageCursors{}, err
+	}
+
+	items, cursors := repository.FinishPage(order.name, keyed, page)
+	return items, cursors, nil
+}
+
+// queryCount counts the rows of a listing across all of its pages
+func queryCount(ctx context.Context, db sqlc.DBTX, q *listQuery) (int64, error) {
+	var total int64
+	sql, args := q.countSQL()
+	err := db.QueryRow(ctx, sql, args...).Scan(&total)
+	return total, err
+}
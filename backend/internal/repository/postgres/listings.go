@@ -0,0 +1,740 @@
+package postgres
+
+import (
+	"context"
+
+	"ratemysoft-backend/internal/domain"
+	"ratemysoft-backend/internal/models/sqlc"
+	"ratemysoft-backend/internal/repository"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// productOrders whitelists the product orderings; sort keys never reach the SQL text directly
+var productOrders = map[repository.ProductOrder]keysetOrder{
+	repository.ProductsByNewest: {name: string(repository.ProductsByNewest), id: "p.id", keys: []keysetKey{
+		{expr: "p.created_at", cast: "timestamptz", desc: true},
+	}},
+	repository.ProductsByRating: {name: string(repository.ProductsByRating), id: "p.id", keys: []keysetKey{
+		{expr: "COALESCE(p.avg_rating, 0)", cast: "float8", desc: true},
+	}},
+	repository.ProductsByReviewCount: {name: string(repository.ProductsByReviewCount), id: "p.id", keys: []keysetKey{
+		{expr: "p.total_reviews", cast: "int4", desc: true},
+	}},
+	repository.ProductsByName: {name: string(repository.ProductsByName), id: "p.id", keys: []keysetKey{
+		{expr: "p.name", cast: "text", desc: false},
+	}},
+	repository.ProductsByTrending: {name: string(repository.ProductsByTrending), id: "p.id", keys: []keysetKey{
+		{
+			expr: "(SELECT COUNT(*) FROM reviews r WHERE r.product_id = p.id AND r.status = 'published' " +
+				"AND r.deleted_at IS NULL AND r.created_at >= NOW() - INTERVAL '30 days')",
+			cast: "int8",
+			desc: true,
+		},
+	}},
+}
+
+// reviewOrders are the orderings of a product's reviews
+var reviewOrders = map[repository.ReviewOrder]keysetOrder{
+	repository.ReviewsByRecent: {name: string(repository.ReviewsByRecent), id: "r.id", keys: []keysetKey{
+		{expr: "r.created_at", cast: "timestamptz", desc: true},
+	}},
+	repository.ReviewsByUpvotes: {name: string(repository.ReviewsByUpvotes), id: "r.id", keys: []keysetKey{
+		{expr: "r.upvote_count", cast: "int4", desc: true},
+		{expr: "r.created_at", cast: "timestamptz", desc: true},
+	}},
+	repository.ReviewsByRatingDesc: {name: string(repository.ReviewsByRatingDesc), id: "r.id", keys: []keysetKey{
+		{expr: "r.rating", cast: "int4", desc: true},
+		{expr: "r.created_at", cast: "timestamptz", desc: true},
+	}},
+	repository.ReviewsByRatingAsc: {name: string(repository.ReviewsByRatingAsc), id: "r.id", keys: []keysetKey{
+		{expr: "r.rating", cast: "int4", desc: false},
+		{expr: "r.created_at", cast: "timestamptz", desc: true},
+	}},
+	repository.ReviewsByHelpful: {name: string(repository.ReviewsByHelpful), id: "r.id", keys: []keysetKey{
+		{expr: "(r.upvote_count + 1) * (1 + ln(1 + COALESCE(ur.reputation, 0)::float8))", cast: "float8", desc: true},
+		{expr: "r.created_at", cast: "timestamptz", desc: true},
+	}},
+}
+
+// searchRank scores a search match: full-text rank plus trigram similarity on the product
+// name, and half as much on the company name, so small typos still rank
+const searchRank = "(ts_rank_cd(d.document, s.tsq) + " +
+	"GREATEST(word_similarity(s.raw, p.name), word_similarity(s.raw, c.name) * 0.5))::float8"
+
+// searchOrder lists the most relevant matches first, then by name
+var searchOrder = keysetOrder{name: "relevance", id: "p.id", keys: []keysetKey{
+	{expr: searchRank, cast: "float8", desc: true},
+	{expr: "p.name", cast: "text", desc: false},
+}}
+
+// leaderboardScores whitelists the score columns a leaderboard ranks by
+var leaderboardScores = map[domain.LeaderboardScore]string{
+	domain.ScoreBayesian: "l.bayesian_score",
+	domain.ScoreWilson:   "l.wilson_score",
+}
+
+// leaderboardOrder lists the highest scores first, then the most reviewed, then by name.
+// The ranks newLeaderboardQuery numbers the rows with follow the same order.
+func leaderboardOrder(score domain.LeaderboardScore) keysetOrder {
+	return keysetOrder{name: string(score), id: "lb.product_id", keys: []keysetKey{
+		{expr: "lb.score", cast: "float8", desc: true},
+		{expr: "lb.review_count", cast: "int4", desc: true},
+		{expr: "lb.product_name", cast: "text", desc: false},
+	}}
+}
+
+// companyOrder lists the newest companies first
+var companyOrder = keysetOrder{name: "newest", id: "id", keys: []keysetKey{
+	{expr: "created_at", cast: "timestamptz", desc: true},
+}}
+
+// companyNameOrder lists companies by name, A to Z
+var companyNameOrder = keysetOrder{name: "name", id: "id", keys: []keysetKey{
+	{expr: "name", cast: "text", desc: false},
+}}
+
+// tagOrder lists the tags used by the most live products first, then by slug
+var tagOrder = keysetOrder{name: "popular", id: "t.id", keys: []keysetKey{
+	{
+		expr: "(SELECT COUNT(*) FROM product_tags pt JOIN products p ON p.id = pt.product_id " +
+			"WHERE pt.tag_id = t.id AND p.deleted_at IS NULL)",
+		cast: "int8",
+		desc: true,
+	},
+	{expr: "t.slug", cast: "text", desc: false},
+}}
+
+// oldestFirst orders a moderation queue by the creation time of the rows of the given table alias
+func oldestFirst(table string) keysetOrder {
+	return keysetOrder{name: "oldest", id: table + ".id", keys: []keysetKey{
+		{expr: table + ".created_at", cast: "timestamptz", desc: false},
+	}}
+}
+
+// mostFlaggedFirst orders a flagged queue by flag count, then oldest first
+func mostFlaggedFirst(table string) keysetOrder {
+	return keysetOrder{name: "flagged", id: table + ".id", keys: []keysetKey{
+		{expr: table + ".flag_count", cast: "int4", desc: true},
+		{expr: table + ".created_at", cast: "timestamptz", desc: false},
+	}}
+}
+
+// ratingEventOrder lists a product's rating events newest first
+var ratingEventOrder = keysetOrder{name: "recent", id: "e.id", keys: []keysetKey{
+	{expr: "e.created_at", cast: "timestamptz", desc: true},
+}}
+
+// auditOrder lists audit events newest first
+var auditOrder = keysetOrder{name: "recent", id: "a.id", keys: []keysetKey{
+	{expr: "a.created_at", cast: "timestamptz", desc: true},
+}}
+
+// searchColumns matches the field order of sqlc.SearchProductsRow. Highlights are delimited
+// with U+E000 and U+E001, so the service can escape the text before adding markup.
+const searchColumns = productColumns + `, c.name, c.slug, ` + searchRank + `, ` +
+	`ts_headline('english', p.name, s.tsq, 'HighlightAll=true, StartSel=' || chr(57344) || ', StopSel=' || chr(57345))::text, ` +
+	`ts_headline('english', coalesce(p.description, p.short_tagline, ''), s.tsq, ` +
+	`'MaxFragments=2, MaxWords=30, MinWords=10, StartSel=' || chr(57344) || ', StopSel=' || chr(57345))::text`
+
+// leaderboardColumns matches the field order of repository.LeaderboardRow
+const leaderboardColumns = `lb.product_id, lb.review_count, lb.positive_count, lb.avg_rating, lb.bayesian_score, ` +
+	`lb.wilson_score, lb.refreshed_at, lb.product_name, lb.product_slug, lb.category, lb.company_name, ` +
+	`lb.company_slug, lb.rank`
+
+// companyColumns matches the field order of sqlc.Company
+const companyColumns = `id, name, website, slug, logo_url, created_at, updated_at, deleted_at`
+
+// productColumns matches the field order of sqlc.Product
+const productColumns = `p.id, p.company_id, p.name, p.slug, p.category, p.short_tagline, p.description, ` +
+	`p.homepage_url, p.docs_url, p.avg_rating, p.total_reviews, p.created_at, p.updated_at, p.deleted_at`
+
+// reviewColumns matches the field order of sqlc.Review
+const reviewColumns = `r.id, r.product_id, r.user_id, r.title, r.body, r.rating, r.status, ` +
+	`r.upvote_count, r.downvote_count, r.flag_count, r.edited, r.created_at, r.updated_at, r.deleted_at`
+
+// commentColumns matches the field order of sqlc.ReviewComment
+const commentColumns = `rc.id, rc.review_id, rc.parent_id, rc.user_id, rc.body, rc.status, rc.official, ` +
+	`rc.company_id, rc.flag_count, rc.edited, rc.created_at, rc.updated_at, rc.deleted_at`
+
+// ratingEventColumns matches the field order of sqlc.ReviewRatingEvent
+const ratingEventColumns = `e.id, e.review_id, e.product_id, e.kind, e.from_rating, e.to_rating, e.created_at`
+
+// auditColumns matches the field order of repository.AuditEventRow
+const auditColumns = `a.id, a.actor_id, COALESCE(u.handle, ''), a.action, a.entity_type, a.entity_id, ` +
+	`a.before, a.after, a.request_id, a.ip_address, a.created_at`
+
+// ListProductsPage lists the products of live companies matching every filter
+func (s *Store) ListProductsPage(ctx context.Context, filter repository.ProductFilter, order repository.ProductOrder, page domain.PageRequest) ([]sqlc.Product, domain.PageCursors, error) {
+	keyset, ok := productOrders[order]
+	if !ok {
+		keyset = productOrders[repository.ProductsByNewest]
+	}
+	return queryPage(ctx, s.pool, newProductQuery(filter), keyset, page, scanProductRow)
+}
+
+// CountProductsMatching counts the products ListProductsPage pages through
+func (s *Store) CountProductsMatching(ctx context.Context, filter repository.ProductFilter) (int64, error) {
+	return queryCount(ctx, s.pool, newProductQuery(filter))
+}
+
+// SearchProductsPage lists the live products matching a full-text search, most relevant first
+func (s *Store) SearchProductsPage(ctx context.Context, search repository.ProductSearch, page domain.PageRequest) ([]sqlc.SearchProductsRow, domain.PageCursors, error) {
+	return queryPage(ctx, s.pool, newSearchQuery(search), searchOrder, page, scanSearchRow)
+}
+
+// CountSearchMatches counts the products SearchProductsPage pages through
+func (s *Store) CountSearchMatches(ctx context.Context, search repository.ProductSearch) (int64, error) {
+	return queryCount(ctx, s.pool, newSearchQuery(search))
+}
+
+// ListLeaderboardPage lists the live products of a leaderboard window, highest score first
+func (s *Store) ListLeaderboardPage(ctx context.Context, filter repository.LeaderboardFilter, score domain.LeaderboardScore, page domain.PageRequest) ([]repository.LeaderboardRow, domain.PageCursors, error) {
+	if _, ok := leaderboardScores[score]; !ok {
+		score = domain.ScoreBayesian
+	}
+	return queryPage(ctx, s.pool, newLeaderboardQuery(filter, score), leaderboardOrder(score), page, scanLeaderboardRow)
+}
+
+// CountLeaderboardEntries counts the products ListLeaderboardPage pages through
+func (s *Store) CountLeaderboardEntries(ctx context.Context, filter repository.LeaderboardFilter) (int64, error) {
+	return queryCount(ctx, s.pool, newLeaderboardQuery(filter, domain.ScoreBayesian))
+}
+
+// ListCompaniesPage lists companies, newest first
+func (s *Store) ListCompaniesPage(ctx context.Context, page domain.PageRequest) ([]sqlc.Company, domain.PageCursors, error) {
+	query := &listQuery{
+		columns:    companyColumns,
+		from:       "companies",
+		conditions: []string{"deleted_at IS NULL"},
+	}
+	return queryPage(ctx, s.pool, query, companyOrder, page, scanCompanyRow)
+}
+
+// SearchCompaniesPage lists the live companies whose name or slug contains query, by name
+func (s *Store) SearchCompaniesPage(ctx context.Context, query string, page domain.PageRequest) ([]sqlc.Company, domain.PageCursors, error) {
+	return queryPage(ctx, s.pool, newCompanySearchQuery(query), companyNameOrder, page, scanCompanyRow)
+}
+
+// CountCompaniesMatching counts the companies SearchCompaniesPage pages through
+func (s *Store) CountCompaniesMatching(ctx context.Context, query string) (int64, error) {
+	return queryCount(ctx, s.pool, newCompanySearchQuery(query))
+}
+
+// ListCompanyClaimsPage lists the claims with a status, oldest first
+func (s *Store) ListCompanyClaimsPage(ctx context.Context, status string, page domain.PageRequest) ([]sqlc.ListCompanyClaimsByStatusRow, domain.PageCursors, error) {
+	query := &listQuery{
+		columns: "cc.id, cc.company_id, cc.user_id, cc.status, cc.verification_method, cc.evidence, " +
+			"cc.reviewed_by, cc.review_note, cc.reviewed_at, cc.created_at, cc.updated_at, c.name, u.handle, u.email",
+		from: "company_claims cc\nJOIN companies c ON cc.company_id = c.id\nJOIN users u ON cc.user_id = u.id",
+	}
+	query.where("cc.status = %s", status)
+	return queryPage(ctx, s.pool, query, oldestFirst("cc"), page,
+		func(rows pgx.Rows, sortKeys *[]string) (sqlc.ListCompanyClaimsByStatusRow, uuid.UUID, error) {
+			var i sqlc.ListCompanyClaimsByStatusRow
+			err := rows.Scan(
+				&i.ID,
+				&i.CompanyID,
+				&i.UserID,
+				&i.Status,
+				&i.VerificationMethod,
+				&i.Evidence,
+				&i.ReviewedBy,
+				&i.ReviewNote,
+				&i.ReviewedAt,
+				&i.CreatedAt,
+				&i.UpdatedAt,
+				&i.CompanyName,
+				&i.UserHandle,
+				&i.UserEmail,
+				sortKeys,
+			)
+			return i, i.ID, err
+		})
+}
+
+// ListProductReviewsPage lists the published reviews of a product
+func (s *Store) ListProductReviewsPage(ctx context.Context, productID uuid.UUID, order repository.ReviewOrder, page domain.PageRequest) ([]sqlc.Review, domain.PageCursors, error) {
+	keyset, ok := reviewOrders[order]
+	if !ok {
+		keyset = reviewOrders[repository.ReviewsByRecent]
+	}
+
+	query := &listQuery{
+		columns:    reviewColumns,
+		from:       "reviews r\nLEFT JOIN user_reputation ur ON ur.user_id = r.user_id",
+		conditions: []string{"r.status = 'published'", "r.deleted_at IS NULL"},
+	}
+	query.where("r.product_id = %s", productID)
+	return queryPage(ctx, s.pool, query, keyset, page, scanReviewRow)
+}
+
+// ListUserReviewsPage lists the reviews of a live user on live products, newest first
+func (s *Store) ListUserReviewsPage(ctx context.Context, userID uuid.UUID, includeUnpublished bool, page domain.PageRequest) ([]sqlc.Review, domain.PageCursors, error) {
+	// The reviews of a deleted account stay up anonymously, so they are not listed together
+	query := &listQuery{
+		columns:    reviewColumns,
+		from:       "reviews r\nJOIN users u ON r.user_id = u.id\nJOIN products p ON r.product_id = p.id\nJOIN companies c ON p.company_id = c.id",
+		conditions: []string{"r.deleted_at IS NULL", "u.deleted_at IS NULL", "p.deleted_at IS NULL", "c.deleted_at IS NULL"},
+	}
+	query.where("r.user_id = %s", userID)
+	if !includeUnpublished {
+		query.conditions = append(query.conditions, "r.status = 'published'")
+	}
+	return queryPage(ctx, s.pool, query, reviewOrders[repository.ReviewsByRecent], page, scanReviewRow)
+}
+
+// ListReviewsByStatusPage lists the reviews with a status on live products, oldest first
+func (s *Store) ListReviewsByStatusPage(ctx context.Context, status string, page domain.PageRequest) ([]sqlc.GetReviewsByStatusRow, domain.PageCursors, error) {
+	query := newReviewQueueQuery()
+	query.where("r.status = %s", status)
+	return queryPage(ctx, s.pool, query, oldestFirst("r"), page, scanReviewQueueRow)
+}
+
+// ListFlaggedReviewsPage lists the unrejected reviews with at least minFlags flags on live products
+func (s *Store) ListFlaggedReviewsPage(ctx context.Context, minFlags int32, page domain.PageRequest) ([]sqlc.ListFlaggedReviewsRow, domain.PageCursors, error) {
+	query := newReviewQueueQuery()
+	query.where("r.flag_count >= %s", minFlags)
+	query.conditions = append(query.conditions, "r.status <> 'rejected'")
+	return queryPage(ctx, s.pool, query, mostFlaggedFirst("r"), page,
+		func(rows pgx.Rows, sortKeys *[]string) (sqlc.ListFlaggedReviewsRow, uuid.UUID, error) {
+			row, id, err := scanReviewQueueRow(rows, sortKeys)
+			return sqlc.ListFlaggedReviewsRow(row), id, err
+		})
+}
+
+// ListCommentsByStatusPage lists the comments with a status, oldest first
+func (s *Store) ListCommentsByStatusPage(ctx context.Context, status string, page domain.PageRequest) ([]sqlc.ListReviewCommentsByStatusRow, domain.PageCursors, error) {
+	query := newCommentQueueQuery()
+	query.where("rc.status = %s", status)
+	return queryPage(ctx, s.pool, query, oldestFirst("rc"), page, scanCommentQueueRow)
+}
+
+// ListFlaggedCommentsPage lists the unrejected comments with at least minFlags flags
+func (s *Store) ListFlaggedCommentsPage(ctx context.Context, minFlags int32, page domain.PageRequest) ([]sqlc.ListFlaggedReviewCommentsRow, domain.PageCursors, error) {
+	query := newCommentQueueQuery()
+	query.where("rc.flag_count >= %s", minFlags)
+	query.conditions = append(query.conditions, "rc.status <> 'rejected'")
+	return queryPage(ctx, s.pool, query, mostFlaggedFirst("rc"), page,
+		func(rows pgx.Rows, sortKeys *[]string) (sqlc.ListFlaggedReviewCommentsRow, uuid.UUID, error) {
+			row, id, err := scanCommentQueueRow(rows, sortKeys)
+			return sqlc.ListFlaggedReviewCommentsRow(row), id, err
+		})
+}
+
+// ListTagsPage lists the tags whose slug starts with prefix, most used first
+func (s *Store) ListTagsPage(ctx context.Context, prefix string, page domain.PageRequest) ([]sqlc.ListTagsRow, domain.PageCursors, error) {
+	query := newTagQuery(prefix)
+	query.columns = "t.id, t.slug, t.name, t.created_at, " + tagOrder.keys[0].expr
+	return queryPage(ctx, s.pool, query, tagOrder, page,
+		func(rows pgx.Rows, sortKeys *[]string) (sqlc.ListTagsRow, uuid.UUID, error) {
+			var i sqlc.ListTagsRow
+			err := rows.Scan(
+				&i.ID,
+				&i.Slug,
+				&i.Name,
+				&i.CreatedAt,
+				&i.ProductCount,
+				sortKeys,
+			)
+			return i, i.ID, err
+		})
+}
+
+// CountTagsMatching counts the tags ListTagsPage pages through
+func (s *Store) CountTagsMatching(ctx context.Context, prefix string) (int64, error) {
+	return queryCount(ctx, s.pool, newTagQuery(prefix))
+}
+
+// ListTagSuggestionsPage lists a product's tag suggestions with a status, oldest first
+func (s *Store) ListTagSuggestionsPage(ctx context.Context, productID uuid.UUID, status string, page domain.PageRequest) ([]sqlc.ListTagSuggestionsByProductRow, domain.PageCursors, error) {
+	query := &listQuery{
+		columns: "ts.id, ts.product_id, ts.user_id, ts.tag_slug, ts.tag_name, ts.status, " +
+			"ts.reviewed_by, ts.review_note, ts.reviewed_at, ts.created_at, ts.updated_at, u.handle",
+		from: "tag_suggestions ts\nJOIN users u ON ts.user_id = u.id",
+	}
+	query.where("ts.product_id = %s", productID)
+	query.where("ts.status = %s", status)
+	return queryPage(ctx, s.pool, query, oldestFirst("ts"), page,
+		func(rows pgx.Rows, sortKeys *[]string) (sqlc.ListTagSuggestionsByProductRow, uuid.UUID, error) {
+			var i sqlc.ListTagSuggestionsByProductRow
+			err := rows.Scan(
+				&i.ID,
+				&i.ProductID,
+				&i.UserID,
+				&i.TagSlug,
+				&i.TagName,
+				&i.Status,
+				&i.ReviewedBy,
+				&i.ReviewNote,
+				&i.ReviewedAt,
+				&i.CreatedAt,
+				&i.UpdatedAt,
+				&i.UserHandle,
+				sortKeys,
+			)
+			return i, i.ID, err
+		})
+}
+
+// ListProductRatingEventsPage lists the rating changes of a product, newest first
+func (s *Store) ListProductRatingEventsPage(ctx context.Context, productID uuid.UUID, page domain.PageRequest) ([]sqlc.ReviewRatingEvent, domain.PageCursors, error) {
+	query := &listQuery{
+		columns: ratingEventColumns,
+		from:    "review_rating_events e",
+	}
+	query.where("e.product_id = %s", productID)
+	return queryPage(ctx, s.pool, query, ratingEventOrder, page, scanRatingEventRow)
+}
+
+// ListAuditEventsPage lists the audit events matching the filter, newest first
+func (s *Store) ListAuditEventsPage(ctx context.Context, filter domain.AuditFilter, page domain.PageRequest) ([]repository.AuditEventRow, domain.PageCursors, error) {
+	return queryPage(ctx, s.pool, newAuditQuery(filter), auditOrder, page, scanAuditEventRow)
+}
+
+// CountAuditEvents counts the audit events matching the filter
+func (s *Store) CountAuditEvents(ctx context.Context, filter domain.AuditFilter) (int64, error) {
+	return queryCount(ctx, s.pool, newAuditQuery(filter))
+}
+
+func newProductQuery(filter repository.ProductFilter) *listQuery {
+	query := &listQuery{
+		columns:    productColumns,
+		from:       "products p\nJOIN companies c ON p.company_id = c.id",
+		conditions: []string{"p.deleted_at IS NULL", "c.deleted_at IS NULL"},
+	}
+
+	if filter.Category != "" {
+		query.whereCategory(filter.Category)
+	}
+	if filter.CompanyID != nil {
+		query.where("p.company_id = %s", *filter.CompanyID)
+	}
+	if filter.CompanySlug != "" {
+		query.where("c.slug = %s", filter.CompanySlug)
+	}
+	if filter.MinRating != nil {
+		query.where("p.avg_rating >= %s", *filter.MinRating)
+	}
+	if filter.MinReviews != nil {
+		query.where("p.total_reviews >= %s", *filter.MinReviews)
+	}
+	if len(filter.Tags) > 0 {
+		// A product must carry all of the tags, or at least one of them
+		required := 1
+		if filter.MatchAllTags {
+			required = len(filter.Tags)
+		}
+		query.where(`(
+  SELECT COUNT(*) FROM product_tags pt JOIN tags t ON pt.tag_id = t.id
+  WHERE pt.product_id = p.id AND t.slug = ANY(%s::text[])
+) >= %s`, filter.Tags, required)
+	}
+	if filter.CreatedAfter != nil {
+		query.where("p.created_at > %s", filter.CreatedAfter.UTC())
+	}
+	return query
+}
+
+// newSearchQuery selects the products matching a search, on the full-text document or
+// approximately on the product or company name
+func newSearchQuery(search repository.ProductSearch) *listQuery {
+	query := newProductQuery(repository.ProductFilter{
+		Category:     search.Category,
+		MinRating:    search.MinRating,
+		Tags:         search.Tags,
+		MatchAllTags: search.MatchAllTags,
+	})
+	raw := query.bind(search.Query)
+	query.columns = searchColumns
+	query.from += "\nJOIN product_search_documents d ON d.product_id = p.id" +
+		"\nCROSS JOIN (SELECT websearch_to_tsquery('english', " + raw + "::text) AS tsq, " + raw + "::text AS raw) s"
+	query.conditions = append(query.conditions, "(d.document @@ s.tsq OR s.raw <% p.name OR s.raw <% c.name)")
+	return query
+}
+
+// newLeaderboardQuery selects a window's scores of live products, numbered by rank in a
+// subquery so that pages reached by cursor still know their ranks
+func newLeaderboardQuery(filter repository.LeaderboardFilter, score domain.LeaderboardScore) *listQuery {
+	inner := &listQuery{
+		from:       "product_leaderboard l\nJOIN products p ON l.product_id = p.id\nJOIN companies c ON p.company_id = c.id",
+		conditions: []string{"p.deleted_at IS NULL", "c.deleted_at IS NULL"},
+	}
+	inner.where("l.time_window = %s", string(filter.Window))
+	if filter.Category != "" {
+		inner.whereCategory(filter.Category)
+	}
+
+	scoreColumn := leaderboardScores[score]
+	return &listQuery{
+		columns: leaderboardColumns,
+		from: "(\nSELECT l.product_id, l.review_count, l.positive_count, l.avg_rating, l.bayesian_score, " +
+			"l.wilson_score, l.refreshed_at, p.name AS product_name, p.slug AS product_slug, p.category, " +
+			"c.name AS company_name, c.slug AS company_slug, " + scoreColumn + " AS score,\n" +
+			"ROW_NUMBER() OVER (ORDER BY " + scoreColumn + " DESC, l.review_count DESC, p.name ASC, l.product_id ASC) AS rank\n" +
+			inner.body() + "\n) lb",
+		args: inner.args,
+	}
+}
+
+func newCompanySearchQuery(search string) *listQuery {
+	query := &listQuery{
+		columns:    companyColumns,
+		from:       "companies",
+		conditions: []string{"deleted_at IS NULL"},
+	}
+	pattern := "%" + search + "%"
+	query.where("(name ILIKE %s OR slug ILIKE %s)", pattern, pattern)
+	return query
+}
+
+// newReviewQueueQuery selects reviews on live products with their author's handle and product name
+func newReviewQueueQuery() *listQuery {
+	return &listQuery{
+		columns:    reviewColumns + ", u.handle, p.name",
+		from:       "reviews r\nJOIN users u ON r.user_id = u.id\nJOIN products p ON r.product_id = p.id",
+		conditions: []string{"r.deleted_at IS NULL", "p.deleted_at IS NULL"},
+	}
+}
+
+// newCommentQueueQuery selects comments with their author's handle
+func newCommentQueueQuery() *listQuery {
+	return &listQuery{
+		columns:    commentColumns + ", u.handle",
+		from:       "review_comments rc\nJOIN users u ON rc.user_id = u.id",
+		conditions: []string{"rc.deleted_at IS NULL"},
+	}
+}
+
+// newTagQuery selects the tags whose slug starts with prefix; the caller picks the columns
+func newTagQuery(prefix string) *listQuery {
+	query := &listQuery{from: "tags t"}
+	if prefix != "" {
+		query.where("t.slug LIKE %s::text || '%%'", prefix)
+	}
+	return query
+}
+
+func newAuditQuery(filter domain.AuditFilter) *listQuery {
+	query := &listQuery{
+		columns: auditColumns,
+		from:    "audit_events a\nLEFT JOIN users u ON a.actor_id = u.id",
+	}
+	if filter.ActorID != nil {
+		query.where("a.actor_id = %s", *filter.ActorID)
+	}
+	if filter.Action != "" {
+		query.where("a.action = %s", string(filter.Action))
+	}
+	if filter.EntityType != "" {
+		query.where("a.entity_type = %s", string(filter.EntityType))
+	}
+	if filter.EntityID != nil {
+		query.where("a.entity_id = %s", *filter.EntityID)
+	}
+	if filter.From != nil {
+		query.where("a.created_at >= %s", filter.From.UTC())
+	}
+	if filter.To != nil {
+		query.where("a.created_at < %s", filter.To.UTC())
+	}
+	return query
+}
+
+// scanProductRow reads a row selected with productColumns
+func scanProductRow(rows pgx.Rows, sortKeys *[]string) (sqlc.Product, uuid.UUID, error) {
+	var i sqlc.Product
+	err := rows.Scan(
+		&i.ID,
+		&i.CompanyID,
+		&i.Name,
+		&i.Slug,
+		&i.Category,
+		&i.ShortTagline,
+		&i.Description,
+		&i.HomepageUrl,
+		&i.DocsUrl,
+		&i.AvgRating,
+		&i.TotalReviews,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		sortKeys,
+	)
+	return i, i.ID, err
+}
+
+// scanSearchRow reads a row selected with searchColumns
+func scanSearchRow(rows pgx.Rows, sortKeys *[]string) (sqlc.SearchProductsRow, uuid.UUID, error) {
+	var i sqlc.SearchProductsRow
+	err := rows.Scan(
+		&i.ID,
+		&i.CompanyID,
+		&i.Name,
+		&i.Slug,
+		&i.Category,
+		&i.ShortTagline,
+		&i.Description,
+		&i.HomepageUrl,
+		&i.DocsUrl,
+		&i.AvgRating,
+		&i.TotalReviews,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.CompanyName,
+		&i.CompanySlug,
+		&i.Rank,
+		&i.NameHighlight,
+		&i.Snippet,
+		sortKeys,
+	)
+	return i, i.ID, err
+}
+
+// scanLeaderboardRow reads a row selected with leaderboardColumns
+func scanLeaderboardRow(rows pgx.Rows, sortKeys *[]string) (repository.LeaderboardRow, uuid.UUID, error) {
+	var i repository.LeaderboardRow
+	err := rows.Scan(
+		&i.ProductID,
+		&i.ReviewCount,
+		&i.PositiveCount,
+		&i.AvgRating,
+		&i.BayesianScore,
+		&i.WilsonScore,
+		&i.RefreshedAt,
+		&i.ProductName,
+		&i.ProductSlug,
+		&i.Category,
+		&i.CompanyName,
+		&i.CompanySlug,
+		&i.Rank,
+		sortKeys,
+	)
+	return i, i.ProductID, err
+}
+
+// scanCompanyRow reads a row selected with companyColumns
+func scanCompanyRow(rows pgx.Rows, sortKeys *[]string) (sqlc.Company, uuid.UUID, error) {
+	var i sqlc.Company
+	err := rows.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Website,
+		&i.Slug,
+		&i.LogoUrl,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		sortKeys,
+	)
+	return i, i.ID, err
+}
+
+// scanReviewRow reads a row selected with reviewColumns
+func scanReviewRow(rows pgx.Rows, sortKeys *[]string) (sqlc.Review, uuid.UUID, error) {
+	var i sqlc.Review
+	err := rows.Scan(
+		&i.ID,
+		&i.ProductID,
+		&i.UserID,
+		&i.Title,
+		&i.Body,
+		&i.Rating,
+		&i.Status,
+		&i.UpvoteCount,
+		&i.DownvoteCount,
+		&i.FlagCount,
+		&i.Edited,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		sortKeys,
+	)
+	return i, i.ID, err
+}
+
+// scanReviewQueueRow reads a row selected by newReviewQueueQuery
+func scanReviewQueueRow(rows pgx.Rows, sortKeys *[]string) (sqlc.GetReviewsByStatusRow, uuid.UUID, error) {
+	var i sqlc.GetReviewsByStatusRow
+	err := rows.Scan(
+		&i.ID,
+		&i.ProductID,
+		&i.UserID,
+		&i.Title,
+		&i.Body,
+		&i.Rating,
+		&i.Status,
+		&i.UpvoteCount,
+		&i.DownvoteCount,
+		&i.FlagCount,
+		&i.Edited,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.UserHandle,
+		&i.ProductName,
+		sortKeys,
+	)
+	return i, i.ID, err
+}
+
+// scanCommentQueueRow reads a row selected by newCommentQueueQuery
+func scanCommentQueueRow(rows pgx.Rows, sortKeys *[]string) (sqlc.ListReviewCommentsByStatusRow, uuid.UUID, error) {
+	var i sqlc.ListReviewCommentsByStatusRow
+	err := rows.Scan(
+		&i.ReviewComment.ID,
+		&i.ReviewComment.ReviewID,
+		&i.ReviewComment.ParentID,
+		&i.ReviewComment.UserID,
+		&i.ReviewComment.Body,
+		&i.ReviewComment.Status,
+		&i.ReviewComment.Official,
+		&i.ReviewComment.CompanyID,
+		&i.ReviewComment.FlagCount,
+		&i.ReviewComment.Edited,
+		&i.ReviewComment.CreatedAt,
+		&i.ReviewComment.UpdatedAt,
+		&i.ReviewComment.DeletedAt,
+		&i.UserHandle,
+		sortKeys,
+	)
+	return i, i.ReviewComment.ID, err
+}
+
+// scanRatingEventRow reads a row selected with ratingEventColumns
+func scanRatingEventRow(rows pgx.Rows, sortKeys *[]string) (sqlc.ReviewRatingEvent, uuid.UUID, error) {
+	var i sqlc.ReviewRatingEvent
+	err := rows.Scan(
+		&i.ID,
+		&i.ReviewID,
+		&i.ProductID,
+		&i.Kind,
+		&i.FromRating,
+		&i.ToRating,
+		&i.CreatedAt,
+		sortKeys,
+	)
+	return i, i.ID, err
+}
+
+// scanAuditEventRow reads a row selected with auditColumns
+func scanAuditEventRow(rows pgx.Rows, sortKeys *[]string) (repository.AuditEventRow, uuid.UUID, error) {
+	var i repository.AuditEventRow
+	err := rows.Scan(
+		&i.ID,
+		&i.ActorID,
+		&i.ActorHandle,
+		&i.Action,
+		&i.EntityType,
+		&i.EntityID,
+		&i.Before,
+		&i.After,
+		&i.RequestID,
+		&i.IpAddress,
+		&i.CreatedAt,
+		sortKeys,
+	)
+	return i, i.ID, err
+}
@@ -0,0 +1,37 @@
+// Package postgres implements the repository interfaces with the sqlc queries.
+package postgres
+
+import (
+	"context"
+
+	"ratemysoft-backend/internal/models/sqlc"
+	"ratemysoft-backend/internal/platform/db"
+	"ratemysoft-backend/internal/repository"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var _ repository.Store = (*Store)(nil)
+
+// Store serves the repositories from Postgres; units of work run in pgx transactions
+// that are retried on serialization failures and deadlocks
+type Store struct {
+	*sqlc.Queries
+	pool *pgxpool.Pool
+	tx   *db.TxManager
+}
+
+func NewStore(pool *pgxpool.Pool, queries *sqlc.Queries) *Store {
+	return &Store{
+		Queries: queries,
+		pool:    pool,
+		tx:      db.NewTxManager(pool, queries),
+	}
+}
+
+// InTx executes fn against transaction-scoped queries, committing only if fn succeeds
+func (s *Store) InTx(ctx context.Context, fn func(q repository.Queries) error) error {
+	return s.tx.InTx(ctx, func(q *sqlc.Queries) error {
+		return fn(q)
+	})
+}
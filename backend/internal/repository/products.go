@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"context"
+
+	"ratemysoft-backend/internal/models/sqlc"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// ProductRepository stores products and the rating stats derived from their reviews
+type ProductRepository interface {
+	CreateProduct(ctx context.Context, arg sqlc.CreateProductParams) (sqlc.Product, error)
+	GetProduct(ctx context.Context, id uuid.UUID) (sqlc.Product, error)
+	GetProductBySlug(ctx context.Context, slug string) (sqlc.GetProductBySlugRow, error)
+	CountProductsByCompany(ctx context.Context, companyID uuid.UUID) (int64, error)
+	ListProductsForComparison(ctx context.Context, arg sqlc.ListProductsForComparisonParams) ([]sqlc.ListProductsForComparisonRow, error)
+	UpdateProduct(ctx context.Context, arg sqlc.UpdateProductParams) (sqlc.Product, error)
+	SoftDeleteProduct(ctx context.Context, id uuid.UUID) error
+	ListProductTags(ctx context.Context, productIds []uuid.UUID) ([]sqlc.ListProductTagsRow, error)
+
+	// LockProduct serializes stats refreshes and tagging of one product until the transaction ends
+	LockProduct(ctx context.Context, id uuid.UUID) error
+	UpdateProductStats(ctx context.Context, arg sqlc.UpdateProductStatsParams) error
+	GetProductRatingStats(ctx context.Context, productID uuid.UUID) (sqlc.ProductRatingStat, error)
+	RefreshProductRatingStats(ctx context.Context, productID uuid.UUID) error
+	ListProductDimensionStats(ctx context.Context, productID uuid.UUID) ([]sqlc.ProductDimensionStat, error)
+	DeleteProductDimensionStats(ctx context.Context, productID uuid.UUID) error
+	RefreshProductDimensionStats(ctx context.Context, productID uuid.UUID) error
+}
+
+// SearchRepository runs the ranked full-text product search. Names and company names also
+// match approximately, so small typos still find results. Highlights in names and snippets
+// are delimited with U+E000 and U+E001.
+type SearchRepository interface {
+	SearchProductCategoryFacets(ctx context.Context, query string) ([]sqlc.SearchProductCategoryFacetsRow, error)
+	SearchProductRatingFacets(ctx context.Context, query string) ([]sqlc.SearchProductRatingFacetsRow, error)
+}
+
+// LeaderboardRepository stores the product scores of each leaderboard window, which
+// RefreshLeaderboardWindow recomputes from the published reviews
+type LeaderboardRepository interface {
+	// LockLeaderboardRefresh serializes refreshes until the transaction ends
+	LockLeaderboardRefresh(ctx context.Context) error
+	DeleteLeaderboardWindow(ctx context.Context, timeWindow string) error
+	RefreshLeaderboardWindow(ctx context.Context, arg sqlc.RefreshLeaderboardWindowParams) error
+	GetLeaderboardRefreshedAt(ctx context.Context, timeWindow string) (pgtype.Timestamptz, error)
+}
+
+// CategoryRepository stores the product category tree
+type CategoryRepository interface {
+	CreateCategory(ctx context.Context, arg sqlc.CreateCategoryParams) (sqlc.Category, error)
+	GetCategory(ctx context.Context, id uuid.UUID) (sqlc.Category, error)
+	GetCategoryBySlug(ctx context.Context, slug string) (sqlc.Category, error)
+	ListCategories(ctx context.Context) ([]sqlc.ListCategoriesRow, error)
+	UpdateCategory(ctx context.Context, arg sqlc.UpdateCategoryParams) (sqlc.Category, error)
+	DeleteCategory(ctx context.Context, id uuid.UUID) error
+	CountCategoryChildren(ctx context.Context, parentID *uuid.UUID) (int64, error)
+	CountProductsInCategory(ctx context.Context, category string) (int64, error)
+}
+
+// PricingRepository stores the pricing plans of products
+type PricingRepository interface {
+	CreatePricingPlan(ctx context.Context, arg sqlc.CreatePricingPlanParams) (sqlc.PricingPlan, error)
+	GetPricingPlan(ctx context.Context, arg sqlc.GetPricingPlanParams) (sqlc.PricingPlan, error)
+	ListPricingPlansByProduct(ctx context.Context, productID uuid.UUID) ([]sqlc.PricingPlan, error)
+	ListPricingPlansByProducts(ctx context.Context, productIds []uuid.UUID) ([]sqlc.PricingPlan, error)
+	UpdatePricingPlan(ctx context.Context, arg sqlc.UpdatePricingPlanParams) (sqlc.PricingPlan, error)
+	DeletePricingPlan(ctx context.Context, arg sqlc.DeletePricingPlanParams) (int64, error)
+}
+
+// TagRepository stores tags, which products carry them and the tag suggestion queue
+type TagRepository interface {
+	UpsertTag(ctx context.Context, arg sqlc.UpsertTagParams) (sqlc.Tag, error)
+	AddProductTag(ctx context.Context, arg sqlc.AddProductTagParams) (int64, error)
+	RemoveProductTag(ctx context.Context, arg sqlc.RemoveProductTagParams) (int64, error)
+	CountProductTags(ctx context.Context, productID uuid.UUID) (int64, error)
+
+	CreateTagSuggestion(ctx context.Context, arg sqlc.CreateTagSuggestionParams) (sqlc.TagSuggestion, error)
+	GetTagSuggestion(ctx context.Context, id uuid.UUID) (sqlc.TagSuggestion, error)
+	GetPendingTagSuggestion(ctx context.Context, arg sqlc.GetPendingTagSuggestionParams) (sqlc.TagSuggestion, error)
+	CountTagSuggestionsByProduct(ctx context.Context, arg sqlc.CountTagSuggestionsByProductParams) (int64, error)
+	ResolveTagSuggestion(ctx context.Context, arg sqlc.ResolveTagSuggestionParams) (sqlc.TagSuggestion, error)
+}
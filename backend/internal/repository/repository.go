@@ -0,0 +1,43 @@
+// Package repository defines the storage the services depend on for users, sessions,
+// companies, products, pricing, tags, reviews, comments, search, the leaderboard, reviewer
+// reputation and the audit log.
+//
+// The methods of Queries mirror the sqlc queries of the same name and use the sqlc row types,
+// so *sqlc.Queries satisfies every one of them and the Postgres store is a thin adapter.
+// Lookups that find nothing return pgx.ErrNoRows in every implementation, so callers treat
+// both stores alike. The keyset listings of ListingRepository build their SQL at request
+// time instead; the Postgres store implements them itself.
+package repository
+
+import "context"
+
+// Queries is every repository, scoped either to a single statement or to a transaction
+type Queries interface {
+	UserRepository
+	CredentialRepository
+	AccountTokenRepository
+	LoginThrottleRepository
+	SessionRepository
+	CompanyRepository
+	CategoryRepository
+	ProductRepository
+	PricingRepository
+	TagRepository
+	ReviewRepository
+	CommentRepository
+	AuditRepository
+	SearchRepository
+	LeaderboardRepository
+	ReputationRepository
+}
+
+// Store runs each repository call on its own, or a group of them as one unit of work
+type Store interface {
+	Queries
+	ListingRepository
+
+	// InTx executes fn against transaction-scoped repositories, committing only if fn succeeds.
+	// fn may be run more than once when the transaction conflicts with a concurrent one, so it
+	// must not keep state from a previous attempt or call back into the Store itself.
+	InTx(ctx context.Context, fn func(q Queries) error) error
+}
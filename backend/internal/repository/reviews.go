@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"context"
+
+	"ratemysoft-backend/internal/models/sqlc"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// ReviewRepository stores reviews together with everything attached to them: sub-ratings,
+// votes, flags, superseded revisions, rating events and moderation decisions
+type ReviewRepository interface {
+	CreateReview(ctx context.Context, arg sqlc.CreateReviewParams) (sqlc.Review, error)
+	GetReview(ctx context.Context, id uuid.UUID) (sqlc.GetReviewRow, error)
+	// GetReviewForUpdate locks the review until the transaction ends
+	GetReviewForUpdate(ctx context.Context, id uuid.UUID) (sqlc.Review, error)
+	GetUserReviewForProduct(ctx context.Context, arg sqlc.GetUserReviewForProductParams) (sqlc.Review, error)
+	UpdateReview(ctx context.Context, arg sqlc.UpdateReviewParams) (sqlc.Review, error)
+	UpdateReviewStatus(ctx context.Context, arg sqlc.UpdateReviewStatusParams) error
+	SoftDeleteReview(ctx context.Context, id uuid.UUID) error
+	CountReviewsByProduct(ctx context.Context, productID uuid.UUID) (int64, error)
+	CountReviewsByUser(ctx context.Context, arg sqlc.CountReviewsByUserParams) (int64, error)
+	CountReviewsByStatus(ctx context.Context, status string) (int64, error)
+	GetAverageRatingByProduct(ctx context.Context, productID uuid.UUID) (pgtype.Numeric, error)
+	CountReviewCommentsByReviews(ctx context.Context, reviewIds []uuid.UUID) ([]sqlc.CountReviewCommentsByReviewsRow, error)
+
+	CreateReviewSubRating(ctx context.Context, arg sqlc.CreateReviewSubRatingParams) error
+	ListReviewSubRatings(ctx context.Context, reviewIds []uuid.UUID) ([]sqlc.ReviewSubRating, error)
+	DeleteReviewSubRatings(ctx context.Context, reviewID uuid.UUID) error
+
+	GetReviewVote(ctx context.Context, arg sqlc.GetReviewVoteParams) (sqlc.ReviewVote, error)
+	UpsertReviewVote(ctx context.Context, arg sqlc.UpsertReviewVoteParams) (sqlc.ReviewVote, error)
+	DeleteReviewVote(ctx context.Context, arg sqlc.DeleteReviewVoteParams) (int64, error)
+	AdjustReviewVoteCounts(ctx context.Context, arg sqlc.AdjustReviewVoteCountsParams) error
+	GetUserVotesForReviews(ctx context.Context, arg sqlc.GetUserVotesForReviewsParams) ([]sqlc.ReviewVote, error)
+
+	GetReviewFlag(ctx context.Context, arg sqlc.GetReviewFlagParams) (sqlc.ReviewFlag, error)
+	UpsertReviewFlag(ctx context.Context, arg sqlc.UpsertReviewFlagParams) (sqlc.ReviewFlag, error)
+	DeleteReviewFlag(ctx context.Context, arg sqlc.DeleteReviewFlagParams) (int64, error)
+	DeleteReviewFlags(ctx context.Context, reviewID uuid.UUID) error
+	ListReviewFlags(ctx context.Context, reviewID uuid.UUID) ([]sqlc.ListReviewFlagsRow, error)
+	AdjustReviewFlagCount(ctx context.Context, arg sqlc.AdjustReviewFlagCountParams) error
+	ClearReviewFlags(ctx context.Context, id uuid.UUID) error
+	CountFlaggedReviews(ctx context.Context, flagCount int32) (int64, error)
+
+	CreateReviewRevision(ctx context.Context, arg sqlc.CreateReviewRevisionParams) error
+	GetLatestReviewRevision(ctx context.Context, reviewID uuid.UUID) (int32, error)
+	ListReviewRevisions(ctx context.Context, reviewID uuid.UUID) ([]sqlc.ReviewRevision, error)
+
+	CreateReviewRatingEvent(ctx context.Context, arg sqlc.CreateReviewRatingEventParams) error
+	CountProductRatingEvents(ctx context.Context, productID uuid.UUID) (int64, error)
+
+	CreateReviewModeration(ctx context.Context, arg sqlc.CreateReviewModerationParams) (sqlc.ReviewModeration, error)
+	ListReviewModerations(ctx context.Context, reviewID uuid.UUID) ([]sqlc.ListReviewModerationsRow, error)
+}
+
+// ReputationRepository stores reviewer reputation scores and category badges, which
+// RefreshUserReputation and RefreshReviewerBadges recompute from the reviews, and serves the
+// public reviewer profiles built on them
+type ReputationRepository interface {
+	// LockReputationRefresh serializes refreshes until the transaction ends
+	LockReputationRefresh(ctx context.Context) error
+	DeleteUserReputation(ctx context.Context) error
+	RefreshUserReputation(ctx context.Context) error
+	DeleteReviewerBadges(ctx context.Context) error
+	RefreshReviewerBadges(ctx context.Context, topN int32) error
+	GetPublicProfile(ctx context.Context, handle string) (sqlc.GetPublicProfileRow, error)
+	ListReviewerBadges(ctx context.Context, userID uuid.UUID) ([]sqlc.ListReviewerBadgesRow, error)
+}
+
+// CommentRepository stores the threaded comments on reviews and the flags raised against them
+type CommentRepository interface {
+	CreateReviewComment(ctx context.Context, arg sqlc.CreateReviewCommentParams) (sqlc.ReviewComment, error)
+	GetReviewComment(ctx context.Context, id uuid.UUID) (sqlc.ReviewComment, error)
+	// GetReviewCommentForUpdate locks the comment until the transaction ends
+	GetReviewCommentForUpdate(ctx context.Context, id uuid.UUID) (sqlc.ReviewComment, error)
+	GetOfficialReviewComment(ctx context.Context, reviewID uuid.UUID) (sqlc.ReviewComment, error)
+	ListReviewComments(ctx context.Context, reviewID uuid.UUID) ([]sqlc.ListReviewCommentsRow, error)
+	UpdateReviewCommentBody(ctx context.Context, arg sqlc.UpdateReviewCommentBodyParams) (sqlc.ReviewComment, error)
+	UpdateReviewCommentStatus(ctx context.Context, arg sqlc.UpdateReviewCommentStatusParams) error
+	SoftDeleteReviewComment(ctx context.Context, id uuid.UUID) error
+	CountReviewCommentsByStatus(ctx context.Context, status string) (int64, error)
+
+	GetReviewCommentFlag(ctx context.Context, arg sqlc.GetReviewCommentFlagParams) (sqlc.ReviewCommentFlag, error)
+	UpsertReviewCommentFlag(ctx context.Context, arg sqlc.UpsertReviewCommentFlagParams) (sqlc.ReviewCommentFlag, error)
+	DeleteReviewCommentFlag(ctx context.Context, arg sqlc.DeleteReviewCommentFlagParams) (int64, error)
+	DeleteReviewCommentFlags(ctx context.Context, commentID uuid.UUID) error
+	AdjustReviewCommentFlagCount(ctx context.Context, arg sqlc.AdjustReviewCommentFlagCountParams) error
+	ClearReviewCommentFlags(ctx context.Context, id uuid.UUID) error
+	CountFlaggedReviewComments(ctx context.Context, flagCount int32) (int64, error)
+}
+
+// AuditRepository appends to the audit log
+type AuditRepository interface {
+	CreateAuditEvent(ctx context.Context, arg sqlc.CreateAuditEventParams) error
+	RedactUserAuditEvents(ctx context.Context, arg sqlc.RedactUserAuditEventsParams) (int64, error)
+}
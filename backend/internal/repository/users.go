@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"context"
+
+	"ratemysoft-backend/internal/models/sqlc"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// UserRepository stores user accounts; soft-deleted users are never returned
+type UserRepository interface {
+	CreateUser(ctx context.Context, arg sqlc.CreateUserParams) (sqlc.User, error)
+	GetUser(ctx context.Context, id uuid.UUID) (sqlc.User, error)
+	GetUserByEmail(ctx context.Context, email string) (sqlc.User, error)
+	GetUserByHandle(ctx context.Context, handle string) (sqlc.User, error)
+	CountUsers(ctx context.Context) (int64, error)
+	UpdateUser(ctx context.Context, arg sqlc.UpdateUserParams) (sqlc.User, error)
+	SetUserEmailVerified(ctx context.Context, arg sqlc.SetUserEmailVerifiedParams) (sqlc.User, error)
+	AnonymizeUser(ctx context.Context, arg sqlc.AnonymizeUserParams) (sqlc.User, error)
+}
+
+// CredentialRepository stores the secrets users sign in with, one per user and provider
+type CredentialRepository interface {
+	CreateCredential(ctx context.Context, arg sqlc.CreateCredentialParams) (sqlc.Credential, error)
+	GetCredential(ctx context.Context, arg sqlc.GetCredentialParams) (sqlc.Credential, error)
+	GetCredentialByIdentifier(ctx context.Context, arg sqlc.GetCredentialByIdentifierParams) (sqlc.Credential, error)
+	UpdateCredential(ctx context.Context, arg sqlc.UpdateCredentialParams) (sqlc.Credential, error)
+	HardDeleteCredential(ctx context.Context, arg sqlc.HardDeleteCredentialParams) error
+	DeleteUserCredentials(ctx context.Context, userID uuid.UUID) (int64, error)
+}
+
+// AccountTokenRepository stores the hashed single-use tokens behind email verification and
+// password reset links
+type AccountTokenRepository interface {
+	CreateAccountToken(ctx context.Context, arg sqlc.CreateAccountTokenParams) (sqlc.AccountToken, error)
+	GetAccountTokenByHash(ctx context.Context, tokenHash string) (sqlc.AccountToken, error)
+	UseAccountToken(ctx context.Context, arg sqlc.UseAccountTokenParams) (int64, error)
+	UseUserAccountTokens(ctx context.Context, arg sqlc.UseUserAccountTokensParams) (int64, error)
+	DeleteExpiredAccountTokens(ctx context.Context, expiresAt pgtype.Timestamptz) (int64, error)
+}
+
+// LoginThrottleRepository counts failed sign-ins per email and per client IP
+type LoginThrottleRepository interface {
+	GetLoginThrottle(ctx context.Context, arg sqlc.GetLoginThrottleParams) (sqlc.LoginThrottle, error)
+	ReserveLoginAttempt(ctx context.Context, arg sqlc.ReserveLoginAttemptParams) (sqlc.LoginThrottle, error)
+	ReleaseLoginAttempt(ctx context.Context, arg sqlc.ReleaseLoginAttemptParams) error
+	LockLoginThrottle(ctx context.Context, arg sqlc.LockLoginThrottleParams) error
+	DeleteLoginThrottle(ctx context.Context, arg sqlc.DeleteLoginThrottleParams) (int64, error)
+	ListLockedAccounts(ctx context.Context, lockedUntil pgtype.Timestamptz) ([]sqlc.ListLockedAccountsRow, error)
+	DeleteStaleLoginThrottles(ctx context.Context, staleBefore pgtype.Timestamptz) (int64, error)
+}
+
+// SessionRepository stores login sessions, their single-use refresh tokens and the
+// deny-list of revoked access tokens
+type SessionRepository interface {
+	CreateSession(ctx context.Context, arg sqlc.CreateSessionParams) (sqlc.Session, error)
+	GetSession(ctx context.Context, id uuid.UUID) (sqlc.Session, error)
+	TouchSession(ctx context.Context, arg sqlc.TouchSessionParams) error
+	RevokeSession(ctx context.Context, arg sqlc.RevokeSessionParams) (int64, error)
+	RevokeUserSessions(ctx context.Context, arg sqlc.RevokeUserSessionsParams) (int64, error)
+	RevokeOtherUserSessions(ctx context.Context, arg sqlc.RevokeOtherUserSessionsParams) (int64, error)
+	DeleteStaleSessions(ctx context.Context, lastUsedAt pgtype.Timestamptz) (int64, error)
+
+	CreateRefreshToken(ctx context.Context, arg sqlc.CreateRefreshTokenParams) (sqlc.RefreshToken, error)
+	// GetRefreshTokenByHashForUpdate locks the token until the transaction ends
+	GetRefreshTokenByHashForUpdate(ctx context.Context, tokenHash string) (sqlc.RefreshToken, error)
+	MarkRefreshTokenUsed(ctx context.Context, arg sqlc.MarkRefreshTokenUsedParams) error
+	DeleteExpiredRefreshTokens(ctx context.Context, expiresAt pgtype.Timestamptz) (int64, error)
+
+	RevokeAccessToken(ctx context.Context, arg sqlc.RevokeAccessTokenParams) error
+	IsAccessTokenRevoked(ctx context.Context, arg sqlc.IsAccessTokenRevokedParams) (bool, error)
+	DeleteExpiredRevokedAccessTokens(ctx context.Context, expiresAt pgtype.Timestamptz) (int64, error)
+}
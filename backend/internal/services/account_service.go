@@ -0,0 +1,319 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"ratemysoft-backend/internal/auth"
+	"ratemysoft-backend/internal/domain"
+	"ratemysoft-backend/internal/models/sqlc"
+	"ratemysoft-backend/internal/platform/mail"
+	"ratemysoft-backend/internal/repository"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// errInvalidAccountToken covers unknown, used, expired and superseded links alike
+var errInvalidAccountToken = domain.Invalid("invalid_account_token", "this link is invalid or has expired; please request a new one")
+
+// AccountConfig configures the links AccountService emails
+type AccountConfig struct {
+	AppBaseURL      string        // frontend origin; links go to /verify-email and /reset-password
+	VerificationTTL time.Duration // how long an email verification link works
+	ResetTTL        time.Duration // how long a password reset link works
+}
+
+// AccountService proves email ownership and resets passwords through single-use links sent by
+// email. Only a hash of each link's token is stored, and issuing a link voids the older ones.
+type AccountService struct {
+	store  repository.Store
+	mailer mail.Mailer
+	cfg    AccountConfig
+}
+
+func NewAccountService(store repository.Store, mailer mail.Mailer, cfg AccountConfig) *AccountService {
+	cfg.AppBaseURL = strings.TrimSuffix(cfg.AppBaseURL, "/")
+	return &AccountService{
+		store:  store,
+		mailer: mailer,
+		cfg:    cfg,
+	}
+}
+
+// SendEmailVerification emails the user a link confirming they own their current address
+func (s *AccountService) SendEmailVerification(ctx context.Context, userID domain.ID) error {
+	user, err := s.store.GetUser(ctx, userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.ErrUserNotFound
+		}
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+	if user.EmailVerifiedAt.Valid {
+		return domain.Conflict("email_already_verified", "your email address is already verified")
+	}
+
+	token, err := s.issue(ctx, user, domain.TokenEmailVerification, s.cfg.VerificationTTL)
+	if err != nil {
+		return err
+	}
+
+	return s.send(ctx, user.Email, "Confirm your email address", fmt.Sprintf(
+		"Hi %s,\n\nPlease confirm your email address for RateMySoft by opening this link:\n\n%s\n\n"+
+			"The link expires in %s. If you did not sign up, you can ignore this email.\n",
+		user.Handle, s.link("/verify-email", token), describeDuration(s.cfg.VerificationTTL),
+	))
+}
+
+// NotifyEmailChanged tells the previous address of a user that their email was changed, so
+// the owner notices if someone else did it
+func (s *AccountService) NotifyEmailChanged(ctx context.Context, previousEmail string, user *domain.User) error {
+	return s.send(ctx, previousEmail, "Your email address was changed", fmt.Sprintf(
+		"Hi %s,\n\nThe email address of your RateMySoft account was changed to %s.\n\n"+
+			"If you did not make this change, please contact support right away; "+
+			"password reset links now go to the new address.\n",
+		user.Handle, user.Email,
+	))
+}
+
+// VerifyEmail redeems an email verification link
+func (s *AccountService) VerifyEmail(ctx context.Context, token string) (*domain.User, error) {
+	now := time.Now()
+	var user sqlc.User
+	err := s.store.InTx(ctx, func(q repository.Queries) error {
+		before, err := redeemAccountToken(ctx, q, token, domain.TokenEmailVerification, now)
+		if err != nil {
+			return err
+		}
+
+		user, err = q.SetUserEmailVerified(ctx, sqlc.SetUserEmailVerifiedParams{
+			ID:              before.ID,
+			EmailVerifiedAt: pgtype.Timestamptz{Time: now, Valid: true},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to verify email: %w", err)
+		}
+
+		return recordAudit(ctx, q, user.ID, domain.AuditUpdate, domain.AuditEntityUser, user.ID, before, user)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return SQLCToDomainUser(user)
+}
+
+// RequestPasswordReset emails a password reset link to the account with this email. Unknown
+// addresses are ignored without an error so the endpoint does not reveal who has an account.
+func (s *AccountService) RequestPasswordReset(ctx context.Context, email string) error {
+	user, err := s.store.GetUserByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil
+		}
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	token, err := s.issue(ctx, user, domain.TokenPasswordReset, s.cfg.ResetTTL)
+	if err != nil {
+		return err
+	}
+
+	return s.send(ctx, user.Email, "Reset your password", fmt.Sprintf(
+		"Hi %s,\n\nSomeone asked to reset the password of your RateMySoft account. "+
+			"To choose a new password, open this link:\n\n%s\n\n"+
+			"The link expires in %s. If you did not ask for this, you can ignore this email; "+
+			"your password stays the same.\n",
+		user.Handle, s.link("/reset-password", token), describeDuration(s.cfg.ResetTTL),
+	))
+}
+
+// ResetPassword redeems a password reset link, setting a new password. Accounts that only signed
+// in through an external provider get a password this way. Opening the link also proves the
+// user owns their email, so it marks the address verified. Whoever knew the old password may
+// still hold a session or be locked out of guessing, so every session is revoked and the
+// account's failed sign-ins are forgotten along with the change.
+func (s *AccountService) ResetPassword(ctx context.Context, token, password string) (*domain.User, error) {
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash password: %w", err)
+	}
+	secretHash := string(hashedPassword)
+
+	now := time.Now()
+	ts := pgtype.Timestamptz{Time: now, Valid: true}
+	var user sqlc.User
+	err = s.store.InTx(ctx, func(q repository.Queries) error {
+		var err error
+		user, err = redeemAccountToken(ctx, q, token, domain.TokenPasswordReset, now)
+		if err != nil {
+			return err
+		}
+
+		_, err = q.UpdateCredential(ctx, sqlc.UpdateCredentialParams{
+			UserID:     user.ID,
+			Provider:   "email",
+			Identifier: user.Email,
+			SecretHash: &secretHash,
+			UpdatedAt:  ts,
+		})
+		if errors.Is(err, pgx.ErrNoRows) {
+			_, err = q.CreateCredential(ctx, sqlc.CreateCredentialParams{
+				UserID:     user.ID,
+				Provider:   "email",
+				Identifier: user.Email,
+				SecretHash: &secretHash,
+				CreatedAt:  ts,
+				UpdatedAt:  ts,
+			})
+		}
+		if err != nil {
+			return fmt.Errorf("failed to set password: %w", err)
+		}
+
+		if _, err := q.RevokeUserSessions(ctx, sqlc.RevokeUserSessionsParams{UserID: user.ID, RevokedAt: ts}); err != nil {
+			return fmt.Errorf("failed to revoke sessions: %w", err)
+		}
+		if _, err := q.DeleteLoginThrottle(ctx, sqlc.DeleteLoginThrottleParams{Scope: throttleAccount, Key: user.Email}); err != nil {
+			return fmt.Errorf("failed to clear login throttle: %w", err)
+		}
+
+		if !user.EmailVerifiedAt.Valid {
+			before := user
+			user, err = q.SetUserEmailVerified(ctx, sqlc.SetUserEmailVerifiedParams{ID: user.ID, EmailVerifiedAt: ts})
+			if err != nil {
+				return fmt.Errorf("failed to verify email: %w", err)
+			}
+			return recordAudit(ctx, q, user.ID, domain.AuditUpdate, domain.AuditEntityUser, user.ID, before, user)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return SQLCToDomainUser(user)
+}
+
+// issue stores a new token for user, voiding the ones issued before for the same purpose
+func (s *AccountService) issue(ctx context.Context, user sqlc.User, purpose domain.TokenPurpose, ttl time.Duration) (string, error) {
+	token, hash, err := auth.GenerateAccountToken()
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+	err = s.store.InTx(ctx, func(q repository.Queries) error {
+		_, err := q.UseUserAccountTokens(ctx, sqlc.UseUserAccountTokensParams{
+			UserID:  user.ID,
+			Purpose: string(purpose),
+			UsedAt:  pgtype.Timestamptz{Time: now, Valid: true},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to void previous links: %w", err)
+		}
+
+		_, err = q.CreateAccountToken(ctx, sqlc.CreateAccountTokenParams{
+			ID:        uuid.New(),
+			UserID:    user.ID,
+			Purpose:   string(purpose),
+			TokenHash: hash,
+			Email:     user.Email,
+			ExpiresAt: pgtype.Timestamptz{Time: now.Add(ttl), Valid: true},
+			CreatedAt: pgtype.Timestamptz{Time: now, Valid: true},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to store link: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// redeemAccountToken marks a token used and returns its user. The token must be unused, unexpired,
+// issued for purpose, and sent to the address the user still has.
+func redeemAccountToken(ctx context.Context, q repository.Queries, token string, purpose domain.TokenPurpose, now time.Time) (sqlc.User, error) {
+	row, err := q.GetAccountTokenByHash(ctx, auth.HashAccountToken(token))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return sqlc.User{}, errInvalidAccountToken
+		}
+		return sqlc.User{}, fmt.Errorf("failed to get link: %w", err)
+	}
+	accountToken := SQLCToDomainAccountToken(row)
+	if accountToken.Purpose != purpose || !accountToken.Usable(now) {
+		return sqlc.User{}, errInvalidAccountToken
+	}
+
+	user, err := q.GetUser(ctx, accountToken.UserID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return sqlc.User{}, errInvalidAccountToken
+		}
+		return sqlc.User{}, fmt.Errorf("failed to get user: %w", err)
+	}
+	if user.Email != accountToken.Email {
+		return sqlc.User{}, errInvalidAccountToken
+	}
+
+	// Only one of two concurrent redemptions gets the row
+	used, err := q.UseAccountToken(ctx, sqlc.UseAccountTokenParams{
+		ID:     accountToken.ID,
+		UsedAt: pgtype.Timestamptz{Time: now, Valid: true},
+	})
+	if err != nil {
+		return sqlc.User{}, fmt.Errorf("failed to use link: %w", err)
+	}
+	if used == 0 {
+		return sqlc.User{}, errInvalidAccountToken
+	}
+	return user, nil
+}
+
+func (s *AccountService) link(path, token string) string {
+	return s.cfg.AppBaseURL + path + "?" + url.Values{"token": {token}}.Encode()
+}
+
+func (s *AccountService) send(ctx context.Context, to, subject, body string) error {
+	if err := s.mailer.Send(ctx, mail.Message{To: to, Subject: subject, Body: body}); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	return nil
+}
+
+// describeDuration renders a link lifetime for an email, e.g. "48 hours" or "30 minutes"
+func describeDuration(d time.Duration) string {
+	if d >= time.Hour && d%time.Hour == 0 {
+		if d == time.Hour {
+			return "1 hour"
+		}
+		return fmt.Sprintf("%d hours", d/time.Hour)
+	}
+	return fmt.Sprintf("%d minutes", d/time.Minute)
+}
+
+// SQLCToDomainAccountToken converts a SQLC AccountToken to a domain AccountToken
+func SQLCToDomainAccountToken(t sqlc.AccountToken) *domain.AccountToken {
+	token := &domain.AccountToken{
+		ID:        t.ID,
+		UserID:    t.UserID,
+		Purpose:   domain.TokenPurpose(t.Purpose),
+		Email:     t.Email,
+		ExpiresAt: t.ExpiresAt.Time,
+		CreatedAt: t.CreatedAt.Time,
+	}
+	if t.UsedAt.Valid {
+		token.UsedAt = &t.UsedAt.Time
+	}
+	return token
+}
@@ -0,0 +1,184 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"ratemysoft-backend/internal/domain"
+	"ratemysoft-backend/internal/platform/mail/mailtest"
+	"ratemysoft-backend/internal/repository/memory"
+)
+
+func newAccountService(store *memory.Store, cfg AccountConfig) (*AccountService, *mailtest.Recorder) {
+	if cfg.VerificationTTL == 0 {
+		cfg.VerificationTTL = 48 * time.Hour
+	}
+	if cfg.ResetTTL == 0 {
+		cfg.ResetTTL = time.Hour
+	}
+	cfg.AppBaseURL = "https://app.example/"
+	mailer := &mailtest.Recorder{}
+	return NewAccountService(store, mailer, cfg), mailer
+}
+
+func TestVerifyEmail(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	svc, mailer := newAccountService(store, AccountConfig{})
+	alice := seedUser(t, store, "alice", domain.RoleUser)
+
+	if err := svc.SendEmailVerification(ctx, alice.UserID); err != nil {
+		t.Fatalf("SendEmailVerification: %v", err)
+	}
+	msg := mailer.Last(t, "alice@example.com")
+	if msg.Subject != "Confirm your email address" {
+		t.Errorf("subject = %q", msg.Subject)
+	}
+	token := mailer.Token(t, "alice@example.com")
+
+	user, err := svc.VerifyEmail(ctx, token)
+	if err != nil {
+		t.Fatalf("VerifyEmail: %v", err)
+	}
+	if user.ID != alice.UserID || !user.IsEmailVerified() {
+		t.Errorf("user = %+v, want alice verified", user)
+	}
+
+	// Links are single use, and a verified address needs no new one
+	_, err = svc.VerifyEmail(ctx, token)
+	assertErrorIs(t, err, domain.ErrValidation)
+	err = svc.SendEmailVerification(ctx, alice.UserID)
+	assertErrorIs(t, err, domain.ErrConflict)
+
+	if events := store.AuditEvents(); len(events) != 1 || events[0].Action != string(domain.AuditUpdate) {
+		t.Errorf("audit log = %+v, want one update event", events)
+	}
+}
+
+func TestVerifyEmailVoidsOlderLinks(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	svc, mailer := newAccountService(store, AccountConfig{})
+	alice := seedUser(t, store, "alice", domain.RoleUser)
+
+	send := func() string {
+		t.Helper()
+		if err := svc.SendEmailVerification(ctx, alice.UserID); err != nil {
+			t.Fatalf("SendEmailVerification: %v", err)
+		}
+		return mailer.Token(t, "alice@example.com")
+	}
+
+	// A new link voids the one sent before
+	first := send()
+	second := send()
+	_, err := svc.VerifyEmail(ctx, first)
+	assertErrorIs(t, err, domain.ErrValidation)
+
+	if _, err := svc.VerifyEmail(ctx, second); err != nil {
+		t.Errorf("VerifyEmail with the latest link: %v", err)
+	}
+
+	_, err = svc.VerifyEmail(ctx, "made-up")
+	assertErrorIs(t, err, domain.ErrValidation)
+}
+
+func TestVerifyEmailRejectsExpiredLinks(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	svc, mailer := newAccountService(store, AccountConfig{VerificationTTL: -time.Minute})
+	alice := seedUser(t, store, "alice", domain.RoleUser)
+
+	if err := svc.SendEmailVerification(ctx, alice.UserID); err != nil {
+		t.Fatalf("SendEmailVerification: %v", err)
+	}
+	_, err := svc.VerifyEmail(ctx, mailer.Token(t, "alice@example.com"))
+	assertErrorIs(t, err, domain.ErrValidation)
+}
+
+func TestResetPassword(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	svc, mailer := newAccountService(store, AccountConfig{})
+	users := NewUserService(store)
+
+	alice, err := users.CreateUser(ctx, CreateUserRequest{Email: "alice@example.com", Handle: "alice", Password: "correct horse"})
+	if err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+
+	// Unknown addresses are ignored silently
+	if err := svc.RequestPasswordReset(ctx, "nobody@example.com"); err != nil {
+		t.Fatalf("RequestPasswordReset unknown: %v", err)
+	}
+	if n := len(mailer.Messages()); n != 0 {
+		t.Fatalf("sent %d messages for an unknown address", n)
+	}
+
+	if err := svc.RequestPasswordReset(ctx, "alice@example.com"); err != nil {
+		t.Fatalf("RequestPasswordReset: %v", err)
+	}
+	token := mailer.Token(t, "alice@example.com")
+
+	// A verification link cannot reset the password
+	if err := svc.SendEmailVerification(ctx, alice.ID); err != nil {
+		t.Fatalf("SendEmailVerification: %v", err)
+	}
+	_, err = svc.ResetPassword(ctx, mailer.Token(t, "alice@example.com"), "battery staple")
+	assertErrorIs(t, err, domain.ErrValidation)
+
+	// Whoever knew the old password holds a session and has locked the account out
+	sessions := NewSessionService(store, time.Hour)
+	session, _, err := sessions.StartSession(ctx, alice.ID, SessionMetadata{})
+	if err != nil {
+		t.Fatalf("StartSession: %v", err)
+	}
+	guard := NewLoginGuard(store, users, LoginPolicy{AccountAttempts: 1, IPAttempts: 100, BaseLockout: time.Hour, MaxLockout: time.Hour})
+	_, err = guard.Authenticate(ctx, "alice@example.com", "wrong", "")
+	assertErrorIs(t, err, domain.ErrUnauthorized)
+
+	user, err := svc.ResetPassword(ctx, token, "battery staple")
+	if err != nil {
+		t.Fatalf("ResetPassword: %v", err)
+	}
+	if !user.IsEmailVerified() {
+		t.Error("reset did not verify the email")
+	}
+
+	if revoked, err := sessions.IsAccessTokenRevoked(ctx, "", session.ID.String()); err != nil || !revoked {
+		t.Errorf("IsAccessTokenRevoked = %v, %v; want the session revoked", revoked, err)
+	}
+	if _, err := guard.Authenticate(ctx, "alice@example.com", "battery staple", ""); err != nil {
+		t.Errorf("Authenticate after reset: %v", err)
+	}
+
+	_, err = users.AuthenticateUser(ctx, "alice@example.com", "correct horse")
+	assertErrorIs(t, err, domain.ErrUnauthorized)
+	if _, err := users.AuthenticateUser(ctx, "alice@example.com", "battery staple"); err != nil {
+		t.Errorf("AuthenticateUser with new password: %v", err)
+	}
+
+	_, err = svc.ResetPassword(ctx, token, "another one")
+	assertErrorIs(t, err, domain.ErrValidation)
+}
+
+func TestResetPasswordAddsPasswordToExternalAccount(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	svc, mailer := newAccountService(store, AccountConfig{})
+	users := NewUserService(store)
+
+	if _, err := users.AuthenticateExternal(ctx, ExternalIdentity{Provider: "github", Subject: "1", Email: "octo@example.com", EmailVerified: true, Username: "octo"}); err != nil {
+		t.Fatalf("AuthenticateExternal: %v", err)
+	}
+	if err := svc.RequestPasswordReset(ctx, "octo@example.com"); err != nil {
+		t.Fatalf("RequestPasswordReset: %v", err)
+	}
+	if _, err := svc.ResetPassword(ctx, mailer.Token(t, "octo@example.com"), "battery staple"); err != nil {
+		t.Fatalf("ResetPassword: %v", err)
+	}
+	if _, err := users.AuthenticateUser(ctx, "octo@example.com", "battery staple"); err != nil {
+		t.Errorf("AuthenticateUser: %v", err)
+	}
+}
@@ -0,0 +1,133 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"ratemysoft-backend/internal/domain"
+	"ratemysoft-backend/internal/models/sqlc"
+	"ratemysoft-backend/internal/repository"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// RequestInfo identifies the HTTP request behind a mutation in the audit log
+type RequestInfo struct {
+	RequestID string
+	IPAddress string
+}
+
+type requestInfoKey struct{}
+
+// WithRequestInfo returns a context whose mutations are audited with the given request details
+func WithRequestInfo(ctx context.Context, info RequestInfo) context.Context {
+	return context.WithValue(ctx, requestInfoKey{}, info)
+}
+
+func requestInfoFrom(ctx context.Context) RequestInfo {
+	info, _ := ctx.Value(requestInfoKey{}).(RequestInfo)
+	return info
+}
+
+// recordAudit appends a mutation to the audit log; run it in the mutation's transaction.
+// before and after are snapshots of the record (nil when it did not exist) and are reduced
+// to the fields that differ. actorID is uuid.Nil for changes made by the system.
+func recordAudit(ctx context.Context, q repository.AuditRepository, actorID uuid.UUID, action domain.AuditAction, entity domain.AuditEntity, entityID uuid.UUID, before, after any) error {
+	beforeJSON, afterJSON, err := auditDiff(before, after)
+	if err != nil {
+		return fmt.Errorf("failed to diff audited %s: %w", entity, err)
+	}
+
+	var actor *uuid.UUID
+	if actorID != uuid.Nil {
+		actor = &actorID
+	}
+
+	info := requestInfoFrom(ctx)
+	var requestID, ipAddress *string
+	if info.RequestID != "" {
+		requestID = &info.RequestID
+	}
+	if info.IPAddress != "" {
+		ipAddress = &info.IPAddress
+	}
+
+	err = q.CreateAuditEvent(ctx, sqlc.CreateAuditEventParams{
+		ID:         uuid.New(),
+		ActorID:    actor,
+		Action:     string(action),
+		EntityType: string(entity),
+		EntityID:   entityID,
+		Before:     beforeJSON,
+		After:      afterJSON,
+		RequestID:  requestID,
+		IpAddress:  ipAddress,
+		CreatedAt: pgtype.Timestamptz{
+			Time:  time.Now().UTC(),
+			Valid: true,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record audit event: %w", err)
+	}
+	return nil
+}
+
+// auditDiff encodes two snapshots of a record as JSON objects. When both exist only the
+// fields whose values differ are kept; a nil snapshot encodes to nil.
+func auditDiff(before, after any) ([]byte, []byte, error) {
+	beforeFields, err := auditFields(before)
+	if err != nil {
+		return nil, nil, err
+	}
+	afterFields, err := auditFields(after)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if beforeFields != nil && afterFields != nil {
+		for field, value := range beforeFields {
+			if other, ok := afterFields[field]; ok && bytes.Equal(value, other) {
+				delete(beforeFields, field)
+				delete(afterFields, field)
+			}
+		}
+	}
+
+	beforeJSON, err := marshalAuditFields(beforeFields)
+	if err != nil {
+		return nil, nil, err
+	}
+	afterJSON, err := marshalAuditFields(afterFields)
+	if err != nil {
+		return nil, nil, err
+	}
+	return beforeJSON, afterJSON, nil
+}
+
+// auditFields splits a snapshot into its top-level JSON fields
+func auditFields(snapshot any) (map[string]json.RawMessage, error) {
+	if snapshot == nil {
+		return nil, nil
+	}
+	encoded, err := json.Marshal(snapshot)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(encoded, &fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+func marshalAuditFields(fields map[string]json.RawMessage) ([]byte, error) {
+	if fields == nil {
+		return nil, nil
+	}
+	return json.Marshal(fields)
+}
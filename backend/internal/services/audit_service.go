@@ -0,0 +1,76 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"ratemysoft-backend/internal/domain"
+	"ratemysoft-backend/internal/repository"
+)
+
+// MaxAuditExport caps the number of events in a single export
+const MaxAuditExport = 10000
+
+// AuditService queries the audit log written by the other services
+type AuditService struct {
+	store repository.Store
+}
+
+func NewAuditService(store repository.Store) *AuditService {
+	return &AuditService{
+		store: store,
+	}
+}
+
+// ListEvents retrieves a page of audit events matching the filter, newest first
+func (s *AuditService) ListEvents(ctx context.Context, filter domain.AuditFilter, page domain.PageRequest) ([]*domain.AuditEvent, domain.PageCursors, error) {
+	rows, cursors, err := s.store.ListAuditEventsPage(ctx, filter, page)
+	if err != nil {
+		return nil, domain.PageCursors{}, listingError("list audit events", err)
+	}
+	return toDomainAuditEvents(rows), cursors, nil
+}
+
+// CountEvents returns the number of audit events matching the filter
+func (s *AuditService) CountEvents(ctx context.Context, filter domain.AuditFilter) (int64, error) {
+	total, err := s.store.CountAuditEvents(ctx, filter)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count audit events: %w", err)
+	}
+	return total, nil
+}
+
+// ExportEvents retrieves up to MaxAuditExport events matching the filter, newest first
+func (s *AuditService) ExportEvents(ctx context.Context, filter domain.AuditFilter) ([]*domain.AuditEvent, error) {
+	page := domain.PageRequest{Limit: MaxAuditExport}
+	rows, _, err := s.store.ListAuditEventsPage(ctx, filter, page)
+	if err != nil {
+		return nil, fmt.Errorf("failed to export audit events: %w", err)
+	}
+	return toDomainAuditEvents(rows), nil
+}
+
+func toDomainAuditEvents(rows []repository.AuditEventRow) []*domain.AuditEvent {
+	events := make([]*domain.AuditEvent, 0, len(rows))
+	for _, row := range rows {
+		event := &domain.AuditEvent{
+			ID:          row.ID,
+			ActorID:     row.ActorID,
+			ActorHandle: row.ActorHandle,
+			Action:      domain.AuditAction(row.Action),
+			EntityType:  domain.AuditEntity(row.EntityType),
+			EntityID:    row.EntityID,
+			Before:      row.Before,
+			After:       row.After,
+			CreatedAt:   row.CreatedAt.Time,
+		}
+		if row.RequestID != nil {
+			event.RequestID = *row.RequestID
+		}
+		if row.IpAddress != nil {
+			event.IPAddress = *row.IpAddress
+		}
+		events = append(events, event)
+	}
+	return events
+}
@@ -0,0 +1,70 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"ratemysoft-backend/internal/domain"
+	"ratemysoft-backend/internal/models/sqlc"
+	"ratemysoft-backend/internal/repository"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// Authorizer decides whether an actor may manage a company or its products.
+// Admins bypass every check; everyone else needs a company_members row with a sufficient role.
+type Authorizer struct {
+	queries repository.Queries
+}
+
+func NewAuthorizer(queries repository.Queries) *Authorizer {
+	return &Authorizer{
+		queries: queries,
+	}
+}
+
+// RequireCompanyRole returns a domain.ErrForbidden error unless the actor holds at least the given role in the company
+func (a *Authorizer) RequireCompanyRole(ctx context.Context, actor domain.Actor, companyID uuid.UUID, required domain.CompanyRole) error {
+	if actor.IsAdmin() {
+		return nil
+	}
+
+	role, ok, err := a.CompanyRole(ctx, actor.UserID, companyID)
+	if err != nil {
+		return err
+	}
+	if !ok || !role.Satisfies(required) {
+		return domain.Forbidden("insufficient_company_role", fmt.Sprintf("requires %s access to this company", required))
+	}
+	return nil
+}
+
+// RequireProductRole resolves the product's company and applies RequireCompanyRole
+func (a *Authorizer) RequireProductRole(ctx context.Context, actor domain.Actor, productID uuid.UUID, required domain.CompanyRole) error {
+	product, err := a.queries.GetProduct(ctx, productID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.ErrProductNotFound
+		}
+		return fmt.Errorf("failed to get product: %w", err)
+	}
+
+	return a.RequireCompanyRole(ctx, actor, product.CompanyID, required)
+}
+
+// CompanyRole returns the user's role in the company; ok is false if they are not a member
+func (a *Authorizer) CompanyRole(ctx context.Context, userID, companyID uuid.UUID) (domain.CompanyRole, bool, error) {
+	member, err := a.queries.GetCompanyMember(ctx, sqlc.GetCompanyMemberParams{
+		CompanyID: companyID,
+		UserID:    userID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", false, nil
+		}
+		return "", false, fmt.Errorf("failed to get company membership: %w", err)
+	}
+	return domain.CompanyRole(member.Role), true, nil
+}
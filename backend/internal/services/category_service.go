@@ -0,0 +1,357 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"ratemysoft-backend/internal/domain"
+	"ratemysoft-backend/internal/models/sqlc"
+	"ratemysoft-backend/internal/repository"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// CategoryService manages the product category tree
+type CategoryService struct {
+	store repository.Store
+}
+
+func NewCategoryService(store repository.Store) *CategoryService {
+	return &CategoryService{
+		store: store,
+	}
+}
+
+// CategoryRequest holds the fields of a category; updates replace every field
+type CategoryRequest struct {
+	Slug        string
+	Name        string
+	Description string
+	Icon        string
+	ParentSlug  string // optional; empty for a top-level category
+	SortOrder   int
+}
+
+// ListCategories retrieves every category with its product counts, ordered for display
+func (s *CategoryService) ListCategories(ctx context.Context) ([]*domain.Category, error) {
+	rows, err := s.store.ListCategories(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list categories: %w", err)
+	}
+
+	categories := make([]*domain.Category, 0, len(rows))
+	byID := make(map[uuid.UUID]*domain.Category, len(rows))
+	for _, row := range rows {
+		category := SQLCToDomainCategory(sqlc.Category{
+			ID:          row.ID,
+			Slug:        row.Slug,
+			Name:        row.Name,
+			Description: row.Description,
+			Icon:        row.Icon,
+			ParentID:    row.ParentID,
+			SortOrder:   row.SortOrder,
+			CreatedAt:   row.CreatedAt,
+			UpdatedAt:   row.UpdatedAt,
+		})
+		category.ProductCount = int(row.ProductCount)
+		categories = append(categories, category)
+		byID[category.ID] = category
+	}
+
+	// Roll each category's direct count up to all of its ancestors
+	for _, category := range categories {
+		visited := make(map[uuid.UUID]bool)
+		for node := category; node != nil && !visited[node.ID]; {
+			visited[node.ID] = true
+			node.TotalProductCount += category.ProductCount
+			if node.ParentID == nil {
+				break
+			}
+			node = byID[*node.ParentID]
+		}
+	}
+
+	return categories, nil
+}
+
+// GetCategory retrieves a category and its direct subcategories by slug
+func (s *CategoryService) GetCategory(ctx context.Context, slug string) (*domain.Category, []*domain.Category, error) {
+	categories, err := s.ListCategories(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var category *domain.Category
+	for _, c := range categories {
+		if string(c.Slug) == slug {
+			category = c
+			break
+		}
+	}
+	if category == nil {
+		return nil, nil, domain.ErrCategoryNotFound
+	}
+
+	children := make([]*domain.Category, 0)
+	for _, c := range categories {
+		if c.ParentID != nil && *c.ParentID == category.ID {
+			children = append(children, c)
+		}
+	}
+	return category, children, nil
+}
+
+// CreateCategory adds a category (admins only; enforced by the route)
+func (s *CategoryService) CreateCategory(ctx context.Context, req CategoryRequest) (*domain.Category, error) {
+	slug, name, err := validateCategoryRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.ensureSlugAvailable(ctx, uuid.Nil, slug); err != nil {
+		return nil, err
+	}
+
+	parentID, err := s.resolveParent(ctx, req.ParentSlug)
+	if err != nil {
+		return nil, err
+	}
+
+	now := pgtype.Timestamptz{
+		Time:  time.Now().UTC(),
+		Valid: true,
+	}
+
+	// Prepare optional fields
+	var description *string
+	if req.Description != "" {
+		description = &req.Description
+	}
+
+	var icon *string
+	if req.Icon != "" {
+		icon = &req.Icon
+	}
+
+	row, err := s.store.CreateCategory(ctx, sqlc.CreateCategoryParams{
+		ID:          uuid.New(),
+		Slug:        string(slug),
+		Name:        name,
+		Description: description,
+		Icon:        icon,
+		ParentID:    parentID,
+		SortOrder:   int32(req.SortOrder),
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create category: %w", err)
+	}
+
+	return SQLCToDomainCategory(row), nil
+}
+
+// UpdateCategory replaces a category's fields (admins only; enforced by the route).
+// Renaming the slug carries over to the category's products.
+func (s *CategoryService) UpdateCategory(ctx context.Context, categoryID string, req CategoryRequest) (*domain.Category, error) {
+	parsedID, err := parseID("category_id", categoryID)
+	if err != nil {
+		return nil, err
+	}
+
+	slug, name, err := validateCategoryRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = s.store.GetCategory(ctx, parsedID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrCategoryNotFound
+		}
+		return nil, fmt.Errorf("failed to get category: %w", err)
+	}
+
+	if err := s.ensureSlugAvailable(ctx, parsedID, slug); err != nil {
+		return nil, err
+	}
+
+	parentID, err := s.resolveParent(ctx, req.ParentSlug)
+	if err != nil {
+		return nil, err
+	}
+
+	// A category cannot be moved below itself or one of its descendants
+	if parentID != nil {
+		if err := s.ensureNotDescendant(ctx, parsedID, *parentID); err != nil {
+			return nil, err
+		}
+	}
+
+	// Prepare optional fields
+	var description *string
+	if req.Description != "" {
+		description = &req.Description
+	}
+
+	var icon *string
+	if req.Icon != "" {
+		icon = &req.Icon
+	}
+
+	row, err := s.store.UpdateCategory(ctx, sqlc.UpdateCategoryParams{
+		ID:          parsedID,
+		Slug:        string(slug),
+		Name:        name,
+		Description: description,
+		Icon:        icon,
+		ParentID:    parentID,
+		SortOrder:   int32(req.SortOrder),
+		UpdatedAt: pgtype.Timestamptz{
+			Time:  time.Now().UTC(),
+			Valid: true,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update category: %w", err)
+	}
+
+	return SQLCToDomainCategory(row), nil
+}
+
+// DeleteCategory removes an empty category without subcategories (admins only; enforced by the route)
+func (s *CategoryService) DeleteCategory(ctx context.Context, categoryID string) error {
+	parsedID, err := parseID("category_id", categoryID)
+	if err != nil {
+		return err
+	}
+
+	category, err := s.store.GetCategory(ctx, parsedID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.ErrCategoryNotFound
+		}
+		return fmt.Errorf("failed to get category: %w", err)
+	}
+
+	children, err := s.store.CountCategoryChildren(ctx, &parsedID)
+	if err != nil {
+		return fmt.Errorf("failed to count subcategories: %w", err)
+	}
+	if children > 0 {
+		return domain.Conflict("category_has_children", "move or delete the subcategories first")
+	}
+
+	products, err := s.store.CountProductsInCategory(ctx, category.Slug)
+	if err != nil {
+		return fmt.Errorf("failed to count products: %w", err)
+	}
+	if products > 0 {
+		return domain.Conflict("category_in_use", "move the category's products to another category first")
+	}
+
+	err = s.store.DeleteCategory(ctx, parsedID)
+	if err != nil {
+		return fmt.Errorf("failed to delete category: %w", err)
+	}
+	return nil
+}
+
+func (s *CategoryService) ensureSlugAvailable(ctx context.Context, categoryID uuid.UUID, slug domain.ProductCategory) error {
+	existing, err := s.store.GetCategoryBySlug(ctx, string(slug))
+	if err == nil && existing.ID != categoryID {
+		return domain.Conflict("slug_taken", "category slug already exists")
+	}
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("failed to check category slug: %w", err)
+	}
+	return nil
+}
+
+func (s *CategoryService) resolveParent(ctx context.Context, parentSlug string) (*uuid.UUID, error) {
+	if parentSlug == "" {
+		return nil, nil
+	}
+
+	parent, err := s.store.GetCategoryBySlug(ctx, parentSlug)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.InvalidField("invalid_parent", "parent", fmt.Sprintf("unknown category %q", parentSlug))
+		}
+		return nil, fmt.Errorf("failed to get parent category: %w", err)
+	}
+	return &parent.ID, nil
+}
+
+// ensureNotDescendant walks up from parentID and fails if it reaches categoryID
+func (s *CategoryService) ensureNotDescendant(ctx context.Context, categoryID, parentID uuid.UUID) error {
+	visited := make(map[uuid.UUID]bool)
+	for id := &parentID; id != nil && !visited[*id]; {
+		if *id == categoryID {
+			return domain.InvalidField("category_cycle", "parent", "a category cannot be nested below itself")
+		}
+		visited[*id] = true
+
+		ancestor, err := s.store.GetCategory(ctx, *id)
+		if err != nil {
+			return fmt.Errorf("failed to get category: %w", err)
+		}
+		id = ancestor.ParentID
+	}
+	return nil
+}
+
+func validateCategoryRequest(req CategoryRequest) (domain.ProductCategory, string, error) {
+	slug, err := domain.NewProductCategory(req.Slug)
+	if err != nil {
+		return "", "", domain.InvalidField("invalid_slug", "slug", "must be lowercase letters and digits separated by single hyphens or underscores").Wrap(err)
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return "", "", domain.InvalidField("name_required", "name", "is required")
+	}
+	return slug, name, nil
+}
+
+// requireCategory fails with a validation error unless the category slug exists
+func requireCategory(ctx context.Context, q repository.CategoryRepository, category string) error {
+	_, err := q.GetCategoryBySlug(ctx, category)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return invalidCategory(category)
+		}
+		return fmt.Errorf("failed to check category: %w", err)
+	}
+	return nil
+}
+
+// SQLCToDomainCategory converts a SQLC Category to a domain Category
+func SQLCToDomainCategory(row sqlc.Category) *domain.Category {
+	description := ""
+	if row.Description != nil {
+		description = *row.Description
+	}
+
+	icon := ""
+	if row.Icon != nil {
+		icon = *row.Icon
+	}
+
+	return &domain.Category{
+		ID:          row.ID,
+		Slug:        domain.ProductCategory(row.Slug),
+		Name:        row.Name,
+		Description: description,
+		Icon:        icon,
+		ParentID:    row.ParentID,
+		SortOrder:   int(row.SortOrder),
+		CreatedAt:   row.CreatedAt.Time,
+		UpdatedAt:   row.UpdatedAt.Time,
+	}
+}
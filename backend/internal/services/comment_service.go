@@ -0,0 +1,642 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"ratemysoft-backend/internal/domain"
+	"ratemysoft-backend/internal/models/sqlc"
+	"ratemysoft-backend/internal/repository"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// CommentService handles threaded comments on reviews, including vendors' official responses.
+// Comments go through the same moderation as reviews: the same initial status, flag threshold
+// and moderation log.
+type CommentService struct {
+	store      repository.Store
+	authz      *Authorizer
+	moderation ModerationConfig
+}
+
+func NewCommentService(store repository.Store, authz *Authorizer, moderation ModerationConfig) *CommentService {
+	if moderation.FlagThreshold < 1 {
+		moderation.FlagThreshold = 1
+	}
+	return &CommentService{
+		store:      store,
+		authz:      authz,
+		moderation: moderation,
+	}
+}
+
+// maxCommentLength bounds a comment body in characters
+const maxCommentLength = 5000
+
+type CreateCommentRequest struct {
+	ReviewID string
+	ParentID string // optional; the comment being replied to, on the same review
+	Body     string
+	Official bool // respond on behalf of the product's company; top-level only
+}
+
+// CreateComment adds a comment or reply to a published review. An official response
+// requires membership in the product's company, and a review gets at most one.
+func (s *CommentService) CreateComment(ctx context.Context, actor domain.Actor, req CreateCommentRequest) (*domain.ReviewComment, error) {
+	reviewID, err := parseID("review_id", req.ReviewID)
+	if err != nil {
+		return nil, err
+	}
+
+	var parentID *uuid.UUID
+	if req.ParentID != "" {
+		id, err := parseID("parent_id", req.ParentID)
+		if err != nil {
+			return nil, err
+		}
+		parentID = &id
+	}
+
+	body, err := commentBody(req.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Official && parentID != nil {
+		return nil, domain.InvalidField("official_reply", "official", "official responses must be top-level comments")
+	}
+
+	var comment *domain.ReviewComment
+	err = s.store.InTx(ctx, func(q repository.Queries) error {
+		// Lock the review so two official responses cannot be posted concurrently
+		review, err := q.GetReviewForUpdate(ctx, reviewID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return domain.ErrReviewNotFound
+			}
+			return fmt.Errorf("failed to get review: %w", err)
+		}
+		// Hidden reviews are not open for discussion
+		if domain.ReviewStatus(review.Status) != domain.ReviewPublished {
+			return domain.ErrReviewNotFound
+		}
+
+		if parentID != nil {
+			parent, err := q.GetReviewComment(ctx, *parentID)
+			if err != nil {
+				if errors.Is(err, pgx.ErrNoRows) {
+					return domain.ErrCommentNotFound
+				}
+				return fmt.Errorf("failed to get parent comment: %w", err)
+			}
+			if parent.ReviewID != reviewID {
+				return domain.InvalidField("parent_mismatch", "parent_id", "must be a comment on the same review")
+			}
+			if domain.ReviewStatus(parent.Status) != domain.ReviewPublished {
+				return domain.ErrCommentNotFound
+			}
+		}
+
+		user, err := q.GetUser(ctx, actor.UserID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return domain.ErrUserNotFound
+			}
+			return fmt.Errorf("failed to get user: %w", err)
+		}
+
+		var company *sqlc.Company
+		if req.Official {
+			company, err = s.requireOfficialResponder(ctx, q, actor, review)
+			if err != nil {
+				return err
+			}
+		}
+
+		now := pgtype.Timestamptz{
+			Time:  time.Now().UTC(),
+			Valid: true,
+		}
+		params := sqlc.CreateReviewCommentParams{
+			ID:        uuid.New(),
+			ReviewID:  reviewID,
+			ParentID:  parentID,
+			UserID:    actor.UserID,
+			Body:      body,
+			Status:    string(s.moderation.initialStatus()),
+			Official:  req.Official,
+			FlagCount: 0,
+			Edited:    false,
+			CreatedAt: now,
+			UpdatedAt: now,
+		}
+		if company != nil {
+			params.CompanyID = &company.ID
+		}
+
+		row, err := q.CreateReviewComment(ctx, params)
+		if err != nil {
+			return fmt.Errorf("failed to create comment: %w", err)
+		}
+
+		comment = SQLCToDomainReviewComment(row)
+		comment.UserHandle = user.Handle
+		if company != nil {
+			comment.CompanyName = company.Name
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return comment, nil
+}
+
+// requireOfficialResponder checks that the actor belongs to the reviewed product's company
+// and that the review has no official response yet; it returns the company.
+// Admins get no exemption here: an official response speaks for the vendor.
+func (s *CommentService) requireOfficialResponder(ctx context.Context, q repository.Queries, actor domain.Actor, review sqlc.Review) (*sqlc.Company, error) {
+	product, err := q.GetProduct(ctx, review.ProductID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrProductNotFound
+		}
+		return nil, fmt.Errorf("failed to get product: %w", err)
+	}
+
+	_, err = q.GetCompanyMember(ctx, sqlc.GetCompanyMemberParams{
+		CompanyID: product.CompanyID,
+		UserID:    actor.UserID,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.Forbidden("not_company_member", "only members of the product's company can post an official response")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get company membership: %w", err)
+	}
+
+	_, err = q.GetOfficialReviewComment(ctx, review.ID)
+	if err == nil {
+		return nil, domain.Conflict("official_response_exists", "this review already has an official response")
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("failed to check official response: %w", err)
+	}
+
+	company, err := q.GetCompany(ctx, product.CompanyID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrCompanyNotFound
+		}
+		return nil, fmt.Errorf("failed to get company: %w", err)
+	}
+	return &company, nil
+}
+
+// ListComments returns the discussion under a published review as a tree: top-level comments
+// oldest first with the official response pinned on top, each followed by its replies.
+// Deleted, pending and rejected comments only remain as empty placeholders while
+// visible replies hang beneath them.
+func (s *CommentService) ListComments(ctx context.Context, reviewID string) ([]*domain.ReviewComment, error) {
+	parsedID, err := parseID("review_id", reviewID)
+	if err != nil {
+		return nil, err
+	}
+
+	review, err := s.store.GetReview(ctx, parsedID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrReviewNotFound
+		}
+		return nil, fmt.Errorf("failed to get review: %w", err)
+	}
+	if domain.ReviewStatus(review.Status) != domain.ReviewPublished {
+		return nil, domain.ErrReviewNotFound
+	}
+
+	rows, err := s.store.ListReviewComments(ctx, parsedID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list comments: %w", err)
+	}
+
+	comments := make([]*domain.ReviewComment, 0, len(rows))
+	byID := make(map[uuid.UUID]*domain.ReviewComment, len(rows))
+	for _, row := range rows {
+		comment := SQLCToDomainReviewComment(row.ReviewComment)
+		comment.UserHandle = row.UserHandle
+		if row.CompanyName != nil {
+			comment.CompanyName = *row.CompanyName
+		}
+		comments = append(comments, comment)
+		byID[comment.ID] = comment
+	}
+
+	// Rows come oldest first, so every reply list is in order too
+	var roots []*domain.ReviewComment
+	for _, comment := range comments {
+		if comment.ParentID != nil {
+			if parent, ok := byID[*comment.ParentID]; ok {
+				parent.Replies = append(parent.Replies, comment)
+				continue
+			}
+		}
+		roots = append(roots, comment)
+	}
+
+	thread := pruneCommentThread(roots)
+	for i, comment := range thread {
+		if comment.Official && isVisibleComment(comment) {
+			copy(thread[1:i+1], thread[:i])
+			thread[0] = comment
+			break
+		}
+	}
+	return thread, nil
+}
+
+func isVisibleComment(comment *domain.ReviewComment) bool {
+	return comment.Status == domain.ReviewPublished && comment.DeletedAt == nil
+}
+
+// pruneCommentThread drops hidden comments without visible replies and blanks
+// the remaining hidden ones, keeping only their place in the thread
+func pruneCommentThread(comments []*domain.ReviewComment) []*domain.ReviewComment {
+	kept := make([]*domain.ReviewComment, 0, len(comments))
+	for _, comment := range comments {
+		comment.Replies = pruneCommentThread(comment.Replies)
+		if isVisibleComment(comment) {
+			kept = append(kept, comment)
+			continue
+		}
+		if len(comment.Replies) == 0 {
+			continue
+		}
+		comment.Body = ""
+		comment.UserHandle = ""
+		comment.CompanyName = ""
+		kept = append(kept, comment)
+	}
+	return kept
+}
+
+// UpdateComment replaces the body of the actor's own comment and marks it edited
+func (s *CommentService) UpdateComment(ctx context.Context, actor domain.Actor, commentID, body string) (*domain.ReviewComment, error) {
+	parsedID, err := parseID("comment_id", commentID)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err = commentBody(body)
+	if err != nil {
+		return nil, err
+	}
+
+	var comment sqlc.ReviewComment
+	err = s.store.InTx(ctx, func(q repository.Queries) error {
+		existing, err := q.GetReviewCommentForUpdate(ctx, parsedID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return domain.ErrCommentNotFound
+			}
+			return fmt.Errorf("failed to get comment: %w", err)
+		}
+
+		if existing.UserID != actor.UserID {
+			return domain.Forbidden("not_comment_author", "you can only edit your own comments")
+		}
+
+		comment, err = q.UpdateReviewCommentBody(ctx, sqlc.UpdateReviewCommentBodyParams{
+			ID:   parsedID,
+			Body: body,
+			UpdatedAt: pgtype.Timestamptz{
+				Time:  time.Now().UTC(),
+				Valid: true,
+			},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to update comment: %w", err)
+		}
+
+		// When comments need approval, an edited published comment has to be approved again
+		if body != existing.Body && s.moderation.RequireApproval && existing.Status == string(domain.ReviewPublished) {
+			err = q.UpdateReviewCommentStatus(ctx, sqlc.UpdateReviewCommentStatusParams{
+				ID:     parsedID,
+				Status: string(domain.ReviewPending),
+			})
+			if err != nil {
+				return fmt.Errorf("failed to update comment status: %w", err)
+			}
+			comment.Status = string(domain.ReviewPending)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return SQLCToDomainReviewComment(comment), nil
+}
+
+// DeleteComment soft deletes the actor's own comment; its replies stay in the thread
+func (s *CommentService) DeleteComment(ctx context.Context, actor domain.Actor, commentID string) error {
+	parsedID, err := parseID("comment_id", commentID)
+	if err != nil {
+		return err
+	}
+
+	existing, err := s.store.GetReviewComment(ctx, parsedID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.ErrCommentNotFound
+		}
+		return fmt.Errorf("failed to get comment: %w", err)
+	}
+
+	if existing.UserID != actor.UserID {
+		return domain.Forbidden("not_comment_author", "you can only delete your own comments")
+	}
+
+	if err := s.store.SoftDeleteReviewComment(ctx, parsedID); err != nil {
+		return fmt.Errorf("failed to delete comment: %w", err)
+	}
+	return nil
+}
+
+// FlagComment records the user's flag on a comment; flagging again only updates the reason
+func (s *CommentService) FlagComment(ctx context.Context, commentID, userID string, reason domain.FlagReason, note string) error {
+	parsedCommentID, err := parseID("comment_id", commentID)
+	if err != nil {
+		return err
+	}
+
+	parsedUserID, err := parseID("user_id", userID)
+	if err != nil {
+		return err
+	}
+
+	var notePtr *string
+	if note != "" {
+		notePtr = &note
+	}
+
+	return s.store.InTx(ctx, func(q repository.Queries) error {
+		// Lock the comment row so concurrent flags on it serialize
+		comment, err := q.GetReviewCommentForUpdate(ctx, parsedCommentID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return domain.ErrCommentNotFound
+			}
+			return fmt.Errorf("failed to get comment: %w", err)
+		}
+
+		if comment.UserID == parsedUserID {
+			return domain.Forbidden("own_comment", "you cannot flag your own comment")
+		}
+
+		alreadyFlagged := true
+		_, err = q.GetReviewCommentFlag(ctx, sqlc.GetReviewCommentFlagParams{
+			CommentID: parsedCommentID,
+			UserID:    parsedUserID,
+		})
+		if errors.Is(err, pgx.ErrNoRows) {
+			alreadyFlagged = false
+		} else if err != nil {
+			return fmt.Errorf("failed to get existing flag: %w", err)
+		}
+
+		now := pgtype.Timestamptz{
+			Time:  time.Now().UTC(),
+			Valid: true,
+		}
+		_, err = q.UpsertReviewCommentFlag(ctx, sqlc.UpsertReviewCommentFlagParams{
+			CommentID: parsedCommentID,
+			UserID:    parsedUserID,
+			Reason:    string(reason),
+			Note:      notePtr,
+			CreatedAt: now,
+			UpdatedAt: now,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to record flag: %w", err)
+		}
+
+		if alreadyFlagged {
+			return nil
+		}
+
+		err = q.AdjustReviewCommentFlagCount(ctx, sqlc.AdjustReviewCommentFlagCountParams{
+			FlagDelta: 1,
+			ID:        parsedCommentID,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to update flag count: %w", err)
+		}
+		return nil
+	})
+}
+
+// UnflagComment retracts the user's flag on a comment; retracting a missing flag is a no-op
+func (s *CommentService) UnflagComment(ctx context.Context, commentID, userID string) error {
+	parsedCommentID, err := parseID("comment_id", commentID)
+	if err != nil {
+		return err
+	}
+
+	parsedUserID, err := parseID("user_id", userID)
+	if err != nil {
+		return err
+	}
+
+	return s.store.InTx(ctx, func(q repository.Queries) error {
+		_, err := q.GetReviewCommentForUpdate(ctx, parsedCommentID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return domain.ErrCommentNotFound
+			}
+			return fmt.Errorf("failed to get comment: %w", err)
+		}
+
+		deleted, err := q.DeleteReviewCommentFlag(ctx, sqlc.DeleteReviewCommentFlagParams{
+			CommentID: parsedCommentID,
+			UserID:    parsedUserID,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to retract flag: %w", err)
+		}
+
+		if deleted == 0 {
+			return nil
+		}
+
+		err = q.AdjustReviewCommentFlagCount(ctx, sqlc.AdjustReviewCommentFlagCountParams{
+			FlagDelta: -1,
+			ID:        parsedCommentID,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to update flag count: %w", err)
+		}
+		return nil
+	})
+}
+
+// ListPendingComments retrieves comments awaiting approval, oldest first
+func (s *CommentService) ListPendingComments(ctx context.Context, page domain.PageRequest) ([]*domain.ReviewComment, domain.PageCursors, error) {
+	rows, cursors, err := s.store.ListCommentsByStatusPage(ctx, string(domain.ReviewPending), page)
+	if err != nil {
+		return nil, domain.PageCursors{}, listingError("list pending comments", err)
+	}
+
+	comments := make([]*domain.ReviewComment, 0, len(rows))
+	for _, row := range rows {
+		comment := SQLCToDomainReviewComment(row.ReviewComment)
+		comment.UserHandle = row.UserHandle
+		comments = append(comments, comment)
+	}
+	return comments, cursors, nil
+}
+
+// CountPendingComments returns the number of comments awaiting approval
+func (s *CommentService) CountPendingComments(ctx context.Context) (int64, error) {
+	count, err := s.store.CountReviewCommentsByStatus(ctx, string(domain.ReviewPending))
+	if err != nil {
+		return 0, fmt.Errorf("failed to count pending comments: %w", err)
+	}
+	return count, nil
+}
+
+// ListFlaggedComments retrieves non-rejected comments at or above the flag threshold, most flagged first
+func (s *CommentService) ListFlaggedComments(ctx context.Context, page domain.PageRequest) ([]*domain.ReviewComment, domain.PageCursors, error) {
+	rows, cursors, err := s.store.ListFlaggedCommentsPage(ctx, s.moderation.FlagThreshold, page)
+	if err != nil {
+		return nil, domain.PageCursors{}, listingError("list flagged comments", err)
+	}
+
+	comments := make([]*domain.ReviewComment, 0, len(rows))
+	for _, row := range rows {
+		comment := SQLCToDomainReviewComment(row.ReviewComment)
+		comment.UserHandle = row.UserHandle
+		comments = append(comments, comment)
+	}
+	return comments, cursors, nil
+}
+
+// CountFlaggedComments returns the number of comments in the flagged queue
+func (s *CommentService) CountFlaggedComments(ctx context.Context) (int64, error) {
+	count, err := s.store.CountFlaggedReviewComments(ctx, s.moderation.FlagThreshold)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count flagged comments: %w", err)
+	}
+	return count, nil
+}
+
+// ApproveComment publishes a pending, rejected or flagged comment and clears its flags
+func (s *CommentService) ApproveComment(ctx context.Context, commentID, moderatorID, reason string) (*domain.ReviewComment, error) {
+	return s.moderateComment(ctx, commentID, moderatorID, domain.ReviewPublished, reason)
+}
+
+// RejectComment hides a comment from its thread; a reason is required
+func (s *CommentService) RejectComment(ctx context.Context, commentID, moderatorID, reason string) (*domain.ReviewComment, error) {
+	if err := requireRejectionReason("comment", reason); err != nil {
+		return nil, err
+	}
+	return s.moderateComment(ctx, commentID, moderatorID, domain.ReviewRejected, reason)
+}
+
+// moderateComment moves a comment to the target status and records the decision in its review's moderation log
+func (s *CommentService) moderateComment(ctx context.Context, commentID, moderatorID string, to domain.ReviewStatus, reason string) (*domain.ReviewComment, error) {
+	parsedCommentID, err := parseID("comment_id", commentID)
+	if err != nil {
+		return nil, err
+	}
+
+	parsedModeratorID, err := parseID("moderator_id", moderatorID)
+	if err != nil {
+		return nil, err
+	}
+
+	var comment sqlc.ReviewComment
+	err = s.store.InTx(ctx, func(q repository.Queries) error {
+		comment, err = q.GetReviewCommentForUpdate(ctx, parsedCommentID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return domain.ErrCommentNotFound
+			}
+			return fmt.Errorf("failed to get comment: %w", err)
+		}
+
+		from := domain.ReviewStatus(comment.Status)
+		if err := checkModerationTransition("comment", from, to, comment.FlagCount); err != nil {
+			return err
+		}
+
+		if from != to {
+			err = q.UpdateReviewCommentStatus(ctx, sqlc.UpdateReviewCommentStatusParams{
+				ID:     parsedCommentID,
+				Status: string(to),
+			})
+			if err != nil {
+				return fmt.Errorf("failed to update comment status: %w", err)
+			}
+			comment.Status = string(to)
+		}
+
+		if to == domain.ReviewPublished && comment.FlagCount > 0 {
+			if err := q.DeleteReviewCommentFlags(ctx, parsedCommentID); err != nil {
+				return fmt.Errorf("failed to resolve comment flags: %w", err)
+			}
+			if err := q.ClearReviewCommentFlags(ctx, parsedCommentID); err != nil {
+				return fmt.Errorf("failed to clear comment flags: %w", err)
+			}
+			comment.FlagCount = 0
+		}
+
+		return recordModeration(ctx, q, comment.ReviewID, &comment.ID, parsedModeratorID, from, to, reason)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return SQLCToDomainReviewComment(comment), nil
+}
+
+// commentBody trims a comment body and checks its length
+func commentBody(body string) (string, error) {
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return "", domain.InvalidField("comment_body_required", "body", "is required")
+	}
+	if len([]rune(body)) > maxCommentLength {
+		return "", domain.InvalidField("comment_too_long", "body", fmt.Sprintf("must be at most %d characters", maxCommentLength))
+	}
+	return body, nil
+}
+
+// SQLCToDomainReviewComment converts a SQLC ReviewComment to a domain ReviewComment
+func SQLCToDomainReviewComment(c sqlc.ReviewComment) *domain.ReviewComment {
+	var deletedAt *time.Time
+	if c.DeletedAt.Valid {
+		deletedAt = &c.DeletedAt.Time
+	}
+
+	return &domain.ReviewComment{
+		ID:        c.ID,
+		ReviewID:  c.ReviewID,
+		ParentID:  c.ParentID,
+		UserID:    c.UserID,
+		Body:      c.Body,
+		Status:    domain.ReviewStatus(c.Status),
+		Official:  c.Official,
+		CompanyID: c.CompanyID,
+		FlagCount: int(c.FlagCount),
+		Edited:    c.Edited,
+		CreatedAt: c.CreatedAt.Time,
+		UpdatedAt: c.UpdatedAt.Time,
+		DeletedAt: deletedAt,
+	}
+}
@@ -4,10 +4,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/url"
+	"strings"
 	"time"
 
 	"ratemysoft-backend/internal/domain"
 	"ratemysoft-backend/internal/models/sqlc"
+	"ratemysoft-backend/internal/repository"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
@@ -16,12 +19,14 @@ import (
 
 // CompanyService handles company-related business logic
 type CompanyService struct {
-	queries *sqlc.Queries
+	store repository.Store
+	authz *Authorizer
 }
 
-func NewCompanyService(queries *sqlc.Queries) *CompanyService {
+func NewCompanyService(store repository.Store, authz *Authorizer) *CompanyService {
 	return &CompanyService{
-		queries: queries,
+		store: store,
+		authz: authz,
 	}
 }
 
@@ -39,18 +44,18 @@ type UpdateCompanyRequest struct {
 	LogoURL string
 }
 
-// CreateCompany creates a new company
-func (s *CompanyService) CreateCompany(ctx context.Context, req CreateCompanyRequest) (*domain.Company, error) {
+// CreateCompany creates a new company and makes the actor its owner
+func (s *CompanyService) CreateCompany(ctx context.Context, actor domain.Actor, req CreateCompanyRequest) (*domain.Company, error) {
 	// Validate slug format
 	slug, err := domain.NewSlug(req.Slug)
 	if err != nil {
-		return nil, fmt.Errorf("invalid slug format: %w", err)
+		return nil, invalidSlug(err)
 	}
 
 	// Check if company with this slug already exists
-	_, err = s.queries.GetCompanyBySlug(ctx, req.Slug)
+	_, err = s.store.GetCompanyBySlug(ctx, req.Slug)
 	if err == nil {
-		return nil, fmt.Errorf("company with slug '%s' already exists", req.Slug)
+		return nil, domain.Conflict("slug_taken", fmt.Sprintf("company with slug '%s' already exists", req.Slug))
 	}
 	if !errors.Is(err, pgx.ErrNoRows) {
 		return nil, fmt.Errorf("failed to check if company exists: %w", err)
@@ -73,18 +78,37 @@ func (s *CompanyService) CreateCompany(ctx context.Context, req CreateCompanyReq
 		logoURL = &req.LogoURL
 	}
 
-	// Create company in database
-	company, err := s.queries.CreateCompany(ctx, sqlc.CreateCompanyParams{
-		ID:        companyID,
-		Name:      req.Name,
-		Website:   website,
-		Slug:      string(slug),
-		LogoUrl:   logoURL,
-		CreatedAt: now,
-		UpdatedAt: now,
+	// Create company and owner membership together
+	var company sqlc.Company
+	err = s.store.InTx(ctx, func(q repository.Queries) error {
+		var err error
+		company, err = q.CreateCompany(ctx, sqlc.CreateCompanyParams{
+			ID:        companyID,
+			Name:      req.Name,
+			Website:   website,
+			Slug:      string(slug),
+			LogoUrl:   logoURL,
+			CreatedAt: now,
+			UpdatedAt: now,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create company: %w", err)
+		}
+
+		_, err = q.UpsertCompanyMember(ctx, sqlc.UpsertCompanyMemberParams{
+			CompanyID: companyID,
+			UserID:    actor.UserID,
+			Role:      string(domain.CompanyOwner),
+			CreatedAt: now,
+			UpdatedAt: now,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to add company owner: %w", err)
+		}
+		return recordAudit(ctx, q, actor.UserID, domain.AuditCreate, domain.AuditEntityCompany, companyID, nil, company)
 	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to create company: %w", err)
+		return nil, err
 	}
 
 	// Convert SQLC company to domain company
@@ -93,15 +117,15 @@ func (s *CompanyService) CreateCompany(ctx context.Context, req CreateCompanyReq
 
 // GetCompanyByID retrieves a company by its ID
 func (s *CompanyService) GetCompanyByID(ctx context.Context, companyID string) (*domain.Company, error) {
-	parsedID, err := uuid.Parse(companyID)
+	parsedID, err := parseID("company_id", companyID)
 	if err != nil {
-		return nil, fmt.Errorf("invalid company ID format: %w", err)
+		return nil, err
 	}
 
-	company, err := s.queries.GetCompany(ctx, parsedID)
+	company, err := s.store.GetCompany(ctx, parsedID)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return nil, fmt.Errorf("company not found")
+			return nil, domain.ErrCompanyNotFound
 		}
 		return nil, fmt.Errorf("failed to get company: %w", err)
 	}
@@ -114,13 +138,13 @@ func (s *CompanyService) GetCompanyBySlug(ctx context.Context, slug string) (*do
 	// Validate slug format
 	_, err := domain.NewSlug(slug)
 	if err != nil {
-		return nil, fmt.Errorf("invalid slug format: %w", err)
+		return nil, invalidSlug(err)
 	}
 
-	company, err := s.queries.GetCompanyBySlug(ctx, slug)
+	company, err := s.store.GetCompanyBySlug(ctx, slug)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return nil, fmt.Errorf("company not found")
+			return nil, domain.ErrCompanyNotFound
 		}
 		return nil, fmt.Errorf("failed to get company: %w", err)
 	}
@@ -128,14 +152,11 @@ func (s *CompanyService) GetCompanyBySlug(ctx context.Context, slug string) (*do
 	return SQLCToDomainCompany(company)
 }
 
-// ListCompanies retrieves a paginated list of companies
-func (s *CompanyService) ListCompanies(ctx context.Context, limit, offset int32) ([]*domain.Company, error) {
-	companies, err := s.queries.ListCompanies(ctx, sqlc.ListCompaniesParams{
-		Limit:  limit,
-		Offset: offset,
-	})
+// ListCompanies retrieves a page of companies, newest first
+func (s *CompanyService) ListCompanies(ctx context.Context, page domain.PageRequest) ([]*domain.Company, domain.PageCursors, error) {
+	companies, cursors, err := s.store.ListCompaniesPage(ctx, page)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list companies: %w", err)
+		return nil, domain.PageCursors{}, listingError("list companies", err)
 	}
 
 	// Convert to domain companies
@@ -143,26 +164,19 @@ func (s *CompanyService) ListCompanies(ctx context.Context, limit, offset int32)
 	for _, company := range companies {
 		domainCompany, err := SQLCToDomainCompany(company)
 		if err != nil {
-			return nil, fmt.Errorf("failed to convert company: %w", err)
+			return nil, domain.PageCursors{}, fmt.Errorf("failed to convert company: %w", err)
 		}
 		domainCompanies = append(domainCompanies, domainCompany)
 	}
 
-	return domainCompanies, nil
+	return domainCompanies, cursors, nil
 }
 
-// SearchCompanies searches for companies by name or slug
-func (s *CompanyService) SearchCompanies(ctx context.Context, query string, limit, offset int32) ([]*domain.Company, error) {
-	// Add wildcards for ILIKE search
-	searchQuery := "%" + query + "%"
-
-	companies, err := s.queries.SearchCompanies(ctx, sqlc.SearchCompaniesParams{
-		Name:   searchQuery,
-		Limit:  limit,
-		Offset: offset,
-	})
+// SearchCompanies retrieves a page of the companies whose name or slug contains query, by name
+func (s *CompanyService) SearchCompanies(ctx context.Context, query string, page domain.PageRequest) ([]*domain.Company, domain.PageCursors, error) {
+	companies, cursors, err := s.store.SearchCompaniesPage(ctx, query, page)
 	if err != nil {
-		return nil, fmt.Errorf("failed to search companies: %w", err)
+		return nil, domain.PageCursors{}, listingError("search companies", err)
 	}
 
 	// Convert to domain companies
@@ -170,41 +184,54 @@ func (s *CompanyService) SearchCompanies(ctx context.Context, query string, limi
 	for _, company := range companies {
 		domainCompany, err := SQLCToDomainCompany(company)
 		if err != nil {
-			return nil, fmt.Errorf("failed to convert company: %w", err)
+			return nil, domain.PageCursors{}, fmt.Errorf("failed to convert company: %w", err)
 		}
 		domainCompanies = append(domainCompanies, domainCompany)
 	}
 
-	return domainCompanies, nil
+	return domainCompanies, cursors, nil
+}
+
+// CountSearchCompanies returns the number of companies SearchCompanies pages through
+func (s *CompanyService) CountSearchCompanies(ctx context.Context, query string) (int64, error) {
+	count, err := s.store.CountCompaniesMatching(ctx, query)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count companies: %w", err)
+	}
+	return count, nil
 }
 
-// UpdateCompany updates an existing company
-func (s *CompanyService) UpdateCompany(ctx context.Context, companyID string, req UpdateCompanyRequest) (*domain.Company, error) {
-	parsedID, err := uuid.Parse(companyID)
+// UpdateCompany updates an existing company (company editors and admins only)
+func (s *CompanyService) UpdateCompany(ctx context.Context, actor domain.Actor, companyID string, req UpdateCompanyRequest) (*domain.Company, error) {
+	parsedID, err := parseID("company_id", companyID)
 	if err != nil {
-		return nil, fmt.Errorf("invalid company ID format: %w", err)
+		return nil, err
 	}
 
 	// Validate slug format
 	slug, err := domain.NewSlug(req.Slug)
 	if err != nil {
-		return nil, fmt.Errorf("invalid slug format: %w", err)
+		return nil, invalidSlug(err)
 	}
 
 	// Check if company exists
-	existingCompany, err := s.queries.GetCompany(ctx, parsedID)
+	existingCompany, err := s.store.GetCompany(ctx, parsedID)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return nil, fmt.Errorf("company not found")
+			return nil, domain.ErrCompanyNotFound
 		}
 		return nil, fmt.Errorf("failed to get company: %w", err)
 	}
 
+	if err := s.authz.RequireCompanyRole(ctx, actor, parsedID, domain.CompanyEditor); err != nil {
+		return nil, err
+	}
+
 	// If slug is changing, check if new slug is already taken
 	if existingCompany.Slug != req.Slug {
-		_, err = s.queries.GetCompanyBySlug(ctx, req.Slug)
+		_, err = s.store.GetCompanyBySlug(ctx, req.Slug)
 		if err == nil {
-			return nil, fmt.Errorf("company with slug '%s' already exists", req.Slug)
+			return nil, domain.Conflict("slug_taken", fmt.Sprintf("company with slug '%s' already exists", req.Slug))
 		}
 		if !errors.Is(err, pgx.ErrNoRows) {
 			return nil, fmt.Errorf("failed to check if slug exists: %w", err)
@@ -228,55 +255,603 @@ func (s *CompanyService) UpdateCompany(ctx context.Context, companyID string, re
 	}
 
 	// Update company in database
-	company, err := s.queries.UpdateCompany(ctx, sqlc.UpdateCompanyParams{
-		ID:        parsedID,
-		Name:      req.Name,
-		Website:   website,
-		Slug:      string(slug),
-		LogoUrl:   logoURL,
-		UpdatedAt: now,
+	var company sqlc.Company
+	err = s.store.InTx(ctx, func(q repository.Queries) error {
+		var err error
+		company, err = q.UpdateCompany(ctx, sqlc.UpdateCompanyParams{
+			ID:        parsedID,
+			Name:      req.Name,
+			Website:   website,
+			Slug:      string(slug),
+			LogoUrl:   logoURL,
+			UpdatedAt: now,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to update company: %w", err)
+		}
+		return recordAudit(ctx, q, actor.UserID, domain.AuditUpdate, domain.AuditEntityCompany, parsedID, existingCompany, company)
 	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to update company: %w", err)
+		return nil, err
 	}
 
 	return SQLCToDomainCompany(company)
 }
 
-// DeleteCompany soft deletes a company
-func (s *CompanyService) DeleteCompany(ctx context.Context, companyID string) error {
-	parsedID, err := uuid.Parse(companyID)
+// DeleteCompany soft deletes a company (company owners and admins only)
+func (s *CompanyService) DeleteCompany(ctx context.Context, actor domain.Actor, companyID string) error {
+	parsedID, err := parseID("company_id", companyID)
 	if err != nil {
-		return fmt.Errorf("invalid company ID format: %w", err)
+		return err
 	}
 
 	// Check if company exists
-	_, err = s.queries.GetCompany(ctx, parsedID)
+	existingCompany, err := s.store.GetCompany(ctx, parsedID)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return fmt.Errorf("company not found")
+			return domain.ErrCompanyNotFound
 		}
 		return fmt.Errorf("failed to get company: %w", err)
 	}
 
-	// Soft delete the company
-	err = s.queries.SoftDeleteCompany(ctx, parsedID)
-	if err != nil {
-		return fmt.Errorf("failed to delete company: %w", err)
+	if err := s.authz.RequireCompanyRole(ctx, actor, parsedID, domain.CompanyOwner); err != nil {
+		return err
 	}
 
-	return nil
+	// Soft delete the company
+	return s.store.InTx(ctx, func(q repository.Queries) error {
+		err := q.SoftDeleteCompany(ctx, parsedID)
+		if err != nil {
+			return fmt.Errorf("failed to delete company: %w", err)
+		}
+		return recordAudit(ctx, q, actor.UserID, domain.AuditDelete, domain.AuditEntityCompany, parsedID, existingCompany, nil)
+	})
 }
 
 // CountCompanies returns the total number of companies
 func (s *CompanyService) CountCompanies(ctx context.Context) (int64, error) {
-	count, err := s.queries.CountCompanies(ctx)
+	count, err := s.store.CountCompanies(ctx)
 	if err != nil {
 		return 0, fmt.Errorf("failed to count companies: %w", err)
 	}
 	return count, nil
 }
 
+// ListCompanyMembers lists the members of a company (company editors and admins only)
+func (s *CompanyService) ListCompanyMembers(ctx context.Context, actor domain.Actor, companyID string) ([]*domain.CompanyMember, error) {
+	parsedID, err := s.getCompanyID(ctx, companyID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.authz.RequireCompanyRole(ctx, actor, parsedID, domain.CompanyEditor); err != nil {
+		return nil, err
+	}
+
+	rows, err := s.store.ListCompanyMembers(ctx, parsedID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list company members: %w", err)
+	}
+
+	members := make([]*domain.CompanyMember, 0, len(rows))
+	for _, row := range rows {
+		members = append(members, &domain.CompanyMember{
+			CompanyID:  row.CompanyID,
+			UserID:     row.UserID,
+			UserHandle: row.UserHandle,
+			Role:       domain.CompanyRole(row.Role),
+			CreatedAt:  row.CreatedAt.Time,
+			UpdatedAt:  row.UpdatedAt.Time,
+		})
+	}
+	return members, nil
+}
+
+// SetCompanyMember adds a user (by handle) to a company or changes their role (company owners and admins only)
+func (s *CompanyService) SetCompanyMember(ctx context.Context, actor domain.Actor, companyID, handle string, role domain.CompanyRole) (*domain.CompanyMember, error) {
+	parsedID, err := s.getCompanyID(ctx, companyID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.authz.RequireCompanyRole(ctx, actor, parsedID, domain.CompanyOwner); err != nil {
+		return nil, err
+	}
+
+	user, err := s.store.GetUserByHandle(ctx, handle)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	now := pgtype.Timestamptz{
+		Time:  time.Now().UTC(),
+		Valid: true,
+	}
+
+	var member sqlc.CompanyMember
+	err = s.store.InTx(ctx, func(q repository.Queries) error {
+		if err := lockCompany(ctx, q, parsedID); err != nil {
+			return err
+		}
+		if role != domain.CompanyOwner {
+			if err := ensureAnotherOwner(ctx, q, parsedID, user.ID); err != nil {
+				return err
+			}
+		}
+
+		previousRole, err := memberRole(ctx, q, parsedID, user.ID)
+		if err != nil {
+			return err
+		}
+
+		member, err = q.UpsertCompanyMember(ctx, sqlc.UpsertCompanyMemberParams{
+			CompanyID: parsedID,
+			UserID:    user.ID,
+			Role:      string(role),
+			CreatedAt: now,
+			UpdatedAt: now,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to set company member: %w", err)
+		}
+		if previousRole != nil && *previousRole == member.Role {
+			return nil
+		}
+		return recordMemberAudit(ctx, q, actor.UserID, parsedID, user.ID, previousRole, &member.Role)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.CompanyMember{
+		CompanyID:  member.CompanyID,
+		UserID:     member.UserID,
+		UserHandle: user.Handle,
+		Role:       domain.CompanyRole(member.Role),
+		CreatedAt:  member.CreatedAt.Time,
+		UpdatedAt:  member.UpdatedAt.Time,
+	}, nil
+}
+
+// RemoveCompanyMember removes a user from a company. Owners and admins can remove anyone;
+// any member can remove themselves. The last owner cannot be removed.
+func (s *CompanyService) RemoveCompanyMember(ctx context.Context, actor domain.Actor, companyID, userID string) error {
+	parsedID, err := s.getCompanyID(ctx, companyID)
+	if err != nil {
+		return err
+	}
+
+	parsedUserID, err := parseID("user_id", userID)
+	if err != nil {
+		return err
+	}
+
+	if parsedUserID != actor.UserID {
+		if err := s.authz.RequireCompanyRole(ctx, actor, parsedID, domain.CompanyOwner); err != nil {
+			return err
+		}
+	}
+
+	return s.store.InTx(ctx, func(q repository.Queries) error {
+		if err := lockCompany(ctx, q, parsedID); err != nil {
+			return err
+		}
+		if err := ensureAnotherOwner(ctx, q, parsedID, parsedUserID); err != nil {
+			return err
+		}
+
+		previousRole, err := memberRole(ctx, q, parsedID, parsedUserID)
+		if err != nil {
+			return err
+		}
+		if previousRole == nil {
+			return domain.NotFound("company_member_not_found", "company member not found")
+		}
+
+		_, err = q.DeleteCompanyMember(ctx, sqlc.DeleteCompanyMemberParams{
+			CompanyID: parsedID,
+			UserID:    parsedUserID,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to remove company member: %w", err)
+		}
+		return recordMemberAudit(ctx, q, actor.UserID, parsedID, parsedUserID, previousRole, nil)
+	})
+}
+
+// ClaimCompany asks for ownership of an existing company. If the company has no owner yet and
+// the claimant's verified email domain matches the company website, the claim is approved
+// immediately; otherwise it waits for an admin.
+func (s *CompanyService) ClaimCompany(ctx context.Context, actor domain.Actor, companyID, evidence string) (*domain.CompanyClaim, error) {
+	parsedID, err := parseID("company_id", companyID)
+	if err != nil {
+		return nil, err
+	}
+
+	company, err := s.store.GetCompany(ctx, parsedID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrCompanyNotFound
+		}
+		return nil, fmt.Errorf("failed to get company: %w", err)
+	}
+
+	user, err := s.store.GetUser(ctx, actor.UserID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	if _, isMember, err := s.authz.CompanyRole(ctx, actor.UserID, parsedID); err != nil {
+		return nil, err
+	} else if isMember {
+		return nil, domain.Conflict("already_member", "you are already a member of this company")
+	}
+
+	_, err = s.store.GetPendingCompanyClaim(ctx, sqlc.GetPendingCompanyClaimParams{
+		CompanyID: parsedID,
+		UserID:    actor.UserID,
+	})
+	if err == nil {
+		return nil, domain.Conflict("claim_pending", "you already have a pending claim for this company")
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("failed to check existing claims: %w", err)
+	}
+
+	website := ""
+	if company.Website != nil {
+		website = *company.Website
+	}
+	// Anyone can register an address at the company's domain, so only a verified one proves control of it
+	domainVerified := user.EmailVerifiedAt.Valid && emailMatchesWebsite(user.Email, website)
+
+	var evidencePtr *string
+	if evidence != "" {
+		evidencePtr = &evidence
+	}
+
+	now := pgtype.Timestamptz{
+		Time:  time.Now().UTC(),
+		Valid: true,
+	}
+
+	var claim sqlc.CompanyClaim
+	err = s.store.InTx(ctx, func(q repository.Queries) error {
+		// The owner count decides the claim, so no owner may leave or join meanwhile
+		if err := lockCompany(ctx, q, parsedID); err != nil {
+			return err
+		}
+		owners, err := q.CountCompanyOwners(ctx, parsedID)
+		if err != nil {
+			return fmt.Errorf("failed to count company owners: %w", err)
+		}
+
+		method := domain.VerifyManual
+		if owners == 0 && domainVerified {
+			method = domain.VerifyEmailDomain
+		}
+
+		claim, err = q.CreateCompanyClaim(ctx, sqlc.CreateCompanyClaimParams{
+			ID:                 uuid.New(),
+			CompanyID:          parsedID,
+			UserID:             actor.UserID,
+			Status:             string(domain.ClaimPending),
+			VerificationMethod: string(method),
+			Evidence:           evidencePtr,
+			CreatedAt:          now,
+			UpdatedAt:          now,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create company claim: %w", err)
+		}
+
+		if method != domain.VerifyEmailDomain {
+			return nil
+		}
+
+		claim, err = approveClaim(ctx, q, claim.ID, nil, "verified by email domain", now)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	domainClaim := SQLCToDomainCompanyClaim(claim)
+	domainClaim.CompanyName = company.Name
+	domainClaim.UserHandle = user.Handle
+	domainClaim.UserEmail = user.Email
+	return domainClaim, nil
+}
+
+// ListCompanyClaims lists claims with the given status, oldest first (admin queue)
+func (s *CompanyService) ListCompanyClaims(ctx context.Context, status domain.ClaimStatus, page domain.PageRequest) ([]*domain.CompanyClaim, domain.PageCursors, error) {
+	rows, cursors, err := s.store.ListCompanyClaimsPage(ctx, string(status), page)
+	if err != nil {
+		return nil, domain.PageCursors{}, listingError("list company claims", err)
+	}
+
+	claims := make([]*domain.CompanyClaim, 0, len(rows))
+	for _, row := range rows {
+		claim := SQLCToDomainCompanyClaim(sqlc.CompanyClaim{
+			ID:                 row.ID,
+			CompanyID:          row.CompanyID,
+			UserID:             row.UserID,
+			Status:             row.Status,
+			VerificationMethod: row.VerificationMethod,
+			Evidence:           row.Evidence,
+			ReviewedBy:         row.ReviewedBy,
+			ReviewNote:         row.ReviewNote,
+			ReviewedAt:         row.ReviewedAt,
+			CreatedAt:          row.CreatedAt,
+			UpdatedAt:          row.UpdatedAt,
+		})
+		claim.CompanyName = row.CompanyName
+		claim.UserHandle = row.UserHandle
+		claim.UserEmail = row.UserEmail
+		claims = append(claims, claim)
+	}
+	return claims, cursors, nil
+}
+
+// CountCompanyClaims returns the number of claims with the given status
+func (s *CompanyService) CountCompanyClaims(ctx context.Context, status domain.ClaimStatus) (int64, error) {
+	count, err := s.store.CountCompanyClaimsByStatus(ctx, string(status))
+	if err != nil {
+		return 0, fmt.Errorf("failed to count company claims: %w", err)
+	}
+	return count, nil
+}
+
+// ApproveCompanyClaim approves a pending claim and makes the claimant an owner (admin only)
+func (s *CompanyService) ApproveCompanyClaim(ctx context.Context, actor domain.Actor, claimID, note string) (*domain.CompanyClaim, error) {
+	return s.resolveCompanyClaim(ctx, actor, claimID, domain.ClaimApproved, note)
+}
+
+// RejectCompanyClaim rejects a pending claim; a note explaining why is required (admin only)
+func (s *CompanyService) RejectCompanyClaim(ctx context.Context, actor domain.Actor, claimID, note string) (*domain.CompanyClaim, error) {
+	if strings.TrimSpace(note) == "" {
+		return nil, domain.InvalidField("note_required", "note", "a reason is required when rejecting a claim")
+	}
+	return s.resolveCompanyClaim(ctx, actor, claimID, domain.ClaimRejected, note)
+}
+
+func (s *CompanyService) resolveCompanyClaim(ctx context.Context, actor domain.Actor, claimID string, to domain.ClaimStatus, note string) (*domain.CompanyClaim, error) {
+	if !actor.IsAdmin() {
+		return nil, domain.Forbidden("admin_required", "only admins can resolve company claims")
+	}
+
+	parsedID, err := parseID("claim_id", claimID)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := s.store.GetCompanyClaim(ctx, parsedID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.NotFound("claim_not_found", "claim not found")
+		}
+		return nil, fmt.Errorf("failed to get company claim: %w", err)
+	}
+	if existing.Status != string(domain.ClaimPending) {
+		return nil, domain.Conflict("claim_resolved", fmt.Sprintf("claim is already %s", existing.Status))
+	}
+
+	now := pgtype.Timestamptz{
+		Time:  time.Now().UTC(),
+		Valid: true,
+	}
+	reviewer := actor.UserID
+
+	var claim sqlc.CompanyClaim
+	err = s.store.InTx(ctx, func(q repository.Queries) error {
+		var err error
+		if to == domain.ClaimApproved {
+			claim, err = approveClaim(ctx, q, parsedID, &reviewer, note, now)
+			return err
+		}
+
+		var notePtr *string
+		if note != "" {
+			notePtr = &note
+		}
+		claim, err = q.ResolveCompanyClaim(ctx, sqlc.ResolveCompanyClaimParams{
+			ID:         parsedID,
+			Status:     string(domain.ClaimRejected),
+			ReviewedBy: &reviewer,
+			ReviewNote: notePtr,
+			ReviewedAt: now,
+		})
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return domain.Conflict("claim_resolved", "claim is already resolved")
+			}
+			return fmt.Errorf("failed to reject company claim: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return SQLCToDomainCompanyClaim(claim), nil
+}
+
+// approveClaim marks a pending claim approved and grants the claimant ownership.
+// reviewer is nil when the claim verified itself.
+func approveClaim(ctx context.Context, q repository.Queries, claimID uuid.UUID, reviewer *uuid.UUID, note string, now pgtype.Timestamptz) (sqlc.CompanyClaim, error) {
+	var notePtr *string
+	if note != "" {
+		notePtr = &note
+	}
+
+	claim, err := q.ResolveCompanyClaim(ctx, sqlc.ResolveCompanyClaimParams{
+		ID:         claimID,
+		Status:     string(domain.ClaimApproved),
+		ReviewedBy: reviewer,
+		ReviewNote: notePtr,
+		ReviewedAt: now,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return sqlc.CompanyClaim{}, domain.Conflict("claim_resolved", "claim is already resolved")
+		}
+		return sqlc.CompanyClaim{}, fmt.Errorf("failed to approve company claim: %w", err)
+	}
+
+	previousRole, err := memberRole(ctx, q, claim.CompanyID, claim.UserID)
+	if err != nil {
+		return sqlc.CompanyClaim{}, err
+	}
+
+	member, err := q.UpsertCompanyMember(ctx, sqlc.UpsertCompanyMemberParams{
+		CompanyID: claim.CompanyID,
+		UserID:    claim.UserID,
+		Role:      string(domain.CompanyOwner),
+		CreatedAt: now,
+		UpdatedAt: now,
+	})
+	if err != nil {
+		return sqlc.CompanyClaim{}, fmt.Errorf("failed to add company owner: %w", err)
+	}
+
+	actorID := claim.UserID
+	if reviewer != nil {
+		actorID = *reviewer
+	}
+	if err := recordMemberAudit(ctx, q, actorID, claim.CompanyID, claim.UserID, previousRole, &member.Role); err != nil {
+		return sqlc.CompanyClaim{}, err
+	}
+	return claim, nil
+}
+
+// memberRole returns the user's role in the company, or nil if they are not a member
+func memberRole(ctx context.Context, q repository.CompanyRepository, companyID, userID uuid.UUID) (*string, error) {
+	member, err := q.GetCompanyMember(ctx, sqlc.GetCompanyMemberParams{
+		CompanyID: companyID,
+		UserID:    userID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get company member: %w", err)
+	}
+	return &member.Role, nil
+}
+
+// recordMemberAudit logs a membership change as an update of the company. The snapshots map
+// the member's user ID to their role, which is null while they are not a member.
+func recordMemberAudit(ctx context.Context, q repository.AuditRepository, actorID, companyID, userID uuid.UUID, before, after *string) error {
+	snapshot := func(role *string) map[string]map[string]*string {
+		return map[string]map[string]*string{"members": {userID.String(): role}}
+	}
+	return recordAudit(ctx, q, actorID, domain.AuditUpdate, domain.AuditEntityCompany, companyID, snapshot(before), snapshot(after))
+}
+
+// lockCompany locks the company row, so that concurrent membership changes cannot both
+// see an owner that the other one is removing
+func lockCompany(ctx context.Context, q repository.CompanyRepository, companyID uuid.UUID) error {
+	_, err := q.GetCompanyForUpdate(ctx, companyID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.ErrCompanyNotFound
+		}
+		return fmt.Errorf("failed to lock company: %w", err)
+	}
+	return nil
+}
+
+// ensureAnotherOwner fails if userID is currently the company's only owner.
+// The caller must hold the company lock (see lockCompany).
+func ensureAnotherOwner(ctx context.Context, q repository.CompanyRepository, companyID, userID uuid.UUID) error {
+	member, err := q.GetCompanyMember(ctx, sqlc.GetCompanyMemberParams{
+		CompanyID: companyID,
+		UserID:    userID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil
+		}
+		return fmt.Errorf("failed to get company member: %w", err)
+	}
+	if member.Role != string(domain.CompanyOwner) {
+		return nil
+	}
+
+	owners, err := q.CountCompanyOwners(ctx, companyID)
+	if err != nil {
+		return fmt.Errorf("failed to count company owners: %w", err)
+	}
+	if owners <= 1 {
+		return domain.Conflict("last_owner", "a company must keep at least one owner")
+	}
+	return nil
+}
+
+// getCompanyID parses a company ID and checks the company exists
+func (s *CompanyService) getCompanyID(ctx context.Context, companyID string) (uuid.UUID, error) {
+	parsedID, err := parseID("company_id", companyID)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	_, err = s.store.GetCompany(ctx, parsedID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return uuid.Nil, domain.ErrCompanyNotFound
+		}
+		return uuid.Nil, fmt.Errorf("failed to get company: %w", err)
+	}
+	return parsedID, nil
+}
+
+// emailMatchesWebsite reports whether the email's domain is the website's host or a subdomain of it
+func emailMatchesWebsite(email, website string) bool {
+	at := strings.LastIndex(email, "@")
+	if at < 0 || website == "" {
+		return false
+	}
+	emailDomain := strings.ToLower(email[at+1:])
+
+	parsed, err := url.Parse(website)
+	if err != nil || parsed.Hostname() == "" {
+		return false
+	}
+	host := strings.TrimPrefix(strings.ToLower(parsed.Hostname()), "www.")
+
+	return emailDomain == host || strings.HasSuffix(emailDomain, "."+host)
+}
+
+// SQLCToDomainCompanyClaim converts a SQLC CompanyClaim to a domain CompanyClaim
+func SQLCToDomainCompanyClaim(c sqlc.CompanyClaim) *domain.CompanyClaim {
+	claim := &domain.CompanyClaim{
+		ID:                 c.ID,
+		CompanyID:          c.CompanyID,
+		UserID:             c.UserID,
+		Status:             domain.ClaimStatus(c.Status),
+		VerificationMethod: domain.ClaimVerification(c.VerificationMethod),
+		ReviewedBy:         c.ReviewedBy,
+		CreatedAt:          c.CreatedAt.Time,
+		UpdatedAt:          c.UpdatedAt.Time,
+	}
+	if c.Evidence != nil {
+		claim.Evidence = *c.Evidence
+	}
+	if c.ReviewNote != nil {
+		claim.ReviewNote = *c.ReviewNote
+	}
+	if c.ReviewedAt.Valid {
+		claim.ReviewedAt = &c.ReviewedAt.Time
+	}
+	return claim
+}
+
 // SQLCToDomainCompany converts a SQLC Company to a domain Company
 func SQLCToDomainCompany(sqlcCompany sqlc.Company) (*domain.Company, error) {
 	slug, err := domain.NewSlug(sqlcCompany.Slug)
@@ -0,0 +1,190 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"ratemysoft-backend/internal/domain"
+	"ratemysoft-backend/internal/models/sqlc"
+	"ratemysoft-backend/internal/repository/memory"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func TestCompanyMembershipControlsAccess(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	svc := NewCompanyService(store, NewAuthorizer(store))
+	owner := seedUser(t, store, "owner", domain.RoleUser)
+	editor := seedUser(t, store, "editor", domain.RoleUser)
+	stranger := seedUser(t, store, "stranger", domain.RoleUser)
+	admin := seedUser(t, store, "admin", domain.RoleAdmin)
+
+	company, err := svc.CreateCompany(ctx, owner, CreateCompanyRequest{Name: "Acme", Slug: "acme"})
+	if err != nil {
+		t.Fatalf("CreateCompany: %v", err)
+	}
+	_, err = svc.CreateCompany(ctx, owner, CreateCompanyRequest{Name: "Acme again", Slug: "acme"})
+	assertErrorIs(t, err, domain.ErrConflict)
+
+	companyID := company.ID.String()
+	update := UpdateCompanyRequest{Name: "Acme Inc", Slug: "acme"}
+
+	_, err = svc.UpdateCompany(ctx, stranger, companyID, update)
+	assertErrorIs(t, err, domain.ErrForbidden)
+
+	if _, err := svc.SetCompanyMember(ctx, owner, companyID, "editor", domain.CompanyEditor); err != nil {
+		t.Fatalf("SetCompanyMember: %v", err)
+	}
+	updated, err := svc.UpdateCompany(ctx, editor, companyID, update)
+	if err != nil {
+		t.Fatalf("UpdateCompany as editor: %v", err)
+	}
+	if updated.Name != "Acme Inc" {
+		t.Errorf("name = %q, want Acme Inc", updated.Name)
+	}
+
+	// Editors may not manage members or delete the company; admins bypass membership
+	_, err = svc.SetCompanyMember(ctx, editor, companyID, "stranger", domain.CompanyEditor)
+	assertErrorIs(t, err, domain.ErrForbidden)
+	assertErrorIs(t, svc.DeleteCompany(ctx, editor, companyID), domain.ErrForbidden)
+
+	members, err := svc.ListCompanyMembers(ctx, admin, companyID)
+	if err != nil {
+		t.Fatalf("ListCompanyMembers: %v", err)
+	}
+	if len(members) != 2 || members[0].Role != domain.CompanyOwner || members[1].UserHandle != "editor" {
+		t.Errorf("members = %+v, want owner then editor", members)
+	}
+}
+
+func TestCompanyKeepsLastOwner(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	svc := NewCompanyService(store, NewAuthorizer(store))
+	owner := seedUser(t, store, "owner", domain.RoleUser)
+	second := seedUser(t, store, "second", domain.RoleUser)
+
+	company, err := svc.CreateCompany(ctx, owner, CreateCompanyRequest{Name: "Acme", Slug: "acme"})
+	if err != nil {
+		t.Fatalf("CreateCompany: %v", err)
+	}
+	companyID := company.ID.String()
+
+	err = svc.RemoveCompanyMember(ctx, owner, companyID, owner.UserID.String())
+	assertErrorIs(t, err, domain.ErrConflict)
+
+	if _, err := svc.SetCompanyMember(ctx, owner, companyID, "second", domain.CompanyOwner); err != nil {
+		t.Fatalf("SetCompanyMember: %v", err)
+	}
+	if err := svc.RemoveCompanyMember(ctx, owner, companyID, owner.UserID.String()); err != nil {
+		t.Fatalf("RemoveCompanyMember with another owner left: %v", err)
+	}
+
+	// Both ownership changes are audited as company updates naming the member
+	events := store.AuditEvents()
+	wantMembers := []struct{ before, after string }{
+		{`{"members":{"` + second.UserID.String() + `":null}}`, `{"members":{"` + second.UserID.String() + `":"owner"}}`},
+		{`{"members":{"` + owner.UserID.String() + `":"owner"}}`, `{"members":{"` + owner.UserID.String() + `":null}}`},
+	}
+	if len(events) != 3 {
+		t.Fatalf("audit events = %d, want company create plus two membership changes", len(events))
+	}
+	for i, want := range wantMembers {
+		event := events[i+1]
+		if event.EntityID != company.ID || event.Action != string(domain.AuditUpdate) ||
+			string(event.Before) != want.before || string(event.After) != want.after {
+			t.Errorf("membership event %d = %s %s -> %s, want %s -> %s", i, event.Action, event.Before, event.After, want.before, want.after)
+		}
+	}
+
+	// The former owner no longer has access, the remaining one does
+	assertErrorIs(t, svc.DeleteCompany(ctx, owner, companyID), domain.ErrForbidden)
+	if err := svc.DeleteCompany(ctx, second, companyID); err != nil {
+		t.Fatalf("DeleteCompany: %v", err)
+	}
+	_, err = svc.GetCompanyByID(ctx, companyID)
+	assertErrorIs(t, err, domain.ErrCompanyNotFound)
+}
+
+func TestClaimCompanyQueuesForAdmin(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	svc := NewCompanyService(store, NewAuthorizer(store))
+	owner := seedUser(t, store, "owner", domain.RoleUser)
+	claimant := seedUser(t, store, "claimant", domain.RoleUser)
+	admin := seedUser(t, store, "admin", domain.RoleAdmin)
+
+	company, err := svc.CreateCompany(ctx, owner, CreateCompanyRequest{Name: "Acme", Slug: "acme"})
+	if err != nil {
+		t.Fatalf("CreateCompany: %v", err)
+	}
+
+	claim, err := svc.ClaimCompany(ctx, claimant, company.ID.String(), "I run it")
+	if err != nil {
+		t.Fatalf("ClaimCompany: %v", err)
+	}
+	if claim.Status != domain.ClaimPending {
+		t.Fatalf("claim status = %q, want pending", claim.Status)
+	}
+	_, err = svc.ClaimCompany(ctx, claimant, company.ID.String(), "again")
+	assertErrorIs(t, err, domain.ErrConflict)
+
+	if n, _ := svc.CountCompanyClaims(ctx, domain.ClaimPending); n != 1 {
+		t.Errorf("pending claims = %d, want 1", n)
+	}
+
+	approved, err := svc.ApproveCompanyClaim(ctx, admin, claim.ID.String(), "")
+	if err != nil {
+		t.Fatalf("ApproveCompanyClaim: %v", err)
+	}
+	if approved.Status != domain.ClaimApproved {
+		t.Errorf("claim status = %q, want approved", approved.Status)
+	}
+	if _, err := svc.ListCompanyMembers(ctx, claimant, company.ID.String()); err != nil {
+		t.Errorf("approved claimant cannot list members: %v", err)
+	}
+}
+
+func TestClaimCompanyByEmailDomainNeedsVerifiedEmail(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	svc := NewCompanyService(store, NewAuthorizer(store))
+	unverified := seedUser(t, store, "unverified", domain.RoleUser)
+	verified := seedUser(t, store, "verified", domain.RoleUser)
+
+	now := pgtype.Timestamptz{Time: time.Now().UTC(), Valid: true}
+	if _, err := store.SetUserEmailVerified(ctx, sqlc.SetUserEmailVerifiedParams{ID: verified.UserID, EmailVerifiedAt: now}); err != nil {
+		t.Fatalf("SetUserEmailVerified: %v", err)
+	}
+
+	// A company without owners, as every company created before memberships existed
+	website := "https://www.example.com"
+	company, err := store.CreateCompany(ctx, sqlc.CreateCompanyParams{
+		ID: uuid.New(), Name: "Example", Website: &website, Slug: "example", CreatedAt: now, UpdatedAt: now,
+	})
+	if err != nil {
+		t.Fatalf("CreateCompany: %v", err)
+	}
+
+	claim, err := svc.ClaimCompany(ctx, unverified, company.ID.String(), "")
+	if err != nil {
+		t.Fatalf("ClaimCompany unverified: %v", err)
+	}
+	if claim.Status != domain.ClaimPending || claim.VerificationMethod != domain.VerifyManual {
+		t.Errorf("unverified claim = %s/%s, want pending/manual", claim.Status, claim.VerificationMethod)
+	}
+	if _, err := svc.ListCompanyMembers(ctx, unverified, company.ID.String()); err == nil {
+		t.Error("unverified claimant became a member")
+	}
+
+	claim, err = svc.ClaimCompany(ctx, verified, company.ID.String(), "")
+	if err != nil {
+		t.Fatalf("ClaimCompany verified: %v", err)
+	}
+	if claim.Status != domain.ClaimApproved || claim.VerificationMethod != domain.VerifyEmailDomain {
+		t.Errorf("verified claim = %s/%s, want approved/email_domain", claim.Status, claim.VerificationMethod)
+	}
+}
@@ -0,0 +1,39 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+
+	"ratemysoft-backend/internal/domain"
+	"ratemysoft-backend/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+// parseID parses a UUID taken from a request, naming the offending field on failure
+func parseID(field, value string) (uuid.UUID, error) {
+	id, err := uuid.Parse(value)
+	if err != nil {
+		return uuid.Nil, domain.InvalidField("invalid_id", field, "must be a valid UUID").Wrap(err)
+	}
+	return id, nil
+}
+
+// errInvalidCredentials deliberately does not say whether the email or the password was wrong
+var errInvalidCredentials = domain.Unauthorized("invalid_credentials", "invalid credentials")
+
+func invalidSlug(err error) error {
+	return domain.InvalidField("invalid_slug", "slug", "must be lowercase letters and digits separated by single hyphens").Wrap(err)
+}
+
+// listingError reports a cursor issued for a different sort as a client error and wraps any other failure
+func listingError(action string, err error) error {
+	if errors.Is(err, repository.ErrCursorMismatch) {
+		return domain.InvalidField("invalid_cursor", "cursor", "cursor does not match the requested sort").Wrap(err)
+	}
+	return fmt.Errorf("failed to %s: %w", action, err)
+}
+
+func invalidCategory(category string) error {
+	return domain.InvalidField("invalid_category", "category", fmt.Sprintf("unknown category %q", category))
+}
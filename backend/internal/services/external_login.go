@@ -0,0 +1,194 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"strings"
+	"time"
+
+	"ratemysoft-backend/internal/domain"
+	"ratemysoft-backend/internal/models/sqlc"
+	"ratemysoft-backend/internal/repository"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	minHandleLength = 3
+	maxHandleLength = 20
+
+	// handleAttempts bounds how many suffixed handles are tried before giving up
+	handleAttempts = 5
+)
+
+var errEmailUnverified = domain.Forbidden("email_unverified", "the provider has not verified this account's email address")
+
+// ExternalIdentity is a user as vouched for by an external identity provider
+type ExternalIdentity struct {
+	Provider      string // credentials provider, e.g. "github"
+	Subject       string // the provider's stable user ID
+	Email         string
+	EmailVerified bool
+	Username      string // suggested handle; may be empty or taken
+}
+
+// AuthenticateExternal signs in the user behind an external identity. A user who signed in with
+// the provider before is found by subject. Otherwise the identity is linked to the account with
+// the same email, or a new account is created; both require the provider to have verified the
+// email, since the email is what ties the identity to an account.
+//
+// An account whose email was never verified may have been registered by someone else to
+// squat on the address. Before such an account is linked, its password is removed and its
+// sessions are revoked, so only the owner of the email keeps access.
+func (s *UserService) AuthenticateExternal(ctx context.Context, identity ExternalIdentity) (*domain.User, error) {
+	credential, err := s.store.GetCredentialByIdentifier(ctx, sqlc.GetCredentialByIdentifierParams{
+		Provider:   identity.Provider,
+		Identifier: identity.Subject,
+	})
+	if err == nil {
+		return s.GetUserByID(ctx, credential.UserID.String())
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("failed to get credentials: %w", err)
+	}
+
+	if identity.Email == "" || !identity.EmailVerified {
+		return nil, errEmailUnverified
+	}
+
+	now := pgtype.Timestamptz{Time: time.Now(), Valid: true}
+	externalCredential := func(userID uuid.UUID) sqlc.CreateCredentialParams {
+		return sqlc.CreateCredentialParams{
+			UserID:     userID,
+			Provider:   identity.Provider,
+			Identifier: identity.Subject,
+			CreatedAt:  now,
+			UpdatedAt:  now,
+		}
+	}
+
+	verified := sqlc.SetUserEmailVerifiedParams{EmailVerifiedAt: now}
+
+	existing, err := s.store.GetUserByEmail(ctx, identity.Email)
+	if err == nil {
+		// The provider vouching for the address also verifies it here
+		err = s.store.InTx(ctx, func(q repository.Queries) error {
+			if _, err := q.CreateCredential(ctx, externalCredential(existing.ID)); err != nil {
+				return fmt.Errorf("failed to link credentials: %w", err)
+			}
+			if existing.EmailVerifiedAt.Valid {
+				return nil
+			}
+
+			// Nobody proved they own the address when the password was set
+			err := q.HardDeleteCredential(ctx, sqlc.HardDeleteCredentialParams{UserID: existing.ID, Provider: "email"})
+			if err != nil {
+				return fmt.Errorf("failed to remove unverified password: %w", err)
+			}
+			_, err = q.RevokeUserSessions(ctx, sqlc.RevokeUserSessionsParams{UserID: existing.ID, RevokedAt: now})
+			if err != nil {
+				return fmt.Errorf("failed to revoke sessions: %w", err)
+			}
+
+			verified.ID = existing.ID
+			user, err := q.SetUserEmailVerified(ctx, verified)
+			if err != nil {
+				return fmt.Errorf("failed to verify email: %w", err)
+			}
+			return recordAudit(ctx, q, user.ID, domain.AuditUpdate, domain.AuditEntityUser, user.ID, existing, user)
+		})
+		if err != nil {
+			return nil, err
+		}
+		return s.GetUserByID(ctx, existing.ID.String())
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("failed to check if user exists: %w", err)
+	}
+
+	handle, err := s.availableHandle(ctx, identity)
+	if err != nil {
+		return nil, err
+	}
+
+	userID := uuid.New()
+	var user sqlc.User
+	err = s.store.InTx(ctx, func(q repository.Queries) error {
+		var err error
+		user, err = q.CreateUser(ctx, sqlc.CreateUserParams{
+			ID:        userID,
+			Email:     identity.Email,
+			Handle:    handle,
+			Role:      "user", // default role
+			CreatedAt: now,
+			UpdatedAt: now,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create user: %w", err)
+		}
+
+		if _, err := q.CreateCredential(ctx, externalCredential(userID)); err != nil {
+			return fmt.Errorf("failed to create credentials: %w", err)
+		}
+
+		verified.ID = userID
+		if user, err = q.SetUserEmailVerified(ctx, verified); err != nil {
+			return fmt.Errorf("failed to verify email: %w", err)
+		}
+
+		return recordAudit(ctx, q, userID, domain.AuditCreate, domain.AuditEntityUser, userID, nil, user)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return SQLCToDomainUser(user)
+}
+
+// availableHandle derives a free handle from the identity's username or email, adding a random
+// numeric suffix when the plain one is taken
+func (s *UserService) availableHandle(ctx context.Context, identity ExternalIdentity) (string, error) {
+	base := handleFrom(identity.Username)
+	if base == "" {
+		local, _, _ := strings.Cut(identity.Email, "@")
+		base = handleFrom(local)
+	}
+	if base == "" {
+		base = "user"
+	}
+
+	candidate := base
+	for range handleAttempts {
+		_, err := s.store.GetUserByHandle(ctx, candidate)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return candidate, nil
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to check handle: %w", err)
+		}
+
+		suffix := fmt.Sprintf("%04d", rand.IntN(10000))
+		candidate = base[:min(len(base), maxHandleLength-len(suffix))] + suffix
+	}
+	return "", domain.Conflict("handle_taken", "could not find a free handle")
+}
+
+// handleFrom keeps the lowercase letters, digits, underscores and hyphens of s, returning ""
+// when too little is left to make a handle
+func handleFrom(s string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(s) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '_' || r == '-' {
+			b.WriteRune(r)
+		}
+	}
+	handle := b.String()
+	if len(handle) < minHandleLength {
+		return ""
+	}
+	return handle[:min(len(handle), maxHandleLength)]
+}
@@ -0,0 +1,154 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"ratemysoft-backend/internal/domain"
+	"ratemysoft-backend/internal/models/sqlc"
+	"ratemysoft-backend/internal/repository/memory"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func TestAuthenticateExternalCreatesAndFindsUser(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	svc := NewUserService(store)
+	identity := ExternalIdentity{Provider: "github", Subject: "583231", Email: "octocat@github.com", EmailVerified: true, Username: "Octo.Cat"}
+
+	user, err := svc.AuthenticateExternal(ctx, identity)
+	if err != nil {
+		t.Fatalf("AuthenticateExternal: %v", err)
+	}
+	if user.Handle != "octocat" || user.Email != "octocat@github.com" {
+		t.Errorf("user = %+v, want handle octocat", user)
+	}
+
+	// The second sign-in finds the user by subject, even if the email changed upstream
+	identity.Email = "new@github.com"
+	again, err := svc.AuthenticateExternal(ctx, identity)
+	if err != nil {
+		t.Fatalf("AuthenticateExternal again: %v", err)
+	}
+	if again.ID != user.ID {
+		t.Errorf("second sign-in = %s, want %s", again.ID, user.ID)
+	}
+
+	// Without a password credential the account cannot sign in with email
+	_, err = svc.AuthenticateUser(ctx, "octocat@github.com", "")
+	assertErrorIs(t, err, domain.ErrUnauthorized)
+
+	if events := store.AuditEvents(); len(events) != 1 || events[0].EntityID != user.ID {
+		t.Errorf("audit log = %+v, want one create event", events)
+	}
+}
+
+func TestAuthenticateExternalLinksByVerifiedEmail(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	svc := NewUserService(store)
+
+	alice, err := svc.CreateUser(ctx, CreateUserRequest{Email: "alice@example.com", Handle: "alice", Password: "correct horse"})
+	if err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+	_, err = store.SetUserEmailVerified(ctx, sqlc.SetUserEmailVerifiedParams{
+		ID:              alice.ID,
+		EmailVerifiedAt: pgtype.Timestamptz{Time: time.Now(), Valid: true},
+	})
+	if err != nil {
+		t.Fatalf("SetUserEmailVerified: %v", err)
+	}
+
+	_, err = svc.AuthenticateExternal(ctx, ExternalIdentity{Provider: "google", Subject: "g-1", Email: "alice@example.com"})
+	assertErrorIs(t, err, domain.ErrForbidden)
+
+	linked, err := svc.AuthenticateExternal(ctx, ExternalIdentity{Provider: "google", Subject: "g-1", Email: "alice@example.com", EmailVerified: true})
+	if err != nil {
+		t.Fatalf("AuthenticateExternal: %v", err)
+	}
+	if linked.ID != alice.ID {
+		t.Errorf("linked user = %s, want alice %s", linked.ID, alice.ID)
+	}
+
+	// The owner of a verified address keeps their password next to the linked provider
+	if _, err := svc.AuthenticateUser(ctx, "alice@example.com", "correct horse"); err != nil {
+		t.Errorf("AuthenticateUser after linking: %v", err)
+	}
+}
+
+func TestAuthenticateExternalTakesOverUnverifiedAccount(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	svc := NewUserService(store)
+	sessions := NewSessionService(store, time.Hour)
+
+	// Someone registers the victim's address before the victim signs up
+	squatted, err := svc.CreateUser(ctx, CreateUserRequest{Email: "victim@example.com", Handle: "squatter", Password: "attacker password"})
+	if err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+	session, _, err := sessions.StartSession(ctx, squatted.ID, SessionMetadata{})
+	if err != nil {
+		t.Fatalf("StartSession: %v", err)
+	}
+
+	linked, err := svc.AuthenticateExternal(ctx, ExternalIdentity{Provider: "google", Subject: "g-1", Email: "victim@example.com", EmailVerified: true})
+	if err != nil {
+		t.Fatalf("AuthenticateExternal: %v", err)
+	}
+	if linked.ID != squatted.ID || linked.EmailVerifiedAt == nil {
+		t.Errorf("linked user = %+v, want the verified account", linked)
+	}
+
+	// The password set before anyone proved ownership of the address is gone
+	_, err = svc.AuthenticateUser(ctx, "victim@example.com", "attacker password")
+	assertErrorIs(t, err, domain.ErrUnauthorized)
+
+	row, err := store.GetSession(ctx, session.ID)
+	if err != nil {
+		t.Fatalf("GetSession: %v", err)
+	}
+	if !row.RevokedAt.Valid {
+		t.Errorf("the squatter's session is still active")
+	}
+}
+
+func TestAuthenticateExternalHandleFallbacks(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	svc := NewUserService(store)
+	seedUser(t, store, "bob", domain.RoleUser)
+
+	taken, err := svc.AuthenticateExternal(ctx, ExternalIdentity{Provider: "oidc", Subject: "1", Email: "bob@corp.example", EmailVerified: true})
+	if err != nil {
+		t.Fatalf("AuthenticateExternal: %v", err)
+	}
+	if len(taken.Handle) != len("bob")+4 || taken.Handle[:3] != "bob" {
+		t.Errorf("handle = %q, want bob with a numeric suffix", taken.Handle)
+	}
+
+	short, err := svc.AuthenticateExternal(ctx, ExternalIdentity{Provider: "oidc", Subject: "2", Email: "x@corp.example", EmailVerified: true, Username: "é"})
+	if err != nil {
+		t.Fatalf("AuthenticateExternal: %v", err)
+	}
+	if short.Handle != "user" {
+		t.Errorf("handle = %q, want the user fallback", short.Handle)
+	}
+}
+
+func TestHandleFrom(t *testing.T) {
+	cases := map[string]string{
+		"Octo.Cat":                     "octocat",
+		"jane_doe-99":                  "jane_doe-99",
+		"ab":                           "",
+		"a-really-long-username-here!": "a-really-long-userna",
+	}
+	for in, want := range cases {
+		if got := handleFrom(in); got != want {
+			t.Errorf("handleFrom(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
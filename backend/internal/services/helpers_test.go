@@ -0,0 +1,68 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"ratemysoft-backend/internal/domain"
+	"ratemysoft-backend/internal/models/sqlc"
+	"ratemysoft-backend/internal/repository/memory"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// seedUser inserts a user straight into the store, skipping the password hashing of
+// UserService.CreateUser, and returns it as an actor
+func seedUser(t *testing.T, store *memory.Store, handle string, role domain.UserRole) domain.Actor {
+	t.Helper()
+	now := pgtype.Timestamptz{Time: time.Now().UTC(), Valid: true}
+	user, err := store.CreateUser(context.Background(), sqlc.CreateUserParams{
+		ID:        uuid.New(),
+		Email:     handle + "@example.com",
+		Handle:    handle,
+		Role:      string(role),
+		CreatedAt: now,
+		UpdatedAt: now,
+	})
+	if err != nil {
+		t.Fatalf("failed to seed user %q: %v", handle, err)
+	}
+	return domain.Actor{UserID: user.ID, Role: role}
+}
+
+// seedProduct creates a company owned by owner with one hosting product in it
+func seedProduct(t *testing.T, store *memory.Store, owner domain.Actor, slug string) *domain.Product {
+	t.Helper()
+	ctx := context.Background()
+	authz := NewAuthorizer(store)
+
+	company, err := NewCompanyService(store, authz).CreateCompany(ctx, owner, CreateCompanyRequest{
+		Name: "Acme " + slug,
+		Slug: "acme-" + slug,
+	})
+	if err != nil {
+		t.Fatalf("failed to seed company: %v", err)
+	}
+	product, err := NewProductService(store, authz).CreateProduct(ctx, owner, CreateProductRequest{
+		CompanyID: company.ID.String(),
+		Name:      "Widget " + slug,
+		Slug:      slug,
+		Category:  "hosting",
+	})
+	if err != nil {
+		t.Fatalf("failed to seed product: %v", err)
+	}
+	return product
+}
+
+func assertErrorIs(t *testing.T, err, target error) {
+	t.Helper()
+	if !errors.Is(err, target) {
+		t.Fatalf("error = %v, want %v", err, target)
+	}
+}
+
+func ptr[T any](v T) *T { return &v }
@@ -0,0 +1,144 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"ratemysoft-backend/internal/domain"
+	"ratemysoft-backend/internal/models/sqlc"
+	"ratemysoft-backend/internal/repository"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// LeaderboardService ranks products by confidence-adjusted rating scores.
+// Scores are precomputed into product_leaderboard by Refresh, which a background job runs periodically.
+type LeaderboardService struct {
+	store       repository.Store
+	priorWeight float64 // number of "virtual" reviews at the global mean added to every product
+}
+
+func NewLeaderboardService(store repository.Store, priorWeight float64) *LeaderboardService {
+	if priorWeight < 0 {
+		priorWeight = 0
+	}
+	return &LeaderboardService{
+		store:       store,
+		priorWeight: priorWeight,
+	}
+}
+
+type LeaderboardParams struct {
+	Window   string
+	Score    string
+	Category string // optional; includes subcategories
+	Page     domain.PageRequest
+}
+
+type LeaderboardResult struct {
+	Window      domain.LeaderboardWindow
+	Score       domain.LeaderboardScore
+	Entries     []*domain.LeaderboardEntry
+	Total       int64
+	RefreshedAt *time.Time // nil until the first refresh of the window
+	Cursors     domain.PageCursors
+}
+
+// Refresh recomputes the scores of every leaderboard window in a single transaction,
+// so readers never see a partially rebuilt leaderboard
+func (s *LeaderboardService) Refresh(ctx context.Context) error {
+	now := time.Now().UTC()
+
+	return s.store.InTx(ctx, func(q repository.Queries) error {
+		// Concurrent refreshes (e.g. from several API instances) would collide on the primary key
+		if err := q.LockLeaderboardRefresh(ctx); err != nil {
+			return fmt.Errorf("failed to lock leaderboard: %w", err)
+		}
+
+		for _, window := range domain.LeaderboardWindows {
+			var since pgtype.Timestamptz
+			if t := window.Since(now); t != nil {
+				since = pgtype.Timestamptz{Time: *t, Valid: true}
+			}
+
+			if err := q.DeleteLeaderboardWindow(ctx, string(window)); err != nil {
+				return fmt.Errorf("failed to clear %s leaderboard: %w", window, err)
+			}
+
+			err := q.RefreshLeaderboardWindow(ctx, sqlc.RefreshLeaderboardWindowParams{
+				Since:       since,
+				TimeWindow:  string(window),
+				PriorWeight: s.priorWeight,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to refresh %s leaderboard: %w", window, err)
+			}
+		}
+		return nil
+	})
+}
+
+// GetLeaderboard retrieves ranked products for a window, optionally limited to one category
+func (s *LeaderboardService) GetLeaderboard(ctx context.Context, params LeaderboardParams) (*LeaderboardResult, error) {
+	window, err := domain.NewLeaderboardWindow(params.Window)
+	if err != nil {
+		return nil, err
+	}
+
+	score, err := domain.NewLeaderboardScore(params.Score)
+	if err != nil {
+		return nil, err
+	}
+
+	if params.Category != "" {
+		if err := requireCategory(ctx, s.store, params.Category); err != nil {
+			return nil, err
+		}
+	}
+	filter := repository.LeaderboardFilter{Window: window, Category: params.Category}
+
+	rows, cursors, err := s.store.ListLeaderboardPage(ctx, filter, score, params.Page)
+	if err != nil {
+		return nil, listingError("get leaderboard", err)
+	}
+
+	total, err := s.store.CountLeaderboardEntries(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count leaderboard: %w", err)
+	}
+
+	refreshedAt, err := s.store.GetLeaderboardRefreshedAt(ctx, string(window))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get leaderboard refresh time: %w", err)
+	}
+
+	result := &LeaderboardResult{
+		Window:  window,
+		Score:   score,
+		Entries: make([]*domain.LeaderboardEntry, 0, len(rows)),
+		Total:   total,
+		Cursors: cursors,
+	}
+	if refreshedAt.Valid {
+		result.RefreshedAt = &refreshedAt.Time
+	}
+
+	for _, row := range rows {
+		result.Entries = append(result.Entries, &domain.LeaderboardEntry{
+			Rank:          int(row.Rank),
+			ProductID:     row.ProductID,
+			ProductName:   row.ProductName,
+			ProductSlug:   domain.Slug(row.ProductSlug),
+			Category:      domain.ProductCategory(row.Category),
+			CompanyName:   row.CompanyName,
+			CompanySlug:   domain.Slug(row.CompanySlug),
+			ReviewCount:   int(row.ReviewCount),
+			PositiveCount: int(row.PositiveCount),
+			AvgRating:     row.AvgRating,
+			BayesianScore: row.BayesianScore,
+			WilsonScore:   row.WilsonScore,
+		})
+	}
+	return result, nil
+}
@@ -0,0 +1,204 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"ratemysoft-backend/internal/domain"
+	"ratemysoft-backend/internal/models/sqlc"
+	"ratemysoft-backend/internal/repository"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// loginFailureWindow is how long a failed sign-in counts towards a lockout
+const loginFailureWindow = 24 * time.Hour
+
+// Throttle scopes of login_throttles
+const (
+	throttleAccount = "account" // keyed by the email signed in with, whether or not it has an account
+	throttleIP      = "ip"      // keyed by the client IP
+)
+
+// LoginPolicy limits failed sign-ins. Once an email or client IP reaches its limit it is locked
+// for BaseLockout, twice as long after every further failure, up to MaxLockout.
+type LoginPolicy struct {
+	AccountAttempts int // failures per email before it is locked
+	IPAttempts      int // failures per client IP before it is locked
+	BaseLockout     time.Duration
+	MaxLockout      time.Duration
+}
+
+// LoginGuard signs users in with a password while slowing down guessing: failed attempts are
+// counted per email and per client IP, and keys past their limit are locked out temporarily.
+// Unknown emails are counted and locked like real ones, so lockouts do not reveal which
+// emails have accounts.
+type LoginGuard struct {
+	store  repository.Store
+	users  *UserService
+	policy LoginPolicy
+}
+
+func NewLoginGuard(store repository.Store, users *UserService, policy LoginPolicy) *LoginGuard {
+	policy.AccountAttempts = max(policy.AccountAttempts, 1)
+	policy.IPAttempts = max(policy.IPAttempts, 1)
+	policy.MaxLockout = max(policy.MaxLockout, policy.BaseLockout)
+	return &LoginGuard{
+		store:  store,
+		users:  users,
+		policy: policy,
+	}
+}
+
+// throttle is one key failed sign-ins are counted under
+type throttle struct {
+	scope string
+	key   string
+	limit int
+}
+
+// Authenticate checks an email and password signed in with from ip, which may be empty
+// when the client address is unknown
+func (g *LoginGuard) Authenticate(ctx context.Context, email, password, ip string) (*domain.User, error) {
+	now := time.Now()
+	throttles := []throttle{{scope: throttleAccount, key: email, limit: g.policy.AccountAttempts}}
+	if ip != "" {
+		throttles = append(throttles, throttle{scope: throttleIP, key: ip, limit: g.policy.IPAttempts})
+	}
+
+	locks, err := g.reserveAttempt(ctx, throttles, now)
+	if err != nil {
+		return nil, err
+	}
+
+	// A wrong password leaves the attempt counted as reserved
+	user, err := g.users.AuthenticateUser(ctx, email, password)
+	if err != nil {
+		return nil, err
+	}
+
+	// Signing in clears the account's failures. The IP only gets its reserved attempt back,
+	// so a credential stuffing run is not reset by the occasional password that works.
+	err = g.store.InTx(ctx, func(q repository.Queries) error {
+		if _, err := q.DeleteLoginThrottle(ctx, sqlc.DeleteLoginThrottleParams{Scope: throttleAccount, Key: email}); err != nil {
+			return fmt.Errorf("failed to clear login throttle: %w", err)
+		}
+		for i, t := range throttles {
+			if t.scope == throttleAccount {
+				continue
+			}
+			err := q.ReleaseLoginAttempt(ctx, sqlc.ReleaseLoginAttemptParams{Scope: t.scope, Key: t.key, ReservedLock: locks[i]})
+			if err != nil {
+				return fmt.Errorf("failed to release login attempt: %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// reserveAttempt counts a sign-in as failed against every throttle before its password is
+// checked, locking the ones it takes to their limit, so concurrent guesses cannot all get past
+// the lock check. Locked keys refuse the attempt without counting it, and the guess gets no
+// answer. It returns the lock each throttle took, if any, for releasing the attempt.
+func (g *LoginGuard) reserveAttempt(ctx context.Context, throttles []throttle, now time.Time) ([]pgtype.Timestamptz, error) {
+	locks := make([]pgtype.Timestamptz, len(throttles))
+	err := g.store.InTx(ctx, func(q repository.Queries) error {
+		var wait time.Duration
+		for i, t := range throttles {
+			row, err := q.ReserveLoginAttempt(ctx, sqlc.ReserveLoginAttemptParams{
+				Scope:        t.scope,
+				Key:          t.key,
+				AttemptedAt:  pgtype.Timestamptz{Time: now, Valid: true},
+				ForgetBefore: pgtype.Timestamptz{Time: now.Add(-loginFailureWindow), Valid: true},
+			})
+			if err != nil {
+				return fmt.Errorf("failed to reserve login attempt: %w", err)
+			}
+			if row.LockedUntil.Valid && row.LockedUntil.Time.After(now) {
+				wait = max(wait, row.LockedUntil.Time.Sub(now))
+				continue
+			}
+			if int(row.Failures) < t.limit {
+				continue
+			}
+
+			locks[i] = pgtype.Timestamptz{Time: now.Add(g.lockout(int(row.Failures) - t.limit)), Valid: true}
+			err = q.LockLoginThrottle(ctx, sqlc.LockLoginThrottleParams{Scope: t.scope, Key: t.key, LockedUntil: locks[i]})
+			if err != nil {
+				return fmt.Errorf("failed to lock login: %w", err)
+			}
+		}
+		// Rolling back takes the attempt off the keys that were not locked too
+		if wait > 0 {
+			return domain.RateLimited("login_locked", "too many failed sign-in attempts; please try again later", wait)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return locks, nil
+}
+
+// lockout is how long a key is locked after excess failures beyond its limit
+func (g *LoginGuard) lockout(excess int) time.Duration {
+	d := g.policy.BaseLockout
+	for range excess {
+		if d >= g.policy.MaxLockout {
+			break
+		}
+		d *= 2
+	}
+	return min(d, g.policy.MaxLockout)
+}
+
+// ListLockedAccounts lists the accounts that refuse sign-ins right now, the longest lockout first
+func (g *LoginGuard) ListLockedAccounts(ctx context.Context) ([]*domain.LockedAccount, error) {
+	rows, err := g.store.ListLockedAccounts(ctx, pgtype.Timestamptz{Time: time.Now(), Valid: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list locked accounts: %w", err)
+	}
+
+	accounts := make([]*domain.LockedAccount, 0, len(rows))
+	for _, row := range rows {
+		accounts = append(accounts, &domain.LockedAccount{
+			UserID:         row.UserID,
+			Handle:         row.Handle,
+			Email:          domain.Email(row.Email),
+			FailedAttempts: int(row.Failures),
+			LastFailureAt:  row.LastFailureAt.Time,
+			LockedUntil:    row.LockedUntil.Time,
+		})
+	}
+	return accounts, nil
+}
+
+// UnlockAccount lifts a user's lockout and forgets their failed sign-ins. Lockouts of the
+// client IPs involved stay in place.
+func (g *LoginGuard) UnlockAccount(ctx context.Context, userID string) error {
+	parsedID, err := parseID("user_id", userID)
+	if err != nil {
+		return err
+	}
+
+	user, err := g.store.GetUser(ctx, parsedID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.ErrUserNotFound
+		}
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	_, err = g.store.DeleteLoginThrottle(ctx, sqlc.DeleteLoginThrottleParams{Scope: throttleAccount, Key: user.Email})
+	if err != nil {
+		return fmt.Errorf("failed to unlock account: %w", err)
+	}
+	return nil
+}
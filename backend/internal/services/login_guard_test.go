@@ -0,0 +1,215 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"ratemysoft-backend/internal/domain"
+	"ratemysoft-backend/internal/models/sqlc"
+	"ratemysoft-backend/internal/repository/memory"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// newLoginGuard returns a guard over a store holding alice@example.com with password "correct horse"
+func newLoginGuard(t *testing.T, policy LoginPolicy) (*LoginGuard, *memory.Store, *domain.User) {
+	t.Helper()
+	store := memory.NewStore()
+	users := NewUserService(store)
+	alice, err := users.CreateUser(context.Background(), CreateUserRequest{Email: "alice@example.com", Handle: "alice", Password: "correct horse"})
+	if err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+	return NewLoginGuard(store, users, policy), store, alice
+}
+
+// expireLockout ends a lockout early, as if its time had passed
+func expireLockout(t *testing.T, store *memory.Store, scope, key string) {
+	t.Helper()
+	err := store.LockLoginThrottle(context.Background(), sqlc.LockLoginThrottleParams{
+		Scope:       scope,
+		Key:         key,
+		LockedUntil: pgtype.Timestamptz{Time: time.Now().Add(-time.Second), Valid: true},
+	})
+	if err != nil {
+		t.Fatalf("LockLoginThrottle: %v", err)
+	}
+}
+
+func assertLockedFor(t *testing.T, err error, want time.Duration) {
+	t.Helper()
+	var domainErr *domain.Error
+	if !errors.As(err, &domainErr) || !errors.Is(err, domain.ErrRateLimited) {
+		t.Fatalf("err = %v, want a rate limited error", err)
+	}
+	if domainErr.RetryAfter <= want-5*time.Second || domainErr.RetryAfter > want {
+		t.Errorf("retry after = %s, want about %s", domainErr.RetryAfter, want)
+	}
+}
+
+func TestLoginGuardLocksAccountWithBackoff(t *testing.T) {
+	ctx := context.Background()
+	guard, store, alice := newLoginGuard(t, LoginPolicy{AccountAttempts: 3, IPAttempts: 100, BaseLockout: time.Minute, MaxLockout: 3 * time.Minute})
+
+	for range 3 {
+		_, err := guard.Authenticate(ctx, "alice@example.com", "wrong", "10.0.0.1")
+		assertErrorIs(t, err, domain.ErrUnauthorized)
+	}
+
+	// Even the right password is refused while locked, and the attempt does not count
+	_, err := guard.Authenticate(ctx, "alice@example.com", "correct horse", "10.0.0.2")
+	assertLockedFor(t, err, time.Minute)
+
+	// Each failure after the lockout doubles it, up to the maximum
+	for _, want := range []time.Duration{2 * time.Minute, 3 * time.Minute} {
+		expireLockout(t, store, throttleAccount, "alice@example.com")
+		_, err = guard.Authenticate(ctx, "alice@example.com", "wrong", "10.0.0.1")
+		assertErrorIs(t, err, domain.ErrUnauthorized)
+		_, err = guard.Authenticate(ctx, "alice@example.com", "correct horse", "10.0.0.1")
+		assertLockedFor(t, err, want)
+	}
+
+	expireLockout(t, store, throttleAccount, "alice@example.com")
+	user, err := guard.Authenticate(ctx, "alice@example.com", "correct horse", "10.0.0.1")
+	if err != nil {
+		t.Fatalf("Authenticate after the lockout: %v", err)
+	}
+	if user.ID != alice.ID {
+		t.Errorf("user = %s, want %s", user.ID, alice.ID)
+	}
+
+	// Signing in forgets the account's failures but not the IP's
+	_, err = store.GetLoginThrottle(ctx, sqlc.GetLoginThrottleParams{Scope: throttleAccount, Key: "alice@example.com"})
+	if !errors.Is(err, pgx.ErrNoRows) {
+		t.Errorf("account throttle after sign-in: err = %v, want none", err)
+	}
+	ip, err := store.GetLoginThrottle(ctx, sqlc.GetLoginThrottleParams{Scope: throttleIP, Key: "10.0.0.1"})
+	if err != nil || ip.Failures != 5 {
+		t.Errorf("ip throttle = %+v, %v; want 5 failures", ip, err)
+	}
+}
+
+func TestLoginGuardTreatsUnknownEmailsAlike(t *testing.T) {
+	ctx := context.Background()
+	guard, _, _ := newLoginGuard(t, LoginPolicy{AccountAttempts: 2, IPAttempts: 100, BaseLockout: time.Minute, MaxLockout: time.Hour})
+
+	for range 2 {
+		_, err := guard.Authenticate(ctx, "nobody@example.com", "guess", "10.0.0.1")
+		assertErrorIs(t, err, domain.ErrUnauthorized)
+	}
+	_, err := guard.Authenticate(ctx, "nobody@example.com", "guess", "10.0.0.1")
+	assertLockedFor(t, err, time.Minute)
+}
+
+func TestLoginGuardLocksClientIP(t *testing.T) {
+	ctx := context.Background()
+	guard, _, _ := newLoginGuard(t, LoginPolicy{AccountAttempts: 100, IPAttempts: 2, BaseLockout: time.Minute, MaxLockout: time.Hour})
+
+	// Stuffing different emails from one address
+	for _, email := range []string{"bob@example.com", "carol@example.com"} {
+		_, err := guard.Authenticate(ctx, email, "guess", "203.0.113.7")
+		assertErrorIs(t, err, domain.ErrUnauthorized)
+	}
+
+	_, err := guard.Authenticate(ctx, "alice@example.com", "correct horse", "203.0.113.7")
+	assertLockedFor(t, err, time.Minute)
+
+	if _, err := guard.Authenticate(ctx, "alice@example.com", "correct horse", "198.51.100.1"); err != nil {
+		t.Errorf("Authenticate from another address: %v", err)
+	}
+}
+
+func TestLoginGuardRefusesConcurrentGuessesPastTheLimit(t *testing.T) {
+	ctx := context.Background()
+	guard, _, _ := newLoginGuard(t, LoginPolicy{AccountAttempts: 3, IPAttempts: 100, BaseLockout: time.Minute, MaxLockout: time.Hour})
+
+	// Every guess is in flight before any of them has failed
+	const guesses = 10
+	errs := make(chan error, guesses)
+	start := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := range guesses {
+		wg.Go(func() {
+			<-start
+			_, err := guard.Authenticate(ctx, "alice@example.com", fmt.Sprintf("guess %d", i), "10.0.0.1")
+			errs <- err
+		})
+	}
+	close(start)
+	wg.Wait()
+	close(errs)
+
+	var checked, refused int
+	for err := range errs {
+		switch {
+		case errors.Is(err, domain.ErrUnauthorized):
+			checked++
+		case errors.Is(err, domain.ErrRateLimited):
+			refused++
+		default:
+			t.Errorf("err = %v, want unauthorized or rate limited", err)
+		}
+	}
+	if checked != 3 || refused != guesses-3 {
+		t.Errorf("passwords checked = %d, refused = %d; want 3 and %d", checked, refused, guesses-3)
+	}
+}
+
+func TestLoginGuardForgetsOldFailures(t *testing.T) {
+	ctx := context.Background()
+	guard, store, _ := newLoginGuard(t, LoginPolicy{AccountAttempts: 2, IPAttempts: 100, BaseLockout: time.Minute, MaxLockout: time.Hour})
+
+	longAgo := time.Now().Add(-loginFailureWindow - time.Hour)
+	_, err := store.ReserveLoginAttempt(ctx, sqlc.ReserveLoginAttemptParams{
+		Scope:        throttleAccount,
+		Key:          "alice@example.com",
+		AttemptedAt:  pgtype.Timestamptz{Time: longAgo, Valid: true},
+		ForgetBefore: pgtype.Timestamptz{Time: longAgo.Add(-loginFailureWindow), Valid: true},
+	})
+	if err != nil {
+		t.Fatalf("ReserveLoginAttempt: %v", err)
+	}
+
+	_, err = guard.Authenticate(ctx, "alice@example.com", "wrong", "")
+	assertErrorIs(t, err, domain.ErrUnauthorized)
+	if _, err := guard.Authenticate(ctx, "alice@example.com", "correct horse", ""); err != nil {
+		t.Errorf("Authenticate after one recent failure: %v", err)
+	}
+}
+
+func TestUnlockAccount(t *testing.T) {
+	ctx := context.Background()
+	guard, _, alice := newLoginGuard(t, LoginPolicy{AccountAttempts: 1, IPAttempts: 100, BaseLockout: time.Hour, MaxLockout: time.Hour})
+
+	for _, email := range []string{"alice@example.com", "nobody@example.com"} {
+		_, err := guard.Authenticate(ctx, email, "wrong", "10.0.0.1")
+		assertErrorIs(t, err, domain.ErrUnauthorized)
+	}
+
+	// Only emails with an account are listed
+	locked, err := guard.ListLockedAccounts(ctx)
+	if err != nil {
+		t.Fatalf("ListLockedAccounts: %v", err)
+	}
+	if len(locked) != 1 || locked[0].UserID != alice.ID || locked[0].FailedAttempts != 1 || !locked[0].LockedUntil.After(time.Now()) {
+		t.Fatalf("locked accounts = %+v, want alice", locked)
+	}
+
+	if err := guard.UnlockAccount(ctx, alice.ID.String()); err != nil {
+		t.Fatalf("UnlockAccount: %v", err)
+	}
+	if _, err := guard.Authenticate(ctx, "alice@example.com", "correct horse", "10.0.0.1"); err != nil {
+		t.Errorf("Authenticate after unlock: %v", err)
+	}
+	if locked, _ := guard.ListLockedAccounts(ctx); len(locked) != 0 {
+		t.Errorf("locked accounts after unlock = %+v, want none", locked)
+	}
+
+	err = guard.UnlockAccount(ctx, "00000000-0000-0000-0000-000000000001")
+	assertErrorIs(t, err, domain.ErrNotFound)
+}
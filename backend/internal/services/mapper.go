@@ -32,14 +32,20 @@ func SQLCToDomainUser(sqlcUser sqlc.User) (*domain.User, error) {
 		deletedAt = &sqlcUser.DeletedAt.Time
 	}
 
+	var emailVerifiedAt *time.Time
+	if sqlcUser.EmailVerifiedAt.Valid {
+		emailVerifiedAt = &sqlcUser.EmailVerifiedAt.Time
+	}
+
 	return &domain.User{
-		ID:        sqlcUser.ID,
-		Email:     email,
-		Handle:    sqlcUser.Handle,
-		Role:      domain.UserRole(sqlcUser.Role),
-		CreatedAt: createdAt,
-		UpdatedAt: updatedAt,
-		DeletedAt: deletedAt,
+		ID:              sqlcUser.ID,
+		Email:           email,
+		Handle:          sqlcUser.Handle,
+		Role:            domain.UserRole(sqlcUser.Role),
+		EmailVerifiedAt: emailVerifiedAt,
+		CreatedAt:       createdAt,
+		UpdatedAt:       updatedAt,
+		DeletedAt:       deletedAt,
 	}, nil
 }
 
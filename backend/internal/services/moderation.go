@@ -0,0 +1,75 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"ratemysoft-backend/internal/domain"
+	"ratemysoft-backend/internal/models/sqlc"
+	"ratemysoft-backend/internal/repository"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// ModerationConfig controls how reviews and review comments enter the moderation queue
+type ModerationConfig struct {
+	RequireApproval bool  // new reviews and comments start as pending instead of published
+	FlagThreshold   int32 // flag_count at which a review or comment shows up in the flagged queue
+
+	RequireVerifiedEmail bool // only users who verified their email can post reviews
+}
+
+// initialStatus is the status new reviews and comments are created with
+func (m ModerationConfig) initialStatus() domain.ReviewStatus {
+	if m.RequireApproval {
+		return domain.ReviewPending
+	}
+	return domain.ReviewPublished
+}
+
+// checkModerationTransition rejects decisions that would change nothing. Approving published
+// content is only meaningful when it has been flagged, since approval also clears the flags.
+func checkModerationTransition(subject string, from, to domain.ReviewStatus, flagCount int32) error {
+	if from == to && (to != domain.ReviewPublished || flagCount == 0) {
+		return domain.Conflict(subject+"_already_moderated", fmt.Sprintf("%s is already %s", subject, to))
+	}
+	return nil
+}
+
+// requireRejectionReason enforces that every rejection explains itself
+func requireRejectionReason(subject, reason string) error {
+	if strings.TrimSpace(reason) == "" {
+		return domain.InvalidField("reason_required", "reason", fmt.Sprintf("a reason is required to reject a %s", subject))
+	}
+	return nil
+}
+
+// recordModeration appends a decision to the review's moderation log; commentID is set
+// when the decision concerns one of the review's comments
+func recordModeration(ctx context.Context, q repository.ReviewRepository, reviewID uuid.UUID, commentID *uuid.UUID, moderatorID uuid.UUID, from, to domain.ReviewStatus, reason string) error {
+	var reasonPtr *string
+	if trimmed := strings.TrimSpace(reason); trimmed != "" {
+		reasonPtr = &trimmed
+	}
+
+	_, err := q.CreateReviewModeration(ctx, sqlc.CreateReviewModerationParams{
+		ID:          uuid.New(),
+		ReviewID:    reviewID,
+		ModeratorID: moderatorID,
+		FromStatus:  string(from),
+		ToStatus:    string(to),
+		Reason:      reasonPtr,
+		CreatedAt: pgtype.Timestamptz{
+			Time:  time.Now().UTC(),
+			Valid: true,
+		},
+		CommentID: commentID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record moderation decision: %w", err)
+	}
+	return nil
+}
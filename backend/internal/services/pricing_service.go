@@ -0,0 +1,454 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"ratemysoft-backend/internal/domain"
+	"ratemysoft-backend/internal/models/sqlc"
+	"ratemysoft-backend/internal/repository"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Bounds on how many products a single comparison may include
+const (
+	minCompareProducts = 2
+	maxCompareProducts = 5
+)
+
+// PricingService handles pricing plans and the pricing comparison
+type PricingService struct {
+	store repository.Store
+	authz *Authorizer
+}
+
+func NewPricingService(store repository.Store, authz *Authorizer) *PricingService {
+	return &PricingService{
+		store: store,
+		authz: authz,
+	}
+}
+
+// PricingPlanRequest holds the fields of a plan; updates replace every field
+type PricingPlanRequest struct {
+	Name          string
+	BillingPeriod string
+	Currency      string
+	PriceCents    int64
+	IsFree        bool
+	PricingUnit   string // defaults to flat
+	UsageUnit     string // required for per_usage plans
+	Features      []string
+	SortOrder     int
+}
+
+// ProductPricing is one product's column in a pricing comparison
+type ProductPricing struct {
+	Product     *domain.Product
+	CompanyName string
+	CompanySlug string
+	Plans       []*domain.PricingPlan
+	HasFreeTier bool
+
+	// Cheapest plan normalized to a monthly price; nil when no plan has a monthly equivalent
+	StartingMonthlyPriceCents *int64
+	StartingCurrency          domain.Currency
+}
+
+// PricingComparison lines up products side by side, in the order they were requested
+type PricingComparison struct {
+	Products []*ProductPricing
+	Features []string // every feature offered by any plan, in first-seen order
+}
+
+// ListPlans retrieves a product's pricing plans, cheapest first within the vendor's ordering
+func (s *PricingService) ListPlans(ctx context.Context, productID string) ([]*domain.PricingPlan, error) {
+	parsedID, err := parseID("product_id", productID)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = s.store.GetProduct(ctx, parsedID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrProductNotFound
+		}
+		return nil, fmt.Errorf("failed to get product: %w", err)
+	}
+
+	rows, err := s.store.ListPricingPlansByProduct(ctx, parsedID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pricing plans: %w", err)
+	}
+
+	plans := make([]*domain.PricingPlan, 0, len(rows))
+	for _, row := range rows {
+		plans = append(plans, SQLCToDomainPricingPlan(row))
+	}
+	return plans, nil
+}
+
+// CreatePlan adds a pricing plan to a product (company editors and admins only)
+func (s *PricingService) CreatePlan(ctx context.Context, actor domain.Actor, productID string, req PricingPlanRequest) (*domain.PricingPlan, error) {
+	parsedID, err := parseID("product_id", productID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.authz.RequireProductRole(ctx, actor, parsedID, domain.CompanyEditor); err != nil {
+		return nil, err
+	}
+
+	plan, err := newPricingPlan(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.ensurePlanNameAvailable(ctx, parsedID, uuid.Nil, plan.Name); err != nil {
+		return nil, err
+	}
+
+	now := pgtype.Timestamptz{
+		Time:  time.Now().UTC(),
+		Valid: true,
+	}
+
+	var usageUnit *string
+	if plan.UsageUnit != "" {
+		usageUnit = &plan.UsageUnit
+	}
+
+	row, err := s.store.CreatePricingPlan(ctx, sqlc.CreatePricingPlanParams{
+		ID:            uuid.New(),
+		ProductID:     parsedID,
+		Name:          plan.Name,
+		BillingPeriod: string(plan.BillingPeriod),
+		Currency:      string(plan.Currency),
+		PriceCents:    plan.PriceCents,
+		IsFree:        plan.IsFree,
+		PricingUnit:   string(plan.PricingUnit),
+		UsageUnit:     usageUnit,
+		Features:      plan.Features,
+		SortOrder:     int32(plan.SortOrder),
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create pricing plan: %w", err)
+	}
+
+	return SQLCToDomainPricingPlan(row), nil
+}
+
+// UpdatePlan replaces a pricing plan's fields (company editors and admins only)
+func (s *PricingService) UpdatePlan(ctx context.Context, actor domain.Actor, productID, planID string, req PricingPlanRequest) (*domain.PricingPlan, error) {
+	parsedProductID, err := parseID("product_id", productID)
+	if err != nil {
+		return nil, err
+	}
+
+	parsedPlanID, err := parseID("plan_id", planID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.authz.RequireProductRole(ctx, actor, parsedProductID, domain.CompanyEditor); err != nil {
+		return nil, err
+	}
+
+	plan, err := newPricingPlan(req)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = s.store.GetPricingPlan(ctx, sqlc.GetPricingPlanParams{
+		ID:        parsedPlanID,
+		ProductID: parsedProductID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrPricingPlanNotFound
+		}
+		return nil, fmt.Errorf("failed to get pricing plan: %w", err)
+	}
+
+	if err := s.ensurePlanNameAvailable(ctx, parsedProductID, parsedPlanID, plan.Name); err != nil {
+		return nil, err
+	}
+
+	var usageUnit *string
+	if plan.UsageUnit != "" {
+		usageUnit = &plan.UsageUnit
+	}
+
+	row, err := s.store.UpdatePricingPlan(ctx, sqlc.UpdatePricingPlanParams{
+		ID:            parsedPlanID,
+		ProductID:     parsedProductID,
+		Name:          plan.Name,
+		BillingPeriod: string(plan.BillingPeriod),
+		Currency:      string(plan.Currency),
+		PriceCents:    plan.PriceCents,
+		IsFree:        plan.IsFree,
+		PricingUnit:   string(plan.PricingUnit),
+		UsageUnit:     usageUnit,
+		Features:      plan.Features,
+		SortOrder:     int32(plan.SortOrder),
+		UpdatedAt: pgtype.Timestamptz{
+			Time:  time.Now().UTC(),
+			Valid: true,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update pricing plan: %w", err)
+	}
+
+	return SQLCToDomainPricingPlan(row), nil
+}
+
+// DeletePlan removes a pricing plan (company editors and admins only)
+func (s *PricingService) DeletePlan(ctx context.Context, actor domain.Actor, productID, planID string) error {
+	parsedProductID, err := parseID("product_id", productID)
+	if err != nil {
+		return err
+	}
+
+	parsedPlanID, err := parseID("plan_id", planID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.authz.RequireProductRole(ctx, actor, parsedProductID, domain.CompanyEditor); err != nil {
+		return err
+	}
+
+	deleted, err := s.store.DeletePricingPlan(ctx, sqlc.DeletePricingPlanParams{
+		ID:        parsedPlanID,
+		ProductID: parsedProductID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete pricing plan: %w", err)
+	}
+	if deleted == 0 {
+		return domain.ErrPricingPlanNotFound
+	}
+	return nil
+}
+
+// ComparePricing builds a side-by-side comparison of products referenced by ID or slug
+func (s *PricingService) ComparePricing(ctx context.Context, refs []string) (*PricingComparison, error) {
+	refs = uniqueNonEmpty(refs)
+	if len(refs) < minCompareProducts || len(refs) > maxCompareProducts {
+		return nil, domain.InvalidField("invalid_compare_products", "products",
+			fmt.Sprintf("must list between %d and %d products", minCompareProducts, maxCompareProducts))
+	}
+
+	var ids []uuid.UUID
+	var slugs []string
+	for _, ref := range refs {
+		if id, err := uuid.Parse(ref); err == nil {
+			ids = append(ids, id)
+			continue
+		}
+		slug, err := domain.NewSlug(ref)
+		if err != nil {
+			return nil, domain.InvalidField("invalid_compare_products", "products",
+				fmt.Sprintf("%q is neither a product ID nor a slug", ref))
+		}
+		slugs = append(slugs, string(slug))
+	}
+
+	rows, err := s.store.ListProductsForComparison(ctx, sqlc.ListProductsForComparisonParams{
+		Ids:   ids,
+		Slugs: slugs,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get products: %w", err)
+	}
+
+	// Resolve the references in request order, skipping a product referenced twice (once by ID, once by slug)
+	comparison := &PricingComparison{Features: []string{}}
+	seen := make(map[uuid.UUID]bool, len(rows))
+	productIDs := make([]uuid.UUID, 0, len(rows))
+	for _, ref := range refs {
+		var match *sqlc.ListProductsForComparisonRow
+		for i := range rows {
+			if rows[i].ID.String() == strings.ToLower(ref) || rows[i].Slug == strings.ToLower(ref) {
+				match = &rows[i]
+				break
+			}
+		}
+		if match == nil {
+			return nil, domain.NotFound("product_not_found", fmt.Sprintf("product %q not found", ref))
+		}
+		if seen[match.ID] {
+			continue
+		}
+		seen[match.ID] = true
+
+		product, err := SQLCToDomainProductFromSlugRow(sqlc.GetProductBySlugRow(*match))
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert product: %w", err)
+		}
+		comparison.Products = append(comparison.Products, &ProductPricing{
+			Product:     product,
+			CompanyName: match.CompanyName,
+			CompanySlug: match.CompanySlug,
+			Plans:       []*domain.PricingPlan{},
+		})
+		productIDs = append(productIDs, match.ID)
+	}
+
+	planRows, err := s.store.ListPricingPlansByProducts(ctx, productIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pricing plans: %w", err)
+	}
+
+	byProduct := make(map[uuid.UUID]*ProductPricing, len(comparison.Products))
+	for _, entry := range comparison.Products {
+		byProduct[entry.Product.ID] = entry
+	}
+
+	featureSeen := make(map[string]bool)
+	for _, row := range planRows {
+		entry := byProduct[row.ProductID]
+		plan := SQLCToDomainPricingPlan(row)
+		entry.Plans = append(entry.Plans, plan)
+
+		if plan.IsFree {
+			entry.HasFreeTier = true
+		}
+		if monthly, ok := plan.MonthlyPriceCents(); ok {
+			if entry.StartingMonthlyPriceCents == nil || monthly < *entry.StartingMonthlyPriceCents {
+				entry.StartingMonthlyPriceCents = &monthly
+				entry.StartingCurrency = plan.Currency
+			}
+		}
+
+		for _, feature := range plan.Features {
+			if !featureSeen[feature] {
+				featureSeen[feature] = true
+				comparison.Features = append(comparison.Features, feature)
+			}
+		}
+	}
+
+	return comparison, nil
+}
+
+// ensurePlanNameAvailable rejects a name already used by another plan of the product
+func (s *PricingService) ensurePlanNameAvailable(ctx context.Context, productID, planID uuid.UUID, name string) error {
+	existing, err := s.store.ListPricingPlansByProduct(ctx, productID)
+	if err != nil {
+		return fmt.Errorf("failed to list pricing plans: %w", err)
+	}
+
+	for _, plan := range existing {
+		if plan.ID != planID && plan.Name == name {
+			return domain.Conflict("plan_name_taken", fmt.Sprintf("product already has a plan named %q", name))
+		}
+	}
+	return nil
+}
+
+// newPricingPlan validates a plan request and normalizes its fields
+func newPricingPlan(req PricingPlanRequest) (*domain.PricingPlan, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, domain.InvalidField("plan_name_required", "name", "is required")
+	}
+
+	period, err := domain.NewBillingPeriod(req.BillingPeriod)
+	if err != nil {
+		return nil, domain.InvalidField("invalid_billing_period", "billing_period", "must be one of: monthly yearly one_time usage")
+	}
+
+	currency, err := domain.NewCurrency(req.Currency)
+	if err != nil {
+		return nil, domain.InvalidField("invalid_currency", "currency", "must be a three-letter ISO 4217 code")
+	}
+
+	if req.PricingUnit == "" {
+		req.PricingUnit = string(domain.UnitFlat)
+	}
+	unit, err := domain.NewPricingUnit(req.PricingUnit)
+	if err != nil {
+		return nil, domain.InvalidField("invalid_pricing_unit", "pricing_unit", "must be one of: flat per_seat per_usage")
+	}
+
+	if req.PriceCents < 0 {
+		return nil, domain.InvalidField("invalid_price", "price_cents", "must not be negative")
+	}
+	if req.IsFree && req.PriceCents != 0 {
+		return nil, domain.InvalidField("invalid_price", "price_cents", "must be 0 for a free plan")
+	}
+
+	usageUnit := strings.TrimSpace(req.UsageUnit)
+	if unit == domain.UnitPerUsage && usageUnit == "" {
+		return nil, domain.InvalidField("usage_unit_required", "usage_unit", "is required for per_usage plans")
+	}
+	if unit != domain.UnitPerUsage {
+		usageUnit = ""
+	}
+
+	return &domain.PricingPlan{
+		Name:          name,
+		BillingPeriod: period,
+		Currency:      currency,
+		PriceCents:    req.PriceCents,
+		IsFree:        req.IsFree,
+		PricingUnit:   unit,
+		UsageUnit:     usageUnit,
+		Features:      uniqueNonEmpty(req.Features),
+		SortOrder:     req.SortOrder,
+	}, nil
+}
+
+// uniqueNonEmpty trims each value and drops blanks and duplicates, keeping the first occurrence
+func uniqueNonEmpty(values []string) []string {
+	result := make([]string, 0, len(values))
+	seen := make(map[string]bool, len(values))
+	for _, v := range values {
+		v = strings.TrimSpace(v)
+		if v == "" || seen[v] {
+			continue
+		}
+		seen[v] = true
+		result = append(result, v)
+	}
+	return result
+}
+
+// SQLCToDomainPricingPlan converts a SQLC PricingPlan to a domain PricingPlan
+func SQLCToDomainPricingPlan(row sqlc.PricingPlan) *domain.PricingPlan {
+	usageUnit := ""
+	if row.UsageUnit != nil {
+		usageUnit = *row.UsageUnit
+	}
+
+	features := row.Features
+	if features == nil {
+		features = []string{}
+	}
+
+	return &domain.PricingPlan{
+		ID:            row.ID,
+		ProductID:     row.ProductID,
+		Name:          row.Name,
+		BillingPeriod: domain.BillingPeriod(row.BillingPeriod),
+		Currency:      domain.Currency(row.Currency),
+		PriceCents:    row.PriceCents,
+		IsFree:        row.IsFree,
+		PricingUnit:   domain.PricingUnit(row.PricingUnit),
+		UsageUnit:     usageUnit,
+		Features:      features,
+		SortOrder:     int(row.SortOrder),
+		CreatedAt:     row.CreatedAt.Time,
+		UpdatedAt:     row.UpdatedAt.Time,
+	}
+}
@@ -0,0 +1,141 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"ratemysoft-backend/internal/domain"
+	"ratemysoft-backend/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+// ProductSort is a sort key accepted by ListProducts
+type ProductSort string
+
+const (
+	SortNewest      ProductSort = "newest"
+	SortRating      ProductSort = "rating"
+	SortReviewCount ProductSort = "review_count"
+	SortName        ProductSort = "name"
+	SortTrending    ProductSort = "trending"
+)
+
+// productSorts whitelists the orderings; sort keys never reach the store directly
+var productSorts = map[ProductSort]repository.ProductOrder{
+	SortNewest:      repository.ProductsByNewest,
+	SortRating:      repository.ProductsByRating,
+	SortReviewCount: repository.ProductsByReviewCount,
+	SortName:        repository.ProductsByName,
+	SortTrending:    repository.ProductsByTrending,
+}
+
+// ProductListParams combines the optional filters and the sort of a product listing
+type ProductListParams struct {
+	Category     string     // optional; includes subcategories
+	Company      string     // optional; company ID or slug
+	MinRating    *float64   // optional
+	MinReviews   *int       // optional
+	Tags         TagFilter  // optional
+	CreatedAfter *time.Time // optional
+	Sort         ProductSort
+	Page         domain.PageRequest
+}
+
+// ProductListResult is one page of products with the total number of matches
+type ProductListResult struct {
+	Products []*domain.Product
+	Total    int64
+	Sort     ProductSort
+	Cursors  domain.PageCursors
+}
+
+// ListProducts retrieves a page of products matching every given filter, in the requested order
+func (s *ProductService) ListProducts(ctx context.Context, params ProductListParams) (*ProductListResult, error) {
+	if params.Sort == "" {
+		params.Sort = SortNewest
+	}
+	order, ok := productSorts[params.Sort]
+	if !ok {
+		return nil, domain.InvalidField("invalid_sort", "sort", "must be one of: newest rating review_count name trending")
+	}
+
+	var filter repository.ProductFilter
+
+	if params.Category != "" {
+		if err := requireCategory(ctx, s.store, params.Category); err != nil {
+			return nil, err
+		}
+		filter.Category = params.Category
+	}
+
+	if params.Company != "" {
+		if companyID, err := uuid.Parse(params.Company); err == nil {
+			filter.CompanyID = &companyID
+		} else {
+			slug, err := domain.NewSlug(params.Company)
+			if err != nil {
+				return nil, domain.InvalidField("invalid_company", "company", "must be a company ID or slug").Wrap(err)
+			}
+			filter.CompanySlug = string(slug)
+		}
+	}
+
+	if params.MinRating != nil {
+		if *params.MinRating < 0 || *params.MinRating > 5 {
+			return nil, domain.InvalidField("invalid_min_rating", "min_rating", "must be between 0 and 5")
+		}
+		filter.MinRating = params.MinRating
+	}
+
+	if params.MinReviews != nil {
+		if *params.MinReviews < 0 {
+			return nil, domain.InvalidField("invalid_min_reviews", "min_reviews", "must not be negative")
+		}
+		minReviews := int32(*params.MinReviews)
+		filter.MinReviews = &minReviews
+	}
+
+	tags, err := params.Tags.normalize()
+	if err != nil {
+		return nil, err
+	}
+	filter.Tags = tags.Tags
+	filter.MatchAllTags = tags.MatchAll
+
+	if params.CreatedAfter != nil {
+		createdAfter := params.CreatedAfter.UTC()
+		filter.CreatedAfter = &createdAfter
+	}
+
+	total, err := s.store.CountProductsMatching(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count products: %w", err)
+	}
+
+	rows, cursors, err := s.store.ListProductsPage(ctx, filter, order, params.Page)
+	if err != nil {
+		return nil, listingError("list products", err)
+	}
+
+	products := make([]*domain.Product, 0, len(rows))
+	for _, row := range rows {
+		product, err := SQLCToDomainProduct(row)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert product: %w", err)
+		}
+		products = append(products, product)
+	}
+
+	if err := attachProductTags(ctx, s.store, products); err != nil {
+		return nil, err
+	}
+
+	return &ProductListResult{
+		Products: products,
+		Total:    total,
+		Sort:     params.Sort,
+		Cursors:  cursors,
+	}, nil
+}
@@ -4,10 +4,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"html"
+	"strconv"
+	"strings"
 	"time"
 
 	"ratemysoft-backend/internal/domain"
 	"ratemysoft-backend/internal/models/sqlc"
+	"ratemysoft-backend/internal/repository"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
@@ -16,12 +20,14 @@ import (
 
 // ProductService handles product-related business logic
 type ProductService struct {
-	queries *sqlc.Queries
+	store repository.Store
+	authz *Authorizer
 }
 
-func NewProductService(queries *sqlc.Queries) *ProductService {
+func NewProductService(store repository.Store, authz *Authorizer) *ProductService {
 	return &ProductService{
-		queries: queries,
+		store: store,
+		authz: authz,
 	}
 }
 
@@ -46,45 +52,44 @@ type UpdateProductRequest struct {
 	DocsURL      string
 }
 
-// CreateProduct creates a new product
-func (s *ProductService) CreateProduct(ctx context.Context, req CreateProductRequest) (*domain.Product, error) {
+// CreateProduct creates a new product; listing under a company requires editor access to it
+func (s *ProductService) CreateProduct(ctx context.Context, actor domain.Actor, req CreateProductRequest) (*domain.Product, error) {
 	var companyID uuid.UUID
 	var err error
 
-	// Handle company ID - if not provided, use or create a default company
-	if req.CompanyID == "" {
-		// Use or create a default company for products without a specific company
-		companyID, err = s.getOrCreateDefaultCompany(ctx)
-		if err != nil {
-			return nil, fmt.Errorf("failed to get or create default company: %w", err)
-		}
-	} else {
+	// Handle company ID - if not provided, the product is listed under a default company,
+	// created along with it. The default company has no members, so only admins can edit
+	// those products afterwards.
+	if req.CompanyID != "" {
 		// Validate provided company ID
-		companyID, err = uuid.Parse(req.CompanyID)
+		companyID, err = parseID("company_id", req.CompanyID)
 		if err != nil {
-			return nil, fmt.Errorf("invalid company ID format: %w", err)
+			return nil, err
 		}
 
 		// Check if company exists
-		_, err = s.queries.GetCompany(ctx, companyID)
+		_, err = s.store.GetCompany(ctx, companyID)
 		if err != nil {
 			if errors.Is(err, pgx.ErrNoRows) {
-				return nil, fmt.Errorf("company not found")
+				return nil, domain.ErrCompanyNotFound
 			}
 			return nil, fmt.Errorf("failed to check company: %w", err)
 		}
+
+		if err := s.authz.RequireCompanyRole(ctx, actor, companyID, domain.CompanyEditor); err != nil {
+			return nil, err
+		}
 	}
 
 	// Validate slug format
 	slug, err := domain.NewSlug(req.Slug)
 	if err != nil {
-		return nil, fmt.Errorf("invalid slug format: %w", err)
+		return nil, invalidSlug(err)
 	}
 
 	// Validate category
-	category := domain.ProductCategory(req.Category)
-	if !isValidCategory(category) {
-		return nil, fmt.Errorf("invalid category: %s", req.Category)
+	if err := requireCategory(ctx, s.store, req.Category); err != nil {
+		return nil, err
 	}
 
 	productID := uuid.New()
@@ -115,23 +120,39 @@ func (s *ProductService) CreateProduct(ctx context.Context, req CreateProductReq
 	}
 
 	// Create product in database
-	product, err := s.queries.CreateProduct(ctx, sqlc.CreateProductParams{
-		ID:           productID,
-		CompanyID:    companyID,
-		Name:         req.Name,
-		Slug:         string(slug),
-		Category:     req.Category,
-		ShortTagline: shortTagline,
-		Description:  description,
-		HomepageUrl:  homepageURL,
-		DocsUrl:      docsURL,
-		AvgRating:    nil,
-		TotalReviews: 0,
-		CreatedAt:    now,
-		UpdatedAt:    now,
+	var product sqlc.Product
+	err = s.store.InTx(ctx, func(q repository.Queries) error {
+		if req.CompanyID == "" {
+			var err error
+			companyID, err = getOrCreateDefaultCompany(ctx, q)
+			if err != nil {
+				return fmt.Errorf("failed to get or create default company: %w", err)
+			}
+		}
+
+		var err error
+		product, err = q.CreateProduct(ctx, sqlc.CreateProductParams{
+			ID:           productID,
+			CompanyID:    companyID,
+			Name:         req.Name,
+			Slug:         string(slug),
+			Category:     req.Category,
+			ShortTagline: shortTagline,
+			Description:  description,
+			HomepageUrl:  homepageURL,
+			DocsUrl:      docsURL,
+			AvgRating:    nil,
+			TotalReviews: 0,
+			CreatedAt:    now,
+			UpdatedAt:    now,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create product: %w", err)
+		}
+		return recordAudit(ctx, q, actor.UserID, domain.AuditCreate, domain.AuditEntityProduct, productID, nil, product)
 	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to create product: %w", err)
+		return nil, err
 	}
 
 	return SQLCToDomainProduct(product)
@@ -139,20 +160,82 @@ func (s *ProductService) CreateProduct(ctx context.Context, req CreateProductReq
 
 // GetProductByID retrieves a product by its ID
 func (s *ProductService) GetProductByID(ctx context.Context, productID string) (*domain.Product, error) {
-	parsedID, err := uuid.Parse(productID)
+	parsedID, err := parseID("product_id", productID)
 	if err != nil {
-		return nil, fmt.Errorf("invalid product ID format: %w", err)
+		return nil, err
 	}
 
-	product, err := s.queries.GetProduct(ctx, parsedID)
+	product, err := s.store.GetProduct(ctx, parsedID)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return nil, fmt.Errorf("product not found")
+			return nil, domain.ErrProductNotFound
 		}
 		return nil, fmt.Errorf("failed to get product: %w", err)
 	}
 
-	return SQLCToDomainProduct(product)
+	domainProduct, err := SQLCToDomainProduct(product)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := attachProductTags(ctx, s.store, []*domain.Product{domainProduct}); err != nil {
+		return nil, err
+	}
+	return domainProduct, nil
+}
+
+// GetProductStats retrieves the rating histogram and per-dimension averages of a product's published reviews
+func (s *ProductService) GetProductStats(ctx context.Context, productID string) (*domain.ProductStats, error) {
+	parsedID, err := parseID("product_id", productID)
+	if err != nil {
+		return nil, err
+	}
+
+	product, err := s.store.GetProduct(ctx, parsedID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrProductNotFound
+		}
+		return nil, fmt.Errorf("failed to get product: %w", err)
+	}
+
+	stats := &domain.ProductStats{
+		ProductID:    product.ID,
+		AvgRating:    product.AvgRating,
+		TotalReviews: int(product.TotalReviews),
+		Dimensions:   []domain.DimensionStat{},
+	}
+
+	// Products without any reviews yet have no histogram row
+	histogram, err := s.store.GetProductRatingStats(ctx, parsedID)
+	if err == nil {
+		stats.Histogram = [5]int{
+			int(histogram.Star1),
+			int(histogram.Star2),
+			int(histogram.Star3),
+			int(histogram.Star4),
+			int(histogram.Star5),
+		}
+		if histogram.UpdatedAt.Valid {
+			stats.UpdatedAt = &histogram.UpdatedAt.Time
+		}
+	} else if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("failed to get rating histogram: %w", err)
+	}
+
+	dimensions, err := s.store.ListProductDimensionStats(ctx, parsedID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dimension stats: %w", err)
+	}
+
+	for _, row := range dimensions {
+		stats.Dimensions = append(stats.Dimensions, domain.DimensionStat{
+			Dimension: domain.RatingDimension(row.Dimension),
+			AvgRating: row.AvgRating,
+			Count:     int(row.RatingCount),
+		})
+	}
+	return stats, nil
 }
 
 // GetProductBySlug retrieves a product by its slug (with company info)
@@ -160,13 +243,13 @@ func (s *ProductService) GetProductBySlug(ctx context.Context, slug string) (*do
 	// Validate slug format
 	_, err := domain.NewSlug(slug)
 	if err != nil {
-		return nil, nil, nil, fmt.Errorf("invalid slug format: %w", err)
+		return nil, nil, nil, invalidSlug(err)
 	}
 
-	productRow, err := s.queries.GetProductBySlug(ctx, slug)
+	productRow, err := s.store.GetProductBySlug(ctx, slug)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return nil, nil, nil, fmt.Errorf("product not found")
+			return nil, nil, nil, domain.ErrProductNotFound
 		}
 		return nil, nil, nil, fmt.Errorf("failed to get product: %w", err)
 	}
@@ -176,116 +259,166 @@ func (s *ProductService) GetProductBySlug(ctx context.Context, slug string) (*do
 		return nil, nil, nil, err
 	}
 
+	if err := attachProductTags(ctx, s.store, []*domain.Product{product}); err != nil {
+		return nil, nil, nil, err
+	}
+
 	return product, &productRow.CompanyName, &productRow.CompanySlug, nil
 }
 
-// ListProducts retrieves a paginated list of products
-func (s *ProductService) ListProducts(ctx context.Context, limit, offset int32) ([]*domain.Product, error) {
-	productRows, err := s.queries.ListProducts(ctx, sqlc.ListProductsParams{
-		Limit:  limit,
-		Offset: offset,
-	})
-	if err != nil {
-		return nil, fmt.Errorf("failed to list products: %w", err)
-	}
+// ProductSearchParams narrows a full-text product search
+type ProductSearchParams struct {
+	Query     string
+	Category  string   // optional; includes subcategories
+	MinRating *float64 // optional
+	Tags      TagFilter
+	Page      domain.PageRequest
+}
 
-	return convertProductRowsToDomain(productRows)
+// ProductSearchResult is one page of ranked search hits with the total and facet counts for the query
+type ProductSearchResult struct {
+	Hits    []*domain.ProductSearchHit
+	Total   int64
+	Facets  domain.SearchFacets
+	Cursors domain.PageCursors
 }
 
-// ListProductsByCategory retrieves products filtered by category
-func (s *ProductService) ListProductsByCategory(ctx context.Context, category string, limit, offset int32) ([]*domain.Product, error) {
-	// Validate category
-	cat := domain.ProductCategory(category)
-	if !isValidCategory(cat) {
-		return nil, fmt.Errorf("invalid category: %s", category)
+// Markers ts_headline wraps matched terms in; private-use code points never occur in product text
+const (
+	highlightStart = "\ue000"
+	highlightStop  = "\ue001"
+)
+
+// SearchProducts runs a weighted full-text search over name, tagline, description and company name,
+// falling back to trigram similarity on names so misspelled queries still match
+func (s *ProductService) SearchProducts(ctx context.Context, params ProductSearchParams) (*ProductSearchResult, error) {
+	if params.Category != "" {
+		if err := requireCategory(ctx, s.store, params.Category); err != nil {
+			return nil, err
+		}
 	}
 
-	productRows, err := s.queries.ListProductsByCategory(ctx, sqlc.ListProductsByCategoryParams{
-		Category: category,
-		Limit:    limit,
-		Offset:   offset,
-	})
+	if params.MinRating != nil && (*params.MinRating < 0 || *params.MinRating > 5) {
+		return nil, domain.InvalidField("invalid_min_rating", "min_rating", "must be between 0 and 5")
+	}
+
+	tags, err := params.Tags.normalize()
 	if err != nil {
-		return nil, fmt.Errorf("failed to list products by category: %w", err)
+		return nil, err
 	}
 
-	return convertCategoryProductRowsToDomain(productRows)
-}
+	search := repository.ProductSearch{
+		Query:        params.Query,
+		Category:     params.Category,
+		MinRating:    params.MinRating,
+		Tags:         tags.Tags,
+		MatchAllTags: tags.MatchAll,
+	}
 
-// SearchProducts searches for products by name or company name
-func (s *ProductService) SearchProducts(ctx context.Context, query string, limit, offset int32) ([]*domain.Product, error) {
-	// Add wildcards for ILIKE search
-	searchQuery := "%" + query + "%"
+	productRows, cursors, err := s.store.SearchProductsPage(ctx, search, params.Page)
+	if err != nil {
+		return nil, listingError("search products", err)
+	}
 
-	productRows, err := s.queries.SearchProducts(ctx, sqlc.SearchProductsParams{
-		Name:   searchQuery,
-		Limit:  limit,
-		Offset: offset,
-	})
+	total, err := s.store.CountSearchMatches(ctx, search)
 	if err != nil {
-		return nil, fmt.Errorf("failed to search products: %w", err)
+		return nil, fmt.Errorf("failed to count search results: %w", err)
 	}
 
-	return convertSearchProductRowsToDomain(productRows)
-}
+	categoryFacets, err := s.store.SearchProductCategoryFacets(ctx, params.Query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get category facets: %w", err)
+	}
 
-// GetProductsByCompany retrieves all products for a company
-func (s *ProductService) GetProductsByCompany(ctx context.Context, companyID string, limit, offset int32) ([]*domain.Product, error) {
-	parsedID, err := uuid.Parse(companyID)
+	ratingFacets, err := s.store.SearchProductRatingFacets(ctx, params.Query)
 	if err != nil {
-		return nil, fmt.Errorf("invalid company ID format: %w", err)
+		return nil, fmt.Errorf("failed to get rating facets: %w", err)
 	}
 
-	products, err := s.queries.GetProductsByCompany(ctx, sqlc.GetProductsByCompanyParams{
-		CompanyID: parsedID,
-		Limit:     limit,
-		Offset:    offset,
-	})
+	hits, err := convertSearchProductRowsToDomain(productRows)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get products by company: %w", err)
+		return nil, err
 	}
 
-	// Convert to domain products
-	domainProducts := make([]*domain.Product, 0, len(products))
-	for _, product := range products {
-		domainProduct, err := SQLCToDomainProduct(product)
-		if err != nil {
-			return nil, fmt.Errorf("failed to convert product: %w", err)
-		}
-		domainProducts = append(domainProducts, domainProduct)
+	products := make([]*domain.Product, 0, len(hits))
+	for _, hit := range hits {
+		products = append(products, hit.Product)
+	}
+	if err := attachProductTags(ctx, s.store, products); err != nil {
+		return nil, err
+	}
+
+	facets := domain.SearchFacets{
+		Categories:    make([]domain.FacetCount, 0, len(categoryFacets)),
+		RatingBuckets: make([]domain.FacetCount, 0, len(ratingFacets)),
+	}
+	for _, f := range categoryFacets {
+		facets.Categories = append(facets.Categories, domain.FacetCount{Value: f.Category, Count: f.Count})
+	}
+	for _, f := range ratingFacets {
+		facets.RatingBuckets = append(facets.RatingBuckets, domain.FacetCount{Value: strconv.Itoa(int(f.RatingBucket)), Count: f.Count})
 	}
 
-	return domainProducts, nil
+	return &ProductSearchResult{
+		Hits:    hits,
+		Total:   total,
+		Facets:  facets,
+		Cursors: cursors,
+	}, nil
+}
+
+// markHighlights escapes headline text for HTML and turns the search markers into <mark> tags
+func markHighlights(text string) string {
+	escaped := html.EscapeString(text)
+	escaped = strings.ReplaceAll(escaped, highlightStart, "<mark>")
+	return strings.ReplaceAll(escaped, highlightStop, "</mark>")
 }
 
-// UpdateProduct updates an existing product
-func (s *ProductService) UpdateProduct(ctx context.Context, productID string, req UpdateProductRequest) (*domain.Product, error) {
-	parsedID, err := uuid.Parse(productID)
+// GetProductsByCompany retrieves a page of a company's products, newest first
+func (s *ProductService) GetProductsByCompany(ctx context.Context, companyID string, page domain.PageRequest) (*ProductListResult, error) {
+	parsedID, err := parseID("company_id", companyID)
 	if err != nil {
-		return nil, fmt.Errorf("invalid product ID format: %w", err)
+		return nil, err
+	}
+
+	return s.ListProducts(ctx, ProductListParams{
+		Company: parsedID.String(),
+		Sort:    SortNewest,
+		Page:    page,
+	})
+}
+
+// UpdateProduct updates an existing product (company editors and admins only)
+func (s *ProductService) UpdateProduct(ctx context.Context, actor domain.Actor, productID string, req UpdateProductRequest) (*domain.Product, error) {
+	parsedID, err := parseID("product_id", productID)
+	if err != nil {
+		return nil, err
 	}
 
 	// Validate slug format
 	slug, err := domain.NewSlug(req.Slug)
 	if err != nil {
-		return nil, fmt.Errorf("invalid slug format: %w", err)
+		return nil, invalidSlug(err)
 	}
 
 	// Validate category
-	category := domain.ProductCategory(req.Category)
-	if !isValidCategory(category) {
-		return nil, fmt.Errorf("invalid category: %s", req.Category)
+	if err := requireCategory(ctx, s.store, req.Category); err != nil {
+		return nil, err
 	}
 
-	// Check if product exists
-	_, err = s.queries.GetProduct(ctx, parsedID)
+	// Check if product exists and the actor may edit it
+	existingProduct, err := s.store.GetProduct(ctx, parsedID)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return nil, fmt.Errorf("product not found")
+			return nil, domain.ErrProductNotFound
 		}
 		return nil, fmt.Errorf("failed to get product: %w", err)
 	}
 
+	if err := s.authz.RequireCompanyRole(ctx, actor, existingProduct.CompanyID, domain.CompanyEditor); err != nil {
+		return nil, err
+	}
+
 	now := pgtype.Timestamptz{
 		Time:  time.Now().UTC(),
 		Valid: true,
@@ -313,68 +446,72 @@ func (s *ProductService) UpdateProduct(ctx context.Context, productID string, re
 	}
 
 	// Update product in database
-	product, err := s.queries.UpdateProduct(ctx, sqlc.UpdateProductParams{
-		ID:           parsedID,
-		Name:         req.Name,
-		Slug:         string(slug),
-		Category:     req.Category,
-		ShortTagline: shortTagline,
-		Description:  description,
-		HomepageUrl:  homepageURL,
-		DocsUrl:      docsURL,
-		AvgRating:    nil, // Will be recalculated by reviews
-		TotalReviews: 0,   // Will be recalculated by reviews
-		UpdatedAt:    now,
+	var product sqlc.Product
+	err = s.store.InTx(ctx, func(q repository.Queries) error {
+		var err error
+		product, err = q.UpdateProduct(ctx, sqlc.UpdateProductParams{
+			ID:           parsedID,
+			Name:         req.Name,
+			Slug:         string(slug),
+			Category:     req.Category,
+			ShortTagline: shortTagline,
+			Description:  description,
+			HomepageUrl:  homepageURL,
+			DocsUrl:      docsURL,
+			AvgRating:    existingProduct.AvgRating,    // Maintained by the review service
+			TotalReviews: existingProduct.TotalReviews, // Maintained by the review service
+			UpdatedAt:    now,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to update product: %w", err)
+		}
+		return recordAudit(ctx, q, actor.UserID, domain.AuditUpdate, domain.AuditEntityProduct, parsedID, existingProduct, product)
 	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to update product: %w", err)
+		return nil, err
 	}
 
 	return SQLCToDomainProduct(product)
 }
 
-// DeleteProduct soft deletes a product
-func (s *ProductService) DeleteProduct(ctx context.Context, productID string) error {
-	parsedID, err := uuid.Parse(productID)
+// DeleteProduct soft deletes a product (company editors and admins only)
+func (s *ProductService) DeleteProduct(ctx context.Context, actor domain.Actor, productID string) error {
+	parsedID, err := parseID("product_id", productID)
 	if err != nil {
-		return fmt.Errorf("invalid product ID format: %w", err)
+		return err
 	}
 
-	// Check if product exists
-	_, err = s.queries.GetProduct(ctx, parsedID)
+	// Check if product exists and the actor may delete it
+	existingProduct, err := s.store.GetProduct(ctx, parsedID)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return fmt.Errorf("product not found")
+			return domain.ErrProductNotFound
 		}
 		return fmt.Errorf("failed to get product: %w", err)
 	}
 
-	// Soft delete the product
-	err = s.queries.SoftDeleteProduct(ctx, parsedID)
-	if err != nil {
-		return fmt.Errorf("failed to delete product: %w", err)
+	if err := s.authz.RequireCompanyRole(ctx, actor, existingProduct.CompanyID, domain.CompanyEditor); err != nil {
+		return err
 	}
 
-	return nil
-}
-
-// CountProducts returns the total number of products
-func (s *ProductService) CountProducts(ctx context.Context) (int64, error) {
-	count, err := s.queries.CountProducts(ctx)
-	if err != nil {
-		return 0, fmt.Errorf("failed to count products: %w", err)
-	}
-	return count, nil
+	// Soft delete the product
+	return s.store.InTx(ctx, func(q repository.Queries) error {
+		err := q.SoftDeleteProduct(ctx, parsedID)
+		if err != nil {
+			return fmt.Errorf("failed to delete product: %w", err)
+		}
+		return recordAudit(ctx, q, actor.UserID, domain.AuditDelete, domain.AuditEntityProduct, parsedID, existingProduct, nil)
+	})
 }
 
 // CountProductsByCompany returns the total number of products for a company
 func (s *ProductService) CountProductsByCompany(ctx context.Context, companyID string) (int64, error) {
-	parsedID, err := uuid.Parse(companyID)
+	parsedID, err := parseID("company_id", companyID)
 	if err != nil {
-		return 0, fmt.Errorf("invalid company ID format: %w", err)
+		return 0, err
 	}
 
-	count, err := s.queries.CountProductsByCompany(ctx, parsedID)
+	count, err := s.store.CountProductsByCompany(ctx, parsedID)
 	if err != nil {
 		return 0, fmt.Errorf("failed to count products by company: %w", err)
 	}
@@ -384,10 +521,10 @@ func (s *ProductService) CountProductsByCompany(ctx context.Context, companyID s
 // Helper functions
 
 // getOrCreateDefaultCompany returns the ID of a default company for products without a specific company
-func (s *ProductService) getOrCreateDefaultCompany(ctx context.Context) (uuid.UUID, error) {
+func getOrCreateDefaultCompany(ctx context.Context, q repository.CompanyRepository) (uuid.UUID, error) {
 	// Try to find existing default company by slug
 	defaultCompanySlug := "independent"
-	existingCompany, err := s.queries.GetCompanyBySlug(ctx, defaultCompanySlug)
+	existingCompany, err := q.GetCompanyBySlug(ctx, defaultCompanySlug)
 	if err == nil {
 		// Default company exists, return its ID
 		return existingCompany.ID, nil
@@ -405,7 +542,7 @@ func (s *ProductService) getOrCreateDefaultCompany(ctx context.Context) (uuid.UU
 		Valid: true,
 	}
 
-	_, err = s.queries.CreateCompany(ctx, sqlc.CreateCompanyParams{
+	_, err = q.CreateCompany(ctx, sqlc.CreateCompanyParams{
 		ID:        companyID,
 		Name:      "Independent",
 		Website:   nil,
@@ -421,53 +558,23 @@ func (s *ProductService) getOrCreateDefaultCompany(ctx context.Context) (uuid.UU
 	return companyID, nil
 }
 
-func isValidCategory(cat domain.ProductCategory) bool {
-	switch cat {
-	case domain.CategoryHosting,
-		domain.CategoryFeatureToggles,
-		domain.CategoryCI,
-		domain.CategoryObservability,
-		domain.CategoryOther:
-		return true
-	default:
-		return false
-	}
-}
-
-func convertProductRowsToDomain(rows []sqlc.ListProductsRow) ([]*domain.Product, error) {
-	domainProducts := make([]*domain.Product, 0, len(rows))
-	for _, row := range rows {
-		domainProduct, err := SQLCToDomainProductFromListRow(row)
-		if err != nil {
-			return nil, fmt.Errorf("failed to convert product: %w", err)
-		}
-		domainProducts = append(domainProducts, domainProduct)
-	}
-	return domainProducts, nil
-}
-
-func convertCategoryProductRowsToDomain(rows []sqlc.ListProductsByCategoryRow) ([]*domain.Product, error) {
-	domainProducts := make([]*domain.Product, 0, len(rows))
-	for _, row := range rows {
-		domainProduct, err := SQLCToDomainProductFromCategoryRow(row)
-		if err != nil {
-			return nil, fmt.Errorf("failed to convert product: %w", err)
-		}
-		domainProducts = append(domainProducts, domainProduct)
-	}
-	return domainProducts, nil
-}
-
-func convertSearchProductRowsToDomain(rows []sqlc.SearchProductsRow) ([]*domain.Product, error) {
-	domainProducts := make([]*domain.Product, 0, len(rows))
+func convertSearchProductRowsToDomain(rows []sqlc.SearchProductsRow) ([]*domain.ProductSearchHit, error) {
+	hits := make([]*domain.ProductSearchHit, 0, len(rows))
 	for _, row := range rows {
 		domainProduct, err := SQLCToDomainProductFromSearchRow(row)
 		if err != nil {
 			return nil, fmt.Errorf("failed to convert product: %w", err)
 		}
-		domainProducts = append(domainProducts, domainProduct)
-	}
-	return domainProducts, nil
+		hits = append(hits, &domain.ProductSearchHit{
+			Product:       domainProduct,
+			CompanyName:   row.CompanyName,
+			CompanySlug:   row.CompanySlug,
+			Rank:          row.Rank,
+			NameHighlight: markHighlights(row.NameHighlight),
+			Snippet:       markHighlights(row.Snippet),
+		})
+	}
+	return hits, nil
 }
 
 // SQLCToDomainProduct converts a SQLC Product to a domain Product
@@ -533,68 +640,6 @@ func SQLCToDomainProduct(sqlcProduct sqlc.Product) (*domain.Product, error) {
 	}, nil
 }
 
-// SQLCToDomainProductFromListRow converts a ListProductsRow to a domain Product
-func SQLCToDomainProductFromListRow(row sqlc.ListProductsRow) (*domain.Product, error) {
-	slug, err := domain.NewSlug(row.Slug)
-	if err != nil {
-		return nil, err
-	}
-
-	createdAt := time.Time{}
-	if row.CreatedAt.Valid {
-		createdAt = row.CreatedAt.Time
-	}
-
-	updatedAt := time.Time{}
-	if row.UpdatedAt.Valid {
-		updatedAt = row.UpdatedAt.Time
-	}
-
-	var deletedAt *time.Time
-	if row.DeletedAt.Valid {
-		deletedAt = &row.DeletedAt.Time
-	}
-
-	shortTagline := ""
-	if row.ShortTagline != nil {
-		shortTagline = *row.ShortTagline
-	}
-
-	description := ""
-	if row.Description != nil {
-		description = *row.Description
-	}
-
-	homepageURL := ""
-	if row.HomepageUrl != nil {
-		homepageURL = *row.HomepageUrl
-	}
-
-	docsURL := ""
-	if row.DocsUrl != nil {
-		docsURL = *row.DocsUrl
-	}
-
-	totalReviews := int(row.TotalReviews)
-
-	return &domain.Product{
-		ID:           row.ID,
-		CompanyID:    row.CompanyID,
-		Name:         row.Name,
-		Slug:         slug,
-		Category:     domain.ProductCategory(row.Category),
-		ShortTagline: shortTagline,
-		Description:  description,
-		HomepageURL:  homepageURL,
-		DocsURL:      docsURL,
-		AvgRating:    row.AvgRating,
-		TotalReviews: totalReviews,
-		CreatedAt:    createdAt,
-		UpdatedAt:    updatedAt,
-		DeletedAt:    deletedAt,
-	}, nil
-}
-
 // SQLCToDomainProductFromSlugRow converts a GetProductBySlugRow to a domain Product
 func SQLCToDomainProductFromSlugRow(row sqlc.GetProductBySlugRow) (*domain.Product, error) {
 	slug, err := domain.NewSlug(row.Slug)
@@ -657,68 +702,6 @@ func SQLCToDomainProductFromSlugRow(row sqlc.GetProductBySlugRow) (*domain.Produ
 	}, nil
 }
 
-// SQLCToDomainProductFromCategoryRow converts a ListProductsByCategoryRow to a domain Product
-func SQLCToDomainProductFromCategoryRow(row sqlc.ListProductsByCategoryRow) (*domain.Product, error) {
-	slug, err := domain.NewSlug(row.Slug)
-	if err != nil {
-		return nil, err
-	}
-
-	createdAt := time.Time{}
-	if row.CreatedAt.Valid {
-		createdAt = row.CreatedAt.Time
-	}
-
-	updatedAt := time.Time{}
-	if row.UpdatedAt.Valid {
-		updatedAt = row.UpdatedAt.Time
-	}
-
-	var deletedAt *time.Time
-	if row.DeletedAt.Valid {
-		deletedAt = &row.DeletedAt.Time
-	}
-
-	shortTagline := ""
-	if row.ShortTagline != nil {
-		shortTagline = *row.ShortTagline
-	}
-
-	description := ""
-	if row.Description != nil {
-		description = *row.Description
-	}
-
-	homepageURL := ""
-	if row.HomepageUrl != nil {
-		homepageURL = *row.HomepageUrl
-	}
-
-	docsURL := ""
-	if row.DocsUrl != nil {
-		docsURL = *row.DocsUrl
-	}
-
-	totalReviews := int(row.TotalReviews)
-
-	return &domain.Product{
-		ID:           row.ID,
-		CompanyID:    row.CompanyID,
-		Name:         row.Name,
-		Slug:         slug,
-		Category:     domain.ProductCategory(row.Category),
-		ShortTagline: shortTagline,
-		Description:  description,
-		HomepageURL:  homepageURL,
-		DocsURL:      docsURL,
-		AvgRating:    row.AvgRating,
-		TotalReviews: totalReviews,
-		CreatedAt:    createdAt,
-		UpdatedAt:    updatedAt,
-		DeletedAt:    deletedAt,
-	}, nil
-}
-
 // SQLCToDomainProductFromSearchRow converts a SearchProductsRow to a domain Product
 func SQLCToDomainProductFromSearchRow(row sqlc.SearchProductsRow) (*domain.Product, error) {
 	slug, err := domain.NewSlug(row.Slug)
@@ -0,0 +1,111 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"ratemysoft-backend/internal/domain"
+	"ratemysoft-backend/internal/repository/memory"
+)
+
+func TestCreateProductValidatesCompanyAndCategory(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	authz := NewAuthorizer(store)
+	companies := NewCompanyService(store, authz)
+	svc := NewProductService(store, authz)
+	owner := seedUser(t, store, "owner", domain.RoleUser)
+	stranger := seedUser(t, store, "stranger", domain.RoleUser)
+
+	company, err := companies.CreateCompany(ctx, owner, CreateCompanyRequest{Name: "Acme", Slug: "acme"})
+	if err != nil {
+		t.Fatalf("CreateCompany: %v", err)
+	}
+	req := CreateProductRequest{CompanyID: company.ID.String(), Name: "Widget", Slug: "widget", Category: "hosting"}
+
+	_, err = svc.CreateProduct(ctx, stranger, req)
+	assertErrorIs(t, err, domain.ErrForbidden)
+
+	badCategory := req
+	badCategory.Category = "spaceships"
+	_, err = svc.CreateProduct(ctx, owner, badCategory)
+	assertErrorIs(t, err, domain.ErrValidation)
+
+	product, err := svc.CreateProduct(ctx, owner, req)
+	if err != nil {
+		t.Fatalf("CreateProduct: %v", err)
+	}
+
+	got, companyName, companySlug, err := svc.GetProductBySlug(ctx, "widget")
+	if err != nil {
+		t.Fatalf("GetProductBySlug: %v", err)
+	}
+	if got.ID != product.ID || *companyName != "Acme" || *companySlug != "acme" {
+		t.Errorf("GetProductBySlug = %s, %s, %s; want %s in Acme", got.ID, *companyName, *companySlug, product.ID)
+	}
+	if n, _ := svc.CountProductsByCompany(ctx, company.ID.String()); n != 1 {
+		t.Errorf("CountProductsByCompany = %d, want 1", n)
+	}
+}
+
+func TestUpdateAndDeleteProduct(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	svc := NewProductService(store, NewAuthorizer(store))
+	owner := seedUser(t, store, "owner", domain.RoleUser)
+	stranger := seedUser(t, store, "stranger", domain.RoleUser)
+	product := seedProduct(t, store, owner, "widget")
+
+	update := UpdateProductRequest{Name: "Widget Pro", Slug: "widget-pro", Category: "observability"}
+	_, err := svc.UpdateProduct(ctx, stranger, product.ID.String(), update)
+	assertErrorIs(t, err, domain.ErrForbidden)
+
+	updated, err := svc.UpdateProduct(ctx, owner, product.ID.String(), update)
+	if err != nil {
+		t.Fatalf("UpdateProduct: %v", err)
+	}
+	if updated.Name != "Widget Pro" || updated.Category != "observability" {
+		t.Errorf("updated product = %+v", updated)
+	}
+
+	if err := svc.DeleteProduct(ctx, owner, product.ID.String()); err != nil {
+		t.Fatalf("DeleteProduct: %v", err)
+	}
+	_, err = svc.GetProductByID(ctx, product.ID.String())
+	assertErrorIs(t, err, domain.ErrProductNotFound)
+
+	// create, update and delete are each audited
+	if events := store.AuditEvents(); len(events) != 4 {
+		t.Errorf("audit events = %d, want company create plus three product events", len(events))
+	}
+}
+
+func TestListProductsPagesByCursor(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	svc := NewProductService(store, NewAuthorizer(store))
+	owner := seedUser(t, store, "owner", domain.RoleUser)
+	for _, slug := range []string{"bravo", "alpha", "charlie"} {
+		seedProduct(t, store, owner, slug)
+	}
+
+	first, err := svc.ListProducts(ctx, ProductListParams{Sort: SortName, Page: domain.PageRequest{Limit: 2}})
+	if err != nil {
+		t.Fatalf("ListProducts: %v", err)
+	}
+	if first.Total != 3 || len(first.Products) != 2 || first.Products[0].Slug != "alpha" || first.Cursors.Next == nil {
+		t.Fatalf("first page = %d of %d, cursors %+v; want alpha, bravo of 3 with a next cursor", len(first.Products), first.Total, first.Cursors)
+	}
+
+	second, err := svc.ListProducts(ctx, ProductListParams{Sort: SortName, Page: domain.PageRequest{Limit: 2, Cursor: first.Cursors.Next}})
+	if err != nil {
+		t.Fatalf("ListProducts: %v", err)
+	}
+	if len(second.Products) != 1 || second.Products[0].Slug != "charlie" || second.Cursors.Next != nil {
+		t.Errorf("second page = %+v, want charlie alone", second.Products)
+	}
+
+	// A cursor only continues the order it was issued for
+	_, err = svc.ListProducts(ctx, ProductListParams{Sort: SortNewest, Page: domain.PageRequest{Limit: 2, Cursor: first.Cursors.Next}})
+	assertErrorIs(t, err, domain.ErrValidation)
+}
@@ -0,0 +1,94 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"ratemysoft-backend/internal/domain"
+	"ratemysoft-backend/internal/repository"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// topReviewersPerCategory is how many reviewers of each category get a badge
+const topReviewersPerCategory = 3
+
+// ReputationService serves public reviewer profiles. Reputation scores and badges are
+// precomputed into user_reputation and reviewer_badges by Refresh, which a background job
+// runs periodically; the formula is documented in queries/reputation.sql.
+type ReputationService struct {
+	store repository.Store
+}
+
+func NewReputationService(store repository.Store) *ReputationService {
+	return &ReputationService{
+		store: store,
+	}
+}
+
+// Refresh recomputes every reputation score and badge in a single transaction,
+// so readers never see a partially rebuilt ranking
+func (s *ReputationService) Refresh(ctx context.Context) error {
+	return s.store.InTx(ctx, func(q repository.Queries) error {
+		// Concurrent refreshes (e.g. from several API instances) would collide on the primary keys
+		if err := q.LockReputationRefresh(ctx); err != nil {
+			return fmt.Errorf("failed to lock reputation: %w", err)
+		}
+
+		if err := q.DeleteUserReputation(ctx); err != nil {
+			return fmt.Errorf("failed to clear reputation: %w", err)
+		}
+		if err := q.RefreshUserReputation(ctx); err != nil {
+			return fmt.Errorf("failed to refresh reputation: %w", err)
+		}
+
+		if err := q.DeleteReviewerBadges(ctx); err != nil {
+			return fmt.Errorf("failed to clear badges: %w", err)
+		}
+		if err := q.RefreshReviewerBadges(ctx, topReviewersPerCategory); err != nil {
+			return fmt.Errorf("failed to refresh badges: %w", err)
+		}
+		return nil
+	})
+}
+
+// GetPublicProfile retrieves the public profile of the user with this handle.
+// Deleted accounts have no profile.
+func (s *ReputationService) GetPublicProfile(ctx context.Context, handle string) (*domain.PublicProfile, error) {
+	row, err := s.store.GetPublicProfile(ctx, handle)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to get profile: %w", err)
+	}
+
+	badges, err := s.store.ListReviewerBadges(ctx, row.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get badges: %w", err)
+	}
+
+	profile := &domain.PublicProfile{
+		UserID:       row.ID,
+		Handle:       row.Handle,
+		JoinedAt:     row.CreatedAt.Time,
+		ReviewCount:  int(row.ReviewCount),
+		HelpfulVotes: int(row.HelpfulVotes),
+		Reputation:   int(row.Reputation),
+		Badges:       make([]domain.ReviewerBadge, 0, len(badges)),
+	}
+	if row.RefreshedAt.Valid {
+		profile.RefreshedAt = &row.RefreshedAt.Time
+	}
+
+	for _, badge := range badges {
+		profile.Badges = append(profile.Badges, domain.ReviewerBadge{
+			Category:     domain.Slug(badge.Category),
+			CategoryName: badge.CategoryName,
+			Rank:         int(badge.Rank),
+			HelpfulVotes: int(badge.HelpfulVotes),
+		})
+	}
+	return profile, nil
+}
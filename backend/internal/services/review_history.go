@@ -0,0 +1,251 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"ratemysoft-backend/internal/domain"
+	"ratemysoft-backend/internal/models/sqlc"
+	"ratemysoft-backend/internal/repository"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// GetReviewRevisions retrieves the superseded versions of a review, oldest first, each with a
+// summary of the edit that replaced it. Only the author and admins see the full text of old
+// versions, and of unpublished reviews only they see anything at all; fullText reports which
+// view was returned. viewer is nil for anonymous requests.
+func (s *ReviewService) GetReviewRevisions(ctx context.Context, reviewID string, viewer *domain.Actor) (revisions []*domain.ReviewRevision, fullText bool, err error) {
+	parsedID, err := parseID("review_id", reviewID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	current, err := s.GetReviewByID(ctx, reviewID, viewer)
+	if err != nil {
+		return nil, false, err
+	}
+	fullText = canSeeUnpublished(viewer, current.UserID)
+
+	rows, err := s.store.ListReviewRevisions(ctx, parsedID)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get review revisions: %w", err)
+	}
+
+	revisions = make([]*domain.ReviewRevision, 0, len(rows))
+	for _, row := range rows {
+		revision, err := sqlcToDomainReviewRevision(row)
+		if err != nil {
+			return nil, false, err
+		}
+		revisions = append(revisions, revision)
+	}
+
+	// Each version is compared with the one that replaced it; the newest with the live review
+	for i, revision := range revisions {
+		next := &domain.ReviewRevision{
+			Title:      current.Title,
+			Body:       current.Body,
+			Rating:     current.Rating,
+			SubRatings: current.SubRatings,
+		}
+		if i+1 < len(revisions) {
+			next = revisions[i+1]
+		}
+		revision.Changes = domain.ReviewChanges{
+			TitleChanged:      revision.Title != next.Title,
+			BodyChanged:       revision.Body != next.Body,
+			SubRatingsChanged: !sameSubRatings(revision.SubRatings, next.SubRatings),
+			RatingFrom:        revision.Rating,
+			RatingTo:          next.Rating,
+		}
+	}
+
+	if !fullText {
+		for _, revision := range revisions {
+			revision.Title = ""
+			revision.Body = ""
+		}
+	}
+	return revisions, fullText, nil
+}
+
+// GetProductRatingEvents retrieves a page of the rating changes behind a product's stats, newest first
+func (s *ReviewService) GetProductRatingEvents(ctx context.Context, productID string, page domain.PageRequest) ([]*domain.RatingEvent, domain.PageCursors, error) {
+	parsedID, err := parseID("product_id", productID)
+	if err != nil {
+		return nil, domain.PageCursors{}, err
+	}
+	rows, cursors, err := s.store.ListProductRatingEventsPage(ctx, parsedID, page)
+	if err != nil {
+		return nil, domain.PageCursors{}, listingError("get rating events", err)
+	}
+
+	events := make([]*domain.RatingEvent, 0, len(rows))
+	for _, row := range rows {
+		events = append(events, sqlcToDomainRatingEvent(row))
+	}
+	return events, cursors, nil
+}
+
+// CountProductRatingEvents returns the total number of rating events recorded for a product
+func (s *ReviewService) CountProductRatingEvents(ctx context.Context, productID string) (int64, error) {
+	parsedID, err := parseID("product_id", productID)
+	if err != nil {
+		return 0, err
+	}
+
+	_, err = s.store.GetProduct(ctx, parsedID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, domain.ErrProductNotFound
+		}
+		return 0, fmt.Errorf("failed to check product: %w", err)
+	}
+
+	count, err := s.store.CountProductRatingEvents(ctx, parsedID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count rating events: %w", err)
+	}
+	return count, nil
+}
+
+// recordRevision stores the version of a review an edit is about to replace
+func recordRevision(ctx context.Context, q repository.ReviewRepository, review sqlc.Review, subRatings domain.SubRatings, now pgtype.Timestamptz) error {
+	latest, err := q.GetLatestReviewRevision(ctx, review.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get latest revision: %w", err)
+	}
+
+	encoded := make(map[string]int, len(subRatings))
+	for dimension, rating := range subRatings {
+		encoded[string(dimension)] = int(rating)
+	}
+	subRatingsJSON, err := json.Marshal(encoded)
+	if err != nil {
+		return fmt.Errorf("failed to encode sub-ratings: %w", err)
+	}
+
+	err = q.CreateReviewRevision(ctx, sqlc.CreateReviewRevisionParams{
+		ID:         uuid.New(),
+		ReviewID:   review.ID,
+		Revision:   latest + 1,
+		Title:      review.Title,
+		Body:       review.Body,
+		Rating:     review.Rating,
+		SubRatings: subRatingsJSON,
+		CreatedAt:  now,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record revision: %w", err)
+	}
+	return nil
+}
+
+// recordRatingEvent appends a change to the ratings counted in a product's stats;
+// from is nil when the review starts counting and to is nil when it stops
+func recordRatingEvent(ctx context.Context, q repository.ReviewRepository, reviewID, productID uuid.UUID, kind domain.RatingEventKind, from, to *int32) error {
+	err := q.CreateReviewRatingEvent(ctx, sqlc.CreateReviewRatingEventParams{
+		ID:         uuid.New(),
+		ReviewID:   reviewID,
+		ProductID:  productID,
+		Kind:       string(kind),
+		FromRating: from,
+		ToRating:   to,
+		CreatedAt: pgtype.Timestamptz{
+			Time:  time.Now().UTC(),
+			Valid: true,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record rating event: %w", err)
+	}
+	return nil
+}
+
+// loadSubRatings reads one review's sub-ratings through the given queries
+func loadSubRatings(ctx context.Context, q repository.ReviewRepository, reviewID uuid.UUID) (domain.SubRatings, error) {
+	rows, err := q.ListReviewSubRatings(ctx, []uuid.UUID{reviewID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sub-ratings: %w", err)
+	}
+
+	var subRatings domain.SubRatings
+	for _, row := range rows {
+		if subRatings == nil {
+			subRatings = make(domain.SubRatings, len(rows))
+		}
+		subRatings[domain.RatingDimension(row.Dimension)] = domain.Rating(row.Rating)
+	}
+	return subRatings, nil
+}
+
+func sameSubRatings(a, b domain.SubRatings) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for dimension, rating := range a {
+		if other, ok := b[dimension]; !ok || other != rating {
+			return false
+		}
+	}
+	return true
+}
+
+func sqlcToDomainReviewRevision(row sqlc.ReviewRevision) (*domain.ReviewRevision, error) {
+	rating, err := domain.NewRating(int(row.Rating))
+	if err != nil {
+		return nil, err
+	}
+
+	var encoded map[string]int
+	if err := json.Unmarshal(row.SubRatings, &encoded); err != nil {
+		return nil, fmt.Errorf("failed to decode revision sub-ratings: %w", err)
+	}
+	var subRatings domain.SubRatings
+	for dimension, value := range encoded {
+		if subRatings == nil {
+			subRatings = make(domain.SubRatings, len(encoded))
+		}
+		subRatings[domain.RatingDimension(dimension)] = domain.Rating(value)
+	}
+
+	title := ""
+	if row.Title != nil {
+		title = *row.Title
+	}
+
+	return &domain.ReviewRevision{
+		ReviewID:   row.ReviewID,
+		Revision:   int(row.Revision),
+		Title:      title,
+		Body:       row.Body,
+		Rating:     rating,
+		SubRatings: subRatings,
+		CreatedAt:  row.CreatedAt.Time,
+	}, nil
+}
+
+func sqlcToDomainRatingEvent(row sqlc.ReviewRatingEvent) *domain.RatingEvent {
+	event := &domain.RatingEvent{
+		ID:        row.ID,
+		ReviewID:  row.ReviewID,
+		ProductID: row.ProductID,
+		Kind:      domain.RatingEventKind(row.Kind),
+		CreatedAt: row.CreatedAt.Time,
+	}
+	if row.FromRating != nil {
+		from := domain.Rating(*row.FromRating)
+		event.FromRating = &from
+	}
+	if row.ToRating != nil {
+		to := domain.Rating(*row.ToRating)
+		event.ToRating = &to
+	}
+	return event
+}
@@ -8,6 +8,7 @@ import (
 
 	"ratemysoft-backend/internal/domain"
 	"ratemysoft-backend/internal/models/sqlc"
+	"ratemysoft-backend/internal/repository"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
@@ -16,68 +17,78 @@ import (
 
 // ReviewService handles review-related business logic
 type ReviewService struct {
-	queries *sqlc.Queries
+	store      repository.Store
+	moderation ModerationConfig
 }
 
-func NewReviewService(queries *sqlc.Queries) *ReviewService {
+func NewReviewService(store repository.Store, moderation ModerationConfig) *ReviewService {
+	if moderation.FlagThreshold < 1 {
+		moderation.FlagThreshold = 1
+	}
 	return &ReviewService{
-		queries: queries,
+		store:      store,
+		moderation: moderation,
 	}
 }
 
 type CreateReviewRequest struct {
-	ProductID string
-	UserID    string
-	Title     string
-	Body      string
-	Rating    int
+	ProductID  string
+	UserID     string
+	Title      string
+	Body       string
+	Rating     int
+	SubRatings map[string]int // optional, keyed by rating dimension
 }
 
 type UpdateReviewRequest struct {
-	Title  string
-	Body   string
-	Rating int
+	Title      string
+	Body       string
+	Rating     int
+	SubRatings map[string]int // nil keeps the existing sub-ratings, non-nil replaces them
 }
 
 // CreateReview creates a new review and updates product stats
 func (s *ReviewService) CreateReview(ctx context.Context, req CreateReviewRequest) (*domain.Review, error) {
 	// Validate product ID
-	productID, err := uuid.Parse(req.ProductID)
+	productID, err := parseID("product_id", req.ProductID)
 	if err != nil {
-		return nil, fmt.Errorf("invalid product ID format: %w", err)
+		return nil, err
 	}
 
 	// Validate user ID
-	userID, err := uuid.Parse(req.UserID)
+	userID, err := parseID("user_id", req.UserID)
 	if err != nil {
-		return nil, fmt.Errorf("invalid user ID format: %w", err)
+		return nil, err
 	}
 
 	// Check if product exists
-	_, err = s.queries.GetProduct(ctx, productID)
+	_, err = s.store.GetProduct(ctx, productID)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return nil, fmt.Errorf("product not found")
+			return nil, domain.ErrProductNotFound
 		}
 		return nil, fmt.Errorf("failed to check product: %w", err)
 	}
 
 	// Check if user exists
-	_, err = s.queries.GetUser(ctx, userID)
+	user, err := s.store.GetUser(ctx, userID)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return nil, fmt.Errorf("user not found")
+			return nil, domain.ErrUserNotFound
 		}
 		return nil, fmt.Errorf("failed to check user: %w", err)
 	}
+	if s.moderation.RequireVerifiedEmail && !user.EmailVerifiedAt.Valid {
+		return nil, domain.Forbidden("email_not_verified", "verify your email address before posting reviews")
+	}
 
 	// Check if user already reviewed this product
-	_, err = s.queries.GetUserReviewForProduct(ctx, sqlc.GetUserReviewForProductParams{
+	_, err = s.store.GetUserReviewForProduct(ctx, sqlc.GetUserReviewForProductParams{
 		ProductID: productID,
 		UserID:    userID,
 	})
 	if err == nil {
-		return nil, fmt.Errorf("you have already reviewed this product")
+		return nil, domain.Conflict("already_reviewed", "you have already reviewed this product")
 	}
 	if !errors.Is(err, pgx.ErrNoRows) {
 		return nil, fmt.Errorf("failed to check existing review: %w", err)
@@ -86,7 +97,12 @@ func (s *ReviewService) CreateReview(ctx context.Context, req CreateReviewReques
 	// Validate rating
 	rating, err := domain.NewRating(req.Rating)
 	if err != nil {
-		return nil, fmt.Errorf("invalid rating: must be between 1 and 5")
+		return nil, domain.InvalidField("invalid_rating", "rating", "must be between 1 and 5")
+	}
+
+	subRatings, err := parseSubRatings(req.SubRatings)
+	if err != nil {
+		return nil, err
 	}
 
 	reviewID := uuid.New()
@@ -101,124 +117,205 @@ func (s *ReviewService) CreateReview(ctx context.Context, req CreateReviewReques
 		title = &req.Title
 	}
 
-	// Create review in database
-	review, err := s.queries.CreateReview(ctx, sqlc.CreateReviewParams{
-		ID:            reviewID,
-		ProductID:     productID,
-		UserID:        userID,
-		Title:         title,
-		Body:          req.Body,
-		Rating:        int32(rating),
-		Status:        string(domain.ReviewPublished), // Auto-publish for MVP
-		UpvoteCount:   0,
-		DownvoteCount: 0,
-		FlagCount:     0,
-		Edited:        false,
-		CreatedAt:     now,
-		UpdatedAt:     now,
+	// New reviews are published immediately unless moderation requires approval
+	status := s.moderation.initialStatus()
+
+	// Create the review, its sub-ratings and the product stats atomically
+	var review sqlc.Review
+	err = s.store.InTx(ctx, func(q repository.Queries) error {
+		review, err = q.CreateReview(ctx, sqlc.CreateReviewParams{
+			ID:            reviewID,
+			ProductID:     productID,
+			UserID:        userID,
+			Title:         title,
+			Body:          req.Body,
+			Rating:        int32(rating),
+			Status:        string(status),
+			UpvoteCount:   0,
+			DownvoteCount: 0,
+			FlagCount:     0,
+			Edited:        false,
+			CreatedAt:     now,
+			UpdatedAt:     now,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create review: %w", err)
+		}
+
+		if err := createSubRatings(ctx, q, reviewID, subRatings, now); err != nil {
+			return err
+		}
+
+		if err := recordAudit(ctx, q, userID, domain.AuditCreate, domain.AuditEntityReview, reviewID, nil, review); err != nil {
+			return err
+		}
+
+		// Pending reviews don't count towards product stats until approved
+		if status != domain.ReviewPublished {
+			return nil
+		}
+		if err := recordRatingEvent(ctx, q, reviewID, productID, domain.RatingEventCreated, nil, &review.Rating); err != nil {
+			return err
+		}
+		return refreshProductStats(ctx, q, productID)
 	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to create review: %w", err)
+		return nil, err
 	}
 
-	// Update product stats (average rating and total reviews)
-	err = s.updateProductStats(ctx, productID)
+	domainReview, err := SQLCToDomainReview(review)
 	if err != nil {
-		// Log error but don't fail the review creation
-		fmt.Printf("Warning: failed to update product stats: %v\n", err)
+		return nil, err
 	}
-
-	return SQLCToDomainReview(review)
+	domainReview.SubRatings = subRatings
+	return domainReview, nil
 }
 
-// GetReviewByID retrieves a review by its ID
-func (s *ReviewService) GetReviewByID(ctx context.Context, reviewID string) (*domain.Review, error) {
-	parsedID, err := uuid.Parse(reviewID)
+// GetReviewByID retrieves a review by its ID. Pending and rejected reviews are only
+// visible to their author and admins; viewer is nil for anonymous callers.
+func (s *ReviewService) GetReviewByID(ctx context.Context, reviewID string, viewer *domain.Actor) (*domain.Review, error) {
+	parsedID, err := parseID("review_id", reviewID)
 	if err != nil {
-		return nil, fmt.Errorf("invalid review ID format: %w", err)
+		return nil, err
+	}
+
+	review, err := s.getReview(ctx, parsedID)
+	if err != nil {
+		return nil, err
+	}
+	if review.Status != domain.ReviewPublished && !canSeeUnpublished(viewer, review.UserID) {
+		return nil, domain.ErrReviewNotFound
 	}
+	return review, nil
+}
+
+// canSeeUnpublished reports whether viewer may read a pending or rejected review by authorID
+func canSeeUnpublished(viewer *domain.Actor, authorID uuid.UUID) bool {
+	return viewer != nil && (viewer.IsAdmin() || viewer.UserID == authorID)
+}
 
-	reviewRow, err := s.queries.GetReview(ctx, parsedID)
+// canSeeReview reports whether the viewer may see the review at all, so that actions
+// on a review they cannot see fail as if it did not exist
+func canSeeReview(viewer *domain.Actor, review sqlc.Review) bool {
+	return review.Status == string(domain.ReviewPublished) || canSeeUnpublished(viewer, review.UserID)
+}
+
+// getReview retrieves a review with its sub-ratings and comment count, whatever its status
+func (s *ReviewService) getReview(ctx context.Context, parsedID uuid.UUID) (*domain.Review, error) {
+	reviewRow, err := s.store.GetReview(ctx, parsedID)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return nil, fmt.Errorf("review not found")
+			return nil, domain.ErrReviewNotFound
 		}
 		return nil, fmt.Errorf("failed to get review: %w", err)
 	}
 
-	return SQLCToDomainReviewFromGetReviewRow(reviewRow)
-}
-
-// GetReviewsByProduct retrieves reviews for a product
-func (s *ReviewService) GetReviewsByProduct(ctx context.Context, productID string, sortBy string, limit, offset int32) ([]*domain.Review, error) {
-	parsedID, err := uuid.Parse(productID)
+	review, err := SQLCToDomainReviewFromGetReviewRow(reviewRow)
 	if err != nil {
-		return nil, fmt.Errorf("invalid product ID format: %w", err)
+		return nil, err
 	}
 
-	// Validate sort parameter
-	validSorts := map[string]bool{
-		"upvotes":     true,
-		"rating_desc": true,
-		"rating_asc":  true,
-		"recent":      true,
+	if err := s.attachSubRatings(ctx, []*domain.Review{review}); err != nil {
+		return nil, err
+	}
+	if err := s.attachCommentCounts(ctx, []*domain.Review{review}); err != nil {
+		return nil, err
 	}
-	if sortBy == "" {
-		sortBy = "recent"
+	return review, nil
+}
+
+// reviewSorts whitelists the orderings of a product's reviews
+var reviewSorts = map[string]repository.ReviewOrder{
+	"recent":      repository.ReviewsByRecent,
+	"upvotes":     repository.ReviewsByUpvotes,
+	"rating_desc": repository.ReviewsByRatingDesc,
+	"rating_asc":  repository.ReviewsByRatingAsc,
+	"helpful":     repository.ReviewsByHelpful,
+}
+
+// GetReviewsByProduct retrieves a page of published reviews for a product.
+// Unknown sort keys fall back to the most recent reviews first.
+func (s *ReviewService) GetReviewsByProduct(ctx context.Context, productID string, sortBy string, page domain.PageRequest) ([]*domain.Review, domain.PageCursors, error) {
+	parsedID, err := parseID("product_id", productID)
+	if err != nil {
+		return nil, domain.PageCursors{}, err
 	}
-	if !validSorts[sortBy] {
-		sortBy = "recent"
+
+	order, ok := reviewSorts[sortBy]
+	if !ok {
+		order = repository.ReviewsByRecent
 	}
 
-	reviewRows, err := s.queries.GetReviewsByProduct(ctx, sqlc.GetReviewsByProductParams{
-		ProductID: parsedID,
-		Column2:   sortBy,
-		Limit:     limit,
-		Offset:    offset,
-	})
+	rows, cursors, err := s.store.ListProductReviewsPage(ctx, parsedID, order, page)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get reviews by product: %w", err)
+		return nil, domain.PageCursors{}, listingError("get reviews by product", err)
 	}
 
-	return convertReviewRowsToDomain(reviewRows)
+	reviews, err := s.finishReviewPage(ctx, rows)
+	if err != nil {
+		return nil, domain.PageCursors{}, err
+	}
+	return reviews, cursors, nil
 }
 
-// GetReviewsByUser retrieves all reviews by a user
-func (s *ReviewService) GetReviewsByUser(ctx context.Context, userID string, limit, offset int32) ([]*domain.Review, error) {
-	parsedID, err := uuid.Parse(userID)
+// GetReviewsByUser retrieves a page of a user's reviews, newest first. Only the user
+// and admins see the pending and rejected ones.
+func (s *ReviewService) GetReviewsByUser(ctx context.Context, userID string, viewer *domain.Actor, page domain.PageRequest) ([]*domain.Review, domain.PageCursors, error) {
+	parsedID, err := parseID("user_id", userID)
 	if err != nil {
-		return nil, fmt.Errorf("invalid user ID format: %w", err)
+		return nil, domain.PageCursors{}, err
 	}
 
-	reviewRows, err := s.queries.GetReviewsByUser(ctx, sqlc.GetReviewsByUserParams{
-		UserID: parsedID,
-		Limit:  limit,
-		Offset: offset,
-	})
+	// The reviews of a deleted account stay up anonymously, so they are not listed together
+	rows, cursors, err := s.store.ListUserReviewsPage(ctx, parsedID, canSeeUnpublished(viewer, parsedID), page)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get reviews by user: %w", err)
+		return nil, domain.PageCursors{}, listingError("get reviews by user", err)
 	}
 
-	return convertUserReviewRowsToDomain(reviewRows)
+	reviews, err := s.finishReviewPage(ctx, rows)
+	if err != nil {
+		return nil, domain.PageCursors{}, err
+	}
+	return reviews, cursors, nil
+}
+
+// finishReviewPage converts a page of review rows and attaches their sub-ratings
+func (s *ReviewService) finishReviewPage(ctx context.Context, rows []sqlc.Review) ([]*domain.Review, error) {
+	reviews := make([]*domain.Review, 0, len(rows))
+	for _, row := range rows {
+		review, err := SQLCToDomainReview(row)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert review: %w", err)
+		}
+		reviews = append(reviews, review)
+	}
+
+	if err := s.attachSubRatings(ctx, reviews); err != nil {
+		return nil, err
+	}
+	if err := s.attachCommentCounts(ctx, reviews); err != nil {
+		return nil, err
+	}
+	return reviews, nil
 }
 
 // UpdateReview updates an existing review
 func (s *ReviewService) UpdateReview(ctx context.Context, reviewID, userID string, req UpdateReviewRequest) (*domain.Review, error) {
-	parsedReviewID, err := uuid.Parse(reviewID)
+	parsedReviewID, err := parseID("review_id", reviewID)
 	if err != nil {
-		return nil, fmt.Errorf("invalid review ID format: %w", err)
+		return nil, err
 	}
 
-	parsedUserID, err := uuid.Parse(userID)
+	parsedUserID, err := parseID("user_id", userID)
 	if err != nil {
-		return nil, fmt.Errorf("invalid user ID format: %w", err)
+		return nil, err
 	}
 
 	// Check if review exists and belongs to user
-	existingReviewRow, err := s.queries.GetReview(ctx, parsedReviewID)
+	existingReviewRow, err := s.store.GetReview(ctx, parsedReviewID)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return nil, fmt.Errorf("review not found")
+			return nil, domain.ErrReviewNotFound
 		}
 		return nil, fmt.Errorf("failed to get review: %w", err)
 	}
@@ -230,13 +327,21 @@ func (s *ReviewService) UpdateReview(ctx context.Context, reviewID, userID strin
 
 	// Verify ownership
 	if existingReview.UserID.String() != parsedUserID.String() {
-		return nil, fmt.Errorf("unauthorized: you can only edit your own reviews")
+		return nil, domain.Forbidden("not_review_author", "you can only edit your own reviews")
 	}
 
 	// Validate rating
 	rating, err := domain.NewRating(req.Rating)
 	if err != nil {
-		return nil, fmt.Errorf("invalid rating: must be between 1 and 5")
+		return nil, domain.InvalidField("invalid_rating", "rating", "must be between 1 and 5")
+	}
+
+	var subRatings domain.SubRatings
+	if req.SubRatings != nil {
+		subRatings, err = parseSubRatings(req.SubRatings)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	now := pgtype.Timestamptz{
@@ -250,48 +355,127 @@ func (s *ReviewService) UpdateReview(ctx context.Context, reviewID, userID strin
 		title = &req.Title
 	}
 
-	// Update review in database
-	review, err := s.queries.UpdateReview(ctx, sqlc.UpdateReviewParams{
-		ID:        parsedReviewID,
-		Title:     title,
-		Body:      req.Body,
-		Rating:    int32(rating),
-		Status:    string(existingReview.Status), // Keep existing status
-		Edited:    true,                          // Mark as edited
-		UpdatedAt: now,
+	var review sqlc.Review
+	err = s.store.InTx(ctx, func(q repository.Queries) error {
+		// Lock the review so concurrent edits number their revisions in order
+		previous, err := q.GetReviewForUpdate(ctx, parsedReviewID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return domain.ErrReviewNotFound
+			}
+			return fmt.Errorf("failed to get review: %w", err)
+		}
+
+		previousSubRatings, err := loadSubRatings(ctx, q, parsedReviewID)
+		if err != nil {
+			return err
+		}
+
+		previousTitle := ""
+		if previous.Title != nil {
+			previousTitle = *previous.Title
+		}
+
+		// Saving an identical version bumps nothing into the history
+		subRatingsChanged := req.SubRatings != nil && !sameSubRatings(subRatings, previousSubRatings)
+		changed := previousTitle != req.Title || previous.Body != req.Body ||
+			previous.Rating != int32(rating) || subRatingsChanged
+		if changed {
+			if err := recordRevision(ctx, q, previous, previousSubRatings, now); err != nil {
+				return err
+			}
+		}
+
+		// Keep the status of the locked row, a moderator may have just changed it. When
+		// reviews need approval, an edited published review has to be approved again.
+		status := previous.Status
+		if changed && s.moderation.RequireApproval && status == string(domain.ReviewPublished) {
+			status = string(domain.ReviewPending)
+		}
+
+		review, err = q.UpdateReview(ctx, sqlc.UpdateReviewParams{
+			ID:        parsedReviewID,
+			Title:     title,
+			Body:      req.Body,
+			Rating:    int32(rating),
+			Status:    status,
+			Edited:    true, // Mark as edited
+			UpdatedAt: now,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to update review: %w", err)
+		}
+
+		if req.SubRatings != nil {
+			if err := q.DeleteReviewSubRatings(ctx, parsedReviewID); err != nil {
+				return fmt.Errorf("failed to replace sub-ratings: %w", err)
+			}
+			if err := createSubRatings(ctx, q, parsedReviewID, subRatings, now); err != nil {
+				return err
+			}
+		}
+
+		if err := recordAudit(ctx, q, parsedUserID, domain.AuditUpdate, domain.AuditEntityReview, parsedReviewID, previous, review); err != nil {
+			return err
+		}
+
+		// A review sent back to moderation stops counting until it is approved again
+		if previous.Status != review.Status {
+			err := recordRatingEvent(ctx, q, parsedReviewID, previous.ProductID, domain.RatingEventUnpublished, &previous.Rating, nil)
+			if err != nil {
+				return err
+			}
+			return refreshProductStats(ctx, q, previous.ProductID)
+		}
+
+		// Update product stats if any rating changed
+		if previous.Rating == review.Rating && !subRatingsChanged {
+			return nil
+		}
+		// Only published reviews count towards the stats, so only their edits are rating events
+		if previous.Rating != review.Rating && previous.Status == string(domain.ReviewPublished) {
+			err := recordRatingEvent(ctx, q, parsedReviewID, previous.ProductID, domain.RatingEventEdited, &previous.Rating, &review.Rating)
+			if err != nil {
+				return err
+			}
+		}
+		return refreshProductStats(ctx, q, previous.ProductID)
 	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to update review: %w", err)
+		return nil, err
 	}
 
-	// Update product stats if rating changed
-	if int(rating) != int(existingReview.Rating) {
-		err = s.updateProductStats(ctx, existingReview.ProductID)
-		if err != nil {
-			fmt.Printf("Warning: failed to update product stats: %v\n", err)
-		}
+	updatedReview, err := SQLCToDomainReview(review)
+	if err != nil {
+		return nil, err
 	}
 
-	return SQLCToDomainReview(review)
+	if err := s.attachSubRatings(ctx, []*domain.Review{updatedReview}); err != nil {
+		return nil, err
+	}
+	if err := s.attachCommentCounts(ctx, []*domain.Review{updatedReview}); err != nil {
+		return nil, err
+	}
+	return updatedReview, nil
 }
 
 // DeleteReview soft deletes a review
 func (s *ReviewService) DeleteReview(ctx context.Context, reviewID, userID string) error {
-	parsedReviewID, err := uuid.Parse(reviewID)
+	parsedReviewID, err := parseID("review_id", reviewID)
 	if err != nil {
-		return fmt.Errorf("invalid review ID format: %w", err)
+		return err
 	}
 
-	parsedUserID, err := uuid.Parse(userID)
+	parsedUserID, err := parseID("user_id", userID)
 	if err != nil {
-		return fmt.Errorf("invalid user ID format: %w", err)
+		return err
 	}
 
 	// Check if review exists and belongs to user
-	existingReviewRow, err := s.queries.GetReview(ctx, parsedReviewID)
+	existingReviewRow, err := s.store.GetReview(ctx, parsedReviewID)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return fmt.Errorf("review not found")
+			return domain.ErrReviewNotFound
 		}
 		return fmt.Errorf("failed to get review: %w", err)
 	}
@@ -303,134 +487,650 @@ func (s *ReviewService) DeleteReview(ctx context.Context, reviewID, userID strin
 
 	// Verify ownership
 	if existingReview.UserID.String() != parsedUserID.String() {
-		return fmt.Errorf("unauthorized: you can only delete your own reviews")
+		return domain.Forbidden("not_review_author", "you can only delete your own reviews")
 	}
 
-	// Soft delete the review
-	err = s.queries.SoftDeleteReview(ctx, parsedReviewID)
+	// Soft delete the review and drop it from the product stats
+	return s.store.InTx(ctx, func(q repository.Queries) error {
+		previous, err := q.GetReviewForUpdate(ctx, parsedReviewID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return domain.ErrReviewNotFound
+			}
+			return fmt.Errorf("failed to get review: %w", err)
+		}
+
+		err = q.SoftDeleteReview(ctx, parsedReviewID)
+		if err != nil {
+			return fmt.Errorf("failed to delete review: %w", err)
+		}
+		if err := recordAudit(ctx, q, parsedUserID, domain.AuditDelete, domain.AuditEntityReview, parsedReviewID, previous, nil); err != nil {
+			return err
+		}
+		if previous.Status == string(domain.ReviewPublished) {
+			err := recordRatingEvent(ctx, q, parsedReviewID, previous.ProductID, domain.RatingEventDeleted, &previous.Rating, nil)
+			if err != nil {
+				return err
+			}
+		}
+		return refreshProductStats(ctx, q, previous.ProductID)
+	})
+}
+
+// VoteResult is the caller's vote and the review's counters after a vote change
+type VoteResult struct {
+	ReviewID      uuid.UUID
+	Vote          domain.VoteDirection
+	UpvoteCount   int
+	DownvoteCount int
+}
+
+// SetVote records the user's vote on a review; domain.VoteNone retracts any existing vote.
+// Repeating the same vote is a no-op, so each user counts at most once per review.
+// Reviews the voter cannot see are reported as not found.
+func (s *ReviewService) SetVote(ctx context.Context, reviewID string, voter domain.Actor, vote domain.VoteDirection) (*VoteResult, error) {
+	parsedReviewID, err := parseID("review_id", reviewID)
 	if err != nil {
-		return fmt.Errorf("failed to delete review: %w", err)
+		return nil, err
 	}
 
-	// Update product stats
-	err = s.updateProductStats(ctx, existingReview.ProductID)
+	var result *VoteResult
+	err = s.store.InTx(ctx, func(q repository.Queries) error {
+		// Lock the review row so concurrent votes on it serialize
+		review, err := q.GetReviewForUpdate(ctx, parsedReviewID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return domain.ErrReviewNotFound
+			}
+			return fmt.Errorf("failed to get review: %w", err)
+		}
+		if !canSeeReview(&voter, review) {
+			return domain.ErrReviewNotFound
+		}
+
+		if review.UserID == voter.UserID {
+			return domain.Forbidden("own_review", "you cannot vote on your own review")
+		}
+
+		previous := domain.VoteNone
+		existing, err := q.GetReviewVote(ctx, sqlc.GetReviewVoteParams{
+			ReviewID: parsedReviewID,
+			UserID:   voter.UserID,
+		})
+		if err == nil {
+			previous = domain.VoteDirection(existing.Vote)
+		} else if !errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("failed to get existing vote: %w", err)
+		}
+
+		result = &VoteResult{
+			ReviewID:      parsedReviewID,
+			Vote:          vote,
+			UpvoteCount:   int(review.UpvoteCount),
+			DownvoteCount: int(review.DownvoteCount),
+		}
+		if vote == previous {
+			return nil
+		}
+
+		if vote == domain.VoteNone {
+			_, err = q.DeleteReviewVote(ctx, sqlc.DeleteReviewVoteParams{
+				ReviewID: parsedReviewID,
+				UserID:   voter.UserID,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to retract vote: %w", err)
+			}
+		} else {
+			now := pgtype.Timestamptz{
+				Time:  time.Now().UTC(),
+				Valid: true,
+			}
+			_, err = q.UpsertReviewVote(ctx, sqlc.UpsertReviewVoteParams{
+				ReviewID:  parsedReviewID,
+				UserID:    voter.UserID,
+				Vote:      string(vote),
+				CreatedAt: now,
+				UpdatedAt: now,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to record vote: %w", err)
+			}
+		}
+
+		upDelta, downDelta := voteDeltas(previous, vote)
+		err = q.AdjustReviewVoteCounts(ctx, sqlc.AdjustReviewVoteCountsParams{
+			UpvoteDelta:   upDelta,
+			DownvoteDelta: downDelta,
+			ID:            parsedReviewID,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to update vote counts: %w", err)
+		}
+
+		result.UpvoteCount += int(upDelta)
+		result.DownvoteCount += int(downDelta)
+		return nil
+	})
 	if err != nil {
-		fmt.Printf("Warning: failed to update product stats: %v\n", err)
+		return nil, err
 	}
 
-	return nil
+	return result, nil
 }
 
-// IncrementUpvote increments the upvote count for a review
-func (s *ReviewService) IncrementUpvote(ctx context.Context, reviewID string) error {
-	parsedID, err := uuid.Parse(reviewID)
+// FlagReview records the user's flag on a review; flagging again only updates the reason
+func (s *ReviewService) FlagReview(ctx context.Context, reviewID string, flagger domain.Actor, reason domain.FlagReason, note string) error {
+	parsedReviewID, err := parseID("review_id", reviewID)
 	if err != nil {
-		return fmt.Errorf("invalid review ID format: %w", err)
+		return err
 	}
 
-	// Check if review exists
-	_, err = s.queries.GetReview(ctx, parsedID)
-	if err != nil {
+	var notePtr *string
+	if note != "" {
+		notePtr = &note
+	}
+
+	return s.store.InTx(ctx, func(q repository.Queries) error {
+		// Lock the review row so concurrent flags on it serialize
+		review, err := q.GetReviewForUpdate(ctx, parsedReviewID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return domain.ErrReviewNotFound
+			}
+			return fmt.Errorf("failed to get review: %w", err)
+		}
+		if !canSeeReview(&flagger, review) {
+			return domain.ErrReviewNotFound
+		}
+
+		if review.UserID == flagger.UserID {
+			return domain.Forbidden("own_review", "you cannot flag your own review")
+		}
+
+		alreadyFlagged := true
+		_, err = q.GetReviewFlag(ctx, sqlc.GetReviewFlagParams{
+			ReviewID: parsedReviewID,
+			UserID:   flagger.UserID,
+		})
 		if errors.Is(err, pgx.ErrNoRows) {
-			return fmt.Errorf("review not found")
+			alreadyFlagged = false
+		} else if err != nil {
+			return fmt.Errorf("failed to get existing flag: %w", err)
 		}
-		return fmt.Errorf("failed to get review: %w", err)
-	}
 
-	err = s.queries.IncrementUpvoteCount(ctx, parsedID)
+		now := pgtype.Timestamptz{
+			Time:  time.Now().UTC(),
+			Valid: true,
+		}
+		_, err = q.UpsertReviewFlag(ctx, sqlc.UpsertReviewFlagParams{
+			ReviewID:  parsedReviewID,
+			UserID:    flagger.UserID,
+			Reason:    string(reason),
+			Note:      notePtr,
+			CreatedAt: now,
+			UpdatedAt: now,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to record flag: %w", err)
+		}
+
+		if alreadyFlagged {
+			return nil
+		}
+
+		err = q.AdjustReviewFlagCount(ctx, sqlc.AdjustReviewFlagCountParams{
+			FlagDelta: 1,
+			ID:        parsedReviewID,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to update flag count: %w", err)
+		}
+		return nil
+	})
+}
+
+// UnflagReview retracts the user's flag on a review; retracting a missing flag is a no-op
+func (s *ReviewService) UnflagReview(ctx context.Context, reviewID string, flagger domain.Actor) error {
+	parsedReviewID, err := parseID("review_id", reviewID)
 	if err != nil {
-		return fmt.Errorf("failed to increment upvote: %w", err)
+		return err
 	}
 
-	return nil
+	return s.store.InTx(ctx, func(q repository.Queries) error {
+		review, err := q.GetReviewForUpdate(ctx, parsedReviewID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return domain.ErrReviewNotFound
+			}
+			return fmt.Errorf("failed to get review: %w", err)
+		}
+		if !canSeeReview(&flagger, review) {
+			return domain.ErrReviewNotFound
+		}
+
+		deleted, err := q.DeleteReviewFlag(ctx, sqlc.DeleteReviewFlagParams{
+			ReviewID: parsedReviewID,
+			UserID:   flagger.UserID,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to retract flag: %w", err)
+		}
+
+		if deleted == 0 {
+			return nil
+		}
+
+		err = q.AdjustReviewFlagCount(ctx, sqlc.AdjustReviewFlagCountParams{
+			FlagDelta: -1,
+			ID:        parsedReviewID,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to update flag count: %w", err)
+		}
+		return nil
+	})
 }
 
-// IncrementDownvote increments the downvote count for a review
-func (s *ReviewService) IncrementDownvote(ctx context.Context, reviewID string) error {
-	parsedID, err := uuid.Parse(reviewID)
+// GetReviewFlags retrieves every user's flag on a review, oldest first
+func (s *ReviewService) GetReviewFlags(ctx context.Context, reviewID string) ([]*domain.ReviewFlag, error) {
+	parsedID, err := parseID("review_id", reviewID)
 	if err != nil {
-		return fmt.Errorf("invalid review ID format: %w", err)
+		return nil, err
 	}
 
-	// Check if review exists
-	_, err = s.queries.GetReview(ctx, parsedID)
+	rows, err := s.store.ListReviewFlags(ctx, parsedID)
 	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			return fmt.Errorf("review not found")
+		return nil, fmt.Errorf("failed to get review flags: %w", err)
+	}
+
+	flags := make([]*domain.ReviewFlag, 0, len(rows))
+	for _, row := range rows {
+		note := ""
+		if row.Note != nil {
+			note = *row.Note
 		}
-		return fmt.Errorf("failed to get review: %w", err)
+		flags = append(flags, &domain.ReviewFlag{
+			ReviewID:   row.ReviewID,
+			UserID:     row.UserID,
+			UserHandle: row.UserHandle,
+			Reason:     domain.FlagReason(row.Reason),
+			Note:       note,
+			CreatedAt:  row.CreatedAt.Time,
+			UpdatedAt:  row.UpdatedAt.Time,
+		})
+	}
+	return flags, nil
+}
+
+// GetUserVotes returns the user's vote on each of the given reviews; reviews without a vote are omitted
+func (s *ReviewService) GetUserVotes(ctx context.Context, userID uuid.UUID, reviewIDs []uuid.UUID) (map[uuid.UUID]domain.VoteDirection, error) {
+	votes := make(map[uuid.UUID]domain.VoteDirection, len(reviewIDs))
+	if len(reviewIDs) == 0 {
+		return votes, nil
 	}
 
-	err = s.queries.IncrementDownvoteCount(ctx, parsedID)
+	rows, err := s.store.GetUserVotesForReviews(ctx, sqlc.GetUserVotesForReviewsParams{
+		UserID:    userID,
+		ReviewIds: reviewIDs,
+	})
 	if err != nil {
-		return fmt.Errorf("failed to increment downvote: %w", err)
+		return nil, fmt.Errorf("failed to get user votes: %w", err)
 	}
 
-	return nil
+	for _, row := range rows {
+		votes[row.ReviewID] = domain.VoteDirection(row.Vote)
+	}
+	return votes, nil
 }
 
-// IncrementFlag increments the flag count for a review
-func (s *ReviewService) IncrementFlag(ctx context.Context, reviewID string) error {
-	parsedID, err := uuid.Parse(reviewID)
+// CountReviewsByProduct returns the total number of published reviews for a product
+func (s *ReviewService) CountReviewsByProduct(ctx context.Context, productID string) (int64, error) {
+	parsedID, err := parseID("product_id", productID)
 	if err != nil {
-		return fmt.Errorf("invalid review ID format: %w", err)
+		return 0, err
 	}
 
-	// Check if review exists
-	_, err = s.queries.GetReview(ctx, parsedID)
+	count, err := s.store.CountReviewsByProduct(ctx, parsedID)
 	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			return fmt.Errorf("review not found")
-		}
-		return fmt.Errorf("failed to get review: %w", err)
+		return 0, fmt.Errorf("failed to count reviews: %w", err)
 	}
+	return count, nil
+}
 
-	err = s.queries.IncrementFlagCount(ctx, parsedID)
+// CountReviewsByUser returns the number of reviews by a user that viewer can see (see GetReviewsByUser)
+func (s *ReviewService) CountReviewsByUser(ctx context.Context, userID string, viewer *domain.Actor) (int64, error) {
+	parsedID, err := parseID("user_id", userID)
 	if err != nil {
-		return fmt.Errorf("failed to increment flag: %w", err)
+		return 0, err
 	}
 
-	return nil
+	count, err := s.store.CountReviewsByUser(ctx, sqlc.CountReviewsByUserParams{
+		UserID:        parsedID,
+		PublishedOnly: !canSeeUnpublished(viewer, parsedID),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to count reviews: %w", err)
+	}
+	return count, nil
 }
 
-// CountReviewsByProduct returns the total number of published reviews for a product
-func (s *ReviewService) CountReviewsByProduct(ctx context.Context, productID string) (int64, error) {
-	parsedID, err := uuid.Parse(productID)
+// ModerationQueueItem is a review awaiting moderation along with display context
+type ModerationQueueItem struct {
+	Review      *domain.Review
+	UserHandle  string
+	ProductName string
+}
+
+// ListPendingReviews retrieves reviews awaiting approval, oldest first
+func (s *ReviewService) ListPendingReviews(ctx context.Context, page domain.PageRequest) ([]*ModerationQueueItem, domain.PageCursors, error) {
+	rows, cursors, err := s.store.ListReviewsByStatusPage(ctx, string(domain.ReviewPending), page)
 	if err != nil {
-		return 0, fmt.Errorf("invalid product ID format: %w", err)
+		return nil, domain.PageCursors{}, listingError("list pending reviews", err)
+	}
+
+	items := make([]*ModerationQueueItem, 0, len(rows))
+	for _, row := range rows {
+		review, err := SQLCToDomainReviewFromStatusRow(row)
+		if err != nil {
+			return nil, domain.PageCursors{}, fmt.Errorf("failed to convert review: %w", err)
+		}
+		items = append(items, &ModerationQueueItem{
+			Review:      review,
+			UserHandle:  row.UserHandle,
+			ProductName: row.ProductName,
+		})
 	}
+	return items, cursors, nil
+}
 
-	count, err := s.queries.CountReviewsByProduct(ctx, parsedID)
+// CountPendingReviews returns the number of reviews awaiting approval
+func (s *ReviewService) CountPendingReviews(ctx context.Context) (int64, error) {
+	count, err := s.store.CountReviewsByStatus(ctx, string(domain.ReviewPending))
 	if err != nil {
-		return 0, fmt.Errorf("failed to count reviews: %w", err)
+		return 0, fmt.Errorf("failed to count pending reviews: %w", err)
 	}
 	return count, nil
 }
 
-// CountReviewsByUser returns the total number of reviews by a user
-func (s *ReviewService) CountReviewsByUser(ctx context.Context, userID string) (int64, error) {
-	parsedID, err := uuid.Parse(userID)
+// ListFlaggedReviews retrieves non-rejected reviews at or above the flag threshold, most flagged first
+func (s *ReviewService) ListFlaggedReviews(ctx context.Context, page domain.PageRequest) ([]*ModerationQueueItem, domain.PageCursors, error) {
+	rows, cursors, err := s.store.ListFlaggedReviewsPage(ctx, s.moderation.FlagThreshold, page)
 	if err != nil {
-		return 0, fmt.Errorf("invalid user ID format: %w", err)
+		return nil, domain.PageCursors{}, listingError("list flagged reviews", err)
 	}
 
-	count, err := s.queries.CountReviewsByUser(ctx, parsedID)
+	items := make([]*ModerationQueueItem, 0, len(rows))
+	for _, row := range rows {
+		review, err := SQLCToDomainReviewFromFlaggedRow(row)
+		if err != nil {
+			return nil, domain.PageCursors{}, fmt.Errorf("failed to convert review: %w", err)
+		}
+		items = append(items, &ModerationQueueItem{
+			Review:      review,
+			UserHandle:  row.UserHandle,
+			ProductName: row.ProductName,
+		})
+	}
+	return items, cursors, nil
+}
+
+// CountFlaggedReviews returns the number of reviews in the flagged queue
+func (s *ReviewService) CountFlaggedReviews(ctx context.Context) (int64, error) {
+	count, err := s.store.CountFlaggedReviews(ctx, s.moderation.FlagThreshold)
 	if err != nil {
-		return 0, fmt.Errorf("failed to count reviews: %w", err)
+		return 0, fmt.Errorf("failed to count flagged reviews: %w", err)
 	}
 	return count, nil
 }
 
-// updateProductStats recalculates and updates product average rating and total reviews
-func (s *ReviewService) updateProductStats(ctx context.Context, productID uuid.UUID) error {
+// ApproveReview publishes a pending, rejected or flagged review and clears its flags
+func (s *ReviewService) ApproveReview(ctx context.Context, reviewID, moderatorID, reason string) (*domain.Review, error) {
+	return s.moderateReview(ctx, reviewID, moderatorID, domain.ReviewPublished, reason)
+}
+
+// RejectReview hides a review from public listings; a reason is required
+func (s *ReviewService) RejectReview(ctx context.Context, reviewID, moderatorID, reason string) (*domain.Review, error) {
+	if err := requireRejectionReason("review", reason); err != nil {
+		return nil, err
+	}
+	return s.moderateReview(ctx, reviewID, moderatorID, domain.ReviewRejected, reason)
+}
+
+// GetModerationHistory retrieves all moderation decisions for a review and its comments, newest first
+func (s *ReviewService) GetModerationHistory(ctx context.Context, reviewID string) ([]*domain.ReviewModeration, error) {
+	parsedID, err := parseID("review_id", reviewID)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.store.ListReviewModerations(ctx, parsedID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get moderation history: %w", err)
+	}
+
+	history := make([]*domain.ReviewModeration, 0, len(rows))
+	for _, row := range rows {
+		reason := ""
+		if row.Reason != nil {
+			reason = *row.Reason
+		}
+		history = append(history, &domain.ReviewModeration{
+			ID:              row.ID,
+			ReviewID:        row.ReviewID,
+			CommentID:       row.CommentID,
+			ModeratorID:     row.ModeratorID,
+			ModeratorHandle: row.ModeratorHandle,
+			FromStatus:      domain.ReviewStatus(row.FromStatus),
+			ToStatus:        domain.ReviewStatus(row.ToStatus),
+			Reason:          reason,
+			CreatedAt:       row.CreatedAt.Time,
+		})
+	}
+	return history, nil
+}
+
+// moderateReview moves a review to the target status, records the decision and recomputes product stats
+func (s *ReviewService) moderateReview(ctx context.Context, reviewID, moderatorID string, to domain.ReviewStatus, reason string) (*domain.Review, error) {
+	parsedReviewID, err := parseID("review_id", reviewID)
+	if err != nil {
+		return nil, err
+	}
+
+	parsedModeratorID, err := parseID("moderator_id", moderatorID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.store.InTx(ctx, func(q repository.Queries) error {
+		// Lock the review so concurrent decisions cannot both pass the transition check
+		existing, err := q.GetReviewForUpdate(ctx, parsedReviewID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return domain.ErrReviewNotFound
+			}
+			return fmt.Errorf("failed to get review: %w", err)
+		}
+
+		from := domain.ReviewStatus(existing.Status)
+
+		if err := checkModerationTransition("review", from, to, existing.FlagCount); err != nil {
+			return err
+		}
+
+		if from != to {
+			err = q.UpdateReviewStatus(ctx, sqlc.UpdateReviewStatusParams{
+				ID:     parsedReviewID,
+				Status: string(to),
+			})
+			if err != nil {
+				return fmt.Errorf("failed to update review status: %w", err)
+			}
+		}
+
+		if to == domain.ReviewPublished && existing.FlagCount > 0 {
+			err = q.DeleteReviewFlags(ctx, parsedReviewID)
+			if err != nil {
+				return fmt.Errorf("failed to resolve review flags: %w", err)
+			}
+
+			err = q.ClearReviewFlags(ctx, parsedReviewID)
+			if err != nil {
+				return fmt.Errorf("failed to clear review flags: %w", err)
+			}
+		}
+
+		if err := recordModeration(ctx, q, parsedReviewID, nil, parsedModeratorID, from, to, reason); err != nil {
+			return err
+		}
+
+		action := domain.AuditApprove
+		if to != domain.ReviewPublished {
+			action = domain.AuditReject
+		}
+		moderated := existing
+		moderated.Status = string(to)
+		if to == domain.ReviewPublished {
+			moderated.FlagCount = 0
+		}
+		if err := recordAudit(ctx, q, parsedModeratorID, action, domain.AuditEntityReview, parsedReviewID, existing, moderated); err != nil {
+			return err
+		}
+
+		// Moving in or out of published changes which reviews count towards the product's stats
+		if from != domain.ReviewPublished && to == domain.ReviewPublished {
+			err := recordRatingEvent(ctx, q, parsedReviewID, existing.ProductID, domain.RatingEventPublished, nil, &existing.Rating)
+			if err != nil {
+				return err
+			}
+		}
+		if from == domain.ReviewPublished && to != domain.ReviewPublished {
+			err := recordRatingEvent(ctx, q, parsedReviewID, existing.ProductID, domain.RatingEventUnpublished, &existing.Rating, nil)
+			if err != nil {
+				return err
+			}
+		}
+
+		return refreshProductStats(ctx, q, existing.ProductID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.getReview(ctx, parsedReviewID)
+}
+
+// attachSubRatings loads the sub-ratings of the given reviews in a single query
+func (s *ReviewService) attachSubRatings(ctx context.Context, reviews []*domain.Review) error {
+	if len(reviews) == 0 {
+		return nil
+	}
+
+	byID := make(map[uuid.UUID]*domain.Review, len(reviews))
+	reviewIDs := make([]uuid.UUID, 0, len(reviews))
+	for _, review := range reviews {
+		byID[review.ID] = review
+		reviewIDs = append(reviewIDs, review.ID)
+	}
+
+	rows, err := s.store.ListReviewSubRatings(ctx, reviewIDs)
+	if err != nil {
+		return fmt.Errorf("failed to get sub-ratings: %w", err)
+	}
+
+	for _, row := range rows {
+		review, ok := byID[row.ReviewID]
+		if !ok {
+			continue
+		}
+		if review.SubRatings == nil {
+			review.SubRatings = make(domain.SubRatings)
+		}
+		review.SubRatings[domain.RatingDimension(row.Dimension)] = domain.Rating(row.Rating)
+	}
+	return nil
+}
+
+// attachCommentCounts counts the published comments of the given reviews in a single query
+func (s *ReviewService) attachCommentCounts(ctx context.Context, reviews []*domain.Review) error {
+	if len(reviews) == 0 {
+		return nil
+	}
+
+	byID := make(map[uuid.UUID]*domain.Review, len(reviews))
+	reviewIDs := make([]uuid.UUID, 0, len(reviews))
+	for _, review := range reviews {
+		byID[review.ID] = review
+		reviewIDs = append(reviewIDs, review.ID)
+	}
+
+	rows, err := s.store.CountReviewCommentsByReviews(ctx, reviewIDs)
+	if err != nil {
+		return fmt.Errorf("failed to count comments: %w", err)
+	}
+
+	for _, row := range rows {
+		if review, ok := byID[row.ReviewID]; ok {
+			review.CommentCount = int(row.CommentCount)
+		}
+	}
+	return nil
+}
+
+// parseSubRatings validates optional per-dimension ratings keyed by dimension name
+func parseSubRatings(raw map[string]int) (domain.SubRatings, error) {
+	if len(raw) == 0 {
+		return nil, nil
+	}
+
+	subRatings := make(domain.SubRatings, len(raw))
+	for key, value := range raw {
+		dimension, err := domain.NewRatingDimension(key)
+		if err != nil {
+			return nil, domain.InvalidField("invalid_rating_dimension", "sub_ratings."+key, "unknown rating dimension")
+		}
+		rating, err := domain.NewRating(value)
+		if err != nil {
+			return nil, domain.InvalidField("invalid_rating", "sub_ratings."+key, "must be between 1 and 5")
+		}
+		subRatings[dimension] = rating
+	}
+	return subRatings, nil
+}
+
+// createSubRatings stores a review's sub-ratings
+func createSubRatings(ctx context.Context, q repository.ReviewRepository, reviewID uuid.UUID, subRatings domain.SubRatings, now pgtype.Timestamptz) error {
+	for dimension, rating := range subRatings {
+		err := q.CreateReviewSubRating(ctx, sqlc.CreateReviewSubRatingParams{
+			ReviewID:  reviewID,
+			Dimension: string(dimension),
+			Rating:    int32(rating),
+			CreatedAt: now,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create sub-rating: %w", err)
+		}
+	}
+	return nil
+}
+
+// refreshProductStats recalculates the product's average rating, total reviews, star histogram
+// and per-dimension averages. Run it in the transaction that changed the product's reviews;
+// the product row lock serializes concurrent refreshes so none of them works from a stale count.
+func refreshProductStats(ctx context.Context, q repository.Queries, productID uuid.UUID) error {
+	err := q.LockProduct(ctx, productID)
+	if err != nil {
+		return fmt.Errorf("failed to lock product: %w", err)
+	}
+
 	// Get average rating
-	avgRatingResult, err := s.queries.GetAverageRatingByProduct(ctx, productID)
+	avgRatingResult, err := q.GetAverageRatingByProduct(ctx, productID)
 	if err != nil {
 		return fmt.Errorf("failed to get average rating: %w", err)
 	}
 
 	// Get total count of published reviews
-	totalReviews, err := s.queries.CountReviewsByProduct(ctx, productID)
+	totalReviews, err := q.CountReviewsByProduct(ctx, productID)
 	if err != nil {
 		return fmt.Errorf("failed to count reviews: %w", err)
 	}
@@ -445,7 +1145,7 @@ func (s *ReviewService) updateProductStats(ctx context.Context, productID uuid.U
 	}
 
 	// Update product stats
-	err = s.queries.UpdateProductStats(ctx, sqlc.UpdateProductStatsParams{
+	err = q.UpdateProductStats(ctx, sqlc.UpdateProductStatsParams{
 		ID:           productID,
 		AvgRating:    avgRating,
 		TotalReviews: int32(totalReviews),
@@ -454,35 +1154,44 @@ func (s *ReviewService) updateProductStats(ctx context.Context, productID uuid.U
 		return fmt.Errorf("failed to update product stats: %w", err)
 	}
 
-	return nil
-}
+	err = q.RefreshProductRatingStats(ctx, productID)
+	if err != nil {
+		return fmt.Errorf("failed to update rating histogram: %w", err)
+	}
 
-// Helper conversion functions
+	// Dimension rows are rebuilt so dimensions nobody rates anymore disappear
+	err = q.DeleteProductDimensionStats(ctx, productID)
+	if err != nil {
+		return fmt.Errorf("failed to clear dimension stats: %w", err)
+	}
 
-func convertReviewRowsToDomain(rows []sqlc.GetReviewsByProductRow) ([]*domain.Review, error) {
-	domainReviews := make([]*domain.Review, 0, len(rows))
-	for _, row := range rows {
-		domainReview, err := SQLCToDomainReviewFromProductRow(row)
-		if err != nil {
-			return nil, fmt.Errorf("failed to convert review: %w", err)
-		}
-		domainReviews = append(domainReviews, domainReview)
+	err = q.RefreshProductDimensionStats(ctx, productID)
+	if err != nil {
+		return fmt.Errorf("failed to update dimension stats: %w", err)
 	}
-	return domainReviews, nil
+
+	return nil
 }
 
-func convertUserReviewRowsToDomain(rows []sqlc.GetReviewsByUserRow) ([]*domain.Review, error) {
-	domainReviews := make([]*domain.Review, 0, len(rows))
-	for _, row := range rows {
-		domainReview, err := SQLCToDomainReviewFromUserRow(row)
-		if err != nil {
-			return nil, fmt.Errorf("failed to convert review: %w", err)
-		}
-		domainReviews = append(domainReviews, domainReview)
-	}
-	return domainReviews, nil
+// voteDeltas returns the counter changes for moving from one vote to another
+func voteDeltas(from, to domain.VoteDirection) (up, down int32) {
+	switch from {
+	case domain.VoteUp:
+		up--
+	case domain.VoteDown:
+		down--
+	}
+	switch to {
+	case domain.VoteUp:
+		up++
+	case domain.VoteDown:
+		down++
+	}
+	return up, down
 }
 
+// Helper conversion functions
+
 // SQLCToDomainReview converts a SQLC Review to a domain Review
 func SQLCToDomainReview(sqlcReview sqlc.Review) (*domain.Review, error) {
 	rating, err := domain.NewRating(int(sqlcReview.Rating))
@@ -571,90 +1280,12 @@ func SQLCToDomainReviewFromGetReviewRow(row sqlc.GetReviewRow) (*domain.Review,
 	}, nil
 }
 
-// SQLCToDomainReviewFromProductRow converts a GetReviewsByProductRow to a domain Review
-func SQLCToDomainReviewFromProductRow(row sqlc.GetReviewsByProductRow) (*domain.Review, error) {
-	rating, err := domain.NewRating(int(row.Rating))
-	if err != nil {
-		return nil, err
-	}
-
-	createdAt := time.Time{}
-	if row.CreatedAt.Valid {
-		createdAt = row.CreatedAt.Time
-	}
-
-	updatedAt := time.Time{}
-	if row.UpdatedAt.Valid {
-		updatedAt = row.UpdatedAt.Time
-	}
-
-	var deletedAt *time.Time
-	if row.DeletedAt.Valid {
-		deletedAt = &row.DeletedAt.Time
-	}
-
-	title := ""
-	if row.Title != nil {
-		title = *row.Title
-	}
-
-	return &domain.Review{
-		ID:           row.ID,
-		ProductID:    row.ProductID,
-		UserID:       row.UserID,
-		Title:        title,
-		Body:         row.Body,
-		Rating:       rating,
-		Status:       domain.ReviewStatus(row.Status),
-		HelpfulCount: int(row.UpvoteCount),
-		FlagCount:    int(row.FlagCount),
-		Edited:       row.Edited,
-		CreatedAt:    createdAt,
-		UpdatedAt:    updatedAt,
-		DeletedAt:    deletedAt,
-	}, nil
+// SQLCToDomainReviewFromStatusRow converts a GetReviewsByStatusRow to a domain Review
+func SQLCToDomainReviewFromStatusRow(row sqlc.GetReviewsByStatusRow) (*domain.Review, error) {
+	return SQLCToDomainReviewFromGetReviewRow(sqlc.GetReviewRow(row))
 }
 
-// SQLCToDomainReviewFromUserRow converts a GetReviewsByUserRow to a domain Review
-func SQLCToDomainReviewFromUserRow(row sqlc.GetReviewsByUserRow) (*domain.Review, error) {
-	rating, err := domain.NewRating(int(row.Rating))
-	if err != nil {
-		return nil, err
-	}
-
-	createdAt := time.Time{}
-	if row.CreatedAt.Valid {
-		createdAt = row.CreatedAt.Time
-	}
-
-	updatedAt := time.Time{}
-	if row.UpdatedAt.Valid {
-		updatedAt = row.UpdatedAt.Time
-	}
-
-	var deletedAt *time.Time
-	if row.DeletedAt.Valid {
-		deletedAt = &row.DeletedAt.Time
-	}
-
-	title := ""
-	if row.Title != nil {
-		title = *row.Title
-	}
-
-	return &domain.Review{
-		ID:           row.ID,
-		ProductID:    row.ProductID,
-		UserID:       row.UserID,
-		Title:        title,
-		Body:         row.Body,
-		Rating:       rating,
-		Status:       domain.ReviewStatus(row.Status),
-		HelpfulCount: int(row.UpvoteCount),
-		FlagCount:    int(row.FlagCount),
-		Edited:       row.Edited,
-		CreatedAt:    createdAt,
-		UpdatedAt:    updatedAt,
-		DeletedAt:    deletedAt,
-	}, nil
+// SQLCToDomainReviewFromFlaggedRow converts a ListFlaggedReviewsRow to a domain Review
+func SQLCToDomainReviewFromFlaggedRow(row sqlc.ListFlaggedReviewsRow) (*domain.Review, error) {
+	return SQLCToDomainReviewFromGetReviewRow(sqlc.GetReviewRow(row))
 }
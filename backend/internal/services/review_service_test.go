@@ -0,0 +1,233 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"ratemysoft-backend/internal/domain"
+	"ratemysoft-backend/internal/models/sqlc"
+	"ratemysoft-backend/internal/repository/memory"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func TestCreateReviewRefreshesProductStats(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	svc := NewReviewService(store, ModerationConfig{})
+	products := NewProductService(store, NewAuthorizer(store))
+	owner := seedUser(t, store, "owner", domain.RoleUser)
+	product := seedProduct(t, store, owner, "widget")
+
+	for i, rating := range []int{5, 4} {
+		reviewer := seedUser(t, store, []string{"alice", "bob"}[i], domain.RoleUser)
+		_, err := svc.CreateReview(ctx, CreateReviewRequest{
+			ProductID:  product.ID.String(),
+			UserID:     reviewer.UserID.String(),
+			Body:       "solid uptime and good docs",
+			Rating:     rating,
+			SubRatings: map[string]int{"docs": rating},
+		})
+		if err != nil {
+			t.Fatalf("CreateReview: %v", err)
+		}
+	}
+
+	stats, err := products.GetProductStats(ctx, product.ID.String())
+	if err != nil {
+		t.Fatalf("GetProductStats: %v", err)
+	}
+	if stats.TotalReviews != 2 || stats.AvgRating == nil || *stats.AvgRating != 4.5 {
+		t.Errorf("stats = %d reviews averaging %v, want 2 averaging 4.5", stats.TotalReviews, stats.AvgRating)
+	}
+	if stats.Histogram != [5]int{0, 0, 0, 1, 1} {
+		t.Errorf("histogram = %v", stats.Histogram)
+	}
+	if len(stats.Dimensions) != 1 || stats.Dimensions[0].Count != 2 {
+		t.Errorf("dimensions = %+v, want docs rated twice", stats.Dimensions)
+	}
+}
+
+func TestCreateReviewRejectsDuplicates(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	svc := NewReviewService(store, ModerationConfig{})
+	owner := seedUser(t, store, "owner", domain.RoleUser)
+	alice := seedUser(t, store, "alice", domain.RoleUser)
+	product := seedProduct(t, store, owner, "widget")
+
+	req := CreateReviewRequest{ProductID: product.ID.String(), UserID: alice.UserID.String(), Body: "great", Rating: 5}
+	if _, err := svc.CreateReview(ctx, req); err != nil {
+		t.Fatalf("CreateReview: %v", err)
+	}
+	_, err := svc.CreateReview(ctx, req)
+	assertErrorIs(t, err, domain.ErrConflict)
+
+	req.Rating = 9
+	req.UserID = owner.UserID.String()
+	_, err = svc.CreateReview(ctx, req)
+	assertErrorIs(t, err, domain.ErrValidation)
+}
+
+func TestModerationQueue(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	svc := NewReviewService(store, ModerationConfig{RequireApproval: true})
+	owner := seedUser(t, store, "owner", domain.RoleUser)
+	alice := seedUser(t, store, "alice", domain.RoleUser)
+	moderator := seedUser(t, store, "moderator", domain.RoleAdmin)
+	product := seedProduct(t, store, owner, "widget")
+
+	review, err := svc.CreateReview(ctx, CreateReviewRequest{ProductID: product.ID.String(), UserID: alice.UserID.String(), Body: "great", Rating: 5})
+	if err != nil {
+		t.Fatalf("CreateReview: %v", err)
+	}
+	if review.Status != domain.ReviewPending {
+		t.Fatalf("status = %q, want pending", review.Status)
+	}
+
+	// Only the author and admins can read a review before it is approved
+	for _, viewer := range []*domain.Actor{nil, &owner} {
+		_, err := svc.GetReviewByID(ctx, review.ID.String(), viewer)
+		assertErrorIs(t, err, domain.ErrReviewNotFound)
+		if n, _ := svc.CountReviewsByUser(ctx, alice.UserID.String(), viewer); n != 0 {
+			t.Errorf("pending review counted for %v: %d", viewer, n)
+		}
+	}
+	for _, viewer := range []*domain.Actor{&alice, &moderator} {
+		if _, err := svc.GetReviewByID(ctx, review.ID.String(), viewer); err != nil {
+			t.Errorf("GetReviewByID as %s: %v", viewer.Role, err)
+		}
+		if n, _ := svc.CountReviewsByUser(ctx, alice.UserID.String(), viewer); n != 1 {
+			t.Errorf("CountReviewsByUser as %s = %d, want 1", viewer.Role, n)
+		}
+	}
+	if n, _ := svc.CountReviewsByProduct(ctx, product.ID.String()); n != 0 {
+		t.Errorf("pending review counted as published: %d", n)
+	}
+
+	// Nor can anyone else vote on it or flag it
+	_, err = svc.SetVote(ctx, review.ID.String(), owner, domain.VoteUp)
+	assertErrorIs(t, err, domain.ErrReviewNotFound)
+	err = svc.FlagReview(ctx, review.ID.String(), owner, domain.FlagSpam, "")
+	assertErrorIs(t, err, domain.ErrReviewNotFound)
+
+	queue, _, err := svc.ListPendingReviews(ctx, domain.PageRequest{Limit: 10})
+	if err != nil {
+		t.Fatalf("ListPendingReviews: %v", err)
+	}
+	if len(queue) != 1 || queue[0].Review.ID != review.ID {
+		t.Fatalf("pending queue = %+v, want the new review", queue)
+	}
+
+	approved, err := svc.ApproveReview(ctx, review.ID.String(), moderator.UserID.String(), "")
+	if err != nil {
+		t.Fatalf("ApproveReview: %v", err)
+	}
+	if approved.Status != domain.ReviewPublished {
+		t.Errorf("status = %q, want published", approved.Status)
+	}
+	if n, _ := svc.CountReviewsByProduct(ctx, product.ID.String()); n != 1 {
+		t.Errorf("CountReviewsByProduct = %d, want 1", n)
+	}
+
+	history, err := svc.GetModerationHistory(ctx, review.ID.String())
+	if err != nil {
+		t.Fatalf("GetModerationHistory: %v", err)
+	}
+	if len(history) != 1 || history[0].ToStatus != domain.ReviewPublished {
+		t.Errorf("history = %+v, want one approval", history)
+	}
+
+	// Editing an approved review sends it back to the queue
+	edited, err := svc.UpdateReview(ctx, review.ID.String(), alice.UserID.String(), UpdateReviewRequest{Body: "changed my mind", Rating: 1})
+	if err != nil {
+		t.Fatalf("UpdateReview: %v", err)
+	}
+	if edited.Status != domain.ReviewPending {
+		t.Errorf("status after edit = %q, want pending", edited.Status)
+	}
+	if n, _ := svc.CountReviewsByProduct(ctx, product.ID.String()); n != 0 {
+		t.Errorf("edited review still counted as published: %d", n)
+	}
+	if n, _ := svc.CountProductRatingEvents(ctx, product.ID.String()); n != 2 {
+		t.Errorf("rating events = %d, want published and unpublished", n)
+	}
+}
+
+func TestVotesAndEdits(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	svc := NewReviewService(store, ModerationConfig{})
+	owner := seedUser(t, store, "owner", domain.RoleUser)
+	alice := seedUser(t, store, "alice", domain.RoleUser)
+	bob := seedUser(t, store, "bob", domain.RoleUser)
+	product := seedProduct(t, store, owner, "widget")
+
+	review, err := svc.CreateReview(ctx, CreateReviewRequest{ProductID: product.ID.String(), UserID: alice.UserID.String(), Body: "great", Rating: 5})
+	if err != nil {
+		t.Fatalf("CreateReview: %v", err)
+	}
+	reviewID := review.ID.String()
+
+	// Repeating a vote is a no-op and switching moves the count
+	for _, vote := range []domain.VoteDirection{domain.VoteUp, domain.VoteUp} {
+		if _, err := svc.SetVote(ctx, reviewID, bob, vote); err != nil {
+			t.Fatalf("SetVote: %v", err)
+		}
+	}
+	result, err := svc.SetVote(ctx, reviewID, bob, domain.VoteDown)
+	if err != nil {
+		t.Fatalf("SetVote: %v", err)
+	}
+	if result.UpvoteCount != 0 || result.DownvoteCount != 1 {
+		t.Errorf("votes = %d up, %d down; want 0 up, 1 down", result.UpvoteCount, result.DownvoteCount)
+	}
+
+	_, err = svc.UpdateReview(ctx, reviewID, bob.UserID.String(), UpdateReviewRequest{Body: "mine now", Rating: 1})
+	assertErrorIs(t, err, domain.ErrForbidden)
+
+	edited, err := svc.UpdateReview(ctx, reviewID, alice.UserID.String(), UpdateReviewRequest{Body: "good, not great", Rating: 3})
+	if err != nil {
+		t.Fatalf("UpdateReview: %v", err)
+	}
+	if !edited.Edited || edited.Rating != 3 {
+		t.Errorf("edited review = %+v", edited)
+	}
+
+	revisions, _, err := svc.GetReviewRevisions(ctx, reviewID, &alice)
+	if err != nil {
+		t.Fatalf("GetReviewRevisions: %v", err)
+	}
+	if len(revisions) != 1 || revisions[0].Rating != 5 {
+		t.Errorf("revisions = %+v, want the original 5-star text", revisions)
+	}
+	if n, _ := svc.CountProductRatingEvents(ctx, product.ID.String()); n != 2 {
+		t.Errorf("rating events = %d, want created and changed", n)
+	}
+}
+
+func TestCreateReviewRequiresVerifiedEmail(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	owner := seedUser(t, store, "owner", domain.RoleUser)
+	alice := seedUser(t, store, "alice", domain.RoleUser)
+	product := seedProduct(t, store, owner, "widget")
+	svc := NewReviewService(store, ModerationConfig{RequireVerifiedEmail: true})
+	req := CreateReviewRequest{ProductID: product.ID.String(), UserID: alice.UserID.String(), Body: "great", Rating: 5}
+
+	_, err := svc.CreateReview(ctx, req)
+	assertErrorIs(t, err, domain.ErrForbidden)
+
+	_, err = store.SetUserEmailVerified(ctx, sqlc.SetUserEmailVerifiedParams{
+		ID:              alice.UserID,
+		EmailVerifiedAt: pgtype.Timestamptz{Time: time.Now(), Valid: true},
+	})
+	if err != nil {
+		t.Fatalf("SetUserEmailVerified: %v", err)
+	}
+	if _, err := svc.CreateReview(ctx, req); err != nil {
+		t.Errorf("CreateReview after verifying: %v", err)
+	}
+}
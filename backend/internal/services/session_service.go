@@ -0,0 +1,313 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"ratemysoft-backend/internal/auth"
+	"ratemysoft-backend/internal/domain"
+	"ratemysoft-backend/internal/models/sqlc"
+	"ratemysoft-backend/internal/repository"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// SessionService manages login sessions, refresh token rotation and access token revocation
+type SessionService struct {
+	store         repository.Store
+	refreshExpiry time.Duration
+}
+
+func NewSessionService(store repository.Store, refreshExpiry time.Duration) *SessionService {
+	return &SessionService{
+		store:         store,
+		refreshExpiry: refreshExpiry,
+	}
+}
+
+// SessionMetadata describes the client that started or refreshed a session
+type SessionMetadata struct {
+	UserAgent string
+	IPAddress string
+}
+
+// StartSession creates a new session for the user and returns it with its first refresh token
+func (s *SessionService) StartSession(ctx context.Context, userID domain.ID, meta SessionMetadata) (*domain.Session, string, error) {
+	now := time.Now()
+	var session sqlc.Session
+	var refreshToken string
+
+	var userAgent *string
+	if meta.UserAgent != "" {
+		userAgent = &meta.UserAgent
+	}
+
+	var ipAddress *string
+	if meta.IPAddress != "" {
+		ipAddress = &meta.IPAddress
+	}
+
+	err := s.store.InTx(ctx, func(q repository.Queries) error {
+		var err error
+		session, err = q.CreateSession(ctx, sqlc.CreateSessionParams{
+			ID:         uuid.New(),
+			UserID:     userID,
+			UserAgent:  userAgent,
+			IpAddress:  ipAddress,
+			CreatedAt:  pgtype.Timestamptz{Time: now, Valid: true},
+			LastUsedAt: pgtype.Timestamptz{Time: now, Valid: true},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create session: %w", err)
+		}
+
+		refreshToken, err = s.issueRefreshToken(ctx, q, session.ID, now)
+		return err
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	return SQLCToDomainSession(session), refreshToken, nil
+}
+
+// RefreshSession exchanges a refresh token for a new one in the same session.
+// Each refresh token is single-use: presenting an already-rotated token is treated
+// as theft and revokes the whole session.
+func (s *SessionService) RefreshSession(ctx context.Context, refreshToken string) (*domain.Session, string, error) {
+	now := time.Now()
+	var session sqlc.Session
+	var newToken string
+	reused := false
+
+	err := s.store.InTx(ctx, func(q repository.Queries) error {
+		token, err := q.GetRefreshTokenByHashForUpdate(ctx, auth.HashRefreshToken(refreshToken))
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return domain.Unauthorized("invalid_refresh_token", "invalid refresh token")
+			}
+			return fmt.Errorf("failed to get refresh token: %w", err)
+		}
+
+		session, err = q.GetSession(ctx, token.SessionID)
+		if err != nil {
+			return fmt.Errorf("failed to get session: %w", err)
+		}
+		if session.RevokedAt.Valid {
+			return domain.Unauthorized("session_revoked", "invalid refresh token: session has been revoked")
+		}
+
+		if token.UsedAt.Valid {
+			// Revoke and commit; the error is reported after the transaction
+			reused = true
+			_, err = q.RevokeSession(ctx, sqlc.RevokeSessionParams{
+				ID:        session.ID,
+				UserID:    session.UserID,
+				RevokedAt: pgtype.Timestamptz{Time: now, Valid: true},
+			})
+			return err
+		}
+
+		if !token.ExpiresAt.Time.After(now) {
+			return domain.Unauthorized("refresh_token_expired", "invalid refresh token: expired")
+		}
+
+		err = q.MarkRefreshTokenUsed(ctx, sqlc.MarkRefreshTokenUsedParams{
+			ID:     token.ID,
+			UsedAt: pgtype.Timestamptz{Time: now, Valid: true},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to rotate refresh token: %w", err)
+		}
+
+		err = q.TouchSession(ctx, sqlc.TouchSessionParams{
+			ID:         session.ID,
+			LastUsedAt: pgtype.Timestamptz{Time: now, Valid: true},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to update session: %w", err)
+		}
+		session.LastUsedAt = pgtype.Timestamptz{Time: now, Valid: true}
+
+		newToken, err = s.issueRefreshToken(ctx, q, session.ID, now)
+		return err
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	if reused {
+		return nil, "", domain.Unauthorized("refresh_token_reused", "invalid refresh token: token reuse detected, session revoked")
+	}
+
+	return SQLCToDomainSession(session), newToken, nil
+}
+
+// EndSession logs out a single session: the presented access token is deny-listed by jti
+// and the session is revoked so its refresh token and other access tokens stop working
+func (s *SessionService) EndSession(ctx context.Context, claims *auth.JWTClaims) error {
+	userID, err := parseID("user_id", claims.UserID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	return s.store.InTx(ctx, func(q repository.Queries) error {
+		if err := s.revokeAccessToken(ctx, q, userID, claims, now); err != nil {
+			return err
+		}
+
+		sessionID, err := uuid.Parse(claims.SessionID)
+		if err != nil {
+			// Token predates sessions; the jti deny-list entry is all we can do
+			return nil
+		}
+
+		_, err = q.RevokeSession(ctx, sqlc.RevokeSessionParams{
+			ID:        sessionID,
+			UserID:    userID,
+			RevokedAt: pgtype.Timestamptz{Time: now, Valid: true},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to revoke session: %w", err)
+		}
+		return nil
+	})
+}
+
+// EndAllSessions logs the user out everywhere and returns the number of sessions revoked
+func (s *SessionService) EndAllSessions(ctx context.Context, claims *auth.JWTClaims) (int64, error) {
+	userID, err := parseID("user_id", claims.UserID)
+	if err != nil {
+		return 0, err
+	}
+
+	now := time.Now()
+	var revoked int64
+	err = s.store.InTx(ctx, func(q repository.Queries) error {
+		if err := s.revokeAccessToken(ctx, q, userID, claims, now); err != nil {
+			return err
+		}
+
+		var err error
+		revoked, err = q.RevokeUserSessions(ctx, sqlc.RevokeUserSessionsParams{
+			UserID:    userID,
+			RevokedAt: pgtype.Timestamptz{Time: now, Valid: true},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to revoke sessions: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return revoked, nil
+}
+
+// IsAccessTokenRevoked implements auth.RevocationChecker
+func (s *SessionService) IsAccessTokenRevoked(ctx context.Context, tokenID, sessionID string) (bool, error) {
+	// Tokens without a jti/sid cannot match a revocation entry; uuid.Nil never does either
+	jti, _ := uuid.Parse(tokenID)
+	sid, _ := uuid.Parse(sessionID)
+
+	revoked, err := s.store.IsAccessTokenRevoked(ctx, sqlc.IsAccessTokenRevokedParams{
+		Jti:       jti,
+		SessionID: sid,
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to check token revocation: %w", err)
+	}
+	return revoked, nil
+}
+
+// PruneExpired deletes refresh tokens, deny-list entries and email verification and password
+// reset tokens that can no longer be used, sessions idle for longer than the refresh token
+// lifetime, and failed sign-in counts that no longer count towards a lockout
+func (s *SessionService) PruneExpired(ctx context.Context) error {
+	now := time.Now()
+
+	if _, err := s.store.DeleteExpiredRefreshTokens(ctx, pgtype.Timestamptz{Time: now, Valid: true}); err != nil {
+		return fmt.Errorf("failed to prune refresh tokens: %w", err)
+	}
+	if _, err := s.store.DeleteExpiredRevokedAccessTokens(ctx, pgtype.Timestamptz{Time: now, Valid: true}); err != nil {
+		return fmt.Errorf("failed to prune revoked access tokens: %w", err)
+	}
+	if _, err := s.store.DeleteExpiredAccountTokens(ctx, pgtype.Timestamptz{Time: now, Valid: true}); err != nil {
+		return fmt.Errorf("failed to prune account tokens: %w", err)
+	}
+	if _, err := s.store.DeleteStaleSessions(ctx, pgtype.Timestamptz{Time: now.Add(-s.refreshExpiry), Valid: true}); err != nil {
+		return fmt.Errorf("failed to prune sessions: %w", err)
+	}
+	if _, err := s.store.DeleteStaleLoginThrottles(ctx, pgtype.Timestamptz{Time: now.Add(-loginFailureWindow), Valid: true}); err != nil {
+		return fmt.Errorf("failed to prune login throttles: %w", err)
+	}
+	return nil
+}
+
+func (s *SessionService) issueRefreshToken(ctx context.Context, q repository.SessionRepository, sessionID uuid.UUID, now time.Time) (string, error) {
+	token, hash, err := auth.GenerateRefreshToken()
+	if err != nil {
+		return "", err
+	}
+
+	_, err = q.CreateRefreshToken(ctx, sqlc.CreateRefreshTokenParams{
+		ID:        uuid.New(),
+		SessionID: sessionID,
+		TokenHash: hash,
+		ExpiresAt: pgtype.Timestamptz{Time: now.Add(s.refreshExpiry), Valid: true},
+		CreatedAt: pgtype.Timestamptz{Time: now, Valid: true},
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to store refresh token: %w", err)
+	}
+	return token, nil
+}
+
+func (s *SessionService) revokeAccessToken(ctx context.Context, q repository.SessionRepository, userID uuid.UUID, claims *auth.JWTClaims, now time.Time) error {
+	jti, err := uuid.Parse(claims.ID)
+	if err != nil {
+		// Tokens issued before jti support can only be revoked via their session
+		return nil
+	}
+
+	expiresAt := now.Add(24 * time.Hour)
+	if claims.ExpiresAt != nil {
+		expiresAt = claims.ExpiresAt.Time
+	}
+
+	err = q.RevokeAccessToken(ctx, sqlc.RevokeAccessTokenParams{
+		Jti:       jti,
+		UserID:    userID,
+		ExpiresAt: pgtype.Timestamptz{Time: expiresAt, Valid: true},
+		RevokedAt: pgtype.Timestamptz{Time: now, Valid: true},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to revoke access token: %w", err)
+	}
+	return nil
+}
+
+// SQLCToDomainSession converts a SQLC Session to a domain Session
+func SQLCToDomainSession(s sqlc.Session) *domain.Session {
+	session := &domain.Session{
+		ID:         s.ID,
+		UserID:     s.UserID,
+		CreatedAt:  s.CreatedAt.Time,
+		LastUsedAt: s.LastUsedAt.Time,
+	}
+	if s.UserAgent != nil {
+		session.UserAgent = *s.UserAgent
+	}
+	if s.IpAddress != nil {
+		session.IPAddress = *s.IpAddress
+	}
+	if s.RevokedAt.Valid {
+		session.RevokedAt = &s.RevokedAt.Time
+	}
+	return session
+}
@@ -0,0 +1,114 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"ratemysoft-backend/internal/auth"
+	"ratemysoft-backend/internal/domain"
+	"ratemysoft-backend/internal/repository/memory"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+func TestRefreshSessionRotatesTokens(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	svc := NewSessionService(store, time.Hour)
+	alice := seedUser(t, store, "alice", domain.RoleUser)
+
+	session, first, err := svc.StartSession(ctx, alice.UserID, SessionMetadata{UserAgent: "test"})
+	if err != nil {
+		t.Fatalf("StartSession: %v", err)
+	}
+
+	refreshed, second, err := svc.RefreshSession(ctx, first)
+	if err != nil {
+		t.Fatalf("RefreshSession: %v", err)
+	}
+	if refreshed.ID != session.ID || second == first {
+		t.Errorf("refresh = session %s with token %q, want session %s with a new token", refreshed.ID, second, session.ID)
+	}
+
+	// Presenting the rotated token again is treated as theft and ends the session
+	_, _, err = svc.RefreshSession(ctx, first)
+	assertErrorIs(t, err, domain.ErrUnauthorized)
+	_, _, err = svc.RefreshSession(ctx, second)
+	assertErrorIs(t, err, domain.ErrUnauthorized)
+
+	revoked, err := svc.IsAccessTokenRevoked(ctx, uuid.NewString(), session.ID.String())
+	if err != nil || !revoked {
+		t.Errorf("IsAccessTokenRevoked = %v, %v; want the session revoked", revoked, err)
+	}
+}
+
+func TestEndSessionRevokesAccessToken(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	svc := NewSessionService(store, time.Hour)
+	alice := seedUser(t, store, "alice", domain.RoleUser)
+
+	session, _, err := svc.StartSession(ctx, alice.UserID, SessionMetadata{})
+	if err != nil {
+		t.Fatalf("StartSession: %v", err)
+	}
+	other, _, err := svc.StartSession(ctx, alice.UserID, SessionMetadata{})
+	if err != nil {
+		t.Fatalf("StartSession: %v", err)
+	}
+
+	claims := &auth.JWTClaims{
+		UserID:           alice.UserID.String(),
+		SessionID:        session.ID.String(),
+		RegisteredClaims: jwt.RegisteredClaims{ID: uuid.NewString()},
+	}
+	if err := svc.EndSession(ctx, claims); err != nil {
+		t.Fatalf("EndSession: %v", err)
+	}
+
+	for _, tc := range []struct {
+		jti, sid string
+		want     bool
+	}{
+		{claims.ID, uuid.NewString(), true},
+		{uuid.NewString(), session.ID.String(), true},
+		{uuid.NewString(), other.ID.String(), false},
+	} {
+		revoked, err := svc.IsAccessTokenRevoked(ctx, tc.jti, tc.sid)
+		if err != nil || revoked != tc.want {
+			t.Errorf("IsAccessTokenRevoked(%s, %s) = %v, %v; want %v", tc.jti, tc.sid, revoked, err, tc.want)
+		}
+	}
+}
+
+func TestPruneExpiredDeletesAccountTokens(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	svc := NewSessionService(store, time.Hour)
+	alice := seedUser(t, store, "alice", domain.RoleUser)
+
+	expired, expiredMail := newAccountService(store, AccountConfig{VerificationTTL: -time.Minute})
+	live, liveMail := newAccountService(store, AccountConfig{})
+	if err := expired.SendEmailVerification(ctx, alice.UserID); err != nil {
+		t.Fatalf("SendEmailVerification: %v", err)
+	}
+	if err := live.SendEmailVerification(ctx, alice.UserID); err != nil {
+		t.Fatalf("SendEmailVerification: %v", err)
+	}
+
+	if err := svc.PruneExpired(ctx); err != nil {
+		t.Fatalf("PruneExpired: %v", err)
+	}
+
+	_, err := store.GetAccountTokenByHash(ctx, auth.HashAccountToken(expiredMail.Token(t, "alice@example.com")))
+	if !errors.Is(err, pgx.ErrNoRows) {
+		t.Errorf("expired token: err = %v, want it deleted", err)
+	}
+	if _, err := store.GetAccountTokenByHash(ctx, auth.HashAccountToken(liveMail.Token(t, "alice@example.com"))); err != nil {
+		t.Errorf("live token: %v", err)
+	}
+}
@@ -0,0 +1,509 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"ratemysoft-backend/internal/domain"
+	"ratemysoft-backend/internal/models/sqlc"
+	"ratemysoft-backend/internal/repository"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	// maxTagsPerProduct caps how many tags a product can carry
+	maxTagsPerProduct = 20
+	// maxFilterTags caps how many tags a single product filter may name
+	maxFilterTags = 10
+)
+
+// TagService manages product tags and the tag suggestion queue
+type TagService struct {
+	store repository.Store
+	authz *Authorizer
+}
+
+func NewTagService(store repository.Store, authz *Authorizer) *TagService {
+	return &TagService{
+		store: store,
+		authz: authz,
+	}
+}
+
+// TagFilter narrows product listings to tagged products. With MatchAll a product needs
+// every tag, otherwise any one of them. An empty filter matches everything.
+type TagFilter struct {
+	Tags     []string
+	MatchAll bool
+}
+
+// normalize validates and de-duplicates the filter's tags into slugs
+func (f TagFilter) normalize() (TagFilter, error) {
+	if len(f.Tags) > maxFilterTags {
+		return TagFilter{}, domain.InvalidField("too_many_tags", "tags", fmt.Sprintf("at most %d tags can be combined", maxFilterTags))
+	}
+
+	seen := make(map[domain.Slug]bool, len(f.Tags))
+	slugs := make([]string, 0, len(f.Tags))
+	for _, raw := range f.Tags {
+		slug, err := domain.NewTagSlug(raw)
+		if err != nil {
+			return TagFilter{}, invalidTag("tags", raw, err)
+		}
+		if seen[slug] {
+			continue
+		}
+		seen[slug] = true
+		slugs = append(slugs, string(slug))
+	}
+	return TagFilter{Tags: slugs, MatchAll: f.MatchAll}, nil
+}
+
+// TagListResult is one page of tags with the total number of matches
+type TagListResult struct {
+	Tags    []*domain.Tag
+	Total   int64
+	Cursors domain.PageCursors
+}
+
+// TagSuggestionListResult is one page of tag suggestions with the total number of matches
+type TagSuggestionListResult struct {
+	Suggestions []*domain.TagSuggestion
+	Total       int64
+	Cursors     domain.PageCursors
+}
+
+// ListTags lists tags by popularity, optionally narrowed to those starting with prefix (for autocomplete)
+func (s *TagService) ListTags(ctx context.Context, prefix string, page domain.PageRequest) (*TagListResult, error) {
+	if prefix != "" {
+		slug, err := domain.NewTagSlug(prefix)
+		if err != nil {
+			return nil, invalidTag("q", prefix, err)
+		}
+		prefix = string(slug)
+	}
+
+	total, err := s.store.CountTagsMatching(ctx, prefix)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count tags: %w", err)
+	}
+
+	rows, cursors, err := s.store.ListTagsPage(ctx, prefix, page)
+	if err != nil {
+		return nil, listingError("list tags", err)
+	}
+
+	tags := make([]*domain.Tag, 0, len(rows))
+	for _, row := range rows {
+		tags = append(tags, &domain.Tag{
+			ID:           row.ID,
+			Slug:         domain.Slug(row.Slug),
+			Name:         row.Name,
+			ProductCount: int(row.ProductCount),
+			CreatedAt:    row.CreatedAt.Time,
+		})
+	}
+	return &TagListResult{Tags: tags, Total: total, Cursors: cursors}, nil
+}
+
+// ListProductTags retrieves the tags of a product
+func (s *TagService) ListProductTags(ctx context.Context, productID string) ([]domain.Tag, error) {
+	parsedID, err := parseID("product_id", productID)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = s.store.GetProduct(ctx, parsedID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrProductNotFound
+		}
+		return nil, fmt.Errorf("failed to get product: %w", err)
+	}
+
+	return loadProductTags(ctx, s.store, parsedID)
+}
+
+// AddProductTags attaches tags to a product, creating tags that do not exist yet
+// (company editors and admins only). Tags already on the product are left as they are.
+func (s *TagService) AddProductTags(ctx context.Context, actor domain.Actor, productID string, names []string) ([]domain.Tag, error) {
+	parsedID, err := parseID("product_id", productID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.authz.RequireProductRole(ctx, actor, parsedID, domain.CompanyEditor); err != nil {
+		return nil, err
+	}
+
+	slugs := make([]domain.Slug, 0, len(names))
+	for _, name := range names {
+		slug, err := domain.NewTagSlug(name)
+		if err != nil {
+			return nil, invalidTag("tags", name, err)
+		}
+		slugs = append(slugs, slug)
+	}
+
+	userID := actor.UserID
+	err = s.store.InTx(ctx, func(q repository.Queries) error {
+		// Serialize tagging of the product so the per-product cap holds
+		if err := q.LockProduct(ctx, parsedID); err != nil {
+			return fmt.Errorf("failed to lock product: %w", err)
+		}
+
+		for i, slug := range slugs {
+			if err := attachTag(ctx, q, parsedID, slug, names[i], &userID); err != nil {
+				return err
+			}
+		}
+
+		count, err := q.CountProductTags(ctx, parsedID)
+		if err != nil {
+			return fmt.Errorf("failed to count product tags: %w", err)
+		}
+		if count > maxTagsPerProduct {
+			return domain.InvalidField("too_many_tags", "tags", fmt.Sprintf("a product can have at most %d tags", maxTagsPerProduct))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return loadProductTags(ctx, s.store, parsedID)
+}
+
+// RemoveProductTag detaches a tag from a product (company editors and admins only)
+func (s *TagService) RemoveProductTag(ctx context.Context, actor domain.Actor, productID, tag string) error {
+	parsedID, err := parseID("product_id", productID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.authz.RequireProductRole(ctx, actor, parsedID, domain.CompanyEditor); err != nil {
+		return err
+	}
+
+	slug, err := domain.NewTagSlug(tag)
+	if err != nil {
+		return invalidTag("tag", tag, err)
+	}
+
+	removed, err := s.store.RemoveProductTag(ctx, sqlc.RemoveProductTagParams{
+		ProductID: parsedID,
+		Slug:      string(slug),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to remove product tag: %w", err)
+	}
+	if removed == 0 {
+		return domain.ErrTagNotFound
+	}
+	return nil
+}
+
+// SuggestTag proposes a tag for a product; any signed-in user may suggest
+func (s *TagService) SuggestTag(ctx context.Context, actor domain.Actor, productID, name string) (*domain.TagSuggestion, error) {
+	parsedID, err := parseID("product_id", productID)
+	if err != nil {
+		return nil, err
+	}
+
+	slug, err := domain.NewTagSlug(name)
+	if err != nil {
+		return nil, invalidTag("tag", name, err)
+	}
+
+	_, err = s.store.GetProduct(ctx, parsedID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrProductNotFound
+		}
+		return nil, fmt.Errorf("failed to get product: %w", err)
+	}
+
+	tags, err := loadProductTags(ctx, s.store, parsedID)
+	if err != nil {
+		return nil, err
+	}
+	for _, tag := range tags {
+		if tag.Slug == slug {
+			return nil, domain.Conflict("tag_already_attached", "the product already has this tag")
+		}
+	}
+
+	_, err = s.store.GetPendingTagSuggestion(ctx, sqlc.GetPendingTagSuggestionParams{
+		ProductID: parsedID,
+		TagSlug:   string(slug),
+	})
+	if err == nil {
+		return nil, domain.Conflict("suggestion_pending", "this tag has already been suggested and awaits review")
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("failed to check pending suggestions: %w", err)
+	}
+
+	now := pgtype.Timestamptz{
+		Time:  time.Now().UTC(),
+		Valid: true,
+	}
+
+	suggestion, err := s.store.CreateTagSuggestion(ctx, sqlc.CreateTagSuggestionParams{
+		ID:        uuid.New(),
+		ProductID: parsedID,
+		UserID:    actor.UserID,
+		TagSlug:   string(slug),
+		TagName:   name,
+		Status:    string(domain.TagSuggestionPending),
+		CreatedAt: now,
+		UpdatedAt: now,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create tag suggestion: %w", err)
+	}
+
+	return SQLCToDomainTagSuggestion(suggestion), nil
+}
+
+// ListTagSuggestions lists a product's suggestions with the given status, oldest first (company editors and admins only)
+func (s *TagService) ListTagSuggestions(ctx context.Context, actor domain.Actor, productID string, status domain.TagSuggestionStatus, page domain.PageRequest) (*TagSuggestionListResult, error) {
+	parsedID, err := parseID("product_id", productID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.authz.RequireProductRole(ctx, actor, parsedID, domain.CompanyEditor); err != nil {
+		return nil, err
+	}
+
+	total, err := s.store.CountTagSuggestionsByProduct(ctx, sqlc.CountTagSuggestionsByProductParams{
+		ProductID: parsedID,
+		Status:    string(status),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to count tag suggestions: %w", err)
+	}
+
+	rows, cursors, err := s.store.ListTagSuggestionsPage(ctx, parsedID, string(status), page)
+	if err != nil {
+		return nil, listingError("list tag suggestions", err)
+	}
+
+	suggestions := make([]*domain.TagSuggestion, 0, len(rows))
+	for _, row := range rows {
+		suggestion := SQLCToDomainTagSuggestion(sqlc.TagSuggestion{
+			ID:         row.ID,
+			ProductID:  row.ProductID,
+			UserID:     row.UserID,
+			TagSlug:    row.TagSlug,
+			TagName:    row.TagName,
+			Status:     row.Status,
+			ReviewedBy: row.ReviewedBy,
+			ReviewNote: row.ReviewNote,
+			ReviewedAt: row.ReviewedAt,
+			CreatedAt:  row.CreatedAt,
+			UpdatedAt:  row.UpdatedAt,
+		})
+		suggestion.UserHandle = row.UserHandle
+		suggestions = append(suggestions, suggestion)
+	}
+	return &TagSuggestionListResult{Suggestions: suggestions, Total: total, Cursors: cursors}, nil
+}
+
+// ApproveTagSuggestion approves a pending suggestion and attaches the tag (company editors and admins only)
+func (s *TagService) ApproveTagSuggestion(ctx context.Context, actor domain.Actor, productID, suggestionID, note string) (*domain.TagSuggestion, error) {
+	return s.resolveTagSuggestion(ctx, actor, productID, suggestionID, domain.TagSuggestionApproved, note)
+}
+
+// RejectTagSuggestion rejects a pending suggestion (company editors and admins only)
+func (s *TagService) RejectTagSuggestion(ctx context.Context, actor domain.Actor, productID, suggestionID, note string) (*domain.TagSuggestion, error) {
+	return s.resolveTagSuggestion(ctx, actor, productID, suggestionID, domain.TagSuggestionRejected, note)
+}
+
+func (s *TagService) resolveTagSuggestion(ctx context.Context, actor domain.Actor, productID, suggestionID string, to domain.TagSuggestionStatus, note string) (*domain.TagSuggestion, error) {
+	parsedProductID, err := parseID("product_id", productID)
+	if err != nil {
+		return nil, err
+	}
+
+	parsedID, err := parseID("suggestion_id", suggestionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.authz.RequireProductRole(ctx, actor, parsedProductID, domain.CompanyEditor); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.store.GetTagSuggestion(ctx, parsedID)
+	if err != nil || existing.ProductID != parsedProductID {
+		if err == nil || errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.NotFound("suggestion_not_found", "tag suggestion not found")
+		}
+		return nil, fmt.Errorf("failed to get tag suggestion: %w", err)
+	}
+	if existing.Status != string(domain.TagSuggestionPending) {
+		return nil, domain.Conflict("suggestion_resolved", fmt.Sprintf("suggestion is already %s", existing.Status))
+	}
+
+	var notePtr *string
+	if note != "" {
+		notePtr = &note
+	}
+	reviewer := actor.UserID
+	now := pgtype.Timestamptz{
+		Time:  time.Now().UTC(),
+		Valid: true,
+	}
+
+	var suggestion sqlc.TagSuggestion
+	err = s.store.InTx(ctx, func(q repository.Queries) error {
+		var err error
+		suggestion, err = q.ResolveTagSuggestion(ctx, sqlc.ResolveTagSuggestionParams{
+			ID:         parsedID,
+			Status:     string(to),
+			ReviewedBy: &reviewer,
+			ReviewNote: notePtr,
+			ReviewedAt: now,
+		})
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return domain.Conflict("suggestion_resolved", "suggestion is already resolved")
+			}
+			return fmt.Errorf("failed to resolve tag suggestion: %w", err)
+		}
+
+		if to != domain.TagSuggestionApproved {
+			return nil
+		}
+
+		if err := q.LockProduct(ctx, parsedProductID); err != nil {
+			return fmt.Errorf("failed to lock product: %w", err)
+		}
+		count, err := q.CountProductTags(ctx, parsedProductID)
+		if err != nil {
+			return fmt.Errorf("failed to count product tags: %w", err)
+		}
+		if count >= maxTagsPerProduct {
+			return domain.Conflict("too_many_tags", fmt.Sprintf("a product can have at most %d tags", maxTagsPerProduct))
+		}
+
+		// Credit the tag to the user who suggested it
+		return attachTag(ctx, q, parsedProductID, domain.Slug(suggestion.TagSlug), suggestion.TagName, &suggestion.UserID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return SQLCToDomainTagSuggestion(suggestion), nil
+}
+
+// attachTag creates the tag if needed and adds it to the product; attaching an existing tag is a no-op
+func attachTag(ctx context.Context, q repository.TagRepository, productID uuid.UUID, slug domain.Slug, name string, addedBy *uuid.UUID) error {
+	now := pgtype.Timestamptz{
+		Time:  time.Now().UTC(),
+		Valid: true,
+	}
+
+	tag, err := q.UpsertTag(ctx, sqlc.UpsertTagParams{
+		ID:        uuid.New(),
+		Slug:      string(slug),
+		Name:      name,
+		CreatedAt: now,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create tag: %w", err)
+	}
+
+	_, err = q.AddProductTag(ctx, sqlc.AddProductTagParams{
+		ProductID: productID,
+		TagID:     tag.ID,
+		AddedBy:   addedBy,
+		CreatedAt: now,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to add product tag: %w", err)
+	}
+	return nil
+}
+
+// loadProductTags retrieves the tags of a single product
+func loadProductTags(ctx context.Context, q repository.ProductRepository, productID uuid.UUID) ([]domain.Tag, error) {
+	product := &domain.Product{ID: productID}
+	if err := attachProductTags(ctx, q, []*domain.Product{product}); err != nil {
+		return nil, err
+	}
+	if product.Tags == nil {
+		return []domain.Tag{}, nil
+	}
+	return product.Tags, nil
+}
+
+// attachProductTags loads the tags of all given products with one query
+func attachProductTags(ctx context.Context, q repository.ProductRepository, products []*domain.Product) error {
+	if len(products) == 0 {
+		return nil
+	}
+
+	byID := make(map[uuid.UUID]*domain.Product, len(products))
+	productIDs := make([]uuid.UUID, 0, len(products))
+	for _, product := range products {
+		byID[product.ID] = product
+		productIDs = append(productIDs, product.ID)
+	}
+
+	rows, err := q.ListProductTags(ctx, productIDs)
+	if err != nil {
+		return fmt.Errorf("failed to get product tags: %w", err)
+	}
+
+	for _, row := range rows {
+		product, ok := byID[row.ProductID]
+		if !ok {
+			continue
+		}
+		product.Tags = append(product.Tags, domain.Tag{
+			ID:   row.ID,
+			Slug: domain.Slug(row.Slug),
+			Name: row.Name,
+		})
+	}
+	return nil
+}
+
+func invalidTag(field, tag string, err error) error {
+	return domain.InvalidField("invalid_tag", field, fmt.Sprintf("%q must be at most 50 lowercase letters, digits and single hyphens", tag)).Wrap(err)
+}
+
+// SQLCToDomainTagSuggestion converts a SQLC TagSuggestion to a domain TagSuggestion
+func SQLCToDomainTagSuggestion(row sqlc.TagSuggestion) *domain.TagSuggestion {
+	reviewNote := ""
+	if row.ReviewNote != nil {
+		reviewNote = *row.ReviewNote
+	}
+
+	var reviewedAt *time.Time
+	if row.ReviewedAt.Valid {
+		reviewedAt = &row.ReviewedAt.Time
+	}
+
+	return &domain.TagSuggestion{
+		ID:         row.ID,
+		ProductID:  row.ProductID,
+		UserID:     row.UserID,
+		TagSlug:    domain.Slug(row.TagSlug),
+		TagName:    row.TagName,
+		Status:     domain.TagSuggestionStatus(row.Status),
+		ReviewedBy: row.ReviewedBy,
+		ReviewNote: reviewNote,
+		ReviewedAt: reviewedAt,
+		CreatedAt:  row.CreatedAt.Time,
+		UpdatedAt:  row.UpdatedAt.Time,
+	}
+}
@@ -0,0 +1,272 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"ratemysoft-backend/internal/domain"
+	"ratemysoft-backend/internal/models/sqlc"
+	"ratemysoft-backend/internal/repository"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"golang.org/x/crypto/bcrypt"
+)
+
+var (
+	errHandleTaken    = domain.Conflict("handle_taken", "this handle is already taken")
+	errNoPassword     = domain.Invalid("no_password", "this account has no password yet; use a password reset link to set one")
+	errWrongPassword  = domain.InvalidField("invalid_current_password", "current_password", "current password is incorrect")
+	errMissingCurrent = domain.InvalidField("current_password_required", "current_password", "is required to change the email or password")
+)
+
+// UpdateProfileRequest changes the fields that are set. Changing the email or password of an
+// account with a password requires CurrentPassword.
+type UpdateProfileRequest struct {
+	Handle          *string
+	Email           *string
+	Password        *string
+	CurrentPassword string
+	SessionID       domain.ID // the session making the change, which stays signed in
+}
+
+// ProfileUpdate is the outcome of UpdateProfile
+type ProfileUpdate struct {
+	User            *domain.User
+	PreviousEmail   string // set when the email changed
+	PasswordChanged bool
+}
+
+// UpdateProfile changes the user's own handle, email or password. A new email address has to
+// be verified again. Changing the email or password revokes every session but req.SessionID
+// in the same transaction, so a session opened with the old credentials cannot outlive them.
+func (s *UserService) UpdateProfile(ctx context.Context, userID domain.ID, req UpdateProfileRequest) (*ProfileUpdate, error) {
+	user, err := s.store.GetUser(ctx, userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	handle, email := user.Handle, user.Email
+	if req.Handle != nil {
+		handle = *req.Handle
+	}
+	if req.Email != nil {
+		email = *req.Email
+	}
+	emailChanged := email != user.Email
+
+	if handle != user.Handle {
+		_, err := s.store.GetUserByHandle(ctx, handle)
+		if err == nil {
+			return nil, errHandleTaken
+		}
+		if !errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("failed to check handle: %w", err)
+		}
+	}
+	if emailChanged {
+		_, err := s.store.GetUserByEmail(ctx, email)
+		if err == nil {
+			return nil, domain.Conflict("email_taken", fmt.Sprintf("user with email %s already exists", email))
+		}
+		if !errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("failed to check email: %w", err)
+		}
+	}
+
+	// The email and password guard the account, so changing either takes the current password.
+	// Accounts that only sign in through a provider have none; they set one with a reset link,
+	// which also proves they still own their email.
+	credential, err := s.store.GetCredential(ctx, sqlc.GetCredentialParams{UserID: user.ID, Provider: "email"})
+	hasPassword := err == nil
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("failed to get credentials: %w", err)
+	}
+	sensitive := emailChanged || req.Password != nil
+	if sensitive && !hasPassword {
+		return nil, errNoPassword
+	}
+	if sensitive {
+		if req.CurrentPassword == "" {
+			return nil, errMissingCurrent
+		}
+		if bcrypt.CompareHashAndPassword([]byte(*credential.SecretHash), []byte(req.CurrentPassword)) != nil {
+			return nil, errWrongPassword
+		}
+	}
+
+	secretHash := credential.SecretHash
+	if req.Password != nil {
+		hashedPassword, err := bcrypt.GenerateFromPassword([]byte(*req.Password), bcrypt.DefaultCost)
+		if err != nil {
+			return nil, fmt.Errorf("failed to hash password: %w", err)
+		}
+		hash := string(hashedPassword)
+		secretHash = &hash
+	}
+
+	now := pgtype.Timestamptz{Time: time.Now(), Valid: true}
+	var updated sqlc.User
+	err = s.store.InTx(ctx, func(q repository.Queries) error {
+		var err error
+		updated, err = q.UpdateUser(ctx, sqlc.UpdateUserParams{
+			ID:        user.ID,
+			Email:     email,
+			Handle:    handle,
+			Role:      user.Role,
+			UpdatedAt: now,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to update user: %w", err)
+		}
+
+		// The email credential signs in with the current address
+		if sensitive {
+			_, err = q.UpdateCredential(ctx, sqlc.UpdateCredentialParams{
+				UserID:     user.ID,
+				Provider:   "email",
+				Identifier: email,
+				SecretHash: secretHash,
+				UpdatedAt:  now,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to update credentials: %w", err)
+			}
+
+			_, err = q.RevokeOtherUserSessions(ctx, sqlc.RevokeOtherUserSessionsParams{
+				UserID:    user.ID,
+				ID:        req.SessionID,
+				RevokedAt: now,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to revoke sessions: %w", err)
+			}
+		}
+
+		return recordAudit(ctx, q, user.ID, domain.AuditUpdate, domain.AuditEntityUser, user.ID, user, updated)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	domainUser, err := SQLCToDomainUser(updated)
+	if err != nil {
+		return nil, err
+	}
+	result := &ProfileUpdate{User: domainUser, PasswordChanged: req.Password != nil}
+	if emailChanged {
+		result.PreviousEmail = user.Email
+	}
+	return result, nil
+}
+
+// DeleteAccount deletes the user's own account. Accounts with a password must confirm it.
+// The user leaves every company they are a member of; the last owner of a company has to hand
+// it over to someone else first.
+//
+// The user row stays behind as an anonymous tombstone so the reviews, comments and votes it
+// authored keep counting: its email and handle are replaced, its credentials are removed and
+// its sessions revoked, earlier audit events of the user carry the tombstone's email and
+// handle instead, and the audit event of the deletion records no personal data.
+func (s *UserService) DeleteAccount(ctx context.Context, userID domain.ID, password string) error {
+	user, err := s.store.GetUser(ctx, userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.ErrUserNotFound
+		}
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	credential, err := s.store.GetCredential(ctx, sqlc.GetCredentialParams{UserID: user.ID, Provider: "email"})
+	if err == nil {
+		if password == "" {
+			return domain.InvalidField("password_required", "password", "is required to delete the account")
+		}
+		if bcrypt.CompareHashAndPassword([]byte(*credential.SecretHash), []byte(password)) != nil {
+			return domain.InvalidField("invalid_password", "password", "password is incorrect")
+		}
+	} else if !errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("failed to get credentials: %w", err)
+	}
+
+	// Derived from a fresh ID rather than the user's, so nothing links the tombstone to them
+	tombstone := strings.ReplaceAll(uuid.NewString(), "-", "")[:12]
+	now := pgtype.Timestamptz{Time: time.Now(), Valid: true}
+
+	return s.store.InTx(ctx, func(q repository.Queries) error {
+		if err := leaveCompanies(ctx, q, user.ID); err != nil {
+			return err
+		}
+
+		if _, err := q.DeleteUserCredentials(ctx, user.ID); err != nil {
+			return fmt.Errorf("failed to delete credentials: %w", err)
+		}
+
+		deleted, err := q.AnonymizeUser(ctx, sqlc.AnonymizeUserParams{
+			ID:        user.ID,
+			Email:     "deleted-" + tombstone + "@users.invalid",
+			Handle:    "deleted-" + tombstone,
+			DeletedAt: now,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to delete user: %w", err)
+		}
+
+		if _, err := q.RevokeUserSessions(ctx, sqlc.RevokeUserSessionsParams{UserID: user.ID, RevokedAt: now}); err != nil {
+			return fmt.Errorf("failed to revoke sessions: %w", err)
+		}
+
+		_, err = q.RedactUserAuditEvents(ctx, sqlc.RedactUserAuditEventsParams{
+			Email:  deleted.Email,
+			Handle: deleted.Handle,
+			UserID: user.ID,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to redact audit events: %w", err)
+		}
+
+		return recordAudit(ctx, q, user.ID, domain.AuditDelete, domain.AuditEntityUser, user.ID, nil, nil)
+	})
+}
+
+// leaveCompanies removes the user from every company they are a member of, failing with
+// last_owner if one of the companies would be left without an owner. Companies are locked in
+// ID order, so two accounts leaving the same companies cannot deadlock.
+func leaveCompanies(ctx context.Context, q repository.Queries, userID uuid.UUID) error {
+	memberships, err := q.ListUserCompanyMemberships(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to list company memberships: %w", err)
+	}
+
+	for _, member := range memberships {
+		// A deleted company needs no owner, so its members just go
+		err := lockCompany(ctx, q, member.CompanyID)
+		if err != nil && !errors.Is(err, domain.ErrCompanyNotFound) {
+			return err
+		}
+		if err == nil {
+			if err := ensureAnotherOwner(ctx, q, member.CompanyID, userID); err != nil {
+				return err
+			}
+		}
+
+		_, err = q.DeleteCompanyMember(ctx, sqlc.DeleteCompanyMemberParams{
+			CompanyID: member.CompanyID,
+			UserID:    userID,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to remove company member: %w", err)
+		}
+		if err := recordMemberAudit(ctx, q, userID, member.CompanyID, userID, &member.Role, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
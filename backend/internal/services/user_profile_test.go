@@ -0,0 +1,307 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"ratemysoft-backend/internal/domain"
+	"ratemysoft-backend/internal/models/sqlc"
+	"ratemysoft-backend/internal/repository/memory"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func TestUpdateProfileHandle(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	svc := NewUserService(store)
+	alice := seedUser(t, store, "alice", domain.RoleUser)
+	seedUser(t, store, "bob", domain.RoleUser)
+
+	_, err := svc.UpdateProfile(ctx, alice.UserID, UpdateProfileRequest{Handle: ptr("bob")})
+	assertErrorIs(t, err, domain.ErrConflict)
+
+	// Seeded users have no password, which a handle change does not need
+	update, err := svc.UpdateProfile(ctx, alice.UserID, UpdateProfileRequest{Handle: ptr("alice_b")})
+	if err != nil {
+		t.Fatalf("UpdateProfile: %v", err)
+	}
+	if update.User.Handle != "alice_b" || update.PreviousEmail != "" || update.PasswordChanged {
+		t.Errorf("update = %+v", update)
+	}
+
+	events := store.AuditEvents()
+	if len(events) != 1 || events[0].Action != string(domain.AuditUpdate) || string(events[0].After) == "" {
+		t.Errorf("audit log = %+v, want one update event", events)
+	}
+}
+
+func TestUpdateProfileEmail(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	svc := NewUserService(store)
+	seedUser(t, store, "bob", domain.RoleUser)
+
+	alice, err := svc.CreateUser(ctx, CreateUserRequest{Email: "alice@example.com", Handle: "alice", Password: "correct horse"})
+	if err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+	_, err = store.SetUserEmailVerified(ctx, sqlc.SetUserEmailVerifiedParams{
+		ID:              alice.ID,
+		EmailVerifiedAt: pgtype.Timestamptz{Time: time.Now(), Valid: true},
+	})
+	if err != nil {
+		t.Fatalf("SetUserEmailVerified: %v", err)
+	}
+
+	_, err = svc.UpdateProfile(ctx, alice.ID, UpdateProfileRequest{Email: ptr("alice@new.example")})
+	assertErrorIs(t, err, domain.ErrValidation)
+	_, err = svc.UpdateProfile(ctx, alice.ID, UpdateProfileRequest{Email: ptr("alice@new.example"), CurrentPassword: "wrong horse"})
+	assertErrorIs(t, err, domain.ErrValidation)
+	_, err = svc.UpdateProfile(ctx, alice.ID, UpdateProfileRequest{Email: ptr("bob@example.com"), CurrentPassword: "correct horse"})
+	assertErrorIs(t, err, domain.ErrConflict)
+
+	// Resubmitting the current email is not a change and needs no password, and keeps the
+	// other sessions
+	session, _, err := NewSessionService(store, time.Hour).StartSession(ctx, alice.ID, SessionMetadata{})
+	if err != nil {
+		t.Fatalf("StartSession: %v", err)
+	}
+	update, err := svc.UpdateProfile(ctx, alice.ID, UpdateProfileRequest{Email: ptr("alice@example.com")})
+	if err != nil {
+		t.Fatalf("UpdateProfile with the same email: %v", err)
+	}
+	if !update.User.IsEmailVerified() || update.PreviousEmail != "" {
+		t.Errorf("update = %+v, want nothing changed", update)
+	}
+	if got, err := store.GetSession(ctx, session.ID); err != nil || got.RevokedAt.Valid {
+		t.Errorf("session = %+v, %v; want it active", got, err)
+	}
+
+	update, err = svc.UpdateProfile(ctx, alice.ID, UpdateProfileRequest{Email: ptr("alice@new.example"), CurrentPassword: "correct horse"})
+	if err != nil {
+		t.Fatalf("UpdateProfile: %v", err)
+	}
+	if update.User.Email != "alice@new.example" || update.User.IsEmailVerified() || update.PreviousEmail != "alice@example.com" {
+		t.Errorf("update = %+v, want the new email unverified", update)
+	}
+
+	if got, err := store.GetSession(ctx, session.ID); err != nil || !got.RevokedAt.Valid {
+		t.Errorf("session after changing the email = %+v, %v; want it revoked", got, err)
+	}
+
+	// The password now goes with the new address
+	if _, err := svc.AuthenticateUser(ctx, "alice@new.example", "correct horse"); err != nil {
+		t.Errorf("AuthenticateUser with the new email: %v", err)
+	}
+	_, err = svc.AuthenticateUser(ctx, "alice@example.com", "correct horse")
+	assertErrorIs(t, err, domain.ErrUnauthorized)
+}
+
+func TestUpdateProfilePassword(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	svc := NewUserService(store)
+
+	alice, err := svc.CreateUser(ctx, CreateUserRequest{Email: "alice@example.com", Handle: "alice", Password: "correct horse"})
+	if err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+
+	_, err = svc.UpdateProfile(ctx, alice.ID, UpdateProfileRequest{Password: ptr("battery staple"), CurrentPassword: "wrong horse"})
+	assertErrorIs(t, err, domain.ErrValidation)
+
+	sessions := NewSessionService(store, time.Hour)
+	current, _, err := sessions.StartSession(ctx, alice.ID, SessionMetadata{})
+	if err != nil {
+		t.Fatalf("StartSession: %v", err)
+	}
+	other, _, err := sessions.StartSession(ctx, alice.ID, SessionMetadata{})
+	if err != nil {
+		t.Fatalf("StartSession: %v", err)
+	}
+
+	update, err := svc.UpdateProfile(ctx, alice.ID, UpdateProfileRequest{Password: ptr("battery staple"), CurrentPassword: "correct horse", SessionID: current.ID})
+	if err != nil {
+		t.Fatalf("UpdateProfile: %v", err)
+	}
+	if !update.PasswordChanged {
+		t.Error("PasswordChanged = false")
+	}
+	if _, err := svc.AuthenticateUser(ctx, "alice@example.com", "battery staple"); err != nil {
+		t.Errorf("AuthenticateUser with the new password: %v", err)
+	}
+
+	// Only the session that changed the password stays signed in
+	if got, err := store.GetSession(ctx, current.ID); err != nil || got.RevokedAt.Valid {
+		t.Errorf("current session = %+v, %v; want it active", got, err)
+	}
+	if got, err := store.GetSession(ctx, other.ID); err != nil || !got.RevokedAt.Valid {
+		t.Errorf("other session = %+v, %v; want it revoked", got, err)
+	}
+
+	// Accounts that sign in through a provider set a first password with a reset link
+	octo, err := svc.AuthenticateExternal(ctx, ExternalIdentity{Provider: "github", Subject: "1", Email: "octo@example.com", EmailVerified: true, Username: "octo"})
+	if err != nil {
+		t.Fatalf("AuthenticateExternal: %v", err)
+	}
+	_, err = svc.UpdateProfile(ctx, octo.ID, UpdateProfileRequest{Password: ptr("battery staple")})
+	assertErrorIs(t, err, domain.ErrValidation)
+	_, err = svc.UpdateProfile(ctx, octo.ID, UpdateProfileRequest{Email: ptr("eve@example.com")})
+	assertErrorIs(t, err, domain.ErrValidation)
+}
+
+func TestDeleteAccount(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	svc := NewUserService(store)
+	owner := seedUser(t, store, "owner", domain.RoleUser)
+	product := seedProduct(t, store, owner, "widget")
+	reviews := NewReviewService(store, ModerationConfig{})
+
+	alice, err := svc.CreateUser(ctx, CreateUserRequest{Email: "alice@example.com", Handle: "alice", Password: "correct horse"})
+	if err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+	review, err := reviews.CreateReview(ctx, CreateReviewRequest{ProductID: product.ID.String(), UserID: alice.ID.String(), Body: "great", Rating: 5})
+	if err != nil {
+		t.Fatalf("CreateReview: %v", err)
+	}
+
+	session, _, err := NewSessionService(store, time.Hour).StartSession(ctx, alice.ID, SessionMetadata{})
+	if err != nil {
+		t.Fatalf("StartSession: %v", err)
+	}
+
+	assertErrorIs(t, svc.DeleteAccount(ctx, alice.ID, ""), domain.ErrValidation)
+	assertErrorIs(t, svc.DeleteAccount(ctx, alice.ID, "wrong horse"), domain.ErrValidation)
+	if err := svc.DeleteAccount(ctx, alice.ID, "correct horse"); err != nil {
+		t.Fatalf("DeleteAccount: %v", err)
+	}
+
+	_, err = svc.GetUserByID(ctx, alice.ID.String())
+	assertErrorIs(t, err, domain.ErrNotFound)
+	_, err = svc.AuthenticateUser(ctx, "alice@example.com", "correct horse")
+	assertErrorIs(t, err, domain.ErrUnauthorized)
+	if got, err := store.GetSession(ctx, session.ID); err != nil || !got.RevokedAt.Valid {
+		t.Errorf("session after deleting = %+v, %v; want it revoked", got, err)
+	}
+
+	// The review stays, credited to an anonymous tombstone
+	row, err := store.GetReview(ctx, review.ID)
+	if err != nil {
+		t.Fatalf("GetReview after deleting the author: %v", err)
+	}
+	if row.UserHandle == "alice" || row.Rating != 5 {
+		t.Errorf("review = %+v, want it kept without alice's handle", row)
+	}
+
+	// The email and handle are free again
+	if _, err := svc.CreateUser(ctx, CreateUserRequest{Email: "alice@example.com", Handle: "alice", Password: "x"}); err != nil {
+		t.Errorf("CreateUser with the freed email and handle: %v", err)
+	}
+
+	// The delete event keeps no personal data, and earlier events no longer name alice
+	events := store.AuditEvents()
+	var deletes int
+	for _, event := range events {
+		if event.EntityID != alice.ID {
+			continue
+		}
+		if strings.Contains(string(event.Before)+string(event.After), "alice") {
+			t.Errorf("%s event still names alice: before %s, after %s", event.Action, event.Before, event.After)
+		}
+		if event.Action == string(domain.AuditDelete) {
+			deletes++
+			if event.EntityID != alice.ID || event.Before != nil || event.After != nil {
+				t.Errorf("delete event = %+v", event)
+			}
+		}
+	}
+	if deletes != 1 {
+		t.Errorf("audit log has %d delete events, want 1", deletes)
+	}
+}
+
+func TestDeleteAccountWithoutPassword(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	svc := NewUserService(store)
+	identity := ExternalIdentity{Provider: "github", Subject: "1", Email: "octo@example.com", EmailVerified: true, Username: "octo"}
+
+	octo, err := svc.AuthenticateExternal(ctx, identity)
+	if err != nil {
+		t.Fatalf("AuthenticateExternal: %v", err)
+	}
+	if err := svc.DeleteAccount(ctx, octo.ID, ""); err != nil {
+		t.Fatalf("DeleteAccount: %v", err)
+	}
+
+	// Signing in with the provider again starts a new account
+	again, err := svc.AuthenticateExternal(ctx, identity)
+	if err != nil {
+		t.Fatalf("AuthenticateExternal after deleting: %v", err)
+	}
+	if again.ID == octo.ID {
+		t.Error("provider sign-in found the deleted account")
+	}
+}
+
+func TestDeleteAccountLeavesCompanies(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	svc := NewUserService(store)
+	companies := NewCompanyService(store, NewAuthorizer(store))
+	bob := seedUser(t, store, "bob", domain.RoleUser)
+
+	alice, err := svc.CreateUser(ctx, CreateUserRequest{Email: "alice@example.com", Handle: "alice", Password: "correct horse"})
+	if err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+	actor := domain.Actor{UserID: alice.ID, Role: domain.RoleUser}
+
+	acme, err := companies.CreateCompany(ctx, actor, CreateCompanyRequest{Name: "Acme", Slug: "acme"})
+	if err != nil {
+		t.Fatalf("CreateCompany: %v", err)
+	}
+	globex, err := companies.CreateCompany(ctx, bob, CreateCompanyRequest{Name: "Globex", Slug: "globex"})
+	if err != nil {
+		t.Fatalf("CreateCompany: %v", err)
+	}
+	if _, err := companies.SetCompanyMember(ctx, bob, globex.ID.String(), "alice", domain.CompanyEditor); err != nil {
+		t.Fatalf("SetCompanyMember: %v", err)
+	}
+
+	// The last owner of Acme has to hand it over first, and nothing changes until then
+	assertErrorIs(t, svc.DeleteAccount(ctx, alice.ID, "correct horse"), domain.ErrConflict)
+	if _, err := svc.GetUserByID(ctx, alice.ID.String()); err != nil {
+		t.Fatalf("GetUserByID after the refused deletion: %v", err)
+	}
+	if _, err := store.GetCompanyMember(ctx, sqlc.GetCompanyMemberParams{CompanyID: globex.ID, UserID: alice.ID}); err != nil {
+		t.Errorf("GetCompanyMember after the refused deletion: %v", err)
+	}
+
+	if _, err := companies.SetCompanyMember(ctx, actor, acme.ID.String(), "bob", domain.CompanyOwner); err != nil {
+		t.Fatalf("SetCompanyMember: %v", err)
+	}
+	if err := svc.DeleteAccount(ctx, alice.ID, "correct horse"); err != nil {
+		t.Fatalf("DeleteAccount: %v", err)
+	}
+
+	memberships, err := store.ListUserCompanyMemberships(ctx, alice.ID)
+	if err != nil {
+		t.Fatalf("ListUserCompanyMemberships: %v", err)
+	}
+	if len(memberships) != 0 {
+		t.Errorf("memberships after deleting = %+v, want none", memberships)
+	}
+	members, err := companies.ListCompanyMembers(ctx, bob, acme.ID.String())
+	if err != nil {
+		t.Fatalf("ListCompanyMembers: %v", err)
+	}
+	if len(members) != 1 || members[0].UserID != bob.UserID {
+		t.Errorf("acme members = %+v, want bob alone", members)
+	}
+}
@@ -4,10 +4,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	"ratemysoft-backend/internal/domain"
 	"ratemysoft-backend/internal/models/sqlc"
+	"ratemysoft-backend/internal/repository"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
@@ -17,12 +19,12 @@ import (
 
 // UserService handles user-related business logic
 type UserService struct {
-	queries *sqlc.Queries
+	store repository.Store
 }
 
-func NewUserService(queries *sqlc.Queries) *UserService {
+func NewUserService(store repository.Store) *UserService {
 	return &UserService{
-		queries: queries,
+		store: store,
 	}
 }
 
@@ -34,15 +36,23 @@ type CreateUserRequest struct {
 
 func (s *UserService) CreateUser(ctx context.Context, req CreateUserRequest) (*domain.User, error) {
 	// Check if user already exists
-	_, err := s.queries.GetUserByEmail(ctx, req.Email)
+	_, err := s.store.GetUserByEmail(ctx, req.Email)
 	if err == nil {
-		return nil, fmt.Errorf("user with email %s already exists", req.Email)
+		return nil, domain.Conflict("email_taken", fmt.Sprintf("user with email %s already exists", req.Email))
 	}
 	// Check if the error is "no rows found" (which is expected for new users)
 	if !errors.Is(err, pgx.ErrNoRows) {
 		return nil, fmt.Errorf("failed to check if user exists: %w", err)
 	}
 
+	_, err = s.store.GetUserByHandle(ctx, req.Handle)
+	if err == nil {
+		return nil, errHandleTaken
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("failed to check handle: %w", err)
+	}
+
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
 	if err != nil {
 		return nil, fmt.Errorf("failed to hash password: %w", err)
@@ -56,29 +66,39 @@ func (s *UserService) CreateUser(ctx context.Context, req CreateUserRequest) (*d
 		Valid: true,
 	}
 
-	user, err := s.queries.CreateUser(ctx, sqlc.CreateUserParams{
-		ID:        userID,
-		Email:     req.Email,
-		Handle:    req.Handle,
-		Role:      "user", // default role
-		CreatedAt: now,
-		UpdatedAt: now,
-	})
-	if err != nil {
-		return nil, fmt.Errorf("failed to create user: %w", err)
-	}
+	// Create the user and their credentials together; registering is audited as the new user's own action
+	var user sqlc.User
+	err = s.store.InTx(ctx, func(q repository.Queries) error {
+		var err error
+		user, err = q.CreateUser(ctx, sqlc.CreateUserParams{
+			ID:        userID,
+			Email:     req.Email,
+			Handle:    req.Handle,
+			Role:      "user", // default role
+			CreatedAt: now,
+			UpdatedAt: now,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create user: %w", err)
+		}
+
+		secretHashStr := string(hashedPassword)
+		_, err = q.CreateCredential(ctx, sqlc.CreateCredentialParams{
+			UserID:     userID,
+			Provider:   "email",
+			Identifier: req.Email,
+			SecretHash: &secretHashStr,
+			CreatedAt:  now,
+			UpdatedAt:  now,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create credentials: %w", err)
+		}
 
-	secretHashStr := string(hashedPassword)
-	_, err = s.queries.CreateCredential(ctx, sqlc.CreateCredentialParams{
-		UserID:     userID,
-		Provider:   "email",
-		Identifier: req.Email,
-		SecretHash: &secretHashStr,
-		CreatedAt:  now,
-		UpdatedAt:  now,
+		return recordAudit(ctx, q, userID, domain.AuditCreate, domain.AuditEntityUser, userID, nil, user)
 	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to create credentials: %w", err)
+		return nil, err
 	}
 
 	// Convert SQLC user back to domain user
@@ -88,22 +108,24 @@ func (s *UserService) CreateUser(ctx context.Context, req CreateUserRequest) (*d
 // AuthenticateUser verifies user credentials and returns user info
 func (s *UserService) AuthenticateUser(ctx context.Context, email, password string) (*domain.User, error) {
 	// Get user by email
-	user, err := s.queries.GetUserByEmail(ctx, email)
+	user, err := s.store.GetUserByEmail(ctx, email)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return nil, fmt.Errorf("invalid credentials")
+			rejectWithoutPassword(password)
+			return nil, errInvalidCredentials
 		}
 		return nil, fmt.Errorf("failed to get user: %w", err)
 	}
 
 	// Get credentials for password verification
-	credential, err := s.queries.GetCredential(ctx, sqlc.GetCredentialParams{
+	credential, err := s.store.GetCredential(ctx, sqlc.GetCredentialParams{
 		UserID:   user.ID,
 		Provider: "email",
 	})
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return nil, fmt.Errorf("invalid credentials")
+			rejectWithoutPassword(password)
+			return nil, errInvalidCredentials
 		}
 		return nil, fmt.Errorf("failed to get credentials: %w", err)
 	}
@@ -111,23 +133,39 @@ func (s *UserService) AuthenticateUser(ctx context.Context, email, password stri
 	// Verify password
 	err = bcrypt.CompareHashAndPassword([]byte(*credential.SecretHash), []byte(password))
 	if err != nil {
-		return nil, fmt.Errorf("invalid credentials")
+		return nil, errInvalidCredentials
 	}
 
 	// Convert SQLC user back to domain user
 	return SQLCToDomainUser(user)
 }
 
+// dummyPasswordHash is compared against when there is no password to check, so a sign-in
+// takes as long for an unknown email as for a wrong password and timing does not reveal
+// which emails have accounts
+var dummyPasswordHash = sync.OnceValue(func() []byte {
+	hash, err := bcrypt.GenerateFromPassword([]byte("not a real password"), bcrypt.DefaultCost)
+	if err != nil {
+		panic(fmt.Sprintf("failed to hash dummy password: %v", err))
+	}
+	return hash
+})
+
+// rejectWithoutPassword spends the time of a password check that is bound to fail
+func rejectWithoutPassword(password string) {
+	_ = bcrypt.CompareHashAndPassword(dummyPasswordHash(), []byte(password))
+}
+
 func (s *UserService) GetUserByID(ctx context.Context, userID string) (*domain.User, error) {
-	parsedID, err := uuid.Parse(userID)
+	parsedID, err := parseID("user_id", userID)
 	if err != nil {
-		return nil, fmt.Errorf("invalid user ID format: %w", err)
+		return nil, err
 	}
 
-	user, err := s.queries.GetUser(ctx, parsedID)
+	user, err := s.store.GetUser(ctx, parsedID)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return nil, fmt.Errorf("user not found")
+			return nil, domain.ErrUserNotFound
 		}
 		return nil, fmt.Errorf("failed to get user: %w", err)
 	}
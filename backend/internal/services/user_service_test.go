@@ -0,0 +1,66 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"ratemysoft-backend/internal/domain"
+	"ratemysoft-backend/internal/repository/memory"
+)
+
+func TestCreateUserAndAuthenticate(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	svc := NewUserService(store)
+
+	user, err := svc.CreateUser(ctx, CreateUserRequest{Email: "alice@example.com", Handle: "alice", Password: "correct horse"})
+	if err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+	if user.Role != domain.RoleUser {
+		t.Errorf("role = %q, want %q", user.Role, domain.RoleUser)
+	}
+
+	_, err = svc.CreateUser(ctx, CreateUserRequest{Email: "alice@example.com", Handle: "alice2", Password: "x"})
+	assertErrorIs(t, err, domain.ErrConflict)
+	_, err = svc.CreateUser(ctx, CreateUserRequest{Email: "alice2@example.com", Handle: "alice", Password: "x"})
+	assertErrorIs(t, err, domain.ErrConflict)
+
+	got, err := svc.AuthenticateUser(ctx, "alice@example.com", "correct horse")
+	if err != nil {
+		t.Fatalf("AuthenticateUser: %v", err)
+	}
+	if got.ID != user.ID {
+		t.Errorf("authenticated user = %s, want %s", got.ID, user.ID)
+	}
+
+	_, err = svc.AuthenticateUser(ctx, "alice@example.com", "wrong")
+	assertErrorIs(t, err, domain.ErrUnauthorized)
+	_, err = svc.AuthenticateUser(ctx, "nobody@example.com", "correct horse")
+	assertErrorIs(t, err, domain.ErrUnauthorized)
+
+	events := store.AuditEvents()
+	if len(events) != 1 || events[0].Action != string(domain.AuditCreate) || events[0].EntityID != user.ID {
+		t.Errorf("audit log = %+v, want one create event for the new user", events)
+	}
+}
+
+func TestGetUserByID(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	svc := NewUserService(store)
+	alice := seedUser(t, store, "alice", domain.RoleUser)
+
+	user, err := svc.GetUserByID(ctx, alice.UserID.String())
+	if err != nil {
+		t.Fatalf("GetUserByID: %v", err)
+	}
+	if user.Handle != "alice" {
+		t.Errorf("handle = %q, want alice", user.Handle)
+	}
+
+	_, err = svc.GetUserByID(ctx, "not-a-uuid")
+	assertErrorIs(t, err, domain.ErrValidation)
+	_, err = svc.GetUserByID(ctx, "00000000-0000-0000-0000-000000000001")
+	assertErrorIs(t, err, domain.ErrNotFound)
+}
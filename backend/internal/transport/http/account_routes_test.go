@@ -0,0 +1,96 @@
+package http_test
+
+import (
+	"net/http"
+	"testing"
+
+	"ratemysoft-backend/internal/transport/http/apitest"
+	"ratemysoft-backend/internal/transport/http/dto"
+)
+
+func TestEmailVerificationRoutes(t *testing.T) {
+	s := apitest.New(t, apitest.WithVerifiedEmailRequired())
+	owner := s.User("owner")
+	alice := s.User("alice")
+	widget := s.Product(owner, s.Company(owner, "acme"), "widget")
+	aliceToken := s.Token(alice)
+	review := map[string]any{"product_id": widget.ID.String(), "body": "Fast deploys and clear docs.", "rating": 4}
+
+	s.Run(t, []apitest.Case{
+		{Name: "review while unverified", Method: http.MethodPost, Path: "/api/v1/reviews", Token: aliceToken, Body: review, Status: http.StatusForbidden, Code: "email_not_verified"},
+		{Name: "request anonymously", Method: http.MethodPost, Path: "/api/v1/auth/verify-email/request", Status: http.StatusUnauthorized, Code: "missing_token"},
+		{Name: "request", Method: http.MethodPost, Path: "/api/v1/auth/verify-email/request", Token: aliceToken, Status: http.StatusAccepted},
+		{Name: "confirm without token", Method: http.MethodPost, Path: "/api/v1/auth/verify-email/confirm", Body: map[string]string{}, Status: http.StatusBadRequest, Code: "validation_failed"},
+		{Name: "confirm unknown token", Method: http.MethodPost, Path: "/api/v1/auth/verify-email/confirm", Body: map[string]string{"token": "made-up"}, Status: http.StatusBadRequest, Code: "invalid_account_token"},
+	})
+
+	token := s.Mail.Token(t, "alice@example.com")
+
+	s.Run(t, []apitest.Case{
+		{
+			Name: "confirm", Method: http.MethodPost, Path: "/api/v1/auth/verify-email/confirm", Body: map[string]string{"token": token}, Status: http.StatusOK,
+			Check: func(t *testing.T, r *apitest.Response) {
+				var user dto.UserResponse
+				r.Decode(t, &user)
+				if user.ID != alice.ID.String() || !user.EmailVerified {
+					t.Errorf("user = %+v, want alice verified", user)
+				}
+			},
+		},
+		{Name: "confirm again", Method: http.MethodPost, Path: "/api/v1/auth/verify-email/confirm", Body: map[string]string{"token": token}, Status: http.StatusBadRequest, Code: "invalid_account_token"},
+		{Name: "request when verified", Method: http.MethodPost, Path: "/api/v1/auth/verify-email/request", Token: aliceToken, Status: http.StatusConflict, Code: "email_already_verified"},
+		{Name: "review when verified", Method: http.MethodPost, Path: "/api/v1/reviews", Token: aliceToken, Body: review, Status: http.StatusCreated},
+
+		// Sessions live in Postgres
+		{
+			Name: "register sends a link", Method: http.MethodPost, Path: "/api/v1/auth/register",
+			Body:   map[string]string{"email": "bob@example.com", "handle": "bob", "password": "correct horse"},
+			Status: http.StatusCreated,
+			Check: func(t *testing.T, r *apitest.Response) {
+				var auth dto.AuthResponse
+				r.Decode(t, &auth)
+				if auth.User.EmailVerified {
+					t.Error("new user is already verified")
+				}
+				if msg := s.Mail.Last(t, "bob@example.com"); msg.Subject != "Confirm your email address" {
+					t.Errorf("subject = %q", msg.Subject)
+				}
+			},
+		},
+	})
+}
+
+func TestPasswordResetRoutes(t *testing.T) {
+	s := apitest.New(t)
+	s.User("alice")
+
+	s.Run(t, []apitest.Case{
+		{Name: "request invalid email", Method: http.MethodPost, Path: "/api/v1/auth/password-reset/request", Body: map[string]string{"email": "alice"}, Status: http.StatusBadRequest, Code: "validation_failed"},
+		{Name: "request unknown email", Method: http.MethodPost, Path: "/api/v1/auth/password-reset/request", Body: map[string]string{"email": "nobody@example.com"}, Status: http.StatusAccepted},
+		{Name: "request", Method: http.MethodPost, Path: "/api/v1/auth/password-reset/request", Body: map[string]string{"email": "Alice@Example.com"}, Status: http.StatusAccepted},
+	})
+
+	if n := len(s.Mail.Messages()); n != 1 {
+		t.Fatalf("sent %d messages, want one to alice", n)
+	}
+	token := s.Mail.Token(t, "alice@example.com")
+
+	s.Run(t, []apitest.Case{
+		{Name: "confirm short password", Method: http.MethodPost, Path: "/api/v1/auth/password-reset/confirm", Body: map[string]string{"token": token, "password": "short"}, Status: http.StatusBadRequest, Code: "validation_failed"},
+		{Name: "confirm unknown token", Method: http.MethodPost, Path: "/api/v1/auth/password-reset/confirm", Body: map[string]string{"token": "made-up", "password": "battery staple"}, Status: http.StatusBadRequest, Code: "invalid_account_token"},
+		{Name: "confirm", Method: http.MethodPost, Path: "/api/v1/auth/password-reset/confirm", Body: map[string]string{"token": token, "password": "battery staple"}, Status: http.StatusOK},
+		{Name: "confirm again", Method: http.MethodPost, Path: "/api/v1/auth/password-reset/confirm", Body: map[string]string{"token": token, "password": "battery staple"}, Status: http.StatusBadRequest, Code: "invalid_account_token"},
+		{
+			Name: "login with new password", Method: http.MethodPost, Path: "/api/v1/auth/login",
+			Body:   map[string]string{"email": "alice@example.com", "password": "battery staple"},
+			Status: http.StatusOK,
+			Check: func(t *testing.T, r *apitest.Response) {
+				var auth dto.AuthResponse
+				r.Decode(t, &auth)
+				if !auth.User.EmailVerified {
+					t.Error("reset did not verify the email")
+				}
+			},
+		},
+	})
+}
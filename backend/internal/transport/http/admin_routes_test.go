@@ -0,0 +1,206 @@
+package http_test
+
+import (
+	"net/http"
+	"testing"
+
+	"ratemysoft-backend/internal/transport/http/apitest"
+	"ratemysoft-backend/internal/transport/http/dto"
+)
+
+func TestAdminRoutes(t *testing.T) {
+	s := apitest.New(t, apitest.WithReviewApproval(), apitest.WithFlagThreshold(1))
+	owner := s.User("owner")
+	alice := s.User("alice")
+	bob := s.User("bob")
+	carol := s.User("carol")
+	admin := s.Admin("admin")
+	acme := s.Company(owner, "acme")
+	widget := s.Product(owner, acme, "widget")
+	first := s.Review(alice, widget, 5)
+	second := s.Review(bob, widget, 2)
+	s.Review(carol, widget, 3)
+
+	aliceToken, bobToken, adminToken := s.Token(alice), s.Token(bob), s.Token(admin)
+	firstPath := "/api/v1/admin/reviews/" + first.ID.String()
+	var claimID string
+
+	s.Run(t, []apitest.Case{
+		{Name: "queue anonymously", Method: http.MethodGet, Path: "/api/v1/admin/reviews/pending", Status: http.StatusUnauthorized, Code: "missing_token"},
+		{Name: "queue as user", Method: http.MethodGet, Path: "/api/v1/admin/reviews/pending", Token: aliceToken, Status: http.StatusForbidden, Code: "insufficient_role"},
+		{
+			Name: "pending second page", Method: http.MethodGet, Path: "/api/v1/admin/reviews/pending?limit=2&offset=2", Token: adminToken, Status: http.StatusOK,
+			Check: func(t *testing.T, r *apitest.Response) {
+				var queue dto.ModerationQueueResponse
+				r.Decode(t, &queue)
+				if queue.Total != 3 || len(queue.Reviews) != 1 || queue.Limit != 2 || queue.Offset != 2 {
+					t.Errorf("queue = %+v, want the last of three pending reviews", queue)
+				}
+			},
+		},
+		{
+			Name: "pending first page", Method: http.MethodGet, Path: "/api/v1/admin/reviews/pending?limit=2", Token: adminToken, Status: http.StatusOK,
+			Check: func(t *testing.T, r *apitest.Response) {
+				var queue dto.ModerationQueueResponse
+				r.Decode(t, &queue)
+				if len(queue.Reviews) != 2 || queue.Reviews[0].ID != first.ID.String() || queue.NextCursor == "" || r.Header.Get("Link") == "" {
+					t.Errorf("queue = %+v, want the two oldest pending reviews and a next link", queue)
+				}
+			},
+		},
+		{Name: "pending with forged cursor", Method: http.MethodGet, Path: "/api/v1/admin/reviews/pending?cursor=abc.def", Token: adminToken, Status: http.StatusBadRequest, Code: "invalid_cursor"},
+		{
+			Name: "pending limit capped", Method: http.MethodGet, Path: "/api/v1/admin/reviews/pending?limit=1000", Token: adminToken, Status: http.StatusOK,
+			Check: func(t *testing.T, r *apitest.Response) {
+				var queue dto.ModerationQueueResponse
+				r.Decode(t, &queue)
+				if queue.Limit != 100 || len(queue.Reviews) != 3 {
+					t.Errorf("limit = %d with %d reviews, want 100 and 3", queue.Limit, len(queue.Reviews))
+				}
+			},
+		},
+		{
+			Name: "pending review by id", Method: http.MethodGet, Path: "/api/v1/reviews/" + first.ID.String(), Token: adminToken, Status: http.StatusOK,
+			Check: func(t *testing.T, r *apitest.Response) {
+				var review dto.ReviewResponse
+				r.Decode(t, &review)
+				if review.Status != "pending" {
+					t.Errorf("status = %q, want pending", review.Status)
+				}
+			},
+		},
+		{Name: "pending review anonymously", Method: http.MethodGet, Path: "/api/v1/reviews/" + first.ID.String(), Status: http.StatusNotFound, Code: "review_not_found"},
+		{Name: "approve malformed id", Method: http.MethodPost, Path: "/api/v1/admin/reviews/nope/approve", Token: adminToken, Body: map[string]string{}, Status: http.StatusBadRequest, Code: "invalid_id"},
+		{Name: "approve", Method: http.MethodPost, Path: firstPath + "/approve", Token: adminToken, Body: map[string]string{}, Status: http.StatusOK},
+		{Name: "reject", Method: http.MethodPost, Path: "/api/v1/admin/reviews/" + second.ID.String() + "/reject", Token: adminToken, Body: map[string]string{"reason": "off topic"}, Status: http.StatusOK},
+		{
+			Name: "moderation history", Method: http.MethodGet, Path: firstPath + "/moderations", Token: adminToken, Status: http.StatusOK,
+			Check: func(t *testing.T, r *apitest.Response) {
+				var body struct {
+					Moderations []dto.ReviewModerationResponse `json:"moderations"`
+				}
+				r.Decode(t, &body)
+				if len(body.Moderations) != 1 || body.Moderations[0].ToStatus != "published" || body.Moderations[0].ModeratorHandle != "admin" {
+					t.Errorf("history = %+v, want one approval by admin", body.Moderations)
+				}
+			},
+		},
+		{Name: "flag approved review", Method: http.MethodPost, Path: "/api/v1/reviews/" + first.ID.String() + "/flag", Token: bobToken, Body: map[string]string{"reason": "fake"}, Status: http.StatusOK},
+		{
+			Name: "flagged queue", Method: http.MethodGet, Path: "/api/v1/admin/reviews/flagged", Token: adminToken, Status: http.StatusOK,
+			Check: func(t *testing.T, r *apitest.Response) {
+				var queue dto.ModerationQueueResponse
+				r.Decode(t, &queue)
+				if queue.Total != 1 || queue.Reviews[0].ID != first.ID.String() {
+					t.Errorf("flagged queue = %+v, want the flagged review", queue)
+				}
+			},
+		},
+		{
+			Name: "flags", Method: http.MethodGet, Path: firstPath + "/flags", Token: adminToken, Status: http.StatusOK,
+			Check: func(t *testing.T, r *apitest.Response) {
+				var body struct {
+					Flags []dto.ReviewFlagResponse `json:"flags"`
+				}
+				r.Decode(t, &body)
+				if len(body.Flags) != 1 || body.Flags[0].UserHandle != "bob" || body.Flags[0].Reason != "fake" {
+					t.Errorf("flags = %+v, want bob's", body.Flags)
+				}
+			},
+		},
+
+		{Name: "pending comments", Method: http.MethodGet, Path: "/api/v1/admin/comments/pending", Token: adminToken, Status: http.StatusOK},
+		{Name: "flagged comments", Method: http.MethodGet, Path: "/api/v1/admin/comments/flagged", Token: adminToken, Status: http.StatusOK},
+		{Name: "approve unknown comment", Method: http.MethodPost, Path: "/api/v1/admin/comments/00000000-0000-0000-0000-000000000001/approve", Token: adminToken, Body: map[string]string{}, Status: http.StatusNotFound, Code: "comment_not_found"},
+		{Name: "reject unknown comment", Method: http.MethodPost, Path: "/api/v1/admin/comments/00000000-0000-0000-0000-000000000001/reject", Token: adminToken, Body: map[string]string{"reason": "off-topic"}, Status: http.StatusNotFound, Code: "comment_not_found"},
+
+		{
+			Name: "claim", Method: http.MethodPost, Path: "/api/v1/companies/" + acme.ID.String() + "/claim", Token: aliceToken, Body: map[string]string{}, Status: http.StatusAccepted,
+			Check: func(t *testing.T, r *apitest.Response) {
+				var claim dto.CompanyClaimResponse
+				r.Decode(t, &claim)
+				claimID = claim.ID
+			},
+		},
+		{Name: "claims bad status", Method: http.MethodGet, Path: "/api/v1/admin/company-claims?status=lost", Token: adminToken, Status: http.StatusBadRequest, Code: "invalid_claim_status"},
+		{
+			Name: "pending claims", Method: http.MethodGet, Path: "/api/v1/admin/company-claims", Token: adminToken, Status: http.StatusOK,
+			Check: func(t *testing.T, r *apitest.Response) {
+				var list dto.CompanyClaimListResponse
+				r.Decode(t, &list)
+				if list.Total != 1 || list.Claims[0].UserHandle != "alice" {
+					t.Errorf("claims = %+v, want alice's", list)
+				}
+			},
+		},
+		{Name: "reject claim without note", Method: http.MethodPost, Path: "/api/v1/admin/company-claims/00000000-0000-0000-0000-000000000001/reject", Token: adminToken, Body: map[string]string{}, Status: http.StatusBadRequest, Code: "note_required"},
+		{Name: "reject unknown claim", Method: http.MethodPost, Path: "/api/v1/admin/company-claims/00000000-0000-0000-0000-000000000001/reject", Token: adminToken, Body: map[string]string{"note": "no evidence"}, Status: http.StatusNotFound, Code: "claim_not_found"},
+	})
+
+	// The claim ID is only known once the claim case has run
+	s.Run(t, []apitest.Case{
+		{Name: "approve claim", Method: http.MethodPost, Path: "/api/v1/admin/company-claims/" + claimID + "/approve", Token: adminToken, Body: map[string]string{"note": "verified"}, Status: http.StatusOK},
+		{Name: "approve claim twice", Method: http.MethodPost, Path: "/api/v1/admin/company-claims/" + claimID + "/approve", Token: adminToken, Body: map[string]string{}, Status: http.StatusConflict, Code: "claim_resolved"},
+		{Name: "claimant manages members", Method: http.MethodGet, Path: "/api/v1/companies/" + acme.ID.String() + "/members", Token: aliceToken, Status: http.StatusOK},
+
+		{Name: "audit events", Method: http.MethodGet, Path: "/api/v1/admin/audit-events?limit=5", Token: adminToken, Status: http.StatusOK},
+		{Name: "audit export", Method: http.MethodGet, Path: "/api/v1/admin/audit-events/export", Token: adminToken, Status: http.StatusOK},
+		{Name: "audit events as user", Method: http.MethodGet, Path: "/api/v1/admin/audit-events", Token: aliceToken, Status: http.StatusForbidden, Code: "insufficient_role"},
+	})
+}
+
+func TestCategoryRoutes(t *testing.T) {
+	s := apitest.New(t)
+	owner := s.User("owner")
+	s.Product(owner, s.Company(owner, "acme"), "widget")
+	ownerToken, adminToken := s.Token(owner), s.Token(s.Admin("admin"))
+	var created dto.CategoryResponse
+
+	s.Run(t, []apitest.Case{
+		{
+			Name: "list", Method: http.MethodGet, Path: "/api/v1/categories", Status: http.StatusOK,
+			Check: func(t *testing.T, r *apitest.Response) {
+				var list dto.CategoryListResponse
+				r.Decode(t, &list)
+				if list.Count != 5 {
+					t.Errorf("count = %d, want the five seeded categories", list.Count)
+				}
+			},
+		},
+		{
+			Name: "get", Method: http.MethodGet, Path: "/api/v1/categories/hosting", Status: http.StatusOK,
+			Check: func(t *testing.T, r *apitest.Response) {
+				var category dto.CategoryDetailResponse
+				r.Decode(t, &category)
+				if category.ProductCount != 1 {
+					t.Errorf("product count = %d, want 1", category.ProductCount)
+				}
+			},
+		},
+		{Name: "get unknown", Method: http.MethodGet, Path: "/api/v1/categories/spaceships", Status: http.StatusNotFound, Code: "category_not_found"},
+
+		{Name: "create as user", Method: http.MethodPost, Path: "/api/v1/admin/categories", Token: ownerToken, Body: map[string]string{"slug": "paas", "name": "PaaS"}, Status: http.StatusForbidden, Code: "insufficient_role"},
+		{Name: "create without name", Method: http.MethodPost, Path: "/api/v1/admin/categories", Token: adminToken, Body: map[string]string{"slug": "paas"}, Status: http.StatusBadRequest, Code: "validation_failed"},
+		{Name: "create taken slug", Method: http.MethodPost, Path: "/api/v1/admin/categories", Token: adminToken, Body: map[string]string{"slug": "hosting", "name": "Hosting"}, Status: http.StatusConflict, Code: "slug_taken"},
+		{
+			Name: "create subcategory", Method: http.MethodPost, Path: "/api/v1/admin/categories", Token: adminToken,
+			Body:   map[string]string{"slug": "paas", "name": "PaaS", "parent": "hosting"},
+			Status: http.StatusCreated,
+			Check: func(t *testing.T, r *apitest.Response) {
+				r.Decode(t, &created)
+				if created.ParentID == nil {
+					t.Errorf("created = %+v, want a parent", created)
+				}
+			},
+		},
+		{Name: "delete by slug", Method: http.MethodDelete, Path: "/api/v1/admin/categories/hosting", Token: adminToken, Status: http.StatusBadRequest, Code: "invalid_id"},
+	})
+
+	categoryPath := "/api/v1/admin/categories/" + created.ID
+	s.Run(t, []apitest.Case{
+		{Name: "update", Method: http.MethodPut, Path: categoryPath, Token: adminToken, Body: map[string]string{"slug": "platforms", "name": "Platforms", "parent": "hosting"}, Status: http.StatusOK},
+		{Name: "get renamed", Method: http.MethodGet, Path: "/api/v1/categories/platforms", Status: http.StatusOK},
+		{Name: "delete", Method: http.MethodDelete, Path: categoryPath, Token: adminToken, Status: http.StatusOK},
+		{Name: "delete again", Method: http.MethodDelete, Path: categoryPath, Token: adminToken, Status: http.StatusNotFound, Code: "category_not_found"},
+	})
+}
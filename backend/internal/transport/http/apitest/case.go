@@ -0,0 +1,41 @@
+package apitest
+
+import (
+	"testing"
+)
+
+// Case is one request of a table-driven route test
+type Case struct {
+	Name   string
+	Method string
+	Path   string
+	Token  string // empty for anonymous requests
+	Body   any    // encoded as JSON unless it is already a string
+	Status int
+	Code   string // problem code expected on error responses, checked when set
+
+	// Check makes optional assertions on the payload of a response with the expected status
+	Check func(t *testing.T, r *Response)
+}
+
+// Run sends the cases in order as subtests, so each case sees the writes of the ones before it
+func (s *Server) Run(t *testing.T, cases []Case) {
+	t.Helper()
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			r := s.do(t, tc.Method, tc.Path, tc.Body, tc.Token)
+			if r.Code != tc.Status {
+				t.Fatalf("%s %s: status = %d, want %d; body: %s", tc.Method, tc.Path, r.Code, tc.Status, r.Body)
+			}
+			if tc.Code != "" {
+				if problem := r.Problem(t); problem.Code != tc.Code {
+					t.Errorf("%s %s: problem code = %q, want %q", tc.Method, tc.Path, problem.Code, tc.Code)
+				}
+			}
+			if tc.Check != nil {
+				tc.Check(t, r)
+			}
+		})
+	}
+}
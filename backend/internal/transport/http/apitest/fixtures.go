@@ -0,0 +1,110 @@
+package apitest
+
+import (
+	"context"
+	"time"
+
+	"ratemysoft-backend/internal/domain"
+	"ratemysoft-backend/internal/models/sqlc"
+	"ratemysoft-backend/internal/services"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// User inserts a regular user with the given handle and an email derived from it.
+// Fixture users have no password; register through the API to test logging in.
+func (s *Server) User(handle string) *domain.User {
+	s.t.Helper()
+	return s.createUser(handle, domain.RoleUser)
+}
+
+// Admin inserts a user with the admin role
+func (s *Server) Admin(handle string) *domain.User {
+	s.t.Helper()
+	return s.createUser(handle, domain.RoleAdmin)
+}
+
+func (s *Server) createUser(handle string, role domain.UserRole) *domain.User {
+	s.t.Helper()
+
+	now := time.Now().UTC()
+	row, err := s.Store.CreateUser(context.Background(), sqlc.CreateUserParams{
+		ID:        uuid.New(),
+		Email:     handle + "@example.com",
+		Handle:    handle,
+		Role:      string(role),
+		CreatedAt: pgtype.Timestamptz{Time: now, Valid: true},
+		UpdatedAt: pgtype.Timestamptz{Time: now, Valid: true},
+	})
+	if err != nil {
+		s.t.Fatalf("failed to create user %q: %v", handle, err)
+	}
+
+	return &domain.User{
+		ID:        row.ID,
+		Email:     domain.Email(row.Email),
+		Handle:    row.Handle,
+		Role:      domain.UserRole(row.Role),
+		CreatedAt: row.CreatedAt.Time,
+		UpdatedAt: row.UpdatedAt.Time,
+	}
+}
+
+// Company creates a company owned by owner, named after its slug
+func (s *Server) Company(owner *domain.User, slug string) *domain.Company {
+	s.t.Helper()
+
+	company, err := services.NewCompanyService(s.Store, s.authz).CreateCompany(context.Background(), actor(owner), services.CreateCompanyRequest{
+		Name: "Company " + slug,
+		Slug: slug,
+	})
+	if err != nil {
+		s.t.Fatalf("failed to create company %q: %v", slug, err)
+	}
+	return company
+}
+
+// Product creates a hosting product in company; owner must be allowed to edit the company
+func (s *Server) Product(owner *domain.User, company *domain.Company, slug string) *domain.Product {
+	s.t.Helper()
+
+	product, err := services.NewProductService(s.Store, s.authz).CreateProduct(context.Background(), actor(owner), services.CreateProductRequest{
+		CompanyID: company.ID.String(),
+		Name:      "Product " + slug,
+		Slug:      slug,
+		Category:  "hosting",
+	})
+	if err != nil {
+		s.t.Fatalf("failed to create product %q: %v", slug, err)
+	}
+	return product
+}
+
+// Review posts a review of product by author; it is published unless the server
+// was built WithReviewApproval
+func (s *Server) Review(author *domain.User, product *domain.Product, rating int) *domain.Review {
+	s.t.Helper()
+
+	review, err := services.NewReviewService(s.Store, s.moderation()).CreateReview(context.Background(), services.CreateReviewRequest{
+		ProductID: product.ID.String(),
+		UserID:    author.ID.String(),
+		Body:      "A fixture review by " + author.Handle,
+		Rating:    rating,
+	})
+	if err != nil {
+		s.t.Fatalf("failed to create review by %q: %v", author.Handle, err)
+	}
+	return review
+}
+
+func (s *Server) moderation() services.ModerationConfig {
+	return services.ModerationConfig{
+		RequireApproval: s.Config.RequireReviewApproval,
+		FlagThreshold:   int32(s.Config.ReviewFlagThreshold),
+	}
+}
+
+func actor(user *domain.User) domain.Actor {
+	return domain.Actor{UserID: user.ID, Role: user.Role}
+}
@@ -0,0 +1,98 @@
+package apitest
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"ratemysoft-backend/internal/platform/migrate"
+	"ratemysoft-backend/migrations"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// DatabaseURLEnv names the variable pointing at a disposable Postgres database for API tests.
+// Every table in it is emptied before each test.
+const DatabaseURLEnv = "TEST_DATABASE_URL"
+
+// ciEnv is set by CI runners, where tests that need Postgres fail rather than skip
+const ciEnv = "CI"
+
+// seededCategories are inserted by the migrations and survive the reset between tests
+var seededCategories = []string{"hosting", "feature_toggles", "ci_cd", "observability", "other"}
+
+// postgresPool connects to the test database, migrates it and removes the previous test's
+// data; it returns nil when no database is configured
+func postgresPool(t testing.TB) *pgxpool.Pool {
+	t.Helper()
+
+	url := os.Getenv(DatabaseURLEnv)
+	if url == "" {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	pool, err := pgxpool.New(ctx, url)
+	if err != nil {
+		t.Fatalf("failed to connect to %s: %v", DatabaseURLEnv, err)
+	}
+	t.Cleanup(pool.Close)
+
+	migrator, err := migrate.New(pool, migrations.FS)
+	if err != nil {
+		t.Fatalf("failed to load migrations: %v", err)
+	}
+	if _, err := migrator.Up(ctx); err != nil {
+		t.Fatalf("failed to migrate test database: %v", err)
+	}
+	if err := reset(ctx, pool); err != nil {
+		t.Fatalf("failed to reset test database: %v", err)
+	}
+
+	return pool
+}
+
+// reset truncates every table except the migration bookkeeping and drops categories the
+// migrations did not create
+func reset(ctx context.Context, pool *pgxpool.Pool) error {
+	rows, err := pool.Query(ctx, `
+		SELECT tablename FROM pg_tables
+		WHERE schemaname = 'public' AND tablename NOT IN ('schema_migrations', 'categories')`)
+	if err != nil {
+		return fmt.Errorf("failed to list tables: %w", err)
+	}
+	tables, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return fmt.Errorf("failed to list tables: %w", err)
+	}
+
+	if len(tables) > 0 {
+		identifiers := make([]string, len(tables))
+		for i, table := range tables {
+			identifiers[i] = pgx.Identifier{table}.Sanitize()
+		}
+		if _, err := pool.Exec(ctx, "TRUNCATE "+strings.Join(identifiers, ", ")+" CASCADE"); err != nil {
+			return fmt.Errorf("failed to truncate tables: %w", err)
+		}
+	}
+
+	// parent_id is ON DELETE RESTRICT, so remove subcategories before their parents
+	for {
+		tag, err := pool.Exec(ctx, `
+			DELETE FROM categories c
+			WHERE c.slug <> ALL($1)
+			  AND NOT EXISTS (SELECT 1 FROM categories child WHERE child.parent_id = c.id)`, seededCategories)
+		if err != nil {
+			return fmt.Errorf("failed to delete test categories: %w", err)
+		}
+		if tag.RowsAffected() == 0 {
+			return nil
+		}
+	}
+}
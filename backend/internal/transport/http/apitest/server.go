@@ -0,0 +1,261 @@
+// Package apitest boots the HTTP API the way cmd/main.go does, for handler-level tests.
+//
+// A Server runs against the in-memory repository store by default. When TEST_DATABASE_URL
+// points at a disposable Postgres database it runs against Postgres instead, migrated and
+// emptied before each test, so the SQL behind the same routes is exercised too.
+package apitest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"ratemysoft-backend/internal/auth"
+	"ratemysoft-backend/internal/domain"
+	"ratemysoft-backend/internal/models/sqlc"
+	"ratemysoft-backend/internal/platform/config"
+	"ratemysoft-backend/internal/platform/mail/mailtest"
+	"ratemysoft-backend/internal/repository"
+	"ratemysoft-backend/internal/repository/memory"
+	"ratemysoft-backend/internal/repository/postgres"
+	"ratemysoft-backend/internal/services"
+	apihttp "ratemysoft-backend/internal/transport/http"
+	"ratemysoft-backend/internal/transport/http/dto"
+	"ratemysoft-backend/internal/transport/http/handlers"
+	"ratemysoft-backend/internal/transport/http/middleware"
+	"ratemysoft-backend/internal/utils"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/labstack/echo/v4"
+)
+
+// Server is the Echo app from router.SetupRoutes wired to test storage and a test JWTService
+type Server struct {
+	Echo   *echo.Echo
+	Store  repository.Store
+	JWT    *auth.JWTService
+	Config *config.Config
+	Mail   *mailtest.Recorder // every email the API sent
+
+	t          testing.TB
+	pool       *pgxpool.Pool // nil on the in-memory store
+	sessions   *services.SessionService
+	reputation *services.ReputationService
+	authz      *services.Authorizer
+}
+
+// Option adjusts the configuration a Server is built with
+type Option func(*config.Config)
+
+// WithReviewApproval makes new reviews wait in the moderation queue
+func WithReviewApproval() Option {
+	return func(cfg *config.Config) {
+		cfg.RequireReviewApproval = true
+	}
+}
+
+// WithFlagThreshold sets the flag count at which a review enters the flagged queue
+func WithFlagThreshold(threshold int) Option {
+	return func(cfg *config.Config) {
+		cfg.ReviewFlagThreshold = threshold
+	}
+}
+
+// WithVerifiedEmailRequired blocks reviews from users who have not verified their email
+func WithVerifiedEmailRequired() Option {
+	return func(cfg *config.Config) {
+		cfg.RequireVerifiedEmail = true
+	}
+}
+
+// WithTrustedProxies makes the server believe X-Forwarded-For on requests from these CIDRs.
+// Test requests come from 192.0.2.1.
+func WithTrustedProxies(cidrs ...string) Option {
+	return func(cfg *config.Config) {
+		cfg.TrustedProxies = cidrs
+	}
+}
+
+// WithOIDC enables the generic OpenID Connect login provider against issuerURL
+func WithOIDC(name, issuerURL, clientID, clientSecret string) Option {
+	return func(cfg *config.Config) {
+		cfg.OIDCProviderName = name
+		cfg.OIDCIssuerURL = issuerURL
+		cfg.OIDCClientID = clientID
+		cfg.OIDCClientSecret = clientSecret
+	}
+}
+
+// New builds a Server for t; see the package documentation for how storage is chosen
+func New(t testing.TB, opts ...Option) *Server {
+	t.Helper()
+
+	cfg := &config.Config{
+		JWTSecret:                 "apitest-jwt-secret",
+		CursorSecret:              "apitest-cursor-secret",
+		JWTAccessExpiryMinutes:    15,
+		RefreshTokenExpiryHours:   24,
+		ReviewFlagThreshold:       3,
+		LeaderboardPriorWeight:    10,
+		AppBaseURL:                "http://app.test",
+		EmailVerificationTTLHours: 48,
+		PasswordResetTTLMinutes:   60,
+		LoginMaxAccountFailures:   5,
+		LoginMaxIPFailures:        20,
+		LoginLockoutSeconds:       30,
+		LoginMaxLockoutMinutes:    60,
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	s := &Server{
+		Config: cfg,
+		JWT:    auth.NewJWTService(cfg.JWTSecret, time.Duration(cfg.JWTAccessExpiryMinutes)*time.Minute),
+		Mail:   &mailtest.Recorder{},
+		t:      t,
+	}
+
+	if pool := postgresPool(t); pool != nil {
+		s.pool = pool
+		s.Store = postgres.NewStore(pool, sqlc.New(pool))
+	} else {
+		s.Store = memory.NewStore()
+	}
+	leaderboard := services.NewLeaderboardService(s.Store, float64(cfg.LeaderboardPriorWeight))
+	s.reputation = services.NewReputationService(s.Store)
+	s.sessions = services.NewSessionService(s.Store, time.Duration(cfg.RefreshTokenExpiryHours)*time.Hour)
+	s.JWT.SetRevocationChecker(s.sessions)
+	s.authz = services.NewAuthorizer(s.Store)
+
+	e := echo.New()
+	e.HideBanner = true
+	e.Validator = utils.NewValidator()
+	e.HTTPErrorHandler = apihttp.HTTPErrorHandler
+	extractor, err := middleware.ClientIP(cfg.TrustedProxies)
+	if err != nil {
+		t.Fatalf("failed to set up client addresses: %v", err)
+	}
+	e.IPExtractor = extractor
+	e.Use(middleware.RequestID())
+
+	handler := handlers.NewHandler(s.Store, s.JWT, s.sessions, leaderboard, s.reputation, s.Mail, cfg)
+	apihttp.SetupRoutes(e, handler, s.JWT)
+	s.Echo = e
+
+	return s
+}
+
+// Postgres reports whether the server runs against a real database
+func (s *Server) Postgres() bool {
+	return s.pool != nil
+}
+
+// RequirePostgres skips the calling test on the in-memory store. In CI it fails the test
+// instead, so routes that need Postgres cannot go untested there.
+func (s *Server) RequirePostgres(t testing.TB) {
+	t.Helper()
+	if s.Postgres() {
+		return
+	}
+	if os.Getenv(ciEnv) != "" {
+		t.Fatalf("needs Postgres, but %s is not set in CI", DatabaseURLEnv)
+	}
+	t.Skipf("needs Postgres; set %s to run", DatabaseURLEnv)
+}
+
+// RefreshReputation recomputes reviewer reputation and badges, as the background job in
+// cmd/main.go does periodically
+func (s *Server) RefreshReputation() {
+	s.t.Helper()
+	if err := s.reputation.Refresh(context.Background()); err != nil {
+		s.t.Fatalf("failed to refresh reputation: %v", err)
+	}
+}
+
+// Token issues an access token for user. The token belongs to a real session, so logout
+// and revocation behave as they do after a login.
+func (s *Server) Token(user *domain.User) string {
+	s.t.Helper()
+
+	session, _, err := s.sessions.StartSession(context.Background(), user.ID, services.SessionMetadata{})
+	if err != nil {
+		s.t.Fatalf("failed to start session for %s: %v", user.Handle, err)
+	}
+
+	token, err := s.JWT.GenerateToken(user, session.ID)
+	if err != nil {
+		s.t.Fatalf("failed to issue token for %s: %v", user.Handle, err)
+	}
+	return token
+}
+
+// Response is a recorded reply from the API
+type Response struct {
+	Code   int
+	Header http.Header
+	Body   []byte
+}
+
+// Do sends a request with an optional JSON body; token may be empty for anonymous calls
+func (s *Server) Do(method, path string, body any, token string) *Response {
+	s.t.Helper()
+	return s.do(s.t, method, path, body, token)
+}
+
+func (s *Server) do(t testing.TB, method, path string, body any, token string) *Response {
+	t.Helper()
+
+	var reader io.Reader
+	if body != nil {
+		raw, ok := body.(string)
+		if !ok {
+			encoded, err := json.Marshal(body)
+			if err != nil {
+				t.Fatalf("failed to encode request body: %v", err)
+			}
+			raw = string(encoded)
+		}
+		reader = bytes.NewBufferString(raw)
+	}
+
+	req := httptest.NewRequest(method, path, reader)
+	if body != nil {
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	}
+	if token != "" {
+		req.Header.Set(echo.HeaderAuthorization, "Bearer "+token)
+	}
+
+	return s.Serve(req)
+}
+
+// Serve sends a prepared request, for calls that need more than a body and a token
+func (s *Server) Serve(req *http.Request) *Response {
+	rec := httptest.NewRecorder()
+	s.Echo.ServeHTTP(rec, req)
+
+	return &Response{Code: rec.Code, Header: rec.Header(), Body: rec.Body.Bytes()}
+}
+
+// Decode unmarshals the JSON body into v, failing the test if it does not parse
+func (r *Response) Decode(t testing.TB, v any) {
+	t.Helper()
+	if err := json.Unmarshal(r.Body, v); err != nil {
+		t.Fatalf("failed to decode response %s: %v", r.Body, err)
+	}
+}
+
+// Problem decodes an RFC 7807 error body
+func (r *Response) Problem(t testing.TB) dto.ProblemResponse {
+	t.Helper()
+	var problem dto.ProblemResponse
+	r.Decode(t, &problem)
+	return problem
+}
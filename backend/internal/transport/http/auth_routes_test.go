@@ -0,0 +1,205 @@
+package http_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"ratemysoft-backend/internal/services"
+	"ratemysoft-backend/internal/transport/http/apitest"
+	"ratemysoft-backend/internal/transport/http/dto"
+
+	"github.com/labstack/echo/v4"
+)
+
+func TestAuthRoutes(t *testing.T) {
+	s := apitest.New(t)
+	alice := s.User("alice")
+	aliceToken := s.Token(alice)
+
+	var refreshToken string
+
+	s.Run(t, []apitest.Case{
+		{Name: "welcome", Method: http.MethodGet, Path: "/", Status: http.StatusOK},
+		{
+			Name: "health", Method: http.MethodGet, Path: "/api/health", Status: http.StatusOK,
+			Check: func(t *testing.T, r *apitest.Response) {
+				var body map[string]any
+				r.Decode(t, &body)
+				if body["database"] != "healthy" {
+					t.Errorf("database = %v, want healthy", body["database"])
+				}
+			},
+		},
+		{Name: "unknown route", Method: http.MethodGet, Path: "/api/v1/nope", Status: http.StatusNotFound, Code: "not_found"},
+
+		{Name: "profile without token", Method: http.MethodGet, Path: "/api/v1/auth/profile", Status: http.StatusUnauthorized, Code: "missing_token"},
+		{Name: "profile with bad token", Method: http.MethodGet, Path: "/api/v1/auth/profile", Token: "not-a-jwt", Status: http.StatusUnauthorized, Code: "invalid_token"},
+		{
+			Name: "profile", Method: http.MethodGet, Path: "/api/v1/auth/profile", Token: aliceToken, Status: http.StatusOK,
+			Check: func(t *testing.T, r *apitest.Response) {
+				var user dto.UserResponse
+				r.Decode(t, &user)
+				if user.ID != alice.ID.String() || user.Handle != "alice" || user.Role != "user" {
+					t.Errorf("profile = %+v, want alice", user)
+				}
+			},
+		},
+
+		{Name: "register malformed body", Method: http.MethodPost, Path: "/api/v1/auth/register", Body: "{", Status: http.StatusBadRequest, Code: "invalid_body"},
+		{
+			Name: "register invalid fields", Method: http.MethodPost, Path: "/api/v1/auth/register",
+			Body:   map[string]string{"email": "not-an-email", "handle": "b", "password": "short"},
+			Status: http.StatusBadRequest, Code: "validation_failed",
+			Check: func(t *testing.T, r *apitest.Response) {
+				if problem := r.Problem(t); len(problem.Errors) != 3 {
+					t.Errorf("field errors = %+v, want email, handle and password", problem.Errors)
+				}
+			},
+		},
+		{
+			Name: "register taken email", Method: http.MethodPost, Path: "/api/v1/auth/register",
+			Body:   map[string]string{"email": "Alice@Example.com", "handle": "alice2", "password": "correct horse"},
+			Status: http.StatusConflict, Code: "email_taken",
+		},
+		{
+			Name: "login unknown user", Method: http.MethodPost, Path: "/api/v1/auth/login",
+			Body:   map[string]string{"email": "nobody@example.com", "password": "correct horse"},
+			Status: http.StatusUnauthorized,
+		},
+
+		// Sessions live in Postgres
+		{
+			Name: "register", Method: http.MethodPost, Path: "/api/v1/auth/register",
+			Body:   map[string]string{"email": "bob@example.com", "handle": "bob", "password": "correct horse"},
+			Status: http.StatusCreated,
+		},
+		{
+			Name: "login", Method: http.MethodPost, Path: "/api/v1/auth/login",
+			Body:   map[string]string{"email": "bob@example.com", "password": "correct horse"},
+			Status: http.StatusOK,
+			Check: func(t *testing.T, r *apitest.Response) {
+				var auth dto.AuthResponse
+				r.Decode(t, &auth)
+				if auth.Token == "" || auth.RefreshToken == "" || auth.User.Handle != "bob" {
+					t.Fatalf("auth response = %+v", auth)
+				}
+				refreshToken = auth.RefreshToken
+			},
+		},
+		{
+			Name: "login wrong password", Method: http.MethodPost, Path: "/api/v1/auth/login",
+			Body:   map[string]string{"email": "bob@example.com", "password": "wrong horse"},
+			Status: http.StatusUnauthorized,
+		},
+		{Name: "refresh missing token", Method: http.MethodPost, Path: "/api/v1/auth/refresh", Body: map[string]string{}, Status: http.StatusBadRequest, Code: "validation_failed"},
+		{Name: "refresh unknown token", Method: http.MethodPost, Path: "/api/v1/auth/refresh", Body: map[string]string{"refresh_token": "bogus"}, Status: http.StatusUnauthorized},
+		{Name: "logout", Method: http.MethodPost, Path: "/api/v1/auth/logout", Token: aliceToken, Status: http.StatusOK},
+		{Name: "profile after logout", Method: http.MethodGet, Path: "/api/v1/auth/profile", Token: aliceToken, Status: http.StatusUnauthorized, Code: "invalid_token"},
+		{Name: "logout all without token", Method: http.MethodPost, Path: "/api/v1/auth/logout-all", Status: http.StatusUnauthorized, Code: "missing_token"},
+	})
+
+	r := s.Do(http.MethodPost, "/api/v1/auth/refresh", map[string]string{"refresh_token": refreshToken}, "")
+	if r.Code != http.StatusOK {
+		t.Errorf("refresh: status = %d; body: %s", r.Code, r.Body)
+	}
+}
+
+func TestLoginLockoutRoutes(t *testing.T) {
+	s := apitest.New(t)
+	admin := s.Admin("admin")
+	adminToken := s.Token(admin)
+
+	// Signing in needs a password, which the fixture users do not have
+	dave, err := services.NewUserService(s.Store).CreateUser(context.Background(), services.CreateUserRequest{
+		Email: "dave@example.com", Handle: "dave", Password: "correct horse",
+	})
+	if err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+	unlockPath := "/api/v1/admin/users/" + dave.ID.String() + "/unlock"
+
+	wrong := map[string]string{"email": "dave@example.com", "password": "wrong password"}
+	right := map[string]string{"email": "dave@example.com", "password": "correct horse"}
+
+	cases := make([]apitest.Case, 0, s.Config.LoginMaxAccountFailures+8)
+	for range s.Config.LoginMaxAccountFailures {
+		cases = append(cases, apitest.Case{Name: "wrong password", Method: http.MethodPost, Path: "/api/v1/auth/login", Body: wrong, Status: http.StatusUnauthorized, Code: "invalid_credentials"})
+	}
+	cases = append(cases,
+		apitest.Case{
+			Name: "locked", Method: http.MethodPost, Path: "/api/v1/auth/login", Body: right, Status: http.StatusTooManyRequests, Code: "login_locked",
+			Check: func(t *testing.T, r *apitest.Response) {
+				if got := r.Header.Get("Retry-After"); got != "30" {
+					t.Errorf("Retry-After = %q, want 30", got)
+				}
+			},
+		},
+		apitest.Case{Name: "list as user", Method: http.MethodGet, Path: "/api/v1/admin/locked-accounts", Token: s.Token(dave), Status: http.StatusForbidden, Code: "insufficient_role"},
+		apitest.Case{
+			Name: "list", Method: http.MethodGet, Path: "/api/v1/admin/locked-accounts", Token: adminToken, Status: http.StatusOK,
+			Check: func(t *testing.T, r *apitest.Response) {
+				var list dto.LockedAccountListResponse
+				r.Decode(t, &list)
+				if len(list.Accounts) != 1 || list.Accounts[0].Handle != "dave" || list.Accounts[0].FailedAttempts != s.Config.LoginMaxAccountFailures {
+					t.Errorf("locked accounts = %+v, want dave", list.Accounts)
+				}
+			},
+		},
+		apitest.Case{Name: "unlock unknown user", Method: http.MethodPost, Path: "/api/v1/admin/users/00000000-0000-0000-0000-000000000001/unlock", Token: adminToken, Status: http.StatusNotFound, Code: "user_not_found"},
+		apitest.Case{Name: "unlock", Method: http.MethodPost, Path: unlockPath, Token: adminToken, Status: http.StatusOK},
+		apitest.Case{
+			Name: "list after unlock", Method: http.MethodGet, Path: "/api/v1/admin/locked-accounts", Token: adminToken, Status: http.StatusOK,
+			Check: func(t *testing.T, r *apitest.Response) {
+				var list dto.LockedAccountListResponse
+				r.Decode(t, &list)
+				if len(list.Accounts) != 0 {
+					t.Errorf("locked accounts = %+v, want none", list.Accounts)
+				}
+			},
+		},
+		apitest.Case{Name: "login after unlock", Method: http.MethodPost, Path: "/api/v1/auth/login", Body: right, Status: http.StatusOK},
+	)
+	s.Run(t, cases)
+}
+
+func TestLoginIPLockoutIgnoresForwardedFor(t *testing.T) {
+	login := func(s *apitest.Server, email, forwardedFor string) *apitest.Response {
+		body := `{"email": "` + email + `", "password": "wrong password"}`
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/login", strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		req.Header.Set(echo.HeaderXForwardedFor, forwardedFor)
+		return s.Serve(req)
+	}
+
+	// A client cannot reset its counter by making up a new X-Forwarded-For for every attempt
+	s := apitest.New(t)
+	for i := range s.Config.LoginMaxIPFailures {
+		r := login(s, fmt.Sprintf("user%d@example.com", i), fmt.Sprintf("203.0.113.%d", i))
+		if r.Code != http.StatusUnauthorized {
+			t.Fatalf("attempt %d: status = %d; body: %s", i, r.Code, r.Body)
+		}
+	}
+	r := login(s, "someone@example.com", "198.51.100.1")
+	if r.Code != http.StatusTooManyRequests {
+		t.Errorf("after %d failures: status = %d, want 429; body: %s", s.Config.LoginMaxIPFailures, r.Code, r.Body)
+	}
+
+	// Behind a trusted proxy, the forwarded address is the client's
+	s = apitest.New(t, apitest.WithTrustedProxies("192.0.2.0/24"))
+	for i := range s.Config.LoginMaxIPFailures {
+		r := login(s, fmt.Sprintf("user%d@example.com", i), "203.0.113.1")
+		if r.Code != http.StatusUnauthorized {
+			t.Fatalf("attempt %d: status = %d; body: %s", i, r.Code, r.Body)
+		}
+	}
+	if r := login(s, "someone@example.com", "203.0.113.2"); r.Code != http.StatusUnauthorized {
+		t.Errorf("other client behind the proxy: status = %d, want 401; body: %s", r.Code, r.Body)
+	}
+	if r := login(s, "someone@example.com", "203.0.113.1"); r.Code != http.StatusTooManyRequests {
+		t.Errorf("locked client behind the proxy: status = %d, want 429; body: %s", r.Code, r.Body)
+	}
+}
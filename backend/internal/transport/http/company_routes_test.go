@@ -0,0 +1,158 @@
+package http_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"ratemysoft-backend/internal/transport/http/apitest"
+	"ratemysoft-backend/internal/transport/http/dto"
+
+	"github.com/labstack/echo/v4"
+)
+
+func TestCompanyRoutes(t *testing.T) {
+	s := apitest.New(t)
+	owner := s.User("owner")
+	editor := s.User("editor")
+	stranger := s.User("stranger")
+	admin := s.Admin("admin")
+	acme := s.Company(owner, "acme")
+	s.Company(owner, "globex")
+
+	ownerToken, editorToken, strangerToken, adminToken := s.Token(owner), s.Token(editor), s.Token(stranger), s.Token(admin)
+	acmePath := "/api/v1/companies/" + acme.ID.String()
+	var created dto.CompanyResponse
+
+	s.Run(t, []apitest.Case{
+		{
+			Name: "get", Method: http.MethodGet, Path: acmePath, Status: http.StatusOK,
+			Check: func(t *testing.T, r *apitest.Response) {
+				var company dto.CompanyResponse
+				r.Decode(t, &company)
+				if company.Slug != "acme" || company.Name != "Company acme" {
+					t.Errorf("company = %+v", company)
+				}
+			},
+		},
+		{Name: "get malformed id", Method: http.MethodGet, Path: "/api/v1/companies/not-a-uuid", Status: http.StatusBadRequest, Code: "invalid_id"},
+		{Name: "get unknown", Method: http.MethodGet, Path: "/api/v1/companies/00000000-0000-0000-0000-000000000001", Status: http.StatusNotFound, Code: "company_not_found"},
+		{Name: "get by slug", Method: http.MethodGet, Path: "/api/v1/companies/slug/globex", Status: http.StatusOK},
+		{Name: "get by unknown slug", Method: http.MethodGet, Path: "/api/v1/companies/slug/initech", Status: http.StatusNotFound, Code: "company_not_found"},
+
+		{Name: "search without query", Method: http.MethodGet, Path: "/api/v1/companies/search", Status: http.StatusBadRequest, Code: "missing_query"},
+		{
+			Name: "search second page", Method: http.MethodGet, Path: "/api/v1/companies/search?q=company&limit=1&offset=1", Status: http.StatusOK,
+			Check: func(t *testing.T, r *apitest.Response) {
+				var list dto.CompanyListResponse
+				r.Decode(t, &list)
+				if list.Total != 2 || len(list.Companies) != 1 || list.Companies[0].Slug != "globex" || list.PrevCursor == "" {
+					t.Errorf("page = %+v, want globex alone and a prev cursor", list)
+				}
+			},
+		},
+		{
+			Name: "search first page", Method: http.MethodGet, Path: "/api/v1/companies/search?q=company&limit=1", Status: http.StatusOK,
+			Check: func(t *testing.T, r *apitest.Response) {
+				var list dto.CompanyListResponse
+				r.Decode(t, &list)
+				if len(list.Companies) != 1 || list.Companies[0].Slug != "acme" || list.NextCursor == "" || r.Header.Get("Link") == "" {
+					t.Errorf("page = %+v, want acme and a next link", list)
+				}
+			},
+		},
+		{Name: "search with forged cursor", Method: http.MethodGet, Path: "/api/v1/companies/search?q=company&cursor=abc.def", Status: http.StatusBadRequest, Code: "invalid_cursor"},
+		{Name: "list with forged cursor", Method: http.MethodGet, Path: "/api/v1/companies?cursor=abc.def", Status: http.StatusBadRequest, Code: "invalid_cursor"},
+		{
+			Name: "list first page", Method: http.MethodGet, Path: "/api/v1/companies?limit=1", Status: http.StatusOK,
+			Check: func(t *testing.T, r *apitest.Response) {
+				var list dto.CompanyListResponse
+				r.Decode(t, &list)
+				if list.Total != 2 || len(list.Companies) != 1 || list.NextCursor == "" || r.Header.Get("Link") == "" {
+					t.Errorf("list = %+v, want one of two companies and a next link", list)
+				}
+			},
+		},
+
+		{Name: "create anonymously", Method: http.MethodPost, Path: "/api/v1/companies", Body: map[string]string{"name": "Initech", "slug": "initech"}, Status: http.StatusUnauthorized, Code: "missing_token"},
+		{Name: "create without name", Method: http.MethodPost, Path: "/api/v1/companies", Token: strangerToken, Body: map[string]string{"slug": "initech"}, Status: http.StatusBadRequest, Code: "validation_failed"},
+		{Name: "create bad slug", Method: http.MethodPost, Path: "/api/v1/companies", Token: strangerToken, Body: map[string]string{"name": "Initech", "slug": "Not A Slug"}, Status: http.StatusBadRequest, Code: "invalid_slug"},
+		{Name: "create taken slug", Method: http.MethodPost, Path: "/api/v1/companies", Token: strangerToken, Body: map[string]string{"name": "Acme", "slug": "acme"}, Status: http.StatusConflict, Code: "slug_taken"},
+		{
+			Name: "create", Method: http.MethodPost, Path: "/api/v1/companies", Token: strangerToken,
+			Body:   map[string]string{"name": "Initech", "slug": "initech", "website": "https://initech.example"},
+			Status: http.StatusCreated,
+			Check: func(t *testing.T, r *apitest.Response) {
+				r.Decode(t, &created)
+				if created.Slug != "initech" || created.Website != "https://initech.example" {
+					t.Errorf("created = %+v", created)
+				}
+			},
+		},
+
+		{Name: "update as stranger", Method: http.MethodPut, Path: acmePath, Token: strangerToken, Body: map[string]string{"name": "Mine", "slug": "acme"}, Status: http.StatusForbidden, Code: "insufficient_company_role"},
+		{Name: "update as owner", Method: http.MethodPut, Path: acmePath, Token: ownerToken, Body: map[string]string{"name": "Acme Inc", "slug": "acme"}, Status: http.StatusOK},
+		{Name: "update as admin", Method: http.MethodPut, Path: acmePath, Token: adminToken, Body: map[string]string{"name": "Acme Corp", "slug": "acme"}, Status: http.StatusOK},
+
+		{Name: "members as stranger", Method: http.MethodGet, Path: acmePath + "/members", Token: strangerToken, Status: http.StatusForbidden, Code: "insufficient_company_role"},
+		{Name: "add member bad role", Method: http.MethodPut, Path: acmePath + "/members", Token: ownerToken, Body: map[string]string{"handle": "editor", "role": "boss"}, Status: http.StatusBadRequest, Code: "validation_failed"},
+		{Name: "add unknown member", Method: http.MethodPut, Path: acmePath + "/members", Token: ownerToken, Body: map[string]string{"handle": "nobody", "role": "editor"}, Status: http.StatusNotFound, Code: "user_not_found"},
+		{Name: "add member", Method: http.MethodPut, Path: acmePath + "/members", Token: ownerToken, Body: map[string]string{"handle": "editor", "role": "editor"}, Status: http.StatusOK},
+		{Name: "add member as editor", Method: http.MethodPut, Path: acmePath + "/members", Token: editorToken, Body: map[string]string{"handle": "stranger", "role": "editor"}, Status: http.StatusForbidden, Code: "insufficient_company_role"},
+		{
+			Name: "members as editor", Method: http.MethodGet, Path: acmePath + "/members", Token: editorToken, Status: http.StatusOK,
+			Check: func(t *testing.T, r *apitest.Response) {
+				var body struct {
+					Members []dto.CompanyMemberResponse `json:"members"`
+				}
+				r.Decode(t, &body)
+				if len(body.Members) != 2 {
+					t.Errorf("members = %+v, want owner and editor", body.Members)
+				}
+			},
+		},
+		{Name: "remove last owner", Method: http.MethodDelete, Path: acmePath + "/members/" + owner.ID.String(), Token: ownerToken, Status: http.StatusConflict, Code: "last_owner"},
+		{Name: "editor leaves", Method: http.MethodDelete, Path: acmePath + "/members/" + editor.ID.String(), Token: editorToken, Status: http.StatusOK},
+
+		{Name: "claim", Method: http.MethodPost, Path: acmePath + "/claim", Token: strangerToken, Body: map[string]string{"evidence": "I run it"}, Status: http.StatusAccepted},
+		{Name: "claim again", Method: http.MethodPost, Path: acmePath + "/claim", Token: strangerToken, Body: map[string]string{}, Status: http.StatusConflict, Code: "claim_pending"},
+		{Name: "claim as member", Method: http.MethodPost, Path: acmePath + "/claim", Token: ownerToken, Body: map[string]string{}, Status: http.StatusConflict, Code: "already_member"},
+
+		{Name: "delete as editor", Method: http.MethodDelete, Path: acmePath, Token: editorToken, Status: http.StatusForbidden, Code: "insufficient_company_role"},
+		{Name: "delete as owner", Method: http.MethodDelete, Path: acmePath, Token: ownerToken, Status: http.StatusOK},
+		{Name: "get deleted", Method: http.MethodGet, Path: acmePath, Status: http.StatusNotFound, Code: "company_not_found"},
+	})
+}
+
+func TestAuditedRequestID(t *testing.T) {
+	s := apitest.New(t)
+	owner := s.User("owner")
+	ownerToken, adminToken := s.Token(owner), s.Token(s.Admin("admin"))
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/companies", strings.NewReader(`{"name": "Acme", "slug": "acme"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.Header.Set(echo.HeaderAuthorization, "Bearer "+ownerToken)
+	req.Header.Set(echo.HeaderXRequestID, "chosen-by-client")
+	created := s.Serve(req)
+	if created.Code != http.StatusCreated {
+		t.Fatalf("create company: status = %d; body: %s", created.Code, created.Body)
+	}
+	requestID := created.Header.Get(echo.HeaderXRequestID)
+	if requestID == "" || requestID == "chosen-by-client" {
+		t.Fatalf("X-Request-ID = %q, want an ID generated by the server", requestID)
+	}
+
+	s.Run(t, []apitest.Case{
+		{
+			Name: "audit event", Method: http.MethodGet, Path: "/api/v1/admin/audit-events?entity_type=company", Token: adminToken, Status: http.StatusOK,
+			Check: func(t *testing.T, r *apitest.Response) {
+				var list dto.AuditEventListResponse
+				r.Decode(t, &list)
+				if len(list.Events) != 1 || list.Events[0].RequestID != requestID {
+					t.Errorf("events = %+v, want one with request ID %q", list.Events, requestID)
+				}
+			},
+		},
+	})
+}
@@ -0,0 +1,32 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// AuditEventResponse represents a single audit log entry. Before and after hold only
+// the fields that changed; before is absent on create and after on delete.
+type AuditEventResponse struct {
+	ID          string          `json:"id"`
+	ActorID     string          `json:"actor_id,omitempty"` // absent for changes made by the system
+	ActorHandle string          `json:"actor_handle,omitempty"`
+	Action      string          `json:"action"`
+	EntityType  string          `json:"entity_type"`
+	EntityID    string          `json:"entity_id"`
+	Before      json.RawMessage `json:"before,omitempty"`
+	After       json.RawMessage `json:"after,omitempty"`
+	RequestID   string          `json:"request_id,omitempty"`
+	IPAddress   string          `json:"ip_address,omitempty"`
+	CreatedAt   time.Time       `json:"created_at"`
+}
+
+// AuditEventListResponse represents a paginated list of audit events
+type AuditEventListResponse struct {
+	Events     []AuditEventResponse `json:"events"`
+	Total      int64                `json:"total"`
+	Limit      int32                `json:"limit"`
+	Offset     int32                `json:"offset"`
+	NextCursor string               `json:"next_cursor,omitempty"`
+	PrevCursor string               `json:"prev_cursor,omitempty"`
+}
@@ -1,5 +1,7 @@
 package dto
 
+import "time"
+
 type LoginRequest struct {
 	Email    string `json:"email" validate:"required,email"`
 	Password string `json:"password" validate:"required"`
@@ -12,13 +14,69 @@ type RegisterRequest struct {
 }
 
 type AuthResponse struct {
-	Token string       `json:"token"`
-	User  UserResponse `json:"user"`
+	Token        string       `json:"token"`
+	RefreshToken string       `json:"refresh_token"`
+	ExpiresIn    int64        `json:"expires_in"` // access token lifetime in seconds
+	User         UserResponse `json:"user"`
+}
+
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
+}
+
+type LogoutAllResponse struct {
+	Message         string `json:"message"`
+	SessionsRevoked int64  `json:"sessions_revoked"`
+}
+
+type OAuthProvidersResponse struct {
+	Providers []string `json:"providers"`
+}
+
+// UpdateProfileRequest changes the fields that are present; a new email or password needs
+// the current password
+type UpdateProfileRequest struct {
+	Handle          *string `json:"handle" validate:"omitempty,min=3,max=20"`
+	Email           *string `json:"email" validate:"omitempty,email"`
+	Password        *string `json:"password" validate:"omitempty,min=8"`
+	CurrentPassword string  `json:"current_password"`
+}
+
+type DeleteAccountRequest struct {
+	Password string `json:"password"` // required for accounts that have one
+}
+
+type VerifyEmailRequest struct {
+	Token string `json:"token" validate:"required"`
+}
+
+type PasswordResetRequest struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+type PasswordResetConfirmRequest struct {
+	Token    string `json:"token" validate:"required"`
+	Password string `json:"password" validate:"required,min=8"`
 }
 
 type UserResponse struct {
-	ID     string `json:"id"`
-	Email  string `json:"email"`
-	Handle string `json:"handle"`
-	Role   string `json:"role"`
+	ID            string `json:"id"`
+	Email         string `json:"email"`
+	EmailVerified bool   `json:"email_verified"`
+	Handle        string `json:"handle"`
+	Role          string `json:"role"`
+}
+
+// LockedAccountResponse represents an account that refuses sign-ins after too many failed attempts
+type LockedAccountResponse struct {
+	UserID         string    `json:"user_id"`
+	Handle         string    `json:"handle"`
+	Email          string    `json:"email"`
+	FailedAttempts int       `json:"failed_attempts"`
+	LastFailureAt  time.Time `json:"last_failure_at"`
+	LockedUntil    time.Time `json:"locked_until"`
+}
+
+type LockedAccountListResponse struct {
+	Accounts []LockedAccountResponse `json:"accounts"`
 }
@@ -0,0 +1,40 @@
+package dto
+
+import "time"
+
+// CategoryRequest represents the request body for creating or replacing a category
+type CategoryRequest struct {
+	Slug        string `json:"slug" validate:"required,min=1,max=100"`
+	Name        string `json:"name" validate:"required,min=1,max=100"`
+	Description string `json:"description" validate:"omitempty,max=500"`
+	Icon        string `json:"icon" validate:"omitempty,max=50"`
+	Parent      string `json:"parent" validate:"omitempty,max=100"` // parent category slug; empty for top level
+	SortOrder   int    `json:"sort_order"`
+}
+
+// CategoryResponse represents a category in API responses
+type CategoryResponse struct {
+	ID                string    `json:"id"`
+	Slug              string    `json:"slug"`
+	Name              string    `json:"name"`
+	Description       string    `json:"description,omitempty"`
+	Icon              string    `json:"icon,omitempty"`
+	ParentID          *string   `json:"parent_id"`
+	SortOrder         int       `json:"sort_order"`
+	ProductCount      int       `json:"product_count"`       // products filed directly under the category
+	TotalProductCount int       `json:"total_product_count"` // including subcategories
+	CreatedAt         time.Time `json:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at"`
+}
+
+// CategoryDetailResponse represents a category together with its direct subcategories
+type CategoryDetailResponse struct {
+	CategoryResponse
+	Children []CategoryResponse `json:"children"`
+}
+
+// CategoryListResponse represents the flat list of categories
+type CategoryListResponse struct {
+	Categories []CategoryResponse `json:"categories"`
+	Count      int                `json:"count"`
+}
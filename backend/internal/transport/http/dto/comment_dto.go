@@ -0,0 +1,63 @@
+package dto
+
+import "time"
+
+// CreateCommentRequest represents the request body for commenting on a review
+type CreateCommentRequest struct {
+	Body     string `json:"body" validate:"required,min=1,max=5000"`
+	ParentID string `json:"parent_id" validate:"omitempty,uuid"` // reply to this comment
+	Official bool   `json:"official"`                            // respond on behalf of the product's company
+}
+
+// UpdateCommentRequest represents the request body for editing a comment
+type UpdateCommentRequest struct {
+	Body string `json:"body" validate:"required,min=1,max=5000"`
+}
+
+// FlagCommentRequest represents the request body for flagging a comment
+type FlagCommentRequest struct {
+	Reason string `json:"reason" validate:"omitempty,oneof=spam offensive off_topic conflict_of_interest fake other"`
+	Note   string `json:"note" validate:"omitempty,max=500"`
+}
+
+// CommentResponse represents a review comment with its replies.
+// Deleted or hidden comments keep their place in a thread with an empty body.
+type CommentResponse struct {
+	ID          string            `json:"id"`
+	ReviewID    string            `json:"review_id"`
+	ParentID    string            `json:"parent_id,omitempty"`
+	UserID      string            `json:"user_id"`
+	UserHandle  string            `json:"user_handle,omitempty"`
+	Body        string            `json:"body"`
+	Status      string            `json:"status"`
+	Official    bool              `json:"official"`
+	CompanyID   string            `json:"company_id,omitempty"`
+	CompanyName string            `json:"company_name,omitempty"`
+	Edited      bool              `json:"edited"`
+	Deleted     bool              `json:"deleted"`
+	CreatedAt   time.Time         `json:"created_at"`
+	UpdatedAt   time.Time         `json:"updated_at"`
+	Replies     []CommentResponse `json:"replies,omitempty"`
+}
+
+// CommentListResponse represents the comment thread of a review
+type CommentListResponse struct {
+	ReviewID string            `json:"review_id"`
+	Comments []CommentResponse `json:"comments"`
+}
+
+// CommentQueueItemResponse represents a comment in the moderation queue
+type CommentQueueItemResponse struct {
+	CommentResponse
+	FlagCount int `json:"flag_count"`
+}
+
+// CommentQueueResponse represents a paginated comment moderation queue
+type CommentQueueResponse struct {
+	Comments   []CommentQueueItemResponse `json:"comments"`
+	Total      int64                      `json:"total"`
+	Limit      int32                      `json:"limit"`
+	Offset     int32                      `json:"offset"`
+	NextCursor string                     `json:"next_cursor,omitempty"`
+	PrevCursor string                     `json:"prev_cursor,omitempty"`
+}
@@ -32,8 +32,64 @@ type CompanyResponse struct {
 
 // CompanyListResponse represents a paginated list of companies
 type CompanyListResponse struct {
-	Companies []CompanyResponse `json:"companies"`
-	Total     int64             `json:"total"`
-	Limit     int32             `json:"limit"`
-	Offset    int32             `json:"offset"`
+	Companies  []CompanyResponse `json:"companies"`
+	Total      int64             `json:"total"`
+	Limit      int32             `json:"limit"`
+	Offset     int32             `json:"offset"`
+	NextCursor string            `json:"next_cursor,omitempty"`
+	PrevCursor string            `json:"prev_cursor,omitempty"`
+}
+
+// CompanyMemberRequest represents the request body for adding a member or changing their role
+type CompanyMemberRequest struct {
+	Handle string `json:"handle" validate:"required,min=3,max=20"`
+	Role   string `json:"role" validate:"required,oneof=owner editor"`
+}
+
+// CompanyMemberResponse represents a company member in API responses
+type CompanyMemberResponse struct {
+	CompanyID  string    `json:"company_id"`
+	UserID     string    `json:"user_id"`
+	UserHandle string    `json:"user_handle"`
+	Role       string    `json:"role"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// ClaimCompanyRequest represents the request body for claiming a company
+type ClaimCompanyRequest struct {
+	Evidence string `json:"evidence" validate:"omitempty,max=1000"`
+}
+
+// ResolveCompanyClaimRequest represents the request body for approving or rejecting a claim
+type ResolveCompanyClaimRequest struct {
+	Note string `json:"note" validate:"omitempty,max=500"`
+}
+
+// CompanyClaimResponse represents a company claim in API responses
+type CompanyClaimResponse struct {
+	ID                 string     `json:"id"`
+	CompanyID          string     `json:"company_id"`
+	CompanyName        string     `json:"company_name,omitempty"`
+	UserID             string     `json:"user_id"`
+	UserHandle         string     `json:"user_handle,omitempty"`
+	UserEmail          string     `json:"user_email,omitempty"`
+	Status             string     `json:"status"`
+	VerificationMethod string     `json:"verification_method"`
+	Evidence           string     `json:"evidence,omitempty"`
+	ReviewedBy         *string    `json:"reviewed_by,omitempty"`
+	ReviewNote         string     `json:"review_note,omitempty"`
+	ReviewedAt         *time.Time `json:"reviewed_at,omitempty"`
+	CreatedAt          time.Time  `json:"created_at"`
+	UpdatedAt          time.Time  `json:"updated_at"`
+}
+
+// CompanyClaimListResponse represents a paginated list of company claims
+type CompanyClaimListResponse struct {
+	Claims     []CompanyClaimResponse `json:"claims"`
+	Total      int64                  `json:"total"`
+	Limit      int32                  `json:"limit"`
+	Offset     int32                  `json:"offset"`
+	NextCursor string                 `json:"next_cursor,omitempty"`
+	PrevCursor string                 `json:"prev_cursor,omitempty"`
 }
@@ -0,0 +1,33 @@
+package dto
+
+import "time"
+
+// LeaderboardEntryResponse represents a ranked product on the leaderboard
+type LeaderboardEntryResponse struct {
+	Rank          int     `json:"rank"`
+	ProductID     string  `json:"product_id"`
+	ProductName   string  `json:"product_name"`
+	ProductSlug   string  `json:"product_slug"`
+	Category      string  `json:"category"`
+	CompanyName   string  `json:"company_name"`
+	CompanySlug   string  `json:"company_slug"`
+	ReviewCount   int     `json:"review_count"`
+	AvgRating     float64 `json:"avg_rating"`
+	Score         float64 `json:"score"` // the score the leaderboard is ranked by
+	BayesianScore float64 `json:"bayesian_score"`
+	WilsonScore   float64 `json:"wilson_score"`
+}
+
+// LeaderboardResponse represents a page of the leaderboard
+type LeaderboardResponse struct {
+	Window      string                     `json:"window"`
+	Score       string                     `json:"score"`
+	Category    string                     `json:"category,omitempty"`
+	Entries     []LeaderboardEntryResponse `json:"entries"`
+	Total       int64                      `json:"total"`
+	Limit       int32                      `json:"limit"`
+	Offset      int32                      `json:"offset"`
+	NextCursor  string                     `json:"next_cursor,omitempty"`
+	PrevCursor  string                     `json:"prev_cursor,omitempty"`
+	RefreshedAt *time.Time                 `json:"refreshed_at,omitempty"`
+}
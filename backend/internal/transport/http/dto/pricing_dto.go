@@ -0,0 +1,61 @@
+package dto
+
+import "time"
+
+// PricingPlanRequest represents the request body for creating or replacing a pricing plan
+type PricingPlanRequest struct {
+	Name          string   `json:"name" validate:"required,min=1,max=100"`
+	BillingPeriod string   `json:"billing_period" validate:"required,oneof=monthly yearly one_time usage"`
+	Currency      string   `json:"currency" validate:"required,len=3"`
+	PriceCents    int64    `json:"price_cents" validate:"min=0"`
+	IsFree        bool     `json:"is_free"`
+	PricingUnit   string   `json:"pricing_unit" validate:"omitempty,oneof=flat per_seat per_usage"`
+	UsageUnit     string   `json:"usage_unit" validate:"omitempty,max=50"`
+	Features      []string `json:"features" validate:"omitempty,max=50,dive,max=200"`
+	SortOrder     int      `json:"sort_order"`
+}
+
+// PricingPlanResponse represents a pricing plan in API responses
+type PricingPlanResponse struct {
+	ID                string    `json:"id"`
+	ProductID         string    `json:"product_id"`
+	Name              string    `json:"name"`
+	BillingPeriod     string    `json:"billing_period"`
+	Currency          string    `json:"currency"`
+	PriceCents        int64     `json:"price_cents"`
+	MonthlyPriceCents *int64    `json:"monthly_price_cents,omitempty"` // normalized price, monthly and yearly plans only
+	IsFree            bool      `json:"is_free"`
+	PricingUnit       string    `json:"pricing_unit"`
+	UsageUnit         string    `json:"usage_unit,omitempty"`
+	Features          []string  `json:"features"`
+	SortOrder         int       `json:"sort_order"`
+	CreatedAt         time.Time `json:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at"`
+}
+
+// PricingPlanListResponse represents a product's pricing plans
+type PricingPlanListResponse struct {
+	Plans []PricingPlanResponse `json:"plans"`
+	Count int                   `json:"count"`
+}
+
+// ProductPricingResponse represents one product's column in a pricing comparison
+type ProductPricingResponse struct {
+	Product                   ProductWithCompanyResponse `json:"product"`
+	Plans                     []PricingPlanResponse      `json:"plans"`
+	HasFreeTier               bool                       `json:"has_free_tier"`
+	StartingMonthlyPriceCents *int64                     `json:"starting_monthly_price_cents,omitempty"`
+	StartingCurrency          string                     `json:"starting_currency,omitempty"`
+}
+
+// FeatureComparisonResponse represents one feature row of a pricing comparison
+type FeatureComparisonResponse struct {
+	Feature   string `json:"feature"`
+	Available []bool `json:"available"` // aligned with the comparison's products
+}
+
+// PricingComparisonResponse represents a side-by-side pricing comparison
+type PricingComparisonResponse struct {
+	Products []ProductPricingResponse    `json:"products"`
+	Features []FeatureComparisonResponse `json:"features"`
+}
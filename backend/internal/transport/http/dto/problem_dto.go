@@ -0,0 +1,19 @@
+package dto
+
+// ProblemResponse is an RFC 7807 problem details body (served as application/problem+json).
+// Code is a stable machine-readable identifier clients can switch on.
+type ProblemResponse struct {
+	Type     string               `json:"type"`
+	Title    string               `json:"title"`
+	Status   int                  `json:"status"`
+	Detail   string               `json:"detail,omitempty"`
+	Instance string               `json:"instance,omitempty"`
+	Code     string               `json:"code"`
+	Errors   []FieldErrorResponse `json:"errors,omitempty"`
+}
+
+// FieldErrorResponse describes why a single request field was rejected
+type FieldErrorResponse struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
@@ -7,7 +7,7 @@ type CreateProductRequest struct {
 	CompanyID    string `json:"company_id" validate:"omitempty,uuid"`
 	Name         string `json:"name" validate:"required,min=1,max=200"`
 	Slug         string `json:"slug" validate:"required,min=1,max=100"`
-	Category     string `json:"category" validate:"required,oneof=hosting feature_toggles ci_cd observability other"`
+	Category     string `json:"category" validate:"required,min=1,max=100"`
 	ShortTagline string `json:"short_tagline" validate:"omitempty,max=200"`
 	Description  string `json:"description" validate:"omitempty"`
 	HomepageURL  string `json:"homepage_url" validate:"omitempty,url"`
@@ -18,7 +18,7 @@ type CreateProductRequest struct {
 type UpdateProductRequest struct {
 	Name         string `json:"name" validate:"required,min=1,max=200"`
 	Slug         string `json:"slug" validate:"required,min=1,max=100"`
-	Category     string `json:"category" validate:"required,oneof=hosting feature_toggles ci_cd observability other"`
+	Category     string `json:"category" validate:"required,min=1,max=100"`
 	ShortTagline string `json:"short_tagline" validate:"omitempty,max=200"`
 	Description  string `json:"description" validate:"omitempty"`
 	HomepageURL  string `json:"homepage_url" validate:"omitempty,url"`
@@ -27,20 +27,21 @@ type UpdateProductRequest struct {
 
 // ProductResponse represents a product in API responses
 type ProductResponse struct {
-	ID           string     `json:"id"`
-	CompanyID    string     `json:"company_id"`
-	Name         string     `json:"name"`
-	Slug         string     `json:"slug"`
-	Category     string     `json:"category"`
-	ShortTagline string     `json:"short_tagline,omitempty"`
-	Description  string     `json:"description,omitempty"`
-	HomepageURL  string     `json:"homepage_url,omitempty"`
-	DocsURL      string     `json:"docs_url,omitempty"`
-	AvgRating    *float64   `json:"avg_rating,omitempty"`
-	TotalReviews int        `json:"total_reviews"`
-	CreatedAt    time.Time  `json:"created_at"`
-	UpdatedAt    time.Time  `json:"updated_at"`
-	DeletedAt    *time.Time `json:"deleted_at,omitempty"`
+	ID           string        `json:"id"`
+	CompanyID    string        `json:"company_id"`
+	Name         string        `json:"name"`
+	Slug         string        `json:"slug"`
+	Category     string        `json:"category"`
+	ShortTagline string        `json:"short_tagline,omitempty"`
+	Description  string        `json:"description,omitempty"`
+	HomepageURL  string        `json:"homepage_url,omitempty"`
+	DocsURL      string        `json:"docs_url,omitempty"`
+	AvgRating    *float64      `json:"avg_rating,omitempty"`
+	TotalReviews int           `json:"total_reviews"`
+	Tags         []TagResponse `json:"tags,omitempty"`
+	CreatedAt    time.Time     `json:"created_at"`
+	UpdatedAt    time.Time     `json:"updated_at"`
+	DeletedAt    *time.Time    `json:"deleted_at,omitempty"`
 }
 
 // ProductWithCompanyResponse represents a product with company information
@@ -52,8 +53,64 @@ type ProductWithCompanyResponse struct {
 
 // ProductListResponse represents a paginated list of products
 type ProductListResponse struct {
-	Products []ProductResponse `json:"products"`
-	Total    int64             `json:"total"`
-	Limit    int32             `json:"limit"`
-	Offset   int32             `json:"offset"`
+	Products   []ProductResponse `json:"products"`
+	Total      int64             `json:"total"`
+	Sort       string            `json:"sort"`
+	Limit      int32             `json:"limit"`
+	Offset     int32             `json:"offset"`
+	NextCursor string            `json:"next_cursor,omitempty"`
+	PrevCursor string            `json:"prev_cursor,omitempty"`
+}
+
+// ProductSearchHitResponse represents a ranked search result with highlighted matches
+type ProductSearchHitResponse struct {
+	ProductWithCompanyResponse
+	Rank       float64                 `json:"rank"`
+	Highlights SearchHighlightResponse `json:"highlights"`
+}
+
+// ProductSearchResponse represents a page of ranked search results
+type ProductSearchResponse struct {
+	Products   []ProductSearchHitResponse `json:"products"`
+	Total      int64                      `json:"total"`
+	Limit      int32                      `json:"limit"`
+	Offset     int32                      `json:"offset"`
+	NextCursor string                     `json:"next_cursor,omitempty"`
+	PrevCursor string                     `json:"prev_cursor,omitempty"`
+	Facets     SearchFacetsResponse       `json:"facets"`
+}
+
+// SearchHighlightResponse holds HTML-escaped text with matched terms wrapped in <mark> tags
+type SearchHighlightResponse struct {
+	Name    string `json:"name"`
+	Snippet string `json:"snippet,omitempty"`
+}
+
+// FacetCountResponse represents the number of matches for one facet value
+type FacetCountResponse struct {
+	Value string `json:"value"`
+	Count int64  `json:"count"`
+}
+
+// SearchFacetsResponse represents facet counts across all matches of a search query
+type SearchFacetsResponse struct {
+	Categories    []FacetCountResponse `json:"categories"`
+	RatingBuckets []FacetCountResponse `json:"rating_buckets"`
+}
+
+// ProductStatsResponse represents the rating breakdown of a product
+type ProductStatsResponse struct {
+	ProductID    string                  `json:"product_id"`
+	AvgRating    *float64                `json:"avg_rating,omitempty"`
+	TotalReviews int                     `json:"total_reviews"`
+	Histogram    map[int]int             `json:"histogram"` // star (1-5) -> number of reviews
+	Dimensions   []DimensionStatResponse `json:"dimensions"`
+	UpdatedAt    *time.Time              `json:"updated_at,omitempty"`
+}
+
+// DimensionStatResponse represents the average of one sub-rating dimension
+type DimensionStatResponse struct {
+	Dimension string  `json:"dimension"`
+	AvgRating float64 `json:"avg_rating"`
+	Count     int     `json:"count"`
 }
@@ -8,6 +8,9 @@ type CreateReviewRequest struct {
 	Title     string `json:"title" validate:"omitempty,max=200"`
 	Body      string `json:"body" validate:"required,min=10"`
 	Rating    int    `json:"rating" validate:"required,min=1,max=5"`
+
+	// Optional per-dimension ratings, e.g. {"ease_of_use": 4, "docs": 3}
+	SubRatings map[string]int `json:"sub_ratings" validate:"omitempty,dive,min=1,max=5"`
 }
 
 // UpdateReviewRequest represents the request body for updating a review
@@ -15,23 +18,29 @@ type UpdateReviewRequest struct {
 	Title  string `json:"title" validate:"omitempty,max=200"`
 	Body   string `json:"body" validate:"required,min=10"`
 	Rating int    `json:"rating" validate:"required,min=1,max=5"`
+
+	// Omit to keep the current sub-ratings; any object, even {}, replaces them
+	SubRatings map[string]int `json:"sub_ratings" validate:"omitempty,dive,min=1,max=5"`
 }
 
 // ReviewResponse represents a review in API responses
 type ReviewResponse struct {
-	ID           string     `json:"id"`
-	ProductID    string     `json:"product_id"`
-	UserID       string     `json:"user_id"`
-	Title        string     `json:"title,omitempty"`
-	Body         string     `json:"body"`
-	Rating       int        `json:"rating"`
-	Status       string     `json:"status"`
-	HelpfulCount int        `json:"helpful_count"`
-	FlagCount    int        `json:"flag_count"`
-	Edited       bool       `json:"edited"`
-	CreatedAt    time.Time  `json:"created_at"`
-	UpdatedAt    time.Time  `json:"updated_at"`
-	DeletedAt    *time.Time `json:"deleted_at,omitempty"`
+	ID           string         `json:"id"`
+	ProductID    string         `json:"product_id"`
+	UserID       string         `json:"user_id"`
+	Title        string         `json:"title,omitempty"`
+	Body         string         `json:"body"`
+	Rating       int            `json:"rating"`
+	SubRatings   map[string]int `json:"sub_ratings,omitempty"`
+	Status       string         `json:"status"`
+	HelpfulCount int            `json:"helpful_count"`
+	FlagCount    int            `json:"flag_count"`
+	CommentCount int            `json:"comment_count"`
+	Edited       bool           `json:"edited"`
+	MyVote       string         `json:"my_vote,omitempty"` // caller's own vote ("up"/"down"), authenticated requests only
+	CreatedAt    time.Time      `json:"created_at"`
+	UpdatedAt    time.Time      `json:"updated_at"`
+	DeletedAt    *time.Time     `json:"deleted_at,omitempty"`
 }
 
 // ReviewWithUserResponse represents a review with user information
@@ -50,8 +59,113 @@ type ReviewWithProductResponse struct {
 
 // ReviewListResponse represents a paginated list of reviews
 type ReviewListResponse struct {
-	Reviews []ReviewResponse `json:"reviews"`
-	Total   int64            `json:"total"`
-	Limit   int32            `json:"limit"`
-	Offset  int32            `json:"offset"`
+	Reviews    []ReviewResponse `json:"reviews"`
+	Total      int64            `json:"total"`
+	Limit      int32            `json:"limit"`
+	Offset     int32            `json:"offset"`
+	NextCursor string           `json:"next_cursor,omitempty"`
+	PrevCursor string           `json:"prev_cursor,omitempty"`
+}
+
+// FlagReviewRequest represents the request body for flagging a review
+type FlagReviewRequest struct {
+	Reason string `json:"reason" validate:"omitempty,oneof=spam offensive off_topic conflict_of_interest fake other"`
+	Note   string `json:"note" validate:"omitempty,max=500"`
+}
+
+// ReviewVoteResponse represents the caller's vote and the review's counters after voting
+type ReviewVoteResponse struct {
+	ReviewID      string `json:"review_id"`
+	Vote          string `json:"vote"` // "up", "down" or "none"
+	UpvoteCount   int    `json:"upvote_count"`
+	DownvoteCount int    `json:"downvote_count"`
+}
+
+// ReviewFlagResponse represents a single user's flag on a review
+type ReviewFlagResponse struct {
+	ReviewID   string    `json:"review_id"`
+	UserID     string    `json:"user_id"`
+	UserHandle string    `json:"user_handle"`
+	Reason     string    `json:"reason"`
+	Note       string    `json:"note,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// ModerateReviewRequest represents the request body for approving or rejecting a review
+type ModerateReviewRequest struct {
+	Reason string `json:"reason" validate:"omitempty,max=500"`
+}
+
+// ModerationQueueItemResponse represents a review in the moderation queue
+type ModerationQueueItemResponse struct {
+	ReviewResponse
+	UserHandle  string `json:"user_handle"`
+	ProductName string `json:"product_name"`
+}
+
+// ModerationQueueResponse represents a paginated moderation queue
+type ModerationQueueResponse struct {
+	Reviews    []ModerationQueueItemResponse `json:"reviews"`
+	Total      int64                         `json:"total"`
+	Limit      int32                         `json:"limit"`
+	Offset     int32                         `json:"offset"`
+	NextCursor string                        `json:"next_cursor,omitempty"`
+	PrevCursor string                        `json:"prev_cursor,omitempty"`
+}
+
+// ReviewModerationResponse represents a single moderation decision
+type ReviewModerationResponse struct {
+	ID              string    `json:"id"`
+	ReviewID        string    `json:"review_id"`
+	CommentID       string    `json:"comment_id,omitempty"` // set when the decision concerned a comment on the review
+	ModeratorID     string    `json:"moderator_id"`
+	ModeratorHandle string    `json:"moderator_handle"`
+	FromStatus      string    `json:"from_status"`
+	ToStatus        string    `json:"to_status"`
+	Reason          string    `json:"reason,omitempty"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+// ReviewRevisionResponse represents a superseded version of a review and the edit that replaced it.
+// Title and body are only included for the review's author and admins.
+type ReviewRevisionResponse struct {
+	Revision          int            `json:"revision"`
+	Title             string         `json:"title,omitempty"`
+	Body              string         `json:"body,omitempty"`
+	SubRatings        map[string]int `json:"sub_ratings,omitempty"`
+	Rating            int            `json:"rating"`
+	RatingAfter       int            `json:"rating_after"`
+	TitleChanged      bool           `json:"title_changed"`
+	BodyChanged       bool           `json:"body_changed"`
+	SubRatingsChanged bool           `json:"sub_ratings_changed"`
+	ReplacedAt        time.Time      `json:"replaced_at"`
+}
+
+// ReviewRevisionListResponse represents the edit history of a review, oldest version first
+type ReviewRevisionListResponse struct {
+	ReviewID  string                   `json:"review_id"`
+	FullText  bool                     `json:"full_text"` // whether old titles and bodies are included
+	Revisions []ReviewRevisionResponse `json:"revisions"`
+}
+
+// RatingEventResponse represents a change to the ratings counted in a product's stats
+type RatingEventResponse struct {
+	ID         string    `json:"id"`
+	ReviewID   string    `json:"review_id"`
+	Kind       string    `json:"kind"`                  // created, edited, deleted, published or unpublished
+	FromRating *int      `json:"from_rating,omitempty"` // absent when the review started counting
+	ToRating   *int      `json:"to_rating,omitempty"`   // absent when the review stopped counting
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// RatingEventListResponse represents a paginated list of a product's rating events
+type RatingEventListResponse struct {
+	ProductID  string                `json:"product_id"`
+	Events     []RatingEventResponse `json:"events"`
+	Total      int64                 `json:"total"`
+	Limit      int32                 `json:"limit"`
+	Offset     int32                 `json:"offset"`
+	NextCursor string                `json:"next_cursor,omitempty"`
+	PrevCursor string                `json:"prev_cursor,omitempty"`
 }
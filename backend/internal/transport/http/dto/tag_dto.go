@@ -0,0 +1,72 @@
+package dto
+
+import "time"
+
+// AddProductTagsRequest represents the request body for tagging a product
+type AddProductTagsRequest struct {
+	Tags []string `json:"tags" validate:"required,min=1,max=20,dive,required,max=50"`
+}
+
+// SuggestTagRequest represents the request body for suggesting a tag
+type SuggestTagRequest struct {
+	Tag string `json:"tag" validate:"required,max=50"`
+}
+
+// ResolveTagSuggestionRequest represents the request body for approving or rejecting a tag suggestion
+type ResolveTagSuggestionRequest struct {
+	Note string `json:"note" validate:"omitempty,max=500"`
+}
+
+// TagResponse represents a tag attached to a product
+type TagResponse struct {
+	Slug string `json:"slug"`
+	Name string `json:"name"`
+}
+
+// TagWithCountResponse represents a tag with the number of products carrying it
+type TagWithCountResponse struct {
+	TagResponse
+	ProductCount int `json:"product_count"`
+}
+
+// TagListResponse represents a paginated list of tags
+type TagListResponse struct {
+	Tags       []TagWithCountResponse `json:"tags"`
+	Total      int64                  `json:"total"`
+	Limit      int32                  `json:"limit"`
+	Offset     int32                  `json:"offset"`
+	NextCursor string                 `json:"next_cursor,omitempty"`
+	PrevCursor string                 `json:"prev_cursor,omitempty"`
+}
+
+// ProductTagsResponse represents the tags of a product
+type ProductTagsResponse struct {
+	ProductID string        `json:"product_id"`
+	Tags      []TagResponse `json:"tags"`
+}
+
+// TagSuggestionResponse represents a tag suggestion in API responses
+type TagSuggestionResponse struct {
+	ID         string     `json:"id"`
+	ProductID  string     `json:"product_id"`
+	UserID     string     `json:"user_id"`
+	UserHandle string     `json:"user_handle,omitempty"`
+	Tag        string     `json:"tag"`
+	TagName    string     `json:"tag_name"`
+	Status     string     `json:"status"`
+	ReviewedBy *string    `json:"reviewed_by,omitempty"`
+	ReviewNote string     `json:"review_note,omitempty"`
+	ReviewedAt *time.Time `json:"reviewed_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+// TagSuggestionListResponse represents a paginated list of tag suggestions
+type TagSuggestionListResponse struct {
+	Suggestions []TagSuggestionResponse `json:"suggestions"`
+	Total       int64                   `json:"total"`
+	Limit       int32                   `json:"limit"`
+	Offset      int32                   `json:"offset"`
+	NextCursor  string                  `json:"next_cursor,omitempty"`
+	PrevCursor  string                  `json:"prev_cursor,omitempty"`
+}
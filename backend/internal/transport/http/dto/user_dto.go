@@ -0,0 +1,23 @@
+package dto
+
+import "time"
+
+// PublicProfileResponse represents what anyone can see about a reviewer
+type PublicProfileResponse struct {
+	ID           string                  `json:"id"`
+	Handle       string                  `json:"handle"`
+	JoinedAt     time.Time               `json:"joined_at"`
+	ReviewCount  int                     `json:"review_count"`
+	HelpfulVotes int                     `json:"helpful_votes"`
+	Reputation   int                     `json:"reputation"`
+	Badges       []ReviewerBadgeResponse `json:"badges"`
+	RefreshedAt  *time.Time              `json:"refreshed_at,omitempty"` // when reputation was last computed
+}
+
+// ReviewerBadgeResponse represents a top reviewer badge
+type ReviewerBadgeResponse struct {
+	Label        string `json:"label"` // e.g. "Top reviewer in CI/CD"
+	Category     string `json:"category"`
+	Rank         int    `json:"rank"`
+	HelpfulVotes int    `json:"helpful_votes"`
+}
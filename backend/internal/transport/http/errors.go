@@ -0,0 +1,177 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	stdhttp "net/http"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"ratemysoft-backend/internal/domain"
+	"ratemysoft-backend/internal/transport/http/dto"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/labstack/echo/v4"
+)
+
+// ProblemContentType is the media type of RFC 7807 error bodies
+const ProblemContentType = "application/problem+json"
+
+// uniqueConflicts maps the unique constraints that guard user input to the conflict the
+// services report when their own check finds the duplicate. A concurrent request can still
+// insert the same value between that check and the write, and then the constraint decides.
+var uniqueConflicts = map[string]*domain.Error{
+	"users_email_key":                   domain.Conflict("email_taken", "a user with this email already exists"),
+	"users_handle_key":                  domain.Conflict("handle_taken", "this handle is already taken"),
+	"companies_slug_key":                domain.Conflict("slug_taken", "a company with this slug already exists"),
+	"products_company_id_slug_key":      domain.Conflict("slug_taken", "the company already has a product with this slug"),
+	"categories_slug_key":               domain.Conflict("slug_taken", "category slug already exists"),
+	"pricing_plans_product_id_name_key": domain.Conflict("plan_name_taken", "the product already has a plan with this name"),
+	"reviews_product_id_user_id_key":    domain.Conflict("already_reviewed", "you have already reviewed this product"),
+	"idx_company_claims_pending":        domain.Conflict("claim_pending", "you already have a pending claim for this company"),
+	"idx_tag_suggestions_pending":       domain.Conflict("suggestion_pending", "this tag has already been suggested and awaits review"),
+	"idx_review_comments_official":      domain.Conflict("official_response_exists", "this review already has an official response"),
+}
+
+// HTTPErrorHandler turns every error returned by a handler or middleware into a problem+json response.
+// Domain errors map to a status by kind; anything unclassified is logged and reported as a bare 500.
+func HTTPErrorHandler(err error, c echo.Context) {
+	if c.Response().Committed {
+		return
+	}
+
+	problem := toProblem(err)
+	problem.Instance = c.Request().URL.Path
+
+	var domainErr *domain.Error
+	if errors.As(err, &domainErr) && domainErr.RetryAfter > 0 {
+		// Whole seconds, rounded up so clients never retry early
+		c.Response().Header().Set(echo.HeaderRetryAfter, strconv.Itoa(int(math.Ceil(domainErr.RetryAfter.Seconds()))))
+	}
+
+	if problem.Status >= stdhttp.StatusInternalServerError {
+		c.Logger().Errorf("%s %s: %v", c.Request().Method, c.Request().URL.Path, err)
+	}
+
+	c.Response().Header().Set(echo.HeaderContentType, ProblemContentType)
+	if c.Request().Method == stdhttp.MethodHead {
+		err = c.NoContent(problem.Status)
+	} else {
+		err = c.JSON(problem.Status, problem)
+	}
+	if err != nil {
+		c.Logger().Error(err)
+	}
+}
+
+func toProblem(err error) dto.ProblemResponse {
+	var domainErr *domain.Error
+	if errors.As(err, &domainErr) {
+		return newProblem(statusForKind(domainErr.Kind), domainErr.Code, domainErr.Message, domainErr.Fields)
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23505" { // unique_violation
+		if conflict, ok := uniqueConflicts[pgErr.ConstraintName]; ok {
+			return newProblem(stdhttp.StatusConflict, conflict.Code, conflict.Message, nil)
+		}
+	}
+
+	var validationErrs validator.ValidationErrors
+	if errors.As(err, &validationErrs) {
+		fields := make([]domain.FieldError, 0, len(validationErrs))
+		for _, fe := range validationErrs {
+			fields = append(fields, domain.FieldError{
+				Field:   fe.Field(),
+				Message: validationMessage(fe),
+			})
+		}
+		return newProblem(stdhttp.StatusBadRequest, "validation_failed", "request validation failed", fields)
+	}
+
+	var httpErr *echo.HTTPError
+	if errors.As(err, &httpErr) {
+		detail := ""
+		if msg, ok := httpErr.Message.(string); ok {
+			detail = msg
+		}
+		return newProblem(httpErr.Code, codeForStatus(httpErr.Code), detail, nil)
+	}
+
+	return newProblem(stdhttp.StatusInternalServerError, "internal_error", "an unexpected error occurred", nil)
+}
+
+func newProblem(status int, code, detail string, fields []domain.FieldError) dto.ProblemResponse {
+	problem := dto.ProblemResponse{
+		Type:   "about:blank",
+		Title:  stdhttp.StatusText(status),
+		Status: status,
+		Detail: detail,
+		Code:   code,
+	}
+	for _, f := range fields {
+		problem.Errors = append(problem.Errors, dto.FieldErrorResponse{
+			Field:   f.Field,
+			Message: f.Message,
+		})
+	}
+	return problem
+}
+
+func statusForKind(kind error) int {
+	switch kind {
+	case domain.ErrValidation:
+		return stdhttp.StatusBadRequest
+	case domain.ErrUnauthorized:
+		return stdhttp.StatusUnauthorized
+	case domain.ErrForbidden:
+		return stdhttp.StatusForbidden
+	case domain.ErrNotFound:
+		return stdhttp.StatusNotFound
+	case domain.ErrConflict:
+		return stdhttp.StatusConflict
+	case domain.ErrRateLimited:
+		return stdhttp.StatusTooManyRequests
+	default:
+		return stdhttp.StatusInternalServerError
+	}
+}
+
+// codeForStatus derives a stable code for framework errors, e.g. 405 -> "method_not_allowed"
+func codeForStatus(status int) string {
+	text := stdhttp.StatusText(status)
+	if text == "" {
+		return "http_error"
+	}
+	return strings.ReplaceAll(strings.ToLower(text), " ", "_")
+}
+
+func validationMessage(fe validator.FieldError) string {
+	switch fe.Tag() {
+	case "required":
+		return "is required"
+	case "email":
+		return "must be a valid email address"
+	case "url":
+		return "must be a valid URL"
+	case "uuid", "uuid4":
+		return "must be a valid UUID"
+	case "min":
+		if fe.Kind() == reflect.String {
+			return fmt.Sprintf("must be at least %s characters", fe.Param())
+		}
+		return fmt.Sprintf("must be at least %s", fe.Param())
+	case "max":
+		if fe.Kind() == reflect.String {
+			return fmt.Sprintf("must be at most %s characters", fe.Param())
+		}
+		return fmt.Sprintf("must be at most %s", fe.Param())
+	case "oneof":
+		return fmt.Sprintf("must be one of: %s", fe.Param())
+	default:
+		return fmt.Sprintf("failed the %q rule", fe.Tag())
+	}
+}
@@ -0,0 +1,43 @@
+package http_test
+
+import (
+	"encoding/json"
+	"fmt"
+	stdhttp "net/http"
+	"net/http/httptest"
+	"testing"
+
+	transporthttp "ratemysoft-backend/internal/transport/http"
+	"ratemysoft-backend/internal/transport/http/dto"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/labstack/echo/v4"
+)
+
+func TestUniqueViolationsBecomeConflicts(t *testing.T) {
+	for _, tc := range []struct {
+		constraint string
+		status     int
+		code       string
+	}{
+		{"users_email_key", stdhttp.StatusConflict, "email_taken"},
+		{"users_handle_key", stdhttp.StatusConflict, "handle_taken"},
+		{"companies_slug_key", stdhttp.StatusConflict, "slug_taken"},
+		{"sessions_pkey", stdhttp.StatusInternalServerError, "internal_error"},
+	} {
+		e := echo.New()
+		rec := httptest.NewRecorder()
+		c := e.NewContext(httptest.NewRequest(stdhttp.MethodPost, "/api/v1/users", nil), rec)
+
+		err := &pgconn.PgError{Code: "23505", ConstraintName: tc.constraint}
+		transporthttp.HTTPErrorHandler(fmt.Errorf("failed to create user: %w", err), c)
+
+		var problem dto.ProblemResponse
+		if err := json.Unmarshal(rec.Body.Bytes(), &problem); err != nil {
+			t.Fatalf("%s: decode problem: %v", tc.constraint, err)
+		}
+		if rec.Code != tc.status || problem.Code != tc.code {
+			t.Errorf("%s: got %d %q, want %d %q", tc.constraint, rec.Code, problem.Code, tc.status, tc.code)
+		}
+	}
+}
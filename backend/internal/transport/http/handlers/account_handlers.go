@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"ratemysoft-backend/internal/auth"
+	"ratemysoft-backend/internal/transport/http/dto"
+
+	"github.com/labstack/echo/v4"
+)
+
+// RequestEmailVerification emails the authenticated user a new verification link
+func (h *Handler) RequestEmailVerification(c echo.Context) error {
+	userID, err := auth.GetUserIDFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(requestContext(c), 15*time.Second)
+	defer cancel()
+
+	if err := h.accountService.SendEmailVerification(ctx, userID); err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusAccepted, map[string]string{
+		"message": "Verification email sent",
+	})
+}
+
+// ConfirmEmailVerification redeems the token from a verification link
+func (h *Handler) ConfirmEmailVerification(c echo.Context) error {
+	var req dto.VerifyEmailRequest
+	if err := bindRequest(c, &req); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(requestContext(c), 10*time.Second)
+	defer cancel()
+
+	user, err := h.accountService.VerifyEmail(ctx, req.Token)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, userResponse(user))
+}
+
+// RequestPasswordReset emails a password reset link. It answers the same whether or not the
+// address has an account.
+func (h *Handler) RequestPasswordReset(c echo.Context) error {
+	var req dto.PasswordResetRequest
+	if err := bindRequest(c, &req); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(requestContext(c), 15*time.Second)
+	defer cancel()
+
+	email := strings.ToLower(strings.TrimSpace(req.Email))
+	if err := h.accountService.RequestPasswordReset(ctx, email); err != nil {
+		// An error here would tell the caller the address has an account
+		log.Printf("Failed to send password reset email: %v", err)
+	}
+
+	return c.JSON(http.StatusAccepted, map[string]string{
+		"message": "If an account uses this email, a password reset link is on its way",
+	})
+}
+
+// ConfirmPasswordReset redeems the token from a password reset link, setting a new password
+// and signing the user out everywhere
+func (h *Handler) ConfirmPasswordReset(c echo.Context) error {
+	var req dto.PasswordResetConfirmRequest
+	if err := bindRequest(c, &req); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(requestContext(c), 10*time.Second)
+	defer cancel()
+
+	if _, err := h.accountService.ResetPassword(ctx, req.Token, req.Password); err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{
+		"message": "Password updated; please sign in again",
+	})
+}
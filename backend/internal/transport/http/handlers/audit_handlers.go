@@ -0,0 +1,186 @@
+package handlers
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"ratemysoft-backend/internal/domain"
+	"ratemysoft-backend/internal/transport/http/dto"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// ListAuditEvents retrieves a page of the audit log, newest first (admin only).
+// Filters: actor_id, action, entity_type, entity_id, from (inclusive) and to (exclusive).
+func (h *Handler) ListAuditEvents(c echo.Context) error {
+	filter, err := parseAuditFilter(c)
+	if err != nil {
+		return err
+	}
+
+	page, err := h.parsePageRequest(c)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(requestContext(c), 10*time.Second)
+	defer cancel()
+
+	total, err := h.audit.CountEvents(ctx, filter)
+	if err != nil {
+		return err
+	}
+
+	events, cursors, err := h.audit.ListEvents(ctx, filter, page)
+	if err != nil {
+		return err
+	}
+
+	responses := make([]dto.AuditEventResponse, 0, len(events))
+	for _, event := range events {
+		responses = append(responses, toAuditEventResponse(event))
+	}
+
+	next, prev := h.writePageLinks(c, cursors)
+	return c.JSON(http.StatusOK, dto.AuditEventListResponse{
+		Events:     responses,
+		Total:      total,
+		Limit:      page.Limit,
+		Offset:     page.Offset,
+		NextCursor: next,
+		PrevCursor: prev,
+	})
+}
+
+// ExportAuditEvents downloads the audit events matching the ListAuditEvents filters as CSV,
+// newest first and capped at services.MaxAuditExport rows (admin only)
+func (h *Handler) ExportAuditEvents(c echo.Context) error {
+	filter, err := parseAuditFilter(c)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(requestContext(c), 60*time.Second)
+	defer cancel()
+
+	events, err := h.audit.ExportEvents(ctx, filter)
+	if err != nil {
+		return err
+	}
+
+	filename := fmt.Sprintf("audit-events-%s.csv", time.Now().UTC().Format("20060102-150405"))
+	c.Response().Header().Set(echo.HeaderContentType, "text/csv; charset=utf-8")
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="%s"`, filename))
+	c.Response().WriteHeader(http.StatusOK)
+
+	w := csv.NewWriter(c.Response())
+	w.Write([]string{
+		"id", "created_at", "actor_id", "actor_handle", "action", "entity_type", "entity_id",
+		"request_id", "ip_address", "before", "after",
+	})
+	for _, event := range events {
+		response := toAuditEventResponse(event)
+		w.Write([]string{
+			response.ID,
+			response.CreatedAt.Format(time.RFC3339),
+			response.ActorID,
+			csvCell(response.ActorHandle),
+			response.Action,
+			response.EntityType,
+			response.EntityID,
+			csvCell(response.RequestID),
+			csvCell(response.IPAddress),
+			string(response.Before),
+			string(response.After),
+		})
+	}
+	w.Flush()
+	return w.Error()
+}
+
+// parseAuditFilter reads the audit log filters from the query string
+func parseAuditFilter(c echo.Context) (domain.AuditFilter, error) {
+	var filter domain.AuditFilter
+
+	if v := c.QueryParam("actor_id"); v != "" {
+		id, err := uuid.Parse(v)
+		if err != nil {
+			return filter, domain.InvalidField("invalid_id", "actor_id", "must be a valid UUID").Wrap(err)
+		}
+		filter.ActorID = &id
+	}
+
+	if v := c.QueryParam("entity_id"); v != "" {
+		id, err := uuid.Parse(v)
+		if err != nil {
+			return filter, domain.InvalidField("invalid_id", "entity_id", "must be a valid UUID").Wrap(err)
+		}
+		filter.EntityID = &id
+	}
+
+	if v := c.QueryParam("action"); v != "" {
+		action, err := domain.NewAuditAction(v)
+		if err != nil {
+			return filter, err
+		}
+		filter.Action = action
+	}
+
+	if v := c.QueryParam("entity_type"); v != "" {
+		entity, err := domain.NewAuditEntity(v)
+		if err != nil {
+			return filter, err
+		}
+		filter.EntityType = entity
+	}
+
+	if v := c.QueryParam("from"); v != "" {
+		from, err := parseTimeParam(v)
+		if err != nil {
+			return filter, domain.InvalidField("invalid_from", "from", "must be an RFC 3339 timestamp or a YYYY-MM-DD date").Wrap(err)
+		}
+		filter.From = &from
+	}
+
+	if v := c.QueryParam("to"); v != "" {
+		to, err := parseTimeParam(v)
+		if err != nil {
+			return filter, domain.InvalidField("invalid_to", "to", "must be an RFC 3339 timestamp or a YYYY-MM-DD date").Wrap(err)
+		}
+		filter.To = &to
+	}
+
+	return filter, nil
+}
+
+func toAuditEventResponse(event *domain.AuditEvent) dto.AuditEventResponse {
+	response := dto.AuditEventResponse{
+		ID:          event.ID.String(),
+		ActorHandle: event.ActorHandle,
+		Action:      string(event.Action),
+		EntityType:  string(event.EntityType),
+		EntityID:    event.EntityID.String(),
+		Before:      event.Before,
+		After:       event.After,
+		RequestID:   event.RequestID,
+		IPAddress:   event.IPAddress,
+		CreatedAt:   event.CreatedAt,
+	}
+	if event.ActorID != nil {
+		response.ActorID = event.ActorID.String()
+	}
+	return response
+}
+
+// csvCell stops spreadsheet applications from evaluating user-controlled text as a formula
+func csvCell(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
@@ -2,147 +2,268 @@ package handlers
 
 import (
 	"context"
+	"errors"
+	"log"
 	"net/http"
 	"strings"
 	"time"
 
 	"ratemysoft-backend/internal/auth"
+	"ratemysoft-backend/internal/domain"
 	"ratemysoft-backend/internal/services"
 	"ratemysoft-backend/internal/transport/http/dto"
 
+	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 )
 
 // Login authenticates a user and returns a token
 func (h *Handler) Login(c echo.Context) error {
 	var req dto.LoginRequest
+	if err := bindRequest(c, &req); err != nil {
+		return err
+	}
+
+	// Create context with 10-second timeout to prevent hanging requests
+	ctx, cancel := context.WithTimeout(requestContext(c), 10*time.Second)
+	defer cancel()
 
-	if err := c.Bind(&req); err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]string{
-			"error": "Invalid request body",
-		})
+	// Failed attempts are counted per email and client IP; too many lock sign-in temporarily
+	user, err := h.loginGuard.Authenticate(ctx, strings.ToLower(strings.TrimSpace(req.Email)), req.Password, c.RealIP())
+	if err != nil {
+		return err
 	}
 
-	// Validate request
-	if err := c.Validate(&req); err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]string{
-			"error":   "Validation failed",
-			"details": err.Error(),
-		})
+	// Start a session and issue access + refresh tokens
+	return h.startSession(ctx, c, http.StatusOK, user)
+}
+
+func (h *Handler) Register(c echo.Context) error {
+	var req dto.RegisterRequest
+	if err := bindRequest(c, &req); err != nil {
+		return err
 	}
 
 	// Create context with 10-second timeout to prevent hanging requests
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(requestContext(c), 10*time.Second)
 	defer cancel()
 
-	user, err := h.userService.AuthenticateUser(ctx, strings.ToLower(strings.TrimSpace(req.Email)), req.Password)
+	user, err := h.userService.CreateUser(ctx, services.CreateUserRequest{
+		Email:    strings.ToLower(strings.TrimSpace(req.Email)),
+		Handle:   strings.TrimSpace(req.Handle),
+		Password: req.Password,
+	})
 	if err != nil {
-		if strings.Contains(err.Error(), "invalid credentials") {
-			return c.JSON(http.StatusUnauthorized, map[string]string{
-				"error": "Invalid email or password",
-			})
-		}
+		return err
+	}
 
-		return c.JSON(http.StatusInternalServerError, map[string]string{
-			"error": "Authentication failed",
-		})
+	// The account works without a verified email, so a mail failure must not fail the sign-up;
+	// the user can ask for another link
+	if err := h.accountService.SendEmailVerification(ctx, user.ID); err != nil {
+		log.Printf("Failed to send verification email to user %s: %v", user.ID, err)
 	}
 
-	// Generate JWT token
-	token, err := h.jwtService.GenerateToken(user)
+	// Start a session and issue access + refresh tokens
+	return h.startSession(ctx, c, http.StatusCreated, user)
+}
+
+// GetProfile returns the authenticated user's profile information
+func (h *Handler) GetProfile(c echo.Context) error {
+	userID, err := auth.GetUserIDFromContext(c)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{
-			"error": "Failed to generate authentication token",
-		})
-	}
-
-	return c.JSON(http.StatusOK, dto.AuthResponse{
-		Token: token,
-		User: dto.UserResponse{
-			ID:     user.ID.String(),
-			Email:  string(user.Email),
-			Handle: user.Handle,
-			Role:   string(user.Role),
-		},
-	})
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(requestContext(c), 5*time.Second)
+	defer cancel()
+
+	// Read the account rather than the token's claims, which go stale when the profile changes
+	user, err := h.userService.GetUserByID(ctx, userID.String())
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, userResponse(user))
 }
 
-func (h *Handler) Register(c echo.Context) error {
-	var req dto.RegisterRequest
+// UpdateProfile changes the authenticated user's handle, email or password
+func (h *Handler) UpdateProfile(c echo.Context) error {
+	claims, err := auth.GetClaimsFromContext(c)
+	if err != nil {
+		return err
+	}
+	userID, err := auth.GetUserIDFromContext(c)
+	if err != nil {
+		return err
+	}
 
-	if err := c.Bind(&req); err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]string{
-			"error": "Invalid request body",
-		})
+	var req dto.UpdateProfileRequest
+	if err := bindRequest(c, &req); err != nil {
+		return err
+	}
+	if req.Handle != nil {
+		*req.Handle = strings.TrimSpace(*req.Handle)
+	}
+	if req.Email != nil {
+		*req.Email = strings.ToLower(strings.TrimSpace(*req.Email))
 	}
 
-	if err := c.Validate(&req); err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]string{
-			"error":   "Validation failed",
-			"details": err.Error(),
-		})
+	// A token without a session keeps none of the user's sessions
+	sessionID, _ := uuid.Parse(claims.SessionID)
+
+	ctx, cancel := context.WithTimeout(requestContext(c), 15*time.Second)
+	defer cancel()
+
+	update, err := h.userService.UpdateProfile(ctx, userID, services.UpdateProfileRequest{
+		Handle:          req.Handle,
+		Email:           req.Email,
+		Password:        req.Password,
+		CurrentPassword: req.CurrentPassword,
+		SessionID:       sessionID,
+	})
+	if err != nil {
+		return err
 	}
 
-	// Create context with 10-second timeout to prevent hanging requests
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	// The change is saved; mail failures only cost the user a link they can ask for again
+	if update.PreviousEmail != "" {
+		if err := h.accountService.NotifyEmailChanged(ctx, update.PreviousEmail, update.User); err != nil {
+			log.Printf("Failed to notify previous email of user %s: %v", userID, err)
+		}
+		if err := h.accountService.SendEmailVerification(ctx, userID); err != nil {
+			log.Printf("Failed to send verification email to user %s: %v", userID, err)
+		}
+	}
+
+	return c.JSON(http.StatusOK, userResponse(update.User))
+}
+
+// DeleteProfile deletes the authenticated user's account and ends all of its sessions.
+// Reviews the user wrote stay up without their name.
+func (h *Handler) DeleteProfile(c echo.Context) error {
+	userID, err := auth.GetUserIDFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.DeleteAccountRequest
+	if err := bindRequest(c, &req); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(requestContext(c), 10*time.Second)
 	defer cancel()
 
-	user, err := h.userService.CreateUser(ctx, services.CreateUserRequest{
-		Email:    strings.ToLower(strings.TrimSpace(req.Email)),
-		Handle:   strings.TrimSpace(req.Handle),
-		Password: req.Password,
+	if err := h.userService.DeleteAccount(ctx, userID, req.Password); err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{
+		"message": "Account deleted",
 	})
+}
+
+// RefreshToken exchanges a refresh token for a new access token and a rotated refresh token
+func (h *Handler) RefreshToken(c echo.Context) error {
+	var req dto.RefreshRequest
+	if err := bindRequest(c, &req); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(requestContext(c), 10*time.Second)
+	defer cancel()
+
+	session, refreshToken, err := h.sessionService.RefreshSession(ctx, req.RefreshToken)
+	if err != nil {
+		return err
+	}
+
+	// Reload the user so role or handle changes take effect on refresh
+	user, err := h.userService.GetUserByID(ctx, session.UserID.String())
 	if err != nil {
-		if strings.Contains(err.Error(), "already exists") {
-			return c.JSON(http.StatusConflict, map[string]string{
-				"error": "User with this email already exists",
-			})
+		if errors.Is(err, domain.ErrNotFound) {
+			return domain.Unauthorized("invalid_refresh_token", "invalid refresh token").Wrap(err)
 		}
+		return err
+	}
+
+	return h.authResponse(c, http.StatusOK, user, session, refreshToken)
+}
+
+// Logout ends the current session, revoking its access and refresh tokens
+func (h *Handler) Logout(c echo.Context) error {
+	claims, err := auth.GetClaimsFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(requestContext(c), 5*time.Second)
+	defer cancel()
 
-		// For debugging, include the actual error message
-		return c.JSON(http.StatusInternalServerError, map[string]string{
-			"error":   "Failed to create user",
-			"details": err.Error(),
-		})
+	if err := h.sessionService.EndSession(ctx, claims); err != nil {
+		return err
 	}
 
-	// Generate JWT token
-	token, err := h.jwtService.GenerateToken(user)
+	return c.JSON(http.StatusOK, map[string]string{
+		"message": "Logged out successfully",
+	})
+}
+
+// LogoutAll ends every session of the authenticated user
+func (h *Handler) LogoutAll(c echo.Context) error {
+	claims, err := auth.GetClaimsFromContext(c)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{
-			"error": "Failed to generate authentication token",
-		})
-	}
-
-	return c.JSON(http.StatusCreated, dto.AuthResponse{
-		Token: token,
-		User: dto.UserResponse{
-			ID:     user.ID.String(),
-			Email:  string(user.Email),
-			Handle: user.Handle,
-			Role:   string(user.Role),
-		},
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(requestContext(c), 5*time.Second)
+	defer cancel()
+
+	revoked, err := h.sessionService.EndAllSessions(ctx, claims)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.LogoutAllResponse{
+		Message:         "Logged out of all sessions",
+		SessionsRevoked: revoked,
 	})
 }
 
-// GetProfile returns the authenticated user's profile information
-func (h *Handler) GetProfile(c echo.Context) error {
-	// User info is already in context from AuthMiddleware
-	userID, err := auth.GetUserIDFromContext(c)
+// startSession creates a new login session for the user and writes the token response
+func (h *Handler) startSession(ctx context.Context, c echo.Context, status int, user *domain.User) error {
+	session, refreshToken, err := h.sessionService.StartSession(ctx, user.ID, services.SessionMetadata{
+		UserAgent: c.Request().UserAgent(),
+		IPAddress: c.RealIP(),
+	})
 	if err != nil {
-		return c.JSON(http.StatusUnauthorized, map[string]string{
-			"error": "User not authenticated",
-		})
+		return err
 	}
 
-	email, _ := auth.GetUserEmailFromContext(c)
-	handle, _ := auth.GetUserHandleFromContext(c)
-	role, _ := auth.GetUserRoleFromContext(c)
+	return h.authResponse(c, status, user, session, refreshToken)
+}
+
+// authResponse issues an access token for the session and writes it alongside the refresh token
+func (h *Handler) authResponse(c echo.Context, status int, user *domain.User, session *domain.Session, refreshToken string) error {
+	token, err := h.jwtService.GenerateToken(user, session.ID)
+	if err != nil {
+		return err
+	}
 
-	return c.JSON(http.StatusOK, map[string]interface{}{
-		"id":     userID.String(),
-		"email":  email,
-		"handle": handle,
-		"role":   role,
+	return c.JSON(status, dto.AuthResponse{
+		Token:        token,
+		RefreshToken: refreshToken,
+		ExpiresIn:    int64(h.jwtService.AccessTokenExpiry().Seconds()),
+		User:         userResponse(user),
 	})
 }
+
+func userResponse(user *domain.User) dto.UserResponse {
+	return dto.UserResponse{
+		ID:            user.ID.String(),
+		Email:         string(user.Email),
+		EmailVerified: user.IsEmailVerified(),
+		Handle:        user.Handle,
+		Role:          string(user.Role),
+	}
+}
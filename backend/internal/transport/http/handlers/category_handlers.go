@@ -0,0 +1,145 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"ratemysoft-backend/internal/domain"
+	"ratemysoft-backend/internal/services"
+	"ratemysoft-backend/internal/transport/http/dto"
+
+	"github.com/labstack/echo/v4"
+)
+
+// ListCategories retrieves every category with its product counts
+func (h *Handler) ListCategories(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(requestContext(c), 5*time.Second)
+	defer cancel()
+
+	categories, err := h.categoryService.ListCategories(ctx)
+	if err != nil {
+		return err
+	}
+
+	responses := toCategoryResponses(categories)
+	return c.JSON(http.StatusOK, dto.CategoryListResponse{
+		Categories: responses,
+		Count:      len(responses),
+	})
+}
+
+// GetCategory retrieves a category and its direct subcategories by slug
+func (h *Handler) GetCategory(c echo.Context) error {
+	slug := c.Param("slug")
+
+	ctx, cancel := context.WithTimeout(requestContext(c), 5*time.Second)
+	defer cancel()
+
+	category, children, err := h.categoryService.GetCategory(ctx, slug)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.CategoryDetailResponse{
+		CategoryResponse: toCategoryResponse(category),
+		Children:         toCategoryResponses(children),
+	})
+}
+
+// CreateCategory adds a category (admin only)
+func (h *Handler) CreateCategory(c echo.Context) error {
+	var req dto.CategoryRequest
+	if err := bindRequest(c, &req); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(requestContext(c), 10*time.Second)
+	defer cancel()
+
+	category, err := h.categoryService.CreateCategory(ctx, toCategoryRequest(req))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusCreated, toCategoryResponse(category))
+}
+
+// UpdateCategory replaces a category (admin only)
+func (h *Handler) UpdateCategory(c echo.Context) error {
+	categoryID := c.Param("id")
+
+	var req dto.CategoryRequest
+	if err := bindRequest(c, &req); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(requestContext(c), 10*time.Second)
+	defer cancel()
+
+	category, err := h.categoryService.UpdateCategory(ctx, categoryID, toCategoryRequest(req))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, toCategoryResponse(category))
+}
+
+// DeleteCategory removes an empty category (admin only)
+func (h *Handler) DeleteCategory(c echo.Context) error {
+	categoryID := c.Param("id")
+
+	ctx, cancel := context.WithTimeout(requestContext(c), 10*time.Second)
+	defer cancel()
+
+	err := h.categoryService.DeleteCategory(ctx, categoryID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{
+		"message": "Category deleted successfully",
+	})
+}
+
+func toCategoryRequest(req dto.CategoryRequest) services.CategoryRequest {
+	return services.CategoryRequest{
+		Slug:        strings.TrimSpace(req.Slug),
+		Name:        strings.TrimSpace(req.Name),
+		Description: strings.TrimSpace(req.Description),
+		Icon:        strings.TrimSpace(req.Icon),
+		ParentSlug:  strings.TrimSpace(req.Parent),
+		SortOrder:   req.SortOrder,
+	}
+}
+
+func toCategoryResponse(category *domain.Category) dto.CategoryResponse {
+	var parentID *string
+	if category.ParentID != nil {
+		id := category.ParentID.String()
+		parentID = &id
+	}
+
+	return dto.CategoryResponse{
+		ID:                category.ID.String(),
+		Slug:              string(category.Slug),
+		Name:              category.Name,
+		Description:       category.Description,
+		Icon:              category.Icon,
+		ParentID:          parentID,
+		SortOrder:         category.SortOrder,
+		ProductCount:      category.ProductCount,
+		TotalProductCount: category.TotalProductCount,
+		CreatedAt:         category.CreatedAt,
+		UpdatedAt:         category.UpdatedAt,
+	}
+}
+
+func toCategoryResponses(categories []*domain.Category) []dto.CategoryResponse {
+	responses := make([]dto.CategoryResponse, 0, len(categories))
+	for _, category := range categories {
+		responses = append(responses, toCategoryResponse(category))
+	}
+	return responses
+}
@@ -0,0 +1,316 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"ratemysoft-backend/internal/auth"
+	"ratemysoft-backend/internal/domain"
+	"ratemysoft-backend/internal/services"
+	"ratemysoft-backend/internal/transport/http/dto"
+
+	"github.com/labstack/echo/v4"
+)
+
+// ListReviewComments retrieves the comment thread of a published review
+func (h *Handler) ListReviewComments(c echo.Context) error {
+	reviewID := c.Param("id")
+
+	ctx, cancel := context.WithTimeout(requestContext(c), 5*time.Second)
+	defer cancel()
+
+	comments, err := h.commentService.ListComments(ctx, reviewID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.CommentListResponse{
+		ReviewID: reviewID,
+		Comments: toCommentResponses(comments),
+	})
+}
+
+// CreateReviewComment comments on a review, replies to a comment, or posts the
+// company's official response
+func (h *Handler) CreateReviewComment(c echo.Context) error {
+	reviewID := c.Param("id")
+
+	actor, err := auth.GetActorFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.CreateCommentRequest
+	if err := bindRequest(c, &req); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(requestContext(c), 10*time.Second)
+	defer cancel()
+
+	comment, err := h.commentService.CreateComment(ctx, actor, services.CreateCommentRequest{
+		ReviewID: reviewID,
+		ParentID: req.ParentID,
+		Body:     req.Body,
+		Official: req.Official,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusCreated, toCommentResponse(comment))
+}
+
+// UpdateReviewComment edits the authenticated user's own comment
+func (h *Handler) UpdateReviewComment(c echo.Context) error {
+	commentID := c.Param("commentId")
+
+	actor, err := auth.GetActorFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.UpdateCommentRequest
+	if err := bindRequest(c, &req); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(requestContext(c), 10*time.Second)
+	defer cancel()
+
+	comment, err := h.commentService.UpdateComment(ctx, actor, commentID, req.Body)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, toCommentResponse(comment))
+}
+
+// DeleteReviewComment deletes the authenticated user's own comment
+func (h *Handler) DeleteReviewComment(c echo.Context) error {
+	commentID := c.Param("commentId")
+
+	actor, err := auth.GetActorFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(requestContext(c), 5*time.Second)
+	defer cancel()
+
+	if err := h.commentService.DeleteComment(ctx, actor, commentID); err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{
+		"message": "Comment deleted successfully",
+	})
+}
+
+// FlagReviewComment flags a comment for moderation
+func (h *Handler) FlagReviewComment(c echo.Context) error {
+	commentID := c.Param("commentId")
+
+	userID, err := auth.GetUserIDFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.FlagCommentRequest
+	if err := bindRequest(c, &req); err != nil {
+		return err
+	}
+
+	reason := domain.FlagOther
+	if req.Reason != "" {
+		reason, err = domain.NewFlagReason(req.Reason)
+		if err != nil {
+			return err
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(requestContext(c), 5*time.Second)
+	defer cancel()
+
+	err = h.commentService.FlagComment(ctx, commentID, userID.String(), reason, strings.TrimSpace(req.Note))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{
+		"message": "Comment flagged successfully",
+	})
+}
+
+// UnflagReviewComment retracts the authenticated user's flag on a comment
+func (h *Handler) UnflagReviewComment(c echo.Context) error {
+	commentID := c.Param("commentId")
+
+	userID, err := auth.GetUserIDFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(requestContext(c), 5*time.Second)
+	defer cancel()
+
+	err = h.commentService.UnflagComment(ctx, commentID, userID.String())
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{
+		"message": "Flag retracted successfully",
+	})
+}
+
+// ListPendingComments retrieves the queue of comments awaiting approval, by cursor or offset (admin only)
+func (h *Handler) ListPendingComments(c echo.Context) error {
+	page, err := h.parsePageRequest(c)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(requestContext(c), 10*time.Second)
+	defer cancel()
+
+	total, err := h.commentService.CountPendingComments(ctx)
+	if err != nil {
+		return err
+	}
+
+	comments, cursors, err := h.commentService.ListPendingComments(ctx, page)
+	if err != nil {
+		return err
+	}
+
+	next, prev := h.writePageLinks(c, cursors)
+	return c.JSON(http.StatusOK, dto.CommentQueueResponse{
+		Comments:   toCommentQueueResponses(comments),
+		Total:      total,
+		Limit:      page.Limit,
+		Offset:     page.Offset,
+		NextCursor: next,
+		PrevCursor: prev,
+	})
+}
+
+// ListFlaggedComments retrieves the queue of comments at or above the flag threshold, by cursor or offset (admin only)
+func (h *Handler) ListFlaggedComments(c echo.Context) error {
+	page, err := h.parsePageRequest(c)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(requestContext(c), 10*time.Second)
+	defer cancel()
+
+	total, err := h.commentService.CountFlaggedComments(ctx)
+	if err != nil {
+		return err
+	}
+
+	comments, cursors, err := h.commentService.ListFlaggedComments(ctx, page)
+	if err != nil {
+		return err
+	}
+
+	next, prev := h.writePageLinks(c, cursors)
+	return c.JSON(http.StatusOK, dto.CommentQueueResponse{
+		Comments:   toCommentQueueResponses(comments),
+		Total:      total,
+		Limit:      page.Limit,
+		Offset:     page.Offset,
+		NextCursor: next,
+		PrevCursor: prev,
+	})
+}
+
+// ApproveComment publishes a comment and clears its flags (admin only)
+func (h *Handler) ApproveComment(c echo.Context) error {
+	return h.moderateComment(c, domain.ReviewPublished)
+}
+
+// RejectComment rejects a comment with a reason (admin only)
+func (h *Handler) RejectComment(c echo.Context) error {
+	return h.moderateComment(c, domain.ReviewRejected)
+}
+
+// moderateComment applies an approve or reject decision on behalf of the authenticated admin
+func (h *Handler) moderateComment(c echo.Context, to domain.ReviewStatus) error {
+	commentID := c.Param("id")
+
+	moderatorID, err := auth.GetUserIDFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.ModerateReviewRequest
+	if err := bindRequest(c, &req); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(requestContext(c), 10*time.Second)
+	defer cancel()
+
+	var comment *domain.ReviewComment
+	if to == domain.ReviewPublished {
+		comment, err = h.commentService.ApproveComment(ctx, commentID, moderatorID.String(), req.Reason)
+	} else {
+		comment, err = h.commentService.RejectComment(ctx, commentID, moderatorID.String(), req.Reason)
+	}
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.CommentQueueItemResponse{
+		CommentResponse: toCommentResponse(comment),
+		FlagCount:       comment.FlagCount,
+	})
+}
+
+func toCommentResponse(comment *domain.ReviewComment) dto.CommentResponse {
+	response := dto.CommentResponse{
+		ID:          comment.ID.String(),
+		ReviewID:    comment.ReviewID.String(),
+		UserID:      comment.UserID.String(),
+		UserHandle:  comment.UserHandle,
+		Body:        comment.Body,
+		Status:      string(comment.Status),
+		Official:    comment.Official,
+		CompanyName: comment.CompanyName,
+		Edited:      comment.Edited,
+		Deleted:     comment.DeletedAt != nil,
+		CreatedAt:   comment.CreatedAt,
+		UpdatedAt:   comment.UpdatedAt,
+		Replies:     toCommentResponses(comment.Replies),
+	}
+	if comment.ParentID != nil {
+		response.ParentID = comment.ParentID.String()
+	}
+	if comment.CompanyID != nil {
+		response.CompanyID = comment.CompanyID.String()
+	}
+	return response
+}
+
+func toCommentResponses(comments []*domain.ReviewComment) []dto.CommentResponse {
+	responses := make([]dto.CommentResponse, 0, len(comments))
+	for _, comment := range comments {
+		responses = append(responses, toCommentResponse(comment))
+	}
+	return responses
+}
+
+func toCommentQueueResponses(comments []*domain.ReviewComment) []dto.CommentQueueItemResponse {
+	responses := make([]dto.CommentQueueItemResponse, 0, len(comments))
+	for _, comment := range comments {
+		responses = append(responses, dto.CommentQueueItemResponse{
+			CommentResponse: toCommentResponse(comment),
+			FlagCount:       comment.FlagCount,
+		})
+	}
+	return responses
+}
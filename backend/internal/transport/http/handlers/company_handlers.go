@@ -3,10 +3,11 @@ package handlers
 import (
 	"context"
 	"net/http"
-	"strconv"
 	"strings"
 	"time"
 
+	"ratemysoft-backend/internal/auth"
+	"ratemysoft-backend/internal/domain"
 	"ratemysoft-backend/internal/services"
 	"ratemysoft-backend/internal/transport/http/dto"
 
@@ -15,45 +16,27 @@ import (
 
 // CreateCompany creates a new company
 func (h *Handler) CreateCompany(c echo.Context) error {
-	var req dto.CreateCompanyRequest
-
-	if err := c.Bind(&req); err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]string{
-			"error": "Invalid request body",
-		})
+	actor, err := auth.GetActorFromContext(c)
+	if err != nil {
+		return err
 	}
 
-	if err := c.Validate(&req); err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]string{
-			"error":   "Validation failed",
-			"details": err.Error(),
-		})
+	var req dto.CreateCompanyRequest
+	if err := bindRequest(c, &req); err != nil {
+		return err
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(requestContext(c), 10*time.Second)
 	defer cancel()
 
-	company, err := h.companyService.CreateCompany(ctx, services.CreateCompanyRequest{
+	company, err := h.companyService.CreateCompany(ctx, actor, services.CreateCompanyRequest{
 		Name:    strings.TrimSpace(req.Name),
 		Website: strings.TrimSpace(req.Website),
 		Slug:    strings.TrimSpace(req.Slug),
 		LogoURL: strings.TrimSpace(req.LogoURL),
 	})
 	if err != nil {
-		if strings.Contains(err.Error(), "already exists") {
-			return c.JSON(http.StatusConflict, map[string]string{
-				"error": err.Error(),
-			})
-		}
-		if strings.Contains(err.Error(), "invalid slug") {
-			return c.JSON(http.StatusBadRequest, map[string]string{
-				"error": err.Error(),
-			})
-		}
-		return c.JSON(http.StatusInternalServerError, map[string]string{
-			"error":   "Failed to create company",
-			"details": err.Error(),
-		})
+		return err
 	}
 
 	return c.JSON(http.StatusCreated, dto.CompanyResponse{
@@ -72,24 +55,12 @@ func (h *Handler) CreateCompany(c echo.Context) error {
 func (h *Handler) GetCompany(c echo.Context) error {
 	companyID := c.Param("id")
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := context.WithTimeout(requestContext(c), 5*time.Second)
 	defer cancel()
 
 	company, err := h.companyService.GetCompanyByID(ctx, companyID)
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
-			return c.JSON(http.StatusNotFound, map[string]string{
-				"error": "Company not found",
-			})
-		}
-		if strings.Contains(err.Error(), "invalid") {
-			return c.JSON(http.StatusBadRequest, map[string]string{
-				"error": "Invalid company ID",
-			})
-		}
-		return c.JSON(http.StatusInternalServerError, map[string]string{
-			"error": "Failed to get company",
-		})
+		return err
 	}
 
 	return c.JSON(http.StatusOK, dto.CompanyResponse{
@@ -108,24 +79,12 @@ func (h *Handler) GetCompany(c echo.Context) error {
 func (h *Handler) GetCompanyBySlug(c echo.Context) error {
 	slug := c.Param("slug")
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := context.WithTimeout(requestContext(c), 5*time.Second)
 	defer cancel()
 
 	company, err := h.companyService.GetCompanyBySlug(ctx, slug)
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
-			return c.JSON(http.StatusNotFound, map[string]string{
-				"error": "Company not found",
-			})
-		}
-		if strings.Contains(err.Error(), "invalid") {
-			return c.JSON(http.StatusBadRequest, map[string]string{
-				"error": "Invalid slug format",
-			})
-		}
-		return c.JSON(http.StatusInternalServerError, map[string]string{
-			"error": "Failed to get company",
-		})
+		return err
 	}
 
 	return c.JSON(http.StatusOK, dto.CompanyResponse{
@@ -140,43 +99,26 @@ func (h *Handler) GetCompanyBySlug(c echo.Context) error {
 	})
 }
 
-// ListCompanies retrieves a paginated list of companies
+// ListCompanies retrieves a page of companies, by cursor or offset
 func (h *Handler) ListCompanies(c echo.Context) error {
-	// Parse pagination parameters
-	limit := int32(50) // default
-	if l := c.QueryParam("limit"); l != "" {
-		if parsed, err := strconv.ParseInt(l, 10, 32); err == nil && parsed > 0 {
-			limit = int32(parsed)
-			if limit > 100 {
-				limit = 100 // max limit
-			}
-		}
-	}
-
-	offset := int32(0) // default
-	if o := c.QueryParam("offset"); o != "" {
-		if parsed, err := strconv.ParseInt(o, 10, 32); err == nil && parsed >= 0 {
-			offset = int32(parsed)
-		}
+	page, err := h.parsePageRequest(c)
+	if err != nil {
+		return err
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(requestContext(c), 10*time.Second)
 	defer cancel()
 
 	// Get total count
 	total, err := h.companyService.CountCompanies(ctx)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{
-			"error": "Failed to count companies",
-		})
+		return err
 	}
 
 	// Get companies
-	companies, err := h.companyService.ListCompanies(ctx, limit, offset)
+	companies, cursors, err := h.companyService.ListCompanies(ctx, page)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{
-			"error": "Failed to list companies",
-		})
+		return err
 	}
 
 	// Convert to response DTOs
@@ -194,49 +136,40 @@ func (h *Handler) ListCompanies(c echo.Context) error {
 		})
 	}
 
+	next, prev := h.writePageLinks(c, cursors)
 	return c.JSON(http.StatusOK, dto.CompanyListResponse{
-		Companies: companyResponses,
-		Total:     total,
-		Limit:     limit,
-		Offset:    offset,
+		Companies:  companyResponses,
+		Total:      total,
+		Limit:      page.Limit,
+		Offset:     page.Offset,
+		NextCursor: next,
+		PrevCursor: prev,
 	})
 }
 
-// SearchCompanies searches for companies by name or slug
+// SearchCompanies retrieves a page of the companies whose name or slug contains q, by cursor or offset
 func (h *Handler) SearchCompanies(c echo.Context) error {
 	query := c.QueryParam("q")
 	if query == "" {
-		return c.JSON(http.StatusBadRequest, map[string]string{
-			"error": "Search query parameter 'q' is required",
-		})
-	}
-
-	// Parse pagination parameters
-	limit := int32(50) // default
-	if l := c.QueryParam("limit"); l != "" {
-		if parsed, err := strconv.ParseInt(l, 10, 32); err == nil && parsed > 0 {
-			limit = int32(parsed)
-			if limit > 100 {
-				limit = 100 // max limit
-			}
-		}
+		return domain.InvalidField("missing_query", "q", "search query is required")
 	}
 
-	offset := int32(0) // default
-	if o := c.QueryParam("offset"); o != "" {
-		if parsed, err := strconv.ParseInt(o, 10, 32); err == nil && parsed >= 0 {
-			offset = int32(parsed)
-		}
+	page, err := h.parsePageRequest(c)
+	if err != nil {
+		return err
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(requestContext(c), 10*time.Second)
 	defer cancel()
 
-	companies, err := h.companyService.SearchCompanies(ctx, query, limit, offset)
+	total, err := h.companyService.CountSearchCompanies(ctx, query)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{
-			"error": "Failed to search companies",
-		})
+		return err
+	}
+
+	companies, cursors, err := h.companyService.SearchCompanies(ctx, query, page)
+	if err != nil {
+		return err
 	}
 
 	// Convert to response DTOs
@@ -254,11 +187,14 @@ func (h *Handler) SearchCompanies(c echo.Context) error {
 		})
 	}
 
-	return c.JSON(http.StatusOK, map[string]interface{}{
-		"companies": companyResponses,
-		"count":     len(companyResponses),
-		"limit":     limit,
-		"offset":    offset,
+	next, prev := h.writePageLinks(c, cursors)
+	return c.JSON(http.StatusOK, dto.CompanyListResponse{
+		Companies:  companyResponses,
+		Total:      total,
+		Limit:      page.Limit,
+		Offset:     page.Offset,
+		NextCursor: next,
+		PrevCursor: prev,
 	})
 }
 
@@ -266,49 +202,27 @@ func (h *Handler) SearchCompanies(c echo.Context) error {
 func (h *Handler) UpdateCompany(c echo.Context) error {
 	companyID := c.Param("id")
 
-	var req dto.UpdateCompanyRequest
-	if err := c.Bind(&req); err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]string{
-			"error": "Invalid request body",
-		})
+	actor, err := auth.GetActorFromContext(c)
+	if err != nil {
+		return err
 	}
 
-	if err := c.Validate(&req); err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]string{
-			"error":   "Validation failed",
-			"details": err.Error(),
-		})
+	var req dto.UpdateCompanyRequest
+	if err := bindRequest(c, &req); err != nil {
+		return err
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(requestContext(c), 10*time.Second)
 	defer cancel()
 
-	company, err := h.companyService.UpdateCompany(ctx, companyID, services.UpdateCompanyRequest{
+	company, err := h.companyService.UpdateCompany(ctx, actor, companyID, services.UpdateCompanyRequest{
 		Name:    strings.TrimSpace(req.Name),
 		Website: strings.TrimSpace(req.Website),
 		Slug:    strings.TrimSpace(req.Slug),
 		LogoURL: strings.TrimSpace(req.LogoURL),
 	})
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
-			return c.JSON(http.StatusNotFound, map[string]string{
-				"error": "Company not found",
-			})
-		}
-		if strings.Contains(err.Error(), "already exists") {
-			return c.JSON(http.StatusConflict, map[string]string{
-				"error": err.Error(),
-			})
-		}
-		if strings.Contains(err.Error(), "invalid") {
-			return c.JSON(http.StatusBadRequest, map[string]string{
-				"error": err.Error(),
-			})
-		}
-		return c.JSON(http.StatusInternalServerError, map[string]string{
-			"error":   "Failed to update company",
-			"details": err.Error(),
-		})
+		return err
 	}
 
 	return c.JSON(http.StatusOK, dto.CompanyResponse{
@@ -327,24 +241,17 @@ func (h *Handler) UpdateCompany(c echo.Context) error {
 func (h *Handler) DeleteCompany(c echo.Context) error {
 	companyID := c.Param("id")
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	actor, err := auth.GetActorFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(requestContext(c), 10*time.Second)
 	defer cancel()
 
-	err := h.companyService.DeleteCompany(ctx, companyID)
+	err = h.companyService.DeleteCompany(ctx, actor, companyID)
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
-			return c.JSON(http.StatusNotFound, map[string]string{
-				"error": "Company not found",
-			})
-		}
-		if strings.Contains(err.Error(), "invalid") {
-			return c.JSON(http.StatusBadRequest, map[string]string{
-				"error": "Invalid company ID",
-			})
-		}
-		return c.JSON(http.StatusInternalServerError, map[string]string{
-			"error": "Failed to delete company",
-		})
+		return err
 	}
 
 	return c.JSON(http.StatusOK, map[string]string{
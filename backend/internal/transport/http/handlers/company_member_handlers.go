@@ -0,0 +1,243 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"ratemysoft-backend/internal/auth"
+	"ratemysoft-backend/internal/domain"
+	"ratemysoft-backend/internal/transport/http/dto"
+
+	"github.com/labstack/echo/v4"
+)
+
+// ListCompanyMembers lists a company's members (company editors and admins only)
+func (h *Handler) ListCompanyMembers(c echo.Context) error {
+	companyID := c.Param("id")
+
+	actor, err := auth.GetActorFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(requestContext(c), 5*time.Second)
+	defer cancel()
+
+	members, err := h.companyService.ListCompanyMembers(ctx, actor, companyID)
+	if err != nil {
+		return err
+	}
+
+	responses := make([]dto.CompanyMemberResponse, 0, len(members))
+	for _, m := range members {
+		responses = append(responses, toCompanyMemberResponse(m))
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"company_id": companyID,
+		"members":    responses,
+	})
+}
+
+// SetCompanyMember adds a member or changes their role (company owners and admins only)
+func (h *Handler) SetCompanyMember(c echo.Context) error {
+	companyID := c.Param("id")
+
+	actor, err := auth.GetActorFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.CompanyMemberRequest
+	if err := bindRequest(c, &req); err != nil {
+		return err
+	}
+
+	role, err := domain.NewCompanyRole(req.Role)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(requestContext(c), 10*time.Second)
+	defer cancel()
+
+	member, err := h.companyService.SetCompanyMember(ctx, actor, companyID, strings.TrimSpace(req.Handle), role)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, toCompanyMemberResponse(member))
+}
+
+// RemoveCompanyMember removes a member from a company (owners, admins, or the member themselves)
+func (h *Handler) RemoveCompanyMember(c echo.Context) error {
+	companyID := c.Param("id")
+	userID := c.Param("userId")
+
+	actor, err := auth.GetActorFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(requestContext(c), 10*time.Second)
+	defer cancel()
+
+	err = h.companyService.RemoveCompanyMember(ctx, actor, companyID, userID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{
+		"message": "Company member removed successfully",
+	})
+}
+
+// ClaimCompany submits a claim for ownership of a company
+func (h *Handler) ClaimCompany(c echo.Context) error {
+	companyID := c.Param("id")
+
+	actor, err := auth.GetActorFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.ClaimCompanyRequest
+	if err := bindRequest(c, &req); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(requestContext(c), 10*time.Second)
+	defer cancel()
+
+	claim, err := h.companyService.ClaimCompany(ctx, actor, companyID, strings.TrimSpace(req.Evidence))
+	if err != nil {
+		return err
+	}
+
+	status := http.StatusAccepted
+	if claim.Status == domain.ClaimApproved {
+		status = http.StatusOK
+	}
+	return c.JSON(status, toCompanyClaimResponse(claim))
+}
+
+// ListCompanyClaims lists company claims by status, pending by default, by cursor or offset (admin only)
+func (h *Handler) ListCompanyClaims(c echo.Context) error {
+	status := domain.ClaimStatus(c.QueryParam("status"))
+	if status == "" {
+		status = domain.ClaimPending
+	}
+	if status != domain.ClaimPending && status != domain.ClaimApproved && status != domain.ClaimRejected {
+		return domain.InvalidField("invalid_claim_status", "status", "must be one of: pending approved rejected")
+	}
+
+	page, err := h.parsePageRequest(c)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(requestContext(c), 10*time.Second)
+	defer cancel()
+
+	total, err := h.companyService.CountCompanyClaims(ctx, status)
+	if err != nil {
+		return err
+	}
+
+	claims, cursors, err := h.companyService.ListCompanyClaims(ctx, status, page)
+	if err != nil {
+		return err
+	}
+
+	responses := make([]dto.CompanyClaimResponse, 0, len(claims))
+	for _, claim := range claims {
+		responses = append(responses, toCompanyClaimResponse(claim))
+	}
+
+	next, prev := h.writePageLinks(c, cursors)
+	return c.JSON(http.StatusOK, dto.CompanyClaimListResponse{
+		Claims:     responses,
+		Total:      total,
+		Limit:      page.Limit,
+		Offset:     page.Offset,
+		NextCursor: next,
+		PrevCursor: prev,
+	})
+}
+
+// ApproveCompanyClaim approves a claim and makes the claimant an owner (admin only)
+func (h *Handler) ApproveCompanyClaim(c echo.Context) error {
+	return h.resolveCompanyClaim(c, domain.ClaimApproved)
+}
+
+// RejectCompanyClaim rejects a claim with a note (admin only)
+func (h *Handler) RejectCompanyClaim(c echo.Context) error {
+	return h.resolveCompanyClaim(c, domain.ClaimRejected)
+}
+
+func (h *Handler) resolveCompanyClaim(c echo.Context, to domain.ClaimStatus) error {
+	claimID := c.Param("id")
+
+	actor, err := auth.GetActorFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.ResolveCompanyClaimRequest
+	if err := bindRequest(c, &req); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(requestContext(c), 10*time.Second)
+	defer cancel()
+
+	var claim *domain.CompanyClaim
+	if to == domain.ClaimApproved {
+		claim, err = h.companyService.ApproveCompanyClaim(ctx, actor, claimID, strings.TrimSpace(req.Note))
+	} else {
+		claim, err = h.companyService.RejectCompanyClaim(ctx, actor, claimID, strings.TrimSpace(req.Note))
+	}
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, toCompanyClaimResponse(claim))
+}
+
+func toCompanyMemberResponse(m *domain.CompanyMember) dto.CompanyMemberResponse {
+	return dto.CompanyMemberResponse{
+		CompanyID:  m.CompanyID.String(),
+		UserID:     m.UserID.String(),
+		UserHandle: m.UserHandle,
+		Role:       string(m.Role),
+		CreatedAt:  m.CreatedAt,
+		UpdatedAt:  m.UpdatedAt,
+	}
+}
+
+func toCompanyClaimResponse(claim *domain.CompanyClaim) dto.CompanyClaimResponse {
+	var reviewedBy *string
+	if claim.ReviewedBy != nil {
+		id := claim.ReviewedBy.String()
+		reviewedBy = &id
+	}
+
+	return dto.CompanyClaimResponse{
+		ID:                 claim.ID.String(),
+		CompanyID:          claim.CompanyID.String(),
+		CompanyName:        claim.CompanyName,
+		UserID:             claim.UserID.String(),
+		UserHandle:         claim.UserHandle,
+		UserEmail:          claim.UserEmail,
+		Status:             string(claim.Status),
+		VerificationMethod: string(claim.VerificationMethod),
+		Evidence:           claim.Evidence,
+		ReviewedBy:         reviewedBy,
+		ReviewNote:         claim.ReviewNote,
+		ReviewedAt:         claim.ReviewedAt,
+		CreatedAt:          claim.CreatedAt,
+		UpdatedAt:          claim.UpdatedAt,
+	}
+}
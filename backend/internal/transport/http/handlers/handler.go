@@ -6,7 +6,10 @@ import (
 	"time"
 
 	"ratemysoft-backend/internal/auth"
-	"ratemysoft-backend/internal/models/sqlc"
+	"ratemysoft-backend/internal/domain"
+	"ratemysoft-backend/internal/platform/config"
+	"ratemysoft-backend/internal/platform/mail"
+	"ratemysoft-backend/internal/repository"
 	"ratemysoft-backend/internal/services"
 
 	"github.com/labstack/echo/v4"
@@ -14,35 +17,94 @@ import (
 
 // Handler holds dependencies for HTTP handlers
 type Handler struct {
-	queries        *sqlc.Queries
-	userService    *services.UserService
-	companyService *services.CompanyService
-	productService *services.ProductService
-	reviewService  *services.ReviewService
-	jwtService     *auth.JWTService
+	store           repository.Store
+	userService     *services.UserService
+	loginGuard      *services.LoginGuard
+	accountService  *services.AccountService
+	companyService  *services.CompanyService
+	productService  *services.ProductService
+	reviewService   *services.ReviewService
+	commentService  *services.CommentService
+	pricingService  *services.PricingService
+	categoryService *services.CategoryService
+	tagService      *services.TagService
+	sessionService  *services.SessionService
+	leaderboard     *services.LeaderboardService
+	reputation      *services.ReputationService
+	audit           *services.AuditService
+	jwtService      *auth.JWTService
+	cursors         *cursorCodec
+	oauth           *oauthLogin
 }
 
-func NewHandler(queries *sqlc.Queries, jwtService *auth.JWTService) *Handler {
+func NewHandler(store repository.Store, jwtService *auth.JWTService, sessionService *services.SessionService, leaderboard *services.LeaderboardService, reputation *services.ReputationService, mailer mail.Mailer, cfg *config.Config) *Handler {
+	authz := services.NewAuthorizer(store)
+	moderation := services.ModerationConfig{
+		RequireApproval: cfg.RequireReviewApproval,
+		FlagThreshold:   int32(cfg.ReviewFlagThreshold),
+
+		RequireVerifiedEmail: cfg.RequireVerifiedEmail,
+	}
+	logins := services.LoginPolicy{
+		AccountAttempts: cfg.LoginMaxAccountFailures,
+		IPAttempts:      cfg.LoginMaxIPFailures,
+		BaseLockout:     time.Duration(cfg.LoginLockoutSeconds) * time.Second,
+		MaxLockout:      time.Duration(cfg.LoginMaxLockoutMinutes) * time.Minute,
+	}
+	accounts := services.AccountConfig{
+		AppBaseURL:      cfg.AppBaseURL,
+		VerificationTTL: time.Duration(cfg.EmailVerificationTTLHours) * time.Hour,
+		ResetTTL:        time.Duration(cfg.PasswordResetTTLMinutes) * time.Minute,
+	}
+	userService := services.NewUserService(store)
+
 	return &Handler{
-		queries:        queries,
-		userService:    services.NewUserService(queries),
-		companyService: services.NewCompanyService(queries),
-		productService: services.NewProductService(queries),
-		reviewService:  services.NewReviewService(queries),
-		jwtService:     jwtService,
+		store:           store,
+		userService:     userService,
+		loginGuard:      services.NewLoginGuard(store, userService, logins),
+		accountService:  services.NewAccountService(store, mailer, accounts),
+		companyService:  services.NewCompanyService(store, authz),
+		productService:  services.NewProductService(store, authz),
+		pricingService:  services.NewPricingService(store, authz),
+		categoryService: services.NewCategoryService(store),
+		tagService:      services.NewTagService(store, authz),
+		reviewService:   services.NewReviewService(store, moderation),
+		commentService:  services.NewCommentService(store, authz, moderation),
+		sessionService:  sessionService,
+		leaderboard:     leaderboard,
+		reputation:      reputation,
+		audit:           services.NewAuditService(store),
+		jwtService:      jwtService,
+		cursors:         newCursorCodec(cfg.CursorSecret),
+		oauth:           newOAuthLogin(cfg),
+	}
+}
+
+// requestContext is the base context of handlers that change audited records. It ends with
+// the request and carries the request ID and client IP into the audit log.
+func requestContext(c echo.Context) context.Context {
+	return services.WithRequestInfo(c.Request().Context(), services.RequestInfo{
+		RequestID: c.Response().Header().Get(echo.HeaderXRequestID),
+		IPAddress: c.RealIP(),
+	})
+}
+
+// bindRequest decodes the request body into req and validates it.
+// Like every handler error, failures are rendered by the central HTTP error handler.
+func bindRequest(c echo.Context, req interface{}) error {
+	if err := c.Bind(req); err != nil {
+		return domain.Invalid("invalid_body", "invalid request body").Wrap(err)
 	}
+	return c.Validate(req)
 }
 
 func (h *Handler) HealthCheck(c echo.Context) error {
 	// Test database connectivity with 5-second timeout for health check
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := context.WithTimeout(requestContext(c), 5*time.Second)
 	defer cancel()
 
 	// Try a simple database query to check connectivity
-	_, err := h.queries.ListUsers(ctx, sqlc.ListUsersParams{
-		Limit:  1,
-		Offset: 0,
-	})
+	_, err := h.store.CountUsers(ctx)
 	dbStatus := "healthy"
 	if err != nil {
 		dbStatus = "unhealthy"
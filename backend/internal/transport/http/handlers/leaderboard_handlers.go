@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"ratemysoft-backend/internal/domain"
+	"ratemysoft-backend/internal/services"
+	"ratemysoft-backend/internal/transport/http/dto"
+
+	"github.com/labstack/echo/v4"
+)
+
+// GetLeaderboard ranks products by a confidence-adjusted score.
+// Query params: category (optional), window (30d, 90d, all; default all), score (bayesian, wilson; default bayesian)
+func (h *Handler) GetLeaderboard(c echo.Context) error {
+	window := c.QueryParam("window")
+	if window == "" {
+		window = string(domain.WindowAllTime)
+	}
+
+	score := c.QueryParam("score")
+	if score == "" {
+		score = string(domain.ScoreBayesian)
+	}
+
+	page, err := h.parsePageRequest(c)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(requestContext(c), 10*time.Second)
+	defer cancel()
+
+	result, err := h.leaderboard.GetLeaderboard(ctx, services.LeaderboardParams{
+		Window:   window,
+		Score:    score,
+		Category: c.QueryParam("category"),
+		Page:     page,
+	})
+	if err != nil {
+		return err
+	}
+
+	entries := make([]dto.LeaderboardEntryResponse, 0, len(result.Entries))
+	for _, entry := range result.Entries {
+		rankedBy := entry.BayesianScore
+		if result.Score == domain.ScoreWilson {
+			rankedBy = entry.WilsonScore
+		}
+
+		entries = append(entries, dto.LeaderboardEntryResponse{
+			Rank:          entry.Rank,
+			ProductID:     entry.ProductID.String(),
+			ProductName:   entry.ProductName,
+			ProductSlug:   string(entry.ProductSlug),
+			Category:      string(entry.Category),
+			CompanyName:   entry.CompanyName,
+			CompanySlug:   string(entry.CompanySlug),
+			ReviewCount:   entry.ReviewCount,
+			AvgRating:     entry.AvgRating,
+			Score:         rankedBy,
+			BayesianScore: entry.BayesianScore,
+			WilsonScore:   entry.WilsonScore,
+		})
+	}
+
+	next, prev := h.writePageLinks(c, result.Cursors)
+	return c.JSON(http.StatusOK, dto.LeaderboardResponse{
+		Window:      string(result.Window),
+		Score:       string(result.Score),
+		Category:    c.QueryParam("category"),
+		Entries:     entries,
+		Total:       result.Total,
+		Limit:       page.Limit,
+		Offset:      page.Offset,
+		NextCursor:  next,
+		PrevCursor:  prev,
+		RefreshedAt: result.RefreshedAt,
+	})
+}
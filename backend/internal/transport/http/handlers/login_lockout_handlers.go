@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"ratemysoft-backend/internal/transport/http/dto"
+
+	"github.com/labstack/echo/v4"
+)
+
+// ListLockedAccounts lists the accounts locked after too many failed sign-ins (admin only)
+func (h *Handler) ListLockedAccounts(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(requestContext(c), 10*time.Second)
+	defer cancel()
+
+	accounts, err := h.loginGuard.ListLockedAccounts(ctx)
+	if err != nil {
+		return err
+	}
+
+	responses := make([]dto.LockedAccountResponse, 0, len(accounts))
+	for _, account := range accounts {
+		responses = append(responses, dto.LockedAccountResponse{
+			UserID:         account.UserID.String(),
+			Handle:         account.Handle,
+			Email:          string(account.Email),
+			FailedAttempts: account.FailedAttempts,
+			LastFailureAt:  account.LastFailureAt,
+			LockedUntil:    account.LockedUntil,
+		})
+	}
+
+	return c.JSON(http.StatusOK, dto.LockedAccountListResponse{Accounts: responses})
+}
+
+// UnlockAccount lets a locked account sign in again and forgets its failed attempts (admin only)
+func (h *Handler) UnlockAccount(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(requestContext(c), 10*time.Second)
+	defer cancel()
+
+	if err := h.loginGuard.UnlockAccount(ctx, c.Param("id")); err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{
+		"message": "Account unlocked",
+	})
+}
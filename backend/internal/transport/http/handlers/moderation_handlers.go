@@ -0,0 +1,229 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"ratemysoft-backend/internal/auth"
+	"ratemysoft-backend/internal/domain"
+	"ratemysoft-backend/internal/services"
+	"ratemysoft-backend/internal/transport/http/dto"
+
+	"github.com/labstack/echo/v4"
+)
+
+// ListPendingReviews retrieves the queue of reviews awaiting approval, by cursor or offset (admin only)
+func (h *Handler) ListPendingReviews(c echo.Context) error {
+	page, err := h.parsePageRequest(c)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(requestContext(c), 10*time.Second)
+	defer cancel()
+
+	total, err := h.reviewService.CountPendingReviews(ctx)
+	if err != nil {
+		return err
+	}
+
+	items, cursors, err := h.reviewService.ListPendingReviews(ctx, page)
+	if err != nil {
+		return err
+	}
+
+	next, prev := h.writePageLinks(c, cursors)
+	return c.JSON(http.StatusOK, dto.ModerationQueueResponse{
+		Reviews:    toModerationQueueResponses(items),
+		Total:      total,
+		Limit:      page.Limit,
+		Offset:     page.Offset,
+		NextCursor: next,
+		PrevCursor: prev,
+	})
+}
+
+// ListFlaggedReviews retrieves the queue of reviews at or above the flag threshold, by cursor or offset (admin only)
+func (h *Handler) ListFlaggedReviews(c echo.Context) error {
+	page, err := h.parsePageRequest(c)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(requestContext(c), 10*time.Second)
+	defer cancel()
+
+	total, err := h.reviewService.CountFlaggedReviews(ctx)
+	if err != nil {
+		return err
+	}
+
+	items, cursors, err := h.reviewService.ListFlaggedReviews(ctx, page)
+	if err != nil {
+		return err
+	}
+
+	next, prev := h.writePageLinks(c, cursors)
+	return c.JSON(http.StatusOK, dto.ModerationQueueResponse{
+		Reviews:    toModerationQueueResponses(items),
+		Total:      total,
+		Limit:      page.Limit,
+		Offset:     page.Offset,
+		NextCursor: next,
+		PrevCursor: prev,
+	})
+}
+
+// ApproveReview publishes a review and clears its flags (admin only)
+func (h *Handler) ApproveReview(c echo.Context) error {
+	return h.moderateReview(c, domain.ReviewPublished)
+}
+
+// RejectReview rejects a review with a reason (admin only)
+func (h *Handler) RejectReview(c echo.Context) error {
+	return h.moderateReview(c, domain.ReviewRejected)
+}
+
+// GetModerationHistory retrieves all moderation decisions for a review (admin only)
+func (h *Handler) GetModerationHistory(c echo.Context) error {
+	reviewID := c.Param("id")
+
+	ctx, cancel := context.WithTimeout(requestContext(c), 5*time.Second)
+	defer cancel()
+
+	history, err := h.reviewService.GetModerationHistory(ctx, reviewID)
+	if err != nil {
+		return err
+	}
+
+	responses := make([]dto.ReviewModerationResponse, 0, len(history))
+	for _, m := range history {
+		var commentID string
+		if m.CommentID != nil {
+			commentID = m.CommentID.String()
+		}
+		responses = append(responses, dto.ReviewModerationResponse{
+			ID:              m.ID.String(),
+			ReviewID:        m.ReviewID.String(),
+			CommentID:       commentID,
+			ModeratorID:     m.ModeratorID.String(),
+			ModeratorHandle: m.ModeratorHandle,
+			FromStatus:      string(m.FromStatus),
+			ToStatus:        string(m.ToStatus),
+			Reason:          m.Reason,
+			CreatedAt:       m.CreatedAt,
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"review_id":   reviewID,
+		"moderations": responses,
+	})
+}
+
+// GetReviewFlags retrieves every user's flag on a review with its reason (admin only)
+func (h *Handler) GetReviewFlags(c echo.Context) error {
+	reviewID := c.Param("id")
+
+	ctx, cancel := context.WithTimeout(requestContext(c), 5*time.Second)
+	defer cancel()
+
+	flags, err := h.reviewService.GetReviewFlags(ctx, reviewID)
+	if err != nil {
+		return err
+	}
+
+	responses := make([]dto.ReviewFlagResponse, 0, len(flags))
+	for _, f := range flags {
+		responses = append(responses, dto.ReviewFlagResponse{
+			ReviewID:   f.ReviewID.String(),
+			UserID:     f.UserID.String(),
+			UserHandle: f.UserHandle,
+			Reason:     string(f.Reason),
+			Note:       f.Note,
+			CreatedAt:  f.CreatedAt,
+			UpdatedAt:  f.UpdatedAt,
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"review_id": reviewID,
+		"flags":     responses,
+	})
+}
+
+// moderateReview applies an approve or reject decision on behalf of the authenticated admin
+func (h *Handler) moderateReview(c echo.Context, to domain.ReviewStatus) error {
+	reviewID := c.Param("id")
+
+	moderatorID, err := auth.GetUserIDFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.ModerateReviewRequest
+	if err := bindRequest(c, &req); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(requestContext(c), 10*time.Second)
+	defer cancel()
+
+	var review *domain.Review
+	if to == domain.ReviewPublished {
+		review, err = h.reviewService.ApproveReview(ctx, reviewID, moderatorID.String(), req.Reason)
+	} else {
+		review, err = h.reviewService.RejectReview(ctx, reviewID, moderatorID.String(), req.Reason)
+	}
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.ReviewResponse{
+		ID:           review.ID.String(),
+		ProductID:    review.ProductID.String(),
+		UserID:       review.UserID.String(),
+		Title:        review.Title,
+		Body:         review.Body,
+		Rating:       int(review.Rating),
+		SubRatings:   toSubRatingsResponse(review.SubRatings),
+		Status:       string(review.Status),
+		HelpfulCount: review.HelpfulCount,
+		FlagCount:    review.FlagCount,
+		CommentCount: review.CommentCount,
+		Edited:       review.Edited,
+		CreatedAt:    review.CreatedAt,
+		UpdatedAt:    review.UpdatedAt,
+		DeletedAt:    review.DeletedAt,
+	})
+}
+
+func toModerationQueueResponses(items []*services.ModerationQueueItem) []dto.ModerationQueueItemResponse {
+	responses := make([]dto.ModerationQueueItemResponse, 0, len(items))
+	for _, item := range items {
+		review := item.Review
+		responses = append(responses, dto.ModerationQueueItemResponse{
+			ReviewResponse: dto.ReviewResponse{
+				ID:           review.ID.String(),
+				ProductID:    review.ProductID.String(),
+				UserID:       review.UserID.String(),
+				Title:        review.Title,
+				Body:         review.Body,
+				Rating:       int(review.Rating),
+				SubRatings:   toSubRatingsResponse(review.SubRatings),
+				Status:       string(review.Status),
+				HelpfulCount: review.HelpfulCount,
+				FlagCount:    review.FlagCount,
+				CommentCount: review.CommentCount,
+				Edited:       review.Edited,
+				CreatedAt:    review.CreatedAt,
+				UpdatedAt:    review.UpdatedAt,
+				DeletedAt:    review.DeletedAt,
+			},
+			UserHandle:  item.UserHandle,
+			ProductName: item.ProductName,
+		})
+	}
+	return responses
+}
@@ -0,0 +1,214 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"ratemysoft-backend/internal/auth/oauth"
+	"ratemysoft-backend/internal/domain"
+	"ratemysoft-backend/internal/platform/config"
+	"ratemysoft-backend/internal/services"
+	"ratemysoft-backend/internal/transport/http/dto"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	// oauthFlowCookie carries the signed login flow from the start to the callback
+	oauthFlowCookie = "oauth_flow"
+
+	// oauthFlowTTL is how long the user has to finish signing in at the provider
+	oauthFlowTTL = 10 * time.Minute
+)
+
+var errInvalidOAuthState = domain.Invalid("invalid_oauth_state", "login attempt expired or did not start here; please sign in again")
+
+// oauthLogin holds what the external login routes need besides the services
+type oauthLogin struct {
+	providers       *oauth.Registry
+	flows           *oauth.FlowCodec
+	redirectBaseURL string
+	successURL      string
+}
+
+// newOAuthLogin enables the providers that have a client ID configured
+func newOAuthLogin(cfg *config.Config) *oauthLogin {
+	var providers []oauth.Provider
+	if cfg.GitHubClientID != "" {
+		providers = append(providers, oauth.NewGitHub(oauth.GitHubConfig{
+			ClientID:     cfg.GitHubClientID,
+			ClientSecret: cfg.GitHubClientSecret,
+		}))
+	}
+	if cfg.GoogleClientID != "" {
+		providers = append(providers, oauth.NewGoogle(cfg.GoogleClientID, cfg.GoogleClientSecret))
+	}
+	if cfg.OIDCClientID != "" && cfg.OIDCIssuerURL != "" {
+		providers = append(providers, oauth.NewOIDC(oauth.OIDCConfig{
+			Name:         cfg.OIDCProviderName,
+			IssuerURL:    cfg.OIDCIssuerURL,
+			ClientID:     cfg.OIDCClientID,
+			ClientSecret: cfg.OIDCClientSecret,
+		}))
+	}
+
+	secret := cfg.OAuthStateSecret
+	if secret == "" {
+		secret = cfg.JWTSecret
+	}
+	return &oauthLogin{
+		providers:       oauth.NewRegistry(providers...),
+		flows:           oauth.NewFlowCodec(secret),
+		redirectBaseURL: strings.TrimSuffix(cfg.OAuthRedirectBaseURL, "/"),
+		successURL:      cfg.OAuthSuccessRedirectURL,
+	}
+}
+
+// ListOAuthProviders returns the names of the external providers users can sign in with
+func (h *Handler) ListOAuthProviders(c echo.Context) error {
+	return c.JSON(http.StatusOK, dto.OAuthProvidersResponse{Providers: h.oauth.providers.Names()})
+}
+
+// StartOAuthLogin redirects the browser to the provider, remembering the flow in a cookie
+func (h *Handler) StartOAuthLogin(c echo.Context) error {
+	provider, err := h.oauthProvider(c)
+	if err != nil {
+		return err
+	}
+
+	flow, err := oauth.NewFlow(provider.Name(), oauthFlowTTL)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(requestContext(c), 10*time.Second)
+	defer cancel()
+
+	authURL, err := provider.AuthURL(ctx, oauth.AuthRequest{
+		RedirectURL:   h.oauthCallbackURL(c),
+		State:         flow.State,
+		CodeChallenge: flow.CodeChallenge(),
+		Nonce:         flow.Nonce,
+	})
+	if err != nil {
+		return err
+	}
+
+	h.setOAuthCookie(c, h.oauth.flows.Encode(flow), int(oauthFlowTTL.Seconds()))
+	return c.Redirect(http.StatusFound, authURL)
+}
+
+// OAuthCallback completes a login: it checks the state against the flow cookie, redeems the
+// code and signs the user in, linking or creating the account as needed
+func (h *Handler) OAuthCallback(c echo.Context) error {
+	provider, err := h.oauthProvider(c)
+	if err != nil {
+		return err
+	}
+
+	// The flow is single use whatever the outcome
+	cookie, cookieErr := c.Cookie(oauthFlowCookie)
+	h.setOAuthCookie(c, "", -1)
+
+	if c.QueryParam("error") != "" {
+		return domain.Unauthorized("oauth_denied", "sign-in was cancelled or refused at the provider")
+	}
+	if cookieErr != nil {
+		return errInvalidOAuthState.Wrap(cookieErr)
+	}
+	flow, err := h.oauth.flows.Decode(cookie.Value, time.Now())
+	if err != nil {
+		return errInvalidOAuthState.Wrap(err)
+	}
+	state := c.QueryParam("state")
+	if flow.Provider != provider.Name() || state == "" || state != flow.State {
+		return errInvalidOAuthState
+	}
+
+	ctx, cancel := context.WithTimeout(requestContext(c), 15*time.Second)
+	defer cancel()
+
+	identity, err := provider.Exchange(ctx, oauth.ExchangeRequest{
+		RedirectURL:  h.oauthCallbackURL(c),
+		Code:         c.QueryParam("code"),
+		CodeVerifier: flow.CodeVerifier,
+		Nonce:        flow.Nonce,
+	})
+	if err != nil {
+		return domain.Unauthorized("oauth_failed", "could not verify the sign-in with the provider").Wrap(err)
+	}
+
+	user, err := h.userService.AuthenticateExternal(ctx, services.ExternalIdentity{
+		Provider:      provider.Name(),
+		Subject:       identity.Subject,
+		Email:         strings.ToLower(strings.TrimSpace(identity.Email)),
+		EmailVerified: identity.EmailVerified,
+		Username:      identity.Username,
+	})
+	if err != nil {
+		return err
+	}
+
+	if h.oauth.successURL == "" {
+		return h.startSession(ctx, c, http.StatusOK, user)
+	}
+
+	// Browser flows hand the tokens to the frontend in the fragment, which never reaches a server
+	session, refreshToken, err := h.sessionService.StartSession(ctx, user.ID, services.SessionMetadata{
+		UserAgent: c.Request().UserAgent(),
+		IPAddress: c.RealIP(),
+	})
+	if err != nil {
+		return err
+	}
+	token, err := h.jwtService.GenerateToken(user, session.ID)
+	if err != nil {
+		return err
+	}
+	fragment := url.Values{
+		"token":         {token},
+		"refresh_token": {refreshToken},
+		"expires_in":    {strconv.FormatInt(int64(h.jwtService.AccessTokenExpiry().Seconds()), 10)},
+	}
+	return c.Redirect(http.StatusFound, h.oauth.successURL+"#"+fragment.Encode())
+}
+
+func (h *Handler) oauthProvider(c echo.Context) (oauth.Provider, error) {
+	name := c.Param("provider")
+	provider, ok := h.oauth.providers.Get(name)
+	if !ok {
+		return nil, domain.NotFound("unknown_provider", "no sign-in provider named "+strconv.Quote(name))
+	}
+	return provider, nil
+}
+
+// oauthCallbackURL is the callback route of the provider in the current request, as the
+// provider must redirect to it
+func (h *Handler) oauthCallbackURL(c echo.Context) string {
+	base := h.oauth.redirectBaseURL
+	if base == "" {
+		base = c.Scheme() + "://" + c.Request().Host
+	}
+	path := strings.TrimSuffix(c.Request().URL.Path, "/start")
+	path = strings.TrimSuffix(path, "/callback")
+	return base + path + "/callback"
+}
+
+// setOAuthCookie writes the flow cookie; a negative maxAge deletes it
+func (h *Handler) setOAuthCookie(c echo.Context, value string, maxAge int) {
+	path := c.Request().URL.Path
+	path = path[:strings.LastIndex(path, "/")]
+	c.SetCookie(&http.Cookie{
+		Name:     oauthFlowCookie,
+		Value:    value,
+		Path:     path, // only sent to this provider's callback
+		MaxAge:   maxAge,
+		HttpOnly: true,
+		Secure:   c.Scheme() == "https",
+		SameSite: http.SameSiteLaxMode, // the callback is a top-level navigation from the provider
+	})
+}
@@ -0,0 +1,152 @@
+package handlers
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"ratemysoft-backend/internal/domain"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// cursorCodec turns page cursors into opaque tokens and back. Tokens are signed so
+// clients cannot forge a boundary; they are not encrypted and hold only sort key values.
+type cursorCodec struct {
+	key []byte
+}
+
+func newCursorCodec(secret string) *cursorCodec {
+	return &cursorCodec{key: []byte(secret)}
+}
+
+// cursorPayload is the JSON form of a domain.Cursor inside a token
+type cursorPayload struct {
+	Sort     string    `json:"s"`
+	Keys     []string  `json:"k"`
+	ID       uuid.UUID `json:"id"`
+	Backward bool      `json:"b,omitempty"`
+}
+
+// encode returns "<payload>.<signature>", both base64url; a nil cursor encodes to ""
+func (cc *cursorCodec) encode(cursor *domain.Cursor) string {
+	if cursor == nil {
+		return ""
+	}
+	payload, _ := json.Marshal(cursorPayload{
+		Sort:     cursor.Sort,
+		Keys:     cursor.Keys,
+		ID:       cursor.ID,
+		Backward: cursor.Backward,
+	})
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	return encoded + "." + base64.RawURLEncoding.EncodeToString(cc.sign(encoded))
+}
+
+func (cc *cursorCodec) decode(token string) (*domain.Cursor, error) {
+	invalid := domain.InvalidField("invalid_cursor", "cursor", "cursor is malformed or has been tampered with")
+
+	encoded, signature, ok := strings.Cut(token, ".")
+	if !ok {
+		return nil, invalid
+	}
+	mac, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(mac, cc.sign(encoded)) {
+		return nil, invalid
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, invalid.Wrap(err)
+	}
+	var p cursorPayload
+	if err := json.Unmarshal(payload, &p); err != nil {
+		return nil, invalid.Wrap(err)
+	}
+
+	return &domain.Cursor{
+		Sort:     p.Sort,
+		Keys:     p.Keys,
+		ID:       p.ID,
+		Backward: p.Backward,
+	}, nil
+}
+
+func (cc *cursorCodec) sign(encoded string) []byte {
+	mac := hmac.New(sha256.New, cc.key)
+	mac.Write([]byte("cursor." + encoded))
+	return mac.Sum(nil)
+}
+
+// parsePagination reads the limit and offset query parameters
+func parsePagination(c echo.Context) (int32, int32) {
+	limit := int32(50) // default
+	if l := c.QueryParam("limit"); l != "" {
+		if parsed, err := strconv.ParseInt(l, 10, 32); err == nil && parsed > 0 {
+			limit = int32(parsed)
+			if limit > 100 {
+				limit = 100 // max limit
+			}
+		}
+	}
+
+	offset := int32(0) // default
+	if o := c.QueryParam("offset"); o != "" {
+		if parsed, err := strconv.ParseInt(o, 10, 32); err == nil && parsed >= 0 {
+			offset = int32(parsed)
+		}
+	}
+
+	return limit, offset
+}
+
+// parsePageRequest reads limit plus either cursor or, for older clients, offset.
+// A cursor takes precedence, in which case the offset is reported as 0.
+func (h *Handler) parsePageRequest(c echo.Context) (domain.PageRequest, error) {
+	limit, offset := parsePagination(c)
+	page := domain.PageRequest{Limit: limit, Offset: offset}
+
+	if token := c.QueryParam("cursor"); token != "" {
+		cursor, err := h.cursors.decode(token)
+		if err != nil {
+			return page, err
+		}
+		page.Cursor = cursor
+		page.Offset = 0
+	}
+	return page, nil
+}
+
+// writePageLinks sets an RFC 8288 Link header pointing at the neighbouring pages
+// and returns their cursor tokens for the response body
+func (h *Handler) writePageLinks(c echo.Context, cursors domain.PageCursors) (next, prev string) {
+	next = h.cursors.encode(cursors.Next)
+	prev = h.cursors.encode(cursors.Prev)
+
+	var links []string
+	if next != "" {
+		links = append(links, pageLink(c, next, "next"))
+	}
+	if prev != "" {
+		links = append(links, pageLink(c, prev, "prev"))
+	}
+	if len(links) > 0 {
+		c.Response().Header().Set("Link", strings.Join(links, ", "))
+	}
+	return next, prev
+}
+
+// pageLink repeats the current request with the cursor in place of any offset
+func pageLink(c echo.Context, token, rel string) string {
+	u := *c.Request().URL
+	query := u.Query()
+	query.Del("offset")
+	query.Set("cursor", token)
+	u.RawQuery = query.Encode()
+	return fmt.Sprintf(`<%s>; rel="%s"`, u.RequestURI(), rel)
+}
@@ -0,0 +1,227 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"ratemysoft-backend/internal/auth"
+	"ratemysoft-backend/internal/domain"
+	"ratemysoft-backend/internal/services"
+	"ratemysoft-backend/internal/transport/http/dto"
+
+	"github.com/labstack/echo/v4"
+)
+
+// ListPricingPlans returns a product's pricing plans
+func (h *Handler) ListPricingPlans(c echo.Context) error {
+	productID := c.Param("id")
+
+	ctx, cancel := context.WithTimeout(requestContext(c), 5*time.Second)
+	defer cancel()
+
+	plans, err := h.pricingService.ListPlans(ctx, productID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.PricingPlanListResponse{
+		Plans: toPricingPlanResponses(plans),
+		Count: len(plans),
+	})
+}
+
+// CreatePricingPlan adds a pricing plan to a product
+func (h *Handler) CreatePricingPlan(c echo.Context) error {
+	productID := c.Param("id")
+
+	actor, err := auth.GetActorFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.PricingPlanRequest
+	if err := bindRequest(c, &req); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(requestContext(c), 10*time.Second)
+	defer cancel()
+
+	plan, err := h.pricingService.CreatePlan(ctx, actor, productID, toPricingPlanRequest(req))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusCreated, toPricingPlanResponse(plan))
+}
+
+// UpdatePricingPlan replaces a pricing plan of a product
+func (h *Handler) UpdatePricingPlan(c echo.Context) error {
+	productID := c.Param("id")
+	planID := c.Param("planId")
+
+	actor, err := auth.GetActorFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.PricingPlanRequest
+	if err := bindRequest(c, &req); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(requestContext(c), 10*time.Second)
+	defer cancel()
+
+	plan, err := h.pricingService.UpdatePlan(ctx, actor, productID, planID, toPricingPlanRequest(req))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, toPricingPlanResponse(plan))
+}
+
+// DeletePricingPlan removes a pricing plan from a product
+func (h *Handler) DeletePricingPlan(c echo.Context) error {
+	productID := c.Param("id")
+	planID := c.Param("planId")
+
+	actor, err := auth.GetActorFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(requestContext(c), 10*time.Second)
+	defer cancel()
+
+	if err := h.pricingService.DeletePlan(ctx, actor, productID, planID); err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{
+		"message": "Pricing plan deleted successfully",
+	})
+}
+
+// ComparePricing lines up the pricing plans and ratings of several products.
+// Query params: products (comma-separated product IDs or slugs, 2 to 5)
+func (h *Handler) ComparePricing(c echo.Context) error {
+	products := c.QueryParam("products")
+	if products == "" {
+		return domain.InvalidField("missing_products", "products", "a comma-separated list of products is required")
+	}
+
+	ctx, cancel := context.WithTimeout(requestContext(c), 10*time.Second)
+	defer cancel()
+
+	comparison, err := h.pricingService.ComparePricing(ctx, strings.Split(products, ","))
+	if err != nil {
+		return err
+	}
+
+	response := dto.PricingComparisonResponse{
+		Products: make([]dto.ProductPricingResponse, 0, len(comparison.Products)),
+		Features: make([]dto.FeatureComparisonResponse, 0, len(comparison.Features)),
+	}
+
+	for _, entry := range comparison.Products {
+		product := entry.Product
+		response.Products = append(response.Products, dto.ProductPricingResponse{
+			Product: dto.ProductWithCompanyResponse{
+				ProductResponse: dto.ProductResponse{
+					ID:           product.ID.String(),
+					CompanyID:    product.CompanyID.String(),
+					Name:         product.Name,
+					Slug:         string(product.Slug),
+					Category:     string(product.Category),
+					ShortTagline: product.ShortTagline,
+					Description:  product.Description,
+					HomepageURL:  product.HomepageURL,
+					DocsURL:      product.DocsURL,
+					AvgRating:    product.AvgRating,
+					TotalReviews: product.TotalReviews,
+					CreatedAt:    product.CreatedAt,
+					UpdatedAt:    product.UpdatedAt,
+					DeletedAt:    product.DeletedAt,
+				},
+				CompanyName: entry.CompanyName,
+				CompanySlug: entry.CompanySlug,
+			},
+			Plans:                     toPricingPlanResponses(entry.Plans),
+			HasFreeTier:               entry.HasFreeTier,
+			StartingMonthlyPriceCents: entry.StartingMonthlyPriceCents,
+			StartingCurrency:          string(entry.StartingCurrency),
+		})
+	}
+
+	for _, feature := range comparison.Features {
+		available := make([]bool, len(comparison.Products))
+		for i, entry := range comparison.Products {
+			available[i] = offersFeature(entry.Plans, feature)
+		}
+		response.Features = append(response.Features, dto.FeatureComparisonResponse{
+			Feature:   feature,
+			Available: available,
+		})
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+func toPricingPlanRequest(req dto.PricingPlanRequest) services.PricingPlanRequest {
+	return services.PricingPlanRequest{
+		Name:          strings.TrimSpace(req.Name),
+		BillingPeriod: req.BillingPeriod,
+		Currency:      strings.TrimSpace(req.Currency),
+		PriceCents:    req.PriceCents,
+		IsFree:        req.IsFree,
+		PricingUnit:   req.PricingUnit,
+		UsageUnit:     strings.TrimSpace(req.UsageUnit),
+		Features:      req.Features,
+		SortOrder:     req.SortOrder,
+	}
+}
+
+func toPricingPlanResponse(plan *domain.PricingPlan) dto.PricingPlanResponse {
+	response := dto.PricingPlanResponse{
+		ID:            plan.ID.String(),
+		ProductID:     plan.ProductID.String(),
+		Name:          plan.Name,
+		BillingPeriod: string(plan.BillingPeriod),
+		Currency:      string(plan.Currency),
+		PriceCents:    plan.PriceCents,
+		IsFree:        plan.IsFree,
+		PricingUnit:   string(plan.PricingUnit),
+		UsageUnit:     plan.UsageUnit,
+		Features:      plan.Features,
+		SortOrder:     plan.SortOrder,
+		CreatedAt:     plan.CreatedAt,
+		UpdatedAt:     plan.UpdatedAt,
+	}
+	if monthly, ok := plan.MonthlyPriceCents(); ok {
+		response.MonthlyPriceCents = &monthly
+	}
+	return response
+}
+
+func toPricingPlanResponses(plans []*domain.PricingPlan) []dto.PricingPlanResponse {
+	responses := make([]dto.PricingPlanResponse, 0, len(plans))
+	for _, plan := range plans {
+		responses = append(responses, toPricingPlanResponse(plan))
+	}
+	return responses
+}
+
+// offersFeature reports whether any of the plans lists the feature
+func offersFeature(plans []*domain.PricingPlan, feature string) bool {
+	for _, plan := range plans {
+		for _, f := range plan.Features {
+			if f == feature {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -7,6 +7,8 @@ import (
 	"strings"
 	"time"
 
+	"ratemysoft-backend/internal/auth"
+	"ratemysoft-backend/internal/domain"
 	"ratemysoft-backend/internal/services"
 	"ratemysoft-backend/internal/transport/http/dto"
 
@@ -15,25 +17,20 @@ import (
 
 // CreateProduct creates a new product
 func (h *Handler) CreateProduct(c echo.Context) error {
-	var req dto.CreateProductRequest
-
-	if err := c.Bind(&req); err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]string{
-			"error": "Invalid request body",
-		})
+	actor, err := auth.GetActorFromContext(c)
+	if err != nil {
+		return err
 	}
 
-	if err := c.Validate(&req); err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]string{
-			"error":   "Validation failed",
-			"details": err.Error(),
-		})
+	var req dto.CreateProductRequest
+	if err := bindRequest(c, &req); err != nil {
+		return err
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(requestContext(c), 10*time.Second)
 	defer cancel()
 
-	product, err := h.productService.CreateProduct(ctx, services.CreateProductRequest{
+	product, err := h.productService.CreateProduct(ctx, actor, services.CreateProductRequest{
 		CompanyID:    req.CompanyID,
 		Name:         strings.TrimSpace(req.Name),
 		Slug:         strings.TrimSpace(req.Slug),
@@ -44,20 +41,7 @@ func (h *Handler) CreateProduct(c echo.Context) error {
 		DocsURL:      strings.TrimSpace(req.DocsURL),
 	})
 	if err != nil {
-		if strings.Contains(err.Error(), "company not found") {
-			return c.JSON(http.StatusNotFound, map[string]string{
-				"error": "Company not found",
-			})
-		}
-		if strings.Contains(err.Error(), "invalid") {
-			return c.JSON(http.StatusBadRequest, map[string]string{
-				"error": err.Error(),
-			})
-		}
-		return c.JSON(http.StatusInternalServerError, map[string]string{
-			"error":   "Failed to create product",
-			"details": err.Error(),
-		})
+		return err
 	}
 
 	return c.JSON(http.StatusCreated, dto.ProductResponse{
@@ -82,24 +66,12 @@ func (h *Handler) CreateProduct(c echo.Context) error {
 func (h *Handler) GetProduct(c echo.Context) error {
 	productID := c.Param("id")
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := context.WithTimeout(requestContext(c), 5*time.Second)
 	defer cancel()
 
 	product, err := h.productService.GetProductByID(ctx, productID)
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
-			return c.JSON(http.StatusNotFound, map[string]string{
-				"error": "Product not found",
-			})
-		}
-		if strings.Contains(err.Error(), "invalid") {
-			return c.JSON(http.StatusBadRequest, map[string]string{
-				"error": "Invalid product ID",
-			})
-		}
-		return c.JSON(http.StatusInternalServerError, map[string]string{
-			"error": "Failed to get product",
-		})
+		return err
 	}
 
 	return c.JSON(http.StatusOK, dto.ProductResponse{
@@ -114,34 +86,112 @@ func (h *Handler) GetProduct(c echo.Context) error {
 		DocsURL:      product.DocsURL,
 		AvgRating:    product.AvgRating,
 		TotalReviews: product.TotalReviews,
+		Tags:         toTagResponses(product.Tags),
 		CreatedAt:    product.CreatedAt,
 		UpdatedAt:    product.UpdatedAt,
 		DeletedAt:    product.DeletedAt,
 	})
 }
 
+// GetProductStats returns the star histogram and sub-rating averages of a product
+func (h *Handler) GetProductStats(c echo.Context) error {
+	productID := c.Param("id")
+
+	ctx, cancel := context.WithTimeout(requestContext(c), 5*time.Second)
+	defer cancel()
+
+	stats, err := h.productService.GetProductStats(ctx, productID)
+	if err != nil {
+		return err
+	}
+
+	histogram := make(map[int]int, len(stats.Histogram))
+	for i, count := range stats.Histogram {
+		histogram[i+1] = count
+	}
+
+	dimensions := make([]dto.DimensionStatResponse, 0, len(stats.Dimensions))
+	for _, d := range stats.Dimensions {
+		dimensions = append(dimensions, dto.DimensionStatResponse{
+			Dimension: string(d.Dimension),
+			AvgRating: d.AvgRating,
+			Count:     d.Count,
+		})
+	}
+
+	return c.JSON(http.StatusOK, dto.ProductStatsResponse{
+		ProductID:    stats.ProductID.String(),
+		AvgRating:    stats.AvgRating,
+		TotalReviews: stats.TotalReviews,
+		Histogram:    histogram,
+		Dimensions:   dimensions,
+		UpdatedAt:    stats.UpdatedAt,
+	})
+}
+
+// GetProductRatingEvents retrieves the rating changes behind a product's stats, newest first
+func (h *Handler) GetProductRatingEvents(c echo.Context) error {
+	productID := c.Param("id")
+
+	page, err := h.parsePageRequest(c)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(requestContext(c), 10*time.Second)
+	defer cancel()
+
+	total, err := h.reviewService.CountProductRatingEvents(ctx, productID)
+	if err != nil {
+		return err
+	}
+
+	events, cursors, err := h.reviewService.GetProductRatingEvents(ctx, productID, page)
+	if err != nil {
+		return err
+	}
+
+	responses := make([]dto.RatingEventResponse, 0, len(events))
+	for _, event := range events {
+		response := dto.RatingEventResponse{
+			ID:        event.ID.String(),
+			ReviewID:  event.ReviewID.String(),
+			Kind:      string(event.Kind),
+			CreatedAt: event.CreatedAt,
+		}
+		if event.FromRating != nil {
+			from := int(*event.FromRating)
+			response.FromRating = &from
+		}
+		if event.ToRating != nil {
+			to := int(*event.ToRating)
+			response.ToRating = &to
+		}
+		responses = append(responses, response)
+	}
+
+	next, prev := h.writePageLinks(c, cursors)
+	return c.JSON(http.StatusOK, dto.RatingEventListResponse{
+		ProductID:  productID,
+		Events:     responses,
+		Total:      total,
+		Limit:      page.Limit,
+		Offset:     page.Offset,
+		NextCursor: next,
+		PrevCursor: prev,
+	})
+}
+
 // GetProductBySlug retrieves a product by slug
 func (h *Handler) GetProductBySlug(c echo.Context) error {
 	slug := c.Param("slug")
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := context.WithTimeout(requestContext(c), 5*time.Second)
 	defer cancel()
 
 	product, companyName, companySlug, err := h.productService.GetProductBySlug(ctx, slug)
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
-			return c.JSON(http.StatusNotFound, map[string]string{
-				"error": "Product not found",
-			})
-		}
-		if strings.Contains(err.Error(), "invalid") {
-			return c.JSON(http.StatusBadRequest, map[string]string{
-				"error": "Invalid slug format",
-			})
-		}
-		return c.JSON(http.StatusInternalServerError, map[string]string{
-			"error": "Failed to get product",
-		})
+		return err
 	}
 
 	response := dto.ProductWithCompanyResponse{
@@ -157,6 +207,7 @@ func (h *Handler) GetProductBySlug(c echo.Context) error {
 			DocsURL:      product.DocsURL,
 			AvgRating:    product.AvgRating,
 			TotalReviews: product.TotalReviews,
+			Tags:         toTagResponses(product.Tags),
 			CreatedAt:    product.CreatedAt,
 			UpdatedAt:    product.UpdatedAt,
 			DeletedAt:    product.DeletedAt,
@@ -173,248 +224,236 @@ func (h *Handler) GetProductBySlug(c echo.Context) error {
 	return c.JSON(http.StatusOK, response)
 }
 
-// ListProducts retrieves a paginated list of products
+// ListProducts retrieves a page of products. Filters (category, company, min_rating, min_reviews,
+// tags, created_after) combine with AND; sort is one of newest, rating, review_count, name or trending.
 func (h *Handler) ListProducts(c echo.Context) error {
-	// Parse pagination parameters
-	limit := int32(50) // default
-	if l := c.QueryParam("limit"); l != "" {
-		if parsed, err := strconv.ParseInt(l, 10, 32); err == nil && parsed > 0 {
-			limit = int32(parsed)
-			if limit > 100 {
-				limit = 100 // max limit
-			}
-		}
+	params, err := parseProductListParams(c)
+	if err != nil {
+		return err
 	}
-
-	offset := int32(0) // default
-	if o := c.QueryParam("offset"); o != "" {
-		if parsed, err := strconv.ParseInt(o, 10, 32); err == nil && parsed >= 0 {
-			offset = int32(parsed)
-		}
+	if params.Page, err = h.parsePageRequest(c); err != nil {
+		return err
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(requestContext(c), 10*time.Second)
 	defer cancel()
 
-	// Get total count
-	total, err := h.productService.CountProducts(ctx)
+	result, err := h.productService.ListProducts(ctx, params)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{
-			"error": "Failed to count products",
-		})
-	}
-
-	// Get products
-	products, err := h.productService.ListProducts(ctx, limit, offset)
-	if err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{
-			"error": "Failed to list products",
-		})
-	}
-
-	// Convert to response DTOs
-	productResponses := make([]dto.ProductResponse, 0, len(products))
-	for _, product := range products {
-		productResponses = append(productResponses, dto.ProductResponse{
-			ID:           product.ID.String(),
-			CompanyID:    product.CompanyID.String(),
-			Name:         product.Name,
-			Slug:         string(product.Slug),
-			Category:     string(product.Category),
-			ShortTagline: product.ShortTagline,
-			Description:  product.Description,
-			HomepageURL:  product.HomepageURL,
-			DocsURL:      product.DocsURL,
-			AvgRating:    product.AvgRating,
-			TotalReviews: product.TotalReviews,
-			CreatedAt:    product.CreatedAt,
-			UpdatedAt:    product.UpdatedAt,
-			DeletedAt:    product.DeletedAt,
-		})
+		return err
 	}
 
+	next, prev := h.writePageLinks(c, result.Cursors)
 	return c.JSON(http.StatusOK, dto.ProductListResponse{
-		Products: productResponses,
-		Total:    total,
-		Limit:    limit,
-		Offset:   offset,
+		Products:   toProductResponses(result.Products),
+		Total:      result.Total,
+		Sort:       string(result.Sort),
+		Limit:      params.Page.Limit,
+		Offset:     params.Page.Offset,
+		NextCursor: next,
+		PrevCursor: prev,
 	})
 }
 
-// ListProductsByCategory retrieves products filtered by category
+// ListProductsByCategory retrieves products of a category and its subcategories.
+// It accepts the same filters and sort keys as ListProducts.
 func (h *Handler) ListProductsByCategory(c echo.Context) error {
 	category := c.Param("category")
 
-	// Parse pagination parameters
-	limit := int32(50) // default
-	if l := c.QueryParam("limit"); l != "" {
-		if parsed, err := strconv.ParseInt(l, 10, 32); err == nil && parsed > 0 {
-			limit = int32(parsed)
-			if limit > 100 {
-				limit = 100 // max limit
-			}
-		}
+	params, err := parseProductListParams(c)
+	if err != nil {
+		return err
 	}
-
-	offset := int32(0) // default
-	if o := c.QueryParam("offset"); o != "" {
-		if parsed, err := strconv.ParseInt(o, 10, 32); err == nil && parsed >= 0 {
-			offset = int32(parsed)
-		}
+	params.Category = category
+	if params.Page, err = h.parsePageRequest(c); err != nil {
+		return err
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(requestContext(c), 10*time.Second)
 	defer cancel()
 
-	products, err := h.productService.ListProductsByCategory(ctx, category, limit, offset)
+	result, err := h.productService.ListProducts(ctx, params)
 	if err != nil {
-		if strings.Contains(err.Error(), "invalid category") {
-			return c.JSON(http.StatusBadRequest, map[string]string{
-				"error": "Invalid category. Valid categories: hosting, feature_toggles, ci_cd, observability, other",
-			})
-		}
-		return c.JSON(http.StatusInternalServerError, map[string]string{
-			"error": "Failed to list products by category",
-		})
-	}
-
-	// Convert to response DTOs
-	productResponses := make([]dto.ProductResponse, 0, len(products))
-	for _, product := range products {
-		productResponses = append(productResponses, dto.ProductResponse{
-			ID:           product.ID.String(),
-			CompanyID:    product.CompanyID.String(),
-			Name:         product.Name,
-			Slug:         string(product.Slug),
-			Category:     string(product.Category),
-			ShortTagline: product.ShortTagline,
-			Description:  product.Description,
-			HomepageURL:  product.HomepageURL,
-			DocsURL:      product.DocsURL,
-			AvgRating:    product.AvgRating,
-			TotalReviews: product.TotalReviews,
-			CreatedAt:    product.CreatedAt,
-			UpdatedAt:    product.UpdatedAt,
-			DeletedAt:    product.DeletedAt,
-		})
+		return err
 	}
 
+	next, prev := h.writePageLinks(c, result.Cursors)
+	productResponses := toProductResponses(result.Products)
 	return c.JSON(http.StatusOK, map[string]interface{}{
-		"products": productResponses,
-		"category": category,
-		"count":    len(productResponses),
-		"limit":    limit,
-		"offset":   offset,
+		"products":    productResponses,
+		"category":    category,
+		"count":       len(productResponses),
+		"total":       result.Total,
+		"limit":       params.Page.Limit,
+		"offset":      params.Page.Offset,
+		"next_cursor": next,
+		"prev_cursor": prev,
 	})
 }
 
-// SearchProducts searches for products by name or company name
+// SearchProducts runs a ranked full-text search with optional category, min_rating and tag filters
 func (h *Handler) SearchProducts(c echo.Context) error {
 	query := c.QueryParam("q")
 	if query == "" {
-		return c.JSON(http.StatusBadRequest, map[string]string{
-			"error": "Search query parameter 'q' is required",
-		})
+		return domain.InvalidField("missing_query", "q", "search query is required")
 	}
 
-	// Parse pagination parameters
-	limit := int32(50) // default
-	if l := c.QueryParam("limit"); l != "" {
-		if parsed, err := strconv.ParseInt(l, 10, 32); err == nil && parsed > 0 {
-			limit = int32(parsed)
-			if limit > 100 {
-				limit = 100 // max limit
-			}
-		}
+	page, err := h.parsePageRequest(c)
+	if err != nil {
+		return err
 	}
 
-	offset := int32(0) // default
-	if o := c.QueryParam("offset"); o != "" {
-		if parsed, err := strconv.ParseInt(o, 10, 32); err == nil && parsed >= 0 {
-			offset = int32(parsed)
+	var minRating *float64
+	if r := c.QueryParam("min_rating"); r != "" {
+		parsed, err := strconv.ParseFloat(r, 64)
+		if err != nil {
+			return domain.InvalidField("invalid_min_rating", "min_rating", "must be a number").Wrap(err)
 		}
+		minRating = &parsed
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	tags, err := parseTagFilter(c)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(requestContext(c), 10*time.Second)
 	defer cancel()
 
-	products, err := h.productService.SearchProducts(ctx, query, limit, offset)
+	result, err := h.productService.SearchProducts(ctx, services.ProductSearchParams{
+		Query:     query,
+		Category:  c.QueryParam("category"),
+		MinRating: minRating,
+		Tags:      tags,
+		Page:      page,
+	})
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{
-			"error": "Failed to search products",
-		})
+		return err
 	}
 
 	// Convert to response DTOs
-	productResponses := make([]dto.ProductResponse, 0, len(products))
-	for _, product := range products {
-		productResponses = append(productResponses, dto.ProductResponse{
-			ID:           product.ID.String(),
-			CompanyID:    product.CompanyID.String(),
-			Name:         product.Name,
-			Slug:         string(product.Slug),
-			Category:     string(product.Category),
-			ShortTagline: product.ShortTagline,
-			Description:  product.Description,
-			HomepageURL:  product.HomepageURL,
-			DocsURL:      product.DocsURL,
-			AvgRating:    product.AvgRating,
-			TotalReviews: product.TotalReviews,
-			CreatedAt:    product.CreatedAt,
-			UpdatedAt:    product.UpdatedAt,
-			DeletedAt:    product.DeletedAt,
+	productResponses := make([]dto.ProductSearchHitResponse, 0, len(result.Hits))
+	for _, hit := range result.Hits {
+		product := hit.Product
+		productResponses = append(productResponses, dto.ProductSearchHitResponse{
+			ProductWithCompanyResponse: dto.ProductWithCompanyResponse{
+				ProductResponse: dto.ProductResponse{
+					ID:           product.ID.String(),
+					CompanyID:    product.CompanyID.String(),
+					Name:         product.Name,
+					Slug:         string(product.Slug),
+					Category:     string(product.Category),
+					ShortTagline: product.ShortTagline,
+					Description:  product.Description,
+					HomepageURL:  product.HomepageURL,
+					DocsURL:      product.DocsURL,
+					AvgRating:    product.AvgRating,
+					TotalReviews: product.TotalReviews,
+					Tags:         toTagResponses(product.Tags),
+					CreatedAt:    product.CreatedAt,
+					UpdatedAt:    product.UpdatedAt,
+					DeletedAt:    product.DeletedAt,
+				},
+				CompanyName: hit.CompanyName,
+				CompanySlug: hit.CompanySlug,
+			},
+			Rank: hit.Rank,
+			Highlights: dto.SearchHighlightResponse{
+				Name:    hit.NameHighlight,
+				Snippet: hit.Snippet,
+			},
 		})
 	}
 
-	return c.JSON(http.StatusOK, map[string]interface{}{
-		"products": productResponses,
-		"count":    len(productResponses),
-		"limit":    limit,
-		"offset":   offset,
+	next, prev := h.writePageLinks(c, result.Cursors)
+	return c.JSON(http.StatusOK, dto.ProductSearchResponse{
+		Products:   productResponses,
+		Total:      result.Total,
+		Limit:      page.Limit,
+		Offset:     page.Offset,
+		NextCursor: next,
+		PrevCursor: prev,
+		Facets: dto.SearchFacetsResponse{
+			Categories:    toFacetCountResponses(result.Facets.Categories),
+			RatingBuckets: toFacetCountResponses(result.Facets.RatingBuckets),
+		},
 	})
 }
 
-// GetProductsByCompany retrieves all products for a company
-func (h *Handler) GetProductsByCompany(c echo.Context) error {
-	companyID := c.Param("companyId")
+// parseProductListParams reads the pagination, filter and sort query parameters of a product listing
+func parseProductListParams(c echo.Context) (services.ProductListParams, error) {
+	params := services.ProductListParams{
+		Category: strings.TrimSpace(c.QueryParam("category")),
+		Company:  strings.TrimSpace(c.QueryParam("company")),
+		Sort:     services.ProductSort(c.QueryParam("sort")),
+	}
 
-	// Parse pagination parameters
-	limit := int32(50) // default
-	if l := c.QueryParam("limit"); l != "" {
-		if parsed, err := strconv.ParseInt(l, 10, 32); err == nil && parsed > 0 {
-			limit = int32(parsed)
-			if limit > 100 {
-				limit = 100 // max limit
-			}
+	if r := c.QueryParam("min_rating"); r != "" {
+		parsed, err := strconv.ParseFloat(r, 64)
+		if err != nil {
+			return params, domain.InvalidField("invalid_min_rating", "min_rating", "must be a number").Wrap(err)
 		}
+		params.MinRating = &parsed
 	}
 
-	offset := int32(0) // default
-	if o := c.QueryParam("offset"); o != "" {
-		if parsed, err := strconv.ParseInt(o, 10, 32); err == nil && parsed >= 0 {
-			offset = int32(parsed)
+	if r := c.QueryParam("min_reviews"); r != "" {
+		parsed, err := strconv.Atoi(r)
+		if err != nil {
+			return params, domain.InvalidField("invalid_min_reviews", "min_reviews", "must be a whole number").Wrap(err)
 		}
+		params.MinReviews = &parsed
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
+	if a := c.QueryParam("created_after"); a != "" {
+		createdAfter, err := parseTimeParam(a)
+		if err != nil {
+			return params, domain.InvalidField("invalid_created_after", "created_after", "must be an RFC 3339 timestamp or a YYYY-MM-DD date").Wrap(err)
+		}
+		params.CreatedAfter = &createdAfter
+	}
 
-	products, err := h.productService.GetProductsByCompany(ctx, companyID, limit, offset)
+	tags, err := parseTagFilter(c)
 	if err != nil {
-		if strings.Contains(err.Error(), "invalid") {
-			return c.JSON(http.StatusBadRequest, map[string]string{
-				"error": "Invalid company ID",
-			})
+		return params, err
+	}
+	params.Tags = tags
+
+	return params, nil
+}
+
+// parseTimeParam accepts a full RFC 3339 timestamp or a plain date (midnight UTC)
+func parseTimeParam(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.DateOnly, value)
+}
+
+// parseTagFilter reads ?tags=a,b (or repeated tags params) and ?tag_match=all|any, which defaults to all
+func parseTagFilter(c echo.Context) (services.TagFilter, error) {
+	var tags []string
+	for _, param := range c.QueryParams()["tags"] {
+		for _, tag := range strings.Split(param, ",") {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				tags = append(tags, tag)
+			}
 		}
-		return c.JSON(http.StatusInternalServerError, map[string]string{
-			"error": "Failed to get products by company",
-		})
 	}
 
-	// Convert to response DTOs
-	productResponses := make([]dto.ProductResponse, 0, len(products))
+	switch c.QueryParam("tag_match") {
+	case "", "all":
+		return services.TagFilter{Tags: tags, MatchAll: true}, nil
+	case "any":
+		return services.TagFilter{Tags: tags, MatchAll: false}, nil
+	default:
+		return services.TagFilter{}, domain.InvalidField("invalid_tag_match", "tag_match", "must be one of: all any")
+	}
+}
+
+func toProductResponses(products []*domain.Product) []dto.ProductResponse {
+	responses := make([]dto.ProductResponse, 0, len(products))
 	for _, product := range products {
-		productResponses = append(productResponses, dto.ProductResponse{
+		responses = append(responses, dto.ProductResponse{
 			ID:           product.ID.String(),
 			CompanyID:    product.CompanyID.String(),
 			Name:         product.Name,
@@ -426,17 +465,52 @@ func (h *Handler) GetProductsByCompany(c echo.Context) error {
 			DocsURL:      product.DocsURL,
 			AvgRating:    product.AvgRating,
 			TotalReviews: product.TotalReviews,
+			Tags:         toTagResponses(product.Tags),
 			CreatedAt:    product.CreatedAt,
 			UpdatedAt:    product.UpdatedAt,
 			DeletedAt:    product.DeletedAt,
 		})
 	}
+	return responses
+}
 
-	return c.JSON(http.StatusOK, map[string]interface{}{
-		"products": productResponses,
-		"count":    len(productResponses),
-		"limit":    limit,
-		"offset":   offset,
+func toFacetCountResponses(facets []domain.FacetCount) []dto.FacetCountResponse {
+	responses := make([]dto.FacetCountResponse, 0, len(facets))
+	for _, f := range facets {
+		responses = append(responses, dto.FacetCountResponse{
+			Value: f.Value,
+			Count: f.Count,
+		})
+	}
+	return responses
+}
+
+// GetProductsByCompany retrieves a page of a company's products, newest first, by cursor or offset
+func (h *Handler) GetProductsByCompany(c echo.Context) error {
+	companyID := c.Param("companyId")
+
+	page, err := h.parsePageRequest(c)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(requestContext(c), 10*time.Second)
+	defer cancel()
+
+	result, err := h.productService.GetProductsByCompany(ctx, companyID, page)
+	if err != nil {
+		return err
+	}
+
+	next, prev := h.writePageLinks(c, result.Cursors)
+	return c.JSON(http.StatusOK, dto.ProductListResponse{
+		Products:   toProductResponses(result.Products),
+		Total:      result.Total,
+		Sort:       string(result.Sort),
+		Limit:      page.Limit,
+		Offset:     page.Offset,
+		NextCursor: next,
+		PrevCursor: prev,
 	})
 }
 
@@ -444,24 +518,20 @@ func (h *Handler) GetProductsByCompany(c echo.Context) error {
 func (h *Handler) UpdateProduct(c echo.Context) error {
 	productID := c.Param("id")
 
-	var req dto.UpdateProductRequest
-	if err := c.Bind(&req); err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]string{
-			"error": "Invalid request body",
-		})
+	actor, err := auth.GetActorFromContext(c)
+	if err != nil {
+		return err
 	}
 
-	if err := c.Validate(&req); err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]string{
-			"error":   "Validation failed",
-			"details": err.Error(),
-		})
+	var req dto.UpdateProductRequest
+	if err := bindRequest(c, &req); err != nil {
+		return err
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(requestContext(c), 10*time.Second)
 	defer cancel()
 
-	product, err := h.productService.UpdateProduct(ctx, productID, services.UpdateProductRequest{
+	product, err := h.productService.UpdateProduct(ctx, actor, productID, services.UpdateProductRequest{
 		Name:         strings.TrimSpace(req.Name),
 		Slug:         strings.TrimSpace(req.Slug),
 		Category:     req.Category,
@@ -471,20 +541,7 @@ func (h *Handler) UpdateProduct(c echo.Context) error {
 		DocsURL:      strings.TrimSpace(req.DocsURL),
 	})
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
-			return c.JSON(http.StatusNotFound, map[string]string{
-				"error": "Product not found",
-			})
-		}
-		if strings.Contains(err.Error(), "invalid") {
-			return c.JSON(http.StatusBadRequest, map[string]string{
-				"error": err.Error(),
-			})
-		}
-		return c.JSON(http.StatusInternalServerError, map[string]string{
-			"error":   "Failed to update product",
-			"details": err.Error(),
-		})
+		return err
 	}
 
 	return c.JSON(http.StatusOK, dto.ProductResponse{
@@ -509,24 +566,17 @@ func (h *Handler) UpdateProduct(c echo.Context) error {
 func (h *Handler) DeleteProduct(c echo.Context) error {
 	productID := c.Param("id")
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	actor, err := auth.GetActorFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(requestContext(c), 10*time.Second)
 	defer cancel()
 
-	err := h.productService.DeleteProduct(ctx, productID)
+	err = h.productService.DeleteProduct(ctx, actor, productID)
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
-			return c.JSON(http.StatusNotFound, map[string]string{
-				"error": "Product not found",
-			})
-		}
-		if strings.Contains(err.Error(), "invalid") {
-			return c.JSON(http.StatusBadRequest, map[string]string{
-				"error": "Invalid product ID",
-			})
-		}
-		return c.JSON(http.StatusInternalServerError, map[string]string{
-			"error": "Failed to delete product",
-		})
+		return err
 	}
 
 	return c.JSON(http.StatusOK, map[string]string{
@@ -3,14 +3,15 @@ package handlers
 import (
 	"context"
 	"net/http"
-	"strconv"
 	"strings"
 	"time"
 
 	"ratemysoft-backend/internal/auth"
+	"ratemysoft-backend/internal/domain"
 	"ratemysoft-backend/internal/services"
 	"ratemysoft-backend/internal/transport/http/dto"
 
+	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 )
 
@@ -19,55 +20,27 @@ func (h *Handler) CreateReview(c echo.Context) error {
 	// Get authenticated user ID from context
 	userID, err := auth.GetUserIDFromContext(c)
 	if err != nil {
-		return c.JSON(http.StatusUnauthorized, map[string]string{
-			"error": "User not authenticated",
-		})
+		return err
 	}
 
 	var req dto.CreateReviewRequest
-	if err := c.Bind(&req); err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]string{
-			"error": "Invalid request body",
-		})
-	}
-
-	if err := c.Validate(&req); err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]string{
-			"error":   "Validation failed",
-			"details": err.Error(),
-		})
+	if err := bindRequest(c, &req); err != nil {
+		return err
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(requestContext(c), 10*time.Second)
 	defer cancel()
 
 	review, err := h.reviewService.CreateReview(ctx, services.CreateReviewRequest{
-		ProductID: req.ProductID,
-		UserID:    userID.String(),
-		Title:     strings.TrimSpace(req.Title),
-		Body:      strings.TrimSpace(req.Body),
-		Rating:    req.Rating,
+		ProductID:  req.ProductID,
+		UserID:     userID.String(),
+		Title:      strings.TrimSpace(req.Title),
+		Body:       strings.TrimSpace(req.Body),
+		Rating:     req.Rating,
+		SubRatings: req.SubRatings,
 	})
 	if err != nil {
-		if strings.Contains(err.Error(), "already reviewed") {
-			return c.JSON(http.StatusConflict, map[string]string{
-				"error": "You have already reviewed this product",
-			})
-		}
-		if strings.Contains(err.Error(), "not found") {
-			return c.JSON(http.StatusNotFound, map[string]string{
-				"error": err.Error(),
-			})
-		}
-		if strings.Contains(err.Error(), "invalid rating") {
-			return c.JSON(http.StatusBadRequest, map[string]string{
-				"error": err.Error(),
-			})
-		}
-		return c.JSON(http.StatusInternalServerError, map[string]string{
-			"error":   "Failed to create review",
-			"details": err.Error(),
-		})
+		return err
 	}
 
 	return c.JSON(http.StatusCreated, dto.ReviewResponse{
@@ -77,9 +50,11 @@ func (h *Handler) CreateReview(c echo.Context) error {
 		Title:        review.Title,
 		Body:         review.Body,
 		Rating:       int(review.Rating),
+		SubRatings:   toSubRatingsResponse(review.SubRatings),
 		Status:       string(review.Status),
 		HelpfulCount: review.HelpfulCount,
 		FlagCount:    review.FlagCount,
+		CommentCount: review.CommentCount,
 		Edited:       review.Edited,
 		CreatedAt:    review.CreatedAt,
 		UpdatedAt:    review.UpdatedAt,
@@ -91,93 +66,69 @@ func (h *Handler) CreateReview(c echo.Context) error {
 func (h *Handler) GetReview(c echo.Context) error {
 	reviewID := c.Param("id")
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	var viewer *domain.Actor
+	if actor, err := auth.GetActorFromContext(c); err == nil {
+		viewer = &actor
+	}
+
+	ctx, cancel := context.WithTimeout(requestContext(c), 5*time.Second)
 	defer cancel()
 
-	review, err := h.reviewService.GetReviewByID(ctx, reviewID)
+	review, err := h.reviewService.GetReviewByID(ctx, reviewID, viewer)
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
-			return c.JSON(http.StatusNotFound, map[string]string{
-				"error": "Review not found",
-			})
-		}
-		if strings.Contains(err.Error(), "invalid") {
-			return c.JSON(http.StatusBadRequest, map[string]string{
-				"error": "Invalid review ID",
-			})
-		}
-		return c.JSON(http.StatusInternalServerError, map[string]string{
-			"error": "Failed to get review",
-		})
+		return err
 	}
 
-	return c.JSON(http.StatusOK, dto.ReviewResponse{
+	response := []dto.ReviewResponse{{
 		ID:           review.ID.String(),
 		ProductID:    review.ProductID.String(),
 		UserID:       review.UserID.String(),
 		Title:        review.Title,
 		Body:         review.Body,
 		Rating:       int(review.Rating),
+		SubRatings:   toSubRatingsResponse(review.SubRatings),
 		Status:       string(review.Status),
 		HelpfulCount: review.HelpfulCount,
 		FlagCount:    review.FlagCount,
+		CommentCount: review.CommentCount,
 		Edited:       review.Edited,
 		CreatedAt:    review.CreatedAt,
 		UpdatedAt:    review.UpdatedAt,
 		DeletedAt:    review.DeletedAt,
-	})
+	}}
+	h.attachMyVotes(ctx, c, response)
+
+	return c.JSON(http.StatusOK, response[0])
 }
 
 // GetReviewsByProduct retrieves reviews for a product
 func (h *Handler) GetReviewsByProduct(c echo.Context) error {
 	productID := c.Param("productId")
 
-	// Parse sort parameter
+	// Parse sort parameter: recent, helpful, upvotes, rating_desc or rating_asc
 	sortBy := c.QueryParam("sort")
 	if sortBy == "" {
 		sortBy = "recent"
 	}
 
-	// Parse pagination parameters
-	limit := int32(50) // default
-	if l := c.QueryParam("limit"); l != "" {
-		if parsed, err := strconv.ParseInt(l, 10, 32); err == nil && parsed > 0 {
-			limit = int32(parsed)
-			if limit > 100 {
-				limit = 100 // max limit
-			}
-		}
-	}
-
-	offset := int32(0) // default
-	if o := c.QueryParam("offset"); o != "" {
-		if parsed, err := strconv.ParseInt(o, 10, 32); err == nil && parsed >= 0 {
-			offset = int32(parsed)
-		}
+	page, err := h.parsePageRequest(c)
+	if err != nil {
+		return err
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(requestContext(c), 10*time.Second)
 	defer cancel()
 
 	// Get total count
 	total, err := h.reviewService.CountReviewsByProduct(ctx, productID)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{
-			"error": "Failed to count reviews",
-		})
+		return err
 	}
 
 	// Get reviews
-	reviews, err := h.reviewService.GetReviewsByProduct(ctx, productID, sortBy, limit, offset)
+	reviews, cursors, err := h.reviewService.GetReviewsByProduct(ctx, productID, sortBy, page)
 	if err != nil {
-		if strings.Contains(err.Error(), "invalid") {
-			return c.JSON(http.StatusBadRequest, map[string]string{
-				"error": err.Error(),
-			})
-		}
-		return c.JSON(http.StatusInternalServerError, map[string]string{
-			"error": "Failed to get reviews",
-		})
+		return err
 	}
 
 	// Convert to response DTOs
@@ -190,9 +141,11 @@ func (h *Handler) GetReviewsByProduct(c echo.Context) error {
 			Title:        review.Title,
 			Body:         review.Body,
 			Rating:       int(review.Rating),
+			SubRatings:   toSubRatingsResponse(review.SubRatings),
 			Status:       string(review.Status),
 			HelpfulCount: review.HelpfulCount,
 			FlagCount:    review.FlagCount,
+			CommentCount: review.CommentCount,
 			Edited:       review.Edited,
 			CreatedAt:    review.CreatedAt,
 			UpdatedAt:    review.UpdatedAt,
@@ -200,11 +153,16 @@ func (h *Handler) GetReviewsByProduct(c echo.Context) error {
 		})
 	}
 
+	h.attachMyVotes(ctx, c, reviewResponses)
+
+	next, prev := h.writePageLinks(c, cursors)
 	return c.JSON(http.StatusOK, dto.ReviewListResponse{
-		Reviews: reviewResponses,
-		Total:   total,
-		Limit:   limit,
-		Offset:  offset,
+		Reviews:    reviewResponses,
+		Total:      total,
+		Limit:      page.Limit,
+		Offset:     page.Offset,
+		NextCursor: next,
+		PrevCursor: prev,
 	})
 }
 
@@ -212,46 +170,30 @@ func (h *Handler) GetReviewsByProduct(c echo.Context) error {
 func (h *Handler) GetReviewsByUser(c echo.Context) error {
 	userID := c.Param("userId")
 
-	// Parse pagination parameters
-	limit := int32(50) // default
-	if l := c.QueryParam("limit"); l != "" {
-		if parsed, err := strconv.ParseInt(l, 10, 32); err == nil && parsed > 0 {
-			limit = int32(parsed)
-			if limit > 100 {
-				limit = 100 // max limit
-			}
-		}
+	page, err := h.parsePageRequest(c)
+	if err != nil {
+		return err
 	}
 
-	offset := int32(0) // default
-	if o := c.QueryParam("offset"); o != "" {
-		if parsed, err := strconv.ParseInt(o, 10, 32); err == nil && parsed >= 0 {
-			offset = int32(parsed)
-		}
+	// The author and admins also see pending and rejected reviews
+	var viewer *domain.Actor
+	if actor, err := auth.GetActorFromContext(c); err == nil {
+		viewer = &actor
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(requestContext(c), 10*time.Second)
 	defer cancel()
 
 	// Get total count
-	total, err := h.reviewService.CountReviewsByUser(ctx, userID)
+	total, err := h.reviewService.CountReviewsByUser(ctx, userID, viewer)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{
-			"error": "Failed to count reviews",
-		})
+		return err
 	}
 
 	// Get reviews
-	reviews, err := h.reviewService.GetReviewsByUser(ctx, userID, limit, offset)
+	reviews, cursors, err := h.reviewService.GetReviewsByUser(ctx, userID, viewer, page)
 	if err != nil {
-		if strings.Contains(err.Error(), "invalid") {
-			return c.JSON(http.StatusBadRequest, map[string]string{
-				"error": "Invalid user ID",
-			})
-		}
-		return c.JSON(http.StatusInternalServerError, map[string]string{
-			"error": "Failed to get reviews",
-		})
+		return err
 	}
 
 	// Convert to response DTOs
@@ -264,9 +206,11 @@ func (h *Handler) GetReviewsByUser(c echo.Context) error {
 			Title:        review.Title,
 			Body:         review.Body,
 			Rating:       int(review.Rating),
+			SubRatings:   toSubRatingsResponse(review.SubRatings),
 			Status:       string(review.Status),
 			HelpfulCount: review.HelpfulCount,
 			FlagCount:    review.FlagCount,
+			CommentCount: review.CommentCount,
 			Edited:       review.Edited,
 			CreatedAt:    review.CreatedAt,
 			UpdatedAt:    review.UpdatedAt,
@@ -274,11 +218,16 @@ func (h *Handler) GetReviewsByUser(c echo.Context) error {
 		})
 	}
 
+	h.attachMyVotes(ctx, c, reviewResponses)
+
+	next, prev := h.writePageLinks(c, cursors)
 	return c.JSON(http.StatusOK, dto.ReviewListResponse{
-		Reviews: reviewResponses,
-		Total:   total,
-		Limit:   limit,
-		Offset:  offset,
+		Reviews:    reviewResponses,
+		Total:      total,
+		Limit:      page.Limit,
+		Offset:     page.Offset,
+		NextCursor: next,
+		PrevCursor: prev,
 	})
 }
 
@@ -289,53 +238,25 @@ func (h *Handler) UpdateReview(c echo.Context) error {
 	// Get authenticated user ID from context
 	userID, err := auth.GetUserIDFromContext(c)
 	if err != nil {
-		return c.JSON(http.StatusUnauthorized, map[string]string{
-			"error": "User not authenticated",
-		})
+		return err
 	}
 
 	var req dto.UpdateReviewRequest
-	if err := c.Bind(&req); err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]string{
-			"error": "Invalid request body",
-		})
+	if err := bindRequest(c, &req); err != nil {
+		return err
 	}
 
-	if err := c.Validate(&req); err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]string{
-			"error":   "Validation failed",
-			"details": err.Error(),
-		})
-	}
-
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(requestContext(c), 10*time.Second)
 	defer cancel()
 
 	review, err := h.reviewService.UpdateReview(ctx, reviewID, userID.String(), services.UpdateReviewRequest{
-		Title:  strings.TrimSpace(req.Title),
-		Body:   strings.TrimSpace(req.Body),
-		Rating: req.Rating,
+		Title:      strings.TrimSpace(req.Title),
+		Body:       strings.TrimSpace(req.Body),
+		Rating:     req.Rating,
+		SubRatings: req.SubRatings,
 	})
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
-			return c.JSON(http.StatusNotFound, map[string]string{
-				"error": "Review not found",
-			})
-		}
-		if strings.Contains(err.Error(), "unauthorized") {
-			return c.JSON(http.StatusForbidden, map[string]string{
-				"error": "You can only edit your own reviews",
-			})
-		}
-		if strings.Contains(err.Error(), "invalid") {
-			return c.JSON(http.StatusBadRequest, map[string]string{
-				"error": err.Error(),
-			})
-		}
-		return c.JSON(http.StatusInternalServerError, map[string]string{
-			"error":   "Failed to update review",
-			"details": err.Error(),
-		})
+		return err
 	}
 
 	return c.JSON(http.StatusOK, dto.ReviewResponse{
@@ -345,9 +266,11 @@ func (h *Handler) UpdateReview(c echo.Context) error {
 		Title:        review.Title,
 		Body:         review.Body,
 		Rating:       int(review.Rating),
+		SubRatings:   toSubRatingsResponse(review.SubRatings),
 		Status:       string(review.Status),
 		HelpfulCount: review.HelpfulCount,
 		FlagCount:    review.FlagCount,
+		CommentCount: review.CommentCount,
 		Edited:       review.Edited,
 		CreatedAt:    review.CreatedAt,
 		UpdatedAt:    review.UpdatedAt,
@@ -362,34 +285,15 @@ func (h *Handler) DeleteReview(c echo.Context) error {
 	// Get authenticated user ID from context
 	userID, err := auth.GetUserIDFromContext(c)
 	if err != nil {
-		return c.JSON(http.StatusUnauthorized, map[string]string{
-			"error": "User not authenticated",
-		})
+		return err
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(requestContext(c), 10*time.Second)
 	defer cancel()
 
 	err = h.reviewService.DeleteReview(ctx, reviewID, userID.String())
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
-			return c.JSON(http.StatusNotFound, map[string]string{
-				"error": "Review not found",
-			})
-		}
-		if strings.Contains(err.Error(), "unauthorized") {
-			return c.JSON(http.StatusForbidden, map[string]string{
-				"error": "You can only delete your own reviews",
-			})
-		}
-		if strings.Contains(err.Error(), "invalid") {
-			return c.JSON(http.StatusBadRequest, map[string]string{
-				"error": "Invalid review ID",
-			})
-		}
-		return c.JSON(http.StatusInternalServerError, map[string]string{
-			"error": "Failed to delete review",
-		})
+		return err
 	}
 
 	return c.JSON(http.StatusOK, map[string]string{
@@ -397,89 +301,185 @@ func (h *Handler) DeleteReview(c echo.Context) error {
 	})
 }
 
-// UpvoteReview increments the upvote count for a review
-func (h *Handler) UpvoteReview(c echo.Context) error {
+// GetReviewRevisions retrieves a review's edit history; old titles and bodies are only
+// included for the review's author and admins
+func (h *Handler) GetReviewRevisions(c echo.Context) error {
 	reviewID := c.Param("id")
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	var viewer *domain.Actor
+	if actor, err := auth.GetActorFromContext(c); err == nil {
+		viewer = &actor
+	}
+
+	ctx, cancel := context.WithTimeout(requestContext(c), 5*time.Second)
 	defer cancel()
 
-	err := h.reviewService.IncrementUpvote(ctx, reviewID)
+	revisions, fullText, err := h.reviewService.GetReviewRevisions(ctx, reviewID, viewer)
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
-			return c.JSON(http.StatusNotFound, map[string]string{
-				"error": "Review not found",
-			})
-		}
-		if strings.Contains(err.Error(), "invalid") {
-			return c.JSON(http.StatusBadRequest, map[string]string{
-				"error": "Invalid review ID",
-			})
-		}
-		return c.JSON(http.StatusInternalServerError, map[string]string{
-			"error": "Failed to upvote review",
+		return err
+	}
+
+	responses := make([]dto.ReviewRevisionResponse, 0, len(revisions))
+	for _, revision := range revisions {
+		responses = append(responses, dto.ReviewRevisionResponse{
+			Revision:          revision.Revision,
+			Title:             revision.Title,
+			Body:              revision.Body,
+			SubRatings:        toSubRatingsResponse(revision.SubRatings),
+			Rating:            int(revision.Changes.RatingFrom),
+			RatingAfter:       int(revision.Changes.RatingTo),
+			TitleChanged:      revision.Changes.TitleChanged,
+			BodyChanged:       revision.Changes.BodyChanged,
+			SubRatingsChanged: revision.Changes.SubRatingsChanged,
+			ReplacedAt:        revision.CreatedAt,
 		})
 	}
 
-	return c.JSON(http.StatusOK, map[string]string{
-		"message": "Review upvoted successfully",
+	return c.JSON(http.StatusOK, dto.ReviewRevisionListResponse{
+		ReviewID:  reviewID,
+		FullText:  fullText,
+		Revisions: responses,
 	})
 }
 
-// DownvoteReview increments the downvote count for a review
+// UpvoteReview records the authenticated user's upvote on a review
+func (h *Handler) UpvoteReview(c echo.Context) error {
+	return h.setReviewVote(c, domain.VoteUp)
+}
+
+// DownvoteReview records the authenticated user's downvote on a review
 func (h *Handler) DownvoteReview(c echo.Context) error {
+	return h.setReviewVote(c, domain.VoteDown)
+}
+
+// RetractReviewVote removes the authenticated user's vote on a review
+func (h *Handler) RetractReviewVote(c echo.Context) error {
+	return h.setReviewVote(c, domain.VoteNone)
+}
+
+// FlagReview records the authenticated user's flag on a review
+func (h *Handler) FlagReview(c echo.Context) error {
 	reviewID := c.Param("id")
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	actor, err := auth.GetActorFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.FlagReviewRequest
+	if err := bindRequest(c, &req); err != nil {
+		return err
+	}
+
+	reason := domain.FlagOther
+	if req.Reason != "" {
+		reason, err = domain.NewFlagReason(req.Reason)
+		if err != nil {
+			return err
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(requestContext(c), 5*time.Second)
 	defer cancel()
 
-	err := h.reviewService.IncrementDownvote(ctx, reviewID)
+	err = h.reviewService.FlagReview(ctx, reviewID, actor, reason, strings.TrimSpace(req.Note))
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
-			return c.JSON(http.StatusNotFound, map[string]string{
-				"error": "Review not found",
-			})
-		}
-		if strings.Contains(err.Error(), "invalid") {
-			return c.JSON(http.StatusBadRequest, map[string]string{
-				"error": "Invalid review ID",
-			})
-		}
-		return c.JSON(http.StatusInternalServerError, map[string]string{
-			"error": "Failed to downvote review",
-		})
+		return err
 	}
 
 	return c.JSON(http.StatusOK, map[string]string{
-		"message": "Review downvoted successfully",
+		"message": "Review flagged successfully",
 	})
 }
 
-// FlagReview increments the flag count for a review
-func (h *Handler) FlagReview(c echo.Context) error {
+// UnflagReview retracts the authenticated user's flag on a review
+func (h *Handler) UnflagReview(c echo.Context) error {
 	reviewID := c.Param("id")
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	actor, err := auth.GetActorFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(requestContext(c), 5*time.Second)
 	defer cancel()
 
-	err := h.reviewService.IncrementFlag(ctx, reviewID)
+	err = h.reviewService.UnflagReview(ctx, reviewID, actor)
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
-			return c.JSON(http.StatusNotFound, map[string]string{
-				"error": "Review not found",
-			})
-		}
-		if strings.Contains(err.Error(), "invalid") {
-			return c.JSON(http.StatusBadRequest, map[string]string{
-				"error": "Invalid review ID",
-			})
-		}
-		return c.JSON(http.StatusInternalServerError, map[string]string{
-			"error": "Failed to flag review",
-		})
+		return err
 	}
 
 	return c.JSON(http.StatusOK, map[string]string{
-		"message": "Review flagged successfully",
+		"message": "Flag retracted successfully",
+	})
+}
+
+// setReviewVote sets (or with domain.VoteNone, retracts) the authenticated user's vote
+func (h *Handler) setReviewVote(c echo.Context, vote domain.VoteDirection) error {
+	reviewID := c.Param("id")
+
+	actor, err := auth.GetActorFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(requestContext(c), 5*time.Second)
+	defer cancel()
+
+	result, err := h.reviewService.SetVote(ctx, reviewID, actor, vote)
+	if err != nil {
+		return err
+	}
+
+	voteName := string(result.Vote)
+	if result.Vote == domain.VoteNone {
+		voteName = "none"
+	}
+
+	return c.JSON(http.StatusOK, dto.ReviewVoteResponse{
+		ReviewID:      result.ReviewID.String(),
+		Vote:          voteName,
+		UpvoteCount:   result.UpvoteCount,
+		DownvoteCount: result.DownvoteCount,
 	})
 }
+
+// attachMyVotes fills in MyVote on each response when the request is authenticated
+func (h *Handler) attachMyVotes(ctx context.Context, c echo.Context, responses []dto.ReviewResponse) {
+	userID, err := auth.GetUserIDFromContext(c)
+	if err != nil || len(responses) == 0 {
+		return
+	}
+
+	reviewIDs := make([]uuid.UUID, 0, len(responses))
+	for _, r := range responses {
+		if id, err := uuid.Parse(r.ID); err == nil {
+			reviewIDs = append(reviewIDs, id)
+		}
+	}
+
+	votes, err := h.reviewService.GetUserVotes(ctx, userID, reviewIDs)
+	if err != nil {
+		// Votes are a convenience; don't fail the listing over them
+		return
+	}
+
+	for i := range responses {
+		if id, err := uuid.Parse(responses[i].ID); err == nil {
+			responses[i].MyVote = string(votes[id])
+		}
+	}
+}
+
+// toSubRatingsResponse flattens a review's sub-ratings into a JSON object keyed by dimension
+func toSubRatingsResponse(subRatings domain.SubRatings) map[string]int {
+	if len(subRatings) == 0 {
+		return nil
+	}
+
+	response := make(map[string]int, len(subRatings))
+	for dimension, rating := range subRatings {
+		response[string(dimension)] = int(rating)
+	}
+	return response
+}
@@ -0,0 +1,269 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"ratemysoft-backend/internal/auth"
+	"ratemysoft-backend/internal/domain"
+	"ratemysoft-backend/internal/transport/http/dto"
+
+	"github.com/labstack/echo/v4"
+)
+
+// ListTags lists tags by popularity, by cursor or offset; ?q= narrows to tags starting with the given text
+func (h *Handler) ListTags(c echo.Context) error {
+	page, err := h.parsePageRequest(c)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(requestContext(c), 5*time.Second)
+	defer cancel()
+
+	result, err := h.tagService.ListTags(ctx, strings.TrimSpace(c.QueryParam("q")), page)
+	if err != nil {
+		return err
+	}
+
+	responses := make([]dto.TagWithCountResponse, 0, len(result.Tags))
+	for _, tag := range result.Tags {
+		responses = append(responses, dto.TagWithCountResponse{
+			TagResponse: dto.TagResponse{
+				Slug: string(tag.Slug),
+				Name: tag.Name,
+			},
+			ProductCount: tag.ProductCount,
+		})
+	}
+
+	next, prev := h.writePageLinks(c, result.Cursors)
+	return c.JSON(http.StatusOK, dto.TagListResponse{
+		Tags:       responses,
+		Total:      result.Total,
+		Limit:      page.Limit,
+		Offset:     page.Offset,
+		NextCursor: next,
+		PrevCursor: prev,
+	})
+}
+
+// ListProductTags retrieves the tags of a product
+func (h *Handler) ListProductTags(c echo.Context) error {
+	productID := c.Param("id")
+
+	ctx, cancel := context.WithTimeout(requestContext(c), 5*time.Second)
+	defer cancel()
+
+	tags, err := h.tagService.ListProductTags(ctx, productID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.ProductTagsResponse{
+		ProductID: productID,
+		Tags:      toTagResponses(tags),
+	})
+}
+
+// AddProductTags attaches tags to a product (company editors and admins)
+func (h *Handler) AddProductTags(c echo.Context) error {
+	productID := c.Param("id")
+
+	actor, err := auth.GetActorFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.AddProductTagsRequest
+	if err := bindRequest(c, &req); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(requestContext(c), 10*time.Second)
+	defer cancel()
+
+	tags, err := h.tagService.AddProductTags(ctx, actor, productID, req.Tags)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.ProductTagsResponse{
+		ProductID: productID,
+		Tags:      toTagResponses(tags),
+	})
+}
+
+// RemoveProductTag detaches a tag from a product (company editors and admins)
+func (h *Handler) RemoveProductTag(c echo.Context) error {
+	productID := c.Param("id")
+	tag := c.Param("tag")
+
+	actor, err := auth.GetActorFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(requestContext(c), 10*time.Second)
+	defer cancel()
+
+	err = h.tagService.RemoveProductTag(ctx, actor, productID, tag)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{
+		"message": "Tag removed successfully",
+	})
+}
+
+// SuggestProductTag proposes a tag for a product; the company's editors review it
+func (h *Handler) SuggestProductTag(c echo.Context) error {
+	productID := c.Param("id")
+
+	actor, err := auth.GetActorFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.SuggestTagRequest
+	if err := bindRequest(c, &req); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(requestContext(c), 10*time.Second)
+	defer cancel()
+
+	suggestion, err := h.tagService.SuggestTag(ctx, actor, productID, strings.TrimSpace(req.Tag))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusCreated, toTagSuggestionResponse(suggestion))
+}
+
+// ListTagSuggestions lists a product's tag suggestions, pending by default (company editors and admins)
+func (h *Handler) ListTagSuggestions(c echo.Context) error {
+	productID := c.Param("id")
+
+	actor, err := auth.GetActorFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	status := domain.TagSuggestionStatus(c.QueryParam("status"))
+	if status == "" {
+		status = domain.TagSuggestionPending
+	}
+	if status != domain.TagSuggestionPending && status != domain.TagSuggestionApproved && status != domain.TagSuggestionRejected {
+		return domain.InvalidField("invalid_suggestion_status", "status", "must be one of: pending approved rejected")
+	}
+
+	page, err := h.parsePageRequest(c)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(requestContext(c), 10*time.Second)
+	defer cancel()
+
+	result, err := h.tagService.ListTagSuggestions(ctx, actor, productID, status, page)
+	if err != nil {
+		return err
+	}
+
+	responses := make([]dto.TagSuggestionResponse, 0, len(result.Suggestions))
+	for _, suggestion := range result.Suggestions {
+		responses = append(responses, toTagSuggestionResponse(suggestion))
+	}
+
+	next, prev := h.writePageLinks(c, result.Cursors)
+	return c.JSON(http.StatusOK, dto.TagSuggestionListResponse{
+		Suggestions: responses,
+		Total:       result.Total,
+		Limit:       page.Limit,
+		Offset:      page.Offset,
+		NextCursor:  next,
+		PrevCursor:  prev,
+	})
+}
+
+// ApproveTagSuggestion approves a suggestion and attaches the tag (company editors and admins)
+func (h *Handler) ApproveTagSuggestion(c echo.Context) error {
+	return h.resolveTagSuggestion(c, domain.TagSuggestionApproved)
+}
+
+// RejectTagSuggestion rejects a suggestion (company editors and admins)
+func (h *Handler) RejectTagSuggestion(c echo.Context) error {
+	return h.resolveTagSuggestion(c, domain.TagSuggestionRejected)
+}
+
+func (h *Handler) resolveTagSuggestion(c echo.Context, to domain.TagSuggestionStatus) error {
+	productID := c.Param("id")
+	suggestionID := c.Param("suggestionId")
+
+	actor, err := auth.GetActorFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.ResolveTagSuggestionRequest
+	if err := bindRequest(c, &req); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(requestContext(c), 10*time.Second)
+	defer cancel()
+
+	var suggestion *domain.TagSuggestion
+	if to == domain.TagSuggestionApproved {
+		suggestion, err = h.tagService.ApproveTagSuggestion(ctx, actor, productID, suggestionID, strings.TrimSpace(req.Note))
+	} else {
+		suggestion, err = h.tagService.RejectTagSuggestion(ctx, actor, productID, suggestionID, strings.TrimSpace(req.Note))
+	}
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, toTagSuggestionResponse(suggestion))
+}
+
+func toTagResponses(tags []domain.Tag) []dto.TagResponse {
+	if tags == nil {
+		return nil
+	}
+
+	responses := make([]dto.TagResponse, 0, len(tags))
+	for _, tag := range tags {
+		responses = append(responses, dto.TagResponse{
+			Slug: string(tag.Slug),
+			Name: tag.Name,
+		})
+	}
+	return responses
+}
+
+func toTagSuggestionResponse(suggestion *domain.TagSuggestion) dto.TagSuggestionResponse {
+	var reviewedBy *string
+	if suggestion.ReviewedBy != nil {
+		id := suggestion.ReviewedBy.String()
+		reviewedBy = &id
+	}
+
+	return dto.TagSuggestionResponse{
+		ID:         suggestion.ID.String(),
+		ProductID:  suggestion.ProductID.String(),
+		UserID:     suggestion.UserID.String(),
+		UserHandle: suggestion.UserHandle,
+		Tag:        string(suggestion.TagSlug),
+		TagName:    suggestion.TagName,
+		Status:     string(suggestion.Status),
+		ReviewedBy: reviewedBy,
+		ReviewNote: suggestion.ReviewNote,
+		ReviewedAt: suggestion.ReviewedAt,
+		CreatedAt:  suggestion.CreatedAt,
+		UpdatedAt:  suggestion.UpdatedAt,
+	}
+}
@@ -0,0 +1,44 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"ratemysoft-backend/internal/transport/http/dto"
+
+	"github.com/labstack/echo/v4"
+)
+
+// GetPublicProfile returns a reviewer's public profile: join date, review count, helpful
+// votes, reputation and badges. The email and role stay private.
+func (h *Handler) GetPublicProfile(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(requestContext(c), 10*time.Second)
+	defer cancel()
+
+	profile, err := h.reputation.GetPublicProfile(ctx, c.Param("handle"))
+	if err != nil {
+		return err
+	}
+
+	badges := make([]dto.ReviewerBadgeResponse, 0, len(profile.Badges))
+	for _, badge := range profile.Badges {
+		badges = append(badges, dto.ReviewerBadgeResponse{
+			Label:        badge.Label(),
+			Category:     string(badge.Category),
+			Rank:         badge.Rank,
+			HelpfulVotes: badge.HelpfulVotes,
+		})
+	}
+
+	return c.JSON(http.StatusOK, dto.PublicProfileResponse{
+		ID:           profile.UserID.String(),
+		Handle:       profile.Handle,
+		JoinedAt:     profile.JoinedAt,
+		ReviewCount:  profile.ReviewCount,
+		HelpfulVotes: profile.HelpfulVotes,
+		Reputation:   profile.Reputation,
+		Badges:       badges,
+		RefreshedAt:  profile.RefreshedAt,
+	})
+}
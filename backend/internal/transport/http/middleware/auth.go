@@ -1,10 +1,10 @@
 package middleware
 
 import (
-	"net/http"
 	"strings"
 
 	"ratemysoft-backend/internal/auth"
+	"ratemysoft-backend/internal/domain"
 
 	"github.com/labstack/echo/v4"
 )
@@ -16,25 +16,19 @@ func AuthMiddleware(jwtService *auth.JWTService) echo.MiddlewareFunc {
 			authHeader := c.Request().Header.Get("Authorization")
 
 			if authHeader == "" || !strings.HasPrefix(authHeader, "Bearer ") {
-				return c.JSON(http.StatusUnauthorized, map[string]string{
-					"error": "Missing or invalid authorization header",
-				})
+				return domain.Unauthorized("missing_token", "missing or invalid authorization header")
 			}
 
 			tokenString := strings.TrimPrefix(authHeader, "Bearer ")
 
 			if tokenString == "" {
-				return c.JSON(http.StatusUnauthorized, map[string]string{
-					"error": "Invalid token",
-				})
+				return domain.Unauthorized("invalid_token", "invalid token")
 			}
 
-			// Validate JWT token
-			claims, err := jwtService.ValidateToken(tokenString)
+			// Validate JWT token and make sure it hasn't been revoked by logout
+			claims, err := jwtService.ValidateAccessToken(c.Request().Context(), tokenString)
 			if err != nil {
-				return c.JSON(http.StatusUnauthorized, map[string]string{
-					"error": "Invalid or expired token",
-				})
+				return domain.Unauthorized("invalid_token", "invalid or expired token").Wrap(err)
 			}
 
 			// Store user information in context
@@ -45,21 +39,37 @@ func AuthMiddleware(jwtService *auth.JWTService) echo.MiddlewareFunc {
 	}
 }
 
+// OptionalAuthMiddleware attaches the user to the context when a valid token is present,
+// but lets anonymous requests through (used by public routes with per-user extras)
+func OptionalAuthMiddleware(jwtService *auth.JWTService) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			authHeader := c.Request().Header.Get("Authorization")
+			if !strings.HasPrefix(authHeader, "Bearer ") {
+				return next(c)
+			}
+
+			claims, err := jwtService.ValidateAccessToken(c.Request().Context(), strings.TrimPrefix(authHeader, "Bearer "))
+			if err == nil {
+				auth.SetUserInContext(c, claims)
+			}
+
+			return next(c)
+		}
+	}
+}
+
 // RequireRole creates middleware that checks if the authenticated user has a specific role
 func RequireRole(role string) echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
 			userRole, err := auth.GetUserRoleFromContext(c)
 			if err != nil {
-				return c.JSON(http.StatusUnauthorized, map[string]string{
-					"error": "User role not found",
-				})
+				return err
 			}
 
 			if userRole != role {
-				return c.JSON(http.StatusForbidden, map[string]string{
-					"error": "Insufficient permissions",
-				})
+				return domain.Forbidden("insufficient_role", "insufficient permissions")
 			}
 
 			return next(c)
@@ -0,0 +1,33 @@
+package middleware
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/labstack/echo/v4"
+)
+
+// ClientIP returns the echo.IPExtractor behind c.RealIP(), which login throttling, sessions and
+// the audit log use as the client address. Any client can send X-Forwarded-For, so the header
+// is only read when the request came through one of trustedProxies (CIDRs); without trusted
+// proxies the address of the connection is used.
+func ClientIP(trustedProxies []string) (echo.IPExtractor, error) {
+	if len(trustedProxies) == 0 {
+		return echo.ExtractIPDirect(), nil
+	}
+
+	// Echo trusts loopback and private addresses by default; only the configured ranges count
+	options := []echo.TrustOption{
+		echo.TrustLoopback(false),
+		echo.TrustLinkLocal(false),
+		echo.TrustPrivateNet(false),
+	}
+	for _, cidr := range trustedProxies {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy %q: %w", cidr, err)
+		}
+		options = append(options, echo.TrustIPRange(network))
+	}
+	return echo.ExtractIPFromXFFHeader(options...), nil
+}
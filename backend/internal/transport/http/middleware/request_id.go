@@ -0,0 +1,18 @@
+package middleware
+
+import (
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// RequestID tags every request with a server-generated ID in the X-Request-ID response header.
+// An ID sent by the client is ignored, so the ID recorded in logs and the audit trail always
+// identifies one request to this server.
+func RequestID() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			c.Response().Header().Set(echo.HeaderXRequestID, uuid.NewString())
+			return next(c)
+		}
+	}
+}
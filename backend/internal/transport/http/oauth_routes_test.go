@@ -0,0 +1,105 @@
+package http_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"ratemysoft-backend/internal/auth/oauth/oauthtest"
+	"ratemysoft-backend/internal/transport/http/apitest"
+	"ratemysoft-backend/internal/transport/http/dto"
+)
+
+func TestOAuthRoutes(t *testing.T) {
+	idp := oauthtest.NewIdP(t, oauthtest.User{Subject: "corp-1", Email: "alice@corp.example", EmailVerified: true, Username: "alice"})
+	s := apitest.New(t, apitest.WithOIDC("corp", idp.URL, oauthtest.ClientID, oauthtest.ClientSecret))
+	s.User("alice") // takes the handle the provider suggests
+
+	s.Run(t, []apitest.Case{
+		{
+			Name: "providers", Method: http.MethodGet, Path: "/api/v1/auth/oauth/providers", Status: http.StatusOK,
+			Check: func(t *testing.T, r *apitest.Response) {
+				var list dto.OAuthProvidersResponse
+				r.Decode(t, &list)
+				if len(list.Providers) != 1 || list.Providers[0] != "corp" {
+					t.Errorf("providers = %v, want [corp]", list.Providers)
+				}
+			},
+		},
+		{Name: "start unknown provider", Method: http.MethodGet, Path: "/api/v1/auth/oauth/gitlab/start", Status: http.StatusNotFound, Code: "unknown_provider"},
+		{Name: "callback without flow", Method: http.MethodGet, Path: "/api/v1/auth/oauth/corp/callback?code=x&state=y", Status: http.StatusBadRequest, Code: "invalid_oauth_state"},
+		{Name: "callback denied", Method: http.MethodGet, Path: "/api/v1/auth/oauth/corp/callback?error=access_denied&state=y", Status: http.StatusUnauthorized, Code: "oauth_denied"},
+	})
+
+	// start sends the browser to the provider with a flow cookie scoped to the callback
+	start := func(t *testing.T) (*url.URL, *http.Cookie) {
+		t.Helper()
+		r := s.Do(http.MethodGet, "/api/v1/auth/oauth/corp/start", nil, "")
+		if r.Code != http.StatusFound {
+			t.Fatalf("start: status = %d, want 302; body: %s", r.Code, r.Body)
+		}
+		cookies := (&http.Response{Header: r.Header}).Cookies()
+		if len(cookies) != 1 || !cookies[0].HttpOnly || cookies[0].Path != "/api/v1/auth/oauth/corp" {
+			t.Fatalf("start: cookies = %+v, want one HttpOnly flow cookie", cookies)
+		}
+		location := r.Header.Get("Location")
+		if !strings.HasPrefix(location, idp.URL+"/authorize?") {
+			t.Fatalf("start: redirect to %s, want the provider", location)
+		}
+		return idp.Authorize(t, location), cookies[0]
+	}
+	callback := func(target *url.URL, cookie *http.Cookie) *apitest.Response {
+		req := httptest.NewRequest(http.MethodGet, target.RequestURI(), nil)
+		req.AddCookie(cookie)
+		return s.Serve(req)
+	}
+
+	t.Run("callback with another state", func(t *testing.T) {
+		target, cookie := start(t)
+		query := target.Query()
+		query.Set("state", "forged")
+		target.RawQuery = query.Encode()
+
+		r := callback(target, cookie)
+		if r.Code != http.StatusBadRequest || r.Problem(t).Code != "invalid_oauth_state" {
+			t.Errorf("status = %d, body: %s; want invalid_oauth_state", r.Code, r.Body)
+		}
+	})
+
+	t.Run("sign in creates and then finds the account", func(t *testing.T) {
+		var first dto.AuthResponse
+		for i := range 2 {
+			target, cookie := start(t)
+			r := callback(target, cookie)
+			if r.Code != http.StatusOK {
+				t.Fatalf("callback %d: status = %d; body: %s", i, r.Code, r.Body)
+			}
+			var resp dto.AuthResponse
+			r.Decode(t, &resp)
+			if resp.Token == "" || resp.RefreshToken == "" {
+				t.Fatalf("callback %d: response = %+v, want tokens", i, resp)
+			}
+			if i == 0 {
+				first = resp
+				continue
+			}
+			if resp.User.ID != first.User.ID {
+				t.Errorf("second sign-in = %s, want %s", resp.User.ID, first.User.ID)
+			}
+		}
+		if first.User.Email != "alice@corp.example" || first.User.Handle == "alice" || !strings.HasPrefix(first.User.Handle, "alice") {
+			t.Errorf("user = %+v, want alice@corp.example with a suffixed handle", first.User)
+		}
+	})
+
+	t.Run("unverified email", func(t *testing.T) {
+		idp.SetUser(oauthtest.User{Subject: "corp-2", Email: "mallory@corp.example"})
+
+		target, cookie := start(t)
+		if r := callback(target, cookie); r.Code != http.StatusForbidden || r.Problem(t).Code != "email_unverified" {
+			t.Errorf("status = %d, body: %s; want email_unverified", r.Code, r.Body)
+		}
+	})
+}
@@ -0,0 +1,193 @@
+package http_test
+
+import (
+	"net/http"
+	"testing"
+
+	"ratemysoft-backend/internal/transport/http/apitest"
+	"ratemysoft-backend/internal/transport/http/dto"
+)
+
+func TestProductRoutes(t *testing.T) {
+	s := apitest.New(t)
+	owner := s.User("owner")
+	stranger := s.User("stranger")
+	reviewer := s.User("reviewer")
+	acme := s.Company(owner, "acme")
+	widget := s.Product(owner, acme, "widget")
+	s.Product(owner, acme, "gadget")
+	s.Review(reviewer, widget, 4)
+
+	ownerToken, strangerToken := s.Token(owner), s.Token(stranger)
+	widgetPath := "/api/v1/products/" + widget.ID.String()
+	update := map[string]string{"name": "Widget Pro", "slug": "widget", "category": "observability"}
+
+	s.Run(t, []apitest.Case{
+		{
+			Name: "get", Method: http.MethodGet, Path: widgetPath, Status: http.StatusOK,
+			Check: func(t *testing.T, r *apitest.Response) {
+				var product dto.ProductResponse
+				r.Decode(t, &product)
+				if product.Slug != "widget" || product.TotalReviews != 1 || product.AvgRating == nil || *product.AvgRating != 4 {
+					t.Errorf("product = %+v, want widget with one 4-star review", product)
+				}
+			},
+		},
+		{Name: "get malformed id", Method: http.MethodGet, Path: "/api/v1/products/not-a-uuid", Status: http.StatusBadRequest, Code: "invalid_id"},
+		{Name: "get unknown", Method: http.MethodGet, Path: "/api/v1/products/00000000-0000-0000-0000-000000000001", Status: http.StatusNotFound, Code: "product_not_found"},
+		{
+			Name: "get by slug", Method: http.MethodGet, Path: "/api/v1/products/slug/gadget", Status: http.StatusOK,
+			Check: func(t *testing.T, r *apitest.Response) {
+				var product dto.ProductWithCompanyResponse
+				r.Decode(t, &product)
+				if product.CompanySlug != "acme" {
+					t.Errorf("company slug = %q, want acme", product.CompanySlug)
+				}
+			},
+		},
+		{
+			Name: "stats", Method: http.MethodGet, Path: widgetPath + "/stats", Status: http.StatusOK,
+			Check: func(t *testing.T, r *apitest.Response) {
+				var stats dto.ProductStatsResponse
+				r.Decode(t, &stats)
+				if stats.TotalReviews != 1 || stats.Histogram[4] != 1 {
+					t.Errorf("stats = %+v, want a single 4-star review", stats)
+				}
+			},
+		},
+		{Name: "rating events", Method: http.MethodGet, Path: widgetPath + "/rating-events", Status: http.StatusOK},
+		{
+			Name: "by company second page", Method: http.MethodGet, Path: "/api/v1/products/company/" + acme.ID.String() + "?limit=1&offset=1", Status: http.StatusOK,
+			Check: func(t *testing.T, r *apitest.Response) {
+				var list dto.ProductListResponse
+				r.Decode(t, &list)
+				if len(list.Products) != 1 || list.Total < 2 || list.Limit != 1 || list.Offset != 1 || list.PrevCursor == "" {
+					t.Errorf("page = %+v, want one product at offset 1 and a prev cursor", list)
+				}
+			},
+		},
+		{Name: "by company malformed id", Method: http.MethodGet, Path: "/api/v1/products/company/acme", Status: http.StatusBadRequest, Code: "invalid_id"},
+
+		{Name: "list bad sort", Method: http.MethodGet, Path: "/api/v1/products?sort=random", Status: http.StatusBadRequest, Code: "invalid_sort"},
+		{Name: "list forged cursor", Method: http.MethodGet, Path: "/api/v1/products?cursor=abc.def", Status: http.StatusBadRequest, Code: "invalid_cursor"},
+		{
+			Name: "list first page", Method: http.MethodGet, Path: "/api/v1/products?limit=1&sort=name", Status: http.StatusOK,
+			Check: func(t *testing.T, r *apitest.Response) {
+				var list dto.ProductListResponse
+				r.Decode(t, &list)
+				if list.Total != 2 || len(list.Products) != 1 || list.Products[0].Slug != "gadget" || list.NextCursor == "" {
+					t.Errorf("list = %+v, want gadget first of two with a next cursor", list)
+				}
+			},
+		},
+		{Name: "list by unknown category", Method: http.MethodGet, Path: "/api/v1/products/category/spaceships", Status: http.StatusBadRequest, Code: "invalid_category"},
+		{Name: "list by category", Method: http.MethodGet, Path: "/api/v1/products/category/hosting", Status: http.StatusOK},
+		{Name: "search without query", Method: http.MethodGet, Path: "/api/v1/products/search", Status: http.StatusBadRequest, Code: "missing_query"},
+		{Name: "search", Method: http.MethodGet, Path: "/api/v1/products/search?q=widget", Status: http.StatusOK},
+		{Name: "search forged cursor", Method: http.MethodGet, Path: "/api/v1/products/search?q=widget&cursor=abc.def", Status: http.StatusBadRequest, Code: "invalid_cursor"},
+
+		{Name: "create anonymously", Method: http.MethodPost, Path: "/api/v1/products", Body: map[string]string{"name": "Doohickey", "slug": "doohickey", "category": "hosting"}, Status: http.StatusUnauthorized, Code: "missing_token"},
+		{Name: "create without category", Method: http.MethodPost, Path: "/api/v1/products", Token: ownerToken, Body: map[string]string{"company_id": acme.ID.String(), "name": "Doohickey", "slug": "doohickey"}, Status: http.StatusBadRequest, Code: "validation_failed"},
+		{Name: "create unknown category", Method: http.MethodPost, Path: "/api/v1/products", Token: ownerToken, Body: map[string]string{"company_id": acme.ID.String(), "name": "Doohickey", "slug": "doohickey", "category": "spaceships"}, Status: http.StatusBadRequest, Code: "invalid_category"},
+		{Name: "create in foreign company", Method: http.MethodPost, Path: "/api/v1/products", Token: strangerToken, Body: map[string]string{"company_id": acme.ID.String(), "name": "Doohickey", "slug": "doohickey", "category": "hosting"}, Status: http.StatusForbidden, Code: "insufficient_company_role"},
+		{
+			Name: "create", Method: http.MethodPost, Path: "/api/v1/products", Token: ownerToken,
+			Body:   map[string]string{"company_id": acme.ID.String(), "name": "Doohickey", "slug": "doohickey", "category": "ci_cd"},
+			Status: http.StatusCreated,
+			Check: func(t *testing.T, r *apitest.Response) {
+				var product dto.ProductResponse
+				r.Decode(t, &product)
+				if product.CompanyID != acme.ID.String() || product.Category != "ci_cd" || product.TotalReviews != 0 {
+					t.Errorf("created = %+v", product)
+				}
+			},
+		},
+
+		{Name: "update as stranger", Method: http.MethodPut, Path: widgetPath, Token: strangerToken, Body: update, Status: http.StatusForbidden, Code: "insufficient_company_role"},
+		{Name: "update invalid url", Method: http.MethodPut, Path: widgetPath, Token: ownerToken, Body: map[string]string{"name": "Widget", "slug": "widget", "category": "hosting", "homepage_url": "nope"}, Status: http.StatusBadRequest, Code: "validation_failed"},
+		{Name: "update", Method: http.MethodPut, Path: widgetPath, Token: ownerToken, Body: update, Status: http.StatusOK},
+
+		{Name: "plans", Method: http.MethodGet, Path: widgetPath + "/plans", Status: http.StatusOK},
+		{Name: "create plan as stranger", Method: http.MethodPost, Path: widgetPath + "/plans", Token: strangerToken, Body: map[string]any{"name": "Pro", "billing_period": "monthly", "currency": "USD", "price_cents": 900}, Status: http.StatusForbidden},
+		{Name: "create plan bad period", Method: http.MethodPost, Path: widgetPath + "/plans", Token: ownerToken, Body: map[string]any{"name": "Pro", "billing_period": "weekly", "currency": "USD"}, Status: http.StatusBadRequest, Code: "validation_failed"},
+		{Name: "create plan", Method: http.MethodPost, Path: widgetPath + "/plans", Token: ownerToken, Body: map[string]any{"name": "Pro", "billing_period": "monthly", "currency": "USD", "price_cents": 900}, Status: http.StatusCreated},
+		{Name: "update unknown plan", Method: http.MethodPut, Path: widgetPath + "/plans/00000000-0000-0000-0000-000000000001", Token: ownerToken, Body: map[string]any{"name": "Pro", "billing_period": "yearly", "currency": "USD"}, Status: http.StatusNotFound},
+		{Name: "delete unknown plan", Method: http.MethodDelete, Path: widgetPath + "/plans/00000000-0000-0000-0000-000000000001", Token: ownerToken, Status: http.StatusNotFound},
+		{Name: "compare without products", Method: http.MethodGet, Path: "/api/v1/compare", Status: http.StatusBadRequest, Code: "missing_products"},
+		{Name: "compare", Method: http.MethodGet, Path: "/api/v1/compare?products=widget,gadget", Status: http.StatusOK},
+
+		{Name: "tags", Method: http.MethodGet, Path: "/api/v1/tags", Status: http.StatusOK},
+		{Name: "product tags", Method: http.MethodGet, Path: widgetPath + "/tags", Status: http.StatusOK},
+		{Name: "add tags as stranger", Method: http.MethodPost, Path: widgetPath + "/tags", Token: strangerToken, Body: map[string]any{"tags": []string{"kubernetes"}}, Status: http.StatusForbidden},
+		{Name: "add no tags", Method: http.MethodPost, Path: widgetPath + "/tags", Token: ownerToken, Body: map[string]any{"tags": []string{}}, Status: http.StatusBadRequest, Code: "validation_failed"},
+		{Name: "add tags", Method: http.MethodPost, Path: widgetPath + "/tags", Token: ownerToken, Body: map[string]any{"tags": []string{"kubernetes"}}, Status: http.StatusOK},
+		{Name: "remove tag", Method: http.MethodDelete, Path: widgetPath + "/tags/kubernetes", Token: ownerToken, Status: http.StatusOK},
+		{Name: "suggest tag", Method: http.MethodPost, Path: widgetPath + "/tag-suggestions", Token: strangerToken, Body: map[string]string{"tag": "serverless"}, Status: http.StatusCreated},
+		{Name: "suggestions as stranger", Method: http.MethodGet, Path: widgetPath + "/tag-suggestions", Token: strangerToken, Status: http.StatusForbidden},
+		{Name: "approve unknown suggestion", Method: http.MethodPost, Path: widgetPath + "/tag-suggestions/00000000-0000-0000-0000-000000000001/approve", Token: ownerToken, Body: map[string]string{}, Status: http.StatusNotFound, Code: "suggestion_not_found"},
+		{Name: "reject unknown suggestion", Method: http.MethodPost, Path: widgetPath + "/tag-suggestions/00000000-0000-0000-0000-000000000001/reject", Token: ownerToken, Body: map[string]string{}, Status: http.StatusNotFound, Code: "suggestion_not_found"},
+
+		{Name: "leaderboard", Method: http.MethodGet, Path: "/api/v1/leaderboard", Status: http.StatusOK},
+		{Name: "leaderboard forged cursor", Method: http.MethodGet, Path: "/api/v1/leaderboard?cursor=abc.def", Status: http.StatusBadRequest, Code: "invalid_cursor"},
+
+		{Name: "delete as stranger", Method: http.MethodDelete, Path: widgetPath, Token: strangerToken, Status: http.StatusForbidden, Code: "insufficient_company_role"},
+		{Name: "delete", Method: http.MethodDelete, Path: widgetPath, Token: ownerToken, Status: http.StatusOK},
+		{Name: "get deleted", Method: http.MethodGet, Path: widgetPath, Status: http.StatusNotFound, Code: "product_not_found"},
+	})
+}
+
+func TestCategoryFilterIncludesSubcategories(t *testing.T) {
+	s := apitest.New(t)
+	admin := s.Admin("admin")
+	owner := s.User("owner")
+	acme := s.Company(owner, "acme")
+	s.Product(owner, acme, "widget")
+	ownerToken, adminToken := s.Token(owner), s.Token(admin)
+
+	s.Run(t, []apitest.Case{
+		{Name: "create subcategory", Method: http.MethodPost, Path: "/api/v1/admin/categories", Token: adminToken, Body: map[string]string{"slug": "paas", "name": "PaaS", "parent": "hosting"}, Status: http.StatusCreated},
+		{Name: "create product in subcategory", Method: http.MethodPost, Path: "/api/v1/products", Token: ownerToken, Body: map[string]string{"company_id": acme.ID.String(), "name": "Widget Cloud", "slug": "widget-cloud", "category": "paas"}, Status: http.StatusCreated},
+		{
+			Name: "search parent category", Method: http.MethodGet, Path: "/api/v1/products/search?q=widget&category=hosting", Status: http.StatusOK,
+			Check: func(t *testing.T, r *apitest.Response) {
+				var body struct {
+					Products []dto.ProductSearchHitResponse `json:"products"`
+					Total    int64                          `json:"total"`
+				}
+				r.Decode(t, &body)
+				if body.Total != 2 || len(body.Products) != 2 {
+					t.Errorf("search = %+v, want the hosting and the paas product", body)
+				}
+			},
+		},
+		{
+			Name: "search subcategory", Method: http.MethodGet, Path: "/api/v1/products/search?q=widget&category=paas", Status: http.StatusOK,
+			Check: func(t *testing.T, r *apitest.Response) {
+				var body struct {
+					Products []dto.ProductSearchHitResponse `json:"products"`
+				}
+				r.Decode(t, &body)
+				if len(body.Products) != 1 || body.Products[0].Slug != "widget-cloud" {
+					t.Errorf("search = %+v, want only the paas product", body)
+				}
+			},
+		},
+		{
+			Name: "search by cursor", Method: http.MethodGet, Path: "/api/v1/products/search?q=widget&limit=1", Status: http.StatusOK,
+			Check: func(t *testing.T, r *apitest.Response) {
+				var first dto.ProductSearchResponse
+				r.Decode(t, &first)
+				if len(first.Products) != 1 || first.NextCursor == "" || r.Header.Get("Link") == "" {
+					t.Fatalf("first page = %+v, want one hit and a next link", first)
+				}
+
+				r = s.Do(http.MethodGet, "/api/v1/products/search?q=widget&limit=1&cursor="+first.NextCursor, nil, "")
+				var second dto.ProductSearchResponse
+				r.Decode(t, &second)
+				if len(second.Products) != 1 || second.Products[0].ID == first.Products[0].ID || second.NextCursor != "" || second.PrevCursor == "" {
+					t.Errorf("second page = %+v, want the other hit and only a prev cursor", second)
+				}
+			},
+		},
+	})
+}
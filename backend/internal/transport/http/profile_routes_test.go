@@ -0,0 +1,94 @@
+package http_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"ratemysoft-backend/internal/services"
+	"ratemysoft-backend/internal/transport/http/apitest"
+	"ratemysoft-backend/internal/transport/http/dto"
+)
+
+func TestProfileRoutes(t *testing.T) {
+	s := apitest.New(t)
+	owner := s.User("owner")
+	s.User("bob")
+	widget := s.Product(owner, s.Company(owner, "acme"), "widget")
+
+	// Fixture users have no password, and changing the email takes one
+	alice, err := services.NewUserService(s.Store).CreateUser(context.Background(), services.CreateUserRequest{
+		Email: "alice@example.com", Handle: "alice", Password: "correct horse",
+	})
+	if err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+	aliceToken := s.Token(alice)
+	review := s.Review(alice, widget, 4)
+
+	profile := func(handle, email string, verified bool) func(t *testing.T, r *apitest.Response) {
+		return func(t *testing.T, r *apitest.Response) {
+			var user dto.UserResponse
+			r.Decode(t, &user)
+			if user.ID != alice.ID.String() || user.Handle != handle || user.Email != email || user.EmailVerified != verified {
+				t.Errorf("profile = %+v, want %s <%s> verified=%t", user, handle, email, verified)
+			}
+		}
+	}
+
+	s.Run(t, []apitest.Case{
+		{Name: "get", Method: http.MethodGet, Path: "/api/v1/auth/profile", Token: aliceToken, Status: http.StatusOK, Check: profile("alice", "alice@example.com", false)},
+		{Name: "update anonymously", Method: http.MethodPut, Path: "/api/v1/auth/profile", Body: map[string]string{"handle": "alicia"}, Status: http.StatusUnauthorized, Code: "missing_token"},
+		{Name: "update short handle", Method: http.MethodPut, Path: "/api/v1/auth/profile", Token: aliceToken, Body: map[string]string{"handle": "al"}, Status: http.StatusBadRequest, Code: "validation_failed"},
+		{Name: "update taken handle", Method: http.MethodPut, Path: "/api/v1/auth/profile", Token: aliceToken, Body: map[string]string{"handle": "bob"}, Status: http.StatusConflict, Code: "handle_taken"},
+		{Name: "update handle", Method: http.MethodPut, Path: "/api/v1/auth/profile", Token: aliceToken, Body: map[string]string{"handle": "alicia"}, Status: http.StatusOK, Check: profile("alicia", "alice@example.com", false)},
+		{Name: "get reads the account", Method: http.MethodGet, Path: "/api/v1/auth/profile", Token: aliceToken, Status: http.StatusOK, Check: profile("alicia", "alice@example.com", false)},
+
+		{Name: "update email without password", Method: http.MethodPut, Path: "/api/v1/auth/profile", Token: aliceToken, Body: map[string]string{"email": "alice@new.example"}, Status: http.StatusBadRequest, Code: "current_password_required"},
+		{Name: "update email wrong password", Method: http.MethodPut, Path: "/api/v1/auth/profile", Token: aliceToken, Body: map[string]string{"email": "alice@new.example", "current_password": "wrong horse"}, Status: http.StatusBadRequest, Code: "invalid_current_password"},
+		{Name: "update taken email", Method: http.MethodPut, Path: "/api/v1/auth/profile", Token: aliceToken, Body: map[string]string{"email": "bob@example.com", "current_password": "correct horse"}, Status: http.StatusConflict, Code: "email_taken"},
+		{
+			Name: "update email", Method: http.MethodPut, Path: "/api/v1/auth/profile", Token: aliceToken,
+			Body:   map[string]string{"email": "Alice@New.example", "current_password": "correct horse"},
+			Status: http.StatusOK,
+			Check: func(t *testing.T, r *apitest.Response) {
+				profile("alicia", "alice@new.example", false)(t, r)
+				if msg := s.Mail.Last(t, "alice@example.com"); msg.Subject != "Your email address was changed" {
+					t.Errorf("mail to the old address: %q", msg.Subject)
+				}
+				if msg := s.Mail.Last(t, "alice@new.example"); msg.Subject != "Confirm your email address" {
+					t.Errorf("mail to the new address: %q", msg.Subject)
+				}
+			},
+		},
+
+		{Name: "update short password", Method: http.MethodPut, Path: "/api/v1/auth/profile", Token: aliceToken, Body: map[string]string{"password": "short", "current_password": "correct horse"}, Status: http.StatusBadRequest, Code: "validation_failed"},
+		{Name: "update password", Method: http.MethodPut, Path: "/api/v1/auth/profile", Token: aliceToken, Body: map[string]string{"password": "battery staple", "current_password": "correct horse"}, Status: http.StatusOK},
+		{
+			Name: "login with new password", Method: http.MethodPost, Path: "/api/v1/auth/login",
+			Body:   map[string]string{"email": "alice@new.example", "password": "battery staple"},
+			Status: http.StatusOK,
+		},
+
+		{Name: "delete without password", Method: http.MethodDelete, Path: "/api/v1/auth/profile", Token: aliceToken, Status: http.StatusBadRequest, Code: "password_required"},
+		{Name: "delete wrong password", Method: http.MethodDelete, Path: "/api/v1/auth/profile", Token: aliceToken, Body: map[string]string{"password": "correct horse"}, Status: http.StatusBadRequest, Code: "invalid_password"},
+		{Name: "delete", Method: http.MethodDelete, Path: "/api/v1/auth/profile", Token: aliceToken, Body: map[string]string{"password": "battery staple"}, Status: http.StatusOK},
+		{Name: "review outlives its author", Method: http.MethodGet, Path: "/api/v1/reviews/" + review.ID.String(), Status: http.StatusOK},
+		{
+			Name: "reviews of a deleted author are not listed together", Method: http.MethodGet, Path: "/api/v1/reviews/user/" + alice.ID.String(),
+			Status: http.StatusOK,
+			Check: func(t *testing.T, r *apitest.Response) {
+				var list dto.ReviewListResponse
+				r.Decode(t, &list)
+				if len(list.Reviews) != 0 {
+					t.Errorf("reviews = %+v, want none", list.Reviews)
+				}
+			},
+		},
+	})
+
+	// Deleting ends the sessions, so the token stops working with the account
+	if r := s.Do(http.MethodGet, "/api/v1/auth/profile", nil, aliceToken); r.Code != http.StatusUnauthorized {
+		t.Errorf("profile after delete: status = %d, want %d; body: %s", r.Code, http.StatusUnauthorized, r.Body)
+	}
+}
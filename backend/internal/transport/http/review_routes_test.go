@@ -0,0 +1,123 @@
+package http_test
+
+import (
+	"net/http"
+	"testing"
+
+	"ratemysoft-backend/internal/transport/http/apitest"
+	"ratemysoft-backend/internal/transport/http/dto"
+)
+
+func TestReviewRoutes(t *testing.T) {
+	s := apitest.New(t)
+	owner := s.User("owner")
+	alice := s.User("alice")
+	bob := s.User("bob")
+	widget := s.Product(owner, s.Company(owner, "acme"), "widget")
+	review := s.Review(alice, widget, 5)
+
+	aliceToken, bobToken, ownerToken := s.Token(alice), s.Token(bob), s.Token(owner)
+	reviewPath := "/api/v1/reviews/" + review.ID.String()
+	body := "Fast deploys and clear docs."
+
+	s.Run(t, []apitest.Case{
+		{
+			Name: "get", Method: http.MethodGet, Path: reviewPath, Status: http.StatusOK,
+			Check: func(t *testing.T, r *apitest.Response) {
+				var got dto.ReviewResponse
+				r.Decode(t, &got)
+				if got.Rating != 5 || got.Status != "published" || got.MyVote != "" {
+					t.Errorf("review = %+v", got)
+				}
+			},
+		},
+		{Name: "get malformed id", Method: http.MethodGet, Path: "/api/v1/reviews/not-a-uuid", Status: http.StatusBadRequest, Code: "invalid_id"},
+		{Name: "get unknown", Method: http.MethodGet, Path: "/api/v1/reviews/00000000-0000-0000-0000-000000000001", Status: http.StatusNotFound, Code: "review_not_found"},
+		{Name: "by product", Method: http.MethodGet, Path: "/api/v1/reviews/product/" + widget.ID.String() + "?limit=1", Status: http.StatusOK},
+		{Name: "by user", Method: http.MethodGet, Path: "/api/v1/reviews/user/" + alice.ID.String(), Status: http.StatusOK},
+		{Name: "by product forged cursor", Method: http.MethodGet, Path: "/api/v1/reviews/product/" + widget.ID.String() + "?cursor=abc.def", Status: http.StatusBadRequest, Code: "invalid_cursor"},
+
+		{Name: "create anonymously", Method: http.MethodPost, Path: "/api/v1/reviews", Body: map[string]any{"product_id": widget.ID.String(), "body": body, "rating": 4}, Status: http.StatusUnauthorized, Code: "missing_token"},
+		{Name: "create short body", Method: http.MethodPost, Path: "/api/v1/reviews", Token: bobToken, Body: map[string]any{"product_id": widget.ID.String(), "body": "meh", "rating": 4}, Status: http.StatusBadRequest, Code: "validation_failed"},
+		{Name: "create bad rating", Method: http.MethodPost, Path: "/api/v1/reviews", Token: bobToken, Body: map[string]any{"product_id": widget.ID.String(), "body": body, "rating": 6}, Status: http.StatusBadRequest, Code: "validation_failed"},
+		{Name: "create for unknown product", Method: http.MethodPost, Path: "/api/v1/reviews", Token: bobToken, Body: map[string]any{"product_id": "00000000-0000-0000-0000-000000000001", "body": body, "rating": 4}, Status: http.StatusNotFound, Code: "product_not_found"},
+		{Name: "create duplicate", Method: http.MethodPost, Path: "/api/v1/reviews", Token: aliceToken, Body: map[string]any{"product_id": widget.ID.String(), "body": body, "rating": 4}, Status: http.StatusConflict, Code: "already_reviewed"},
+		{
+			Name: "create", Method: http.MethodPost, Path: "/api/v1/reviews", Token: bobToken,
+			Body:   map[string]any{"product_id": widget.ID.String(), "body": body, "rating": 3, "sub_ratings": map[string]int{"docs": 4}},
+			Status: http.StatusCreated,
+			Check: func(t *testing.T, r *apitest.Response) {
+				var created dto.ReviewResponse
+				r.Decode(t, &created)
+				if created.UserID != bob.ID.String() || created.SubRatings["docs"] != 4 {
+					t.Errorf("created = %+v", created)
+				}
+			},
+		},
+
+		{Name: "update as someone else", Method: http.MethodPut, Path: reviewPath, Token: bobToken, Body: map[string]any{"body": body, "rating": 1}, Status: http.StatusForbidden, Code: "not_review_author"},
+		{Name: "update", Method: http.MethodPut, Path: reviewPath, Token: aliceToken, Body: map[string]any{"body": body, "rating": 4}, Status: http.StatusOK},
+		{
+			Name: "revisions as author", Method: http.MethodGet, Path: reviewPath + "/revisions", Token: aliceToken, Status: http.StatusOK,
+			Check: func(t *testing.T, r *apitest.Response) {
+				var list dto.ReviewRevisionListResponse
+				r.Decode(t, &list)
+				if len(list.Revisions) != 1 || list.Revisions[0].Body == "" {
+					t.Errorf("revisions = %+v, want the original text", list)
+				}
+			},
+		},
+		{
+			Name: "revisions anonymously", Method: http.MethodGet, Path: reviewPath + "/revisions", Status: http.StatusOK,
+			Check: func(t *testing.T, r *apitest.Response) {
+				var list dto.ReviewRevisionListResponse
+				r.Decode(t, &list)
+				if len(list.Revisions) != 1 || list.Revisions[0].Body != "" {
+					t.Errorf("revisions = %+v, want a summary without text", list)
+				}
+			},
+		},
+
+		{Name: "upvote own review", Method: http.MethodPost, Path: reviewPath + "/upvote", Token: aliceToken, Status: http.StatusForbidden, Code: "own_review"},
+		{Name: "upvote", Method: http.MethodPost, Path: reviewPath + "/upvote", Token: bobToken, Status: http.StatusOK},
+		{
+			Name: "downvote", Method: http.MethodPost, Path: reviewPath + "/downvote", Token: ownerToken, Status: http.StatusOK,
+			Check: func(t *testing.T, r *apitest.Response) {
+				var vote dto.ReviewVoteResponse
+				r.Decode(t, &vote)
+				if vote.Vote != "down" || vote.UpvoteCount != 1 || vote.DownvoteCount != 1 {
+					t.Errorf("vote = %+v, want one of each", vote)
+				}
+			},
+		},
+		{
+			Name: "get with my vote", Method: http.MethodGet, Path: reviewPath, Token: bobToken, Status: http.StatusOK,
+			Check: func(t *testing.T, r *apitest.Response) {
+				var got dto.ReviewResponse
+				r.Decode(t, &got)
+				if got.MyVote != "up" {
+					t.Errorf("my_vote = %q, want up", got.MyVote)
+				}
+			},
+		},
+		{Name: "retract vote", Method: http.MethodDelete, Path: reviewPath + "/vote", Token: bobToken, Status: http.StatusOK},
+
+		{Name: "flag own review", Method: http.MethodPost, Path: reviewPath + "/flag", Token: aliceToken, Body: map[string]string{"reason": "spam"}, Status: http.StatusForbidden, Code: "own_review"},
+		{Name: "flag bad reason", Method: http.MethodPost, Path: reviewPath + "/flag", Token: bobToken, Body: map[string]string{"reason": "boring"}, Status: http.StatusBadRequest, Code: "validation_failed"},
+		{Name: "flag", Method: http.MethodPost, Path: reviewPath + "/flag", Token: bobToken, Body: map[string]string{"reason": "spam"}, Status: http.StatusOK},
+		{Name: "unflag", Method: http.MethodDelete, Path: reviewPath + "/flag", Token: bobToken, Status: http.StatusOK},
+
+		{Name: "comments", Method: http.MethodGet, Path: reviewPath + "/comments", Status: http.StatusOK},
+		{Name: "comment empty body", Method: http.MethodPost, Path: reviewPath + "/comments", Token: bobToken, Body: map[string]string{}, Status: http.StatusBadRequest, Code: "validation_failed"},
+		{Name: "comment", Method: http.MethodPost, Path: reviewPath + "/comments", Token: bobToken, Body: map[string]string{"body": "Agreed"}, Status: http.StatusCreated},
+		{Name: "official response as outsider", Method: http.MethodPost, Path: reviewPath + "/comments", Token: bobToken, Body: map[string]any{"body": "Thanks!", "official": true}, Status: http.StatusForbidden, Code: "not_company_member"},
+		{Name: "update unknown comment", Method: http.MethodPut, Path: "/api/v1/reviews/comments/00000000-0000-0000-0000-000000000001", Token: bobToken, Body: map[string]string{"body": "Edited"}, Status: http.StatusNotFound, Code: "comment_not_found"},
+		{Name: "delete unknown comment", Method: http.MethodDelete, Path: "/api/v1/reviews/comments/00000000-0000-0000-0000-000000000001", Token: bobToken, Status: http.StatusNotFound, Code: "comment_not_found"},
+		{Name: "flag unknown comment", Method: http.MethodPost, Path: "/api/v1/reviews/comments/00000000-0000-0000-0000-000000000001/flag", Token: bobToken, Body: map[string]string{}, Status: http.StatusNotFound, Code: "comment_not_found"},
+		{Name: "unflag comment anonymously", Method: http.MethodDelete, Path: "/api/v1/reviews/comments/00000000-0000-0000-0000-000000000001/flag", Status: http.StatusUnauthorized, Code: "missing_token"},
+
+		{Name: "delete as someone else", Method: http.MethodDelete, Path: reviewPath, Token: bobToken, Status: http.StatusForbidden, Code: "not_review_author"},
+		{Name: "delete", Method: http.MethodDelete, Path: reviewPath, Token: aliceToken, Status: http.StatusOK},
+		{Name: "get deleted", Method: http.MethodGet, Path: reviewPath, Status: http.StatusNotFound, Code: "review_not_found"},
+	})
+}
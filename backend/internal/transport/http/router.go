@@ -30,12 +30,22 @@ func SetupRoutes(e *echo.Echo, h *handlers.Handler, jwtService *auth.JWTService)
 	authGroup := v1.Group("/auth")
 	authGroup.POST("/login", h.Login)
 	authGroup.POST("/register", h.Register)
+	authGroup.POST("/refresh", h.RefreshToken)
+	authGroup.POST("/verify-email/confirm", h.ConfirmEmailVerification)
+	authGroup.POST("/password-reset/request", h.RequestPasswordReset)
+	authGroup.POST("/password-reset/confirm", h.ConfirmPasswordReset)
+	authGroup.GET("/oauth/providers", h.ListOAuthProviders)
+	authGroup.GET("/oauth/:provider/start", h.StartOAuthLogin)  // Redirects to the provider
+	authGroup.GET("/oauth/:provider/callback", h.OAuthCallback) // Provider redirects back here
 
 	// Protected auth routes (require authentication)
 	authProtected := v1.Group("/auth", middleware.AuthMiddleware(jwtService))
 	authProtected.GET("/profile", h.GetProfile)
-	// authProtected.POST("/logout", h.Logout)
-	// authProtected.PUT("/profile", h.UpdateProfile)
+	authProtected.PUT("/profile", h.UpdateProfile)
+	authProtected.DELETE("/profile", h.DeleteProfile)
+	authProtected.POST("/logout", h.Logout)
+	authProtected.POST("/logout-all", h.LogoutAll)
+	authProtected.POST("/verify-email/request", h.RequestEmailVerification)
 
 	// Company routes - mixed public and protected
 	companies := v1.Group("/companies")
@@ -48,6 +58,10 @@ func SetupRoutes(e *echo.Echo, h *handlers.Handler, jwtService *auth.JWTService)
 	companies.POST("", h.CreateCompany, middleware.AuthMiddleware(jwtService))
 	companies.PUT("/:id", h.UpdateCompany, middleware.AuthMiddleware(jwtService))
 	companies.DELETE("/:id", h.DeleteCompany, middleware.AuthMiddleware(jwtService))
+	companies.POST("/:id/claim", h.ClaimCompany, middleware.AuthMiddleware(jwtService))
+	companies.GET("/:id/members", h.ListCompanyMembers, middleware.AuthMiddleware(jwtService))
+	companies.PUT("/:id/members", h.SetCompanyMember, middleware.AuthMiddleware(jwtService))
+	companies.DELETE("/:id/members/:userId", h.RemoveCompanyMember, middleware.AuthMiddleware(jwtService))
 
 	// Product routes - mixed public and protected
 	products := v1.Group("/products")
@@ -56,18 +70,50 @@ func SetupRoutes(e *echo.Echo, h *handlers.Handler, jwtService *auth.JWTService)
 	products.GET("/category/:category", h.ListProductsByCategory) // Public
 	products.GET("/company/:companyId", h.GetProductsByCompany)   // Public
 	products.GET("/:id", h.GetProduct)                            // Public
+	products.GET("/:id/stats", h.GetProductStats)                 // Public
+	products.GET("/:id/rating-events", h.GetProductRatingEvents)  // Public
 	products.GET("/slug/:slug", h.GetProductBySlug)               // Public
+	products.GET("/:id/plans", h.ListPricingPlans)                // Public
+	products.GET("/:id/tags", h.ListProductTags)                  // Public
 
 	// Protected product routes (require auth)
 	products.POST("", h.CreateProduct, middleware.AuthMiddleware(jwtService))
 	products.PUT("/:id", h.UpdateProduct, middleware.AuthMiddleware(jwtService))
 	products.DELETE("/:id", h.DeleteProduct, middleware.AuthMiddleware(jwtService))
+	products.POST("/:id/plans", h.CreatePricingPlan, middleware.AuthMiddleware(jwtService))
+	products.PUT("/:id/plans/:planId", h.UpdatePricingPlan, middleware.AuthMiddleware(jwtService))
+	products.DELETE("/:id/plans/:planId", h.DeletePricingPlan, middleware.AuthMiddleware(jwtService))
+	products.POST("/:id/tags", h.AddProductTags, middleware.AuthMiddleware(jwtService))
+	products.DELETE("/:id/tags/:tag", h.RemoveProductTag, middleware.AuthMiddleware(jwtService))
+	products.POST("/:id/tag-suggestions", h.SuggestProductTag, middleware.AuthMiddleware(jwtService))
+	products.GET("/:id/tag-suggestions", h.ListTagSuggestions, middleware.AuthMiddleware(jwtService))
+	products.POST("/:id/tag-suggestions/:suggestionId/approve", h.ApproveTagSuggestion, middleware.AuthMiddleware(jwtService))
+	products.POST("/:id/tag-suggestions/:suggestionId/reject", h.RejectTagSuggestion, middleware.AuthMiddleware(jwtService))
+
+	// Category routes (public; managed under /admin/categories)
+	v1.GET("/categories", h.ListCategories)
+	v1.GET("/categories/:slug", h.GetCategory)
+
+	// Tags (public)
+	v1.GET("/tags", h.ListTags)
+
+	// Pricing comparison (public)
+	v1.GET("/compare", h.ComparePricing)
+
+	// Leaderboard (public)
+	v1.GET("/leaderboard", h.GetLeaderboard)
+
+	// Public reviewer profiles; a user's reviews are listed under /reviews/user/:userId
+	v1.GET("/users/:handle", h.GetPublicProfile)
 
 	// Review routes - mixed public and protected
+	// Public review routes accept an optional token so responses can include the caller's vote
 	reviews := v1.Group("/reviews")
-	reviews.GET("/product/:productId", h.GetReviewsByProduct) // Public
-	reviews.GET("/user/:userId", h.GetReviewsByUser)          // Public
-	reviews.GET("/:id", h.GetReview)                          // Public
+	reviews.GET("/product/:productId", h.GetReviewsByProduct, middleware.OptionalAuthMiddleware(jwtService)) // Public
+	reviews.GET("/user/:userId", h.GetReviewsByUser, middleware.OptionalAuthMiddleware(jwtService))          // Public
+	reviews.GET("/:id", h.GetReview, middleware.OptionalAuthMiddleware(jwtService))                          // Public
+	reviews.GET("/:id/comments", h.ListReviewComments)                                                       // Public
+	reviews.GET("/:id/revisions", h.GetReviewRevisions, middleware.OptionalAuthMiddleware(jwtService))       // Public summary; full text for author and admins
 
 	// Protected review routes (require auth)
 	reviews.POST("", h.CreateReview, middleware.AuthMiddleware(jwtService))
@@ -75,5 +121,51 @@ func SetupRoutes(e *echo.Echo, h *handlers.Handler, jwtService *auth.JWTService)
 	reviews.DELETE("/:id", h.DeleteReview, middleware.AuthMiddleware(jwtService))
 	reviews.POST("/:id/upvote", h.UpvoteReview, middleware.AuthMiddleware(jwtService))
 	reviews.POST("/:id/downvote", h.DownvoteReview, middleware.AuthMiddleware(jwtService))
+	reviews.DELETE("/:id/vote", h.RetractReviewVote, middleware.AuthMiddleware(jwtService))
 	reviews.POST("/:id/flag", h.FlagReview, middleware.AuthMiddleware(jwtService))
+	reviews.DELETE("/:id/flag", h.UnflagReview, middleware.AuthMiddleware(jwtService))
+	reviews.POST("/:id/comments", h.CreateReviewComment, middleware.AuthMiddleware(jwtService))
+	reviews.PUT("/comments/:commentId", h.UpdateReviewComment, middleware.AuthMiddleware(jwtService))
+	reviews.DELETE("/comments/:commentId", h.DeleteReviewComment, middleware.AuthMiddleware(jwtService))
+	reviews.POST("/comments/:commentId/flag", h.FlagReviewComment, middleware.AuthMiddleware(jwtService))
+	reviews.DELETE("/comments/:commentId/flag", h.UnflagReviewComment, middleware.AuthMiddleware(jwtService))
+
+	// Admin routes (require auth + admin role)
+	admin := v1.Group("/admin", middleware.AuthMiddleware(jwtService), middleware.RequireAdmin())
+
+	// Review moderation queue
+	adminReviews := admin.Group("/reviews")
+	adminReviews.GET("/pending", h.ListPendingReviews)
+	adminReviews.GET("/flagged", h.ListFlaggedReviews)
+	adminReviews.GET("/:id/moderations", h.GetModerationHistory)
+	adminReviews.GET("/:id/flags", h.GetReviewFlags)
+	adminReviews.POST("/:id/approve", h.ApproveReview)
+	adminReviews.POST("/:id/reject", h.RejectReview)
+
+	// Comment moderation queue; decisions land in the review's moderation history
+	adminComments := admin.Group("/comments")
+	adminComments.GET("/pending", h.ListPendingComments)
+	adminComments.GET("/flagged", h.ListFlaggedComments)
+	adminComments.POST("/:id/approve", h.ApproveComment)
+	adminComments.POST("/:id/reject", h.RejectComment)
+
+	// Company claim verification
+	adminClaims := admin.Group("/company-claims")
+	adminClaims.GET("", h.ListCompanyClaims)
+	adminClaims.POST("/:id/approve", h.ApproveCompanyClaim)
+	adminClaims.POST("/:id/reject", h.RejectCompanyClaim)
+
+	// Accounts locked after too many failed sign-ins
+	admin.GET("/locked-accounts", h.ListLockedAccounts)
+	admin.POST("/users/:id/unlock", h.UnlockAccount)
+
+	// Audit log of changes to companies, products, reviews and users
+	admin.GET("/audit-events", h.ListAuditEvents)
+	admin.GET("/audit-events/export", h.ExportAuditEvents)
+
+	// Category management
+	adminCategories := admin.Group("/categories")
+	adminCategories.POST("", h.CreateCategory)
+	adminCategories.PUT("/:id", h.UpdateCategory)
+	adminCategories.DELETE("/:id", h.DeleteCategory)
 }
@@ -0,0 +1,101 @@
+package http_test
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"ratemysoft-backend/internal/transport/http/apitest"
+	"ratemysoft-backend/internal/transport/http/dto"
+)
+
+func TestPublicProfileRoutes(t *testing.T) {
+	s := apitest.New(t)
+
+	owner := s.User("owner")
+	alice := s.User("alice")
+	bob := s.User("bob")
+	carol := s.User("carol")
+	acme := s.Company(owner, "acme")
+	widget := s.Product(owner, acme, "widget")
+	gadget := s.Product(owner, acme, "gadget")
+
+	praised := s.Review(alice, widget, 5)
+	latest := s.Review(bob, widget, 3)
+	s.Review(bob, gadget, 4)
+
+	if r := s.Do(http.MethodPost, "/api/v1/reviews/"+praised.ID.String()+"/upvote", nil, s.Token(carol)); r.Code != http.StatusOK {
+		t.Fatalf("upvote: status = %d; body: %s", r.Code, r.Body)
+	}
+	s.RefreshReputation()
+
+	profile := func(want dto.PublicProfileResponse) func(t *testing.T, r *apitest.Response) {
+		return func(t *testing.T, r *apitest.Response) {
+			var got dto.PublicProfileResponse
+			r.Decode(t, &got)
+			if got.Handle != want.Handle || got.ReviewCount != want.ReviewCount ||
+				got.HelpfulVotes != want.HelpfulVotes || got.Reputation != want.Reputation ||
+				len(got.Badges) != len(want.Badges) || got.JoinedAt.IsZero() {
+				t.Fatalf("profile = %+v, want %+v", got, want)
+			}
+			for i, badge := range want.Badges {
+				if got.Badges[i] != badge {
+					t.Errorf("badge %d = %+v, want %+v", i, got.Badges[i], badge)
+				}
+			}
+		}
+	}
+
+	reviewsPath := "/api/v1/reviews/product/" + widget.ID.String()
+
+	s.Run(t, []apitest.Case{
+		{
+			// 1 helpful vote + 5 for the published review; tenure counts from the first month
+			Name: "top reviewer", Method: http.MethodGet, Path: "/api/v1/users/alice", Status: http.StatusOK,
+			Check: profile(dto.PublicProfileResponse{
+				Handle: "alice", ReviewCount: 1, HelpfulVotes: 1, Reputation: 6,
+				Badges: []dto.ReviewerBadgeResponse{{Label: "Top reviewer in Web Hosting", Category: "hosting", Rank: 1, HelpfulVotes: 1}},
+			}),
+		},
+		{
+			Name: "reviewer without votes", Method: http.MethodGet, Path: "/api/v1/users/bob", Status: http.StatusOK,
+			Check: profile(dto.PublicProfileResponse{Handle: "bob", ReviewCount: 2, Reputation: 10}),
+		},
+		{
+			Name: "user without reviews", Method: http.MethodGet, Path: "/api/v1/users/carol", Status: http.StatusOK,
+			Check: profile(dto.PublicProfileResponse{Handle: "carol"}),
+		},
+		{Name: "unknown handle", Method: http.MethodGet, Path: "/api/v1/users/nobody", Status: http.StatusNotFound, Code: "user_not_found"},
+
+		{
+			Name: "recent sort", Method: http.MethodGet, Path: reviewsPath, Status: http.StatusOK,
+			Check: func(t *testing.T, r *apitest.Response) {
+				var list dto.ReviewListResponse
+				r.Decode(t, &list)
+				if len(list.Reviews) != 2 || list.Reviews[0].ID != latest.ID.String() {
+					t.Errorf("reviews = %+v, want the newest first", list.Reviews)
+				}
+			},
+		},
+		{
+			Name: "helpful sort pages", Method: http.MethodGet, Path: reviewsPath + "?sort=helpful&limit=1", Status: http.StatusOK,
+			Check: func(t *testing.T, r *apitest.Response) {
+				var first dto.ReviewListResponse
+				r.Decode(t, &first)
+				if len(first.Reviews) != 1 || first.Reviews[0].ID != praised.ID.String() || first.NextCursor == "" {
+					t.Fatalf("first page = %+v, want the upvoted review", first)
+				}
+
+				next := s.Do(http.MethodGet, reviewsPath+"?sort=helpful&limit=1&cursor="+url.QueryEscape(first.NextCursor), nil, "")
+				var second dto.ReviewListResponse
+				next.Decode(t, &second)
+				if next.Code != http.StatusOK || len(second.Reviews) != 1 || second.Reviews[0].ID != latest.ID.String() {
+					t.Errorf("second page = %d %+v", next.Code, second)
+				}
+			},
+		},
+
+		{Name: "delete account", Method: http.MethodDelete, Path: "/api/v1/auth/profile", Token: s.Token(bob), Body: map[string]any{}, Status: http.StatusOK},
+		{Name: "deleted account", Method: http.MethodGet, Path: "/api/v1/users/bob", Status: http.StatusNotFound, Code: "user_not_found"},
+	})
+}
@@ -1,6 +1,9 @@
 package utils
 
 import (
+	"reflect"
+	"strings"
+
 	"github.com/go-playground/validator/v10"
 )
 
@@ -10,8 +13,19 @@ type CustomValidator struct {
 }
 
 func NewValidator() *CustomValidator {
+	v := validator.New()
+
+	// Report fields by their JSON name so validation errors match the request body
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+		if name == "" || name == "-" {
+			return field.Name
+		}
+		return name
+	})
+
 	return &CustomValidator{
-		validator: validator.New(),
+		validator: v,
 	}
 }
 
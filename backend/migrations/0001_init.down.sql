@@ -0,0 +1,16 @@
+-- Migration: 0001_init.down.sql
+-- Description: Drop the initial schema
+-- Author: RateMySoft Team
+-- Created: 2024
+
+-- Drop tables (triggers go with them)
+DROP TABLE IF EXISTS credentials;
+DROP TABLE IF EXISTS reviews;
+DROP TABLE IF EXISTS products;
+DROP TABLE IF EXISTS companies;
+DROP TABLE IF EXISTS users;
+
+-- Drop updated_at trigger function
+DROP FUNCTION IF EXISTS update_updated_at_column();
+
+DROP EXTENSION IF EXISTS "uuid-ossp";
@@ -1,4 +1,4 @@
--- Migration: 0001_init.sql
+-- Migration: 0001_init.up.sql
 -- Description: Initial database schema for RateMySoft
 -- Author: RateMySoft Team
 -- Created: 2024
@@ -0,0 +1,7 @@
+-- Migration: 0002_review_moderation.down.sql
+-- Description: Drop review moderation decisions
+-- Author: RateMySoft Team
+-- Created: 2025
+
+DROP INDEX IF EXISTS idx_reviews_flag_count;
+DROP TABLE IF EXISTS review_moderations;
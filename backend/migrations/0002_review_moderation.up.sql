@@ -0,0 +1,22 @@
+-- Migration: 0002_review_moderation.up.sql
+-- Description: Moderation decisions for pending and flagged reviews
+-- Author: RateMySoft Team
+-- Created: 2025
+
+-- Create review_moderations table (one row per approve/reject decision)
+CREATE TABLE review_moderations (
+  id uuid PRIMARY KEY,
+  review_id uuid NOT NULL REFERENCES reviews(id) ON DELETE CASCADE,
+  moderator_id uuid NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  from_status text NOT NULL,
+  to_status text NOT NULL,
+  reason text,
+  created_at timestamptz NOT NULL
+);
+
+-- Create indexes for review_moderations
+CREATE INDEX idx_review_moderations_review ON review_moderations(review_id);
+CREATE INDEX idx_review_moderations_moderator ON review_moderations(moderator_id);
+
+-- Speed up the flagged queue
+CREATE INDEX idx_reviews_flag_count ON reviews(flag_count) WHERE deleted_at IS NULL;
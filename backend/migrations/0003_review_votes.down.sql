@@ -0,0 +1,7 @@
+-- Migration: 0003_review_votes.down.sql
+-- Description: Drop the vote and flag ledgers (review counters are left as they are)
+-- Author: RateMySoft Team
+-- Created: 2025
+
+DROP TABLE IF EXISTS review_flags;
+DROP TABLE IF EXISTS review_votes;
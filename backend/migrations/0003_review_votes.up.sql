@@ -0,0 +1,40 @@
+-- Migration: 0003_review_votes.up.sql
+-- Description: Per-user vote and flag ledgers backing the review counters
+-- Author: RateMySoft Team
+-- Created: 2025
+
+-- Create review_votes table (at most one vote per user per review; no row means no vote)
+CREATE TABLE review_votes (
+  review_id uuid NOT NULL REFERENCES reviews(id) ON DELETE CASCADE,
+  user_id uuid NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  vote text NOT NULL CHECK (vote IN ('up', 'down')),
+  created_at timestamptz NOT NULL,
+  updated_at timestamptz NOT NULL,
+  PRIMARY KEY (review_id, user_id)
+);
+
+-- Create indexes for review_votes
+CREATE INDEX idx_review_votes_user ON review_votes(user_id);
+
+-- Create review_flags table (at most one flag per user per review)
+CREATE TABLE review_flags (
+  review_id uuid NOT NULL REFERENCES reviews(id) ON DELETE CASCADE,
+  user_id uuid NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  reason text NOT NULL CHECK (reason IN ('spam', 'offensive', 'off_topic', 'conflict_of_interest', 'fake', 'other')),
+  note text,
+  created_at timestamptz NOT NULL,
+  updated_at timestamptz NOT NULL,
+  PRIMARY KEY (review_id, user_id)
+);
+
+-- Create indexes for review_flags
+CREATE INDEX idx_review_flags_user ON review_flags(user_id);
+
+-- Create triggers for updated_at
+CREATE TRIGGER update_review_votes_updated_at 
+    BEFORE UPDATE ON review_votes
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TRIGGER update_review_flags_updated_at 
+    BEFORE UPDATE ON review_flags
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,8 @@
+-- Migration: 0004_sessions.down.sql
+-- Description: Drop sessions, refresh tokens and the access token deny-list
+-- Author: RateMySoft Team
+-- Created: 2025
+
+DROP TABLE IF EXISTS revoked_access_tokens;
+DROP TABLE IF EXISTS refresh_tokens;
+DROP TABLE IF EXISTS sessions;
@@ -0,0 +1,44 @@
+-- Migration: 0004_sessions.up.sql
+-- Description: Login sessions, rotating refresh tokens and access token revocation
+-- Author: RateMySoft Team
+-- Created: 2025
+
+-- Create sessions table (one row per login; every access and refresh token belongs to a session)
+CREATE TABLE sessions (
+  id uuid PRIMARY KEY,
+  user_id uuid NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  user_agent text,
+  ip_address text,
+  created_at timestamptz NOT NULL,
+  last_used_at timestamptz NOT NULL,
+  revoked_at timestamptz
+);
+
+-- Create indexes for sessions
+CREATE INDEX idx_sessions_user ON sessions(user_id) WHERE revoked_at IS NULL;
+CREATE INDEX idx_sessions_last_used ON sessions(last_used_at);
+
+-- Create refresh_tokens table (only the SHA-256 hash of the token is stored)
+CREATE TABLE refresh_tokens (
+  id uuid PRIMARY KEY,
+  session_id uuid NOT NULL REFERENCES sessions(id) ON DELETE CASCADE,
+  token_hash text NOT NULL UNIQUE,
+  expires_at timestamptz NOT NULL,
+  created_at timestamptz NOT NULL,
+  used_at timestamptz -- set when rotated; presenting a used token again revokes the session
+);
+
+-- Create indexes for refresh_tokens
+CREATE INDEX idx_refresh_tokens_session ON refresh_tokens(session_id);
+CREATE INDEX idx_refresh_tokens_expires ON refresh_tokens(expires_at);
+
+-- Create revoked_access_tokens table (jti deny-list, kept until the token would have expired)
+CREATE TABLE revoked_access_tokens (
+  jti uuid PRIMARY KEY,
+  user_id uuid NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  expires_at timestamptz NOT NULL,
+  revoked_at timestamptz NOT NULL
+);
+
+-- Create indexes for revoked_access_tokens
+CREATE INDEX idx_revoked_access_tokens_expires ON revoked_access_tokens(expires_at);
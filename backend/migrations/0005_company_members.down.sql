@@ -0,0 +1,7 @@
+-- Migration: 0005_company_members.down.sql
+-- Description: Drop company membership and claims
+-- Author: RateMySoft Team
+-- Created: 2025
+
+DROP TABLE IF EXISTS company_claims;
+DROP TABLE IF EXISTS company_members;
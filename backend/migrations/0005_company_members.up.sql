@@ -0,0 +1,47 @@
+-- Migration: 0005_company_members.up.sql
+-- Description: Company membership roles and the company claim/verification flow
+-- Author: RateMySoft Team
+-- Created: 2025
+
+-- Create company_members table (who may manage a company and its products)
+CREATE TABLE company_members (
+  company_id uuid NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+  user_id uuid NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  role text NOT NULL CHECK (role IN ('owner', 'editor')),
+  created_at timestamptz NOT NULL,
+  updated_at timestamptz NOT NULL,
+  PRIMARY KEY (company_id, user_id)
+);
+
+-- Create indexes for company_members
+CREATE INDEX idx_company_members_user ON company_members(user_id);
+
+-- Create company_claims table (requests to become owner of an existing company)
+CREATE TABLE company_claims (
+  id uuid PRIMARY KEY,
+  company_id uuid NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+  user_id uuid NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  status text NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'approved', 'rejected')),
+  verification_method text NOT NULL CHECK (verification_method IN ('email_domain', 'manual')),
+  evidence text,
+  reviewed_by uuid REFERENCES users(id) ON DELETE SET NULL,
+  review_note text,
+  reviewed_at timestamptz,
+  created_at timestamptz NOT NULL,
+  updated_at timestamptz NOT NULL
+);
+
+-- Create indexes for company_claims
+CREATE INDEX idx_company_claims_company ON company_claims(company_id);
+CREATE INDEX idx_company_claims_status ON company_claims(status);
+-- At most one open claim per user per company
+CREATE UNIQUE INDEX idx_company_claims_pending ON company_claims(company_id, user_id) WHERE status = 'pending';
+
+-- Create triggers for updated_at
+CREATE TRIGGER update_company_members_updated_at 
+    BEFORE UPDATE ON company_members
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TRIGGER update_company_claims_updated_at 
+    BEFORE UPDATE ON company_claims
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,18 @@
+-- Migration: 0006_product_search.down.sql
+-- Description: Drop product search documents, triggers and trigram indexes
+-- Author: RateMySoft Team
+-- Created: 2025
+
+-- Drop triggers and functions
+DROP TRIGGER IF EXISTS companies_search_document ON companies;
+DROP TRIGGER IF EXISTS products_search_document ON products;
+DROP FUNCTION IF EXISTS companies_search_document_trigger();
+DROP FUNCTION IF EXISTS products_search_document_trigger();
+DROP FUNCTION IF EXISTS refresh_product_search_document(uuid);
+
+-- Drop indexes and the search table
+DROP INDEX IF EXISTS idx_companies_name_trgm;
+DROP INDEX IF EXISTS idx_products_name_trgm;
+DROP TABLE IF EXISTS product_search_documents;
+
+DROP EXTENSION IF EXISTS pg_trgm;
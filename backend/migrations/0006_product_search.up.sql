@@ -0,0 +1,70 @@
+-- Migration: 0006_product_search.up.sql
+-- Description: Weighted full-text search documents and trigram indexes for products
+-- Author: RateMySoft Team
+-- Created: 2025
+
+-- Enable trigram matching for typo tolerance
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- Create product_search_documents table (one tsvector per product, kept in sync by triggers)
+-- Weights: name (A) > tagline (B) > description (C) > company name (D)
+CREATE TABLE product_search_documents (
+  product_id uuid PRIMARY KEY REFERENCES products(id) ON DELETE CASCADE,
+  document tsvector NOT NULL,
+  updated_at timestamptz NOT NULL DEFAULT NOW()
+);
+
+-- Create indexes for search
+CREATE INDEX idx_product_search_documents_document ON product_search_documents USING GIN (document);
+CREATE INDEX idx_products_name_trgm ON products USING GIN (name gin_trgm_ops);
+CREATE INDEX idx_companies_name_trgm ON companies USING GIN (name gin_trgm_ops);
+
+-- Rebuild the search document for one product
+CREATE OR REPLACE FUNCTION refresh_product_search_document(target uuid)
+RETURNS void AS $$
+BEGIN
+    INSERT INTO product_search_documents (product_id, document, updated_at)
+    SELECT p.id,
+           setweight(to_tsvector('english', coalesce(p.name, '')), 'A') ||
+           setweight(to_tsvector('english', coalesce(p.short_tagline, '')), 'B') ||
+           setweight(to_tsvector('english', coalesce(p.description, '')), 'C') ||
+           setweight(to_tsvector('english', coalesce(c.name, '')), 'D'),
+           NOW()
+    FROM products p
+    JOIN companies c ON p.company_id = c.id
+    WHERE p.id = target
+    ON CONFLICT (product_id) DO UPDATE
+    SET document = EXCLUDED.document,
+        updated_at = EXCLUDED.updated_at;
+END;
+$$ language 'plpgsql';
+
+CREATE OR REPLACE FUNCTION products_search_document_trigger()
+RETURNS TRIGGER AS $$
+BEGIN
+    PERFORM refresh_product_search_document(NEW.id);
+    RETURN NEW;
+END;
+$$ language 'plpgsql';
+
+CREATE OR REPLACE FUNCTION companies_search_document_trigger()
+RETURNS TRIGGER AS $$
+BEGIN
+    PERFORM refresh_product_search_document(p.id)
+    FROM products p
+    WHERE p.company_id = NEW.id;
+    RETURN NEW;
+END;
+$$ language 'plpgsql';
+
+-- Create triggers to keep search documents in sync
+CREATE TRIGGER products_search_document
+    AFTER INSERT OR UPDATE OF name, short_tagline, description, company_id ON products
+    FOR EACH ROW EXECUTE FUNCTION products_search_document_trigger();
+
+CREATE TRIGGER companies_search_document
+    AFTER UPDATE OF name ON companies
+    FOR EACH ROW EXECUTE FUNCTION companies_search_document_trigger();
+
+-- Backfill existing products
+SELECT refresh_product_search_document(id) FROM products;
@@ -0,0 +1,8 @@
+-- Migration: 0007_review_sub_ratings.down.sql
+-- Description: Drop review sub-ratings and product rating statistics
+-- Author: RateMySoft Team
+-- Created: 2025
+
+DROP TABLE IF EXISTS product_dimension_stats;
+DROP TABLE IF EXISTS product_rating_stats;
+DROP TABLE IF EXISTS review_sub_ratings;
@@ -0,0 +1,48 @@
+-- Migration: 0007_review_sub_ratings.up.sql
+-- Description: Per-dimension review sub-ratings and precomputed product rating statistics
+-- Author: RateMySoft Team
+-- Created: 2025
+
+-- Create review_sub_ratings table (optional per-dimension scores attached to a review)
+CREATE TABLE review_sub_ratings (
+  review_id uuid NOT NULL REFERENCES reviews(id) ON DELETE CASCADE,
+  dimension text NOT NULL CHECK (dimension IN ('ease_of_use', 'docs', 'support', 'value', 'reliability')),
+  rating int NOT NULL CHECK (rating BETWEEN 1 AND 5),
+  created_at timestamptz NOT NULL,
+  PRIMARY KEY (review_id, dimension)
+);
+
+-- Create product_rating_stats table (star histogram over published reviews)
+CREATE TABLE product_rating_stats (
+  product_id uuid PRIMARY KEY REFERENCES products(id) ON DELETE CASCADE,
+  star_1 int NOT NULL DEFAULT 0,
+  star_2 int NOT NULL DEFAULT 0,
+  star_3 int NOT NULL DEFAULT 0,
+  star_4 int NOT NULL DEFAULT 0,
+  star_5 int NOT NULL DEFAULT 0,
+  updated_at timestamptz NOT NULL
+);
+
+-- Create product_dimension_stats table (per-dimension averages over published reviews)
+CREATE TABLE product_dimension_stats (
+  product_id uuid NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+  dimension text NOT NULL,
+  avg_rating double precision NOT NULL,
+  rating_count int NOT NULL,
+  updated_at timestamptz NOT NULL,
+  PRIMARY KEY (product_id, dimension)
+);
+
+-- Backfill histograms for existing reviews
+INSERT INTO product_rating_stats (product_id, star_1, star_2, star_3, star_4, star_5, updated_at)
+SELECT
+  product_id,
+  COUNT(*) FILTER (WHERE rating = 1),
+  COUNT(*) FILTER (WHERE rating = 2),
+  COUNT(*) FILTER (WHERE rating = 3),
+  COUNT(*) FILTER (WHERE rating = 4),
+  COUNT(*) FILTER (WHERE rating = 5),
+  NOW()
+FROM reviews
+WHERE status = 'published' AND deleted_at IS NULL
+GROUP BY product_id;
@@ -0,0 +1,6 @@
+-- Migration: 0008_product_leaderboard.down.sql
+-- Description: Drop the product leaderboard
+-- Author: RateMySoft Team
+-- Created: 2025
+
+DROP TABLE IF EXISTS product_leaderboard;
@@ -0,0 +1,21 @@
+-- Migration: 0008_product_leaderboard.up.sql
+-- Description: Precomputed confidence-adjusted product rankings per time window
+-- Author: RateMySoft Team
+-- Created: 2025
+
+-- Create product_leaderboard table (rebuilt periodically by the leaderboard job)
+CREATE TABLE product_leaderboard (
+  time_window text NOT NULL CHECK (time_window IN ('30d', '90d', 'all')),
+  product_id uuid NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+  review_count int NOT NULL,
+  positive_count int NOT NULL, -- reviews rated 4 or 5 stars
+  avg_rating double precision NOT NULL,
+  bayesian_score double precision NOT NULL,
+  wilson_score double precision NOT NULL,
+  refreshed_at timestamptz NOT NULL,
+  PRIMARY KEY (time_window, product_id)
+);
+
+-- Create indexes for product_leaderboard
+CREATE INDEX idx_product_leaderboard_bayesian ON product_leaderboard(time_window, bayesian_score DESC);
+CREATE INDEX idx_product_leaderboard_wilson ON product_leaderboard(time_window, wilson_score DESC);
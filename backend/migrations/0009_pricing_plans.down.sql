@@ -0,0 +1,6 @@
+-- Migration: 0009_pricing_plans.down.sql
+-- Description: Drop pricing plans
+-- Author: RateMySoft Team
+-- Created: 2025
+
+DROP TABLE IF EXISTS pricing_plans;
@@ -0,0 +1,30 @@
+-- Migration: 0009_pricing_plans.up.sql
+-- Description: Per-product pricing plans for the pricing comparison
+-- Author: RateMySoft Team
+-- Created: 2025
+
+-- Create pricing_plans table
+CREATE TABLE pricing_plans (
+  id uuid PRIMARY KEY,
+  product_id uuid NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+  name text NOT NULL,
+  billing_period text NOT NULL CHECK (billing_period IN ('monthly', 'yearly', 'one_time', 'usage')),
+  currency text NOT NULL CHECK (currency ~ '^[A-Z]{3}$'), -- ISO 4217 code
+  price_cents bigint NOT NULL CHECK (price_cents >= 0),
+  is_free boolean NOT NULL DEFAULT false,
+  pricing_unit text NOT NULL DEFAULT 'flat' CHECK (pricing_unit IN ('flat', 'per_seat', 'per_usage')),
+  usage_unit text, -- what one unit of usage is, e.g. '1k requests'; only for per_usage plans
+  features text[] NOT NULL DEFAULT '{}',
+  sort_order int NOT NULL DEFAULT 0,
+  created_at timestamptz NOT NULL,
+  updated_at timestamptz NOT NULL,
+  UNIQUE (product_id, name)
+);
+
+-- Create indexes for pricing_plans
+CREATE INDEX idx_pricing_plans_product ON pricing_plans(product_id, sort_order);
+
+-- Create triggers for updated_at
+CREATE TRIGGER update_pricing_plans_updated_at
+    BEFORE UPDATE ON pricing_plans
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,7 @@
+-- Migration: 0010_categories.down.sql
+-- Description: Drop categories; products keep their category slug as plain text
+-- Author: RateMySoft Team
+-- Created: 2025
+
+ALTER TABLE products DROP CONSTRAINT IF EXISTS products_category_fkey;
+DROP TABLE IF EXISTS categories;
@@ -0,0 +1,45 @@
+-- Migration: 0010_categories.up.sql
+-- Description: Data-driven product categories with a parent/child hierarchy
+-- Author: RateMySoft Team
+-- Created: 2025
+
+-- Create categories table
+CREATE TABLE categories (
+  id uuid PRIMARY KEY,
+  slug text UNIQUE NOT NULL CHECK (slug ~ '^[a-z0-9]+([_-][a-z0-9]+)*$'),
+  name text NOT NULL,
+  description text,
+  icon text, -- icon name understood by the frontend, e.g. 'server'
+  parent_id uuid REFERENCES categories(id) ON DELETE RESTRICT,
+  sort_order int NOT NULL DEFAULT 0,
+  created_at timestamptz NOT NULL,
+  updated_at timestamptz NOT NULL,
+  CHECK (parent_id IS NULL OR parent_id <> id)
+);
+
+-- Create indexes for categories
+CREATE INDEX idx_categories_parent ON categories(parent_id);
+
+-- Create triggers for updated_at
+CREATE TRIGGER update_categories_updated_at
+    BEFORE UPDATE ON categories
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- Seed the categories that used to be hard-coded
+INSERT INTO categories (id, slug, name, description, icon, sort_order, created_at, updated_at) VALUES
+  (gen_random_uuid(), 'hosting', 'Web Hosting', 'Hosting and deployment platforms', 'server', 10, NOW(), NOW()),
+  (gen_random_uuid(), 'feature_toggles', 'Feature Management', 'Feature flags and experimentation', 'toggle-right', 20, NOW(), NOW()),
+  (gen_random_uuid(), 'ci_cd', 'CI/CD & DevOps', 'Continuous integration and delivery', 'git-branch', 30, NOW(), NOW()),
+  (gen_random_uuid(), 'observability', 'Monitoring & Analytics', 'Logging, metrics, tracing and alerting', 'activity', 40, NOW(), NOW()),
+  (gen_random_uuid(), 'other', 'Other Tools', 'Everything else', 'box', 1000, NOW(), NOW());
+
+-- Keep any category already in use, so the foreign key below can be added
+INSERT INTO categories (id, slug, name, created_at, updated_at)
+SELECT gen_random_uuid(), p.category, initcap(replace(p.category, '_', ' ')), NOW(), NOW()
+FROM (SELECT DISTINCT category FROM products) p
+WHERE NOT EXISTS (SELECT 1 FROM categories c WHERE c.slug = p.category);
+
+-- Products reference their category by slug; renaming a slug carries over to its products
+ALTER TABLE products
+  ADD CONSTRAINT products_category_fkey
+  FOREIGN KEY (category) REFERENCES categories(slug) ON UPDATE CASCADE;
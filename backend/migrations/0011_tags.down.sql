@@ -0,0 +1,8 @@
+-- Migration: 0011_tags.down.sql
+-- Description: Drop product tags and tag suggestions
+-- Author: RateMySoft Team
+-- Created: 2025
+
+DROP TABLE IF EXISTS tag_suggestions;
+DROP TABLE IF EXISTS product_tags;
+DROP TABLE IF EXISTS tags;
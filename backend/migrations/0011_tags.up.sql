@@ -0,0 +1,49 @@
+-- Migration: 0011_tags.up.sql
+-- Description: Free-form product tags, product tagging, and moderated tag suggestions
+-- Author: RateMySoft Team
+-- Created: 2025
+
+-- Create tags table (slug is the normalized form used in filters, name is the display form)
+CREATE TABLE tags (
+  id uuid PRIMARY KEY,
+  slug text NOT NULL UNIQUE CHECK (slug ~ '^[a-z0-9]+(-[a-z0-9]+)*$'),
+  name text NOT NULL,
+  created_at timestamptz NOT NULL
+);
+
+-- Create product_tags table
+CREATE TABLE product_tags (
+  product_id uuid NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+  tag_id uuid NOT NULL REFERENCES tags(id) ON DELETE CASCADE,
+  added_by uuid REFERENCES users(id) ON DELETE SET NULL,
+  created_at timestamptz NOT NULL,
+  PRIMARY KEY (product_id, tag_id)
+);
+
+-- Create indexes for product_tags (tag filters look products up by tag)
+CREATE INDEX idx_product_tags_tag ON product_tags(tag_id);
+
+-- Create tag_suggestions table (tags proposed by any user, approved by the product's company editors)
+CREATE TABLE tag_suggestions (
+  id uuid PRIMARY KEY,
+  product_id uuid NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+  user_id uuid NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  tag_slug text NOT NULL,
+  tag_name text NOT NULL,
+  status text NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'approved', 'rejected')),
+  reviewed_by uuid REFERENCES users(id) ON DELETE SET NULL,
+  review_note text,
+  reviewed_at timestamptz,
+  created_at timestamptz NOT NULL,
+  updated_at timestamptz NOT NULL
+);
+
+-- Create indexes for tag_suggestions
+CREATE INDEX idx_tag_suggestions_product_status ON tag_suggestions(product_id, status);
+-- At most one open suggestion per tag per product
+CREATE UNIQUE INDEX idx_tag_suggestions_pending ON tag_suggestions(product_id, tag_slug) WHERE status = 'pending';
+
+-- Create triggers for updated_at
+CREATE TRIGGER update_tag_suggestions_updated_at 
+    BEFORE UPDATE ON tag_suggestions
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
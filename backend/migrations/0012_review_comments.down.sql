@@ -0,0 +1,9 @@
+-- Migration: 0012_review_comments.down.sql
+-- Description: Drop review comments and comment flags
+-- Author: RateMySoft Team
+-- Created: 2025
+
+DROP INDEX IF EXISTS idx_review_moderations_comment;
+ALTER TABLE review_moderations DROP COLUMN IF EXISTS comment_id;
+DROP TABLE IF EXISTS review_comment_flags;
+DROP TABLE IF EXISTS review_comments;
@@ -0,0 +1,55 @@
+-- Migration: 0012_review_comments.up.sql
+-- Description: Threaded review comments, vendor official responses and comment flags
+-- Author: RateMySoft Team
+-- Created: 2025
+
+-- Create review_comments table (parent_id is NULL for top-level comments)
+CREATE TABLE review_comments (
+  id uuid PRIMARY KEY,
+  review_id uuid NOT NULL REFERENCES reviews(id) ON DELETE CASCADE,
+  parent_id uuid REFERENCES review_comments(id) ON DELETE CASCADE,
+  user_id uuid NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  body text NOT NULL,
+  status text NOT NULL CHECK (status IN ('pending', 'published', 'rejected')),
+  -- Official responses are written by a member of the product's company on its behalf
+  official boolean NOT NULL DEFAULT false,
+  company_id uuid REFERENCES companies(id) ON DELETE SET NULL,
+  flag_count int NOT NULL DEFAULT 0,
+  edited boolean NOT NULL DEFAULT false,
+  created_at timestamptz NOT NULL,
+  updated_at timestamptz NOT NULL,
+  deleted_at timestamptz,
+  CHECK (NOT official OR (parent_id IS NULL AND company_id IS NOT NULL))
+);
+
+-- Create indexes for review_comments
+CREATE INDEX idx_review_comments_review ON review_comments(review_id, created_at);
+CREATE INDEX idx_review_comments_parent ON review_comments(parent_id);
+CREATE INDEX idx_review_comments_user ON review_comments(user_id);
+CREATE INDEX idx_review_comments_status ON review_comments(status) WHERE deleted_at IS NULL;
+-- At most one official response per review
+CREATE UNIQUE INDEX idx_review_comments_official ON review_comments(review_id) WHERE official AND deleted_at IS NULL;
+
+-- Create review_comment_flags table (at most one flag per user per comment)
+CREATE TABLE review_comment_flags (
+  comment_id uuid NOT NULL REFERENCES review_comments(id) ON DELETE CASCADE,
+  user_id uuid NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  reason text NOT NULL CHECK (reason IN ('spam', 'offensive', 'off_topic', 'conflict_of_interest', 'fake', 'other')),
+  note text,
+  created_at timestamptz NOT NULL,
+  updated_at timestamptz NOT NULL,
+  PRIMARY KEY (comment_id, user_id)
+);
+
+-- Moderation decisions on a comment are logged with the review it belongs to
+ALTER TABLE review_moderations ADD COLUMN comment_id uuid REFERENCES review_comments(id) ON DELETE CASCADE;
+CREATE INDEX idx_review_moderations_comment ON review_moderations(comment_id) WHERE comment_id IS NOT NULL;
+
+-- Create triggers for updated_at
+CREATE TRIGGER update_review_comments_updated_at 
+    BEFORE UPDATE ON review_comments
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TRIGGER update_review_comment_flags_updated_at 
+    BEFORE UPDATE ON review_comment_flags
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,7 @@
+-- Migration: 0013_review_revisions.down.sql
+-- Description: Drop review revisions and rating change events
+-- Author: RateMySoft Team
+-- Created: 2025
+
+DROP TABLE IF EXISTS review_rating_events;
+DROP TABLE IF EXISTS review_revisions;
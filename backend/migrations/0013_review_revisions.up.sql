@@ -0,0 +1,35 @@
+-- Migration: 0013_review_revisions.up.sql
+-- Description: Review edit history and rating change events behind product stats
+-- Author: RateMySoft Team
+-- Created: 2025
+
+-- Create review_revisions table: every superseded version of a review, numbered from 1 (the original)
+CREATE TABLE review_revisions (
+  id uuid PRIMARY KEY,
+  review_id uuid NOT NULL REFERENCES reviews(id) ON DELETE CASCADE,
+  revision int NOT NULL,
+  title text,
+  body text NOT NULL,
+  rating int NOT NULL CHECK (rating BETWEEN 1 AND 5),
+  -- Sub-ratings of the version as a {"dimension": rating} object
+  sub_ratings jsonb NOT NULL DEFAULT '{}',
+  -- When an edit replaced this version
+  created_at timestamptz NOT NULL,
+  UNIQUE (review_id, revision)
+);
+
+-- Create review_rating_events table: each change to the ratings counted in a product's stats.
+-- from_rating is NULL when a review starts counting, to_rating when it stops.
+CREATE TABLE review_rating_events (
+  id uuid PRIMARY KEY,
+  review_id uuid NOT NULL REFERENCES reviews(id) ON DELETE CASCADE,
+  product_id uuid NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+  kind text NOT NULL CHECK (kind IN ('created', 'edited', 'deleted', 'published', 'unpublished')),
+  from_rating int CHECK (from_rating BETWEEN 1 AND 5),
+  to_rating int CHECK (to_rating BETWEEN 1 AND 5),
+  created_at timestamptz NOT NULL
+);
+
+-- Create indexes for review_rating_events
+CREATE INDEX idx_review_rating_events_product ON review_rating_events(product_id, created_at DESC);
+CREATE INDEX idx_review_rating_events_review ON review_rating_events(review_id);
@@ -0,0 +1,6 @@
+-- Migration: 0014_audit_events.down.sql
+-- Description: Drop the audit log
+-- Author: RateMySoft Team
+-- Created: 2025
+
+DROP TABLE IF EXISTS audit_events;
@@ -0,0 +1,25 @@
+-- Migration: 0014_audit_events.up.sql
+-- Description: Audit log of mutations to companies, products, reviews and users
+-- Author: RateMySoft Team
+-- Created: 2025
+
+-- Create audit_events table: one row per mutation, written in the mutation's transaction.
+-- before/after hold only the fields that changed; before is NULL on create, after on delete.
+CREATE TABLE audit_events (
+  id uuid PRIMARY KEY,
+  -- NULL for changes made by the system rather than a user
+  actor_id uuid REFERENCES users(id) ON DELETE SET NULL,
+  action text NOT NULL CHECK (action IN ('create', 'update', 'delete', 'approve', 'reject')),
+  entity_type text NOT NULL CHECK (entity_type IN ('company', 'product', 'review', 'user')),
+  entity_id uuid NOT NULL,
+  before jsonb,
+  after jsonb,
+  request_id text,
+  ip_address text,
+  created_at timestamptz NOT NULL
+);
+
+-- Create indexes for audit_events
+CREATE INDEX idx_audit_events_created ON audit_events(created_at DESC, id DESC);
+CREATE INDEX idx_audit_events_entity ON audit_events(entity_type, entity_id, created_at DESC);
+CREATE INDEX idx_audit_events_actor ON audit_events(actor_id, created_at DESC);
@@ -0,0 +1,7 @@
+-- Migration: 0015_account_tokens.down.sql
+-- Description: Drop account tokens and email verification
+-- Author: RateMySoft Team
+-- Created: 2025
+
+DROP TABLE IF EXISTS account_tokens;
+ALTER TABLE users DROP COLUMN IF EXISTS email_verified_at;
@@ -0,0 +1,25 @@
+-- Migration: 0015_account_tokens.up.sql
+-- Description: Email verification and single-use tokens for verification and password reset links
+-- Author: RateMySoft Team
+-- Created: 2025
+
+-- When the user proved they own their current email address; NULL until then
+ALTER TABLE users ADD COLUMN email_verified_at timestamptz;
+
+-- Create account_tokens table: links sent by email. Only the SHA-256 hash of a token is stored;
+-- a token is consumed by setting used_at, and issuing a new one consumes the user's older ones.
+CREATE TABLE account_tokens (
+  id uuid PRIMARY KEY,
+  user_id uuid NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  purpose text NOT NULL CHECK (purpose IN ('email_verification', 'password_reset')),
+  token_hash text NOT NULL UNIQUE,
+  -- The address the link was sent to; a verification only counts if it is still the user's email
+  email text NOT NULL,
+  expires_at timestamptz NOT NULL,
+  created_at timestamptz NOT NULL,
+  used_at timestamptz
+);
+
+-- Create indexes for account_tokens
+CREATE INDEX idx_account_tokens_user ON account_tokens(user_id, purpose) WHERE used_at IS NULL;
+CREATE INDEX idx_account_tokens_expires ON account_tokens(expires_at);
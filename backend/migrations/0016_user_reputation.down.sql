@@ -0,0 +1,7 @@
+-- Migration: 0016_user_reputation.down.sql
+-- Description: Drop reviewer reputation and badges
+-- Author: RateMySoft Team
+-- Created: 2025
+
+DROP TABLE IF EXISTS reviewer_badges;
+DROP TABLE IF EXISTS user_reputation;
@@ -0,0 +1,28 @@
+-- Migration: 0016_user_reputation.up.sql
+-- Description: Precomputed reviewer reputation and top reviewer badges
+-- Author: RateMySoft Team
+-- Created: 2025
+
+-- Create user_reputation table (rebuilt periodically by the reputation job).
+-- Only users who wrote a review get a row; everyone else has a reputation of 0.
+CREATE TABLE user_reputation (
+  user_id uuid PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+  published_count int NOT NULL, -- published reviews
+  rejected_count int NOT NULL, -- reviews rejected by a moderator
+  helpful_votes int NOT NULL, -- upvotes received on published reviews
+  reputation int NOT NULL,
+  refreshed_at timestamptz NOT NULL
+);
+
+-- Create reviewer_badges table: the top reviewers of each category by helpful votes received
+CREATE TABLE reviewer_badges (
+  user_id uuid NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  category text NOT NULL REFERENCES categories(slug) ON UPDATE CASCADE ON DELETE CASCADE,
+  rank int NOT NULL CHECK (rank > 0),
+  helpful_votes int NOT NULL,
+  refreshed_at timestamptz NOT NULL,
+  PRIMARY KEY (user_id, category)
+);
+
+-- Create indexes for reviewer_badges
+CREATE INDEX idx_reviewer_badges_category ON reviewer_badges(category, rank);
@@ -0,0 +1,6 @@
+-- Migration: 0017_login_throttles.down.sql
+-- Description: Drop failed sign-in tracking
+-- Author: RateMySoft Team
+-- Created: 2025
+
+DROP TABLE IF EXISTS login_throttles;
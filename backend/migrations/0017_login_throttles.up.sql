@@ -0,0 +1,19 @@
+-- Migration: 0017_login_throttles.up.sql
+-- Description: Failed sign-in tracking for brute-force protection
+-- Author: RateMySoft Team
+-- Created: 2025
+
+-- Create login_throttles table: failed sign-ins per email and per client IP.
+-- Once failures pass the limit the key is locked, for twice as long after every further failure.
+CREATE TABLE login_throttles (
+  scope text NOT NULL CHECK (scope IN ('account', 'ip')),
+  key text NOT NULL, -- the lowercased email for 'account', the client IP for 'ip'
+  failures int NOT NULL,
+  last_failure_at timestamptz NOT NULL,
+  locked_until timestamptz, -- NULL until failures first pass the limit
+  PRIMARY KEY (scope, key)
+);
+
+-- Create indexes for login_throttles
+CREATE INDEX idx_login_throttles_locked ON login_throttles(scope, locked_until) WHERE locked_until IS NOT NULL;
+CREATE INDEX idx_login_throttles_last_failure ON login_throttles(last_failure_at);
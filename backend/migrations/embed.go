@@ -0,0 +1,11 @@
+// Package migrations embeds the versioned SQL migrations so the backend binary can apply them.
+//
+// Each version has a NNNN_name.up.sql file and a matching NNNN_name.down.sql file.
+package migrations
+
+import "embed"
+
+// FS holds every *.sql migration file in this directory
+//
+//go:embed *.sql
+var FS embed.FS
@@ -17,8 +17,16 @@ const AddSolution = () => {
   const [showCompanySearch, setShowCompanySearch] = useState(false);
   const [searchQuery, setSearchQuery] = useState('');
   const [isSearching, setIsSearching] = useState(false);
+  const [categories, setCategories] = useState([]);
   const dropdownRef = useRef(null);
 
+  // Load the category options
+  useEffect(() => {
+    apiService.getCategories()
+      .then(setCategories)
+      .catch((error) => console.error('Error loading categories:', error));
+  }, []);
+
   // Handle clicking outside the dropdown
   useEffect(() => {
     const handleClickOutside = (event) => {
@@ -193,11 +201,11 @@ const AddSolution = () => {
                 required
               >
                 <option value="">Select a category</option>
-                <option value="hosting">Hosting</option>
-                <option value="feature_toggles">Feature Toggles</option>
-                <option value="ci_cd">CI/CD</option>
-                <option value="observability">Observability</option>
-                <option value="other">Other</option>
+                {categories.map(category => (
+                  <option key={category.id} value={category.slug}>
+                    {category.name}
+                  </option>
+                ))}
               </select>
             </div>
 
@@ -43,7 +43,7 @@ class ApiService {
       const data = await response.json();
 
       if (!response.ok) {
-        throw new Error(data.error || data.details || 'Request failed');
+        throw new Error(data.detail || data.title || 'Request failed');
       }
 
       return data;
@@ -117,6 +117,12 @@ class ApiService {
     return response.products || response;
   }
 
+  // Category endpoints
+  async getCategories() {
+    const response = await this.request('/categories');
+    return response.categories || response;
+  }
+
   async getProductsByCompany(companyId) {
     const response = await this.request(`/products/company/${companyId}`);
     // Backend returns paginated response with products array